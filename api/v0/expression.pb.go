@@ -75,6 +75,7 @@ const (
 	Expression_VALUE                      Expression_ExpressionType = 2
 	Expression_LOGICAL_AND                Expression_ExpressionType = 10
 	Expression_LOGICAL_OR                 Expression_ExpressionType = 11
+	Expression_LOGICAL_NOT                Expression_ExpressionType = 12
 	Expression_EQ                         Expression_ExpressionType = 20
 	Expression_NE                         Expression_ExpressionType = 21
 	Expression_LT                         Expression_ExpressionType = 22
@@ -93,6 +94,7 @@ var Expression_ExpressionType_name = map[int32]string{
 	2:  "VALUE",
 	10: "LOGICAL_AND",
 	11: "LOGICAL_OR",
+	12: "LOGICAL_NOT",
 	20: "EQ",
 	21: "NE",
 	22: "LT",
@@ -110,6 +112,7 @@ var Expression_ExpressionType_value = map[string]int32{
 	"VALUE":                      2,
 	"LOGICAL_AND":                10,
 	"LOGICAL_OR":                 11,
+	"LOGICAL_NOT":                12,
 	"EQ":                         20,
 	"NE":                         21,
 	"LT":                         22,
@@ -582,47 +585,47 @@ func init() {
 func init() { proto.RegisterFile("capsule8/api/v0/expression.proto", fileDescriptor4) }
 
 var fileDescriptor4 = []byte{
-	// 659 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xdf, 0x6e, 0xda, 0x4a,
-	0x10, 0xc6, 0x6d, 0xc2, 0x3f, 0x8f, 0x03, 0xac, 0x56, 0x27, 0x39, 0x84, 0x1c, 0x25, 0x16, 0xe7,
-	0xa2, 0x56, 0xa4, 0x9a, 0xd4, 0x89, 0x22, 0xae, 0x2a, 0x41, 0xd8, 0xc6, 0x56, 0x1d, 0x9b, 0xda,
-	0x26, 0x6d, 0xaf, 0x10, 0x34, 0x0e, 0x58, 0x22, 0xd8, 0xb2, 0x71, 0xd4, 0x3c, 0x58, 0xef, 0xfb,
-	0x12, 0x7d, 0x91, 0x3e, 0x41, 0xb5, 0xbb, 0x98, 0x26, 0x4d, 0xd4, 0xf6, 0x6a, 0xc6, 0xdf, 0xfe,
-	0x66, 0x96, 0xf9, 0x86, 0x05, 0xe5, 0xd3, 0x24, 0x4e, 0xb3, 0x45, 0xd0, 0xed, 0x4c, 0xe2, 0xb0,
-	0x73, 0x77, 0xdc, 0x09, 0x3e, 0xc7, 0x49, 0x90, 0xa6, 0x61, 0xb4, 0xd4, 0xe2, 0x24, 0x5a, 0x45,
-	0xb8, 0x91, 0x13, 0xda, 0x24, 0x0e, 0xb5, 0xbb, 0xe3, 0xd6, 0xe1, 0x2c, 0x8a, 0x66, 0x8b, 0xa0,
-	0xc3, 0x8e, 0xa7, 0xd9, 0x4d, 0x67, 0x15, 0xde, 0x06, 0xe9, 0x6a, 0x72, 0x1b, 0xf3, 0x8a, 0xf6,
-	0xd7, 0x02, 0x94, 0xae, 0x26, 0x8b, 0x2c, 0xc0, 0x1a, 0x14, 0x57, 0xf7, 0x71, 0xd0, 0x14, 0x15,
-	0x51, 0xad, 0xeb, 0x2d, 0xed, 0x97, 0x56, 0x1a, 0xa3, 0xfc, 0xfb, 0x38, 0x70, 0x19, 0x87, 0xff,
-	0x87, 0xed, 0x34, 0x9c, 0x2d, 0x83, 0xeb, 0xf1, 0x1d, 0x3d, 0x69, 0x82, 0x22, 0xaa, 0xd8, 0x10,
-	0x5c, 0x99, 0xab, 0xbc, 0xe9, 0x0b, 0xa8, 0x67, 0xcb, 0x47, 0x98, 0xac, 0x88, 0x6a, 0xd1, 0x10,
-	0xdc, 0x5a, 0xae, 0x73, 0x90, 0x76, 0x5b, 0x25, 0xe1, 0x72, 0xb6, 0xc6, 0xb6, 0x15, 0x51, 0x95,
-	0x58, 0x37, 0xa6, 0x72, 0xe8, 0x10, 0x60, 0x1a, 0x45, 0x8b, 0x35, 0x52, 0x53, 0x44, 0xb5, 0x6a,
-	0x08, 0xae, 0x44, 0xb5, 0x4d, 0x97, 0xeb, 0x28, 0x9b, 0x2e, 0x82, 0x35, 0x52, 0x57, 0x44, 0x55,
-	0xa4, 0x5d, 0xb8, 0xca, 0x21, 0x02, 0x8d, 0x8d, 0x0b, 0x6b, 0xae, 0xa1, 0x88, 0xaa, 0xac, 0xb7,
-	0x34, 0xee, 0x96, 0x96, 0xbb, 0xa5, 0xf9, 0x39, 0x67, 0x08, 0x6e, 0x7d, 0x53, 0xc4, 0xda, 0xf4,
-	0x2b, 0x50, 0x62, 0xc5, 0xed, 0x39, 0x54, 0xfb, 0xe1, 0x72, 0x92, 0xdc, 0x3b, 0x31, 0x7e, 0x09,
-	0x5b, 0x8b, 0x79, 0xca, 0x3c, 0x94, 0xf5, 0xfd, 0x27, 0x1e, 0x92, 0xcd, 0xc2, 0x5c, 0xca, 0x51,
-	0x3c, 0x99, 0xa7, 0xcd, 0xc2, 0x5f, 0xe0, 0xc9, 0x3c, 0x6d, 0x7f, 0xdf, 0x02, 0xf8, 0xa9, 0xe1,
-	0xd7, 0x8f, 0x36, 0x76, 0xf4, 0x9b, 0xf2, 0x07, 0xe9, 0x83, 0x0d, 0x2a, 0x00, 0xe1, 0x75, 0xb0,
-	0x5c, 0x85, 0x37, 0x61, 0x90, 0xb0, 0xfd, 0x51, 0xc7, 0x1f, 0x68, 0x58, 0x5b, 0xcf, 0xc8, 0xb6,
-	0x26, 0xeb, 0xbb, 0xcf, 0xff, 0x29, 0x0c, 0xc1, 0xe5, 0x18, 0xee, 0x82, 0x34, 0x65, 0x56, 0x8c,
-	0xa3, 0x98, 0xad, 0x50, 0xd6, 0xf7, 0x9e, 0xd4, 0xe4, 0x66, 0x19, 0x82, 0x5b, 0x9d, 0xe6, 0xc6,
-	0x75, 0xa1, 0x9a, 0xe5, 0x85, 0xb5, 0x3f, 0xda, 0x61, 0x08, 0x6e, 0x25, 0xe3, 0x95, 0xed, 0x6f,
-	0x22, 0xd4, 0x1f, 0x8f, 0x87, 0x0f, 0xa0, 0x45, 0x3e, 0x0c, 0x5d, 0xe2, 0x79, 0xa6, 0x63, 0xfb,
-	0x1f, 0x87, 0x64, 0x3c, 0xb2, 0xbd, 0x21, 0x39, 0x37, 0xdf, 0x98, 0x64, 0x80, 0x04, 0x5c, 0x07,
-	0x30, 0x07, 0xc4, 0xf6, 0xe9, 0xb7, 0x8b, 0x44, 0x2c, 0x41, 0xe9, 0xaa, 0x67, 0x8d, 0x08, 0x2a,
-	0xe0, 0x06, 0xc8, 0x96, 0x73, 0x61, 0x9e, 0xf7, 0xac, 0x71, 0xcf, 0x1e, 0x20, 0xa0, 0x6c, 0x2e,
-	0x38, 0x2e, 0x92, 0x71, 0x19, 0x0a, 0xe4, 0x1d, 0xfa, 0x87, 0x46, 0x9b, 0xa0, 0x1d, 0x1a, 0x2d,
-	0x1f, 0xed, 0xb2, 0x48, 0xd0, 0xbf, 0x34, 0x5e, 0xf8, 0xa8, 0xc9, 0x22, 0x41, 0x7b, 0xb8, 0x0a,
-	0x45, 0xcb, 0x7c, 0x4b, 0x50, 0x0b, 0xcb, 0x50, 0x31, 0xbd, 0xb1, 0x3d, 0xb2, 0x2c, 0xb4, 0x4f,
-	0xef, 0xa1, 0x1f, 0x8e, 0xcf, 0x85, 0xff, 0xa8, 0xd0, 0x37, 0xfd, 0xf7, 0xa6, 0x47, 0xd8, 0xc5,
-	0x07, 0xfd, 0x32, 0x14, 0xe9, 0xfb, 0x3e, 0xfa, 0x22, 0x82, 0xb4, 0x79, 0x7b, 0x78, 0x0f, 0x76,
-	0xd8, 0x4f, 0x7d, 0x66, 0x2a, 0x80, 0xb2, 0xe7, 0xbb, 0xa6, 0x7d, 0xc1, 0x27, 0xf2, 0x4c, 0xdb,
-	0xef, 0xa2, 0x02, 0x93, 0x4d, 0xdb, 0x7f, 0x75, 0x86, 0xb6, 0xf2, 0xfc, 0x44, 0x47, 0xc5, 0x3c,
-	0x3f, 0x3b, 0x45, 0x25, 0x8a, 0x8f, 0x18, 0x5e, 0xa6, 0xf2, 0x88, 0xe3, 0x95, 0x3c, 0x3f, 0xd1,
-	0x51, 0x35, 0xcf, 0xcf, 0x4e, 0x91, 0x44, 0x67, 0xea, 0x3b, 0x8e, 0x85, 0x80, 0xaa, 0x03, 0x67,
-	0xd4, 0xb7, 0x08, 0x92, 0x71, 0x0d, 0x24, 0xdf, 0xbc, 0x24, 0x9e, 0xdf, 0xbb, 0x1c, 0xa2, 0xed,
-	0x69, 0x99, 0xbd, 0xa2, 0x93, 0x1f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xab, 0x9f, 0x14, 0x60, 0xb6,
-	0x04, 0x00, 0x00,
+	// 663 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xdd, 0x6e, 0x9b, 0x4a,
+	0x10, 0xc7, 0xc1, 0xf1, 0xe7, 0xe0, 0x8f, 0xd5, 0xea, 0x24, 0xc7, 0x71, 0x8e, 0x12, 0xe4, 0x73,
+	0x51, 0x14, 0xa9, 0x38, 0x25, 0x51, 0xe4, 0xab, 0x4a, 0x76, 0xbc, 0x0d, 0xa8, 0x04, 0x5c, 0x58,
+	0xa7, 0xed, 0x95, 0x65, 0x37, 0xc4, 0x46, 0x72, 0x0c, 0x02, 0x13, 0x35, 0xd7, 0x7d, 0xa6, 0xde,
+	0xf7, 0x69, 0xfa, 0x1c, 0xd5, 0x2e, 0xc6, 0x75, 0x9a, 0xa8, 0xed, 0xd5, 0x0c, 0xff, 0xfd, 0xcd,
+	0x68, 0xe7, 0x3f, 0x2c, 0xc8, 0x9f, 0x26, 0x61, 0x9c, 0x2c, 0xbc, 0x6e, 0x67, 0x12, 0xfa, 0x9d,
+	0xfb, 0x93, 0x8e, 0xf7, 0x39, 0x8c, 0xbc, 0x38, 0xf6, 0x83, 0xa5, 0x1a, 0x46, 0xc1, 0x2a, 0xc0,
+	0x8d, 0x8c, 0x50, 0x27, 0xa1, 0xaf, 0xde, 0x9f, 0xb4, 0x8e, 0x66, 0x41, 0x30, 0x5b, 0x78, 0x1d,
+	0x7e, 0x3c, 0x4d, 0x6e, 0x3b, 0x2b, 0xff, 0xce, 0x8b, 0x57, 0x93, 0xbb, 0x30, 0xad, 0x68, 0x7f,
+	0xcb, 0x41, 0xe1, 0x7a, 0xb2, 0x48, 0x3c, 0xac, 0x42, 0x7e, 0xf5, 0x10, 0x7a, 0x4d, 0x51, 0x16,
+	0x95, 0xba, 0xd6, 0x52, 0x7f, 0x69, 0xa5, 0x72, 0x8a, 0x3e, 0x84, 0x9e, 0xc3, 0x39, 0xfc, 0x3f,
+	0x54, 0x63, 0x7f, 0xb6, 0xf4, 0x6e, 0xc6, 0xf7, 0xec, 0xa4, 0x09, 0xb2, 0xa8, 0x60, 0x5d, 0x70,
+	0xa4, 0x54, 0x4d, 0x9b, 0xbe, 0x80, 0x7a, 0xb2, 0x7c, 0x84, 0x49, 0xb2, 0xa8, 0xe4, 0x75, 0xc1,
+	0xa9, 0x65, 0x7a, 0x0a, 0xb2, 0x6e, 0xab, 0xc8, 0x5f, 0xce, 0xd6, 0x58, 0x55, 0x16, 0x95, 0x0a,
+	0xef, 0xc6, 0xd5, 0x14, 0x3a, 0x02, 0x98, 0x06, 0xc1, 0x62, 0x8d, 0xd4, 0x64, 0x51, 0x29, 0xeb,
+	0x82, 0x53, 0x61, 0xda, 0xa6, 0xcb, 0x4d, 0x90, 0x4c, 0x17, 0xde, 0x1a, 0xa9, 0xcb, 0xa2, 0x22,
+	0xb2, 0x2e, 0xa9, 0x9a, 0x42, 0x04, 0x1a, 0x1b, 0x17, 0xd6, 0x5c, 0x43, 0x16, 0x15, 0x49, 0x6b,
+	0xa9, 0xa9, 0x5b, 0x6a, 0xe6, 0x96, 0x4a, 0x33, 0x4e, 0x17, 0x9c, 0xfa, 0xa6, 0x88, 0xb7, 0xe9,
+	0x97, 0xa0, 0xc0, 0x8b, 0xdb, 0x73, 0x28, 0xf7, 0xfd, 0xe5, 0x24, 0x7a, 0xb0, 0x43, 0xfc, 0x12,
+	0x76, 0x16, 0xf3, 0x98, 0x7b, 0x28, 0x69, 0x07, 0x4f, 0x3c, 0x24, 0x9b, 0x85, 0x39, 0x8c, 0x63,
+	0x78, 0x34, 0x8f, 0x9b, 0xb9, 0xbf, 0xc0, 0xa3, 0x79, 0xdc, 0xfe, 0x92, 0x07, 0xf8, 0xa9, 0xe1,
+	0xd7, 0x8f, 0x36, 0x76, 0xfc, 0x9b, 0xf2, 0xad, 0x74, 0x6b, 0x83, 0x32, 0x80, 0x7f, 0xe3, 0x2d,
+	0x57, 0xfe, 0xad, 0xef, 0x45, 0x7c, 0x7f, 0xcc, 0xf1, 0x2d, 0x0d, 0xab, 0xeb, 0x19, 0xf9, 0xd6,
+	0x24, 0x6d, 0xef, 0xf9, 0x9f, 0x42, 0x17, 0x9c, 0x14, 0xc3, 0x5d, 0xa8, 0x4c, 0xb9, 0x15, 0xe3,
+	0x20, 0xe4, 0x2b, 0x94, 0xb4, 0xfd, 0x27, 0x35, 0x99, 0x59, 0xba, 0xe0, 0x94, 0xa7, 0x99, 0x71,
+	0x5d, 0x28, 0x27, 0x59, 0x61, 0xed, 0x8f, 0x76, 0xe8, 0x82, 0x53, 0x4a, 0xd2, 0xca, 0xf6, 0x77,
+	0x11, 0xea, 0x8f, 0xc7, 0xc3, 0x87, 0xd0, 0x22, 0x1f, 0x86, 0x0e, 0x71, 0x5d, 0xc3, 0xb6, 0xe8,
+	0xc7, 0x21, 0x19, 0x8f, 0x2c, 0x77, 0x48, 0x2e, 0x8c, 0x37, 0x06, 0x19, 0x20, 0x01, 0xd7, 0x01,
+	0x8c, 0x01, 0xb1, 0x28, 0xfb, 0x76, 0x90, 0x88, 0x2b, 0x50, 0xb8, 0xee, 0x99, 0x23, 0x82, 0x72,
+	0xb8, 0x01, 0x92, 0x69, 0x5f, 0x1a, 0x17, 0x3d, 0x73, 0xdc, 0xb3, 0x06, 0x08, 0x18, 0x9b, 0x09,
+	0xb6, 0x83, 0xa4, 0x6d, 0xc0, 0xb2, 0x29, 0xaa, 0xe2, 0x22, 0xe4, 0xc8, 0x3b, 0xf4, 0x0f, 0x8b,
+	0x16, 0x41, 0xbb, 0x2c, 0x9a, 0x14, 0xed, 0xf1, 0x48, 0xd0, 0xbf, 0x2c, 0x5e, 0x52, 0xd4, 0xe4,
+	0x91, 0xa0, 0x7d, 0x5c, 0x86, 0xbc, 0x69, 0xbc, 0x25, 0xa8, 0x85, 0x25, 0x28, 0x19, 0xee, 0xd8,
+	0x1a, 0x99, 0x26, 0x3a, 0x60, 0x7d, 0xd9, 0x87, 0x4d, 0x53, 0xe1, 0x3f, 0x26, 0xf4, 0x0d, 0xfa,
+	0xde, 0x70, 0x09, 0xbf, 0xc9, 0x61, 0xbf, 0x08, 0x79, 0xf6, 0xe0, 0x8f, 0xbf, 0x8a, 0x50, 0xd9,
+	0x3c, 0x46, 0xbc, 0x0f, 0xbb, 0xfc, 0xee, 0xcf, 0x8c, 0x09, 0x50, 0x74, 0xa9, 0x63, 0x58, 0x97,
+	0xe9, 0x88, 0xae, 0x61, 0xd1, 0x2e, 0xca, 0x71, 0xd9, 0xb0, 0xe8, 0xab, 0x73, 0xb4, 0x93, 0xe5,
+	0xa7, 0x1a, 0xca, 0x67, 0xf9, 0xf9, 0x19, 0x2a, 0x30, 0x7c, 0xc4, 0xf1, 0x22, 0x93, 0x47, 0x29,
+	0x5e, 0xca, 0xf2, 0x53, 0x0d, 0x95, 0xb3, 0xfc, 0xfc, 0x0c, 0x55, 0xd8, 0x4c, 0x7d, 0xdb, 0x36,
+	0x11, 0x30, 0x75, 0x60, 0x8f, 0xfa, 0x26, 0x41, 0x12, 0xae, 0x41, 0x85, 0x1a, 0x57, 0xc4, 0xa5,
+	0xbd, 0xab, 0x21, 0xaa, 0x4e, 0x8b, 0xfc, 0x59, 0x9d, 0xfe, 0x08, 0x00, 0x00, 0xff, 0xff, 0xc1,
+	0x94, 0x52, 0x6c, 0xc7, 0x04, 0x00, 0x00,
 }
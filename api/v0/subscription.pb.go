@@ -67,6 +67,38 @@ func (x ContainerEventView) String() string {
 }
 func (ContainerEventView) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{1} }
 
+// Possible overflow policies
+type BufferModifier_OverflowPolicy int32
+
+const (
+	// Events delivered while the buffer is full are dropped
+	BufferModifier_DROP_NEWEST BufferModifier_OverflowPolicy = 0
+	// The oldest buffered event is dropped to make room
+	BufferModifier_DROP_OLDEST BufferModifier_OverflowPolicy = 1
+	// Delivery waits up to block_timeout for room, delaying
+	// the Sensor's delivery of events to other
+	// subscriptions, and then drops the event
+	BufferModifier_BLOCK BufferModifier_OverflowPolicy = 2
+)
+
+var BufferModifier_OverflowPolicy_name = map[int32]string{
+	0: "DROP_NEWEST",
+	1: "DROP_OLDEST",
+	2: "BLOCK",
+}
+var BufferModifier_OverflowPolicy_value = map[string]int32{
+	"DROP_NEWEST": 0,
+	"DROP_OLDEST": 1,
+	"BLOCK":       2,
+}
+
+func (x BufferModifier_OverflowPolicy) String() string {
+	return proto.EnumName(BufferModifier_OverflowPolicy_name, int32(x))
+}
+func (BufferModifier_OverflowPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor3, []int{26, 0}
+}
+
 // Possible interval types
 type ThrottleModifier_IntervalType int32
 
@@ -98,7 +130,7 @@ func (x ThrottleModifier_IntervalType) String() string {
 	return proto.EnumName(ThrottleModifier_IntervalType_name, int32(x))
 }
 func (ThrottleModifier_IntervalType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor3, []int{14, 0}
+	return fileDescriptor3, []int{29, 0}
 }
 
 //
@@ -127,6 +159,59 @@ type Subscription struct {
 	ForDuration *google_protobuf1.Int64Value `protobuf:"bytes,11,opt,name=for_duration,json=forDuration" json:"for_duration,omitempty"`
 	// If not empty, apply the specified modifier to the subscription.
 	Modifier *Modifier `protobuf:"bytes,20,opt,name=modifier" json:"modifier,omitempty"`
+	// If not zero, the size in pages of the kernel ring buffers used
+	// for the subscription's events instead of the sensor's default.
+	// It must be a power of 2. Larger buffers use more memory, but
+	// lose fewer events when event rates are high.
+	RingBufferPages uint32 `protobuf:"varint,21,opt,name=ring_buffer_pages,json=ringBufferPages" json:"ring_buffer_pages,omitempty"`
+	// If true, kernel module load, anonymous executable memory
+	// mapping, and executable memory protection change events carry
+	// the stack traces of the task that caused them. Process exec
+	// events carry stack traces only if the sensor is also configured
+	// to capture them. Capturing stack traces makes each of these
+	// events more expensive.
+	CaptureStackTraces bool `protobuf:"varint,22,opt,name=capture_stack_traces,json=captureStackTraces" json:"capture_stack_traces,omitempty"`
+	// If not empty, only return events for which this expression is
+	// true. It is evaluated by the Sensor against each event after the
+	// event filters and container filter have been applied. Fields of
+	// the TelemetryEvent are named by their paths from "event", i.e.
+	//   event.image_name.startsWith("redis") && event.credentials.uid == 0
+	// Operators are ||, &&, !, ==, !=, <, <=, >, >=, and &. Strings may
+	// be tested with startsWith, endsWith, contains, and matches (an RE2
+	// regular expression, i.e. event.process.exec_filename.matches(
+	// "^/usr/s?bin/")). Comparing a field with null tests whether it is
+	// present in the event.
+	Expression string `protobuf:"bytes,23,opt,name=expression" json:"expression,omitempty"`
+	// If true, events that refer to a container are not sent until the
+	// container's created or running event has been sent on the stream,
+	// and they carry the container's metadata from the Sensor's cache
+	// even if it was not known when the event occurred. The stream
+	// includes the created and running events of containers, and if a
+	// container was created before the subscription, a running or
+	// created event is made for it from the cache. Events are held for
+	// at most 5 seconds waiting for their container.
+	CorrelateContainers bool `protobuf:"varint,24,opt,name=correlate_containers,json=correlateContainers" json:"correlate_containers,omitempty"`
+	// If not zero, the number of seconds after which the Sensor ends
+	// the subscription and its stream, releasing the resources used
+	// for it even if the client has gone away without closing the
+	// stream.
+	TtlSeconds uint32 `protobuf:"varint,25,opt,name=ttl_seconds,json=ttlSeconds" json:"ttl_seconds,omitempty"`
+	// If set, the events of the subscription are delivered at least
+	// once. Each response carrying events is numbered by its
+	// GetEventsResponse.sequence_number and held by the Sensor until
+	// it is acknowledged with TelemetryService.AcknowledgeEvents. When
+	// a stream is opened with the delivery_id of a closed one, the
+	// responses that were not acknowledged are sent again before any
+	// new events. Only one stream may use a delivery_id at a time, and
+	// the unacknowledged responses of a delivery_id that is not used
+	// again are discarded after the Sensor's configured retention
+	// time.
+	DeliveryId string `protobuf:"bytes,26,opt,name=delivery_id,json=deliveryId" json:"delivery_id,omitempty"`
+	// If true, process exec events carry the environment variables of
+	// the executed process whose names are in the Sensor's configured
+	// allowlist. All other variables are stripped, since environments
+	// often hold secrets.
+	CaptureExecEnvironment bool `protobuf:"varint,27,opt,name=capture_exec_environment,json=captureExecEnvironment" json:"capture_exec_environment,omitempty"`
 }
 
 func (m *Subscription) Reset()                    { *m = Subscription{} }
@@ -169,10 +254,59 @@ func (m *Subscription) GetModifier() *Modifier {
 	return nil
 }
 
+func (m *Subscription) GetRingBufferPages() uint32 {
+	if m != nil {
+		return m.RingBufferPages
+	}
+	return 0
+}
+
+func (m *Subscription) GetCaptureStackTraces() bool {
+	if m != nil {
+		return m.CaptureStackTraces
+	}
+	return false
+}
+
+func (m *Subscription) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *Subscription) GetCorrelateContainers() bool {
+	if m != nil {
+		return m.CorrelateContainers
+	}
+	return false
+}
+
+func (m *Subscription) GetTtlSeconds() uint32 {
+	if m != nil {
+		return m.TtlSeconds
+	}
+	return 0
+}
+
+func (m *Subscription) GetDeliveryId() string {
+	if m != nil {
+		return m.DeliveryId
+	}
+	return ""
+}
+
+func (m *Subscription) GetCaptureExecEnvironment() bool {
+	if m != nil {
+		return m.CaptureExecEnvironment
+	}
+	return false
+}
+
 // The ContainerFilter restricts events in the Subscription to the
 // running containers indicated. All of the fields in this message are
 // effectively "ORed" together to create the list of containers to
-// monitor for the subscription.
+// monitor for the subscription, less any containers matching exclude.
 type ContainerFilter struct {
 	// Zero or more container IDs (e.g.
 	// 254dd98a7bf1581560ddace9f98b7933bfb3c2f5fc0504ec1b8dcc9614bc7062)
@@ -185,7 +319,22 @@ type ContainerFilter struct {
 	// Container image name (shell-style globs are supported). May be of the
 	// form "busybox", "foo/bar" or
 	// "sha256:d462265d362c919b7dd37f8ba80caa822d13704695f47c8fc42a1c2266ecd164"
+	// In globs, "*" and "?" do not match "/" and "**" matches anything,
+	// i.e. "registry.internal/*/nginx:*". Images named without a tag or
+	// digest are also matched as if they had the tag "latest".
 	ImageNames []string `protobuf:"bytes,4,rep,name=image_names,json=imageNames" json:"image_names,omitempty"`
+	// Zero or more regular expressions (RE2 syntax) matched against
+	// container names. Patterns match anywhere in the name unless they
+	// are anchored with "^" or "$".
+	NameRegexps []string `protobuf:"bytes,5,rep,name=name_regexps,json=nameRegexps" json:"name_regexps,omitempty"`
+	// Zero or more regular expressions (RE2 syntax) matched against
+	// container image names, i.e. "^registry\.internal/.*/nginx:"
+	ImageNameRegexps []string `protobuf:"bytes,6,rep,name=image_name_regexps,json=imageNameRegexps" json:"image_name_regexps,omitempty"`
+	// Optional; containers matching this filter are excluded, even if
+	// they match the other fields, i.e. image_names "*-sidecar:*". If
+	// no other fields are set, events from all other containers and
+	// from the host are included.
+	Exclude *ContainerFilter `protobuf:"bytes,7,opt,name=exclude" json:"exclude,omitempty"`
 }
 
 func (m *ContainerFilter) Reset()                    { *m = ContainerFilter{} }
@@ -221,9 +370,33 @@ func (m *ContainerFilter) GetImageNames() []string {
 	return nil
 }
 
+func (m *ContainerFilter) GetNameRegexps() []string {
+	if m != nil {
+		return m.NameRegexps
+	}
+	return nil
+}
+
+func (m *ContainerFilter) GetImageNameRegexps() []string {
+	if m != nil {
+		return m.ImageNameRegexps
+	}
+	return nil
+}
+
+func (m *ContainerFilter) GetExclude() *ContainerFilter {
+	if m != nil {
+		return m.Exclude
+	}
+	return nil
+}
+
 // The EventFilter specifies events to include. All of the specified
 // fields are effectively "ORed" together to create the list of events
-// included in the Subscription.
+// included in the Subscription. Events of every class are delivered on
+// the Subscription's single stream in the order that the sensor
+// dispatches them, which merges kernel and sensor-generated events by
+// their timestamps; a client does not need a stream per event class.
 type EventFilter struct {
 	// Zero or more filters specifying which system calls to include
 	SyscallEvents []*SyscallEventFilter `protobuf:"bytes,1,rep,name=syscall_events,json=syscallEvents" json:"syscall_events,omitempty"`
@@ -237,8 +410,30 @@ type EventFilter struct {
 	NetworkEvents []*NetworkEventFilter `protobuf:"bytes,5,rep,name=network_events,json=networkEvents" json:"network_events,omitempty"`
 	// Zero or more performance events to include
 	PerformanceEvents []*PerformanceEventFilter `protobuf:"bytes,6,rep,name=performance_events,json=performanceEvents" json:"performance_events,omitempty"`
+	// Zero or more kernel module events to include
+	KernelModuleEvents []*KernelModuleEventFilter `protobuf:"bytes,7,rep,name=kernel_module_events,json=kernelModuleEvents" json:"kernel_module_events,omitempty"`
+	// Zero or more mount events to include
+	MountEvents []*MountEventFilter `protobuf:"bytes,8,rep,name=mount_events,json=mountEvents" json:"mount_events,omitempty"`
+	// Zero or more memory events to include
+	MemoryEvents []*MemoryEventFilter `protobuf:"bytes,9,rep,name=memory_events,json=memoryEvents" json:"memory_events,omitempty"`
+	// Zero or more signal events to include
+	SignalEvents []*SignalEventFilter `protobuf:"bytes,12,rep,name=signal_events,json=signalEvents" json:"signal_events,omitempty"`
+	// Zero or more Linux Security Module events to include
+	LsmEvents []*LsmEventFilter `protobuf:"bytes,13,rep,name=lsm_events,json=lsmEvents" json:"lsm_events,omitempty"`
+	// Zero or more TTY events to include
+	TtyEvents []*TtyEventFilter `protobuf:"bytes,14,rep,name=tty_events,json=ttyEvents" json:"tty_events,omitempty"`
+	// Zero or more io_uring events to include
+	IoUringEvents []*IoUringEventFilter `protobuf:"bytes,16,rep,name=io_uring_events,json=ioUringEvents" json:"io_uring_events,omitempty"`
+	// Zero or more BPF events to include
+	BpfEvents []*BpfEventFilter `protobuf:"bytes,17,rep,name=bpf_events,json=bpfEvents" json:"bpf_events,omitempty"`
+	// Zero or more user-space function calls to include
+	UserEvents []*UserFunctionCallFilter `protobuf:"bytes,18,rep,name=user_events,json=userEvents" json:"user_events,omitempty"`
 	// Zero or more container events to include
 	ContainerEvents []*ContainerEventFilter `protobuf:"bytes,10,rep,name=container_events,json=containerEvents" json:"container_events,omitempty"`
+	// Zero or more image events to include
+	ImageEvents []*ImageEventFilter `protobuf:"bytes,11,rep,name=image_events,json=imageEvents" json:"image_events,omitempty"`
+	// Zero or more login session events to include
+	SessionEvents []*SessionEventFilter `protobuf:"bytes,15,rep,name=session_events,json=sessionEvents" json:"session_events,omitempty"`
 	// Zero or more character generators to configure and return events from
 	// (for debugging)
 	ChargenEvents []*ChargenEventFilter `protobuf:"bytes,100,rep,name=chargen_events,json=chargenEvents" json:"chargen_events,omitempty"`
@@ -294,6 +489,69 @@ func (m *EventFilter) GetPerformanceEvents() []*PerformanceEventFilter {
 	return nil
 }
 
+func (m *EventFilter) GetKernelModuleEvents() []*KernelModuleEventFilter {
+	if m != nil {
+		return m.KernelModuleEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetMountEvents() []*MountEventFilter {
+	if m != nil {
+		return m.MountEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetMemoryEvents() []*MemoryEventFilter {
+	if m != nil {
+		return m.MemoryEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetSignalEvents() []*SignalEventFilter {
+	if m != nil {
+		return m.SignalEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetLsmEvents() []*LsmEventFilter {
+	if m != nil {
+		return m.LsmEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetTtyEvents() []*TtyEventFilter {
+	if m != nil {
+		return m.TtyEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetIoUringEvents() []*IoUringEventFilter {
+	if m != nil {
+		return m.IoUringEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetBpfEvents() []*BpfEventFilter {
+	if m != nil {
+		return m.BpfEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetUserEvents() []*UserFunctionCallFilter {
+	if m != nil {
+		return m.UserEvents
+	}
+	return nil
+}
+
 func (m *EventFilter) GetContainerEvents() []*ContainerEventFilter {
 	if m != nil {
 		return m.ContainerEvents
@@ -301,6 +559,20 @@ func (m *EventFilter) GetContainerEvents() []*ContainerEventFilter {
 	return nil
 }
 
+func (m *EventFilter) GetImageEvents() []*ImageEventFilter {
+	if m != nil {
+		return m.ImageEvents
+	}
+	return nil
+}
+
+func (m *EventFilter) GetSessionEvents() []*SessionEventFilter {
+	if m != nil {
+		return m.SessionEvents
+	}
+	return nil
+}
+
 func (m *EventFilter) GetChargenEvents() []*ChargenEventFilter {
 	if m != nil {
 		return m.ChargenEvents
@@ -320,8 +592,17 @@ func (m *EventFilter) GetTickerEvents() []*TickerEventFilter {
 // "ANDed" to specify a matching event.
 type SyscallEventFilter struct {
 	// Required; type of system call event (entry or exit)
-	Type             SyscallEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SyscallEventType" json:"type,omitempty"`
-	FilterExpression *Expression      `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+	Type SyscallEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SyscallEventType" json:"type,omitempty"`
+	// Optional; the set of system call numbers to match. For enter
+	// events, this attaches directly to the sys_enter tracepoint for
+	// only these system calls, which allows system calls that the
+	// Sensor does not otherwise support to be traced.
+	Ids []int64 `protobuf:"varint,3,rep,packed,name=ids" json:"ids,omitempty"`
+	// Optional; for enter events with ids set, the number of integer
+	// arguments to fetch, starting with arg0 (at most 6). Arguments that
+	// are not fetched are reported as 0.
+	ArgCount         uint32      `protobuf:"varint,4,opt,name=arg_count,json=argCount" json:"arg_count,omitempty"`
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
 	// Required; system call number from
 	// arch/x86/entry/syscalls/syscall_64.tbl
 	Id *google_protobuf1.Int64Value `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
@@ -348,6 +629,20 @@ func (m *SyscallEventFilter) GetType() SyscallEventType {
 	return SyscallEventType_SYSCALL_EVENT_TYPE_UNKNOWN
 }
 
+func (m *SyscallEventFilter) GetIds() []int64 {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+func (m *SyscallEventFilter) GetArgCount() uint32 {
+	if m != nil {
+		return m.ArgCount
+	}
+	return 0
+}
+
 func (m *SyscallEventFilter) GetFilterExpression() *Expression {
 	if m != nil {
 		return m.FilterExpression
@@ -416,8 +711,19 @@ func (m *SyscallEventFilter) GetRet() *google_protobuf1.Int64Value {
 // specify a matching event.
 type ProcessEventFilter struct {
 	// Required; the process event type to match
-	Type             ProcessEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ProcessEventType" json:"type,omitempty"`
-	FilterExpression *Expression      `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+	Type ProcessEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ProcessEventType" json:"type,omitempty"`
+	// Optional; for capability change events, require that at least one
+	// of the capabilities in this mask was gained. Bit n of the mask
+	// corresponds to capability number n (e.g., CAP_SYS_ADMIN is 21).
+	CapabilityMask uint64 `protobuf:"varint,2,opt,name=capability_mask,json=capabilityMask" json:"capability_mask,omitempty"`
+	// Optional; for exec events, exclude events with any of these
+	// filenames passed to execve(2), i.e. "/usr/bin/pause"
+	ExcludeExecFilenames []string `protobuf:"bytes,3,rep,name=exclude_exec_filenames,json=excludeExecFilenames" json:"exclude_exec_filenames,omitempty"`
+	// Optional; for exec events, exclude events with a filename passed
+	// to execve(2) matching any of these patterns, where '*' matches
+	// any sequence of characters
+	ExcludeExecFilenamePatterns []string    `protobuf:"bytes,4,rep,name=exclude_exec_filename_patterns,json=excludeExecFilenamePatterns" json:"exclude_exec_filename_patterns,omitempty"`
+	FilterExpression            *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
 	// Optional; require exact match on the filename passed to execve(2)
 	ExecFilename *google_protobuf1.StringValue `protobuf:"bytes,12,opt,name=exec_filename,json=execFilename" json:"exec_filename,omitempty"`
 	// Optional; require pattern match on the filename passed to execve(2)
@@ -438,6 +744,27 @@ func (m *ProcessEventFilter) GetType() ProcessEventType {
 	return ProcessEventType_PROCESS_EVENT_TYPE_UNKNOWN
 }
 
+func (m *ProcessEventFilter) GetCapabilityMask() uint64 {
+	if m != nil {
+		return m.CapabilityMask
+	}
+	return 0
+}
+
+func (m *ProcessEventFilter) GetExcludeExecFilenames() []string {
+	if m != nil {
+		return m.ExcludeExecFilenames
+	}
+	return nil
+}
+
+func (m *ProcessEventFilter) GetExcludeExecFilenamePatterns() []string {
+	if m != nil {
+		return m.ExcludeExecFilenamePatterns
+	}
+	return nil
+}
+
 func (m *ProcessEventFilter) GetFilterExpression() *Expression {
 	if m != nil {
 		return m.FilterExpression
@@ -471,8 +798,15 @@ func (m *ProcessEventFilter) GetExitCode() *google_protobuf1.Int32Value {
 // specify a matching event.
 type FileEventFilter struct {
 	// Required; the file event type to match
-	Type             FileEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.FileEventType" json:"type,omitempty"`
-	FilterExpression *Expression   `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+	Type FileEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.FileEventType" json:"type,omitempty"`
+	// Optional; require the filename being acted upon to begin with
+	// one of these prefixes
+	PathPrefixes []string `protobuf:"bytes,2,rep,name=path_prefixes,json=pathPrefixes" json:"path_prefixes,omitempty"`
+	// Optional; require the filename being acted upon to match one of
+	// these patterns, where '*' matches any sequence of characters and
+	// '?' matches any single character
+	PathGlobs        []string    `protobuf:"bytes,3,rep,name=path_globs,json=pathGlobs" json:"path_globs,omitempty"`
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
 	// Optional; require exact match on the filename being acted upon
 	Filename *google_protobuf1.StringValue `protobuf:"bytes,10,opt,name=filename" json:"filename,omitempty"`
 	// Optional; require pattern match on the filename being acted upon
@@ -497,6 +831,20 @@ func (m *FileEventFilter) GetType() FileEventType {
 	return FileEventType_FILE_EVENT_TYPE_UNKNOWN
 }
 
+func (m *FileEventFilter) GetPathPrefixes() []string {
+	if m != nil {
+		return m.PathPrefixes
+	}
+	return nil
+}
+
+func (m *FileEventFilter) GetPathGlobs() []string {
+	if m != nil {
+		return m.PathGlobs
+	}
+	return nil
+}
+
 func (m *FileEventFilter) GetFilterExpression() *Expression {
 	if m != nil {
 		return m.FilterExpression
@@ -532,6 +880,344 @@ func (m *FileEventFilter) GetCreateModeMask() *google_protobuf1.Int32Value {
 	return nil
 }
 
+// The BpfEventFilter specifies which BPF events to include in the
+// Subscription.
+type BpfEventFilter struct {
+	// Required; the BPF event type to match
+	Type             BpfEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.BpfEventType" json:"type,omitempty"`
+	FilterExpression *Expression  `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *BpfEventFilter) Reset()                    { *m = BpfEventFilter{} }
+func (m *BpfEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*BpfEventFilter) ProtoMessage()               {}
+func (*BpfEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
+
+func (m *BpfEventFilter) GetType() BpfEventType {
+	if m != nil {
+		return m.Type
+	}
+	return BpfEventType_BPF_EVENT_TYPE_UNKNOWN
+}
+
+func (m *BpfEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The IoUringEventFilter specifies which io_uring events to include in the
+// Subscription.
+type IoUringEventFilter struct {
+	// Required; the io_uring event type to match
+	Type             IoUringEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.IoUringEventType" json:"type,omitempty"`
+	FilterExpression *Expression      `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *IoUringEventFilter) Reset()                    { *m = IoUringEventFilter{} }
+func (m *IoUringEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*IoUringEventFilter) ProtoMessage()               {}
+func (*IoUringEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
+
+func (m *IoUringEventFilter) GetType() IoUringEventType {
+	if m != nil {
+		return m.Type
+	}
+	return IoUringEventType_IO_URING_EVENT_TYPE_UNKNOWN
+}
+
+func (m *IoUringEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The UserFunctionCallFilter specifies which user-space function call
+// events to include in the Subscription. The function is probed in the
+// executable or shared library file, so calls are reported for every process
+// that maps the file, including processes in containers. The arguments map
+// is the same as for KernelFunctionCallFilter.
+type UserFunctionCallFilter struct {
+	// Required; the user function call event type to match
+	Type UserFunctionCallEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.UserFunctionCallEventType" json:"type,omitempty"`
+	// Required; the absolute path of the executable or shared library to
+	// probe, as seen by the Sensor. Files inside of a container may be
+	// probed via /proc/PID/root for any process in the container.
+	Executable string `protobuf:"bytes,10,opt,name=executable" json:"executable,omitempty"`
+	// Optional; the symbol to probe. Either symbol or offset is required.
+	Symbol string `protobuf:"bytes,11,opt,name=symbol" json:"symbol,omitempty"`
+	// Optional; the file offset of the instruction to probe when symbol
+	// is not set. For exit events, this must be the offset of the first
+	// instruction of the function.
+	Offset uint64 `protobuf:"varint,12,opt,name=offset" json:"offset,omitempty"`
+	// Optional; the field names and data to be returned by the kernel
+	// when the event triggers, as in KernelFunctionCallFilter. Memory
+	// references are read from the address space of the process. For
+	// exit events, "$retval" refers to the function's return value
+	// (e.g., "$retval:s32").
+	Arguments map[string]string `protobuf:"bytes,13,rep,name=arguments" json:"arguments,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optional; a filter to apply to the user probe.
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *UserFunctionCallFilter) Reset()                    { *m = UserFunctionCallFilter{} }
+func (m *UserFunctionCallFilter) String() string            { return proto.CompactTextString(m) }
+func (*UserFunctionCallFilter) ProtoMessage()               {}
+func (*UserFunctionCallFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+
+func (m *UserFunctionCallFilter) GetType() UserFunctionCallEventType {
+	if m != nil {
+		return m.Type
+	}
+	return UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_UNKNOWN
+}
+
+func (m *UserFunctionCallFilter) GetExecutable() string {
+	if m != nil {
+		return m.Executable
+	}
+	return ""
+}
+
+func (m *UserFunctionCallFilter) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *UserFunctionCallFilter) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *UserFunctionCallFilter) GetArguments() map[string]string {
+	if m != nil {
+		return m.Arguments
+	}
+	return nil
+}
+
+func (m *UserFunctionCallFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The KernelModuleEventFilter specifies which kernel module events to
+// include in the Subscription.
+type KernelModuleEventFilter struct {
+	// Required; the kernel module event type to match
+	Type             KernelModuleEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.KernelModuleEventType" json:"type,omitempty"`
+	FilterExpression *Expression           `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *KernelModuleEventFilter) Reset()                    { *m = KernelModuleEventFilter{} }
+func (m *KernelModuleEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*KernelModuleEventFilter) ProtoMessage()               {}
+func (*KernelModuleEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
+
+func (m *KernelModuleEventFilter) GetType() KernelModuleEventType {
+	if m != nil {
+		return m.Type
+	}
+	return KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNKNOWN
+}
+
+func (m *KernelModuleEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The LsmEventFilter specifies which Linux Security Module events to include
+// in the Subscription.
+type LsmEventFilter struct {
+	// Required; the LSM event type to match
+	Type             LsmEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.LsmEventType" json:"type,omitempty"`
+	FilterExpression *Expression  `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *LsmEventFilter) Reset()                    { *m = LsmEventFilter{} }
+func (m *LsmEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*LsmEventFilter) ProtoMessage()               {}
+func (*LsmEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{10} }
+
+func (m *LsmEventFilter) GetType() LsmEventType {
+	if m != nil {
+		return m.Type
+	}
+	return LsmEventType_LSM_EVENT_TYPE_UNKNOWN
+}
+
+func (m *LsmEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The MemoryEventFilter specifies which memory events to include in the
+// Subscription.
+type MemoryEventFilter struct {
+	// Required; the memory event type to match
+	Type             MemoryEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.MemoryEventType" json:"type,omitempty"`
+	FilterExpression *Expression     `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *MemoryEventFilter) Reset()                    { *m = MemoryEventFilter{} }
+func (m *MemoryEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*MemoryEventFilter) ProtoMessage()               {}
+func (*MemoryEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
+
+func (m *MemoryEventFilter) GetType() MemoryEventType {
+	if m != nil {
+		return m.Type
+	}
+	return MemoryEventType_MEMORY_EVENT_TYPE_UNKNOWN
+}
+
+func (m *MemoryEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The MountEventFilter specifies which mount events to include in the
+// Subscription.
+type MountEventFilter struct {
+	// Required; the mount event type to match
+	Type             MountEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.MountEventType" json:"type,omitempty"`
+	FilterExpression *Expression    `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *MountEventFilter) Reset()                    { *m = MountEventFilter{} }
+func (m *MountEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*MountEventFilter) ProtoMessage()               {}
+func (*MountEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{12} }
+
+func (m *MountEventFilter) GetType() MountEventType {
+	if m != nil {
+		return m.Type
+	}
+	return MountEventType_MOUNT_EVENT_TYPE_UNKNOWN
+}
+
+func (m *MountEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The SessionEventFilter specifies which login session events to include in
+// the Subscription.
+type SessionEventFilter struct {
+	// Required; the session event type to match
+	Type             SessionEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SessionEventType" json:"type,omitempty"`
+	FilterExpression *Expression      `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *SessionEventFilter) Reset()                    { *m = SessionEventFilter{} }
+func (m *SessionEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*SessionEventFilter) ProtoMessage()               {}
+func (*SessionEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{13} }
+
+func (m *SessionEventFilter) GetType() SessionEventType {
+	if m != nil {
+		return m.Type
+	}
+	return SessionEventType_SESSION_EVENT_TYPE_UNKNOWN
+}
+
+func (m *SessionEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The SignalEventFilter specifies which signal events to include in the
+// Subscription.
+type SignalEventFilter struct {
+	// Required; the signal event type to match
+	Type SignalEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SignalEventType" json:"type,omitempty"`
+	// Optional; only include signals with one of these numbers
+	Signals          []int32     `protobuf:"zigzag32,2,rep,packed,name=signals" json:"signals,omitempty"`
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *SignalEventFilter) Reset()                    { *m = SignalEventFilter{} }
+func (m *SignalEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*SignalEventFilter) ProtoMessage()               {}
+func (*SignalEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{14} }
+
+func (m *SignalEventFilter) GetType() SignalEventType {
+	if m != nil {
+		return m.Type
+	}
+	return SignalEventType_SIGNAL_EVENT_TYPE_UNKNOWN
+}
+
+func (m *SignalEventFilter) GetSignals() []int32 {
+	if m != nil {
+		return m.Signals
+	}
+	return nil
+}
+
+func (m *SignalEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
+// The TtyEventFilter specifies which TTY events to include in the
+// Subscription.
+type TtyEventFilter struct {
+	// Required; the TTY event type to match
+	Type TtyEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.TtyEventType" json:"type,omitempty"`
+	// Optional; include the input that was read in each event. Terminal
+	// input can contain passwords and other secrets, so only the length
+	// of the input is reported unless this is set.
+	IncludeData      bool        `protobuf:"varint,2,opt,name=include_data,json=includeData" json:"include_data,omitempty"`
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *TtyEventFilter) Reset()                    { *m = TtyEventFilter{} }
+func (m *TtyEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*TtyEventFilter) ProtoMessage()               {}
+func (*TtyEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{15} }
+
+func (m *TtyEventFilter) GetType() TtyEventType {
+	if m != nil {
+		return m.Type
+	}
+	return TtyEventType_TTY_EVENT_TYPE_UNKNOWN
+}
+
+func (m *TtyEventFilter) GetIncludeData() bool {
+	if m != nil {
+		return m.IncludeData
+	}
+	return false
+}
+
+func (m *TtyEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
 // The KernelFunctionCallFilter specifies which kernel function call
 // events to include in the Subscription. The arguments map defines
 // values that will be fetched at each call and returned along with
@@ -551,7 +1237,8 @@ type KernelFunctionCallFilter struct {
 	// register containing the desired data and a suffix indicating the
 	// type of the data (e.g., "s32", "string", "u64", etc.). This map is
 	// used to construct the "fetchargs" passed to the kernel when creating
-	// the kernel probe.
+	// the kernel probe. For exit events, "$retval" refers to the
+	// function's return value (e.g., "$retval:s64").
 	Arguments map[string]string `protobuf:"bytes,11,rep,name=arguments" json:"arguments,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Optional; a filter to apply to kernel probe.
 	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
@@ -560,7 +1247,7 @@ type KernelFunctionCallFilter struct {
 func (m *KernelFunctionCallFilter) Reset()                    { *m = KernelFunctionCallFilter{} }
 func (m *KernelFunctionCallFilter) String() string            { return proto.CompactTextString(m) }
 func (*KernelFunctionCallFilter) ProtoMessage()               {}
-func (*KernelFunctionCallFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
+func (*KernelFunctionCallFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{16} }
 
 func (m *KernelFunctionCallFilter) GetType() KernelFunctionCallEventType {
 	if m != nil {
@@ -604,7 +1291,7 @@ type NetworkEventFilter struct {
 func (m *NetworkEventFilter) Reset()                    { *m = NetworkEventFilter{} }
 func (m *NetworkEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*NetworkEventFilter) ProtoMessage()               {}
-func (*NetworkEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
+func (*NetworkEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{17} }
 
 func (m *NetworkEventFilter) GetType() NetworkEventType {
 	if m != nil {
@@ -635,7 +1322,7 @@ type PerformanceEventCounter struct {
 func (m *PerformanceEventCounter) Reset()                    { *m = PerformanceEventCounter{} }
 func (m *PerformanceEventCounter) String() string            { return proto.CompactTextString(m) }
 func (*PerformanceEventCounter) ProtoMessage()               {}
-func (*PerformanceEventCounter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+func (*PerformanceEventCounter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{18} }
 
 func (m *PerformanceEventCounter) GetType() PerformanceEventType {
 	if m != nil {
@@ -666,12 +1353,16 @@ type PerformanceEventFilter struct {
 	//	*PerformanceEventFilter_Period
 	//	*PerformanceEventFilter_Frequency
 	SampleRate isPerformanceEventFilter_SampleRate `protobuf_oneof:"sample_rate"`
+	// Optional; the perf_event cgroups to count events for, relative to
+	// the perf_event cgroup mountpoint. If empty, events are counted for
+	// everything the sensor monitors.
+	Cgroups []string `protobuf:"bytes,14,rep,name=cgroups" json:"cgroups,omitempty"`
 }
 
 func (m *PerformanceEventFilter) Reset()                    { *m = PerformanceEventFilter{} }
 func (m *PerformanceEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*PerformanceEventFilter) ProtoMessage()               {}
-func (*PerformanceEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
+func (*PerformanceEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{19} }
 
 type isPerformanceEventFilter_SampleRate interface {
 	isPerformanceEventFilter_SampleRate()
@@ -722,6 +1413,13 @@ func (m *PerformanceEventFilter) GetFrequency() uint64 {
 	return 0
 }
 
+func (m *PerformanceEventFilter) GetCgroups() []string {
+	if m != nil {
+		return m.Cgroups
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*PerformanceEventFilter) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _PerformanceEventFilter_OneofMarshaler, _PerformanceEventFilter_OneofUnmarshaler, _PerformanceEventFilter_OneofSizer, []interface{}{
@@ -802,7 +1500,7 @@ type ContainerEventFilter struct {
 func (m *ContainerEventFilter) Reset()                    { *m = ContainerEventFilter{} }
 func (m *ContainerEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*ContainerEventFilter) ProtoMessage()               {}
-func (*ContainerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{10} }
+func (*ContainerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{20} }
 
 func (m *ContainerEventFilter) GetType() ContainerEventType {
 	if m != nil {
@@ -825,6 +1523,35 @@ func (m *ContainerEventFilter) GetFilterExpression() *Expression {
 	return nil
 }
 
+// The ImageEventFilter specifies which container image events to include
+// in the Subscription.
+type ImageEventFilter struct {
+	// Required, specify the particular type of event type to match
+	Type ImageEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ImageEventType" json:"type,omitempty"`
+	// Optional; a filter to apply to events. Only events for which the
+	// evaluation of the filter expression is true will be returned.
+	FilterExpression *Expression `protobuf:"bytes,100,opt,name=filter_expression,json=filterExpression" json:"filter_expression,omitempty"`
+}
+
+func (m *ImageEventFilter) Reset()                    { *m = ImageEventFilter{} }
+func (m *ImageEventFilter) String() string            { return proto.CompactTextString(m) }
+func (*ImageEventFilter) ProtoMessage()               {}
+func (*ImageEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{21} }
+
+func (m *ImageEventFilter) GetType() ImageEventType {
+	if m != nil {
+		return m.Type
+	}
+	return ImageEventType_IMAGE_EVENT_TYPE_UNKNOWN
+}
+
+func (m *ImageEventFilter) GetFilterExpression() *Expression {
+	if m != nil {
+		return m.FilterExpression
+	}
+	return nil
+}
+
 // The ChargenEventFilter configures a character stream generator and
 // includes events from it in the Subscription.
 type ChargenEventFilter struct {
@@ -835,7 +1562,7 @@ type ChargenEventFilter struct {
 func (m *ChargenEventFilter) Reset()                    { *m = ChargenEventFilter{} }
 func (m *ChargenEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*ChargenEventFilter) ProtoMessage()               {}
-func (*ChargenEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
+func (*ChargenEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{22} }
 
 func (m *ChargenEventFilter) GetLength() uint64 {
 	if m != nil {
@@ -854,7 +1581,7 @@ type TickerEventFilter struct {
 func (m *TickerEventFilter) Reset()                    { *m = TickerEventFilter{} }
 func (m *TickerEventFilter) String() string            { return proto.CompactTextString(m) }
 func (*TickerEventFilter) ProtoMessage()               {}
-func (*TickerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{12} }
+func (*TickerEventFilter) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{23} }
 
 func (m *TickerEventFilter) GetInterval() int64 {
 	if m != nil {
@@ -867,14 +1594,21 @@ func (m *TickerEventFilter) GetInterval() int64 {
 // stream, a modifier can apply a throttle or limit etc. Modifiers can be
 // used together.
 type Modifier struct {
-	Throttle *ThrottleModifier `protobuf:"bytes,1,opt,name=throttle" json:"throttle,omitempty"`
-	Limit    *LimitModifier    `protobuf:"bytes,2,opt,name=limit" json:"limit,omitempty"`
+	Throttle      *ThrottleModifier      `protobuf:"bytes,1,opt,name=throttle" json:"throttle,omitempty"`
+	Limit         *LimitModifier         `protobuf:"bytes,2,opt,name=limit" json:"limit,omitempty"`
+	RateLimit     *RateLimitModifier     `protobuf:"bytes,3,opt,name=rate_limit,json=rateLimit" json:"rate_limit,omitempty"`
+	Sample        *SampleModifier        `protobuf:"bytes,4,opt,name=sample" json:"sample,omitempty"`
+	KeyedThrottle *KeyedThrottleModifier `protobuf:"bytes,5,opt,name=keyed_throttle,json=keyedThrottle" json:"keyed_throttle,omitempty"`
+	Aggregate     *AggregateModifier     `protobuf:"bytes,6,opt,name=aggregate" json:"aggregate,omitempty"`
+	Projection    *ProjectionModifier    `protobuf:"bytes,7,opt,name=projection" json:"projection,omitempty"`
+	Buffer        *BufferModifier        `protobuf:"bytes,8,opt,name=buffer" json:"buffer,omitempty"`
+	Batch         *BatchModifier         `protobuf:"bytes,9,opt,name=batch" json:"batch,omitempty"`
 }
 
 func (m *Modifier) Reset()                    { *m = Modifier{} }
 func (m *Modifier) String() string            { return proto.CompactTextString(m) }
 func (*Modifier) ProtoMessage()               {}
-func (*Modifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{13} }
+func (*Modifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{24} }
 
 func (m *Modifier) GetThrottle() *ThrottleModifier {
 	if m != nil {
@@ -890,6 +1624,230 @@ func (m *Modifier) GetLimit() *LimitModifier {
 	return nil
 }
 
+func (m *Modifier) GetRateLimit() *RateLimitModifier {
+	if m != nil {
+		return m.RateLimit
+	}
+	return nil
+}
+
+func (m *Modifier) GetSample() *SampleModifier {
+	if m != nil {
+		return m.Sample
+	}
+	return nil
+}
+
+func (m *Modifier) GetKeyedThrottle() *KeyedThrottleModifier {
+	if m != nil {
+		return m.KeyedThrottle
+	}
+	return nil
+}
+
+func (m *Modifier) GetAggregate() *AggregateModifier {
+	if m != nil {
+		return m.Aggregate
+	}
+	return nil
+}
+
+func (m *Modifier) GetProjection() *ProjectionModifier {
+	if m != nil {
+		return m.Projection
+	}
+	return nil
+}
+
+func (m *Modifier) GetBuffer() *BufferModifier {
+	if m != nil {
+		return m.Buffer
+	}
+	return nil
+}
+
+func (m *Modifier) GetBatch() *BatchModifier {
+	if m != nil {
+		return m.Batch
+	}
+	return nil
+}
+
+// The BatchModifier sends events to the client in batches, with many
+// events in each GetEventsResponse, rather than one event per response,
+// which is much faster for busy subscriptions. A batch is sent once it has
+// max_events events or its first event has waited max_latency, whichever
+// is sooner. With a delivery_id, each batch is acknowledged as a whole.
+type BatchModifier struct {
+	// Required; the most events sent in one response, which may not
+	// be more than 10000
+	MaxEvents uint32 `protobuf:"varint,1,opt,name=max_events,json=maxEvents" json:"max_events,omitempty"`
+	// Optional; the longest time that an event waits for its batch to
+	// be sent, which may not be more than 10 seconds. If 0, 100
+	// milliseconds is used.
+	MaxLatency int64 `protobuf:"varint,2,opt,name=max_latency,json=maxLatency" json:"max_latency,omitempty"`
+	// Optional; the max latency type (milliseconds, seconds, etc.)
+	MaxLatencyType ThrottleModifier_IntervalType `protobuf:"varint,3,opt,name=max_latency_type,json=maxLatencyType,enum=capsule8.api.v0.ThrottleModifier_IntervalType" json:"max_latency_type,omitempty"`
+}
+
+func (m *BatchModifier) Reset()                    { *m = BatchModifier{} }
+func (m *BatchModifier) String() string            { return proto.CompactTextString(m) }
+func (*BatchModifier) ProtoMessage()               {}
+func (*BatchModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{25} }
+
+func (m *BatchModifier) GetMaxEvents() uint32 {
+	if m != nil {
+		return m.MaxEvents
+	}
+	return 0
+}
+
+func (m *BatchModifier) GetMaxLatency() int64 {
+	if m != nil {
+		return m.MaxLatency
+	}
+	return 0
+}
+
+func (m *BatchModifier) GetMaxLatencyType() ThrottleModifier_IntervalType {
+	if m != nil {
+		return m.MaxLatencyType
+	}
+	return ThrottleModifier_MILLISECOND
+}
+
+// The BufferModifier configures the buffer that holds the events delivered
+// to a subscription by the Sensor until they are sent to the client, and
+// what is done when it is full because the client is not reading events
+// quickly enough. Each subscription has its own buffer, so a slow client's
+// events are dropped without delaying other subscriptions, except as
+// allowed by the BLOCK policy.
+type BufferModifier struct {
+	// Optional; the number of events to buffer. If 0, the Sensor's
+	// configured channel buffer length is used.
+	Length uint32 `protobuf:"varint,1,opt,name=length" json:"length,omitempty"`
+	// Optional; the overflow policy to use
+	OverflowPolicy BufferModifier_OverflowPolicy `protobuf:"varint,2,opt,name=overflow_policy,json=overflowPolicy,enum=capsule8.api.v0.BufferModifier_OverflowPolicy" json:"overflow_policy,omitempty"`
+	// Required for BLOCK; the longest time to wait for room in the
+	// buffer, which may not be more than one second
+	BlockTimeout int64 `protobuf:"varint,3,opt,name=block_timeout,json=blockTimeout" json:"block_timeout,omitempty"`
+	// Required for BLOCK; the block timeout type (milliseconds,
+	// seconds, etc.)
+	BlockTimeoutType ThrottleModifier_IntervalType `protobuf:"varint,4,opt,name=block_timeout_type,json=blockTimeoutType,enum=capsule8.api.v0.ThrottleModifier_IntervalType" json:"block_timeout_type,omitempty"`
+}
+
+func (m *BufferModifier) Reset()                    { *m = BufferModifier{} }
+func (m *BufferModifier) String() string            { return proto.CompactTextString(m) }
+func (*BufferModifier) ProtoMessage()               {}
+func (*BufferModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{26} }
+
+func (m *BufferModifier) GetLength() uint32 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *BufferModifier) GetOverflowPolicy() BufferModifier_OverflowPolicy {
+	if m != nil {
+		return m.OverflowPolicy
+	}
+	return BufferModifier_DROP_NEWEST
+}
+
+func (m *BufferModifier) GetBlockTimeout() int64 {
+	if m != nil {
+		return m.BlockTimeout
+	}
+	return 0
+}
+
+func (m *BufferModifier) GetBlockTimeoutType() ThrottleModifier_IntervalType {
+	if m != nil {
+		return m.BlockTimeoutType
+	}
+	return ThrottleModifier_MILLISECOND
+}
+
+// The ProjectionModifier restricts the fields of the TelemetryEvents sent
+// by the Sensor, i.e. to leave out the very large docker_config_json and
+// oci_config_json of container events. Fields are named as in
+// Subscription.expression, except that any field may be named; naming a
+// message names all of the fields in it. The id, sensor_id,
+// sensor_sequence_number, and sensor_monotime_nanos fields and the kind of
+// event are always sent.
+type ProjectionModifier struct {
+	// Optional; if not empty, only these fields are sent, i.e.
+	// "event.container_id" or "event.process"
+	Include []string `protobuf:"bytes,1,rep,name=include" json:"include,omitempty"`
+	// Optional; these fields are not sent, i.e.
+	// "event.container.docker_config_json"
+	Exclude []string `protobuf:"bytes,2,rep,name=exclude" json:"exclude,omitempty"`
+}
+
+func (m *ProjectionModifier) Reset()                    { *m = ProjectionModifier{} }
+func (m *ProjectionModifier) String() string            { return proto.CompactTextString(m) }
+func (*ProjectionModifier) ProtoMessage()               {}
+func (*ProjectionModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{27} }
+
+func (m *ProjectionModifier) GetInclude() []string {
+	if m != nil {
+		return m.Include
+	}
+	return nil
+}
+
+func (m *ProjectionModifier) GetExclude() []string {
+	if m != nil {
+		return m.Exclude
+	}
+	return nil
+}
+
+// The AggregateModifier collapses identical events seen by the Sensor
+// within a time interval into a single event. The first event of each
+// interval is sent when the interval ends, with an EventAggregate giving the
+// number of events collapsed into it and the times of the first and last of
+// them. Aggregated events are subject to the other modifiers when they are
+// sent.
+type AggregateModifier struct {
+	// Required; the interval to use
+	Interval int64 `protobuf:"varint,1,opt,name=interval" json:"interval,omitempty"`
+	// Required; the interval type (milliseconds, seconds, etc.)
+	IntervalType ThrottleModifier_IntervalType `protobuf:"varint,2,opt,name=interval_type,json=intervalType,enum=capsule8.api.v0.ThrottleModifier_IntervalType" json:"interval_type,omitempty"`
+	// Optional; the fields of the TelemetryEvent that identify identical
+	// events, named as in Subscription.expression. If empty, events
+	// are identical if all of their fields other than id,
+	// sensor_sequence_number, and sensor_monotime_nanos are equal.
+	Keys []string `protobuf:"bytes,3,rep,name=keys" json:"keys,omitempty"`
+}
+
+func (m *AggregateModifier) Reset()                    { *m = AggregateModifier{} }
+func (m *AggregateModifier) String() string            { return proto.CompactTextString(m) }
+func (*AggregateModifier) ProtoMessage()               {}
+func (*AggregateModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{28} }
+
+func (m *AggregateModifier) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *AggregateModifier) GetIntervalType() ThrottleModifier_IntervalType {
+	if m != nil {
+		return m.IntervalType
+	}
+	return ThrottleModifier_MILLISECOND
+}
+
+func (m *AggregateModifier) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
 // The ThrottleModifier modulates events sent by the Sensor to one per
 // time interval specified.
 type ThrottleModifier struct {
@@ -902,7 +1860,7 @@ type ThrottleModifier struct {
 func (m *ThrottleModifier) Reset()                    { *m = ThrottleModifier{} }
 func (m *ThrottleModifier) String() string            { return proto.CompactTextString(m) }
 func (*ThrottleModifier) ProtoMessage()               {}
-func (*ThrottleModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{14} }
+func (*ThrottleModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{29} }
 
 func (m *ThrottleModifier) GetInterval() int64 {
 	if m != nil {
@@ -918,6 +1876,56 @@ func (m *ThrottleModifier) GetIntervalType() ThrottleModifier_IntervalType {
 	return ThrottleModifier_MILLISECOND
 }
 
+// The KeyedThrottleModifier limits the number of events sent by the Sensor
+// in each time interval for each distinct key, so that a burst of events
+// with one key, such as from one container, does not crowd out the others.
+type KeyedThrottleModifier struct {
+	// Required; the fields of the TelemetryEvent whose values form the
+	// key, named as in Subscription.expression (i.e.
+	// "event.container_id" or "event.process.exec_filename"). Events
+	// without a field are keyed on an empty value for it.
+	Keys []string `protobuf:"bytes,1,rep,name=keys" json:"keys,omitempty"`
+	// Required; the maximum number of events for each key per interval
+	Limit uint64 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Required; the interval to use
+	Interval int64 `protobuf:"varint,3,opt,name=interval" json:"interval,omitempty"`
+	// Required; the interval type (milliseconds, seconds, etc.)
+	IntervalType ThrottleModifier_IntervalType `protobuf:"varint,4,opt,name=interval_type,json=intervalType,enum=capsule8.api.v0.ThrottleModifier_IntervalType" json:"interval_type,omitempty"`
+}
+
+func (m *KeyedThrottleModifier) Reset()                    { *m = KeyedThrottleModifier{} }
+func (m *KeyedThrottleModifier) String() string            { return proto.CompactTextString(m) }
+func (*KeyedThrottleModifier) ProtoMessage()               {}
+func (*KeyedThrottleModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{30} }
+
+func (m *KeyedThrottleModifier) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *KeyedThrottleModifier) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *KeyedThrottleModifier) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *KeyedThrottleModifier) GetIntervalType() ThrottleModifier_IntervalType {
+	if m != nil {
+		return m.IntervalType
+	}
+	return ThrottleModifier_MILLISECOND
+}
+
 // The LimitModifier cancels the subscription on each Sensor after the
 // specified number of events. The entire Subscription may return more
 // events than this depending on how many active Sensors there are.
@@ -929,7 +1937,7 @@ type LimitModifier struct {
 func (m *LimitModifier) Reset()                    { *m = LimitModifier{} }
 func (m *LimitModifier) String() string            { return proto.CompactTextString(m) }
 func (*LimitModifier) ProtoMessage()               {}
-func (*LimitModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{15} }
+func (*LimitModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{31} }
 
 func (m *LimitModifier) GetLimit() int64 {
 	if m != nil {
@@ -938,6 +1946,58 @@ func (m *LimitModifier) GetLimit() int64 {
 	return 0
 }
 
+// The RateLimitModifier limits the rate at which the Sensor sends events
+// for the subscription. Events in excess of the rate are dropped by the
+// Sensor as they are dispatched, before they are queued for the client.
+type RateLimitModifier struct {
+	// Required; the maximum sustained number of events per second
+	EventsPerSecond float64 `protobuf:"fixed64,1,opt,name=events_per_second,json=eventsPerSecond" json:"events_per_second,omitempty"`
+	// The maximum number of events that may be sent at once when the
+	// subscription has been quiet. If zero, the burst is the greater of
+	// events_per_second and 1.
+	Burst uint32 `protobuf:"varint,2,opt,name=burst" json:"burst,omitempty"`
+}
+
+func (m *RateLimitModifier) Reset()                    { *m = RateLimitModifier{} }
+func (m *RateLimitModifier) String() string            { return proto.CompactTextString(m) }
+func (*RateLimitModifier) ProtoMessage()               {}
+func (*RateLimitModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{32} }
+
+func (m *RateLimitModifier) GetEventsPerSecond() float64 {
+	if m != nil {
+		return m.EventsPerSecond
+	}
+	return 0
+}
+
+func (m *RateLimitModifier) GetBurst() uint32 {
+	if m != nil {
+		return m.Burst
+	}
+	return 0
+}
+
+// The SampleModifier randomly selects the events sent by the Sensor for the
+// subscription, so that high frequency events can be consumed statistically.
+// Each event is selected independently with a probability of 1 / one_in, so
+// the number of events sent varies. Sampling is applied before a rate limit.
+type SampleModifier struct {
+	// Required; select one in this many events
+	OneIn uint32 `protobuf:"varint,1,opt,name=one_in,json=oneIn" json:"one_in,omitempty"`
+}
+
+func (m *SampleModifier) Reset()                    { *m = SampleModifier{} }
+func (m *SampleModifier) String() string            { return proto.CompactTextString(m) }
+func (*SampleModifier) ProtoMessage()               {}
+func (*SampleModifier) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{33} }
+
+func (m *SampleModifier) GetOneIn() uint32 {
+	if m != nil {
+		return m.OneIn
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Subscription)(nil), "capsule8.api.v0.Subscription")
 	proto.RegisterType((*ContainerFilter)(nil), "capsule8.api.v0.ContainerFilter")
@@ -945,111 +2005,208 @@ func init() {
 	proto.RegisterType((*SyscallEventFilter)(nil), "capsule8.api.v0.SyscallEventFilter")
 	proto.RegisterType((*ProcessEventFilter)(nil), "capsule8.api.v0.ProcessEventFilter")
 	proto.RegisterType((*FileEventFilter)(nil), "capsule8.api.v0.FileEventFilter")
+	proto.RegisterType((*BpfEventFilter)(nil), "capsule8.api.v0.BpfEventFilter")
+	proto.RegisterType((*IoUringEventFilter)(nil), "capsule8.api.v0.IoUringEventFilter")
+	proto.RegisterType((*UserFunctionCallFilter)(nil), "capsule8.api.v0.UserFunctionCallFilter")
+	proto.RegisterType((*KernelModuleEventFilter)(nil), "capsule8.api.v0.KernelModuleEventFilter")
+	proto.RegisterType((*LsmEventFilter)(nil), "capsule8.api.v0.LsmEventFilter")
+	proto.RegisterType((*MemoryEventFilter)(nil), "capsule8.api.v0.MemoryEventFilter")
+	proto.RegisterType((*MountEventFilter)(nil), "capsule8.api.v0.MountEventFilter")
+	proto.RegisterType((*SessionEventFilter)(nil), "capsule8.api.v0.SessionEventFilter")
+	proto.RegisterType((*SignalEventFilter)(nil), "capsule8.api.v0.SignalEventFilter")
+	proto.RegisterType((*TtyEventFilter)(nil), "capsule8.api.v0.TtyEventFilter")
 	proto.RegisterType((*KernelFunctionCallFilter)(nil), "capsule8.api.v0.KernelFunctionCallFilter")
 	proto.RegisterType((*NetworkEventFilter)(nil), "capsule8.api.v0.NetworkEventFilter")
 	proto.RegisterType((*PerformanceEventCounter)(nil), "capsule8.api.v0.PerformanceEventCounter")
 	proto.RegisterType((*PerformanceEventFilter)(nil), "capsule8.api.v0.PerformanceEventFilter")
 	proto.RegisterType((*ContainerEventFilter)(nil), "capsule8.api.v0.ContainerEventFilter")
+	proto.RegisterType((*ImageEventFilter)(nil), "capsule8.api.v0.ImageEventFilter")
 	proto.RegisterType((*ChargenEventFilter)(nil), "capsule8.api.v0.ChargenEventFilter")
 	proto.RegisterType((*TickerEventFilter)(nil), "capsule8.api.v0.TickerEventFilter")
 	proto.RegisterType((*Modifier)(nil), "capsule8.api.v0.Modifier")
+	proto.RegisterType((*BatchModifier)(nil), "capsule8.api.v0.BatchModifier")
+	proto.RegisterType((*BufferModifier)(nil), "capsule8.api.v0.BufferModifier")
+	proto.RegisterType((*ProjectionModifier)(nil), "capsule8.api.v0.ProjectionModifier")
+	proto.RegisterType((*AggregateModifier)(nil), "capsule8.api.v0.AggregateModifier")
 	proto.RegisterType((*ThrottleModifier)(nil), "capsule8.api.v0.ThrottleModifier")
+	proto.RegisterType((*KeyedThrottleModifier)(nil), "capsule8.api.v0.KeyedThrottleModifier")
 	proto.RegisterType((*LimitModifier)(nil), "capsule8.api.v0.LimitModifier")
+	proto.RegisterType((*RateLimitModifier)(nil), "capsule8.api.v0.RateLimitModifier")
+	proto.RegisterType((*SampleModifier)(nil), "capsule8.api.v0.SampleModifier")
 	proto.RegisterEnum("capsule8.api.v0.SampleRateType", SampleRateType_name, SampleRateType_value)
 	proto.RegisterEnum("capsule8.api.v0.ContainerEventView", ContainerEventView_name, ContainerEventView_value)
+	proto.RegisterEnum("capsule8.api.v0.BufferModifier_OverflowPolicy", BufferModifier_OverflowPolicy_name, BufferModifier_OverflowPolicy_value)
 	proto.RegisterEnum("capsule8.api.v0.ThrottleModifier_IntervalType", ThrottleModifier_IntervalType_name, ThrottleModifier_IntervalType_value)
 }
 
 func init() { proto.RegisterFile("capsule8/api/v0/subscription.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 1403 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdb, 0x72, 0xda, 0x46,
-	0x18, 0x36, 0x07, 0x7b, 0xe0, 0xe7, 0xa4, 0x6c, 0xd3, 0x84, 0x3a, 0x19, 0xc7, 0x55, 0xc6, 0xd3,
-	0x24, 0x4d, 0xb1, 0x83, 0xed, 0xc6, 0xed, 0xf4, 0x10, 0x42, 0x20, 0xa1, 0xb1, 0x31, 0x15, 0xb6,
-	0x3b, 0xb9, 0xd2, 0xc8, 0x62, 0x21, 0x1a, 0x84, 0xa4, 0xee, 0x0a, 0x3b, 0x5c, 0xf5, 0x29, 0x7a,
-	0xd9, 0xbe, 0x4c, 0x67, 0xfa, 0x00, 0x9d, 0xce, 0xf4, 0x05, 0x7a, 0xdd, 0x67, 0xe8, 0xec, 0x01,
-	0x90, 0x50, 0x30, 0x5c, 0xc4, 0x77, 0xda, 0x7f, 0xbf, 0xef, 0xe3, 0x3f, 0xed, 0xbf, 0x0b, 0xa8,
-	0xa6, 0xe1, 0xd1, 0xa1, 0x8d, 0x0f, 0xb6, 0x0d, 0xcf, 0xda, 0xbe, 0xd8, 0xd9, 0xa6, 0xc3, 0x73,
-	0x6a, 0x12, 0xcb, 0xf3, 0x2d, 0xd7, 0x29, 0x79, 0xc4, 0xf5, 0x5d, 0x54, 0x18, 0x63, 0x4a, 0x86,
-	0x67, 0x95, 0x2e, 0x76, 0xd6, 0xb7, 0x66, 0x49, 0x3e, 0xb6, 0xf1, 0x00, 0xfb, 0x64, 0xa4, 0xe3,
-	0x0b, 0xec, 0xf8, 0x82, 0xb7, 0xbe, 0x39, 0x0b, 0xc3, 0xef, 0x3c, 0x82, 0x29, 0x9d, 0x28, 0xaf,
-	0x6f, 0xf4, 0x5c, 0xb7, 0x67, 0xe3, 0x6d, 0xbe, 0x3a, 0x1f, 0x76, 0xb7, 0x2f, 0x89, 0xe1, 0x79,
-	0x98, 0x50, 0xb1, 0xaf, 0xfe, 0x13, 0x87, 0x6c, 0x3b, 0xe0, 0x10, 0xfa, 0x1e, 0xb2, 0xfc, 0x17,
-	0xf4, 0xae, 0x65, 0xfb, 0x98, 0x14, 0x63, 0x9b, 0xb1, 0x07, 0x99, 0xf2, 0xdd, 0xd2, 0x8c, 0x87,
-	0xa5, 0x1a, 0x03, 0xd5, 0x39, 0x46, 0xcb, 0xe0, 0xe9, 0x02, 0xbd, 0x06, 0xc5, 0x74, 0x1d, 0xdf,
-	0xb0, 0x1c, 0x4c, 0xc6, 0x22, 0x71, 0x2e, 0xb2, 0x19, 0x11, 0xa9, 0x8e, 0x81, 0x52, 0xa8, 0x60,
-	0x86, 0x0d, 0xe8, 0x39, 0xe4, 0xa9, 0xe5, 0x98, 0x58, 0xef, 0x0c, 0x89, 0xc1, 0xfc, 0x2b, 0x02,
-	0x97, 0xba, 0x53, 0x12, 0x71, 0x95, 0xc6, 0x71, 0x95, 0x1a, 0x8e, 0xff, 0xe5, 0xde, 0x99, 0x61,
-	0x0f, 0xb1, 0x96, 0xe3, 0x94, 0x17, 0x92, 0x81, 0xbe, 0x83, 0x6c, 0xd7, 0x25, 0x53, 0x85, 0xcc,
-	0x62, 0x85, 0x4c, 0xd7, 0x25, 0x13, 0xfe, 0x3e, 0xa4, 0x06, 0x6e, 0xc7, 0xea, 0x5a, 0x98, 0x14,
-	0x6f, 0x72, 0xee, 0x27, 0x91, 0x40, 0x8e, 0x24, 0x40, 0x9b, 0x40, 0xd5, 0x4b, 0x28, 0xcc, 0x84,
-	0x87, 0x14, 0x48, 0x58, 0x1d, 0x5a, 0x8c, 0x6d, 0x26, 0x1e, 0xa4, 0x35, 0xf6, 0x89, 0x6e, 0xc2,
-	0xaa, 0x63, 0x0c, 0x30, 0x2d, 0xc6, 0xb9, 0x4d, 0x2c, 0xd0, 0x1d, 0x48, 0x5b, 0x03, 0xa3, 0x87,
-	0x75, 0x86, 0x4e, 0xf0, 0x9d, 0x14, 0x37, 0x34, 0x3a, 0x14, 0xdd, 0x83, 0x8c, 0xd8, 0x14, 0xc4,
-	0x24, 0xdf, 0x06, 0x6e, 0x6a, 0x32, 0x8b, 0xfa, 0xc7, 0x2a, 0x64, 0x02, 0xd5, 0x41, 0x3f, 0x40,
-	0x9e, 0x8e, 0xa8, 0x69, 0xd8, 0xb6, 0xe8, 0x1d, 0xe1, 0x40, 0xa6, 0x7c, 0x3f, 0x12, 0x45, 0x5b,
-	0xc0, 0x82, 0xa5, 0xcd, 0xd1, 0x80, 0x8d, 0x32, 0x2d, 0x8f, 0xb8, 0x26, 0xa6, 0x74, 0xac, 0x15,
-	0x9f, 0xa3, 0xd5, 0x12, 0xb0, 0x90, 0x96, 0x17, 0xb0, 0x51, 0x54, 0x81, 0x4c, 0xd7, 0xb2, 0xf1,
-	0x58, 0x28, 0xc1, 0x85, 0xa2, 0x3d, 0x52, 0xb7, 0x6c, 0x1c, 0x54, 0x81, 0xee, 0xd8, 0x40, 0x51,
-	0x13, 0x72, 0x7d, 0x4c, 0x1c, 0x3c, 0x89, 0x2c, 0xc9, 0x45, 0x1e, 0x46, 0x44, 0x5e, 0x73, 0x54,
-	0x7d, 0xe8, 0x98, 0xac, 0xa4, 0x55, 0xc3, 0xb6, 0xa5, 0x5a, 0x56, 0xf0, 0xa7, 0xe1, 0x39, 0xd8,
-	0xbf, 0x74, 0x49, 0x7f, 0x2c, 0xb8, 0x3a, 0x27, 0xbc, 0xa6, 0x80, 0x85, 0xc2, 0x73, 0x02, 0x36,
-	0x8a, 0xce, 0x00, 0x79, 0x98, 0x74, 0x5d, 0x32, 0x30, 0x58, 0x03, 0x4b, 0xbd, 0x35, 0xae, 0xf7,
-	0x59, 0x34, 0x5d, 0x53, 0x68, 0x50, 0xf3, 0x86, 0x37, 0x63, 0xa7, 0xa8, 0x15, 0x3c, 0x5f, 0x52,
-	0x15, 0xb8, 0xea, 0xd6, 0xfc, 0xf3, 0x15, 0xd4, 0x9c, 0x1e, 0xb2, 0x69, 0xd4, 0xe6, 0x5b, 0x83,
-	0xf4, 0xb0, 0x33, 0xd6, 0xeb, 0xcc, 0x89, 0xba, 0x2a, 0x60, 0xa1, 0xa8, 0xcd, 0x80, 0x8d, 0xa2,
-	0x97, 0x90, 0xf3, 0x2d, 0xb3, 0x3f, 0x75, 0x0d, 0x73, 0x29, 0x35, 0x22, 0x75, 0xc2, 0x51, 0x41,
-	0xa5, 0xac, 0x3f, 0x35, 0x51, 0xf5, 0xb7, 0x24, 0xa0, 0x68, 0x3f, 0xa2, 0x7d, 0x48, 0xfa, 0x23,
-	0x0f, 0xf3, 0xb1, 0x94, 0x2f, 0x7f, 0x7a, 0x65, 0x0b, 0x9f, 0x8c, 0x3c, 0xac, 0x71, 0x38, 0x7a,
-	0x05, 0x37, 0xc4, 0x28, 0xd2, 0xa7, 0x13, 0xb2, 0xd8, 0x91, 0x83, 0x20, 0x32, 0xda, 0x26, 0x10,
-	0x4d, 0x11, 0xac, 0xa9, 0x05, 0x7d, 0x0e, 0x71, 0xab, 0x23, 0x07, 0xda, 0x95, 0x33, 0x24, 0x6e,
-	0x75, 0xd0, 0x0e, 0x24, 0x0d, 0xd2, 0xdb, 0x91, 0x43, 0xeb, 0x6e, 0x04, 0x7e, 0x1a, 0xc0, 0x73,
-	0xa4, 0x64, 0x3c, 0x91, 0x43, 0x6a, 0x31, 0xe3, 0x89, 0x64, 0x94, 0x8b, 0xd9, 0x25, 0x19, 0x65,
-	0xc9, 0xd8, 0x2d, 0xe6, 0x96, 0x64, 0xec, 0x4a, 0xc6, 0x5e, 0x31, 0xbf, 0x24, 0x63, 0x4f, 0x32,
-	0xf6, 0x8b, 0x85, 0x25, 0x19, 0xfb, 0xe8, 0x0b, 0x48, 0x10, 0xec, 0xcb, 0x09, 0x7b, 0x65, 0x66,
-	0x19, 0x4e, 0xfd, 0x37, 0x0e, 0x28, 0x3a, 0x63, 0x16, 0xf6, 0x47, 0x90, 0x72, 0x2d, 0xfd, 0x51,
-	0x81, 0x1c, 0x7e, 0x87, 0x4d, 0x76, 0xf3, 0x61, 0x36, 0xa1, 0xe7, 0xd6, 0xa5, 0xed, 0x13, 0xcb,
-	0xe9, 0x89, 0x88, 0xb2, 0x8c, 0x52, 0x97, 0x0c, 0xd4, 0x82, 0x8f, 0x43, 0x12, 0xba, 0x67, 0xf8,
-	0x3e, 0x26, 0xce, 0xdc, 0x82, 0x05, 0xa5, 0x3e, 0x0a, 0x4a, 0xb5, 0x04, 0x11, 0x1d, 0x40, 0x1a,
-	0xbf, 0xb3, 0x7c, 0xdd, 0x74, 0x3b, 0x58, 0x16, 0xf1, 0xbd, 0x19, 0xde, 0x2d, 0x0b, 0x91, 0x14,
-	0x43, 0x57, 0xdd, 0x0e, 0x56, 0x7f, 0x4f, 0x40, 0x61, 0x66, 0x02, 0xa3, 0x72, 0x28, 0xc7, 0x1b,
-	0xf3, 0x27, 0xf6, 0xb5, 0x24, 0xf8, 0x00, 0x52, 0x93, 0xdc, 0xc2, 0x12, 0x09, 0x99, 0xa0, 0xd1,
-	0x4b, 0x50, 0x22, 0x29, 0xcd, 0x2c, 0xa1, 0x50, 0xe8, 0xce, 0xa4, 0xb3, 0x0a, 0x05, 0xd7, 0xc3,
-	0x8e, 0xde, 0xb5, 0x8d, 0x1e, 0xd5, 0x07, 0x06, 0xed, 0xcb, 0x2a, 0x5f, 0x99, 0xd4, 0x1c, 0xe3,
-	0xd4, 0x19, 0xe5, 0xc8, 0xa0, 0x7d, 0x54, 0x03, 0xc5, 0x24, 0xd8, 0xf0, 0xb1, 0x3e, 0x70, 0x3b,
-	0x58, 0xa8, 0xe4, 0x16, 0xab, 0xe4, 0x05, 0xe9, 0xc8, 0xed, 0x60, 0x26, 0xa3, 0xfe, 0x1d, 0x87,
-	0xe2, 0xbc, 0xdb, 0x0d, 0x3d, 0x0b, 0x55, 0xea, 0xf1, 0x12, 0xd7, 0xe2, 0x6c, 0xdd, 0x6e, 0xc1,
-	0x1a, 0x1d, 0x0d, 0xce, 0x5d, 0x9b, 0xe7, 0x3a, 0xad, 0xc9, 0x15, 0x3a, 0x83, 0xb4, 0x41, 0x7a,
-	0xc3, 0x01, 0x9f, 0xf1, 0x19, 0x3e, 0xe3, 0x0f, 0x96, 0xbe, 0x75, 0x4b, 0x95, 0x31, 0xb5, 0xe6,
-	0xf8, 0x64, 0xa4, 0x4d, 0xa5, 0x3e, 0x5c, 0x9f, 0xac, 0x7f, 0x03, 0xf9, 0xf0, 0xcf, 0xb0, 0xe7,
-	0x57, 0x1f, 0x8f, 0x78, 0x32, 0xd2, 0x1a, 0xfb, 0x64, 0xcf, 0xaf, 0x0b, 0x96, 0x55, 0x3e, 0xcf,
-	0xd3, 0x9a, 0x58, 0x7c, 0x1d, 0x3f, 0x88, 0xa9, 0xbf, 0xc6, 0x00, 0x45, 0xef, 0xf8, 0x85, 0xe3,
-	0x25, 0x48, 0xb9, 0x8e, 0xee, 0x57, 0x6d, 0xb8, 0x3d, 0xfb, 0x54, 0xa8, 0xba, 0x43, 0x87, 0xf9,
-	0xf6, 0x55, 0xc8, 0xb7, 0xad, 0x85, 0x4f, 0x8c, 0x70, 0x95, 0x4d, 0xd7, 0xe9, 0x5a, 0x3d, 0x9e,
-	0x88, 0xa4, 0x26, 0x57, 0xea, 0x7f, 0x31, 0xb8, 0xf5, 0xfe, 0x97, 0x09, 0x7a, 0x06, 0x6b, 0xa1,
-	0xc7, 0xc7, 0x83, 0x85, 0xbf, 0x27, 0xfd, 0xd4, 0x24, 0x0f, 0x35, 0x40, 0xa1, 0xc6, 0xc0, 0xb3,
-	0xb1, 0x4e, 0xd8, 0x29, 0xe0, 0xbe, 0x67, 0xb8, 0xef, 0xf7, 0xa2, 0xd7, 0x3a, 0x07, 0x6a, 0x86,
-	0x8f, 0xb9, 0xd7, 0x79, 0x1a, 0x5a, 0xa3, 0x22, 0xac, 0x79, 0x98, 0x58, 0x6e, 0x87, 0x9f, 0xc3,
-	0xe4, 0xab, 0x15, 0x4d, 0xae, 0xd1, 0x06, 0xa4, 0xbb, 0x04, 0xff, 0x3c, 0xc4, 0x8e, 0x39, 0xe2,
-	0xc7, 0x8b, 0x6d, 0x4e, 0x4d, 0xcf, 0x73, 0x90, 0x09, 0x38, 0xa1, 0xfe, 0x15, 0x83, 0x9b, 0xef,
-	0x7b, 0x34, 0xa1, 0xa7, 0xa1, 0xe4, 0xde, 0x5f, 0xf0, 0xd2, 0x0a, 0xa4, 0xf6, 0x29, 0x24, 0x2f,
-	0x2c, 0x7c, 0xc9, 0x13, 0xbb, 0x98, 0x78, 0x66, 0xe1, 0x4b, 0x8d, 0x13, 0x3e, 0x60, 0xcf, 0x3c,
-	0x06, 0x14, 0x7d, 0xb8, 0xb1, 0x9a, 0xdb, 0xd8, 0xe9, 0xf9, 0x6f, 0x79, 0x4c, 0x49, 0x4d, 0xae,
-	0xd4, 0x6d, 0xb8, 0x11, 0x79, 0x9b, 0xa1, 0x75, 0x48, 0x59, 0xac, 0x78, 0x17, 0x86, 0xcd, 0xe1,
-	0x09, 0x6d, 0xb2, 0x56, 0x7f, 0x81, 0xd4, 0xf8, 0xef, 0x0f, 0xfa, 0x16, 0x52, 0xfe, 0x5b, 0xe2,
-	0xfa, 0xbe, 0x8d, 0xe5, 0x3f, 0xc7, 0xe8, 0x19, 0x39, 0x91, 0x80, 0xe9, 0x7f, 0xa6, 0x31, 0x05,
-	0xed, 0xc1, 0xaa, 0x6d, 0x0d, 0x2c, 0x5f, 0xbe, 0xaf, 0xa2, 0x57, 0xcb, 0x21, 0xdb, 0x9d, 0x10,
-	0x05, 0x58, 0xfd, 0x33, 0x06, 0xca, 0xac, 0xe8, 0x55, 0x1e, 0xa3, 0x36, 0xe4, 0xc6, 0xdf, 0xa2,
-	0xed, 0x44, 0x71, 0x4a, 0x0b, 0x5d, 0x65, 0x83, 0x98, 0xd3, 0x78, 0x81, 0xb3, 0x56, 0x60, 0xa5,
-	0x56, 0x20, 0x1b, 0xdc, 0x45, 0x05, 0xc8, 0x1c, 0x35, 0x0e, 0x0f, 0x1b, 0xed, 0x5a, 0xf5, 0xb8,
-	0xf9, 0x42, 0x59, 0x41, 0x00, 0x6b, 0xf2, 0x3b, 0xc6, 0xbe, 0x8f, 0x1a, 0xcd, 0xd3, 0x93, 0x9a,
-	0x12, 0x47, 0x29, 0x48, 0xbe, 0x3a, 0x3e, 0xd5, 0x94, 0x84, 0xba, 0x05, 0xb9, 0x50, 0x80, 0x6c,
-	0x3e, 0x89, 0x7c, 0x88, 0x08, 0xc4, 0xe2, 0x51, 0x1f, 0xf2, 0xe1, 0xf3, 0x80, 0xee, 0x42, 0xb1,
-	0x5d, 0x39, 0x6a, 0x1d, 0xd6, 0x74, 0xad, 0x72, 0x52, 0xd3, 0x4f, 0xde, 0xb4, 0x6a, 0xfa, 0x69,
-	0xf3, 0x75, 0xf3, 0xf8, 0xa7, 0xa6, 0xb2, 0x82, 0xee, 0xc0, 0xed, 0xc8, 0x6e, 0xab, 0xa6, 0x35,
-	0x8e, 0x99, 0x27, 0x1b, 0xb0, 0x1e, 0xd9, 0xac, 0x6b, 0xb5, 0x1f, 0x4f, 0x6b, 0xcd, 0xea, 0x1b,
-	0x25, 0xfe, 0xe8, 0x21, 0xa0, 0x68, 0x8b, 0xa2, 0x34, 0xac, 0x3e, 0xaf, 0xb4, 0x1b, 0x55, 0x65,
-	0x85, 0xb9, 0x5f, 0x3f, 0x3d, 0x3c, 0x54, 0x62, 0xe7, 0x6b, 0xfc, 0xbe, 0xda, 0xfd, 0x3f, 0x00,
-	0x00, 0xff, 0xff, 0x90, 0x64, 0xb5, 0x26, 0xf2, 0x10, 0x00, 0x00,
+	// 2647 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5a, 0x5b, 0x6f, 0x1b, 0xc7,
+	0xf5, 0xf7, 0x92, 0x94, 0x4c, 0x1e, 0x5e, 0x35, 0x91, 0xed, 0x8d, 0x9c, 0xd8, 0x0a, 0x0d, 0xff,
+	0xa3, 0xf8, 0x9f, 0xca, 0xf7, 0xc4, 0x0d, 0x9a, 0x34, 0xb2, 0x4c, 0xc5, 0xaa, 0x75, 0xeb, 0x4a,
+	0xb2, 0x9b, 0xa2, 0xc0, 0x62, 0xb9, 0x1c, 0xd2, 0x5b, 0x2e, 0x77, 0xb7, 0x33, 0x43, 0x59, 0x7c,
+	0x0f, 0x8a, 0xbc, 0xf4, 0xa1, 0x28, 0x0a, 0xf4, 0xad, 0xcf, 0x7d, 0x28, 0x50, 0xf4, 0x13, 0x14,
+	0x28, 0xd0, 0xa7, 0x3e, 0x15, 0xfd, 0x00, 0x45, 0x3f, 0x49, 0x31, 0x97, 0xbd, 0x71, 0xb9, 0xa6,
+	0x10, 0x48, 0x05, 0xfa, 0xc6, 0x39, 0xe7, 0xfc, 0x7e, 0x3a, 0x67, 0xe6, 0xcc, 0x99, 0x33, 0xb3,
+	0x82, 0xb6, 0x6d, 0x05, 0x74, 0xec, 0xe2, 0x27, 0x77, 0xad, 0xc0, 0xb9, 0x7b, 0x72, 0xef, 0x2e,
+	0x1d, 0x77, 0xa9, 0x4d, 0x9c, 0x80, 0x39, 0xbe, 0xb7, 0x1e, 0x10, 0x9f, 0xf9, 0xa8, 0x19, 0xda,
+	0xac, 0x5b, 0x81, 0xb3, 0x7e, 0x72, 0x6f, 0xe5, 0xf6, 0x34, 0x88, 0x61, 0x17, 0x8f, 0x30, 0x23,
+	0x13, 0x13, 0x9f, 0x60, 0x8f, 0x49, 0xdc, 0xca, 0xea, 0xb4, 0x19, 0x3e, 0x0d, 0x08, 0xa6, 0x34,
+	0x62, 0x5e, 0xb9, 0x31, 0xf0, 0xfd, 0x81, 0x8b, 0xef, 0x8a, 0x51, 0x77, 0xdc, 0xbf, 0xfb, 0x86,
+	0x58, 0x41, 0x80, 0x09, 0x95, 0xfa, 0xf6, 0x37, 0x0b, 0x50, 0x3b, 0x4c, 0x38, 0x84, 0x7e, 0x08,
+	0x35, 0xf1, 0x17, 0xcc, 0xbe, 0xe3, 0x32, 0x4c, 0x74, 0x6d, 0x55, 0x5b, 0xab, 0x3e, 0x78, 0x6f,
+	0x7d, 0xca, 0xc3, 0xf5, 0x0e, 0x37, 0xda, 0x12, 0x36, 0x46, 0x15, 0xc7, 0x03, 0xf4, 0x02, 0x5a,
+	0xb6, 0xef, 0x31, 0xcb, 0xf1, 0x30, 0x09, 0x49, 0x0a, 0x82, 0x64, 0x35, 0x43, 0xb2, 0x19, 0x1a,
+	0x2a, 0xa2, 0xa6, 0x9d, 0x16, 0xa0, 0xa7, 0xd0, 0xa0, 0x8e, 0x67, 0x63, 0xb3, 0x37, 0x26, 0x16,
+	0xf7, 0x4f, 0x07, 0x41, 0x75, 0x7d, 0x5d, 0xc6, 0xb5, 0x1e, 0xc6, 0xb5, 0xbe, 0xed, 0xb1, 0x4f,
+	0x1e, 0xbd, 0xb4, 0xdc, 0x31, 0x36, 0xea, 0x02, 0xf2, 0x4c, 0x21, 0xd0, 0x17, 0x50, 0xeb, 0xfb,
+	0x24, 0x66, 0xa8, 0xce, 0x67, 0xa8, 0xf6, 0x7d, 0x12, 0xe1, 0x1f, 0x43, 0x79, 0xe4, 0xf7, 0x9c,
+	0xbe, 0x83, 0x89, 0xbe, 0x2c, 0xb0, 0xef, 0x66, 0x02, 0xd9, 0x55, 0x06, 0x46, 0x64, 0x8a, 0xee,
+	0xc0, 0x12, 0x71, 0xbc, 0x81, 0xd9, 0x1d, 0xf7, 0xfb, 0x98, 0x98, 0x81, 0x35, 0xc0, 0x54, 0xbf,
+	0xb2, 0xaa, 0xad, 0xd5, 0x8d, 0x26, 0x57, 0x3c, 0x15, 0xf2, 0x03, 0x2e, 0x46, 0xf7, 0x60, 0xd9,
+	0xb6, 0x02, 0x36, 0x26, 0xd8, 0xa4, 0xcc, 0xb2, 0x87, 0x26, 0x23, 0x96, 0x8d, 0xa9, 0x7e, 0x75,
+	0x55, 0x5b, 0x2b, 0x1b, 0x48, 0xe9, 0x0e, 0xb9, 0xea, 0x48, 0x68, 0xd0, 0x0d, 0x80, 0x78, 0xad,
+	0xf5, 0x6b, 0xab, 0xda, 0x5a, 0xc5, 0x48, 0x48, 0xd0, 0x7d, 0x58, 0xb6, 0x7d, 0x42, 0xb0, 0x6b,
+	0x31, 0x6c, 0x46, 0xb3, 0x4a, 0x75, 0x5d, 0x30, 0xbe, 0x13, 0xe9, 0xa2, 0x15, 0xa0, 0xe8, 0x26,
+	0x54, 0x19, 0x73, 0x4d, 0x8a, 0x6d, 0xdf, 0xeb, 0x51, 0xfd, 0x5d, 0xe1, 0x2a, 0x30, 0xe6, 0x1e,
+	0x4a, 0x09, 0x37, 0xe8, 0x61, 0xd7, 0x39, 0xc1, 0x64, 0x62, 0x3a, 0x3d, 0x7d, 0x45, 0xfe, 0xd1,
+	0x50, 0xb4, 0xdd, 0x43, 0x4f, 0x40, 0x0f, 0xc3, 0xc0, 0xa7, 0xd8, 0x36, 0xb1, 0x77, 0xe2, 0x10,
+	0xdf, 0x1b, 0x61, 0x8f, 0xe9, 0xd7, 0xc5, 0x1f, 0xbe, 0xaa, 0xf4, 0x9d, 0x53, 0x6c, 0x77, 0x62,
+	0x6d, 0xfb, 0x9b, 0x02, 0x34, 0xa7, 0x92, 0x01, 0xb5, 0xa0, 0xe8, 0xf4, 0xa8, 0xae, 0xad, 0x16,
+	0xd7, 0x2a, 0x06, 0xff, 0x89, 0x96, 0x61, 0xc1, 0xb3, 0x46, 0x98, 0xea, 0x05, 0x21, 0x93, 0x03,
+	0x74, 0x1d, 0x2a, 0xce, 0xc8, 0x1a, 0x60, 0x93, 0x5b, 0x17, 0x85, 0xa6, 0x2c, 0x04, 0xdb, 0xd2,
+	0x67, 0xa9, 0x94, 0xc0, 0x92, 0x50, 0x83, 0x10, 0xed, 0x09, 0xf4, 0x07, 0x50, 0xe3, 0x2a, 0x93,
+	0xe0, 0x01, 0x3e, 0x0d, 0xa8, 0xbe, 0x20, 0x2c, 0xaa, 0x5c, 0x66, 0x48, 0x11, 0xfa, 0x18, 0x50,
+	0xcc, 0x11, 0x19, 0x2e, 0x0a, 0xc3, 0x56, 0x44, 0x15, 0x5a, 0x7f, 0x06, 0x97, 0xf1, 0xa9, 0xed,
+	0x8e, 0x7b, 0x58, 0xbf, 0x7c, 0xc6, 0xb4, 0x0f, 0x01, 0xed, 0x7f, 0x55, 0xa1, 0x9a, 0xd8, 0x58,
+	0xe8, 0x47, 0xd0, 0xa0, 0x13, 0x6a, 0x5b, 0xae, 0x2b, 0xb7, 0xbd, 0x9c, 0x8d, 0xea, 0x83, 0x5b,
+	0x19, 0xca, 0x43, 0x69, 0x96, 0xdc, 0x95, 0x75, 0x9a, 0x90, 0x51, 0xce, 0x15, 0x10, 0xdf, 0xc6,
+	0x94, 0x86, 0x5c, 0x85, 0x1c, 0xae, 0x03, 0x69, 0x96, 0xe2, 0x0a, 0x12, 0x32, 0x8a, 0x36, 0xa0,
+	0xda, 0x77, 0x5c, 0x1c, 0x12, 0x15, 0x05, 0x51, 0x36, 0xce, 0x2d, 0xc7, 0xc5, 0x49, 0x16, 0xe8,
+	0x87, 0x02, 0x8a, 0xf6, 0xa0, 0x3e, 0xc4, 0xc4, 0xc3, 0x51, 0x64, 0x25, 0x41, 0xf2, 0x51, 0x86,
+	0xe4, 0x85, 0xb0, 0xda, 0x1a, 0x7b, 0x36, 0xdf, 0x8d, 0x9b, 0x96, 0xeb, 0x2a, 0xb6, 0x9a, 0xc4,
+	0xc7, 0xe1, 0x79, 0x98, 0xbd, 0xf1, 0xc9, 0x30, 0x24, 0x5c, 0xc8, 0x09, 0x6f, 0x4f, 0x9a, 0xa5,
+	0xc2, 0xf3, 0x12, 0x32, 0x8a, 0x5e, 0x02, 0x0a, 0x30, 0xe9, 0xfb, 0x64, 0x64, 0xf1, 0xda, 0xa3,
+	0xf8, 0x16, 0x05, 0xdf, 0x87, 0xd9, 0xe9, 0x8a, 0x4d, 0x93, 0x9c, 0x4b, 0xc1, 0x94, 0x9c, 0xa2,
+	0x9f, 0xc2, 0xb2, 0x8a, 0x79, 0xe4, 0xf7, 0xc6, 0xf1, 0xfc, 0x5d, 0x16, 0xcc, 0x6b, 0x39, 0xa1,
+	0xef, 0x0a, 0xdb, 0x24, 0x35, 0x1a, 0x4e, 0x2b, 0x28, 0x7a, 0x06, 0xb5, 0x91, 0x3f, 0xf6, 0x58,
+	0xc8, 0x59, 0x16, 0x9c, 0x1f, 0xcc, 0xa8, 0x54, 0x63, 0x8f, 0xa5, 0x8a, 0xf7, 0x28, 0x92, 0x50,
+	0xf4, 0x15, 0xd4, 0x47, 0x78, 0xe4, 0x87, 0xc7, 0x0c, 0xd5, 0x2b, 0x82, 0xa6, 0x9d, 0xa5, 0x11,
+	0x56, 0x49, 0x9e, 0xda, 0x28, 0x16, 0x09, 0x22, 0xea, 0x0c, 0x3c, 0x2b, 0x5a, 0xde, 0x5a, 0x0e,
+	0xd1, 0xa1, 0xb0, 0x4a, 0x11, 0xd1, 0x58, 0x44, 0xd1, 0x17, 0x00, 0x2e, 0x1d, 0x85, 0x2c, 0x75,
+	0xc1, 0x72, 0x33, 0xc3, 0xb2, 0x43, 0x47, 0x49, 0x8a, 0x8a, 0xab, 0xc6, 0x02, 0xcf, 0x58, 0x14,
+	0x4e, 0x23, 0x07, 0x7f, 0xc4, 0x52, 0xb1, 0x54, 0x18, 0x0b, 0x03, 0x79, 0x01, 0x4d, 0xc7, 0x37,
+	0xc7, 0xa2, 0x94, 0x2b, 0x92, 0x56, 0x4e, 0x62, 0x6d, 0xfb, 0xc7, 0xdc, 0x2c, 0x95, 0x58, 0x4e,
+	0x42, 0x26, 0x9c, 0xe9, 0x06, 0xfd, 0x90, 0x67, 0x29, 0xc7, 0x99, 0xa7, 0x41, 0x3f, 0xe5, 0x4c,
+	0x57, 0x8d, 0x29, 0x7a, 0x0e, 0xd5, 0x31, 0xc5, 0x24, 0x24, 0x40, 0x39, 0x19, 0x79, 0x4c, 0x31,
+	0x99, 0xb1, 0x61, 0x80, 0x63, 0x15, 0xd3, 0x41, 0xf2, 0x94, 0x56, 0x74, 0x20, 0xe8, 0x6e, 0xe7,
+	0x97, 0xab, 0xa4, 0x57, 0xf1, 0x51, 0x1d, 0x27, 0xa0, 0xac, 0x92, 0x8a, 0xad, 0x9a, 0x93, 0x80,
+	0xdb, 0xdc, 0x28, 0x95, 0x80, 0x4e, 0x24, 0x11, 0xdb, 0x98, 0xca, 0x23, 0x2c, 0xe4, 0x69, 0xe6,
+	0x55, 0x3c, 0x69, 0x96, 0xae, 0x78, 0x09, 0x99, 0xe0, 0xb2, 0x5f, 0x5b, 0x64, 0x80, 0x23, 0xae,
+	0x5e, 0x0e, 0xd7, 0xa6, 0x34, 0x4b, 0x71, 0xd9, 0x09, 0x99, 0xc8, 0x67, 0xe6, 0xd8, 0xc3, 0x78,
+	0xb2, 0x70, 0x4e, 0x3e, 0x1f, 0x09, 0xab, 0x54, 0x3e, 0xb3, 0x58, 0x44, 0xdb, 0x7f, 0x2f, 0x01,
+	0xca, 0x16, 0x6b, 0xf4, 0x18, 0x4a, 0x6c, 0x12, 0x60, 0xd1, 0x6e, 0x35, 0x66, 0xcc, 0x5a, 0x12,
+	0x72, 0x34, 0x09, 0xb0, 0x21, 0xcc, 0xc3, 0x33, 0x92, 0x17, 0xe0, 0xa2, 0x3c, 0x23, 0xaf, 0x43,
+	0xc5, 0x22, 0x03, 0xd3, 0xe6, 0x9b, 0x5a, 0x2f, 0x89, 0x33, 0xbc, 0x6c, 0x91, 0xc1, 0x26, 0x1f,
+	0xa3, 0xe7, 0xb0, 0x24, 0x3b, 0x32, 0x33, 0xd1, 0x3c, 0xf4, 0x54, 0x3f, 0x94, 0xe9, 0xf0, 0x22,
+	0x13, 0xa3, 0x25, 0x51, 0xb1, 0x04, 0xfd, 0x3f, 0x14, 0x9c, 0x9e, 0xea, 0xeb, 0xde, 0xda, 0x4a,
+	0x15, 0x9c, 0x1e, 0xba, 0x07, 0x25, 0x8b, 0x0c, 0xee, 0xa9, 0xde, 0xed, 0xbd, 0x8c, 0xf9, 0x71,
+	0xc2, 0x5e, 0x58, 0x2a, 0xc4, 0x7d, 0xd5, 0xab, 0xcd, 0x47, 0xdc, 0x57, 0x88, 0x07, 0x7a, 0xed,
+	0x8c, 0x88, 0x07, 0x0a, 0xf1, 0x50, 0xaf, 0x9f, 0x11, 0xf1, 0x50, 0x21, 0x1e, 0xe9, 0x8d, 0x33,
+	0x22, 0x1e, 0x29, 0xc4, 0x63, 0xbd, 0x79, 0x46, 0xc4, 0x63, 0xf4, 0x3d, 0x28, 0x12, 0xcc, 0x54,
+	0xa3, 0xf9, 0xd6, 0x99, 0xe5, 0x76, 0xed, 0x6f, 0x4b, 0x80, 0xb2, 0xe7, 0xf5, 0xdc, 0x74, 0x4a,
+	0x42, 0x12, 0xe9, 0xf4, 0x21, 0xf0, 0x9b, 0x88, 0xd5, 0x75, 0x5c, 0x87, 0x4d, 0xcc, 0x91, 0x45,
+	0x87, 0x62, 0x89, 0x4b, 0x46, 0x23, 0x16, 0xef, 0x5a, 0x74, 0x88, 0x1e, 0xc1, 0x55, 0xd5, 0xb3,
+	0xc8, 0x4e, 0x8f, 0x1f, 0xec, 0xb2, 0xc3, 0x92, 0x0d, 0xd8, 0xb2, 0xd2, 0xf2, 0x3e, 0x6f, 0x2b,
+	0xd4, 0xa1, 0x4d, 0xb8, 0x31, 0x13, 0x65, 0x06, 0x16, 0x63, 0x98, 0x78, 0x61, 0x7f, 0x76, 0x7d,
+	0x06, 0xfa, 0x40, 0x99, 0x9c, 0x63, 0x0e, 0x6f, 0x40, 0x3d, 0xe5, 0x46, 0x6e, 0xee, 0x1c, 0x32,
+	0x5e, 0xc3, 0xe5, 0xac, 0xd7, 0x70, 0xc2, 0x29, 0x74, 0x00, 0x57, 0x66, 0x46, 0x92, 0x9b, 0x54,
+	0x49, 0xaa, 0x77, 0x70, 0x36, 0x3e, 0xf4, 0x04, 0x2a, 0xf8, 0xd4, 0x61, 0xa6, 0xed, 0xf7, 0xb0,
+	0x4a, 0xb4, 0x99, 0x59, 0xf0, 0xf0, 0x81, 0x24, 0x29, 0x73, 0xeb, 0x4d, 0xbf, 0x87, 0xdb, 0xff,
+	0x2e, 0x42, 0x73, 0xaa, 0xe3, 0x42, 0x0f, 0x52, 0x79, 0x70, 0x23, 0xbf, 0x43, 0x4b, 0x24, 0xc1,
+	0x2d, 0xa8, 0x07, 0x16, 0x7b, 0x6d, 0x06, 0x04, 0xf7, 0x9d, 0xd3, 0xa8, 0xdb, 0xae, 0x71, 0xe1,
+	0x81, 0x92, 0xa1, 0xf7, 0x01, 0x84, 0xd1, 0xc0, 0xf5, 0xbb, 0xe1, 0xa2, 0x57, 0xb8, 0xe4, 0x2b,
+	0x2e, 0x38, 0xc7, 0x45, 0x7a, 0x02, 0xe5, 0x68, 0x7d, 0xe0, 0x0c, 0x93, 0x1a, 0x59, 0xa3, 0xaf,
+	0xa0, 0x95, 0x59, 0x96, 0xea, 0x19, 0x18, 0x9a, 0xfd, 0xa9, 0x25, 0xd9, 0x84, 0xa6, 0x1f, 0x60,
+	0xcf, 0xec, 0xbb, 0xd6, 0x80, 0xca, 0x5d, 0x51, 0x9b, 0xbf, 0x30, 0x75, 0x8e, 0xd9, 0xe2, 0x10,
+	0xb1, 0x63, 0x3a, 0xd0, 0xb2, 0x09, 0xe6, 0xb7, 0xb1, 0x91, 0xdf, 0xc3, 0x92, 0xa5, 0x3e, 0x9f,
+	0xa5, 0x21, 0x41, 0xbb, 0x7e, 0x0f, 0x73, 0x9a, 0xf6, 0xaf, 0x34, 0x68, 0xa4, 0xfb, 0x03, 0x74,
+	0x3f, 0xb5, 0xc6, 0xef, 0xe7, 0xb6, 0x13, 0x89, 0x25, 0x3e, 0xb7, 0xe5, 0x69, 0xff, 0x56, 0x03,
+	0x94, 0xed, 0x7b, 0xe6, 0xd6, 0x9f, 0x24, 0xe4, 0x42, 0xfc, 0xfa, 0x65, 0x11, 0xae, 0xce, 0x6e,
+	0x83, 0xd0, 0x17, 0x29, 0xdf, 0xee, 0xcc, 0xed, 0x9e, 0xa6, 0x9d, 0x14, 0x57, 0x6f, 0x6c, 0x8f,
+	0x99, 0xd5, 0x75, 0x65, 0x4e, 0x8a, 0xab, 0x77, 0x28, 0x41, 0x57, 0x61, 0x91, 0x4e, 0x46, 0x5d,
+	0xdf, 0x15, 0xd9, 0x56, 0x31, 0xd4, 0x88, 0xcb, 0xfd, 0x7e, 0x9f, 0x62, 0x26, 0xb2, 0xa7, 0x64,
+	0xa8, 0x11, 0x3a, 0x12, 0x27, 0xf6, 0x78, 0x94, 0x68, 0x70, 0x3f, 0x39, 0x63, 0x4b, 0xb7, 0xbe,
+	0x11, 0x02, 0x3b, 0x1e, 0x23, 0x13, 0x23, 0x26, 0x3a, 0xbf, 0xa9, 0x5c, 0xf9, 0x01, 0x34, 0xd2,
+	0x7f, 0x86, 0x77, 0x1d, 0x43, 0x3c, 0x11, 0x13, 0x58, 0x31, 0xf8, 0x4f, 0x7e, 0x33, 0x3f, 0xe1,
+	0xf9, 0x2a, 0x8e, 0x8b, 0x8a, 0x21, 0x07, 0x9f, 0x15, 0x9e, 0x68, 0xed, 0xdf, 0x6b, 0x70, 0x2d,
+	0xe7, 0x1e, 0x83, 0x3e, 0x4b, 0xad, 0xc4, 0xff, 0xcd, 0xbf, 0xff, 0x5c, 0x48, 0xaa, 0xf0, 0x2d,
+	0x95, 0xbe, 0x3f, 0xcc, 0xdd, 0x52, 0xa1, 0xf9, 0x85, 0xf8, 0xf3, 0x1b, 0x0d, 0x96, 0x32, 0xd7,
+	0x2b, 0xf4, 0x28, 0xe5, 0xd2, 0xea, 0xdb, 0x2e, 0x64, 0x17, 0xe2, 0xd5, 0xaf, 0x35, 0x68, 0x4d,
+	0xdf, 0x1d, 0xd1, 0xc3, 0x94, 0x53, 0x37, 0xdf, 0x72, 0xd9, 0xbc, 0xb0, 0xe2, 0x93, 0xbd, 0x06,
+	0xcc, 0xef, 0xa5, 0x13, 0x90, 0x0b, 0xf1, 0xeb, 0x8f, 0x1a, 0x2c, 0x65, 0xee, 0xb5, 0x73, 0x57,
+	0x30, 0x81, 0x48, 0x78, 0xa5, 0xc3, 0x65, 0x79, 0x1f, 0x96, 0xe7, 0xf0, 0x92, 0x11, 0x0e, 0xcf,
+	0xd1, 0xdf, 0x3f, 0x69, 0xd0, 0x48, 0xdf, 0x80, 0xe7, 0xee, 0x80, 0xd0, 0x3c, 0xe1, 0xe9, 0x07,
+	0x50, 0x73, 0x3c, 0xd9, 0xdd, 0xf5, 0x2c, 0x66, 0x89, 0x52, 0x50, 0x36, 0xaa, 0x4a, 0xf6, 0xcc,
+	0x62, 0xd6, 0x39, 0xba, 0xfc, 0xcf, 0x02, 0xe8, 0x79, 0x2f, 0x43, 0xe8, 0xcb, 0x94, 0xf3, 0x1f,
+	0x9f, 0xe1, 0x49, 0x69, 0x3a, 0x96, 0xb8, 0x86, 0x43, 0xaa, 0x86, 0xbf, 0x4c, 0xd6, 0x6a, 0x79,
+	0xc3, 0x7d, 0x72, 0xe6, 0x17, 0xab, 0xff, 0x81, 0x6a, 0xcd, 0x77, 0x54, 0xf6, 0x7d, 0x6c, 0xee,
+	0x8e, 0x4a, 0x42, 0x2e, 0x64, 0x47, 0xb9, 0x70, 0x6d, 0xfa, 0x99, 0x4d, 0xdc, 0x68, 0x31, 0x41,
+	0xdf, 0x4f, 0xf9, 0x76, 0x7b, 0xee, 0xf3, 0x5c, 0x7a, 0x95, 0x6d, 0xdf, 0xeb, 0x3b, 0x03, 0x75,
+	0xcb, 0x51, 0xa3, 0xf6, 0xb7, 0x05, 0xb8, 0x3a, 0xfb, 0x55, 0x0f, 0x7d, 0x09, 0x8b, 0xa9, 0xd7,
+	0x92, 0xb5, 0xb9, 0x7f, 0x4f, 0xf9, 0x69, 0x28, 0x1c, 0xda, 0x86, 0x16, 0xb5, 0x46, 0x81, 0x8b,
+	0x4d, 0xc2, 0xbb, 0x41, 0xe1, 0x7b, 0x35, 0xa7, 0x7e, 0x1e, 0x0a, 0x43, 0xc3, 0x62, 0x58, 0x78,
+	0xdd, 0xa0, 0xa9, 0x31, 0xd2, 0x61, 0x31, 0xc0, 0xc4, 0xf1, 0x7b, 0xb2, 0xa3, 0x78, 0x7e, 0xc9,
+	0x50, 0x63, 0x74, 0x03, 0x2a, 0x7d, 0x82, 0x7f, 0x31, 0xc6, 0x9e, 0x3d, 0x11, 0x6d, 0x26, 0x57,
+	0xc6, 0x22, 0x5e, 0x55, 0xec, 0x01, 0xf1, 0xc7, 0x81, 0x7c, 0x12, 0xab, 0x18, 0xe1, 0xf0, 0x69,
+	0x1d, 0xaa, 0x09, 0xf7, 0xda, 0xff, 0xd0, 0x60, 0x79, 0xd6, 0xfb, 0x0f, 0xfa, 0x34, 0x35, 0xed,
+	0xb7, 0xe6, 0x3c, 0x1a, 0x25, 0x26, 0xfd, 0x53, 0x28, 0x9d, 0x38, 0xf8, 0x8d, 0x98, 0xf2, 0xf9,
+	0xc0, 0x97, 0x0e, 0x7e, 0x63, 0x08, 0xc0, 0x39, 0x9f, 0x65, 0xd3, 0xcf, 0x50, 0x73, 0xcf, 0xb2,
+	0x18, 0x70, 0x21, 0x19, 0xfe, 0x31, 0xa0, 0xec, 0x2b, 0x14, 0xcf, 0x50, 0x17, 0x7b, 0x03, 0xf6,
+	0x5a, 0xb8, 0x55, 0x32, 0xd4, 0xa8, 0x7d, 0x17, 0x96, 0x32, 0x0f, 0x4d, 0x68, 0x05, 0xca, 0x0e,
+	0x4f, 0xb5, 0x13, 0xcb, 0x15, 0xe6, 0x45, 0x23, 0x1a, 0xb7, 0xff, 0x52, 0x82, 0x72, 0xf8, 0x91,
+	0x0a, 0x7d, 0x0e, 0x65, 0xf6, 0x9a, 0xf8, 0x8c, 0xb9, 0x58, 0x7d, 0xdf, 0xcb, 0x6e, 0xe9, 0x23,
+	0x65, 0x10, 0x7f, 0xd9, 0x0a, 0x21, 0xe8, 0x11, 0x2c, 0xb8, 0xce, 0xc8, 0x61, 0xea, 0xf9, 0x27,
+	0x7b, 0xab, 0xdc, 0xe1, 0xda, 0x08, 0x28, 0x8d, 0xd1, 0x06, 0x80, 0x48, 0x78, 0x09, 0x2d, 0x0a,
+	0x68, 0xf6, 0xf9, 0x8c, 0xe7, 0x76, 0x1a, 0x5e, 0x21, 0xa1, 0x08, 0x7d, 0x0a, 0x8b, 0x32, 0x37,
+	0xc5, 0xc3, 0x56, 0x35, 0x77, 0xc3, 0x44, 0x58, 0x65, 0x8e, 0x76, 0xa1, 0x31, 0xc4, 0x13, 0xdc,
+	0x33, 0xa3, 0xb0, 0x17, 0x04, 0xc1, 0xac, 0x96, 0x73, 0x82, 0x7b, 0x99, 0xd8, 0xeb, 0xc3, 0xa4,
+	0x18, 0x7d, 0x09, 0x15, 0x6b, 0x30, 0x20, 0x78, 0x60, 0x31, 0xac, 0x2f, 0xe6, 0x44, 0xb2, 0x11,
+	0x5a, 0xc4, 0x91, 0x44, 0x20, 0xb4, 0x09, 0x10, 0x10, 0xff, 0xe7, 0x58, 0x9c, 0x10, 0xea, 0x3b,
+	0xd1, 0xcc, 0x0f, 0x31, 0xca, 0x24, 0xe2, 0x48, 0xc0, 0xf8, 0x74, 0xc8, 0x8f, 0x8b, 0x7a, 0x39,
+	0x67, 0x3a, 0xe4, 0x37, 0xc6, 0x78, 0x3a, 0xa4, 0x39, 0x5f, 0xc0, 0xae, 0xc5, 0xec, 0xd7, 0x7a,
+	0x25, 0x67, 0x01, 0x9f, 0x72, 0x6d, 0xbc, 0x80, 0xc2, 0xb8, 0xfd, 0x07, 0x0d, 0xea, 0x29, 0x05,
+	0x7a, 0x1f, 0x60, 0x64, 0x9d, 0xc6, 0x9f, 0xa6, 0xb4, 0xb5, 0xba, 0x51, 0x19, 0x59, 0xa7, 0xea,
+	0xcd, 0xf4, 0x26, 0x54, 0xb9, 0xda, 0xb5, 0x98, 0x28, 0x43, 0x05, 0x91, 0x92, 0x1c, 0xb1, 0x23,
+	0x25, 0xe8, 0x27, 0xd0, 0x4a, 0x18, 0xc8, 0x52, 0x58, 0x14, 0xdb, 0x6f, 0x7d, 0x6e, 0x3e, 0xf2,
+	0x4b, 0xb2, 0x48, 0x6d, 0x59, 0x19, 0x63, 0x56, 0x3e, 0x6e, 0xff, 0xb5, 0x00, 0x8d, 0x74, 0xf0,
+	0x53, 0x5b, 0xa9, 0x1e, 0x6e, 0x25, 0xf4, 0x0a, 0x9a, 0xfe, 0x09, 0x26, 0x7d, 0xd7, 0x7f, 0x63,
+	0x06, 0xbe, 0xeb, 0x28, 0x4f, 0x67, 0xf9, 0x90, 0x66, 0x5c, 0xdf, 0x57, 0xb0, 0x03, 0x81, 0x32,
+	0x1a, 0x7e, 0x6a, 0x8c, 0x6e, 0x41, 0xbd, 0xeb, 0xfa, 0xf6, 0xd0, 0x64, 0xce, 0x08, 0xfb, 0x63,
+	0x99, 0xf3, 0x45, 0xa3, 0x26, 0x84, 0x47, 0x52, 0x86, 0x7e, 0x06, 0x28, 0x65, 0x24, 0x27, 0xa1,
+	0xf4, 0x9d, 0x26, 0xa1, 0x95, 0x64, 0x16, 0xd3, 0xf0, 0x39, 0x34, 0xd2, 0x4e, 0xa2, 0x26, 0x54,
+	0x9f, 0x19, 0xfb, 0x07, 0xe6, 0x5e, 0xe7, 0x55, 0xe7, 0xf0, 0xa8, 0x75, 0x29, 0x12, 0xec, 0xef,
+	0x3c, 0xe3, 0x02, 0x0d, 0x55, 0x60, 0xe1, 0xe9, 0xce, 0xfe, 0xe6, 0x8b, 0x56, 0xa1, 0xfd, 0x5c,
+	0xbc, 0x2d, 0x4e, 0xa5, 0x20, 0x3f, 0x3b, 0x54, 0x4f, 0xa7, 0xbe, 0xcd, 0x86, 0x43, 0xae, 0x09,
+	0x3f, 0x7d, 0xca, 0x37, 0xa3, 0xe8, 0xc3, 0xe6, 0xef, 0x34, 0x58, 0xca, 0x6c, 0x88, 0xb7, 0x15,
+	0x2c, 0x74, 0x08, 0xf5, 0xf0, 0xb7, 0x9c, 0x93, 0xc2, 0x77, 0x9a, 0x93, 0x9a, 0x93, 0x18, 0x21,
+	0x04, 0xa5, 0x21, 0x9e, 0x84, 0xef, 0x55, 0xe2, 0x77, 0xfb, 0x6f, 0x1a, 0xb4, 0xa6, 0x39, 0xfe,
+	0xeb, 0x9e, 0xb5, 0x37, 0xa0, 0x96, 0xd4, 0xf2, 0x65, 0xd9, 0xdd, 0xde, 0xd9, 0xd9, 0x3e, 0xec,
+	0x6c, 0xee, 0xef, 0x3d, 0x6b, 0x5d, 0x42, 0x00, 0x8b, 0xea, 0xb7, 0xc6, 0x7f, 0xef, 0x6e, 0xef,
+	0x1d, 0x1f, 0x75, 0x5a, 0x05, 0x54, 0x86, 0xd2, 0xf3, 0xfd, 0x63, 0xa3, 0x55, 0x6c, 0xff, 0x59,
+	0x83, 0x2b, 0x33, 0xcb, 0x57, 0x14, 0xb6, 0x16, 0x87, 0xcd, 0x7b, 0xc0, 0xb8, 0x88, 0x97, 0xc2,
+	0x22, 0x9d, 0x8c, 0xbb, 0x38, 0x2f, 0xee, 0xd2, 0x39, 0xc4, 0x7d, 0x1b, 0xea, 0xa9, 0x72, 0x1f,
+	0xfb, 0x25, 0xa7, 0x5d, 0x0e, 0xda, 0xc7, 0xb0, 0x94, 0x39, 0x19, 0xd0, 0x1d, 0x58, 0x92, 0xa5,
+	0xc7, 0x0c, 0x30, 0x51, 0xff, 0xb7, 0x20, 0x60, 0x9a, 0xd1, 0x94, 0x8a, 0x03, 0x4c, 0xe4, 0x3f,
+	0x2f, 0x70, 0xda, 0xee, 0x98, 0x50, 0x19, 0x6e, 0xdd, 0x90, 0x83, 0xf6, 0x87, 0xd0, 0x48, 0x9f,
+	0x18, 0xe8, 0x0a, 0x2c, 0xfa, 0x1e, 0x36, 0x1d, 0x4f, 0x55, 0x89, 0x05, 0xdf, 0xc3, 0xdb, 0xde,
+	0x9d, 0x61, 0x68, 0x18, 0xf5, 0x5e, 0xef, 0x81, 0x7e, 0xb8, 0xb1, 0x7b, 0xb0, 0xd3, 0x31, 0x8d,
+	0x8d, 0xa3, 0x8e, 0x79, 0xf4, 0xf5, 0x41, 0xc7, 0x3c, 0xde, 0x7b, 0xb1, 0xb7, 0xff, 0x6a, 0xaf,
+	0x75, 0x09, 0x5d, 0x87, 0x6b, 0x19, 0xed, 0x41, 0xc7, 0xd8, 0xde, 0xe7, 0xcb, 0x77, 0x03, 0x56,
+	0x32, 0xca, 0x2d, 0xa3, 0xf3, 0xe3, 0xe3, 0xce, 0xde, 0xe6, 0xd7, 0xad, 0xc2, 0x9d, 0x8f, 0x00,
+	0x65, 0x9b, 0x20, 0xb1, 0x2f, 0x37, 0x0e, 0xb7, 0x37, 0x5b, 0x97, 0xf8, 0x9a, 0x6f, 0x1d, 0xef,
+	0xec, 0xb4, 0xb4, 0xee, 0xa2, 0x78, 0x33, 0x7c, 0xf8, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x6e,
+	0xc5, 0xbc, 0xeb, 0x65, 0x24, 0x00, 0x00,
 }
@@ -12,6 +12,34 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// Possible BpfEvent types
+type BpfEventType int32
+
+const (
+	// The type of event is unknown
+	BpfEventType_BPF_EVENT_TYPE_UNKNOWN BpfEventType = 0
+	// The event is the loading of a BPF program into the kernel
+	BpfEventType_BPF_EVENT_TYPE_PROGRAM_LOAD BpfEventType = 1
+	// The event is the attachment of a loaded BPF program to a hook
+	BpfEventType_BPF_EVENT_TYPE_PROGRAM_ATTACH BpfEventType = 2
+)
+
+var BpfEventType_name = map[int32]string{
+	0: "BPF_EVENT_TYPE_UNKNOWN",
+	1: "BPF_EVENT_TYPE_PROGRAM_LOAD",
+	2: "BPF_EVENT_TYPE_PROGRAM_ATTACH",
+}
+var BpfEventType_value = map[string]int32{
+	"BPF_EVENT_TYPE_UNKNOWN":        0,
+	"BPF_EVENT_TYPE_PROGRAM_LOAD":   1,
+	"BPF_EVENT_TYPE_PROGRAM_ATTACH": 2,
+}
+
+func (x BpfEventType) String() string {
+	return proto.EnumName(BpfEventType_name, int32(x))
+}
+func (BpfEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+
 type ContainerEventType int32
 
 const (
@@ -21,6 +49,9 @@ const (
 	ContainerEventType_CONTAINER_EVENT_TYPE_EXITED    ContainerEventType = 3
 	ContainerEventType_CONTAINER_EVENT_TYPE_DESTROYED ContainerEventType = 4
 	ContainerEventType_CONTAINER_EVENT_TYPE_UPDATED   ContainerEventType = 5
+	ContainerEventType_CONTAINER_EVENT_TYPE_HEALTH    ContainerEventType = 6
+	ContainerEventType_CONTAINER_EVENT_TYPE_PAUSED    ContainerEventType = 7
+	ContainerEventType_CONTAINER_EVENT_TYPE_RESUMED   ContainerEventType = 8
 )
 
 var ContainerEventType_name = map[int32]string{
@@ -30,6 +61,9 @@ var ContainerEventType_name = map[int32]string{
 	3: "CONTAINER_EVENT_TYPE_EXITED",
 	4: "CONTAINER_EVENT_TYPE_DESTROYED",
 	5: "CONTAINER_EVENT_TYPE_UPDATED",
+	6: "CONTAINER_EVENT_TYPE_HEALTH",
+	7: "CONTAINER_EVENT_TYPE_PAUSED",
+	8: "CONTAINER_EVENT_TYPE_RESUMED",
 }
 var ContainerEventType_value = map[string]int32{
 	"CONTAINER_EVENT_TYPE_UNKNOWN":   0,
@@ -38,12 +72,184 @@ var ContainerEventType_value = map[string]int32{
 	"CONTAINER_EVENT_TYPE_EXITED":    3,
 	"CONTAINER_EVENT_TYPE_DESTROYED": 4,
 	"CONTAINER_EVENT_TYPE_UPDATED":   5,
+	"CONTAINER_EVENT_TYPE_HEALTH":    6,
+	"CONTAINER_EVENT_TYPE_PAUSED":    7,
+	"CONTAINER_EVENT_TYPE_RESUMED":   8,
 }
 
 func (x ContainerEventType) String() string {
 	return proto.EnumName(ContainerEventType_name, int32(x))
 }
-func (ContainerEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+func (ContainerEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+
+type ImageEventType int32
+
+const (
+	ImageEventType_IMAGE_EVENT_TYPE_UNKNOWN  ImageEventType = 0
+	ImageEventType_IMAGE_EVENT_TYPE_PULLED   ImageEventType = 1
+	ImageEventType_IMAGE_EVENT_TYPE_TAGGED   ImageEventType = 2
+	ImageEventType_IMAGE_EVENT_TYPE_UNTAGGED ImageEventType = 3
+	ImageEventType_IMAGE_EVENT_TYPE_DELETED  ImageEventType = 4
+)
+
+var ImageEventType_name = map[int32]string{
+	0: "IMAGE_EVENT_TYPE_UNKNOWN",
+	1: "IMAGE_EVENT_TYPE_PULLED",
+	2: "IMAGE_EVENT_TYPE_TAGGED",
+	3: "IMAGE_EVENT_TYPE_UNTAGGED",
+	4: "IMAGE_EVENT_TYPE_DELETED",
+}
+var ImageEventType_value = map[string]int32{
+	"IMAGE_EVENT_TYPE_UNKNOWN":  0,
+	"IMAGE_EVENT_TYPE_PULLED":   1,
+	"IMAGE_EVENT_TYPE_TAGGED":   2,
+	"IMAGE_EVENT_TYPE_UNTAGGED": 3,
+	"IMAGE_EVENT_TYPE_DELETED":  4,
+}
+
+func (x ImageEventType) String() string {
+	return proto.EnumName(ImageEventType_name, int32(x))
+}
+func (ImageEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+
+// Possible IoUringEvent types
+type IoUringEventType int32
+
+const (
+	// The type of event is unknown
+	IoUringEventType_IO_URING_EVENT_TYPE_UNKNOWN IoUringEventType = 0
+	// The event is the creation of an io_uring instance
+	IoUringEventType_IO_URING_EVENT_TYPE_SETUP IoUringEventType = 1
+	// The event is the submission of an operation to an io_uring
+	// instance
+	IoUringEventType_IO_URING_EVENT_TYPE_SUBMIT IoUringEventType = 2
+)
+
+var IoUringEventType_name = map[int32]string{
+	0: "IO_URING_EVENT_TYPE_UNKNOWN",
+	1: "IO_URING_EVENT_TYPE_SETUP",
+	2: "IO_URING_EVENT_TYPE_SUBMIT",
+}
+var IoUringEventType_value = map[string]int32{
+	"IO_URING_EVENT_TYPE_UNKNOWN": 0,
+	"IO_URING_EVENT_TYPE_SETUP":   1,
+	"IO_URING_EVENT_TYPE_SUBMIT":  2,
+}
+
+func (x IoUringEventType) String() string {
+	return proto.EnumName(IoUringEventType_name, int32(x))
+}
+func (IoUringEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+
+// Possible KernelModuleEvent types
+type KernelModuleEventType int32
+
+const (
+	// The type of event is unknown
+	KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNKNOWN KernelModuleEventType = 0
+	// The event is a kernel module load event
+	KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD KernelModuleEventType = 1
+	// The event is a kernel module unload event. This is also generated
+	// when a module is freed after failing to initialize.
+	KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD KernelModuleEventType = 2
+)
+
+var KernelModuleEventType_name = map[int32]string{
+	0: "KERNEL_MODULE_EVENT_TYPE_UNKNOWN",
+	1: "KERNEL_MODULE_EVENT_TYPE_LOAD",
+	2: "KERNEL_MODULE_EVENT_TYPE_UNLOAD",
+}
+var KernelModuleEventType_value = map[string]int32{
+	"KERNEL_MODULE_EVENT_TYPE_UNKNOWN": 0,
+	"KERNEL_MODULE_EVENT_TYPE_LOAD":    1,
+	"KERNEL_MODULE_EVENT_TYPE_UNLOAD":  2,
+}
+
+func (x KernelModuleEventType) String() string {
+	return proto.EnumName(KernelModuleEventType_name, int32(x))
+}
+func (KernelModuleEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+
+// Possible LsmEvent types
+type LsmEventType int32
+
+const (
+	// The type of event is unknown
+	LsmEventType_LSM_EVENT_TYPE_UNKNOWN LsmEventType = 0
+	// The event is an access denial by a Linux Security Module
+	LsmEventType_LSM_EVENT_TYPE_DENIAL LsmEventType = 1
+)
+
+var LsmEventType_name = map[int32]string{
+	0: "LSM_EVENT_TYPE_UNKNOWN",
+	1: "LSM_EVENT_TYPE_DENIAL",
+}
+var LsmEventType_value = map[string]int32{
+	"LSM_EVENT_TYPE_UNKNOWN": 0,
+	"LSM_EVENT_TYPE_DENIAL":  1,
+}
+
+func (x LsmEventType) String() string {
+	return proto.EnumName(LsmEventType_name, int32(x))
+}
+func (LsmEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+
+// Possible MemoryEvent types
+type MemoryEventType int32
+
+const (
+	// The type of event is unknown
+	MemoryEventType_MEMORY_EVENT_TYPE_UNKNOWN MemoryEventType = 0
+	// The event is an executable anonymous memory mapping event
+	MemoryEventType_MEMORY_EVENT_TYPE_MMAP_EXEC MemoryEventType = 1
+	// The event is a memory protection change that makes writable
+	// memory executable
+	MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT_EXEC MemoryEventType = 2
+)
+
+var MemoryEventType_name = map[int32]string{
+	0: "MEMORY_EVENT_TYPE_UNKNOWN",
+	1: "MEMORY_EVENT_TYPE_MMAP_EXEC",
+	2: "MEMORY_EVENT_TYPE_MPROTECT_EXEC",
+}
+var MemoryEventType_value = map[string]int32{
+	"MEMORY_EVENT_TYPE_UNKNOWN":       0,
+	"MEMORY_EVENT_TYPE_MMAP_EXEC":     1,
+	"MEMORY_EVENT_TYPE_MPROTECT_EXEC": 2,
+}
+
+func (x MemoryEventType) String() string {
+	return proto.EnumName(MemoryEventType_name, int32(x))
+}
+func (MemoryEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+// Possible MountEvent types
+type MountEventType int32
+
+const (
+	// The type of event is unknown
+	MountEventType_MOUNT_EVENT_TYPE_UNKNOWN MountEventType = 0
+	// The event is a filesystem mount event
+	MountEventType_MOUNT_EVENT_TYPE_MOUNT MountEventType = 1
+	// The event is a filesystem unmount event
+	MountEventType_MOUNT_EVENT_TYPE_UNMOUNT MountEventType = 2
+)
+
+var MountEventType_name = map[int32]string{
+	0: "MOUNT_EVENT_TYPE_UNKNOWN",
+	1: "MOUNT_EVENT_TYPE_MOUNT",
+	2: "MOUNT_EVENT_TYPE_UNMOUNT",
+}
+var MountEventType_value = map[string]int32{
+	"MOUNT_EVENT_TYPE_UNKNOWN": 0,
+	"MOUNT_EVENT_TYPE_MOUNT":   1,
+	"MOUNT_EVENT_TYPE_UNMOUNT": 2,
+}
+
+func (x MountEventType) String() string {
+	return proto.EnumName(MountEventType_name, int32(x))
+}
+func (MountEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
 
 // Possible ProcessEvent types
 type ProcessEventType int32
@@ -59,27 +265,147 @@ const (
 	ProcessEventType_PROCESS_EVENT_TYPE_EXIT ProcessEventType = 3
 	// The event is a process update event
 	ProcessEventType_PROCESS_EVENT_TYPE_UPDATE ProcessEventType = 4
+	// The event is a process credential change event
+	ProcessEventType_PROCESS_EVENT_TYPE_CRED_CHANGE ProcessEventType = 5
+	// The event is a process capability change event. It is only
+	// generated when capabilities are gained.
+	ProcessEventType_PROCESS_EVENT_TYPE_CAPABILITY_CHANGE ProcessEventType = 6
+	// The event is a process ptrace attach event. It is generated for
+	// PTRACE_ATTACH, PTRACE_SEIZE, and PTRACE_TRACEME requests.
+	ProcessEventType_PROCESS_EVENT_TYPE_PTRACE_ATTACH ProcessEventType = 7
+	// The event is a process OOM kill event. The process associated
+	// with the event is the one selected to be killed by the kernel's
+	// out of memory killer.
+	ProcessEventType_PROCESS_EVENT_TYPE_OOM_KILL ProcessEventType = 8
+	// The event is a process crash event. It is generated when a
+	// process is killed by a signal whose default action is to dump
+	// core, such as SIGSEGV or SIGABRT, whether or not a core file is
+	// actually written.
+	ProcessEventType_PROCESS_EVENT_TYPE_CRASH ProcessEventType = 9
+	// The event is a process seccomp violation event. It is generated
+	// when a seccomp filter stops a system call with SECCOMP_RET_TRAP
+	// or kills the process with SECCOMP_RET_KILL_PROCESS or
+	// SECCOMP_RET_KILL_THREAD. Kernels older than Linux 5.15 only
+	// report violations while the audit subsystem is enabled.
+	ProcessEventType_PROCESS_EVENT_TYPE_SECCOMP_VIOLATION ProcessEventType = 10
 )
 
 var ProcessEventType_name = map[int32]string{
-	0: "PROCESS_EVENT_TYPE_UNKNOWN",
-	1: "PROCESS_EVENT_TYPE_FORK",
-	2: "PROCESS_EVENT_TYPE_EXEC",
-	3: "PROCESS_EVENT_TYPE_EXIT",
-	4: "PROCESS_EVENT_TYPE_UPDATE",
+	0:  "PROCESS_EVENT_TYPE_UNKNOWN",
+	1:  "PROCESS_EVENT_TYPE_FORK",
+	2:  "PROCESS_EVENT_TYPE_EXEC",
+	3:  "PROCESS_EVENT_TYPE_EXIT",
+	4:  "PROCESS_EVENT_TYPE_UPDATE",
+	5:  "PROCESS_EVENT_TYPE_CRED_CHANGE",
+	6:  "PROCESS_EVENT_TYPE_CAPABILITY_CHANGE",
+	7:  "PROCESS_EVENT_TYPE_PTRACE_ATTACH",
+	8:  "PROCESS_EVENT_TYPE_OOM_KILL",
+	9:  "PROCESS_EVENT_TYPE_CRASH",
+	10: "PROCESS_EVENT_TYPE_SECCOMP_VIOLATION",
 }
 var ProcessEventType_value = map[string]int32{
-	"PROCESS_EVENT_TYPE_UNKNOWN": 0,
-	"PROCESS_EVENT_TYPE_FORK":    1,
-	"PROCESS_EVENT_TYPE_EXEC":    2,
-	"PROCESS_EVENT_TYPE_EXIT":    3,
-	"PROCESS_EVENT_TYPE_UPDATE":  4,
+	"PROCESS_EVENT_TYPE_UNKNOWN":           0,
+	"PROCESS_EVENT_TYPE_FORK":              1,
+	"PROCESS_EVENT_TYPE_EXEC":              2,
+	"PROCESS_EVENT_TYPE_EXIT":              3,
+	"PROCESS_EVENT_TYPE_UPDATE":            4,
+	"PROCESS_EVENT_TYPE_CRED_CHANGE":       5,
+	"PROCESS_EVENT_TYPE_CAPABILITY_CHANGE": 6,
+	"PROCESS_EVENT_TYPE_PTRACE_ATTACH":     7,
+	"PROCESS_EVENT_TYPE_OOM_KILL":          8,
+	"PROCESS_EVENT_TYPE_CRASH":             9,
+	"PROCESS_EVENT_TYPE_SECCOMP_VIOLATION": 10,
 }
 
 func (x ProcessEventType) String() string {
 	return proto.EnumName(ProcessEventType_name, int32(x))
 }
-func (ProcessEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+func (ProcessEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+
+// Possible actions taken by a seccomp filter
+type SeccompAction int32
+
+const (
+	SeccompAction_SECCOMP_ACTION_UNKNOWN SeccompAction = 0
+	// The system call was stopped and SIGSYS was sent to the process.
+	SeccompAction_SECCOMP_ACTION_TRAP SeccompAction = 1
+	// The process was killed with SIGSYS.
+	SeccompAction_SECCOMP_ACTION_KILL SeccompAction = 2
+)
+
+var SeccompAction_name = map[int32]string{
+	0: "SECCOMP_ACTION_UNKNOWN",
+	1: "SECCOMP_ACTION_TRAP",
+	2: "SECCOMP_ACTION_KILL",
+}
+var SeccompAction_value = map[string]int32{
+	"SECCOMP_ACTION_UNKNOWN": 0,
+	"SECCOMP_ACTION_TRAP":    1,
+	"SECCOMP_ACTION_KILL":    2,
+}
+
+func (x SeccompAction) String() string {
+	return proto.EnumName(SeccompAction_name, int32(x))
+}
+func (SeccompAction) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+
+// Possible SessionEvent types
+type SessionEventType int32
+
+const (
+	// The type of event is unknown
+	SessionEventType_SESSION_EVENT_TYPE_UNKNOWN SessionEventType = 0
+	// The event is a user logging in to the host
+	SessionEventType_SESSION_EVENT_TYPE_LOGIN SessionEventType = 1
+	// The event is a user logging out of the host
+	SessionEventType_SESSION_EVENT_TYPE_LOGOUT SessionEventType = 2
+)
+
+var SessionEventType_name = map[int32]string{
+	0: "SESSION_EVENT_TYPE_UNKNOWN",
+	1: "SESSION_EVENT_TYPE_LOGIN",
+	2: "SESSION_EVENT_TYPE_LOGOUT",
+}
+var SessionEventType_value = map[string]int32{
+	"SESSION_EVENT_TYPE_UNKNOWN": 0,
+	"SESSION_EVENT_TYPE_LOGIN":   1,
+	"SESSION_EVENT_TYPE_LOGOUT":  2,
+}
+
+func (x SessionEventType) String() string {
+	return proto.EnumName(SessionEventType_name, int32(x))
+}
+func (SessionEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+
+// Possible SignalEvent types
+type SignalEventType int32
+
+const (
+	// The type of event is unknown
+	SignalEventType_SIGNAL_EVENT_TYPE_UNKNOWN SignalEventType = 0
+	// The event is a signal generation event. The process associated
+	// with the event is the one sending the signal.
+	SignalEventType_SIGNAL_EVENT_TYPE_GENERATE SignalEventType = 1
+	// The event is a signal delivery event. The process associated with
+	// the event is the one receiving the signal.
+	SignalEventType_SIGNAL_EVENT_TYPE_DELIVER SignalEventType = 2
+)
+
+var SignalEventType_name = map[int32]string{
+	0: "SIGNAL_EVENT_TYPE_UNKNOWN",
+	1: "SIGNAL_EVENT_TYPE_GENERATE",
+	2: "SIGNAL_EVENT_TYPE_DELIVER",
+}
+var SignalEventType_value = map[string]int32{
+	"SIGNAL_EVENT_TYPE_UNKNOWN":  0,
+	"SIGNAL_EVENT_TYPE_GENERATE": 1,
+	"SIGNAL_EVENT_TYPE_DELIVER":  2,
+}
+
+func (x SignalEventType) String() string {
+	return proto.EnumName(SignalEventType_name, int32(x))
+}
+func (SignalEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
 
 // Possible SyscallEvent types
 type SyscallEventType int32
@@ -107,7 +433,31 @@ var SyscallEventType_value = map[string]int32{
 func (x SyscallEventType) String() string {
 	return proto.EnumName(SyscallEventType_name, int32(x))
 }
-func (SyscallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+func (SyscallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
+
+// Possible TtyEvent types
+type TtyEventType int32
+
+const (
+	// The type of event is unknown
+	TtyEventType_TTY_EVENT_TYPE_UNKNOWN TtyEventType = 0
+	// The event is a read of terminal input
+	TtyEventType_TTY_EVENT_TYPE_READ TtyEventType = 1
+)
+
+var TtyEventType_name = map[int32]string{
+	0: "TTY_EVENT_TYPE_UNKNOWN",
+	1: "TTY_EVENT_TYPE_READ",
+}
+var TtyEventType_value = map[string]int32{
+	"TTY_EVENT_TYPE_UNKNOWN": 0,
+	"TTY_EVENT_TYPE_READ":    1,
+}
+
+func (x TtyEventType) String() string {
+	return proto.EnumName(TtyEventType_name, int32(x))
+}
+func (TtyEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
 
 // Possible FileEvent types
 type FileEventType int32
@@ -117,21 +467,29 @@ const (
 	FileEventType_FILE_EVENT_TYPE_UNKNOWN FileEventType = 0
 	// The event is a file open event
 	FileEventType_FILE_EVENT_TYPE_OPEN FileEventType = 1
+	// The event is a file write event
+	FileEventType_FILE_EVENT_TYPE_WRITE FileEventType = 2
+	// The event is the creation of an anonymous memory file
+	FileEventType_FILE_EVENT_TYPE_MEMFD_CREATE FileEventType = 3
 )
 
 var FileEventType_name = map[int32]string{
 	0: "FILE_EVENT_TYPE_UNKNOWN",
 	1: "FILE_EVENT_TYPE_OPEN",
+	2: "FILE_EVENT_TYPE_WRITE",
+	3: "FILE_EVENT_TYPE_MEMFD_CREATE",
 }
 var FileEventType_value = map[string]int32{
-	"FILE_EVENT_TYPE_UNKNOWN": 0,
-	"FILE_EVENT_TYPE_OPEN":    1,
+	"FILE_EVENT_TYPE_UNKNOWN":      0,
+	"FILE_EVENT_TYPE_OPEN":         1,
+	"FILE_EVENT_TYPE_WRITE":        2,
+	"FILE_EVENT_TYPE_MEMFD_CREATE": 3,
 }
 
 func (x FileEventType) String() string {
 	return proto.EnumName(FileEventType_name, int32(x))
 }
-func (FileEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+func (FileEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{14} }
 
 // Possible KernelFunctionCallEvent types
 type KernelFunctionCallEventType int32
@@ -159,7 +517,35 @@ var KernelFunctionCallEventType_value = map[string]int32{
 func (x KernelFunctionCallEventType) String() string {
 	return proto.EnumName(KernelFunctionCallEventType_name, int32(x))
 }
-func (KernelFunctionCallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (KernelFunctionCallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{15} }
+
+// Possible UserFunctionCallEvent types
+type UserFunctionCallEventType int32
+
+const (
+	// The type of event is unknown
+	UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_UNKNOWN UserFunctionCallEventType = 0
+	// The event is a user-space function being entered.
+	UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_ENTER UserFunctionCallEventType = 1
+	// The event is a user-space function being exited.
+	UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_EXIT UserFunctionCallEventType = 2
+)
+
+var UserFunctionCallEventType_name = map[int32]string{
+	0: "USER_FUNCTION_CALL_EVENT_TYPE_UNKNOWN",
+	1: "USER_FUNCTION_CALL_EVENT_TYPE_ENTER",
+	2: "USER_FUNCTION_CALL_EVENT_TYPE_EXIT",
+}
+var UserFunctionCallEventType_value = map[string]int32{
+	"USER_FUNCTION_CALL_EVENT_TYPE_UNKNOWN": 0,
+	"USER_FUNCTION_CALL_EVENT_TYPE_ENTER":   1,
+	"USER_FUNCTION_CALL_EVENT_TYPE_EXIT":    2,
+}
+
+func (x UserFunctionCallEventType) String() string {
+	return proto.EnumName(UserFunctionCallEventType_name, int32(x))
+}
+func (UserFunctionCallEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{16} }
 
 // Possible network event types
 type NetworkEventType int32
@@ -194,6 +580,21 @@ const (
 	// The event is the result of an attempt to receive data from a
 	// specific address
 	NetworkEventType_NETWORK_EVENT_TYPE_RECVFROM_RESULT NetworkEventType = 12
+	// The event is an outbound TCP connection being initiated
+	NetworkEventType_NETWORK_EVENT_TYPE_TCP_CONNECT NetworkEventType = 13
+	// The event is an inbound TCP connection being accepted
+	NetworkEventType_NETWORK_EVENT_TYPE_TCP_ACCEPT NetworkEventType = 14
+	// The event is a DNS query being sent to a name server
+	NetworkEventType_NETWORK_EVENT_TYPE_DNS_QUERY NetworkEventType = 15
+	// The event is a connection to a unix domain stream socket; address
+	// is the path of the socket
+	NetworkEventType_NETWORK_EVENT_TYPE_UNIX_CONNECT NetworkEventType = 16
+	// The event is a TCP socket beginning to listen for connections;
+	// local_address is the address the socket is bound to
+	NetworkEventType_NETWORK_EVENT_TYPE_TCP_LISTEN NetworkEventType = 17
+	// The event is a UDP socket being bound to a local port; local_address
+	// is the address the socket is bound to
+	NetworkEventType_NETWORK_EVENT_TYPE_UDP_BIND NetworkEventType = 18
 )
 
 var NetworkEventType_name = map[int32]string{
@@ -210,6 +611,12 @@ var NetworkEventType_name = map[int32]string{
 	10: "NETWORK_EVENT_TYPE_SENDTO_RESULT",
 	11: "NETWORK_EVENT_TYPE_RECVFROM_ATTEMPT",
 	12: "NETWORK_EVENT_TYPE_RECVFROM_RESULT",
+	13: "NETWORK_EVENT_TYPE_TCP_CONNECT",
+	14: "NETWORK_EVENT_TYPE_TCP_ACCEPT",
+	15: "NETWORK_EVENT_TYPE_DNS_QUERY",
+	16: "NETWORK_EVENT_TYPE_UNIX_CONNECT",
+	17: "NETWORK_EVENT_TYPE_TCP_LISTEN",
+	18: "NETWORK_EVENT_TYPE_UDP_BIND",
 }
 var NetworkEventType_value = map[string]int32{
 	"NETWORK_EVENT_TYPE_UNKNOWN":          0,
@@ -225,12 +632,18 @@ var NetworkEventType_value = map[string]int32{
 	"NETWORK_EVENT_TYPE_SENDTO_RESULT":    10,
 	"NETWORK_EVENT_TYPE_RECVFROM_ATTEMPT": 11,
 	"NETWORK_EVENT_TYPE_RECVFROM_RESULT":  12,
+	"NETWORK_EVENT_TYPE_TCP_CONNECT":      13,
+	"NETWORK_EVENT_TYPE_TCP_ACCEPT":       14,
+	"NETWORK_EVENT_TYPE_DNS_QUERY":        15,
+	"NETWORK_EVENT_TYPE_UNIX_CONNECT":     16,
+	"NETWORK_EVENT_TYPE_TCP_LISTEN":       17,
+	"NETWORK_EVENT_TYPE_UDP_BIND":         18,
 }
 
 func (x NetworkEventType) String() string {
 	return proto.EnumName(NetworkEventType_name, int32(x))
 }
-func (NetworkEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+func (NetworkEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{17} }
 
 // Possible performance event types
 type PerformanceEventType int32
@@ -262,7 +675,7 @@ var PerformanceEventType_value = map[string]int32{
 func (x PerformanceEventType) String() string {
 	return proto.EnumName(PerformanceEventType_name, int32(x))
 }
-func (PerformanceEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+func (PerformanceEventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{18} }
 
 // Possible field types
 type KernelFunctionCallEvent_FieldType int32
@@ -323,7 +736,7 @@ func (x KernelFunctionCallEvent_FieldType) String() string {
 	return proto.EnumName(KernelFunctionCallEvent_FieldType_name, int32(x))
 }
 func (KernelFunctionCallEvent_FieldType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{8, 0}
+	return fileDescriptor1, []int{24, 0}
 }
 
 // An event observed by the Sensor.
@@ -353,7 +766,8 @@ type TelemetryEvent struct {
 	SensorMonotimeNanos int64 `protobuf:"varint,7,opt,name=sensor_monotime_nanos,json=sensorMonotimeNanos" json:"sensor_monotime_nanos,omitempty"`
 	// Process Lineage contains one process context for each process in the
 	// hierarchy, starting with the current process, up to the root of the
-	// process namespace.
+	// process namespace. It is included in exec and network events and
+	// is limited to the Sensor's configured lineage depth.
 	ProcessLineage []*Process `protobuf:"bytes,8,rep,name=process_lineage,json=processLineage" json:"process_lineage,omitempty"`
 	// Name of container associated with the event
 	ContainerName string `protobuf:"bytes,30,opt,name=container_name,json=containerName" json:"container_name,omitempty"`
@@ -371,7 +785,20 @@ type TelemetryEvent struct {
 	//	*TelemetryEvent_KernelCall
 	//	*TelemetryEvent_Network
 	//	*TelemetryEvent_Performance
+	//	*TelemetryEvent_KernelModule
+	//	*TelemetryEvent_Mount
+	//	*TelemetryEvent_Memory
+	//	*TelemetryEvent_Signal
+	//	*TelemetryEvent_Lsm
+	//	*TelemetryEvent_Tty
+	//	*TelemetryEvent_IoUring
+	//	*TelemetryEvent_Bpf
+	//	*TelemetryEvent_UserCall
 	//	*TelemetryEvent_Container
+	//	*TelemetryEvent_Image
+	//	*TelemetryEvent_Session
+	//	*TelemetryEvent_LostEvents
+	//	*TelemetryEvent_SensorStatus
 	//	*TelemetryEvent_Chargen
 	//	*TelemetryEvent_Ticker
 	Event isTelemetryEvent_Event `protobuf_oneof:"event"`
@@ -382,6 +809,23 @@ type TelemetryEvent struct {
 	// Kernel's TGID of the task associated with the event. This
 	// corresponds the userland's PID.
 	ProcessTgid int32 `protobuf:"varint,203,opt,name=process_tgid,json=processTgid" json:"process_tgid,omitempty"`
+	// Stack traces of the task associated with the event at the time
+	// of the event, if the subscription requested them and they were
+	// captured.
+	StackTrace *StackTrace `protobuf:"bytes,204,opt,name=stack_trace,json=stackTrace" json:"stack_trace,omitempty"`
+	// PID of the task associated with the event as seen inside its own
+	// PID namespace (i.e. inside its container). It is the same as
+	// process_pid for tasks in the Sensor's PID namespace and zero if it
+	// is not known.
+	ProcessNamespacePid int32 `protobuf:"varint,205,opt,name=process_namespace_pid,json=processNamespacePid" json:"process_namespace_pid,omitempty"`
+	// TGID of the task associated with the event as seen inside its own
+	// PID namespace. It is the same as process_tgid for tasks in the
+	// Sensor's PID namespace and zero if it is not known.
+	ProcessNamespaceTgid int32 `protobuf:"varint,206,opt,name=process_namespace_tgid,json=processNamespaceTgid" json:"process_namespace_tgid,omitempty"`
+	// Namespaces of the task associated with the event, if they are
+	// known. A containerized task with a host namespace may have
+	// broken out of its container.
+	Namespaces *Namespaces `protobuf:"bytes,207,opt,name=namespaces" json:"namespaces,omitempty"`
 }
 
 func (m *TelemetryEvent) Reset()                    { *m = TelemetryEvent{} }
@@ -411,9 +855,48 @@ type TelemetryEvent_Network struct {
 type TelemetryEvent_Performance struct {
 	Performance *PerformanceEvent `protobuf:"bytes,15,opt,name=performance,oneof"`
 }
+type TelemetryEvent_KernelModule struct {
+	KernelModule *KernelModuleEvent `protobuf:"bytes,16,opt,name=kernel_module,json=kernelModule,oneof"`
+}
+type TelemetryEvent_Mount struct {
+	Mount *MountEvent `protobuf:"bytes,17,opt,name=mount,oneof"`
+}
+type TelemetryEvent_Memory struct {
+	Memory *MemoryEvent `protobuf:"bytes,18,opt,name=memory,oneof"`
+}
+type TelemetryEvent_Signal struct {
+	Signal *SignalEvent `protobuf:"bytes,19,opt,name=signal,oneof"`
+}
+type TelemetryEvent_Lsm struct {
+	Lsm *LsmEvent `protobuf:"bytes,22,opt,name=lsm,oneof"`
+}
+type TelemetryEvent_Tty struct {
+	Tty *TtyEvent `protobuf:"bytes,23,opt,name=tty,oneof"`
+}
+type TelemetryEvent_IoUring struct {
+	IoUring *IoUringEvent `protobuf:"bytes,25,opt,name=io_uring,json=ioUring,oneof"`
+}
+type TelemetryEvent_Bpf struct {
+	Bpf *BpfEvent `protobuf:"bytes,26,opt,name=bpf,oneof"`
+}
+type TelemetryEvent_UserCall struct {
+	UserCall *UserFunctionCallEvent `protobuf:"bytes,27,opt,name=user_call,json=userCall,oneof"`
+}
 type TelemetryEvent_Container struct {
 	Container *ContainerEvent `protobuf:"bytes,20,opt,name=container,oneof"`
 }
+type TelemetryEvent_Image struct {
+	Image *ImageEvent `protobuf:"bytes,21,opt,name=image,oneof"`
+}
+type TelemetryEvent_Session struct {
+	Session *SessionEvent `protobuf:"bytes,24,opt,name=session,oneof"`
+}
+type TelemetryEvent_LostEvents struct {
+	LostEvents *LostEventsEvent `protobuf:"bytes,28,opt,name=lost_events,json=lostEvents,oneof"`
+}
+type TelemetryEvent_SensorStatus struct {
+	SensorStatus *SensorStatusEvent `protobuf:"bytes,29,opt,name=sensor_status,json=sensorStatus,oneof"`
+}
 type TelemetryEvent_Chargen struct {
 	Chargen *ChargenEvent `protobuf:"bytes,100,opt,name=chargen,oneof"`
 }
@@ -421,15 +904,28 @@ type TelemetryEvent_Ticker struct {
 	Ticker *TickerEvent `protobuf:"bytes,101,opt,name=ticker,oneof"`
 }
 
-func (*TelemetryEvent_Syscall) isTelemetryEvent_Event()     {}
-func (*TelemetryEvent_Process) isTelemetryEvent_Event()     {}
-func (*TelemetryEvent_File) isTelemetryEvent_Event()        {}
-func (*TelemetryEvent_KernelCall) isTelemetryEvent_Event()  {}
-func (*TelemetryEvent_Network) isTelemetryEvent_Event()     {}
-func (*TelemetryEvent_Performance) isTelemetryEvent_Event() {}
-func (*TelemetryEvent_Container) isTelemetryEvent_Event()   {}
-func (*TelemetryEvent_Chargen) isTelemetryEvent_Event()     {}
-func (*TelemetryEvent_Ticker) isTelemetryEvent_Event()      {}
+func (*TelemetryEvent_Syscall) isTelemetryEvent_Event()      {}
+func (*TelemetryEvent_Process) isTelemetryEvent_Event()      {}
+func (*TelemetryEvent_File) isTelemetryEvent_Event()         {}
+func (*TelemetryEvent_KernelCall) isTelemetryEvent_Event()   {}
+func (*TelemetryEvent_Network) isTelemetryEvent_Event()      {}
+func (*TelemetryEvent_Performance) isTelemetryEvent_Event()  {}
+func (*TelemetryEvent_KernelModule) isTelemetryEvent_Event() {}
+func (*TelemetryEvent_Mount) isTelemetryEvent_Event()        {}
+func (*TelemetryEvent_Memory) isTelemetryEvent_Event()       {}
+func (*TelemetryEvent_Signal) isTelemetryEvent_Event()       {}
+func (*TelemetryEvent_Lsm) isTelemetryEvent_Event()          {}
+func (*TelemetryEvent_Tty) isTelemetryEvent_Event()          {}
+func (*TelemetryEvent_IoUring) isTelemetryEvent_Event()      {}
+func (*TelemetryEvent_Bpf) isTelemetryEvent_Event()          {}
+func (*TelemetryEvent_UserCall) isTelemetryEvent_Event()     {}
+func (*TelemetryEvent_Container) isTelemetryEvent_Event()    {}
+func (*TelemetryEvent_Image) isTelemetryEvent_Event()        {}
+func (*TelemetryEvent_Session) isTelemetryEvent_Event()      {}
+func (*TelemetryEvent_LostEvents) isTelemetryEvent_Event()   {}
+func (*TelemetryEvent_SensorStatus) isTelemetryEvent_Event() {}
+func (*TelemetryEvent_Chargen) isTelemetryEvent_Event()      {}
+func (*TelemetryEvent_Ticker) isTelemetryEvent_Event()       {}
 
 func (m *TelemetryEvent) GetEvent() isTelemetryEvent_Event {
 	if m != nil {
@@ -557,6 +1053,69 @@ func (m *TelemetryEvent) GetPerformance() *PerformanceEvent {
 	return nil
 }
 
+func (m *TelemetryEvent) GetKernelModule() *KernelModuleEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_KernelModule); ok {
+		return x.KernelModule
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetMount() *MountEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Mount); ok {
+		return x.Mount
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetMemory() *MemoryEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Memory); ok {
+		return x.Memory
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetSignal() *SignalEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Signal); ok {
+		return x.Signal
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetLsm() *LsmEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Lsm); ok {
+		return x.Lsm
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetTty() *TtyEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Tty); ok {
+		return x.Tty
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetIoUring() *IoUringEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_IoUring); ok {
+		return x.IoUring
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetBpf() *BpfEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Bpf); ok {
+		return x.Bpf
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetUserCall() *UserFunctionCallEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_UserCall); ok {
+		return x.UserCall
+	}
+	return nil
+}
+
 func (m *TelemetryEvent) GetContainer() *ContainerEvent {
 	if x, ok := m.GetEvent().(*TelemetryEvent_Container); ok {
 		return x.Container
@@ -564,6 +1123,34 @@ func (m *TelemetryEvent) GetContainer() *ContainerEvent {
 	return nil
 }
 
+func (m *TelemetryEvent) GetImage() *ImageEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Image); ok {
+		return x.Image
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetSession() *SessionEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_Session); ok {
+		return x.Session
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetLostEvents() *LostEventsEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_LostEvents); ok {
+		return x.LostEvents
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetSensorStatus() *SensorStatusEvent {
+	if x, ok := m.GetEvent().(*TelemetryEvent_SensorStatus); ok {
+		return x.SensorStatus
+	}
+	return nil
+}
+
 func (m *TelemetryEvent) GetChargen() *ChargenEvent {
 	if x, ok := m.GetEvent().(*TelemetryEvent_Chargen); ok {
 		return x.Chargen
@@ -599,6 +1186,34 @@ func (m *TelemetryEvent) GetProcessTgid() int32 {
 	return 0
 }
 
+func (m *TelemetryEvent) GetStackTrace() *StackTrace {
+	if m != nil {
+		return m.StackTrace
+	}
+	return nil
+}
+
+func (m *TelemetryEvent) GetProcessNamespacePid() int32 {
+	if m != nil {
+		return m.ProcessNamespacePid
+	}
+	return 0
+}
+
+func (m *TelemetryEvent) GetProcessNamespaceTgid() int32 {
+	if m != nil {
+		return m.ProcessNamespaceTgid
+	}
+	return 0
+}
+
+func (m *TelemetryEvent) GetNamespaces() *Namespaces {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TelemetryEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TelemetryEvent_OneofMarshaler, _TelemetryEvent_OneofUnmarshaler, _TelemetryEvent_OneofSizer, []interface{}{
@@ -608,7 +1223,20 @@ func (*TelemetryEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer
 		(*TelemetryEvent_KernelCall)(nil),
 		(*TelemetryEvent_Network)(nil),
 		(*TelemetryEvent_Performance)(nil),
+		(*TelemetryEvent_KernelModule)(nil),
+		(*TelemetryEvent_Mount)(nil),
+		(*TelemetryEvent_Memory)(nil),
+		(*TelemetryEvent_Signal)(nil),
+		(*TelemetryEvent_Lsm)(nil),
+		(*TelemetryEvent_Tty)(nil),
+		(*TelemetryEvent_IoUring)(nil),
+		(*TelemetryEvent_Bpf)(nil),
+		(*TelemetryEvent_UserCall)(nil),
 		(*TelemetryEvent_Container)(nil),
+		(*TelemetryEvent_Image)(nil),
+		(*TelemetryEvent_Session)(nil),
+		(*TelemetryEvent_LostEvents)(nil),
+		(*TelemetryEvent_SensorStatus)(nil),
 		(*TelemetryEvent_Chargen)(nil),
 		(*TelemetryEvent_Ticker)(nil),
 	}
@@ -648,11 +1276,76 @@ func _TelemetryEvent_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Performance); err != nil {
 			return err
 		}
+	case *TelemetryEvent_KernelModule:
+		b.EncodeVarint(16<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.KernelModule); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Mount:
+		b.EncodeVarint(17<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Mount); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Memory:
+		b.EncodeVarint(18<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Memory); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Signal:
+		b.EncodeVarint(19<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Signal); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Lsm:
+		b.EncodeVarint(22<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Lsm); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Tty:
+		b.EncodeVarint(23<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Tty); err != nil {
+			return err
+		}
+	case *TelemetryEvent_IoUring:
+		b.EncodeVarint(25<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.IoUring); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Bpf:
+		b.EncodeVarint(26<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Bpf); err != nil {
+			return err
+		}
+	case *TelemetryEvent_UserCall:
+		b.EncodeVarint(27<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.UserCall); err != nil {
+			return err
+		}
 	case *TelemetryEvent_Container:
 		b.EncodeVarint(20<<3 | proto.WireBytes)
 		if err := b.EncodeMessage(x.Container); err != nil {
 			return err
 		}
+	case *TelemetryEvent_Image:
+		b.EncodeVarint(21<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Image); err != nil {
+			return err
+		}
+	case *TelemetryEvent_Session:
+		b.EncodeVarint(24<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Session); err != nil {
+			return err
+		}
+	case *TelemetryEvent_LostEvents:
+		b.EncodeVarint(28<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LostEvents); err != nil {
+			return err
+		}
+	case *TelemetryEvent_SensorStatus:
+		b.EncodeVarint(29<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SensorStatus); err != nil {
+			return err
+		}
 	case *TelemetryEvent_Chargen:
 		b.EncodeVarint(100<<3 | proto.WireBytes)
 		if err := b.EncodeMessage(x.Chargen); err != nil {
@@ -721,48 +1414,152 @@ func _TelemetryEvent_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Event = &TelemetryEvent_Performance{msg}
 		return true, err
-	case 20: // event.container
+	case 16: // event.kernel_module
 		if wire != proto.WireBytes {
 			return true, proto.ErrInternalBadWireType
 		}
-		msg := new(ContainerEvent)
+		msg := new(KernelModuleEvent)
 		err := b.DecodeMessage(msg)
-		m.Event = &TelemetryEvent_Container{msg}
+		m.Event = &TelemetryEvent_KernelModule{msg}
 		return true, err
-	case 100: // event.chargen
+	case 17: // event.mount
 		if wire != proto.WireBytes {
 			return true, proto.ErrInternalBadWireType
 		}
-		msg := new(ChargenEvent)
+		msg := new(MountEvent)
 		err := b.DecodeMessage(msg)
-		m.Event = &TelemetryEvent_Chargen{msg}
+		m.Event = &TelemetryEvent_Mount{msg}
 		return true, err
-	case 101: // event.ticker
+	case 18: // event.memory
 		if wire != proto.WireBytes {
 			return true, proto.ErrInternalBadWireType
 		}
-		msg := new(TickerEvent)
+		msg := new(MemoryEvent)
 		err := b.DecodeMessage(msg)
-		m.Event = &TelemetryEvent_Ticker{msg}
+		m.Event = &TelemetryEvent_Memory{msg}
 		return true, err
-	default:
-		return false, nil
-	}
-}
-
-func _TelemetryEvent_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*TelemetryEvent)
-	// event
-	switch x := m.Event.(type) {
-	case *TelemetryEvent_Syscall:
-		s := proto.Size(x.Syscall)
-		n += proto.SizeVarint(10<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *TelemetryEvent_Process:
-		s := proto.Size(x.Process)
-		n += proto.SizeVarint(11<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(s))
+	case 19: // event.signal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SignalEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Signal{msg}
+		return true, err
+	case 22: // event.lsm
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LsmEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Lsm{msg}
+		return true, err
+	case 23: // event.tty
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TtyEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Tty{msg}
+		return true, err
+	case 25: // event.io_uring
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(IoUringEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_IoUring{msg}
+		return true, err
+	case 26: // event.bpf
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BpfEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Bpf{msg}
+		return true, err
+	case 27: // event.user_call
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(UserFunctionCallEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_UserCall{msg}
+		return true, err
+	case 20: // event.container
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ContainerEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Container{msg}
+		return true, err
+	case 21: // event.image
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ImageEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Image{msg}
+		return true, err
+	case 24: // event.session
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SessionEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Session{msg}
+		return true, err
+	case 28: // event.lost_events
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LostEventsEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_LostEvents{msg}
+		return true, err
+	case 29: // event.sensor_status
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SensorStatusEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_SensorStatus{msg}
+		return true, err
+	case 100: // event.chargen
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChargenEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Chargen{msg}
+		return true, err
+	case 101: // event.ticker
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TickerEvent)
+		err := b.DecodeMessage(msg)
+		m.Event = &TelemetryEvent_Ticker{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _TelemetryEvent_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*TelemetryEvent)
+	// event
+	switch x := m.Event.(type) {
+	case *TelemetryEvent_Syscall:
+		s := proto.Size(x.Syscall)
+		n += proto.SizeVarint(10<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Process:
+		s := proto.Size(x.Process)
+		n += proto.SizeVarint(11<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
 		n += s
 	case *TelemetryEvent_File:
 		s := proto.Size(x.File)
@@ -784,11 +1581,76 @@ func _TelemetryEvent_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(15<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TelemetryEvent_KernelModule:
+		s := proto.Size(x.KernelModule)
+		n += proto.SizeVarint(16<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Mount:
+		s := proto.Size(x.Mount)
+		n += proto.SizeVarint(17<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Memory:
+		s := proto.Size(x.Memory)
+		n += proto.SizeVarint(18<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Signal:
+		s := proto.Size(x.Signal)
+		n += proto.SizeVarint(19<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Lsm:
+		s := proto.Size(x.Lsm)
+		n += proto.SizeVarint(22<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Tty:
+		s := proto.Size(x.Tty)
+		n += proto.SizeVarint(23<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_IoUring:
+		s := proto.Size(x.IoUring)
+		n += proto.SizeVarint(25<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Bpf:
+		s := proto.Size(x.Bpf)
+		n += proto.SizeVarint(26<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_UserCall:
+		s := proto.Size(x.UserCall)
+		n += proto.SizeVarint(27<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case *TelemetryEvent_Container:
 		s := proto.Size(x.Container)
 		n += proto.SizeVarint(20<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TelemetryEvent_Image:
+		s := proto.Size(x.Image)
+		n += proto.SizeVarint(21<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_Session:
+		s := proto.Size(x.Session)
+		n += proto.SizeVarint(24<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_LostEvents:
+		s := proto.Size(x.LostEvents)
+		n += proto.SizeVarint(28<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TelemetryEvent_SensorStatus:
+		s := proto.Size(x.SensorStatus)
+		n += proto.SizeVarint(29<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case *TelemetryEvent_Chargen:
 		s := proto.Size(x.Chargen)
 		n += proto.SizeVarint(100<<3 | proto.WireBytes)
@@ -833,6 +1695,146 @@ func (m *ChargenEvent) GetCharacters() string {
 	return ""
 }
 
+// The LostEventsEvent reports that the kernel dropped events for the
+// subscription because they could not be read quickly enough. Events
+// matching the subscription that occurred during the window may be
+// missing from the event stream.
+type LostEventsEvent struct {
+	// The number of events lost
+	Count uint64 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	// The sensor_monotime_nanos at which the window began. The window
+	// ends at the sensor_monotime_nanos of this event.
+	WindowStartMonotimeNanos int64 `protobuf:"varint,2,opt,name=window_start_monotime_nanos,json=windowStartMonotimeNanos" json:"window_start_monotime_nanos,omitempty"`
+}
+
+func (m *LostEventsEvent) Reset()                    { *m = LostEventsEvent{} }
+func (m *LostEventsEvent) String() string            { return proto.CompactTextString(m) }
+func (*LostEventsEvent) ProtoMessage()               {}
+func (*LostEventsEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+
+func (m *LostEventsEvent) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *LostEventsEvent) GetWindowStartMonotimeNanos() int64 {
+	if m != nil {
+		return m.WindowStartMonotimeNanos
+	}
+	return 0
+}
+
+// The SensorStatusEvent reports the health of the Sensor. It is sent on
+// each stream every StatusInterval, so that clients can see that the Sensor
+// is degraded alongside the events that they are analyzing. Counts are
+// since the Sensor started.
+type SensorStatusEvent struct {
+	// The time since the Sensor started
+	UptimeNanos int64 `protobuf:"varint,1,opt,name=uptime_nanos,json=uptimeNanos" json:"uptime_nanos,omitempty"`
+	// The number of events received from the Sensor's event sources
+	EventsReceived uint64 `protobuf:"varint,2,opt,name=events_received,json=eventsReceived" json:"events_received,omitempty"`
+	// The average number of events received from the Sensor's event
+	// sources per second since the stream's last SensorStatusEvent,
+	// or since the stream was opened
+	EventsPerSecond float64 `protobuf:"fixed64,3,opt,name=events_per_second,json=eventsPerSecond" json:"events_per_second,omitempty"`
+	// The number of records that the kernel dropped because a ring
+	// buffer was full
+	LostRecords uint64 `protobuf:"varint,4,opt,name=lost_records,json=lostRecords" json:"lost_records,omitempty"`
+	// The number of events dropped because a subscription's rate
+	// limit was exceeded
+	RateLimitedEvents uint64 `protobuf:"varint,5,opt,name=rate_limited_events,json=rateLimitedEvents" json:"rate_limited_events,omitempty"`
+	// The number of records that could not be decoded
+	DecodeErrors uint64 `protobuf:"varint,6,opt,name=decode_errors,json=decodeErrors" json:"decode_errors,omitempty"`
+	// The number of events dropped from the stream because the client
+	// was not reading them quickly enough
+	StreamEventsDropped uint64 `protobuf:"varint,7,opt,name=stream_events_dropped,json=streamEventsDropped" json:"stream_events_dropped,omitempty"`
+	// The bytes of allocated heap objects, and the total bytes of
+	// memory obtained from the operating system by the Sensor
+	HeapAllocBytes uint64 `protobuf:"varint,8,opt,name=heap_alloc_bytes,json=heapAllocBytes" json:"heap_alloc_bytes,omitempty"`
+	SysBytes       uint64 `protobuf:"varint,9,opt,name=sys_bytes,json=sysBytes" json:"sys_bytes,omitempty"`
+	// The tracepoints, kprobes, and uprobes that the Sensor has
+	// registered for its subscriptions, e.g. "sched/sched_process_exec"
+	// or "kprobe:do_sys_open"
+	Probes []string `protobuf:"bytes,10,rep,name=probes" json:"probes,omitempty"`
+}
+
+func (m *SensorStatusEvent) Reset()                    { *m = SensorStatusEvent{} }
+func (m *SensorStatusEvent) String() string            { return proto.CompactTextString(m) }
+func (*SensorStatusEvent) ProtoMessage()               {}
+func (*SensorStatusEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+
+func (m *SensorStatusEvent) GetUptimeNanos() int64 {
+	if m != nil {
+		return m.UptimeNanos
+	}
+	return 0
+}
+
+func (m *SensorStatusEvent) GetEventsReceived() uint64 {
+	if m != nil {
+		return m.EventsReceived
+	}
+	return 0
+}
+
+func (m *SensorStatusEvent) GetEventsPerSecond() float64 {
+	if m != nil {
+		return m.EventsPerSecond
+	}
+	return 0
+}
+
+func (m *SensorStatusEvent) GetLostRecords() uint64 {
+	if m != nil {
+		return m.LostRecords
+	}
+	return 0
+}
+
+func (m *SensorStatusEvent) GetRateLimitedEvents() uint64 {
+	if m != nil {
+		return m.RateLimitedEvents
+	}
+	return 0
+}
+
+func (m *SensorStatusEvent) GetDecodeErrors() uint64 {
+	if m != nil {
+		return m.DecodeErrors
+	}
+	return 0
+}
+
+func (m *SensorStatusEvent) GetStreamEventsDropped() uint64 {
+	if m != nil {
+		return m.StreamEventsDropped
+	}
+	return 0
+}
+
+func (m *SensorStatusEvent) GetHeapAllocBytes() uint64 {
+	if m != nil {
+		return m.HeapAllocBytes
+	}
+	return 0
+}
+
+func (m *SensorStatusEvent) GetSysBytes() uint64 {
+	if m != nil {
+		return m.SysBytes
+	}
+	return 0
+}
+
+func (m *SensorStatusEvent) GetProbes() []string {
+	if m != nil {
+		return m.Probes
+	}
+	return nil
+}
+
 type TickerEvent struct {
 	// The number of seconds elapsed since January 1, 1970 UTC.
 	//
@@ -847,7 +1849,7 @@ type TickerEvent struct {
 func (m *TickerEvent) Reset()                    { *m = TickerEvent{} }
 func (m *TickerEvent) String() string            { return proto.CompactTextString(m) }
 func (*TickerEvent) ProtoMessage()               {}
-func (*TickerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+func (*TickerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
 
 func (m *TickerEvent) GetSeconds() int64 {
 	if m != nil {
@@ -863,6 +1865,87 @@ func (m *TickerEvent) GetNanoseconds() int64 {
 	return 0
 }
 
+// BpfEvent describes the use of the bpf(2) system call to load BPF programs
+// into the kernel and attach them. Requires Linux 4.15 or later.
+type BpfEvent struct {
+	// The type of event described by this BpfEvent message
+	Type BpfEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.BpfEventType" json:"type,omitempty"`
+	// Present when the event is a program load event. This is the
+	// BPF_PROG_TYPE_* type of the program (i.e. BPF_PROG_TYPE_KPROBE
+	// is 2).
+	LoadProgramType uint32 `protobuf:"varint,10,opt,name=load_program_type,json=loadProgramType" json:"load_program_type,omitempty"`
+	// Present when the event is a program load event. This is the name
+	// the process gave the program, if any.
+	LoadProgramName string `protobuf:"bytes,11,opt,name=load_program_name,json=loadProgramName" json:"load_program_name,omitempty"`
+	// Present when the event is a program load event. This is the
+	// number of instructions in the program.
+	LoadInstructionCount uint32 `protobuf:"varint,12,opt,name=load_instruction_count,json=loadInstructionCount" json:"load_instruction_count,omitempty"`
+	// Present when the event is a program attach event. This is the
+	// BPF_* attach type (i.e. BPF_CGROUP_INET_INGRESS is 0).
+	AttachType uint32 `protobuf:"varint,20,opt,name=attach_type,json=attachType" json:"attach_type,omitempty"`
+	// Present when the event is a program attach event. This is the
+	// file descriptor of the program being attached.
+	AttachProgramFd int32 `protobuf:"zigzag32,21,opt,name=attach_program_fd,json=attachProgramFd" json:"attach_program_fd,omitempty"`
+	// Present when the event is a program attach event. This is the
+	// file descriptor of the object the program is attached to (i.e.
+	// a cgroup directory).
+	AttachTargetFd int32 `protobuf:"zigzag32,22,opt,name=attach_target_fd,json=attachTargetFd" json:"attach_target_fd,omitempty"`
+}
+
+func (m *BpfEvent) Reset()                    { *m = BpfEvent{} }
+func (m *BpfEvent) String() string            { return proto.CompactTextString(m) }
+func (*BpfEvent) ProtoMessage()               {}
+func (*BpfEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+
+func (m *BpfEvent) GetType() BpfEventType {
+	if m != nil {
+		return m.Type
+	}
+	return BpfEventType_BPF_EVENT_TYPE_UNKNOWN
+}
+
+func (m *BpfEvent) GetLoadProgramType() uint32 {
+	if m != nil {
+		return m.LoadProgramType
+	}
+	return 0
+}
+
+func (m *BpfEvent) GetLoadProgramName() string {
+	if m != nil {
+		return m.LoadProgramName
+	}
+	return ""
+}
+
+func (m *BpfEvent) GetLoadInstructionCount() uint32 {
+	if m != nil {
+		return m.LoadInstructionCount
+	}
+	return 0
+}
+
+func (m *BpfEvent) GetAttachType() uint32 {
+	if m != nil {
+		return m.AttachType
+	}
+	return 0
+}
+
+func (m *BpfEvent) GetAttachProgramFd() int32 {
+	if m != nil {
+		return m.AttachProgramFd
+	}
+	return 0
+}
+
+func (m *BpfEvent) GetAttachTargetFd() int32 {
+	if m != nil {
+		return m.AttachTargetFd
+	}
+	return 0
+}
+
 // ContainerEvent describes a Docker container or Rkt App lifecycle event
 type ContainerEvent struct {
 	Type ContainerEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ContainerEventType" json:"type,omitempty"`
@@ -874,8 +1957,16 @@ type ContainerEvent struct {
 	// "gcr.io/google_containers/nginx-ingress-controller")
 	//
 	ImageName string `protobuf:"bytes,11,opt,name=image_name,json=imageName" json:"image_name,omitempty"`
+	// The registry digest of the container image for image_name's
+	// repository, if known (i.e. "sha256:...")
+	ImageDigest string `protobuf:"bytes,12,opt,name=image_digest,json=imageDigest" json:"image_digest,omitempty"`
+	// Labels defined by the container image, if known
+	ImageLabels map[string]string `protobuf:"bytes,13,rep,name=image_labels,json=imageLabels" json:"image_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Host process identifier of the container's init process.
 	HostPid int32 `protobuf:"zigzag32,20,opt,name=host_pid,json=hostPid" json:"host_pid,omitempty"`
+	// The Sensor's cached information about the process identified by
+	// host_pid, if it is known.
+	HostProcess *ProcessInfo `protobuf:"bytes,21,opt,name=host_process,json=hostProcess" json:"host_process,omitempty"`
 	// Optional, only included on CONTAINER_EVENT_TYPE_EXIT events
 	ExitCode int32 `protobuf:"zigzag32,30,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
 	// The exit status will typically one of the values defined in
@@ -887,6 +1978,11 @@ type ContainerEvent struct {
 	// If true, indicates that the process dumped a core when
 	// it terminated.
 	ExitCoreDumped bool `protobuf:"varint,33,opt,name=exit_core_dumped,json=exitCoreDumped" json:"exit_core_dumped,omitempty"`
+	// The result of the container's most recent health check (i.e.
+	// "starting", "healthy", or "unhealthy"). Empty if the container
+	// has no health check. CONTAINER_EVENT_TYPE_HEALTH events are
+	// sent when this changes.
+	HealthStatus string `protobuf:"bytes,40,opt,name=health_status,json=healthStatus" json:"health_status,omitempty"`
 	// Docker container configuration file
 	DockerConfigJson string `protobuf:"bytes,100,opt,name=docker_config_json,json=dockerConfigJson" json:"docker_config_json,omitempty"`
 	// OCI container configuration file
@@ -896,7 +1992,7 @@ type ContainerEvent struct {
 func (m *ContainerEvent) Reset()                    { *m = ContainerEvent{} }
 func (m *ContainerEvent) String() string            { return proto.CompactTextString(m) }
 func (*ContainerEvent) ProtoMessage()               {}
-func (*ContainerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+func (*ContainerEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
 
 func (m *ContainerEvent) GetType() ContainerEventType {
 	if m != nil {
@@ -926,6 +2022,20 @@ func (m *ContainerEvent) GetImageName() string {
 	return ""
 }
 
+func (m *ContainerEvent) GetImageDigest() string {
+	if m != nil {
+		return m.ImageDigest
+	}
+	return ""
+}
+
+func (m *ContainerEvent) GetImageLabels() map[string]string {
+	if m != nil {
+		return m.ImageLabels
+	}
+	return nil
+}
+
 func (m *ContainerEvent) GetHostPid() int32 {
 	if m != nil {
 		return m.HostPid
@@ -933,6 +2043,13 @@ func (m *ContainerEvent) GetHostPid() int32 {
 	return 0
 }
 
+func (m *ContainerEvent) GetHostProcess() *ProcessInfo {
+	if m != nil {
+		return m.HostProcess
+	}
+	return nil
+}
+
 func (m *ContainerEvent) GetExitCode() int32 {
 	if m != nil {
 		return m.ExitCode
@@ -961,6 +2078,13 @@ func (m *ContainerEvent) GetExitCoreDumped() bool {
 	return false
 }
 
+func (m *ContainerEvent) GetHealthStatus() string {
+	if m != nil {
+		return m.HealthStatus
+	}
+	return ""
+}
+
 func (m *ContainerEvent) GetDockerConfigJson() string {
 	if m != nil {
 		return m.DockerConfigJson
@@ -975,26 +2099,463 @@ func (m *ContainerEvent) GetOciConfigJson() string {
 	return ""
 }
 
-// ProcessEvent describes an event that occurred related to processes starting
-// and exiting as detected by the Sensor.
-type ProcessEvent struct {
-	// The type of event described by this ProcessEvent message
-	Type ProcessEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ProcessEventType" json:"type,omitempty"`
-	// Present when the event is a fork event. This is the PID of the
-	// new child process.
-	ForkChildPid int32 `protobuf:"zigzag32,10,opt,name=fork_child_pid,json=forkChildPid" json:"fork_child_pid,omitempty"`
-	// Present when the event is a fork event. This is the Sensor's process
-	// ID of the new child process.
-	ForkChildId string `protobuf:"bytes,11,opt,name=fork_child_id,json=forkChildId" json:"fork_child_id,omitempty"`
-	// Present when the event is an exec event. This is the filename of the
-	// executable that was executed.
-	ExecFilename string `protobuf:"bytes,20,opt,name=exec_filename,json=execFilename" json:"exec_filename,omitempty"`
-	// Present when the event is an exec event. Repeated for each argument
-	// passed to the executable on the command-line.
-	ExecCommandLine []string `protobuf:"bytes,21,rep,name=exec_command_line,json=execCommandLine" json:"exec_command_line,omitempty"`
-	// Present when the event is an exit event. This is the exit code that
-	// the process exited with.
-	ExitCode int32 `protobuf:"zigzag32,30,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+// ImageEvent describes a container image being pulled, tagged, or removed
+// on the host
+type ImageEvent struct {
+	Type ImageEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ImageEventType" json:"type,omitempty"`
+	// Unique identifier of the image
+	ImageId string `protobuf:"bytes,2,opt,name=image_id,json=imageId" json:"image_id,omitempty"`
+	//
+	// The image reference involved in the event (i.e. "busybox:latest"
+	// or "gcr.io/google_containers/nginx-ingress-controller:0.9.0")
+	//
+	ImageName string `protobuf:"bytes,3,opt,name=image_name,json=imageName" json:"image_name,omitempty"`
+	// The registry portion of image_name (i.e. "docker.io" or "gcr.io")
+	Registry string `protobuf:"bytes,4,opt,name=registry" json:"registry,omitempty"`
+	// The registry digest of the image for image_name's repository, if
+	// known (i.e. "sha256:...")
+	Digest string `protobuf:"bytes,5,opt,name=digest" json:"digest,omitempty"`
+	// All known tags for the image
+	RepoTags []string `protobuf:"bytes,6,rep,name=repo_tags,json=repoTags" json:"repo_tags,omitempty"`
+	// All known registry digests for the image
+	RepoDigests []string `protobuf:"bytes,7,rep,name=repo_digests,json=repoDigests" json:"repo_digests,omitempty"`
+}
+
+func (m *ImageEvent) Reset()                    { *m = ImageEvent{} }
+func (m *ImageEvent) String() string            { return proto.CompactTextString(m) }
+func (*ImageEvent) ProtoMessage()               {}
+func (*ImageEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+
+func (m *ImageEvent) GetType() ImageEventType {
+	if m != nil {
+		return m.Type
+	}
+	return ImageEventType_IMAGE_EVENT_TYPE_UNKNOWN
+}
+
+func (m *ImageEvent) GetImageId() string {
+	if m != nil {
+		return m.ImageId
+	}
+	return ""
+}
+
+func (m *ImageEvent) GetImageName() string {
+	if m != nil {
+		return m.ImageName
+	}
+	return ""
+}
+
+func (m *ImageEvent) GetRegistry() string {
+	if m != nil {
+		return m.Registry
+	}
+	return ""
+}
+
+func (m *ImageEvent) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *ImageEvent) GetRepoTags() []string {
+	if m != nil {
+		return m.RepoTags
+	}
+	return nil
+}
+
+func (m *ImageEvent) GetRepoDigests() []string {
+	if m != nil {
+		return m.RepoDigests
+	}
+	return nil
+}
+
+// IoUringEvent describes the use of io_uring, which allows processes to
+// perform file and network I/O without making the system calls that are
+// reported by other events. Requires Linux 5.5 or later.
+type IoUringEvent struct {
+	// The type of event described by this IoUringEvent message
+	Type IoUringEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.IoUringEventType" json:"type,omitempty"`
+	// Present when the event is a setup event. This is the file
+	// descriptor of the new io_uring instance.
+	SetupFd int32 `protobuf:"zigzag32,10,opt,name=setup_fd,json=setupFd" json:"setup_fd,omitempty"`
+	// Present when the event is a setup event. This is the number of
+	// entries in the submission queue.
+	SetupSqEntries uint32 `protobuf:"varint,11,opt,name=setup_sq_entries,json=setupSqEntries" json:"setup_sq_entries,omitempty"`
+	// Present when the event is a setup event. This is the number of
+	// entries in the completion queue.
+	SetupCqEntries uint32 `protobuf:"varint,12,opt,name=setup_cq_entries,json=setupCqEntries" json:"setup_cq_entries,omitempty"`
+	// Present when the event is a setup event. These are the
+	// IORING_SETUP_* flags the instance was created with.
+	SetupFlags uint32 `protobuf:"varint,13,opt,name=setup_flags,json=setupFlags" json:"setup_flags,omitempty"`
+	// Present when the event is a submit event. This is the operation
+	// that was submitted (i.e. IORING_OP_READV is 1).
+	SubmitOpcode uint32 `protobuf:"varint,20,opt,name=submit_opcode,json=submitOpcode" json:"submit_opcode,omitempty"`
+	// Present when the event is a submit event. This is the value the
+	// process associated with the operation.
+	SubmitUserData uint64 `protobuf:"varint,21,opt,name=submit_user_data,json=submitUserData" json:"submit_user_data,omitempty"`
+	// Present when the event is a submit event. This is true if the
+	// operation was submitted by the kernel's submission queue polling
+	// thread rather than by a system call.
+	SubmitSqThread bool `protobuf:"varint,22,opt,name=submit_sq_thread,json=submitSqThread" json:"submit_sq_thread,omitempty"`
+}
+
+func (m *IoUringEvent) Reset()                    { *m = IoUringEvent{} }
+func (m *IoUringEvent) String() string            { return proto.CompactTextString(m) }
+func (*IoUringEvent) ProtoMessage()               {}
+func (*IoUringEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+
+func (m *IoUringEvent) GetType() IoUringEventType {
+	if m != nil {
+		return m.Type
+	}
+	return IoUringEventType_IO_URING_EVENT_TYPE_UNKNOWN
+}
+
+func (m *IoUringEvent) GetSetupFd() int32 {
+	if m != nil {
+		return m.SetupFd
+	}
+	return 0
+}
+
+func (m *IoUringEvent) GetSetupSqEntries() uint32 {
+	if m != nil {
+		return m.SetupSqEntries
+	}
+	return 0
+}
+
+func (m *IoUringEvent) GetSetupCqEntries() uint32 {
+	if m != nil {
+		return m.SetupCqEntries
+	}
+	return 0
+}
+
+func (m *IoUringEvent) GetSetupFlags() uint32 {
+	if m != nil {
+		return m.SetupFlags
+	}
+	return 0
+}
+
+func (m *IoUringEvent) GetSubmitOpcode() uint32 {
+	if m != nil {
+		return m.SubmitOpcode
+	}
+	return 0
+}
+
+func (m *IoUringEvent) GetSubmitUserData() uint64 {
+	if m != nil {
+		return m.SubmitUserData
+	}
+	return 0
+}
+
+func (m *IoUringEvent) GetSubmitSqThread() bool {
+	if m != nil {
+		return m.SubmitSqThread
+	}
+	return false
+}
+
+// KernelModuleEvent describes a kernel module being loaded or unloaded as
+// detected by the Sensor. The process associated with the event is the one
+// that called init_module(2), finit_module(2), or delete_module(2).
+type KernelModuleEvent struct {
+	// The type of event described by this KernelModuleEvent message
+	Type KernelModuleEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.KernelModuleEventType" json:"type,omitempty"`
+	// The name of the kernel module
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *KernelModuleEvent) Reset()                    { *m = KernelModuleEvent{} }
+func (m *KernelModuleEvent) String() string            { return proto.CompactTextString(m) }
+func (*KernelModuleEvent) ProtoMessage()               {}
+func (*KernelModuleEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+
+func (m *KernelModuleEvent) GetType() KernelModuleEventType {
+	if m != nil {
+		return m.Type
+	}
+	return KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNKNOWN
+}
+
+func (m *KernelModuleEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// LsmEvent describes a decision made by a Linux Security Module (SELinux or
+// AppArmor) as reported by the kernel audit subsystem. The process associated
+// with the event is the one whose access was denied. Reading audit records
+// requires Linux 3.16 or later and CAP_AUDIT_READ.
+type LsmEvent struct {
+	// The type of event described by this LsmEvent message
+	Type LsmEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.LsmEventType" json:"type,omitempty"`
+	// The security module that made the decision ("selinux" or
+	// "apparmor")
+	Module string `protobuf:"bytes,2,opt,name=module" json:"module,omitempty"`
+	// The operation that was denied. For SELinux this is the list of
+	// denied permissions (i.e. "read write"); for AppArmor it is the
+	// operation (i.e. "open").
+	Operation string `protobuf:"bytes,3,opt,name=operation" json:"operation,omitempty"`
+	// The security context of the subject. For SELinux this is the
+	// source context; for AppArmor it is the profile name.
+	Subject string `protobuf:"bytes,4,opt,name=subject" json:"subject,omitempty"`
+	// The security context of the object. For SELinux this is the
+	// target context; it is not set for AppArmor.
+	Object string `protobuf:"bytes,5,opt,name=object" json:"object,omitempty"`
+	// The class of the object (i.e. "file"), if reported
+	ObjectClass string `protobuf:"bytes,6,opt,name=object_class,json=objectClass" json:"object_class,omitempty"`
+	// The name or path of the object, if reported
+	Name string `protobuf:"bytes,7,opt,name=name" json:"name,omitempty"`
+	// The complete audit message text
+	Message string `protobuf:"bytes,8,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *LsmEvent) Reset()                    { *m = LsmEvent{} }
+func (m *LsmEvent) String() string            { return proto.CompactTextString(m) }
+func (*LsmEvent) ProtoMessage()               {}
+func (*LsmEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+
+func (m *LsmEvent) GetType() LsmEventType {
+	if m != nil {
+		return m.Type
+	}
+	return LsmEventType_LSM_EVENT_TYPE_UNKNOWN
+}
+
+func (m *LsmEvent) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
+func (m *LsmEvent) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+func (m *LsmEvent) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *LsmEvent) GetObject() string {
+	if m != nil {
+		return m.Object
+	}
+	return ""
+}
+
+func (m *LsmEvent) GetObjectClass() string {
+	if m != nil {
+		return m.ObjectClass
+	}
+	return ""
+}
+
+func (m *LsmEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LsmEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// MemoryEvent describes a change to the memory mappings of a process as
+// detected by the Sensor. The event is reported when the system call is made,
+// so it may describe an attempt that fails.
+type MemoryEvent struct {
+	// The type of event described by this MemoryEvent message
+	Type MemoryEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.MemoryEventType" json:"type,omitempty"`
+	// The start address of the memory region. For mmap(2) this is the
+	// address requested by the process, which is only a hint unless
+	// MAP_FIXED is set and is usually 0.
+	Address uint64 `protobuf:"varint,2,opt,name=address" json:"address,omitempty"`
+	// The length of the memory region in bytes
+	Length uint64 `protobuf:"varint,3,opt,name=length" json:"length,omitempty"`
+	// The memory protection flags requested (PROT_*)
+	Prot uint32 `protobuf:"varint,4,opt,name=prot" json:"prot,omitempty"`
+	// The mmap(2) flags requested (MAP_*); only set for mmap events
+	Flags uint32 `protobuf:"varint,5,opt,name=flags" json:"flags,omitempty"`
+	// The memory protection flags (PROT_*) of the region before the
+	// change; only set for mprotect events
+	OldProt uint32 `protobuf:"varint,6,opt,name=old_prot,json=oldProt" json:"old_prot,omitempty"`
+}
+
+func (m *MemoryEvent) Reset()                    { *m = MemoryEvent{} }
+func (m *MemoryEvent) String() string            { return proto.CompactTextString(m) }
+func (*MemoryEvent) ProtoMessage()               {}
+func (*MemoryEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+
+func (m *MemoryEvent) GetType() MemoryEventType {
+	if m != nil {
+		return m.Type
+	}
+	return MemoryEventType_MEMORY_EVENT_TYPE_UNKNOWN
+}
+
+func (m *MemoryEvent) GetAddress() uint64 {
+	if m != nil {
+		return m.Address
+	}
+	return 0
+}
+
+func (m *MemoryEvent) GetLength() uint64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *MemoryEvent) GetProt() uint32 {
+	if m != nil {
+		return m.Prot
+	}
+	return 0
+}
+
+func (m *MemoryEvent) GetFlags() uint32 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+func (m *MemoryEvent) GetOldProt() uint32 {
+	if m != nil {
+		return m.OldProt
+	}
+	return 0
+}
+
+// MountEvent describes a call to mount(2) or umount2(2) as detected by the
+// Sensor. The event is reported when the system call is made, so it may
+// describe an attempt that fails.
+type MountEvent struct {
+	// The type of event described by this MountEvent message
+	Type MountEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.MountEventType" json:"type,omitempty"`
+	// The device or filesystem being mounted; only set for mount events
+	Source string `protobuf:"bytes,2,opt,name=source" json:"source,omitempty"`
+	// The mount point
+	Target string `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	// The type of the filesystem being mounted; only set for mount
+	// events
+	Fstype string `protobuf:"bytes,4,opt,name=fstype" json:"fstype,omitempty"`
+	// The flags passed to mount(2) or umount2(2)
+	Flags uint64 `protobuf:"varint,5,opt,name=flags" json:"flags,omitempty"`
+}
+
+func (m *MountEvent) Reset()                    { *m = MountEvent{} }
+func (m *MountEvent) String() string            { return proto.CompactTextString(m) }
+func (*MountEvent) ProtoMessage()               {}
+func (*MountEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{12} }
+
+func (m *MountEvent) GetType() MountEventType {
+	if m != nil {
+		return m.Type
+	}
+	return MountEventType_MOUNT_EVENT_TYPE_UNKNOWN
+}
+
+func (m *MountEvent) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *MountEvent) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *MountEvent) GetFstype() string {
+	if m != nil {
+		return m.Fstype
+	}
+	return ""
+}
+
+func (m *MountEvent) GetFlags() uint64 {
+	if m != nil {
+		return m.Flags
+	}
+	return 0
+}
+
+// ProcessEvent describes an event that occurred related to processes starting
+// and exiting as detected by the Sensor.
+type ProcessEvent struct {
+	// The type of event described by this ProcessEvent message
+	Type ProcessEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.ProcessEventType" json:"type,omitempty"`
+	// Present when the event is a fork event. This is the PID of the
+	// new child process.
+	ForkChildPid int32 `protobuf:"zigzag32,10,opt,name=fork_child_pid,json=forkChildPid" json:"fork_child_pid,omitempty"`
+	// Present when the event is a fork event. This is the Sensor's process
+	// ID of the new child process.
+	ForkChildId string `protobuf:"bytes,11,opt,name=fork_child_id,json=forkChildId" json:"fork_child_id,omitempty"`
+	// Present when the event is a fork event. This is the TGID of the
+	// new child process, which is the same as fork_parent_tgid if the
+	// child is a new thread in the parent's thread group.
+	ForkChildTgid int32 `protobuf:"zigzag32,12,opt,name=fork_child_tgid,json=forkChildTgid" json:"fork_child_tgid,omitempty"`
+	// Present when the event is a fork event. This is the set of CLONE_*
+	// flags passed to clone(2) (e.g., CLONE_THREAD, CLONE_NEWNS, etc.).
+	ForkCloneFlags uint64 `protobuf:"varint,13,opt,name=fork_clone_flags,json=forkCloneFlags" json:"fork_clone_flags,omitempty"`
+	// Present when the event is a fork event. This is the PID of the
+	// task that created the new child process.
+	ForkParentPid int32 `protobuf:"zigzag32,14,opt,name=fork_parent_pid,json=forkParentPid" json:"fork_parent_pid,omitempty"`
+	// Present when the event is a fork event. This is the TGID of the
+	// task that created the new child process.
+	ForkParentTgid int32 `protobuf:"zigzag32,15,opt,name=fork_parent_tgid,json=forkParentTgid" json:"fork_parent_tgid,omitempty"`
+	// Present when the event is an exec event. This is the filename of the
+	// executable that was executed.
+	ExecFilename string `protobuf:"bytes,20,opt,name=exec_filename,json=execFilename" json:"exec_filename,omitempty"`
+	// Present when the event is an exec event. Repeated for each argument
+	// passed to the executable on the command-line.
+	ExecCommandLine []string `protobuf:"bytes,21,rep,name=exec_command_line,json=execCommandLine" json:"exec_command_line,omitempty"`
+	// Present when the event is an exec event. This is true if the
+	// executable or its interpreter is an anonymous memory file created
+	// by memfd_create(2), which is commonly used to run programs that
+	// are never written to a filesystem.
+	ExecFileless bool `protobuf:"varint,22,opt,name=exec_fileless,json=execFileless" json:"exec_fileless,omitempty"`
+	// Present when the event is an exec event and the Sensor is
+	// configured to hash executables. This is the hex encoded SHA-256
+	// hash of the executed program, if it could be read.
+	ExecSha256 string `protobuf:"bytes,23,opt,name=exec_sha256,json=execSha256" json:"exec_sha256,omitempty"`
+	// Present when the event is an exec event and the subscription
+	// captures exec environments. Repeated for each environment
+	// variable of the process, as "NAME=value", that the Sensor is
+	// configured to capture; all others are stripped.
+	ExecEnvironment []string `protobuf:"bytes,24,rep,name=exec_environment,json=execEnvironment" json:"exec_environment,omitempty"`
+	// Present when the event is an exit event. This is the exit code that
+	// the process exited with.
+	ExitCode int32 `protobuf:"zigzag32,30,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
 	// Present when the event is an exit event. This will typically be one9
 	// of the values defined in stdlib.h like EXIT_SUCCESS, EXIT_FAILURE,
 	// or EXIT_USAGE.
@@ -1005,84 +2566,624 @@ type ProcessEvent struct {
 	// Present when the event is an exit event. If true, indicates that the
 	// process dumped a core when it terminated.
 	ExitCoreDumped bool `protobuf:"varint,33,opt,name=exit_core_dumped,json=exitCoreDumped" json:"exit_core_dumped,omitempty"`
+	// Present when the event is an exit event. This is the TGID of the
+	// exiting task. It is the same as the task's PID if the task is the
+	// thread group leader.
+	ExitTgid int32 `protobuf:"zigzag32,34,opt,name=exit_tgid,json=exitTgid" json:"exit_tgid,omitempty"`
+	// Present when the event is an exit event. This is the PID of the
+	// parent of the exiting task's thread group, if it is known.
+	ExitParentPid int32 `protobuf:"zigzag32,35,opt,name=exit_parent_pid,json=exitParentPid" json:"exit_parent_pid,omitempty"`
+	// Present when the event is an exit event. This is the TGID of the
+	// parent of the exiting task's thread group, if it is known.
+	ExitParentTgid int32 `protobuf:"zigzag32,36,opt,name=exit_parent_tgid,json=exitParentTgid" json:"exit_parent_tgid,omitempty"`
 	// Present when the event is an update event that informs of an update
 	// to the process's current working directory.
 	UpdateCwd string `protobuf:"bytes,40,opt,name=update_cwd,json=updateCwd" json:"update_cwd,omitempty"`
+	// Present when the event is a credential change event. These are the
+	// credentials that the process had before the change.
+	CredChangeOld *Credentials `protobuf:"bytes,50,opt,name=cred_change_old,json=credChangeOld" json:"cred_change_old,omitempty"`
+	// Present when the event is a credential change event. These are the
+	// credentials that the process has after the change.
+	CredChangeNew *Credentials `protobuf:"bytes,51,opt,name=cred_change_new,json=credChangeNew" json:"cred_change_new,omitempty"`
+	// Present when the event is a capability change event. This is the
+	// set of capabilities that were added to the process's permitted or
+	// effective sets. Bit n corresponds to capability number n.
+	CapChangeGained uint64 `protobuf:"varint,60,opt,name=cap_change_gained,json=capChangeGained" json:"cap_change_gained,omitempty"`
+	// Present when the event is a capability change event. This is the
+	// process's permitted capability set after the change.
+	CapChangePermitted uint64 `protobuf:"varint,61,opt,name=cap_change_permitted,json=capChangePermitted" json:"cap_change_permitted,omitempty"`
+	// Present when the event is a capability change event. This is the
+	// process's effective capability set after the change.
+	CapChangeEffective uint64 `protobuf:"varint,62,opt,name=cap_change_effective,json=capChangeEffective" json:"cap_change_effective,omitempty"`
+	// Present when the event is a capability change event. This is the
+	// process's permitted capability set before the change.
+	CapChangeOldPermitted uint64 `protobuf:"varint,63,opt,name=cap_change_old_permitted,json=capChangeOldPermitted" json:"cap_change_old_permitted,omitempty"`
+	// Present when the event is a capability change event. This is the
+	// process's effective capability set before the change.
+	CapChangeOldEffective uint64 `protobuf:"varint,64,opt,name=cap_change_old_effective,json=capChangeOldEffective" json:"cap_change_old_effective,omitempty"`
+	// Present when the event is a ptrace attach event. This is the
+	// ptrace(2) request that was made.
+	PtraceRequest int64 `protobuf:"zigzag64,70,opt,name=ptrace_request,json=ptraceRequest" json:"ptrace_request,omitempty"`
+	// Present when the event is a ptrace attach event. This is the PID
+	// of the tracing process. For PTRACE_TRACEME requests, this is the
+	// parent of the process that made the request.
+	PtraceTracerPid int32 `protobuf:"zigzag32,71,opt,name=ptrace_tracer_pid,json=ptraceTracerPid" json:"ptrace_tracer_pid,omitempty"`
+	// Present when the event is a ptrace attach event. This is the
+	// Sensor's process identifier for the tracing process.
+	PtraceTracerProcessId string `protobuf:"bytes,72,opt,name=ptrace_tracer_process_id,json=ptraceTracerProcessId" json:"ptrace_tracer_process_id,omitempty"`
+	// Present when the event is a ptrace attach event. This is the
+	// container ID of the tracing process, if any.
+	PtraceTracerContainerId string `protobuf:"bytes,73,opt,name=ptrace_tracer_container_id,json=ptraceTracerContainerId" json:"ptrace_tracer_container_id,omitempty"`
+	// Present when the event is a ptrace attach event. This is the PID
+	// of the traced process, translated into the Sensor's PID
+	// namespace.
+	PtraceTraceePid int32 `protobuf:"zigzag32,74,opt,name=ptrace_tracee_pid,json=ptraceTraceePid" json:"ptrace_tracee_pid,omitempty"`
+	// Present when the event is a ptrace attach event. This is the
+	// Sensor's process identifier for the traced process.
+	PtraceTraceeProcessId string `protobuf:"bytes,75,opt,name=ptrace_tracee_process_id,json=ptraceTraceeProcessId" json:"ptrace_tracee_process_id,omitempty"`
+	// Present when the event is a ptrace attach event. This is the
+	// container ID of the traced process, if any.
+	PtraceTraceeContainerId string `protobuf:"bytes,76,opt,name=ptrace_tracee_container_id,json=ptraceTraceeContainerId" json:"ptrace_tracee_container_id,omitempty"`
+	// Present when the event is an OOM kill event. This is the PID of
+	// the process whose memory allocation caused the OOM killer to run.
+	OomKillTriggerPid int32 `protobuf:"zigzag32,80,opt,name=oom_kill_trigger_pid,json=oomKillTriggerPid" json:"oom_kill_trigger_pid,omitempty"`
+	// Present when the event is an OOM kill event. This is the total
+	// virtual memory size of the killed process in kilobytes. The
+	// memory sizes are only reported by Linux 6.2 and later.
+	OomKillTotalVm uint64 `protobuf:"varint,81,opt,name=oom_kill_total_vm,json=oomKillTotalVm" json:"oom_kill_total_vm,omitempty"`
+	// Present when the event is an OOM kill event. This is the
+	// anonymous resident memory size of the killed process in
+	// kilobytes.
+	OomKillAnonRss uint64 `protobuf:"varint,82,opt,name=oom_kill_anon_rss,json=oomKillAnonRss" json:"oom_kill_anon_rss,omitempty"`
+	// Present when the event is an OOM kill event. This is the
+	// file-backed resident memory size of the killed process in
+	// kilobytes.
+	OomKillFileRss uint64 `protobuf:"varint,83,opt,name=oom_kill_file_rss,json=oomKillFileRss" json:"oom_kill_file_rss,omitempty"`
+	// Present when the event is an OOM kill event. This is the shared
+	// memory resident size of the killed process in kilobytes.
+	OomKillShmemRss uint64 `protobuf:"varint,84,opt,name=oom_kill_shmem_rss,json=oomKillShmemRss" json:"oom_kill_shmem_rss,omitempty"`
+	// Present when the event is an OOM kill event. This is the
+	// oom_score_adj value of the killed process.
+	OomKillScoreAdj int32 `protobuf:"zigzag32,85,opt,name=oom_kill_score_adj,json=oomKillScoreAdj" json:"oom_kill_score_adj,omitempty"`
+	// Present when the event is a crash event. This is the number of
+	// the fatal signal.
+	CrashSignal int32 `protobuf:"zigzag32,90,opt,name=crash_signal,json=crashSignal" json:"crash_signal,omitempty"`
+	// Present when the event is a crash event. This is the si_code of
+	// the fatal signal, which describes why it was sent.
+	CrashCode int32 `protobuf:"zigzag32,91,opt,name=crash_code,json=crashCode" json:"crash_code,omitempty"`
+	// Present when the event is a crash event. This is the path of the
+	// executable that was running when the process crashed. It may be
+	// empty if the process exited before the event could be decoded.
+	CrashExecutable string `protobuf:"bytes,92,opt,name=crash_executable,json=crashExecutable" json:"crash_executable,omitempty"`
+	// Present when the event is a seccomp violation event. This is the
+	// number of the system call that was stopped.
+	SeccompSyscall int32 `protobuf:"zigzag32,100,opt,name=seccomp_syscall,json=seccompSyscall" json:"seccomp_syscall,omitempty"`
+	// Present when the event is a seccomp violation event. This is the
+	// action taken by the seccomp filter.
+	SeccompAction SeccompAction `protobuf:"varint,101,opt,name=seccomp_action,json=seccompAction,enum=capsule8.api.v0.SeccompAction" json:"seccomp_action,omitempty"`
+	// Present when the event is a seccomp violation event. This is the
+	// SECCOMP_RET_DATA portion of the filter's return value.
+	SeccompData uint32 `protobuf:"varint,102,opt,name=seccomp_data,json=seccompData" json:"seccomp_data,omitempty"`
+	// Present when the event is a seccomp violation event. This is the
+	// name of the system call that was stopped, if it is known.
+	SeccompSyscallName string `protobuf:"bytes,103,opt,name=seccomp_syscall_name,json=seccompSyscallName" json:"seccomp_syscall_name,omitempty"`
 }
 
 func (m *ProcessEvent) Reset()                    { *m = ProcessEvent{} }
 func (m *ProcessEvent) String() string            { return proto.CompactTextString(m) }
 func (*ProcessEvent) ProtoMessage()               {}
-func (*ProcessEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (*ProcessEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{13} }
 
 func (m *ProcessEvent) GetType() ProcessEventType {
 	if m != nil {
 		return m.Type
 	}
-	return ProcessEventType_PROCESS_EVENT_TYPE_UNKNOWN
+	return ProcessEventType_PROCESS_EVENT_TYPE_UNKNOWN
+}
+
+func (m *ProcessEvent) GetForkChildPid() int32 {
+	if m != nil {
+		return m.ForkChildPid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetForkChildId() string {
+	if m != nil {
+		return m.ForkChildId
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetForkChildTgid() int32 {
+	if m != nil {
+		return m.ForkChildTgid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetForkCloneFlags() uint64 {
+	if m != nil {
+		return m.ForkCloneFlags
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetForkParentPid() int32 {
+	if m != nil {
+		return m.ForkParentPid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetForkParentTgid() int32 {
+	if m != nil {
+		return m.ForkParentTgid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetExecFilename() string {
+	if m != nil {
+		return m.ExecFilename
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetExecCommandLine() []string {
+	if m != nil {
+		return m.ExecCommandLine
+	}
+	return nil
+}
+
+func (m *ProcessEvent) GetExecFileless() bool {
+	if m != nil {
+		return m.ExecFileless
+	}
+	return false
+}
+
+func (m *ProcessEvent) GetExecSha256() string {
+	if m != nil {
+		return m.ExecSha256
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetExecEnvironment() []string {
+	if m != nil {
+		return m.ExecEnvironment
+	}
+	return nil
+}
+
+func (m *ProcessEvent) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetExitStatus() uint32 {
+	if m != nil {
+		return m.ExitStatus
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetExitSignal() uint32 {
+	if m != nil {
+		return m.ExitSignal
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetExitCoreDumped() bool {
+	if m != nil {
+		return m.ExitCoreDumped
+	}
+	return false
+}
+
+func (m *ProcessEvent) GetExitTgid() int32 {
+	if m != nil {
+		return m.ExitTgid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetExitParentPid() int32 {
+	if m != nil {
+		return m.ExitParentPid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetExitParentTgid() int32 {
+	if m != nil {
+		return m.ExitParentTgid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetUpdateCwd() string {
+	if m != nil {
+		return m.UpdateCwd
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetCredChangeOld() *Credentials {
+	if m != nil {
+		return m.CredChangeOld
+	}
+	return nil
+}
+
+func (m *ProcessEvent) GetCredChangeNew() *Credentials {
+	if m != nil {
+		return m.CredChangeNew
+	}
+	return nil
+}
+
+func (m *ProcessEvent) GetCapChangeGained() uint64 {
+	if m != nil {
+		return m.CapChangeGained
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetCapChangePermitted() uint64 {
+	if m != nil {
+		return m.CapChangePermitted
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetCapChangeEffective() uint64 {
+	if m != nil {
+		return m.CapChangeEffective
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetCapChangeOldPermitted() uint64 {
+	if m != nil {
+		return m.CapChangeOldPermitted
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetCapChangeOldEffective() uint64 {
+	if m != nil {
+		return m.CapChangeOldEffective
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetPtraceRequest() int64 {
+	if m != nil {
+		return m.PtraceRequest
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetPtraceTracerPid() int32 {
+	if m != nil {
+		return m.PtraceTracerPid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetPtraceTracerProcessId() string {
+	if m != nil {
+		return m.PtraceTracerProcessId
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetPtraceTracerContainerId() string {
+	if m != nil {
+		return m.PtraceTracerContainerId
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetPtraceTraceePid() int32 {
+	if m != nil {
+		return m.PtraceTraceePid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetPtraceTraceeProcessId() string {
+	if m != nil {
+		return m.PtraceTraceeProcessId
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetPtraceTraceeContainerId() string {
+	if m != nil {
+		return m.PtraceTraceeContainerId
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetOomKillTriggerPid() int32 {
+	if m != nil {
+		return m.OomKillTriggerPid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetOomKillTotalVm() uint64 {
+	if m != nil {
+		return m.OomKillTotalVm
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetOomKillAnonRss() uint64 {
+	if m != nil {
+		return m.OomKillAnonRss
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetOomKillFileRss() uint64 {
+	if m != nil {
+		return m.OomKillFileRss
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetOomKillShmemRss() uint64 {
+	if m != nil {
+		return m.OomKillShmemRss
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetOomKillScoreAdj() int32 {
+	if m != nil {
+		return m.OomKillScoreAdj
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetCrashSignal() int32 {
+	if m != nil {
+		return m.CrashSignal
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetCrashCode() int32 {
+	if m != nil {
+		return m.CrashCode
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetCrashExecutable() string {
+	if m != nil {
+		return m.CrashExecutable
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetSeccompSyscall() int32 {
+	if m != nil {
+		return m.SeccompSyscall
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetSeccompAction() SeccompAction {
+	if m != nil {
+		return m.SeccompAction
+	}
+	return SeccompAction_SECCOMP_ACTION_UNKNOWN
+}
+
+func (m *ProcessEvent) GetSeccompData() uint32 {
+	if m != nil {
+		return m.SeccompData
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetSeccompSyscallName() string {
+	if m != nil {
+		return m.SeccompSyscallName
+	}
+	return ""
+}
+
+// SessionEvent describes an interactive login session on the host, as
+// recorded in the login accounting file (wtmp). The process associated with
+// the event is the session's login process (i.e. the sshd process serving
+// the session), so that activity in the session can be correlated with it.
+type SessionEvent struct {
+	// The type of event described by this SessionEvent message
+	Type SessionEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SessionEventType" json:"type,omitempty"`
+	// The name of the user that logged in
+	User string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	// The terminal line of the session (i.e. "pts/0")
+	Tty string `protobuf:"bytes,3,opt,name=tty" json:"tty,omitempty"`
+	// The remote host name, if the session is remote
+	Host string `protobuf:"bytes,4,opt,name=host" json:"host,omitempty"`
+	// The remote IP address, if the session is remote
+	Address string `protobuf:"bytes,5,opt,name=address" json:"address,omitempty"`
+	// The session ID recorded for the session, if any
+	SessionId int32 `protobuf:"zigzag32,6,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+}
+
+func (m *SessionEvent) Reset()                    { *m = SessionEvent{} }
+func (m *SessionEvent) String() string            { return proto.CompactTextString(m) }
+func (*SessionEvent) ProtoMessage()               {}
+func (*SessionEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{14} }
+
+func (m *SessionEvent) GetType() SessionEventType {
+	if m != nil {
+		return m.Type
+	}
+	return SessionEventType_SESSION_EVENT_TYPE_UNKNOWN
+}
+
+func (m *SessionEvent) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *SessionEvent) GetTty() string {
+	if m != nil {
+		return m.Tty
+	}
+	return ""
+}
+
+func (m *SessionEvent) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *SessionEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SessionEvent) GetSessionId() int32 {
+	if m != nil {
+		return m.SessionId
+	}
+	return 0
+}
+
+// SignalEvent describes a signal being sent to or delivered to a process as
+// detected by the Sensor.
+type SignalEvent struct {
+	// The type of event described by this SignalEvent message
+	Type SignalEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SignalEventType" json:"type,omitempty"`
+	// The signal number
+	Signal int32 `protobuf:"zigzag32,2,opt,name=signal" json:"signal,omitempty"`
+	// The errno value from the signal information
+	Errno int32 `protobuf:"zigzag32,3,opt,name=errno" json:"errno,omitempty"`
+	// The si_code value from the signal information
+	Code int32 `protobuf:"zigzag32,4,opt,name=code" json:"code,omitempty"`
+	// Present when the event is a generate event. This is the PID of
+	// the process that the signal is sent to.
+	GenerateTargetPid int32 `protobuf:"zigzag32,10,opt,name=generate_target_pid,json=generateTargetPid" json:"generate_target_pid,omitempty"`
+	// Present when the event is a generate event. This is the Sensor's
+	// process identifier for the process that the signal is sent to.
+	GenerateTargetProcessId string `protobuf:"bytes,11,opt,name=generate_target_process_id,json=generateTargetProcessId" json:"generate_target_process_id,omitempty"`
+	// Present when the event is a generate event. This is the container
+	// ID of the process that the signal is sent to, if any.
+	GenerateTargetContainerId string `protobuf:"bytes,12,opt,name=generate_target_container_id,json=generateTargetContainerId" json:"generate_target_container_id,omitempty"`
+	// Present when the event is a generate event. This is true if the
+	// signal is sent to the whole thread group.
+	GenerateGroup bool `protobuf:"varint,13,opt,name=generate_group,json=generateGroup" json:"generate_group,omitempty"`
+	// Present when the event is a generate event. This is the outcome
+	// of generating the signal: 0 (delivered), 1 (ignored), 2 (already
+	// pending), 3 (queue overflow), or 4 (signal information lost).
+	GenerateResult int32 `protobuf:"zigzag32,14,opt,name=generate_result,json=generateResult" json:"generate_result,omitempty"`
+	// Present when the event is a deliver event. This is the address of
+	// the signal handler, or 0 (SIG_DFL) or 1 (SIG_IGN).
+	DeliverSaHandler uint64 `protobuf:"varint,20,opt,name=deliver_sa_handler,json=deliverSaHandler" json:"deliver_sa_handler,omitempty"`
+	// Present when the event is a deliver event. This is the sa_flags
+	// value of the signal handler.
+	DeliverSaFlags uint64 `protobuf:"varint,21,opt,name=deliver_sa_flags,json=deliverSaFlags" json:"deliver_sa_flags,omitempty"`
+}
+
+func (m *SignalEvent) Reset()                    { *m = SignalEvent{} }
+func (m *SignalEvent) String() string            { return proto.CompactTextString(m) }
+func (*SignalEvent) ProtoMessage()               {}
+func (*SignalEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{15} }
+
+func (m *SignalEvent) GetType() SignalEventType {
+	if m != nil {
+		return m.Type
+	}
+	return SignalEventType_SIGNAL_EVENT_TYPE_UNKNOWN
 }
 
-func (m *ProcessEvent) GetForkChildPid() int32 {
+func (m *SignalEvent) GetSignal() int32 {
 	if m != nil {
-		return m.ForkChildPid
+		return m.Signal
 	}
 	return 0
 }
 
-func (m *ProcessEvent) GetForkChildId() string {
+func (m *SignalEvent) GetErrno() int32 {
 	if m != nil {
-		return m.ForkChildId
+		return m.Errno
 	}
-	return ""
+	return 0
 }
 
-func (m *ProcessEvent) GetExecFilename() string {
+func (m *SignalEvent) GetCode() int32 {
 	if m != nil {
-		return m.ExecFilename
+		return m.Code
 	}
-	return ""
+	return 0
 }
 
-func (m *ProcessEvent) GetExecCommandLine() []string {
+func (m *SignalEvent) GetGenerateTargetPid() int32 {
 	if m != nil {
-		return m.ExecCommandLine
+		return m.GenerateTargetPid
 	}
-	return nil
+	return 0
 }
 
-func (m *ProcessEvent) GetExitCode() int32 {
+func (m *SignalEvent) GetGenerateTargetProcessId() string {
 	if m != nil {
-		return m.ExitCode
+		return m.GenerateTargetProcessId
 	}
-	return 0
+	return ""
 }
 
-func (m *ProcessEvent) GetExitStatus() uint32 {
+func (m *SignalEvent) GetGenerateTargetContainerId() string {
 	if m != nil {
-		return m.ExitStatus
+		return m.GenerateTargetContainerId
 	}
-	return 0
+	return ""
 }
 
-func (m *ProcessEvent) GetExitSignal() uint32 {
+func (m *SignalEvent) GetGenerateGroup() bool {
 	if m != nil {
-		return m.ExitSignal
+		return m.GenerateGroup
+	}
+	return false
+}
+
+func (m *SignalEvent) GetGenerateResult() int32 {
+	if m != nil {
+		return m.GenerateResult
 	}
 	return 0
 }
 
-func (m *ProcessEvent) GetExitCoreDumped() bool {
+func (m *SignalEvent) GetDeliverSaHandler() uint64 {
 	if m != nil {
-		return m.ExitCoreDumped
+		return m.DeliverSaHandler
 	}
-	return false
+	return 0
 }
 
-func (m *ProcessEvent) GetUpdateCwd() string {
+func (m *SignalEvent) GetDeliverSaFlags() uint64 {
 	if m != nil {
-		return m.UpdateCwd
+		return m.DeliverSaFlags
 	}
-	return ""
+	return 0
 }
 
 // SyscallEvent describes an event that occurred related to system calls being
@@ -1092,6 +3193,9 @@ type SyscallEvent struct {
 	Type SyscallEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.SyscallEventType" json:"type,omitempty"`
 	// The syscall number for either enter or exit events.
 	Id int64 `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
+	// The name of the syscall for either enter or exit events, if it is
+	// known. System call numbers and names differ between architectures.
+	Name string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
 	// Present when the event is an enter event. This is the first
 	// argument passed to the system call.
 	Arg0 uint64 `protobuf:"varint,10,opt,name=arg0" json:"arg0,omitempty"`
@@ -1118,7 +3222,7 @@ type SyscallEvent struct {
 func (m *SyscallEvent) Reset()                    { *m = SyscallEvent{} }
 func (m *SyscallEvent) String() string            { return proto.CompactTextString(m) }
 func (*SyscallEvent) ProtoMessage()               {}
-func (*SyscallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+func (*SyscallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{16} }
 
 func (m *SyscallEvent) GetType() SyscallEventType {
 	if m != nil {
@@ -1134,6 +3238,13 @@ func (m *SyscallEvent) GetId() int64 {
 	return 0
 }
 
+func (m *SyscallEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 func (m *SyscallEvent) GetArg0() uint64 {
 	if m != nil {
 		return m.Arg0
@@ -1183,13 +3294,53 @@ func (m *SyscallEvent) GetRet() int64 {
 	return 0
 }
 
+// TtyEvent describes input read from a terminal, such as the keystrokes of
+// an interactive shell session. The process associated with the event is the
+// reader.
+type TtyEvent struct {
+	// The type of event described by this TtyEvent message
+	Type TtyEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.TtyEventType" json:"type,omitempty"`
+	// The input that was read. This is only set if the subscription's
+	// TtyEventFilter requested it with include_data.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// The number of bytes of input that were read
+	Length uint64 `protobuf:"varint,3,opt,name=length" json:"length,omitempty"`
+}
+
+func (m *TtyEvent) Reset()                    { *m = TtyEvent{} }
+func (m *TtyEvent) String() string            { return proto.CompactTextString(m) }
+func (*TtyEvent) ProtoMessage()               {}
+func (*TtyEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{17} }
+
+func (m *TtyEvent) GetType() TtyEventType {
+	if m != nil {
+		return m.Type
+	}
+	return TtyEventType_TTY_EVENT_TYPE_UNKNOWN
+}
+
+func (m *TtyEvent) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *TtyEvent) GetLength() uint64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
 // FileEvent describes an event that occurred related to file operations
 // occurring as detected by the Sensor.
 type FileEvent struct {
 	// The type of event described by this FileEvent message
 	Type FileEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.FileEventType" json:"type,omitempty"`
-	// Present when the event is a file open event. This is the filename of
-	// the file being opened.
+	// Present when the event is a file open, write, or memfd create
+	// event. This is the filename of the file being opened or written,
+	// or the name given to the anonymous memory file being created.
 	Filename string `protobuf:"bytes,10,opt,name=filename" json:"filename,omitempty"`
 	// Present when the event is a file open event. This is the set of
 	// flags with which the file was opened (e.g., O_RDONLY, O_NONBLOCK,
@@ -1198,12 +3349,19 @@ type FileEvent struct {
 	// Present when the event is a file open event. This is the set of file
 	// permissions used in a creat(2) system call.
 	OpenMode int32 `protobuf:"zigzag32,12,opt,name=open_mode,json=openMode" json:"open_mode,omitempty"`
+	// Present when the event is a file write event. This is the number of
+	// bytes requested to be written.
+	WriteCount uint64 `protobuf:"varint,13,opt,name=write_count,json=writeCount" json:"write_count,omitempty"`
+	// Present when the event is a memfd create event. This is the set of
+	// flags with which the file was created (e.g., MFD_CLOEXEC,
+	// MFD_ALLOW_SEALING, etc.).
+	MemfdFlags uint32 `protobuf:"varint,14,opt,name=memfd_flags,json=memfdFlags" json:"memfd_flags,omitempty"`
 }
 
 func (m *FileEvent) Reset()                    { *m = FileEvent{} }
 func (m *FileEvent) String() string            { return proto.CompactTextString(m) }
 func (*FileEvent) ProtoMessage()               {}
-func (*FileEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+func (*FileEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{18} }
 
 func (m *FileEvent) GetType() FileEventType {
 	if m != nil {
@@ -1233,15 +3391,35 @@ func (m *FileEvent) GetOpenMode() int32 {
 	return 0
 }
 
+func (m *FileEvent) GetWriteCount() uint64 {
+	if m != nil {
+		return m.WriteCount
+	}
+	return 0
+}
+
+func (m *FileEvent) GetMemfdFlags() uint32 {
+	if m != nil {
+		return m.MemfdFlags
+	}
+	return 0
+}
+
 type Process struct {
 	Pid     int32  `protobuf:"zigzag32,1,opt,name=pid" json:"pid,omitempty"`
 	Command string `protobuf:"bytes,2,opt,name=command" json:"command,omitempty"`
+	// Path of the program that the process is running, if it is known
+	Executable string `protobuf:"bytes,3,opt,name=executable" json:"executable,omitempty"`
+	// Unique process identifier of the process
+	ProcessId string `protobuf:"bytes,4,opt,name=process_id,json=processId" json:"process_id,omitempty"`
+	// Container identifier of the process, if it is in a container
+	ContainerId string `protobuf:"bytes,5,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
 }
 
 func (m *Process) Reset()                    { *m = Process{} }
 func (m *Process) String() string            { return proto.CompactTextString(m) }
 func (*Process) ProtoMessage()               {}
-func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{19} }
 
 func (m *Process) GetPid() int32 {
 	if m != nil {
@@ -1257,6 +3435,242 @@ func (m *Process) GetCommand() string {
 	return ""
 }
 
+func (m *Process) GetExecutable() string {
+	if m != nil {
+		return m.Executable
+	}
+	return ""
+}
+
+func (m *Process) GetProcessId() string {
+	if m != nil {
+		return m.ProcessId
+	}
+	return ""
+}
+
+func (m *Process) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+// Namespaces identifies the namespaces of a task by the inode numbers of its
+// /proc/<pid>/ns files. Tasks in the same namespace have the same inode
+// number for it.
+type Namespaces struct {
+	Net  uint64 `protobuf:"varint,1,opt,name=net" json:"net,omitempty"`
+	Mnt  uint64 `protobuf:"varint,2,opt,name=mnt" json:"mnt,omitempty"`
+	Pid  uint64 `protobuf:"varint,3,opt,name=pid" json:"pid,omitempty"`
+	User uint64 `protobuf:"varint,4,opt,name=user" json:"user,omitempty"`
+}
+
+func (m *Namespaces) Reset()                    { *m = Namespaces{} }
+func (m *Namespaces) String() string            { return proto.CompactTextString(m) }
+func (*Namespaces) ProtoMessage()               {}
+func (*Namespaces) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{20} }
+
+func (m *Namespaces) GetNet() uint64 {
+	if m != nil {
+		return m.Net
+	}
+	return 0
+}
+
+func (m *Namespaces) GetMnt() uint64 {
+	if m != nil {
+		return m.Mnt
+	}
+	return 0
+}
+
+func (m *Namespaces) GetPid() uint64 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *Namespaces) GetUser() uint64 {
+	if m != nil {
+		return m.User
+	}
+	return 0
+}
+
+// ProcessInfo is the Sensor's cached information about a host process.
+type ProcessInfo struct {
+	// Unique process identifier of the process
+	ProcessId string `protobuf:"bytes,1,opt,name=process_id,json=processId" json:"process_id,omitempty"`
+	// Kernel's PID of the process
+	Pid int32 `protobuf:"zigzag32,2,opt,name=pid" json:"pid,omitempty"`
+	// Kernel's TGID of the process
+	Tgid int32 `protobuf:"zigzag32,3,opt,name=tgid" json:"tgid,omitempty"`
+	// Path of the program that the process is running
+	Executable string `protobuf:"bytes,4,opt,name=executable" json:"executable,omitempty"`
+	// The command-line of the process
+	CommandLine []string `protobuf:"bytes,5,rep,name=command_line,json=commandLine" json:"command_line,omitempty"`
+	// Current working directory of the process
+	Cwd string `protobuf:"bytes,6,opt,name=cwd" json:"cwd,omitempty"`
+	// Credentials of the process
+	Credentials *Credentials `protobuf:"bytes,7,opt,name=credentials" json:"credentials,omitempty"`
+	// Monotonic nanosecond timestamp at which the process started
+	StartTime int64 `protobuf:"varint,8,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	// Container identifier of the process, if it is in a container
+	ContainerId string `protobuf:"bytes,9,opt,name=container_id,json=containerId" json:"container_id,omitempty"`
+	// PID of the process as seen inside its own PID namespace
+	NamespacePid int32 `protobuf:"zigzag32,10,opt,name=namespace_pid,json=namespacePid" json:"namespace_pid,omitempty"`
+}
+
+func (m *ProcessInfo) Reset()                    { *m = ProcessInfo{} }
+func (m *ProcessInfo) String() string            { return proto.CompactTextString(m) }
+func (*ProcessInfo) ProtoMessage()               {}
+func (*ProcessInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{21} }
+
+func (m *ProcessInfo) GetProcessId() string {
+	if m != nil {
+		return m.ProcessId
+	}
+	return ""
+}
+
+func (m *ProcessInfo) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *ProcessInfo) GetTgid() int32 {
+	if m != nil {
+		return m.Tgid
+	}
+	return 0
+}
+
+func (m *ProcessInfo) GetExecutable() string {
+	if m != nil {
+		return m.Executable
+	}
+	return ""
+}
+
+func (m *ProcessInfo) GetCommandLine() []string {
+	if m != nil {
+		return m.CommandLine
+	}
+	return nil
+}
+
+func (m *ProcessInfo) GetCwd() string {
+	if m != nil {
+		return m.Cwd
+	}
+	return ""
+}
+
+func (m *ProcessInfo) GetCredentials() *Credentials {
+	if m != nil {
+		return m.Credentials
+	}
+	return nil
+}
+
+func (m *ProcessInfo) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *ProcessInfo) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *ProcessInfo) GetNamespacePid() int32 {
+	if m != nil {
+		return m.NamespacePid
+	}
+	return 0
+}
+
+// The StackTrace holds the kernel and user stacks of a task. Frames are
+// ordered from the innermost (most recent call) to the outermost.
+type StackTrace struct {
+	KernelFrames []*StackFrame `protobuf:"bytes,1,rep,name=kernel_frames,json=kernelFrames" json:"kernel_frames,omitempty"`
+	UserFrames   []*StackFrame `protobuf:"bytes,2,rep,name=user_frames,json=userFrames" json:"user_frames,omitempty"`
+}
+
+func (m *StackTrace) Reset()                    { *m = StackTrace{} }
+func (m *StackTrace) String() string            { return proto.CompactTextString(m) }
+func (*StackTrace) ProtoMessage()               {}
+func (*StackTrace) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{22} }
+
+func (m *StackTrace) GetKernelFrames() []*StackFrame {
+	if m != nil {
+		return m.KernelFrames
+	}
+	return nil
+}
+
+func (m *StackTrace) GetUserFrames() []*StackFrame {
+	if m != nil {
+		return m.UserFrames
+	}
+	return nil
+}
+
+type StackFrame struct {
+	// Instruction pointer
+	Address uint64 `protobuf:"varint,1,opt,name=address" json:"address,omitempty"`
+	// For kernel frames, the name of the kernel function containing
+	// the address, if it could be determined. User frames are not
+	// symbolized.
+	Symbol string `protobuf:"bytes,2,opt,name=symbol" json:"symbol,omitempty"`
+	// The offset of the address from the start of symbol
+	Offset uint64 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	// For kernel frames, the name of the kernel module containing the
+	// address, or empty for the kernel image.
+	Module string `protobuf:"bytes,4,opt,name=module" json:"module,omitempty"`
+}
+
+func (m *StackFrame) Reset()                    { *m = StackFrame{} }
+func (m *StackFrame) String() string            { return proto.CompactTextString(m) }
+func (*StackFrame) ProtoMessage()               {}
+func (*StackFrame) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{23} }
+
+func (m *StackFrame) GetAddress() uint64 {
+	if m != nil {
+		return m.Address
+	}
+	return 0
+}
+
+func (m *StackFrame) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *StackFrame) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *StackFrame) GetModule() string {
+	if m != nil {
+		return m.Module
+	}
+	return ""
+}
+
 // KernelFunctionCallEvent describes an event that occurred related to kernel
 // functions being entered or exited.
 type KernelFunctionCallEvent struct {
@@ -1265,12 +3679,16 @@ type KernelFunctionCallEvent struct {
 	// that are the names of the arguments, and the values are the actual
 	// values for each field.
 	Arguments map[string]*KernelFunctionCallEvent_FieldValue `protobuf:"bytes,1,rep,name=arguments" json:"arguments,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The type of event described by this KernelFunctionCallEvent message
+	Type KernelFunctionCallEventType `protobuf:"varint,2,opt,name=type,enum=capsule8.api.v0.KernelFunctionCallEventType" json:"type,omitempty"`
+	// The probed kernel symbol, as specified in the filter
+	Symbol string `protobuf:"bytes,3,opt,name=symbol" json:"symbol,omitempty"`
 }
 
 func (m *KernelFunctionCallEvent) Reset()                    { *m = KernelFunctionCallEvent{} }
 func (m *KernelFunctionCallEvent) String() string            { return proto.CompactTextString(m) }
 func (*KernelFunctionCallEvent) ProtoMessage()               {}
-func (*KernelFunctionCallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+func (*KernelFunctionCallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{24} }
 
 func (m *KernelFunctionCallEvent) GetArguments() map[string]*KernelFunctionCallEvent_FieldValue {
 	if m != nil {
@@ -1279,6 +3697,20 @@ func (m *KernelFunctionCallEvent) GetArguments() map[string]*KernelFunctionCallE
 	return nil
 }
 
+func (m *KernelFunctionCallEvent) GetType() KernelFunctionCallEventType {
+	if m != nil {
+		return m.Type
+	}
+	return KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_UNKNOWN
+}
+
+func (m *KernelFunctionCallEvent) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
 // The representation of a field value, which is composed of type
 // information and the value itself.
 type KernelFunctionCallEvent_FieldValue struct {
@@ -1296,7 +3728,7 @@ func (m *KernelFunctionCallEvent_FieldValue) Reset()         { *m = KernelFuncti
 func (m *KernelFunctionCallEvent_FieldValue) String() string { return proto.CompactTextString(m) }
 func (*KernelFunctionCallEvent_FieldValue) ProtoMessage()    {}
 func (*KernelFunctionCallEvent_FieldValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{8, 0}
+	return fileDescriptor1, []int{24, 0}
 }
 
 type isKernelFunctionCallEvent_FieldValue_Value interface {
@@ -1458,6 +3890,57 @@ func _KernelFunctionCallEvent_FieldValue_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// UserFunctionCallEvent describes an event that occurred related to
+// user-space functions in executables or shared libraries being entered or
+// exited.
+type UserFunctionCallEvent struct {
+	// The type of event described by this UserFunctionCallEvent message
+	Type UserFunctionCallEventType `protobuf:"varint,1,opt,name=type,enum=capsule8.api.v0.UserFunctionCallEventType" json:"type,omitempty"`
+	// The path of the probed executable or shared library, as specified
+	// in the filter
+	Executable string `protobuf:"bytes,2,opt,name=executable" json:"executable,omitempty"`
+	// The probed symbol, or the probed file offset in hexadecimal if no
+	// symbol was specified in the filter
+	Symbol string `protobuf:"bytes,3,opt,name=symbol" json:"symbol,omitempty"`
+	// This is a map of argument names and values. The keys are strings
+	// that are the names of the arguments, and the values are the actual
+	// values for each field.
+	Arguments map[string]*KernelFunctionCallEvent_FieldValue `protobuf:"bytes,4,rep,name=arguments" json:"arguments,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *UserFunctionCallEvent) Reset()                    { *m = UserFunctionCallEvent{} }
+func (m *UserFunctionCallEvent) String() string            { return proto.CompactTextString(m) }
+func (*UserFunctionCallEvent) ProtoMessage()               {}
+func (*UserFunctionCallEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{25} }
+
+func (m *UserFunctionCallEvent) GetType() UserFunctionCallEventType {
+	if m != nil {
+		return m.Type
+	}
+	return UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_UNKNOWN
+}
+
+func (m *UserFunctionCallEvent) GetExecutable() string {
+	if m != nil {
+		return m.Executable
+	}
+	return ""
+}
+
+func (m *UserFunctionCallEvent) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *UserFunctionCallEvent) GetArguments() map[string]*KernelFunctionCallEvent_FieldValue {
+	if m != nil {
+		return m.Arguments
+	}
+	return nil
+}
+
 // NetworkEvent describes an event that occurred related to network activity
 // occurring as detected by the Sensor.
 type NetworkEvent struct {
@@ -1478,12 +3961,22 @@ type NetworkEvent struct {
 	// Present only when the event describes a listen attempt. This is the
 	// value of the backlog argument passed to listen(2).
 	Backlog uint64 `protobuf:"varint,13,opt,name=backlog" json:"backlog,omitempty"`
+	// Present only when the event describes a TCP connection, a TCP
+	// listener, or a UDP bind. This is the local address of the socket;
+	// for TCP connections, address is the remote address.
+	LocalAddress *NetworkAddress `protobuf:"bytes,14,opt,name=local_address,json=localAddress" json:"local_address,omitempty"`
+	// Present only when the event describes a DNS query. This is the
+	// query's message ID; address is the name server's address.
+	DnsQueryId uint32 `protobuf:"varint,15,opt,name=dns_query_id,json=dnsQueryId" json:"dns_query_id,omitempty"`
+	// Present only when the event describes a DNS query. This is the
+	// name being queried (i.e. "www.example.com").
+	DnsQueryName string `protobuf:"bytes,16,opt,name=dns_query_name,json=dnsQueryName" json:"dns_query_name,omitempty"`
 }
 
 func (m *NetworkEvent) Reset()                    { *m = NetworkEvent{} }
 func (m *NetworkEvent) String() string            { return proto.CompactTextString(m) }
 func (*NetworkEvent) ProtoMessage()               {}
-func (*NetworkEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+func (*NetworkEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{26} }
 
 func (m *NetworkEvent) GetType() NetworkEventType {
 	if m != nil {
@@ -1520,6 +4013,27 @@ func (m *NetworkEvent) GetBacklog() uint64 {
 	return 0
 }
 
+func (m *NetworkEvent) GetLocalAddress() *NetworkAddress {
+	if m != nil {
+		return m.LocalAddress
+	}
+	return nil
+}
+
+func (m *NetworkEvent) GetDnsQueryId() uint32 {
+	if m != nil {
+		return m.DnsQueryId
+	}
+	return 0
+}
+
+func (m *NetworkEvent) GetDnsQueryName() string {
+	if m != nil {
+		return m.DnsQueryName
+	}
+	return ""
+}
+
 // PerformanceEventValue is a single performance event counter. It contains
 // the perf config value and its associated counter value.
 type PerformanceEventValue struct {
@@ -1535,7 +4049,7 @@ type PerformanceEventValue struct {
 func (m *PerformanceEventValue) Reset()                    { *m = PerformanceEventValue{} }
 func (m *PerformanceEventValue) String() string            { return proto.CompactTextString(m) }
 func (*PerformanceEventValue) ProtoMessage()               {}
-func (*PerformanceEventValue) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+func (*PerformanceEventValue) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{27} }
 
 func (m *PerformanceEventValue) GetType() PerformanceEventType {
 	if m != nil {
@@ -1577,7 +4091,7 @@ type PerformanceEvent struct {
 func (m *PerformanceEvent) Reset()                    { *m = PerformanceEvent{} }
 func (m *PerformanceEvent) String() string            { return proto.CompactTextString(m) }
 func (*PerformanceEvent) ProtoMessage()               {}
-func (*PerformanceEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+func (*PerformanceEvent) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{28} }
 
 func (m *PerformanceEvent) GetTotalTimeEnabled() uint64 {
 	if m != nil {
@@ -1603,22 +4117,51 @@ func (m *PerformanceEvent) GetValues() []*PerformanceEventValue {
 func init() {
 	proto.RegisterType((*TelemetryEvent)(nil), "capsule8.api.v0.TelemetryEvent")
 	proto.RegisterType((*ChargenEvent)(nil), "capsule8.api.v0.ChargenEvent")
+	proto.RegisterType((*LostEventsEvent)(nil), "capsule8.api.v0.LostEventsEvent")
+	proto.RegisterType((*SensorStatusEvent)(nil), "capsule8.api.v0.SensorStatusEvent")
 	proto.RegisterType((*TickerEvent)(nil), "capsule8.api.v0.TickerEvent")
+	proto.RegisterType((*BpfEvent)(nil), "capsule8.api.v0.BpfEvent")
 	proto.RegisterType((*ContainerEvent)(nil), "capsule8.api.v0.ContainerEvent")
+	proto.RegisterType((*ImageEvent)(nil), "capsule8.api.v0.ImageEvent")
+	proto.RegisterType((*IoUringEvent)(nil), "capsule8.api.v0.IoUringEvent")
+	proto.RegisterType((*KernelModuleEvent)(nil), "capsule8.api.v0.KernelModuleEvent")
+	proto.RegisterType((*LsmEvent)(nil), "capsule8.api.v0.LsmEvent")
+	proto.RegisterType((*MemoryEvent)(nil), "capsule8.api.v0.MemoryEvent")
+	proto.RegisterType((*MountEvent)(nil), "capsule8.api.v0.MountEvent")
 	proto.RegisterType((*ProcessEvent)(nil), "capsule8.api.v0.ProcessEvent")
+	proto.RegisterType((*SessionEvent)(nil), "capsule8.api.v0.SessionEvent")
+	proto.RegisterType((*SignalEvent)(nil), "capsule8.api.v0.SignalEvent")
 	proto.RegisterType((*SyscallEvent)(nil), "capsule8.api.v0.SyscallEvent")
+	proto.RegisterType((*TtyEvent)(nil), "capsule8.api.v0.TtyEvent")
 	proto.RegisterType((*FileEvent)(nil), "capsule8.api.v0.FileEvent")
 	proto.RegisterType((*Process)(nil), "capsule8.api.v0.Process")
+	proto.RegisterType((*Namespaces)(nil), "capsule8.api.v0.Namespaces")
+	proto.RegisterType((*ProcessInfo)(nil), "capsule8.api.v0.ProcessInfo")
+	proto.RegisterType((*StackTrace)(nil), "capsule8.api.v0.StackTrace")
+	proto.RegisterType((*StackFrame)(nil), "capsule8.api.v0.StackFrame")
 	proto.RegisterType((*KernelFunctionCallEvent)(nil), "capsule8.api.v0.KernelFunctionCallEvent")
 	proto.RegisterType((*KernelFunctionCallEvent_FieldValue)(nil), "capsule8.api.v0.KernelFunctionCallEvent.FieldValue")
+	proto.RegisterType((*UserFunctionCallEvent)(nil), "capsule8.api.v0.UserFunctionCallEvent")
 	proto.RegisterType((*NetworkEvent)(nil), "capsule8.api.v0.NetworkEvent")
 	proto.RegisterType((*PerformanceEventValue)(nil), "capsule8.api.v0.PerformanceEventValue")
 	proto.RegisterType((*PerformanceEvent)(nil), "capsule8.api.v0.PerformanceEvent")
+	proto.RegisterEnum("capsule8.api.v0.BpfEventType", BpfEventType_name, BpfEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.ContainerEventType", ContainerEventType_name, ContainerEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.ImageEventType", ImageEventType_name, ImageEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.IoUringEventType", IoUringEventType_name, IoUringEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.KernelModuleEventType", KernelModuleEventType_name, KernelModuleEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.LsmEventType", LsmEventType_name, LsmEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.MemoryEventType", MemoryEventType_name, MemoryEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.MountEventType", MountEventType_name, MountEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.ProcessEventType", ProcessEventType_name, ProcessEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.SeccompAction", SeccompAction_name, SeccompAction_value)
+	proto.RegisterEnum("capsule8.api.v0.SessionEventType", SessionEventType_name, SessionEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.SignalEventType", SignalEventType_name, SignalEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.SyscallEventType", SyscallEventType_name, SyscallEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.TtyEventType", TtyEventType_name, TtyEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.FileEventType", FileEventType_name, FileEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.KernelFunctionCallEventType", KernelFunctionCallEventType_name, KernelFunctionCallEventType_value)
+	proto.RegisterEnum("capsule8.api.v0.UserFunctionCallEventType", UserFunctionCallEventType_name, UserFunctionCallEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.NetworkEventType", NetworkEventType_name, NetworkEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.PerformanceEventType", PerformanceEventType_name, PerformanceEventType_value)
 	proto.RegisterEnum("capsule8.api.v0.KernelFunctionCallEvent_FieldType", KernelFunctionCallEvent_FieldType_name, KernelFunctionCallEvent_FieldType_value)
@@ -1627,129 +4170,318 @@ func init() {
 func init() { proto.RegisterFile("capsule8/api/v0/telemetry_event.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 1972 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x3f, 0x77, 0xdb, 0xc8,
-	0x11, 0x37, 0x44, 0x4a, 0x22, 0x87, 0x14, 0x0d, 0x6d, 0xe4, 0x3b, 0x58, 0xb2, 0x2d, 0x8a, 0xf2,
-	0x1f, 0x46, 0xc9, 0x93, 0x6d, 0xca, 0xf6, 0xf9, 0x52, 0xe4, 0x1e, 0x0d, 0x81, 0x31, 0x4f, 0x32,
-	0xa8, 0x2c, 0x21, 0xfb, 0x5c, 0xe1, 0x41, 0xc0, 0x8a, 0x46, 0x44, 0x02, 0x3c, 0x00, 0xb4, 0xad,
-	0x2e, 0x2f, 0x55, 0x9a, 0x14, 0xa9, 0x52, 0xa6, 0x4d, 0x95, 0x7c, 0x8d, 0xdc, 0xe5, 0x43, 0xe4,
-	0xe5, 0x13, 0xa4, 0x49, 0x9d, 0x97, 0xb7, 0xb3, 0x0b, 0xfe, 0x91, 0x08, 0xeb, 0xd2, 0x5d, 0xb7,
-	0xfb, 0x9b, 0xdf, 0x0c, 0x66, 0x76, 0x66, 0x67, 0x87, 0x84, 0x7b, 0xae, 0x33, 0x8c, 0x47, 0x7d,
-	0xf6, 0xfc, 0xa1, 0x33, 0xf4, 0x1f, 0xbe, 0x7f, 0xf4, 0x30, 0x61, 0x7d, 0x36, 0x60, 0x49, 0x74,
-	0x6e, 0xb3, 0xf7, 0x2c, 0x48, 0x76, 0x87, 0x51, 0x98, 0x84, 0xe4, 0x7a, 0x4a, 0xdb, 0x75, 0x86,
-	0xfe, 0xee, 0xfb, 0x47, 0xeb, 0x1b, 0x97, 0xf4, 0xce, 0x87, 0x2c, 0x16, 0xec, 0xda, 0xbf, 0x0b,
-	0x50, 0xb1, 0x52, 0x3b, 0x06, 0x37, 0x43, 0x2a, 0xb0, 0xe0, 0x7b, 0x9a, 0x52, 0x55, 0xea, 0x45,
-	0xba, 0xe0, 0x7b, 0xe4, 0x36, 0xc0, 0x30, 0x0a, 0x5d, 0x16, 0xc7, 0xb6, 0xef, 0x69, 0x0b, 0x88,
-	0x17, 0x25, 0xd2, 0xf6, 0xc8, 0x26, 0x94, 0x52, 0xf1, 0xd0, 0xf7, 0xb4, 0x5c, 0x55, 0xa9, 0x2f,
-	0xd2, 0x54, 0xe3, 0xc8, 0xf7, 0xc8, 0x16, 0x94, 0xdd, 0x30, 0x48, 0x1c, 0x3f, 0x60, 0x11, 0xb7,
-	0x90, 0x47, 0x0b, 0xa5, 0x31, 0xd6, 0xf6, 0xc8, 0x06, 0x14, 0x63, 0x16, 0xc4, 0x21, 0xca, 0x17,
-	0x51, 0x5e, 0x10, 0x40, 0xdb, 0x23, 0x4f, 0xe0, 0x33, 0x29, 0x8c, 0xd9, 0xb7, 0x23, 0x16, 0xb8,
-	0xcc, 0x0e, 0x46, 0x83, 0x13, 0x16, 0x69, 0x4b, 0x55, 0xa5, 0x9e, 0xa7, 0x6b, 0x42, 0xda, 0x95,
-	0x42, 0x13, 0x65, 0xa4, 0x01, 0x37, 0xa4, 0xd6, 0x20, 0x0c, 0xc2, 0xc4, 0x1f, 0x30, 0x3b, 0x70,
-	0x82, 0x30, 0xd6, 0x96, 0xab, 0x4a, 0x3d, 0x47, 0x7f, 0x22, 0x84, 0xaf, 0xa4, 0xcc, 0xe4, 0x22,
-	0xd2, 0x84, 0xeb, 0x69, 0x28, 0x7d, 0x3f, 0x60, 0x4e, 0x8f, 0x69, 0x85, 0x6a, 0xae, 0x5e, 0x6a,
-	0x68, 0xbb, 0x17, 0x0e, 0x75, 0xf7, 0x48, 0xf0, 0x68, 0x45, 0x2a, 0x1c, 0x0a, 0x3e, 0xb9, 0x07,
-	0x95, 0x49, 0xb0, 0x81, 0x33, 0x60, 0xda, 0x1d, 0x0c, 0x67, 0x65, 0x8c, 0x9a, 0xce, 0x80, 0x91,
-	0x9b, 0x50, 0xf0, 0x07, 0x4e, 0x8f, 0xf1, 0x78, 0x37, 0x91, 0xb0, 0x8c, 0xfb, 0x36, 0x1e, 0xb7,
-	0x10, 0xa1, 0x76, 0x55, 0x1c, 0x37, 0x22, 0xa8, 0xf9, 0x25, 0x2c, 0xc7, 0xe7, 0xb1, 0xeb, 0xf4,
-	0xfb, 0x1a, 0x54, 0x95, 0x7a, 0xa9, 0x71, 0xfb, 0x92, 0x6f, 0x5d, 0x21, 0xc7, 0x6c, 0xbe, 0xbc,
-	0x46, 0x53, 0x3e, 0x57, 0x95, 0xde, 0x6a, 0xa5, 0x0c, 0x55, 0x19, 0xd6, 0x58, 0x55, 0xf2, 0xc9,
-	0x23, 0xc8, 0x9f, 0xfa, 0x7d, 0xa6, 0x95, 0x51, 0x6f, 0xfd, 0x92, 0x5e, 0xcb, 0xef, 0xb3, 0x54,
-	0x09, 0x99, 0xe4, 0x00, 0x4a, 0x67, 0x2c, 0x0a, 0x58, 0xdf, 0x46, 0x5f, 0x57, 0x50, 0xb1, 0x7e,
-	0x49, 0xf1, 0x00, 0x39, 0xad, 0x51, 0xe0, 0x26, 0x7e, 0x18, 0xe8, 0x53, 0x6e, 0x83, 0x50, 0xd7,
-	0xa5, 0xe7, 0x01, 0x4b, 0x3e, 0x84, 0xd1, 0x99, 0x56, 0xc9, 0xf0, 0xdc, 0x14, 0xf2, 0xb1, 0xe7,
-	0x92, 0x4f, 0x0c, 0x28, 0x0d, 0x59, 0x74, 0x1a, 0x46, 0x03, 0x27, 0x70, 0x99, 0x76, 0x1d, 0xd5,
-	0xb7, 0x2e, 0x07, 0x3e, 0xe1, 0xa4, 0x26, 0xa6, 0xf5, 0xc8, 0x57, 0x50, 0x1c, 0x67, 0x50, 0x5b,
-	0x43, 0x23, 0x9b, 0x97, 0x8c, 0xe8, 0x29, 0x23, 0x35, 0x31, 0xd1, 0xe1, 0x21, 0xb8, 0xef, 0x9c,
-	0xa8, 0xc7, 0x02, 0xcd, 0xcb, 0x08, 0x41, 0x17, 0xf2, 0x71, 0x08, 0x92, 0x4f, 0x9e, 0xc1, 0x52,
-	0xe2, 0xbb, 0x67, 0x2c, 0xd2, 0x18, 0x6a, 0xde, 0xba, 0xa4, 0x69, 0xa1, 0x38, 0x55, 0x94, 0x6c,
-	0xb2, 0x0a, 0x39, 0x77, 0x38, 0xd2, 0xbe, 0x53, 0xf0, 0x4a, 0xf2, 0x35, 0xf9, 0x0a, 0x4a, 0x6e,
-	0xc4, 0x3c, 0x16, 0x24, 0xbe, 0xd3, 0x8f, 0xb5, 0xef, 0x95, 0x0c, 0x83, 0xfa, 0x84, 0x44, 0xa7,
-	0x35, 0x48, 0x0d, 0xca, 0xe9, 0x15, 0x49, 0x7a, 0xbe, 0xa7, 0xfd, 0x43, 0x18, 0x4f, 0x5b, 0x80,
-	0xd5, 0xf3, 0xbd, 0x17, 0xcb, 0xb0, 0x88, 0x0d, 0xe9, 0xeb, 0xa5, 0xc2, 0xdf, 0x15, 0xf5, 0x3b,
-	0x65, 0x2c, 0xb5, 0x13, 0xdf, 0xab, 0xed, 0x43, 0x79, 0x3a, 0x50, 0xb2, 0x06, 0x8b, 0x7e, 0xe0,
-	0xb1, 0x8f, 0xd8, 0x71, 0xf2, 0x54, 0x6c, 0xc8, 0x1d, 0x00, 0x1e, 0xbe, 0xe3, 0x26, 0x2c, 0x8a,
-	0x65, 0xd3, 0x99, 0x42, 0x6a, 0x6d, 0x28, 0x4d, 0x05, 0x4d, 0x34, 0x58, 0x8e, 0x99, 0x1b, 0x06,
-	0x5e, 0x8c, 0x66, 0x72, 0x34, 0xdd, 0x92, 0x2a, 0x94, 0xf0, 0xde, 0x4b, 0xe9, 0x02, 0x4a, 0xa7,
-	0xa1, 0xda, 0x1f, 0x73, 0x50, 0x99, 0xcd, 0x1c, 0xf9, 0x02, 0xf2, 0xbc, 0x49, 0xa2, 0xad, 0x4a,
-	0x63, 0xfb, 0x8a, 0x44, 0x5b, 0xe7, 0x43, 0x46, 0x51, 0x81, 0x10, 0xc8, 0xe3, 0xb5, 0x15, 0x0e,
-	0xe3, 0x7a, 0xe6, 0xae, 0xc3, 0xa7, 0xee, 0x7a, 0xe9, 0xe2, 0x5d, 0xbf, 0x09, 0x85, 0x77, 0x61,
-	0x9c, 0x60, 0x5f, 0xe5, 0x35, 0xb7, 0x4a, 0x97, 0xf9, 0x9e, 0x37, 0xd5, 0x0d, 0x28, 0xb2, 0x8f,
-	0x7e, 0x62, 0xbb, 0xa1, 0x27, 0x5a, 0xcc, 0x2a, 0x2d, 0x70, 0x40, 0x0f, 0x3d, 0xc6, 0x5b, 0x32,
-	0x0a, 0xe3, 0xc4, 0x49, 0x46, 0x31, 0x36, 0x98, 0x15, 0x0a, 0x1c, 0xea, 0x22, 0x32, 0x21, 0xf8,
-	0xbd, 0xc0, 0xe9, 0x63, 0x93, 0x49, 0x09, 0x88, 0x90, 0x3a, 0xa8, 0xd2, 0x7c, 0xc4, 0x6c, 0x6f,
-	0x34, 0x18, 0x32, 0x4f, 0xdb, 0xaa, 0x2a, 0xf5, 0x02, 0xad, 0x88, 0xaf, 0x44, 0x6c, 0x1f, 0x51,
-	0xf2, 0x73, 0x20, 0x5e, 0xc8, 0x13, 0x61, 0xbb, 0x61, 0x70, 0xea, 0xf7, 0xec, 0xdf, 0xc4, 0xa1,
-	0x28, 0xf1, 0x22, 0x55, 0x85, 0x44, 0x47, 0xc1, 0xd7, 0x71, 0x18, 0x90, 0xfb, 0x70, 0x3d, 0x74,
-	0xfd, 0x19, 0x2a, 0x13, 0xfd, 0x31, 0x74, 0xfd, 0x09, 0xaf, 0xf6, 0xfb, 0x1c, 0x94, 0xa7, 0x7b,
-	0x11, 0x79, 0x3a, 0x93, 0x91, 0xad, 0x4f, 0x36, 0xae, 0xa9, 0x7c, 0xdc, 0x85, 0xca, 0x69, 0x18,
-	0x9d, 0xd9, 0xee, 0x3b, 0xbf, 0xef, 0xe1, 0x39, 0x02, 0x9e, 0x55, 0x99, 0xa3, 0x3a, 0x07, 0xf9,
-	0x61, 0xd6, 0x60, 0x65, 0x8a, 0xe5, 0x7b, 0x32, 0x13, 0xa5, 0x31, 0xa9, 0xed, 0x91, 0x6d, 0x58,
-	0x61, 0x1f, 0x99, 0x6b, 0xf3, 0xe6, 0x86, 0xd9, 0x5a, 0x43, 0x4e, 0x99, 0x83, 0x2d, 0x89, 0x91,
-	0x1d, 0x58, 0x45, 0x92, 0x1b, 0x0e, 0x06, 0x4e, 0xe0, 0xe1, 0x2b, 0xa2, 0xdd, 0xa8, 0xe6, 0xea,
-	0x45, 0x7a, 0x9d, 0x0b, 0x74, 0x81, 0xf3, 0xc7, 0xe2, 0xc7, 0x93, 0xc1, 0xdb, 0x00, 0xa3, 0xa1,
-	0xe7, 0x24, 0xcc, 0x76, 0x3f, 0x78, 0x5a, 0x5d, 0x14, 0xa1, 0x40, 0xf4, 0x0f, 0x5e, 0xed, 0x9f,
-	0x0a, 0x94, 0xa7, 0x5f, 0x94, 0x2b, 0x53, 0x31, 0x4d, 0x9e, 0x4a, 0x85, 0x18, 0x2b, 0xc4, 0xfd,
-	0xe3, 0x63, 0x05, 0x81, 0xbc, 0x13, 0xf5, 0x1e, 0x61, 0x42, 0xf2, 0x14, 0xd7, 0x12, 0x7b, 0x8c,
-	0xe7, 0x2f, 0xb0, 0xc7, 0x12, 0x6b, 0xe0, 0xd3, 0x23, 0xb0, 0x86, 0xc4, 0xf6, 0xf0, 0x55, 0x11,
-	0xd8, 0x9e, 0xc4, 0x9e, 0xe0, 0x03, 0x21, 0xb0, 0x27, 0x12, 0x7b, 0x8a, 0x5d, 0x5f, 0x60, 0x4f,
-	0x89, 0x0a, 0xb9, 0x88, 0x25, 0x98, 0xbe, 0x1c, 0xe5, 0xcb, 0xda, 0x9f, 0x14, 0x28, 0x8e, 0x1f,
-	0x30, 0xd2, 0x98, 0x09, 0xef, 0x4e, 0xf6, 0x53, 0x37, 0x15, 0xdb, 0x3a, 0x14, 0xc6, 0x75, 0x21,
-	0xae, 0xf8, 0x78, 0xcf, 0x8f, 0x37, 0x1c, 0xb2, 0xc0, 0x3e, 0xed, 0x3b, 0x3d, 0xf1, 0xf0, 0xae,
-	0xd2, 0x22, 0x47, 0x5a, 0x1c, 0xe0, 0x65, 0x80, 0xe2, 0x01, 0x2f, 0x83, 0xb2, 0x28, 0x03, 0x0e,
-	0xbc, 0x0a, 0x3d, 0x56, 0x7b, 0x0a, 0xcb, 0xb2, 0xb0, 0xb9, 0xdb, 0x43, 0x39, 0x96, 0xad, 0x52,
-	0xbe, 0xe4, 0x3d, 0x4f, 0xd6, 0x99, 0x6c, 0x37, 0xe9, 0xb6, 0xf6, 0x9f, 0x3c, 0x7c, 0x9e, 0xf1,
-	0xb0, 0x92, 0x63, 0x28, 0x3a, 0x51, 0x6f, 0x34, 0x60, 0x41, 0xc2, 0x7b, 0x25, 0x9f, 0x6e, 0xbe,
-	0xf8, 0xa1, 0xaf, 0xf2, 0x6e, 0x33, 0xd5, 0x34, 0x82, 0x24, 0x3a, 0xa7, 0x13, 0x4b, 0xeb, 0xff,
-	0x55, 0x00, 0x5a, 0x3e, 0xeb, 0x7b, 0xaf, 0x9d, 0xfe, 0x88, 0x91, 0x5f, 0x03, 0x9c, 0xf2, 0x9d,
-	0x3d, 0x75, 0x94, 0x8d, 0x1f, 0xfc, 0x19, 0x34, 0x84, 0xc7, 0x5b, 0x3c, 0x4d, 0x97, 0x64, 0x0b,
-	0x4a, 0x27, 0xe7, 0x09, 0x8b, 0xed, 0xf7, 0xfc, 0x0b, 0x18, 0x72, 0x99, 0x8f, 0x09, 0x08, 0x8a,
-	0xaf, 0x6e, 0x43, 0x39, 0x4e, 0x22, 0x3f, 0xe8, 0x49, 0x0e, 0x9f, 0x45, 0x8b, 0xfc, 0x25, 0x17,
-	0xe8, 0x84, 0xe4, 0xf7, 0x02, 0xe6, 0x49, 0x12, 0x1f, 0x47, 0x09, 0x92, 0x10, 0x15, 0xa4, 0x07,
-	0x50, 0x19, 0x05, 0x33, 0x34, 0x3e, 0x95, 0xe6, 0x5f, 0x5e, 0xa3, 0x2b, 0x29, 0x8e, 0x44, 0xfe,
-	0xd6, 0xa1, 0x7c, 0xfd, 0x5b, 0xa8, 0xcc, 0x9e, 0x0e, 0xcf, 0xd8, 0x19, 0x3b, 0x97, 0x83, 0x34,
-	0x5f, 0x92, 0xb6, 0x24, 0xa3, 0xf3, 0xa5, 0xc6, 0xde, 0xff, 0x77, 0x20, 0xf8, 0x41, 0x2a, 0x2c,
-	0xfc, 0x62, 0xe1, 0xb9, 0x52, 0xfb, 0x03, 0xd6, 0x6d, 0x7a, 0x3e, 0x25, 0x58, 0x3e, 0x36, 0x0f,
-	0xcc, 0xce, 0x1b, 0x53, 0xbd, 0x46, 0x8a, 0xb0, 0xf8, 0xe2, 0xad, 0x65, 0x74, 0x55, 0x85, 0x00,
-	0x2c, 0x75, 0x2d, 0xda, 0x36, 0x7f, 0xa5, 0x2e, 0x70, 0xb8, 0xdb, 0x36, 0xad, 0xe7, 0x6a, 0x0e,
-	0xe1, 0xb6, 0x69, 0x3d, 0x7e, 0xa6, 0xe6, 0xd3, 0xf5, 0x5e, 0x43, 0x5d, 0x4c, 0xd7, 0xcf, 0x9e,
-	0xa8, 0x4b, 0x9c, 0x7e, 0x8c, 0xf4, 0x65, 0x0e, 0x1f, 0x0b, 0x7a, 0x21, 0x5d, 0xef, 0x35, 0xd4,
-	0x62, 0xba, 0x7e, 0xf6, 0x44, 0x85, 0xda, 0xf7, 0x0a, 0x94, 0xa7, 0xc7, 0xb0, 0x2b, 0x3b, 0xc5,
-	0x34, 0x79, 0xea, 0x36, 0x7d, 0x06, 0x4b, 0x71, 0xe8, 0x9e, 0x9d, 0x7a, 0xb2, 0x37, 0xc8, 0x1d,
-	0x1f, 0xa1, 0x1c, 0xcf, 0x8b, 0x26, 0xf3, 0xeb, 0x66, 0x96, 0xc5, 0xa6, 0xa0, 0xd1, 0x94, 0xcf,
-	0x4d, 0x46, 0x2c, 0x1e, 0xf5, 0x13, 0xbc, 0x62, 0x84, 0xca, 0x1d, 0xbf, 0x43, 0x27, 0x8e, 0x7b,
-	0xd6, 0x0f, 0x7b, 0xb2, 0x97, 0xa4, 0xdb, 0xda, 0x6f, 0x15, 0xb8, 0x71, 0x71, 0x28, 0x14, 0xb5,
-	0xf1, 0xe5, 0x4c, 0x54, 0xf7, 0xae, 0x1c, 0x25, 0x67, 0x23, 0x13, 0x4f, 0x1f, 0x56, 0x40, 0x9e,
-	0xca, 0x1d, 0x9f, 0x81, 0x26, 0x15, 0x9b, 0x97, 0x39, 0xae, 0xfd, 0x55, 0x01, 0xf5, 0xa2, 0x31,
-	0xfe, 0xde, 0x26, 0x61, 0xe2, 0xf4, 0x6d, 0xfc, 0x49, 0xc3, 0x02, 0xe7, 0xa4, 0xcf, 0x3c, 0x39,
-	0x3b, 0xa9, 0x28, 0xb1, 0xfc, 0x01, 0x33, 0x04, 0x7e, 0x81, 0x1d, 0x8d, 0x82, 0xc0, 0x0f, 0xd2,
-	0x8f, 0x4f, 0xd8, 0x54, 0xe0, 0xe4, 0x97, 0xb0, 0x84, 0x5f, 0x8e, 0xb5, 0x1c, 0x36, 0x86, 0xfb,
-	0x57, 0xc6, 0x26, 0x6a, 0x52, 0x6a, 0xed, 0xfc, 0x4b, 0x01, 0x72, 0x79, 0x34, 0x22, 0x55, 0xb8,
-	0xa5, 0x77, 0x4c, 0xab, 0xd9, 0x36, 0x0d, 0x6a, 0x1b, 0xaf, 0x0d, 0xd3, 0xb2, 0xad, 0xb7, 0x47,
-	0x86, 0x3d, 0x29, 0xd7, 0x2c, 0x86, 0x4e, 0x8d, 0xa6, 0x65, 0xec, 0xab, 0x4a, 0x26, 0x83, 0x1e,
-	0x9b, 0xa6, 0xa8, 0xed, 0x4d, 0xd8, 0x98, 0xcb, 0x30, 0xbe, 0x69, 0x73, 0x13, 0x39, 0x52, 0x83,
-	0x3b, 0x73, 0x09, 0xfb, 0x46, 0xd7, 0xa2, 0x9d, 0xb7, 0xc6, 0xbe, 0x9a, 0xcf, 0x76, 0xf5, 0x68,
-	0x1f, 0x1d, 0x59, 0xdc, 0xf9, 0x0b, 0x4f, 0xca, 0x85, 0x61, 0x83, 0xdc, 0x81, 0xf5, 0x23, 0xda,
-	0xd1, 0x8d, 0x6e, 0x77, 0x7e, 0x7c, 0x1b, 0xf0, 0xf9, 0x1c, 0x79, 0xab, 0x43, 0x0f, 0x54, 0x25,
-	0x43, 0x68, 0x7c, 0x63, 0xe8, 0xea, 0x42, 0xa6, 0xb0, 0x6d, 0xa9, 0x39, 0x72, 0x1b, 0x6e, 0xce,
-	0xfb, 0x2c, 0xfa, 0xaa, 0xe6, 0x77, 0x06, 0xa0, 0x5e, 0x7c, 0x8b, 0xb9, 0xa7, 0xdd, 0xb7, 0x5d,
-	0xbd, 0x79, 0x78, 0x38, 0xdf, 0xd3, 0x5b, 0xa0, 0xcd, 0x91, 0x1b, 0xa6, 0x65, 0x50, 0xe1, 0xea,
-	0x3c, 0x29, 0xf7, 0x66, 0x61, 0xa7, 0x05, 0x2b, 0x33, 0x6f, 0x23, 0x67, 0xb7, 0xda, 0x87, 0xc6,
-	0xfc, 0x0f, 0x69, 0xb0, 0x76, 0x51, 0xd8, 0x39, 0x32, 0x4c, 0x55, 0xd9, 0xf9, 0xb3, 0x02, 0x1b,
-	0x19, 0x8d, 0x10, 0xcd, 0xfe, 0x0c, 0x1e, 0x1c, 0x18, 0xd4, 0x34, 0x0e, 0xed, 0xd6, 0xb1, 0xa9,
-	0x5b, 0xed, 0x8e, 0x69, 0x67, 0xc7, 0xf3, 0x53, 0xb8, 0x77, 0x15, 0x39, 0x0d, 0xae, 0x0e, 0x77,
-	0xaf, 0xa4, 0x8a, 0x48, 0x7f, 0x97, 0x07, 0xf5, 0x62, 0xef, 0xe2, 0x27, 0x6b, 0x1a, 0xd6, 0x9b,
-	0x0e, 0x3d, 0x98, 0xef, 0xc9, 0x7d, 0xa8, 0xcd, 0x91, 0xeb, 0x1d, 0xd3, 0x34, 0x74, 0xcb, 0x6e,
-	0x5a, 0x96, 0xf1, 0xea, 0xc8, 0x52, 0x15, 0x72, 0x0f, 0xb6, 0x3e, 0xc1, 0xa3, 0x46, 0xf7, 0xf8,
-	0xd0, 0x52, 0x17, 0xc8, 0x36, 0x6c, 0xce, 0xa1, 0xbd, 0x68, 0x9b, 0xfb, 0x63, 0x5b, 0x58, 0xf2,
-	0x59, 0x24, 0x69, 0x28, 0x9f, 0xf1, 0xbd, 0xc3, 0x76, 0xd7, 0x32, 0xcc, 0xb1, 0xa9, 0x45, 0x72,
-	0x17, 0xaa, 0xd9, 0x34, 0x69, 0x6c, 0x29, 0xc3, 0x58, 0x53, 0xd7, 0x8d, 0xa3, 0x49, 0x8c, 0xcb,
-	0x19, 0xc6, 0x24, 0x4d, 0x1a, 0x2b, 0x64, 0x18, 0xeb, 0x1a, 0xe6, 0xbe, 0xd5, 0x19, 0x1b, 0x2b,
-	0x66, 0x18, 0x93, 0x34, 0x69, 0x0c, 0xc8, 0x03, 0xd8, 0x9e, 0xc3, 0xa2, 0x86, 0xfe, 0xba, 0x45,
-	0x3b, 0xaf, 0xc6, 0xe6, 0x4a, 0x19, 0x79, 0x1a, 0x13, 0xa5, 0xc1, 0xf2, 0xce, 0xdf, 0x14, 0x58,
-	0x9b, 0xd7, 0xea, 0xf9, 0xa1, 0x1f, 0x19, 0xb4, 0xd5, 0xa1, 0xaf, 0x9a, 0xa6, 0x9e, 0x51, 0xfd,
-	0xdb, 0xb0, 0x99, 0xc1, 0x79, 0xd9, 0xa4, 0xfb, 0x6f, 0x9a, 0xd4, 0x50, 0x15, 0x5e, 0xbb, 0x57,
-	0x90, 0x6c, 0xbd, 0xa9, 0xbf, 0x34, 0x44, 0x35, 0x64, 0x50, 0xbb, 0x9d, 0x96, 0x85, 0xf6, 0x72,
-	0x27, 0x4b, 0xf8, 0x97, 0xdf, 0xde, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x17, 0x91, 0x9f, 0xbf,
-	0x49, 0x14, 0x00, 0x00,
+	// 4999 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5b, 0xcb, 0x73, 0xdb, 0x48,
+	0x73, 0x5f, 0x3e, 0xf4, 0x60, 0xf3, 0x21, 0x08, 0x96, 0x6c, 0x58, 0x7e, 0xc9, 0xf4, 0x63, 0xb5,
+	0xfa, 0xbe, 0x78, 0xbd, 0xb2, 0xbd, 0xfb, 0xbd, 0xf6, 0x41, 0x93, 0x90, 0xc4, 0x35, 0x45, 0x72,
+	0x41, 0xc8, 0xbb, 0xce, 0xa3, 0x50, 0x10, 0x31, 0xa2, 0xb0, 0x06, 0x01, 0x1a, 0x00, 0xed, 0xd5,
+	0x2d, 0x55, 0xa9, 0xef, 0x96, 0x5c, 0xf3, 0x1d, 0xbf, 0x53, 0xae, 0xf9, 0xae, 0xa9, 0x1c, 0x93,
+	0x4a, 0x55, 0xbe, 0x3c, 0x36, 0x97, 0x54, 0xe5, 0x4b, 0xe5, 0x8f, 0xc8, 0x21, 0x55, 0x39, 0xa6,
+	0x52, 0xdd, 0x33, 0x00, 0xc1, 0x07, 0x2c, 0xef, 0x29, 0xb9, 0xa8, 0x30, 0xdd, 0xbf, 0xee, 0xe9,
+	0x99, 0xe9, 0xe9, 0xe9, 0xe9, 0xa1, 0xe0, 0x5e, 0xdf, 0x1c, 0x05, 0x63, 0x87, 0xfd, 0xe4, 0x43,
+	0x73, 0x64, 0x7f, 0xf8, 0xfa, 0xe1, 0x87, 0x21, 0x73, 0xd8, 0x90, 0x85, 0xfe, 0xb9, 0xc1, 0x5e,
+	0x33, 0x37, 0x7c, 0x30, 0xf2, 0xbd, 0xd0, 0x93, 0xd7, 0x22, 0xd8, 0x03, 0x73, 0x64, 0x3f, 0x78,
+	0xfd, 0x70, 0xeb, 0xda, 0x9c, 0xdc, 0xf9, 0x88, 0x05, 0x1c, 0x5d, 0xfd, 0x9d, 0x04, 0x15, 0x3d,
+	0xd2, 0xa3, 0xa2, 0x1a, 0xb9, 0x02, 0x59, 0xdb, 0x52, 0x32, 0xdb, 0x99, 0x9d, 0x82, 0x96, 0xb5,
+	0x2d, 0xf9, 0x06, 0xc0, 0xc8, 0xf7, 0xfa, 0x2c, 0x08, 0x0c, 0xdb, 0x52, 0xb2, 0x44, 0x2f, 0x08,
+	0x4a, 0xd3, 0x92, 0x6f, 0x41, 0x31, 0x62, 0x8f, 0x6c, 0x4b, 0xc9, 0x6d, 0x67, 0x76, 0x96, 0xb4,
+	0x48, 0xa2, 0x6b, 0x5b, 0xf2, 0x6d, 0x28, 0xf5, 0x3d, 0x37, 0x34, 0x6d, 0x97, 0xf9, 0xa8, 0x21,
+	0x4f, 0x1a, 0x8a, 0x31, 0xad, 0x69, 0xc9, 0xd7, 0xa0, 0x10, 0x30, 0x37, 0xf0, 0x88, 0xbf, 0x44,
+	0xfc, 0x55, 0x4e, 0x68, 0x5a, 0xf2, 0x63, 0xb8, 0x2c, 0x98, 0x01, 0x7b, 0x35, 0x66, 0x6e, 0x9f,
+	0x19, 0xee, 0x78, 0x78, 0xc2, 0x7c, 0x65, 0x79, 0x3b, 0xb3, 0x93, 0xd7, 0x36, 0x38, 0xb7, 0x27,
+	0x98, 0x6d, 0xe2, 0xc9, 0x7b, 0xb0, 0x29, 0xa4, 0x86, 0x9e, 0xeb, 0x85, 0xf6, 0x90, 0x19, 0xae,
+	0xe9, 0x7a, 0x81, 0xb2, 0xb2, 0x9d, 0xd9, 0xc9, 0x69, 0x97, 0x38, 0xf3, 0x48, 0xf0, 0xda, 0xc8,
+	0x92, 0x6b, 0xb0, 0x16, 0x0d, 0xc5, 0xb1, 0x5d, 0x66, 0x0e, 0x98, 0xb2, 0xba, 0x9d, 0xdb, 0x29,
+	0xee, 0x29, 0x0f, 0x66, 0x26, 0xf5, 0x41, 0x97, 0xe3, 0xb4, 0x8a, 0x10, 0x68, 0x71, 0xbc, 0x7c,
+	0x0f, 0x2a, 0x93, 0xc1, 0xba, 0xe6, 0x90, 0x29, 0x37, 0x69, 0x38, 0xe5, 0x98, 0xda, 0x36, 0x87,
+	0x4c, 0xbe, 0x0a, 0xab, 0xf6, 0xd0, 0x1c, 0x30, 0x1c, 0xef, 0x2d, 0x02, 0xac, 0x50, 0xbb, 0x49,
+	0xd3, 0xcd, 0x59, 0x24, 0xbd, 0xcd, 0xa7, 0x9b, 0x28, 0x24, 0xf9, 0x53, 0x58, 0x09, 0xce, 0x83,
+	0xbe, 0xe9, 0x38, 0x0a, 0x6c, 0x67, 0x76, 0x8a, 0x7b, 0x37, 0xe6, 0x6c, 0xeb, 0x71, 0x3e, 0xad,
+	0xe6, 0xe1, 0x7b, 0x5a, 0x84, 0x47, 0x51, 0x61, 0xad, 0x52, 0x4c, 0x11, 0x15, 0xc3, 0x8a, 0x45,
+	0x05, 0x5e, 0x7e, 0x08, 0xf9, 0x53, 0xdb, 0x61, 0x4a, 0x89, 0xe4, 0xb6, 0xe6, 0xe4, 0xf6, 0x6d,
+	0x87, 0x45, 0x42, 0x84, 0x94, 0x9f, 0x41, 0xf1, 0x25, 0xf3, 0x5d, 0xe6, 0x18, 0x64, 0x6b, 0x99,
+	0x04, 0x77, 0xe6, 0x04, 0x9f, 0x11, 0x66, 0x7f, 0xec, 0xf6, 0x43, 0xdb, 0x73, 0xeb, 0x09, 0xb3,
+	0x81, 0x8b, 0xd7, 0x85, 0xe5, 0x2e, 0x0b, 0xdf, 0x78, 0xfe, 0x4b, 0xa5, 0x92, 0x62, 0x79, 0x9b,
+	0xf3, 0x63, 0xcb, 0x05, 0x5e, 0x56, 0xa1, 0x38, 0x62, 0xfe, 0xa9, 0xe7, 0x0f, 0x4d, 0xb7, 0xcf,
+	0x94, 0x35, 0x12, 0xbf, 0x3d, 0x3f, 0xf0, 0x09, 0x26, 0x52, 0x91, 0x94, 0x93, 0x9b, 0x50, 0x16,
+	0xc3, 0x19, 0x7a, 0xd6, 0xd8, 0x61, 0x8a, 0x44, 0x8a, 0xaa, 0x29, 0x03, 0x3a, 0x22, 0x50, 0xa4,
+	0xa9, 0xf4, 0x32, 0x41, 0x94, 0x1f, 0xc1, 0xd2, 0xd0, 0x1b, 0xbb, 0xa1, 0xb2, 0x4e, 0x2a, 0xae,
+	0xcd, 0xa9, 0x38, 0x42, 0x6e, 0x24, 0xcb, 0xb1, 0xf2, 0xc7, 0xb0, 0x3c, 0x64, 0x43, 0xcf, 0x3f,
+	0x57, 0x64, 0x92, 0xba, 0x3e, 0x2f, 0x45, 0xec, 0x48, 0x4c, 0xa0, 0x51, 0x2e, 0xb0, 0x07, 0xae,
+	0xe9, 0x28, 0x97, 0x52, 0xe4, 0x7a, 0xc4, 0x8e, 0xe5, 0x38, 0x5a, 0xfe, 0x3d, 0xc8, 0x39, 0xc1,
+	0x50, 0xb9, 0x4c, 0x42, 0x57, 0xe7, 0x84, 0x5a, 0xc1, 0x30, 0x92, 0x40, 0x1c, 0xc2, 0xc3, 0xf0,
+	0x5c, 0xb9, 0x92, 0x02, 0xd7, 0xc3, 0xd8, 0x30, 0xc4, 0xc9, 0x3f, 0x83, 0x55, 0xdb, 0x33, 0xc6,
+	0xbe, 0xed, 0x0e, 0x94, 0xab, 0x29, 0x0b, 0xda, 0xf4, 0x8e, 0x91, 0x1f, 0x2f, 0xa8, 0xcd, 0xdb,
+	0xd8, 0xd5, 0xc9, 0xe8, 0x54, 0xd9, 0x4a, 0xe9, 0xea, 0xe9, 0xe8, 0x34, 0xee, 0xea, 0x64, 0x74,
+	0x2a, 0xab, 0x50, 0x18, 0x07, 0xcc, 0xe7, 0x5e, 0x78, 0x8d, 0x84, 0xee, 0xcf, 0x09, 0x1d, 0x07,
+	0xcc, 0x5f, 0xe4, 0x83, 0xab, 0x28, 0x4a, 0x1e, 0xf8, 0x39, 0x14, 0xe2, 0x1d, 0xac, 0x6c, 0x90,
+	0x9a, 0x5b, 0x73, 0x6a, 0xea, 0x11, 0x22, 0x92, 0x9f, 0xc8, 0xe0, 0xaa, 0xd3, 0x26, 0x56, 0x36,
+	0x53, 0x56, 0xbd, 0x89, 0xdc, 0x78, 0xd5, 0x09, 0x4b, 0x9b, 0x9d, 0x05, 0x81, 0xed, 0xb9, 0x8a,
+	0x92, 0xb6, 0xd9, 0x39, 0x7f, 0xb2, 0xd9, 0x79, 0x5b, 0xae, 0x43, 0xd1, 0xf1, 0x82, 0x90, 0x1f,
+	0x0d, 0x81, 0x72, 0x9d, 0xc4, 0xb7, 0xe7, 0x17, 0xd2, 0x0b, 0xb8, 0xab, 0xc5, 0x7b, 0x1e, 0x9c,
+	0x98, 0x84, 0x5e, 0x1f, 0x85, 0xde, 0xd0, 0x0c, 0xc7, 0x81, 0x72, 0x23, 0xc5, 0xeb, 0x7b, 0x3c,
+	0x04, 0x13, 0x28, 0xf6, 0xfa, 0x20, 0x41, 0xc4, 0xa1, 0xf4, 0xcf, 0x4c, 0x7f, 0xc0, 0x5c, 0xc5,
+	0x4a, 0x19, 0x4a, 0x9d, 0xf3, 0xe3, 0xa1, 0x08, 0x3c, 0xfa, 0x70, 0x68, 0xf7, 0x5f, 0x32, 0x5f,
+	0x61, 0x29, 0x3e, 0xac, 0x13, 0x3b, 0xf6, 0x61, 0x8e, 0x96, 0xd7, 0x21, 0xd7, 0x1f, 0x8d, 0x95,
+	0xdf, 0x66, 0xe8, 0x48, 0xc2, 0x6f, 0xf9, 0x73, 0x28, 0xf6, 0x7d, 0x66, 0x31, 0x37, 0xb4, 0x4d,
+	0x27, 0x50, 0xfe, 0x21, 0x93, 0xa2, 0xb0, 0x3e, 0x01, 0x69, 0x49, 0x09, 0xb9, 0x0a, 0xa5, 0xe8,
+	0x88, 0x08, 0x07, 0xb6, 0xa5, 0xfc, 0x23, 0x57, 0x1e, 0x1d, 0x81, 0xfa, 0xc0, 0xb6, 0xe4, 0x4f,
+	0xa1, 0x18, 0x84, 0x66, 0xff, 0xa5, 0x11, 0xfa, 0x66, 0x9f, 0x29, 0xff, 0x94, 0x49, 0x59, 0xf1,
+	0x1e, 0x82, 0x74, 0xc4, 0x68, 0x10, 0xc4, 0xdf, 0xf2, 0x23, 0xd8, 0x8c, 0xba, 0xc0, 0x23, 0x20,
+	0x18, 0x99, 0x7d, 0x46, 0x47, 0xeb, 0x3f, 0xf3, 0xbe, 0x2e, 0x09, 0x6e, 0x3b, 0x62, 0xe2, 0x21,
+	0xfb, 0x04, 0x2e, 0xcf, 0x0b, 0x91, 0x85, 0xdf, 0x73, 0xa9, 0x8d, 0x59, 0x29, 0x32, 0xf5, 0x17,
+	0x00, 0x31, 0x3c, 0x50, 0xfe, 0x25, 0xcd, 0xd2, 0x58, 0x28, 0xd0, 0x12, 0xf8, 0xa7, 0x2b, 0xb0,
+	0x44, 0xee, 0xf5, 0xe5, 0xf2, 0xea, 0xdf, 0x67, 0xa4, 0xdf, 0x66, 0xe2, 0x69, 0x30, 0x42, 0xdb,
+	0xaa, 0x36, 0xa0, 0x94, 0x5c, 0x51, 0x79, 0x03, 0x96, 0x6c, 0xd7, 0x62, 0xdf, 0x51, 0x6a, 0x91,
+	0xd7, 0x78, 0x43, 0xbe, 0x09, 0x80, 0xeb, 0x6c, 0xf6, 0x43, 0xe6, 0x07, 0x22, 0xbb, 0x48, 0x50,
+	0xaa, 0xa7, 0xb0, 0x36, 0xe3, 0xa3, 0xa8, 0xa8, 0x4f, 0x01, 0x54, 0x28, 0xa2, 0x86, 0xfc, 0x29,
+	0x5c, 0x7b, 0x63, 0xbb, 0x96, 0xf7, 0x06, 0x7d, 0xd5, 0x0f, 0x67, 0x8f, 0xfd, 0x2c, 0x1d, 0xfb,
+	0x0a, 0x87, 0xf4, 0x10, 0x31, 0x75, 0xf6, 0x57, 0xff, 0x3c, 0x07, 0xeb, 0x73, 0x5e, 0x8c, 0xb9,
+	0xcb, 0x78, 0x94, 0xd0, 0x92, 0x21, 0x2d, 0x45, 0x4e, 0xe3, 0x49, 0xc3, 0xfb, 0xb0, 0xc6, 0xf7,
+	0x98, 0xe1, 0xb3, 0x3e, 0xb3, 0x5f, 0x33, 0x9e, 0x23, 0xe5, 0xb5, 0x0a, 0x27, 0x6b, 0x82, 0x2a,
+	0xef, 0xc2, 0xba, 0x00, 0x8e, 0x18, 0xe6, 0x32, 0x7d, 0xcf, 0xe5, 0xe9, 0x52, 0x46, 0x13, 0x1a,
+	0xba, 0xcc, 0xef, 0x11, 0x19, 0xfb, 0xa5, 0xdd, 0xeb, 0xb3, 0xbe, 0xe7, 0x5b, 0x01, 0xe5, 0x4c,
+	0x79, 0x8d, 0x76, 0xb4, 0xc6, 0x49, 0xf2, 0x03, 0xb8, 0xe4, 0x9b, 0x21, 0x33, 0x1c, 0x7b, 0x68,
+	0x87, 0xcc, 0x8a, 0x36, 0xfa, 0x12, 0x21, 0xd7, 0x91, 0xd5, 0xe2, 0x1c, 0xb1, 0x97, 0xef, 0x40,
+	0xd9, 0x62, 0x7d, 0xcf, 0x62, 0x06, 0xf3, 0x7d, 0xcf, 0x0f, 0x44, 0xf6, 0x54, 0xe2, 0x44, 0x95,
+	0x68, 0x94, 0x35, 0x85, 0x3e, 0x33, 0x87, 0x42, 0x9d, 0x61, 0xf9, 0xde, 0x68, 0xc4, 0x2c, 0xca,
+	0x9a, 0xf2, 0xda, 0x25, 0xce, 0xe4, 0x1a, 0x1b, 0x9c, 0x25, 0xef, 0x80, 0x74, 0xc6, 0xcc, 0x91,
+	0x61, 0x3a, 0x8e, 0xd7, 0x37, 0x4e, 0xce, 0x43, 0x16, 0x28, 0xab, 0x7c, 0x06, 0x90, 0x5e, 0x43,
+	0xf2, 0x53, 0xa4, 0x52, 0x9a, 0x77, 0x1e, 0x08, 0x48, 0x81, 0x20, 0xab, 0xc1, 0x79, 0xc0, 0x99,
+	0x97, 0x61, 0x79, 0xe4, 0x7b, 0x27, 0x2c, 0x50, 0x60, 0x3b, 0xb7, 0x53, 0xd0, 0x44, 0xab, 0xda,
+	0x84, 0x62, 0x62, 0x7b, 0xcb, 0x0a, 0x86, 0x44, 0x9c, 0xa3, 0x68, 0x31, 0xa2, 0xa6, 0xbc, 0x0d,
+	0x45, 0x5a, 0x24, 0xc1, 0xe5, 0x0b, 0x9e, 0x24, 0x55, 0xff, 0x26, 0x0b, 0xab, 0xd1, 0xf9, 0x20,
+	0x7f, 0x04, 0x79, 0x4c, 0x84, 0x49, 0x4b, 0x65, 0x41, 0x34, 0x8a, 0x80, 0xfa, 0xf9, 0x88, 0x69,
+	0x04, 0xc5, 0x15, 0x74, 0x3c, 0xd3, 0x32, 0x46, 0xbe, 0x37, 0xf0, 0xcd, 0xa1, 0x41, 0xf2, 0x98,
+	0x85, 0x95, 0xb5, 0x35, 0x64, 0x74, 0x39, 0x5d, 0x5f, 0x84, 0xa5, 0x6c, 0xae, 0x48, 0xee, 0x9d,
+	0xc4, 0x52, 0x4e, 0xf7, 0x18, 0x2e, 0x13, 0xd6, 0x76, 0x83, 0xd0, 0x1f, 0xd3, 0x29, 0x64, 0x70,
+	0x0f, 0x2f, 0x91, 0xf2, 0x0d, 0xe4, 0x36, 0x27, 0xcc, 0x3a, 0x39, 0xfc, 0x2d, 0x28, 0x9a, 0x61,
+	0x68, 0xf6, 0xcf, 0xb8, 0x1d, 0x1b, 0x04, 0x05, 0x4e, 0x8a, 0x4c, 0x10, 0x80, 0xc8, 0x88, 0x53,
+	0x8b, 0x8e, 0x9f, 0x75, 0x6d, 0x8d, 0x33, 0x84, 0x11, 0xfb, 0xb4, 0x88, 0x91, 0x32, 0xdc, 0xb2,
+	0x21, 0x42, 0x2f, 0x13, 0xb4, 0x22, 0x34, 0x12, 0x79, 0xdf, 0xaa, 0xfe, 0x66, 0x09, 0x2a, 0xd3,
+	0x07, 0x9d, 0xfc, 0xc9, 0xd4, 0x54, 0xde, 0xb9, 0xe0, 0x5c, 0x4c, 0x4c, 0xa8, 0x0c, 0x79, 0x9a,
+	0x17, 0xbe, 0xed, 0xe9, 0x7b, 0x2a, 0x35, 0x86, 0xb7, 0xa5, 0xc6, 0xc5, 0xd9, 0xd4, 0xf8, 0x36,
+	0x94, 0x38, 0xdb, 0xb2, 0x07, 0x2c, 0xe0, 0x93, 0x57, 0xd0, 0x8a, 0x44, 0x6b, 0x10, 0x49, 0xee,
+	0x45, 0x10, 0xc7, 0x3c, 0x61, 0x4e, 0xa0, 0x94, 0x29, 0xbd, 0x7f, 0x78, 0x81, 0xc5, 0xfc, 0x6c,
+	0x6e, 0x91, 0x88, 0xea, 0x86, 0xfe, 0xb9, 0x50, 0xca, 0x29, 0x68, 0xf1, 0x19, 0x6e, 0x56, 0x8c,
+	0xd1, 0x1b, 0x34, 0x67, 0x2b, 0xd8, 0xc6, 0xb0, 0xfc, 0x39, 0x94, 0x38, 0x4b, 0xe4, 0xdd, 0x9b,
+	0x29, 0xe7, 0x8d, 0xc8, 0xbb, 0x9b, 0xee, 0xa9, 0xa7, 0x15, 0x49, 0x58, 0x24, 0xde, 0xd7, 0xa0,
+	0xc0, 0xbe, 0xb3, 0x43, 0x03, 0xf7, 0x28, 0x5d, 0x25, 0xd6, 0xb5, 0x55, 0x24, 0xd4, 0x3d, 0x8b,
+	0xa1, 0x07, 0x10, 0x53, 0x1c, 0xce, 0xb7, 0xb8, 0x07, 0x20, 0x49, 0x1c, 0xba, 0x31, 0x80, 0xa7,
+	0x80, 0xdb, 0x09, 0x00, 0x4f, 0xf3, 0x76, 0x40, 0x12, 0xea, 0x7d, 0x66, 0x58, 0xe3, 0x21, 0x6e,
+	0xf5, 0xdb, 0xdb, 0x99, 0x9d, 0x55, 0xad, 0xc2, 0x7b, 0xf1, 0x59, 0x83, 0xa8, 0x18, 0x3e, 0xce,
+	0x98, 0xe9, 0x84, 0x67, 0x51, 0x6f, 0x3b, 0x34, 0xbb, 0x25, 0x4e, 0x14, 0xfd, 0xfd, 0x18, 0x64,
+	0xcb, 0xc3, 0xbd, 0x6a, 0xf4, 0x3d, 0xf7, 0xd4, 0x1e, 0x18, 0xdf, 0x06, 0x1e, 0x3f, 0xef, 0x0b,
+	0x9a, 0xc4, 0x39, 0x75, 0x62, 0x7c, 0x19, 0x78, 0xae, 0x7c, 0x1f, 0xd6, 0xbc, 0xbe, 0x3d, 0x05,
+	0x65, 0xfc, 0xb2, 0xe4, 0xf5, 0xed, 0x09, 0x6e, 0xeb, 0x33, 0x90, 0x66, 0x17, 0x40, 0x96, 0x20,
+	0xf7, 0x92, 0x9d, 0x8b, 0x5b, 0x2a, 0x7e, 0xe2, 0xa9, 0xf0, 0xda, 0x74, 0xc6, 0x91, 0x33, 0xf1,
+	0xc6, 0xcf, 0xb2, 0x3f, 0xc9, 0x54, 0xff, 0x33, 0x03, 0x30, 0xc9, 0xae, 0xe4, 0x47, 0x53, 0xde,
+	0x7a, 0xeb, 0x2d, 0x89, 0x58, 0xc2, 0x53, 0x93, 0x5e, 0x99, 0x7d, 0x9b, 0x57, 0xe6, 0x66, 0xbd,
+	0x72, 0x0b, 0x56, 0x7d, 0x36, 0xb0, 0x83, 0xd0, 0x3f, 0x17, 0x57, 0xdf, 0xb8, 0x8d, 0x31, 0x4f,
+	0xf8, 0x2a, 0xbf, 0xf4, 0x8a, 0x16, 0xae, 0xba, 0xcf, 0x46, 0x9e, 0x11, 0x9a, 0x03, 0x8c, 0xd3,
+	0x39, 0x2e, 0x34, 0xf2, 0x74, 0x73, 0x10, 0xa0, 0x9b, 0x13, 0x93, 0x63, 0xf1, 0x42, 0x8b, 0xfc,
+	0x22, 0xd2, 0xb8, 0x97, 0x07, 0xd5, 0xef, 0xb3, 0x50, 0x4a, 0xe6, 0xcf, 0xf2, 0x93, 0xa9, 0x31,
+	0xdf, 0x7e, 0x6b, 0xb2, 0x3d, 0x3d, 0xea, 0x80, 0x85, 0xe3, 0x11, 0x46, 0x03, 0xe0, 0x9e, 0x4d,
+	0x6d, 0x1e, 0x30, 0x38, 0x2b, 0x78, 0x65, 0x30, 0x37, 0xf4, 0x6d, 0xc6, 0x6f, 0x95, 0x65, 0xad,
+	0x42, 0xf4, 0xde, 0x2b, 0x95, 0x53, 0x27, 0xc8, 0xfe, 0x04, 0x59, 0x4a, 0x20, 0xeb, 0x31, 0xf2,
+	0x16, 0x14, 0x45, 0x77, 0x0e, 0x0e, 0xbc, 0xcc, 0xdd, 0x95, 0xf7, 0x88, 0x14, 0x74, 0xc2, 0x60,
+	0x7c, 0x32, 0xb4, 0x43, 0xc3, 0x1b, 0xd1, 0x8e, 0xe0, 0x41, 0xaf, 0xc4, 0x89, 0x1d, 0xa2, 0x51,
+	0x7f, 0x1c, 0x44, 0x89, 0xbf, 0x65, 0x86, 0x26, 0xed, 0xbb, 0xbc, 0x56, 0xe1, 0x74, 0xcc, 0xf6,
+	0x1b, 0x66, 0x68, 0x26, 0x90, 0xc1, 0x2b, 0x23, 0x3c, 0xf3, 0x99, 0xc9, 0x83, 0xde, 0x6a, 0x84,
+	0xec, 0xbd, 0xd2, 0x89, 0x5a, 0xed, 0xc3, 0xfa, 0xdc, 0xc5, 0x4e, 0xfe, 0xd9, 0xd4, 0xa4, 0xde,
+	0xbf, 0xf8, 0x2a, 0xf8, 0xf6, 0xc8, 0x57, 0xfd, 0xef, 0x0c, 0xac, 0x46, 0x17, 0xab, 0x0b, 0x8f,
+	0xa7, 0x08, 0x98, 0xd0, 0x79, 0x19, 0x96, 0xc5, 0xe5, 0x94, 0x6b, 0x15, 0x2d, 0xf9, 0x3a, 0x14,
+	0xbc, 0x11, 0xf3, 0x4d, 0x3c, 0x3a, 0x22, 0xff, 0x8c, 0x09, 0x74, 0xa0, 0x8e, 0x4f, 0xbe, 0x65,
+	0xfd, 0x50, 0xb8, 0x67, 0xd4, 0x44, 0x7d, 0x1e, 0x67, 0x08, 0xef, 0xe4, 0x2d, 0x74, 0x40, 0xfe,
+	0x65, 0xf4, 0x1d, 0x33, 0xe0, 0x89, 0x44, 0x41, 0x2b, 0x72, 0x5a, 0x1d, 0x49, 0xf1, 0xf0, 0x56,
+	0x12, 0x81, 0x5d, 0x81, 0x95, 0x21, 0x0b, 0x02, 0x5e, 0x55, 0xa1, 0x8e, 0x44, 0xb3, 0xfa, 0xd7,
+	0x19, 0x28, 0x26, 0xae, 0xaf, 0xf2, 0xe3, 0xa9, 0xb1, 0x6f, 0xbf, 0xed, 0xaa, 0x9b, 0x18, 0xbe,
+	0x02, 0x2b, 0xa6, 0x65, 0xf9, 0x18, 0x66, 0x79, 0x02, 0x16, 0x35, 0x71, 0x20, 0x0e, 0x73, 0x07,
+	0xe1, 0x19, 0x8d, 0x3e, 0xaf, 0x89, 0x16, 0x5a, 0x39, 0xf2, 0x3d, 0x3e, 0xee, 0xb2, 0x46, 0xdf,
+	0x18, 0x46, 0xb8, 0xf7, 0x2d, 0x11, 0x91, 0x37, 0x70, 0x23, 0x78, 0x0e, 0x1d, 0xe6, 0x21, 0x0d,
+	0xb7, 0xac, 0xad, 0x78, 0x0e, 0x9e, 0xe1, 0x61, 0xf5, 0xd7, 0x19, 0x80, 0xc9, 0x8d, 0xfd, 0xc2,
+	0xe8, 0x32, 0x81, 0x4e, 0xaf, 0x5c, 0xe0, 0x8d, 0xfd, 0x7e, 0xbc, 0x72, 0xbc, 0x85, 0x74, 0x7e,
+	0x1c, 0x8b, 0x65, 0x13, 0x2d, 0xa4, 0x9f, 0x06, 0xd4, 0x0d, 0x5f, 0x32, 0xd1, 0x9a, 0x36, 0x3e,
+	0x2f, 0x8c, 0xaf, 0xfe, 0xad, 0x04, 0xa5, 0x64, 0x61, 0xe7, 0xc2, 0x68, 0x90, 0x04, 0x27, 0xac,
+	0xbc, 0x0b, 0x95, 0x53, 0xcf, 0x7f, 0x69, 0xf4, 0xcf, 0x6c, 0x9c, 0x0b, 0x3b, 0x8a, 0x09, 0x25,
+	0xa4, 0xd6, 0x91, 0x88, 0x47, 0x5e, 0x15, 0xca, 0x09, 0x94, 0x6d, 0x89, 0x73, 0xba, 0x18, 0x83,
+	0x9a, 0x16, 0x46, 0xfe, 0x04, 0x86, 0xae, 0x29, 0x25, 0x52, 0x55, 0x8e, 0x51, 0x74, 0x3d, 0xd9,
+	0x01, 0x89, 0xe3, 0x1c, 0xcf, 0x65, 0x89, 0xa8, 0x90, 0xd7, 0xc8, 0x92, 0x3a, 0x92, 0x79, 0x64,
+	0x88, 0x34, 0x8e, 0x4c, 0x9f, 0xb9, 0xfc, 0x28, 0xae, 0x4c, 0x34, 0x76, 0x89, 0xda, 0x4d, 0x68,
+	0x14, 0x38, 0xea, 0x7a, 0x8d, 0xe7, 0x39, 0x13, 0x20, 0xf5, 0x7d, 0x07, 0xca, 0xec, 0x3b, 0xd6,
+	0x37, 0x4e, 0x6d, 0x87, 0x91, 0x2f, 0x6f, 0xf0, 0x03, 0x0f, 0x89, 0xfb, 0x82, 0x46, 0x39, 0x3d,
+	0x82, 0xfa, 0xde, 0x70, 0x68, 0xba, 0x16, 0x95, 0x0d, 0x95, 0x4d, 0x0a, 0xc8, 0x6b, 0xc8, 0xa8,
+	0x73, 0x7a, 0xcb, 0x76, 0xd9, 0x94, 0x42, 0x07, 0xbd, 0x94, 0x87, 0x9a, 0x58, 0x21, 0xd2, 0xf8,
+	0x89, 0xcd, 0xfa, 0x46, 0x70, 0x66, 0xee, 0x3d, 0xf9, 0x98, 0x0a, 0x2a, 0x05, 0x3c, 0xb1, 0x59,
+	0xbf, 0x47, 0x14, 0xf9, 0x03, 0x3c, 0xb1, 0x59, 0xdf, 0x60, 0xee, 0x6b, 0xdb, 0xf7, 0xdc, 0x21,
+	0x73, 0x43, 0x45, 0x99, 0x74, 0xa8, 0x4e, 0xc8, 0xff, 0x7f, 0x72, 0x87, 0xc8, 0x10, 0x9a, 0xed,
+	0xea, 0xc4, 0x10, 0x9a, 0xe7, 0xfb, 0xb0, 0x46, 0xcc, 0xc4, 0xca, 0xdd, 0xe1, 0x2b, 0x87, 0xe4,
+	0xa9, 0x95, 0x4b, 0xe2, 0x48, 0xd7, 0x5d, 0xbe, 0x72, 0x13, 0x20, 0x69, 0xbc, 0x01, 0x30, 0x1e,
+	0x59, 0x78, 0x37, 0xea, 0xbf, 0xb1, 0x44, 0x9e, 0x52, 0xe0, 0x94, 0xfa, 0x1b, 0x4b, 0x6e, 0xc0,
+	0x1a, 0xde, 0xe8, 0x8d, 0xfe, 0x99, 0xe9, 0x0e, 0x98, 0xe1, 0x39, 0x96, 0xb2, 0xf7, 0x0e, 0x65,
+	0x80, 0x32, 0x0a, 0xd5, 0x49, 0xa6, 0xe3, 0xcc, 0x69, 0x71, 0xd9, 0x1b, 0xe5, 0xd1, 0x0f, 0xd3,
+	0xd2, 0x66, 0x6f, 0xd0, 0x7f, 0xfa, 0xe6, 0x28, 0x52, 0x32, 0xc0, 0x94, 0xd3, 0x52, 0x7e, 0x41,
+	0x1e, 0xbe, 0xd6, 0x37, 0x47, 0x1c, 0x78, 0x40, 0x64, 0xf9, 0x21, 0x6c, 0x24, 0xb0, 0x23, 0xe6,
+	0x0f, 0xed, 0x30, 0x64, 0x96, 0xf2, 0x29, 0xc1, 0xe5, 0x18, 0xde, 0x8d, 0x38, 0x33, 0x12, 0xec,
+	0xf4, 0x94, 0xf5, 0x43, 0xfb, 0x35, 0x53, 0x3e, 0x9b, 0x91, 0x50, 0x23, 0x8e, 0xfc, 0x09, 0x28,
+	0x09, 0x09, 0x0a, 0x79, 0x71, 0x3f, 0x9f, 0x93, 0xd4, 0x66, 0x2c, 0xd5, 0x71, 0xac, 0x49, 0x57,
+	0xf3, 0x82, 0x93, 0xee, 0xbe, 0x98, 0x17, 0x9c, 0xf4, 0x78, 0x0f, 0x2a, 0x23, 0xaa, 0x93, 0x18,
+	0x3e, 0x7b, 0x35, 0xc6, 0x54, 0x68, 0x7f, 0x3b, 0xb3, 0x23, 0x6b, 0x65, 0x4e, 0xd5, 0x38, 0x11,
+	0x27, 0x4a, 0xc0, 0xe8, 0xaf, 0x4f, 0x7e, 0x72, 0xc0, 0xef, 0x32, 0x9c, 0x41, 0xc5, 0x13, 0x1f,
+	0x3d, 0xe5, 0x13, 0x50, 0x66, 0xb0, 0x93, 0xe7, 0x8b, 0x43, 0xf2, 0x86, 0xcd, 0x29, 0x91, 0xf8,
+	0x29, 0xe3, 0xe7, 0xb0, 0x35, 0x2d, 0x38, 0xf5, 0x6e, 0xd1, 0x24, 0xd1, 0x2b, 0x49, 0xd1, 0x7a,
+	0xe2, 0x0d, 0x63, 0xc6, 0x42, 0x5e, 0xb2, 0xf9, 0x72, 0xce, 0x42, 0xb6, 0xc0, 0x42, 0x96, 0xb4,
+	0xf0, 0xd9, 0x9c, 0x85, 0x2c, 0xd5, 0x42, 0x36, 0x6d, 0x61, 0x6b, 0xce, 0x42, 0x96, 0xb4, 0xf0,
+	0x43, 0xd8, 0xf0, 0xbc, 0xa1, 0xf1, 0xd2, 0x76, 0x1c, 0x23, 0xf4, 0xed, 0xc1, 0x40, 0x4c, 0x63,
+	0x97, 0x8c, 0x5c, 0xf7, 0xbc, 0xe1, 0x33, 0xdb, 0x71, 0x74, 0xce, 0x41, 0x33, 0x3f, 0x80, 0xf5,
+	0x89, 0x80, 0x17, 0x9a, 0x8e, 0xf1, 0x7a, 0xa8, 0x7c, 0xc5, 0xe3, 0x6f, 0x84, 0x46, 0xf2, 0xf3,
+	0xe1, 0x14, 0xd4, 0x74, 0x3d, 0xd7, 0xf0, 0x83, 0x40, 0xd1, 0xa6, 0xa0, 0x35, 0xd7, 0x73, 0xb5,
+	0x20, 0x98, 0x82, 0x62, 0x2c, 0x24, 0x68, 0x6f, 0x0a, 0x8a, 0xe1, 0x10, 0xa1, 0x3f, 0x02, 0x39,
+	0x86, 0x06, 0x67, 0x43, 0x36, 0x24, 0xac, 0xce, 0xf7, 0x87, 0xc0, 0xf6, 0x90, 0x3e, 0x07, 0xa6,
+	0xa0, 0x64, 0x5a, 0xdf, 0x2a, 0xc7, 0x7c, 0x05, 0x22, 0x30, 0xd2, 0x6b, 0xd6, 0xb7, 0xf4, 0x28,
+	0xe5, 0x9b, 0xc1, 0x59, 0x14, 0xde, 0x7e, 0x9f, 0x60, 0x45, 0xa2, 0x89, 0xf8, 0x76, 0x03, 0x80,
+	0x43, 0x28, 0x7e, 0xfe, 0x01, 0x01, 0x0a, 0x44, 0xa1, 0x00, 0xfa, 0x01, 0x48, 0x9c, 0x8d, 0x61,
+	0x77, 0x1c, 0x9a, 0x27, 0x0e, 0x53, 0xfe, 0x90, 0xdf, 0xef, 0x89, 0xae, 0xc6, 0x64, 0xf9, 0x7d,
+	0x58, 0x0b, 0x58, 0xbf, 0xef, 0x0d, 0x47, 0x46, 0xf4, 0x76, 0x63, 0xf1, 0xc8, 0x25, 0xc8, 0xe2,
+	0xc5, 0x46, 0x56, 0x21, 0xa2, 0x18, 0x26, 0xdd, 0xf4, 0xe9, 0x42, 0x54, 0xd9, 0xbb, 0xb9, 0xa0,
+	0xe0, 0x4a, 0xb0, 0x1a, 0xa1, 0xb4, 0x72, 0x90, 0x6c, 0xe2, 0xe0, 0x22, 0x35, 0x94, 0xfd, 0x9e,
+	0x52, 0xec, 0x2e, 0x0a, 0x1a, 0xa5, 0xbe, 0x0f, 0x61, 0x63, 0xc6, 0x24, 0x7e, 0x7d, 0x19, 0xd0,
+	0x08, 0xe4, 0x69, 0xbb, 0xf0, 0x1e, 0x53, 0xfd, 0xab, 0x0c, 0x94, 0x92, 0xc5, 0xe6, 0x0b, 0xb3,
+	0x88, 0x24, 0x78, 0x3a, 0xf3, 0xc5, 0xbc, 0x3c, 0xca, 0x7c, 0xf1, 0x1b, 0x6f, 0x73, 0x61, 0x78,
+	0x2e, 0x92, 0x1c, 0x7a, 0x21, 0x90, 0x21, 0x8f, 0xd7, 0x60, 0x91, 0xdf, 0xd0, 0x77, 0x32, 0xc1,
+	0xe3, 0x09, 0x69, 0x9c, 0xe0, 0xdd, 0x00, 0x10, 0x75, 0x6f, 0xdc, 0x06, 0xcb, 0x7c, 0xa9, 0x04,
+	0xa5, 0x69, 0x55, 0xff, 0x23, 0x07, 0xc5, 0xc4, 0x33, 0xc7, 0x85, 0xf9, 0x65, 0x02, 0x3b, 0x93,
+	0xa4, 0x71, 0x67, 0xc9, 0x52, 0x07, 0xd1, 0x53, 0xc9, 0x06, 0x2c, 0x31, 0xdf, 0x77, 0x3d, 0x32,
+	0x7f, 0x5d, 0xe3, 0x0d, 0x1c, 0x00, 0xf9, 0x4d, 0x9e, 0x88, 0xf4, 0x2d, 0x3f, 0x80, 0x4b, 0x03,
+	0xe6, 0x32, 0x2a, 0xdb, 0x89, 0x32, 0xcb, 0x24, 0x8b, 0x5a, 0x8f, 0x58, 0xbc, 0xd2, 0x82, 0xfb,
+	0xef, 0xe7, 0xb0, 0x35, 0x87, 0x9f, 0x04, 0x0a, 0x9e, 0x57, 0x5d, 0x99, 0x11, 0x8b, 0x43, 0xc5,
+	0xe7, 0x70, 0x7d, 0x56, 0x78, 0x2a, 0x58, 0xf0, 0xea, 0xc8, 0xd5, 0x69, 0xf1, 0x64, 0xb8, 0xb8,
+	0x07, 0x95, 0x58, 0xc1, 0xc0, 0xf7, 0xc6, 0x23, 0x4a, 0xbd, 0x56, 0xb5, 0x72, 0x44, 0x3d, 0x40,
+	0x22, 0x3a, 0x77, 0x0c, 0xf3, 0x59, 0x30, 0x76, 0x42, 0x91, 0x79, 0xc5, 0xd2, 0x1a, 0x51, 0xa9,
+	0x38, 0xc0, 0x1c, 0xfb, 0x35, 0xf3, 0x8d, 0xc0, 0x34, 0xce, 0x4c, 0xd7, 0x72, 0xc4, 0x5b, 0x4a,
+	0x5e, 0x93, 0x04, 0xa7, 0x67, 0x1e, 0x72, 0x3a, 0x1e, 0xf7, 0x09, 0x34, 0x4f, 0xfd, 0xc4, 0x2d,
+	0x2e, 0xc6, 0x52, 0xea, 0x57, 0xfd, 0x2f, 0x74, 0xcc, 0xc4, 0x93, 0xe7, 0xc5, 0x8e, 0x99, 0x00,
+	0x27, 0xd6, 0x97, 0xbf, 0x7b, 0xf3, 0xb2, 0x61, 0xd6, 0xb6, 0xe2, 0x3b, 0x4c, 0x2e, 0x71, 0x87,
+	0x91, 0x21, 0x6f, 0xfa, 0x83, 0x87, 0xb4, 0x64, 0x79, 0x8d, 0xbe, 0x05, 0xed, 0x23, 0x5a, 0x0f,
+	0x4e, 0xfb, 0x48, 0xd0, 0xf6, 0x68, 0x92, 0x39, 0x6d, 0x4f, 0xd0, 0x1e, 0x89, 0x04, 0x96, 0xbe,
+	0x05, 0xed, 0x31, 0xcd, 0x18, 0xa7, 0x3d, 0x16, 0xb4, 0x27, 0x94, 0x96, 0x72, 0xda, 0x13, 0xdc,
+	0x20, 0x3e, 0x0b, 0x69, 0xb2, 0x72, 0x1a, 0x7e, 0x56, 0x6d, 0x58, 0x8d, 0x5e, 0xd5, 0x2e, 0xbc,
+	0x2b, 0x46, 0xc0, 0xe9, 0x5d, 0x48, 0xa1, 0x01, 0x87, 0x5b, 0xd2, 0xe8, 0x3b, 0xed, 0x9a, 0x54,
+	0xfd, 0xf7, 0x0c, 0x14, 0xe2, 0x07, 0x5e, 0x79, 0x6f, 0xaa, 0xb3, 0x9b, 0xe9, 0x4f, 0xc1, 0x89,
+	0xde, 0xb6, 0x60, 0x35, 0x4e, 0xa3, 0x79, 0x4d, 0x2f, 0x6e, 0xe3, 0xde, 0xf5, 0x46, 0xcc, 0x15,
+	0x4b, 0x5c, 0xe4, 0x7b, 0x17, 0x29, 0x3c, 0xb1, 0xbf, 0x46, 0x97, 0x57, 0xd7, 0x18, 0xe2, 0x66,
+	0xe2, 0x97, 0x84, 0x55, 0x24, 0x1c, 0x89, 0x24, 0xf6, 0x8d, 0x6f, 0x63, 0xa2, 0x47, 0xd5, 0x52,
+	0x3e, 0xb3, 0x40, 0xa4, 0xb8, 0x46, 0x3a, 0x64, 0xc3, 0x53, 0x4b, 0x68, 0xaf, 0xf0, 0x24, 0x96,
+	0x48, 0xdc, 0x79, 0x7e, 0x95, 0x81, 0x95, 0xa8, 0xd6, 0x26, 0x41, 0x6e, 0x24, 0x7e, 0xf9, 0xb0,
+	0xae, 0xe1, 0x27, 0x46, 0x1c, 0x91, 0xd9, 0x47, 0x45, 0x1f, 0xd1, 0x94, 0x6f, 0x02, 0x24, 0xe2,
+	0x7e, 0x6e, 0x92, 0xa6, 0x8b, 0x90, 0x3f, 0xfd, 0xa3, 0x89, 0xfc, 0xec, 0x8f, 0x26, 0x66, 0x7f,
+	0x13, 0xb1, 0x34, 0xf7, 0x9b, 0x88, 0xea, 0x73, 0x80, 0xc9, 0xb3, 0x0b, 0xda, 0xe6, 0xb2, 0xe8,
+	0xc5, 0x03, 0x3f, 0x91, 0x32, 0x74, 0x43, 0x71, 0xd5, 0xc5, 0xcf, 0xc8, 0x7e, 0xbe, 0x78, 0x64,
+	0x7f, 0x14, 0x6b, 0xf9, 0xf3, 0x01, 0x7d, 0x57, 0xbf, 0xcf, 0x42, 0x31, 0x51, 0x6e, 0x9c, 0xb1,
+	0x34, 0x33, 0x6b, 0xa9, 0x50, 0x9a, 0x9d, 0x4c, 0x8a, 0x0c, 0x79, 0x4a, 0xbe, 0x79, 0xb8, 0xa3,
+	0xef, 0x99, 0xe9, 0xc8, 0xcf, 0x4d, 0x07, 0x8d, 0x37, 0x71, 0x45, 0x5a, 0xe2, 0x35, 0xab, 0x7e,
+	0xe2, 0x7a, 0x24, 0x41, 0x0e, 0xd3, 0x75, 0x5e, 0x4c, 0xc0, 0x4f, 0xf9, 0xb3, 0xe9, 0xc7, 0xba,
+	0x95, 0x1f, 0xfa, 0x56, 0x87, 0xa7, 0x02, 0x3d, 0x05, 0x85, 0xf6, 0x90, 0xd7, 0x1c, 0x72, 0x5a,
+	0x81, 0x28, 0xba, 0x3d, 0x64, 0x73, 0x6b, 0x50, 0x98, 0xff, 0x5d, 0xca, 0x1d, 0x28, 0x4f, 0x3f,
+	0xc1, 0x89, 0x0b, 0xaf, 0x9b, 0x78, 0x7a, 0xab, 0xfe, 0x69, 0x06, 0x60, 0xf2, 0x94, 0x27, 0x7f,
+	0x11, 0xff, 0x52, 0xe0, 0xd4, 0x47, 0x98, 0x92, 0xa1, 0x1a, 0x73, 0xca, 0xf3, 0xdf, 0x3e, 0x62,
+	0xa2, 0x1f, 0x08, 0x50, 0x23, 0x90, 0x7f, 0x01, 0x45, 0xaa, 0x5c, 0x09, 0xf9, 0xec, 0xc5, 0xf2,
+	0x80, 0x78, 0x2e, 0x5d, 0x75, 0x85, 0x35, 0xd4, 0x4c, 0x9e, 0x99, 0x99, 0xb9, 0xa2, 0x48, 0x70,
+	0x3e, 0x3c, 0xf1, 0x9c, 0xb8, 0xe6, 0x40, 0x2d, 0xaa, 0xfa, 0x9c, 0x9e, 0x06, 0xa2, 0xe6, 0x90,
+	0xd7, 0x44, 0x2b, 0x51, 0x5d, 0xca, 0x27, 0xab, 0x4b, 0xd5, 0xef, 0x97, 0xe0, 0x4a, 0xca, 0xaf,
+	0x38, 0xe4, 0x63, 0x28, 0x98, 0xfe, 0x60, 0x3c, 0xa4, 0x97, 0x29, 0x3e, 0x0f, 0x9f, 0xbc, 0xeb,
+	0x4f, 0x40, 0x1e, 0xd4, 0x22, 0x49, 0x5e, 0x72, 0x9f, 0x68, 0x92, 0xbf, 0x10, 0x21, 0x28, 0x4b,
+	0x21, 0xe8, 0xc7, 0xef, 0xaa, 0x71, 0xe6, 0x2c, 0xe7, 0x83, 0xcf, 0x25, 0x07, 0xbf, 0xf5, 0x3f,
+	0x19, 0x80, 0x7d, 0x9b, 0x39, 0xd6, 0x73, 0xd3, 0x19, 0x33, 0xf9, 0x2b, 0x80, 0x53, 0x6c, 0x19,
+	0x89, 0x88, 0xb7, 0xf7, 0xce, 0x03, 0x20, 0x45, 0xd4, 0x69, 0xe1, 0x34, 0xfa, 0x94, 0x6f, 0x43,
+	0x91, 0xde, 0xbf, 0x8c, 0x49, 0xb1, 0xba, 0x74, 0xf8, 0x9e, 0x06, 0x44, 0xe4, 0xbd, 0xde, 0x81,
+	0x52, 0x10, 0xfa, 0xb6, 0x3b, 0x10, 0x18, 0x32, 0xf1, 0xf0, 0x3d, 0xad, 0xc8, 0xa9, 0x13, 0x90,
+	0x3d, 0x70, 0x99, 0x25, 0x40, 0xb8, 0x28, 0x32, 0x81, 0x88, 0xca, 0x41, 0xef, 0x43, 0x65, 0xec,
+	0x4e, 0xc1, 0xa8, 0x30, 0x74, 0xf8, 0x9e, 0x56, 0x8e, 0xe8, 0x04, 0x7c, 0xba, 0x22, 0x8a, 0xe7,
+	0x5b, 0xaf, 0xa0, 0x32, 0x3d, 0xef, 0x0b, 0x2a, 0xed, 0xcd, 0x64, 0xa5, 0xbd, 0xb8, 0xf7, 0xe8,
+	0x87, 0x4d, 0x08, 0x75, 0x98, 0x2c, 0xcf, 0xff, 0x19, 0x1d, 0x2f, 0xd1, 0xfc, 0x14, 0x61, 0xe5,
+	0xb8, 0xfd, 0xac, 0xdd, 0xf9, 0xba, 0x2d, 0xbd, 0x27, 0x17, 0x60, 0xe9, 0xe9, 0x0b, 0x5d, 0xed,
+	0x49, 0x19, 0x19, 0x60, 0xb9, 0xa7, 0x6b, 0xcd, 0xf6, 0x81, 0x94, 0x45, 0x72, 0xaf, 0xd9, 0xd6,
+	0x7f, 0x22, 0xe5, 0x88, 0xdc, 0x6c, 0xeb, 0x1f, 0x7d, 0x2c, 0xe5, 0xa3, 0xef, 0x47, 0x7b, 0xd2,
+	0x52, 0xf4, 0xfd, 0xf1, 0x63, 0x69, 0x19, 0xe1, 0xc7, 0x04, 0x5f, 0x41, 0xf2, 0x31, 0x87, 0xaf,
+	0x46, 0xdf, 0x8f, 0xf6, 0xa4, 0x42, 0xf4, 0xfd, 0xf1, 0x63, 0x09, 0xaa, 0xff, 0x96, 0x85, 0xcd,
+	0x85, 0x3f, 0x08, 0x91, 0x3f, 0x9b, 0x3a, 0xfa, 0x76, 0xdf, 0xed, 0x67, 0x24, 0x09, 0xaf, 0x9b,
+	0x8e, 0x92, 0xd9, 0xb9, 0x28, 0x99, 0xe2, 0x95, 0x72, 0x2f, 0xb9, 0x8d, 0xf2, 0xb4, 0x8d, 0x9e,
+	0xbc, 0x5b, 0xe7, 0xe9, 0x9b, 0xe8, 0xff, 0x62, 0xa5, 0x7f, 0x97, 0x85, 0x52, 0xf2, 0x77, 0x5a,
+	0x17, 0x66, 0x6a, 0x49, 0xf0, 0x6c, 0xb9, 0xb4, 0xff, 0x52, 0x3c, 0x4a, 0xe4, 0x35, 0xd1, 0x92,
+	0x7f, 0x3a, 0x09, 0x76, 0xc5, 0x94, 0x9f, 0xe8, 0x08, 0x8d, 0x35, 0x0e, 0x9b, 0x8a, 0x86, 0x22,
+	0x79, 0x2d, 0x51, 0xf9, 0x41, 0xb4, 0x30, 0x7e, 0x9e, 0x98, 0xfd, 0x97, 0x8e, 0x37, 0x10, 0xd9,
+	0x45, 0xd4, 0x94, 0x1b, 0x50, 0x76, 0xbc, 0xbe, 0xe9, 0x18, 0x51, 0x97, 0x95, 0x77, 0xeb, 0xb2,
+	0x44, 0x52, 0xa2, 0x25, 0x6f, 0x43, 0xc9, 0x72, 0x03, 0xe3, 0xd5, 0x98, 0xf9, 0xe7, 0x86, 0xa8,
+	0x45, 0x96, 0x35, 0xb0, 0xdc, 0xe0, 0x2b, 0x24, 0x35, 0x2d, 0xf9, 0x2e, 0x54, 0x26, 0x08, 0xca,
+	0xa0, 0x24, 0x5e, 0x88, 0x8c, 0x30, 0x74, 0x3b, 0xfb, 0xe3, 0x0c, 0x6c, 0xce, 0xfe, 0x86, 0x8d,
+	0xc7, 0x80, 0x9f, 0x4e, 0xcd, 0xf1, 0xbd, 0x0b, 0x7f, 0xf9, 0x36, 0x3d, 0xcf, 0xfc, 0x71, 0x4e,
+	0x64, 0x19, 0xa2, 0x35, 0x79, 0x6a, 0xe3, 0x27, 0x04, 0x6f, 0x54, 0xff, 0x32, 0x03, 0xd2, 0xac,
+	0x32, 0x4c, 0xfa, 0x79, 0xe5, 0x80, 0x7e, 0x44, 0xc1, 0x5c, 0xf4, 0x73, 0x4b, 0x1c, 0x45, 0x12,
+	0x71, 0xf0, 0x2c, 0x56, 0x39, 0x7d, 0x06, 0xed, 0x8f, 0x5d, 0xd7, 0x76, 0xa3, 0xce, 0x27, 0x68,
+	0x8d, 0xd3, 0xe5, 0xcf, 0x60, 0x99, 0x7a, 0x0e, 0x94, 0x1c, 0xed, 0x89, 0xfb, 0x17, 0x8e, 0x8d,
+	0x7b, 0xa4, 0x90, 0xda, 0x75, 0xa1, 0x94, 0x7c, 0xe4, 0x97, 0xb7, 0xe0, 0xf2, 0xd3, 0xee, 0xbe,
+	0xa1, 0x3e, 0x57, 0xdb, 0xba, 0xa1, 0xbf, 0xe8, 0xaa, 0xc6, 0x24, 0x12, 0xdd, 0x82, 0x6b, 0x33,
+	0xbc, 0xae, 0xd6, 0x39, 0xd0, 0x6a, 0x47, 0x46, 0xab, 0x53, 0x6b, 0x48, 0x19, 0xf9, 0x36, 0xdc,
+	0x48, 0x01, 0xd4, 0x74, 0xbd, 0x56, 0x3f, 0x94, 0xb2, 0xbb, 0x7f, 0x97, 0x05, 0x79, 0xfe, 0x29,
+	0x5c, 0xde, 0x86, 0xeb, 0xf5, 0x4e, 0x5b, 0xaf, 0x35, 0xdb, 0xaa, 0xb6, 0xb8, 0xf3, 0x34, 0x44,
+	0x5d, 0x53, 0x6b, 0xba, 0x8a, 0xbd, 0xa7, 0x21, 0xb4, 0xe3, 0x76, 0x9b, 0xc7, 0xcc, 0x5b, 0x70,
+	0x6d, 0x21, 0x42, 0xfd, 0xa6, 0x89, 0x2a, 0x72, 0x72, 0x15, 0x6e, 0x2e, 0x04, 0x34, 0xd4, 0x9e,
+	0xae, 0x75, 0x5e, 0xa8, 0x0d, 0x29, 0x9f, 0x6e, 0x6a, 0xb7, 0x41, 0x86, 0x2c, 0xa5, 0x76, 0x73,
+	0xa8, 0xd6, 0x5a, 0xfa, 0xa1, 0xb4, 0x9c, 0x0a, 0xe8, 0xd6, 0x8e, 0x7b, 0x6a, 0x43, 0x5a, 0x49,
+	0x1f, 0x8a, 0xda, 0x3b, 0x3e, 0x52, 0x1b, 0xd2, 0xea, 0xee, 0x5f, 0x64, 0xa0, 0x32, 0xfd, 0x48,
+	0x2b, 0x5f, 0x07, 0xa5, 0x79, 0x54, 0x3b, 0x50, 0x17, 0xcf, 0xdf, 0x35, 0xb8, 0x32, 0xc7, 0xed,
+	0x1e, 0xb7, 0x5a, 0x34, 0x75, 0x8b, 0x98, 0x7a, 0xed, 0xe0, 0x40, 0x6d, 0x48, 0x59, 0xf9, 0x06,
+	0x5c, 0x5d, 0xa0, 0x57, 0xb0, 0x73, 0x0b, 0xbb, 0x6d, 0xa8, 0x2d, 0x15, 0xe7, 0x22, 0xbf, 0xeb,
+	0x83, 0x34, 0xfb, 0xae, 0x8a, 0xc3, 0x6f, 0x76, 0x8c, 0x63, 0x3c, 0xc8, 0x16, 0xdb, 0x8a, 0x3d,
+	0x2e, 0x00, 0xf4, 0x54, 0xfd, 0xb8, 0x2b, 0x65, 0xe4, 0x9b, 0xb0, 0xb5, 0x90, 0x7d, 0xfc, 0xf4,
+	0xa8, 0xa9, 0x4b, 0xd9, 0xdd, 0x5f, 0x66, 0x60, 0x73, 0xe1, 0xbb, 0xa3, 0x7c, 0x17, 0xb6, 0x9f,
+	0xa9, 0x5a, 0x5b, 0x6d, 0x19, 0x47, 0x9d, 0xc6, 0x71, 0x2b, 0x65, 0xaa, 0x6e, 0xc3, 0x8d, 0x54,
+	0x94, 0xf0, 0xf4, 0x3b, 0x70, 0xeb, 0x2d, 0x8a, 0x08, 0x94, 0xdd, 0x55, 0xa1, 0x94, 0x7c, 0xa1,
+	0xc4, 0xbd, 0xd5, 0xea, 0x1d, 0x2d, 0xee, 0xf3, 0x2a, 0x6c, 0xce, 0xf0, 0x1a, 0x6a, 0xbb, 0x59,
+	0x6b, 0x49, 0x99, 0xdd, 0xd7, 0xb0, 0x36, 0xf3, 0xd8, 0x87, 0x13, 0x74, 0xa4, 0x1e, 0x75, 0xb4,
+	0x17, 0xa9, 0x1b, 0x75, 0x9e, 0x7d, 0x74, 0x54, 0xeb, 0x1a, 0xea, 0x37, 0x6a, 0x9d, 0x9b, 0xbf,
+	0x00, 0xd0, 0xd5, 0x3a, 0xba, 0x5a, 0xd7, 0x39, 0x28, 0xbb, 0x7b, 0x06, 0x95, 0xe9, 0x87, 0x3a,
+	0x5c, 0xea, 0xa3, 0xce, 0x71, 0x5b, 0x5f, 0xdc, 0xeb, 0x16, 0x5c, 0x9e, 0xe3, 0x12, 0x41, 0xca,
+	0xa4, 0x48, 0x72, 0x6e, 0x76, 0xf7, 0x97, 0x39, 0x90, 0x66, 0xdf, 0xdb, 0x70, 0x95, 0xbb, 0x5a,
+	0xa7, 0xae, 0xf6, 0x7a, 0xa9, 0x0e, 0xbd, 0x80, 0xbf, 0xdf, 0xd1, 0x9e, 0x71, 0x87, 0x5e, 0xc0,
+	0xe4, 0x03, 0x4b, 0x65, 0x36, 0x75, 0x29, 0x87, 0x53, 0xbb, 0xa8, 0x5b, 0xda, 0xdc, 0x52, 0x1e,
+	0x23, 0xc4, 0x02, 0x76, 0x5d, 0x53, 0x1b, 0x46, 0xfd, 0xb0, 0xd6, 0x3e, 0x50, 0xa5, 0x25, 0x79,
+	0x07, 0xee, 0x2e, 0xc2, 0xd4, 0xba, 0xb5, 0xa7, 0xcd, 0x56, 0x53, 0x7f, 0x11, 0x21, 0x97, 0xd1,
+	0x1f, 0x17, 0x20, 0xbb, 0xba, 0x56, 0xab, 0xab, 0x51, 0xcc, 0x5c, 0xc1, 0xe5, 0x5c, 0x80, 0xea,
+	0x74, 0x8e, 0x8c, 0x67, 0xcd, 0x56, 0x4b, 0x5a, 0xc5, 0xd9, 0x5d, 0x68, 0x54, 0xad, 0x77, 0x28,
+	0x15, 0x52, 0xcc, 0xe9, 0xa9, 0xf5, 0x7a, 0xe7, 0xa8, 0x6b, 0x3c, 0x6f, 0x76, 0x5a, 0x35, 0xbd,
+	0xd9, 0x69, 0x4b, 0xb0, 0xfb, 0x47, 0x50, 0x9e, 0xaa, 0xa9, 0xe2, 0x92, 0x46, 0xb8, 0x5a, 0x1d,
+	0x41, 0x89, 0xf9, 0xbf, 0x02, 0x97, 0x66, 0x78, 0xba, 0x56, 0xc3, 0xed, 0x39, 0xcf, 0x20, 0x33,
+	0xb3, 0xbb, 0x1e, 0x48, 0xb3, 0xf5, 0x50, 0x5c, 0xe5, 0x9e, 0xda, 0xeb, 0x21, 0x6a, 0xe1, 0x2a,
+	0x5f, 0x07, 0x65, 0x01, 0xbf, 0xd5, 0x39, 0x68, 0xb6, 0xa5, 0x0c, 0x2e, 0xd6, 0x62, 0x6e, 0xe7,
+	0x58, 0xa7, 0x0e, 0xd7, 0x66, 0xca, 0x98, 0x24, 0xd1, 0x3c, 0x68, 0xd7, 0x5a, 0x8b, 0xbb, 0x43,
+	0x73, 0xe6, 0xd8, 0x07, 0x6a, 0x5b, 0xd5, 0x70, 0xf9, 0x33, 0x8b, 0xc5, 0x1b, 0x6a, 0xab, 0xf9,
+	0x5c, 0xd5, 0xa4, 0xec, 0xee, 0x10, 0xa4, 0xd9, 0xc2, 0x1a, 0xa9, 0x7c, 0xd1, 0xab, 0xd7, 0x5a,
+	0xad, 0xf4, 0x11, 0xce, 0xf3, 0xd5, 0xb6, 0xae, 0x6a, 0xdc, 0x91, 0x17, 0x71, 0xbf, 0xa1, 0x40,
+	0x57, 0x87, 0x52, 0xb2, 0xac, 0x85, 0xcb, 0xa5, 0xeb, 0x29, 0x31, 0xe1, 0x0a, 0x5c, 0x9a, 0xe1,
+	0x69, 0x2a, 0x86, 0xb2, 0xdd, 0x3f, 0xc9, 0x40, 0x79, 0xaa, 0x5e, 0x85, 0x7d, 0xee, 0x37, 0xd3,
+	0x82, 0xa3, 0x02, 0x1b, 0xb3, 0xcc, 0x4e, 0x57, 0xc5, 0xc5, 0xb8, 0x0a, 0x9b, 0xb3, 0x9c, 0xaf,
+	0xb5, 0xa6, 0xae, 0x4a, 0x59, 0x3c, 0xcf, 0x66, 0x59, 0x47, 0xea, 0xd1, 0x7e, 0x43, 0x9c, 0xde,
+	0x52, 0x6e, 0xf7, 0xd7, 0x19, 0xb8, 0xf6, 0x96, 0x2b, 0xab, 0xfc, 0x23, 0x78, 0x5f, 0x04, 0xdc,
+	0xfd, 0xe3, 0x36, 0xf7, 0xaa, 0xf4, 0x29, 0xfd, 0x00, 0xee, 0x5d, 0x04, 0x8e, 0xe6, 0x77, 0x07,
+	0xee, 0x5e, 0x08, 0xe5, 0x93, 0xfd, 0xab, 0x0c, 0x5c, 0x4d, 0xbd, 0xdc, 0x60, 0x97, 0xc7, 0x3d,
+	0x55, 0x7b, 0x17, 0xeb, 0xde, 0x87, 0x3b, 0x6f, 0x87, 0x46, 0xb6, 0xdd, 0x87, 0xea, 0x05, 0x40,
+	0x6e, 0xd9, 0xbf, 0x2e, 0x81, 0x34, 0x7b, 0x4b, 0x40, 0xb7, 0x6b, 0xab, 0xfa, 0xd7, 0x1d, 0xed,
+	0xd9, 0x62, 0x2b, 0xee, 0x43, 0x75, 0x01, 0xbf, 0xde, 0x69, 0xb7, 0xf1, 0x08, 0xa8, 0xe9, 0xba,
+	0x7a, 0xd4, 0xc5, 0xc8, 0x7d, 0x0f, 0x6e, 0xbf, 0x05, 0x87, 0x09, 0x49, 0x4b, 0x97, 0xb2, 0x78,
+	0xa2, 0x2c, 0x80, 0x3d, 0x6d, 0xb6, 0x1b, 0xb1, 0x2e, 0x4a, 0xaf, 0xd2, 0x40, 0x42, 0x51, 0x3e,
+	0xa5, 0xbf, 0x56, 0xb3, 0xa7, 0xab, 0xed, 0x58, 0xd5, 0x12, 0x46, 0xce, 0x74, 0x98, 0x50, 0xb6,
+	0x9c, 0xa2, 0xac, 0x56, 0xaf, 0xab, 0xdd, 0xc9, 0x18, 0x57, 0x52, 0x94, 0x09, 0x98, 0x50, 0xb6,
+	0x9a, 0xa2, 0xac, 0xa7, 0xb6, 0x1b, 0x7a, 0x27, 0x56, 0x56, 0x48, 0x51, 0x26, 0x60, 0x42, 0x19,
+	0xa0, 0x13, 0x2c, 0x40, 0x69, 0x6a, 0xfd, 0xf9, 0xbe, 0xd6, 0x39, 0x8a, 0xd5, 0x15, 0x53, 0xd6,
+	0x29, 0x06, 0x0a, 0x85, 0xa5, 0x94, 0xb9, 0xd5, 0xeb, 0xdd, 0x68, 0xad, 0xa4, 0x32, 0x26, 0x36,
+	0x29, 0x18, 0x3e, 0x56, 0xa9, 0x82, 0x3b, 0x75, 0x01, 0xa4, 0xd1, 0xee, 0x19, 0x5f, 0x1d, 0xab,
+	0xda, 0x0b, 0x69, 0x2d, 0x65, 0xa5, 0x8f, 0xdb, 0xcd, 0x6f, 0xe2, 0x9e, 0xa4, 0xb7, 0xf4, 0xc4,
+	0x97, 0x48, 0x5a, 0xc7, 0x53, 0x6d, 0x91, 0x9e, 0x46, 0x97, 0x1c, 0x42, 0x92, 0x77, 0x7f, 0x93,
+	0x81, 0x8d, 0x45, 0x17, 0x33, 0x3a, 0x83, 0x55, 0x6d, 0xbf, 0xa3, 0x1d, 0xd5, 0xda, 0xf5, 0x94,
+	0x30, 0x75, 0x07, 0x6e, 0xa5, 0x60, 0x0e, 0x6b, 0x5a, 0xe3, 0xeb, 0x9a, 0x86, 0xd1, 0xfc, 0x03,
+	0xb8, 0x77, 0x01, 0xc8, 0xa8, 0xd7, 0xea, 0x87, 0x2a, 0xf7, 0xef, 0x14, 0x68, 0xaf, 0xb3, 0xaf,
+	0x93, 0xbe, 0xdc, 0xc9, 0x32, 0xfd, 0x3f, 0xe1, 0xa3, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x3d,
+	0xb9, 0x52, 0x30, 0xa6, 0x38, 0x00, 0x00,
 }
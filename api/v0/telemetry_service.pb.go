@@ -19,11 +19,53 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// The versions of the Telemetry API. A client sends the version that it
+// was built against in GetEventsRequest, and the Sensor replies with the
+// version that it will use for the stream, which is the lower of the
+// client's version and the newest version that the Sensor supports. Events
+// that did not exist at the stream's version are not sent on it, so older
+// clients keep working as event types are added, and subscriptions with
+// filters for them are refused with INVALID_ARGUMENT.
+type APIVersion int32
+
+const (
+	// Clients that predate versioning, which are treated as version 1
+	APIVersion_API_VERSION_UNSPECIFIED APIVersion = 0
+	// The original API, with syscall, process, file, kernel call,
+	// network, performance, and container events
+	APIVersion_API_VERSION_1 APIVersion = 1
+	// Adds kernel module, mount, memory, signal, LSM, TTY, io_uring,
+	// BPF, user function call, image, session, lost, and sensor
+	// status events; container health, pause, and resume events;
+	// process credential change through seccomp violation events;
+	// TCP connect through UDP bind network events; and file write
+	// and memfd_create events
+	APIVersion_API_VERSION_2 APIVersion = 2
+)
+
+var APIVersion_name = map[int32]string{
+	0: "API_VERSION_UNSPECIFIED",
+	1: "API_VERSION_1",
+	2: "API_VERSION_2",
+}
+var APIVersion_value = map[string]int32{
+	"API_VERSION_UNSPECIFIED": 0,
+	"API_VERSION_1":           1,
+	"API_VERSION_2":           2,
+}
+
+func (x APIVersion) String() string {
+	return proto.EnumName(APIVersion_name, int32(x))
+}
+func (APIVersion) EnumDescriptor() ([]byte, []int) { return fileDescriptor2, []int{0} }
+
 // A request message to initiate the streaming of telemetry events
 type GetEventsRequest struct {
 	// The Subscription message defines which events should be
 	// returned in the stream.
 	Subscription *Subscription `protobuf:"bytes,1,opt,name=subscription" json:"subscription,omitempty"`
+	// The version of the API that the client was built against
+	ApiVersion APIVersion `protobuf:"varint,2,opt,name=api_version,json=apiVersion,enum=capsule8.api.v0.APIVersion" json:"api_version,omitempty"`
 }
 
 func (m *GetEventsRequest) Reset()                    { *m = GetEventsRequest{} }
@@ -38,12 +80,34 @@ func (m *GetEventsRequest) GetSubscription() *Subscription {
 	return nil
 }
 
+func (m *GetEventsRequest) GetApiVersion() APIVersion {
+	if m != nil {
+		return m.ApiVersion
+	}
+	return APIVersion_API_VERSION_UNSPECIFIED
+}
+
 // A response message containing telemetry events
 type GetEventsResponse struct {
 	// Can publish one or more message(s) at a time
 	Events []*ReceivedTelemetryEvent `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
 	// Can publish one or more status(es) at a time
 	Statuses []*google_rpc.Status `protobuf:"bytes,2,rep,name=statuses" json:"statuses,omitempty"`
+	// The identifier of the stream's subscription, present in the
+	// first response of a stream whose subscription was accepted. It
+	// is used to modify the subscription with ModifySubscription.
+	SubscriptionId string `protobuf:"bytes,3,opt,name=subscription_id,json=subscriptionId" json:"subscription_id,omitempty"`
+	// The number of the response in the stream's delivery, present if
+	// the subscription has a delivery_id and the response carries
+	// events. Responses that are sent again keep their numbers.
+	SequenceNumber uint64 `protobuf:"varint,4,opt,name=sequence_number,json=sequenceNumber" json:"sequence_number,omitempty"`
+	// The version of the API used for the stream, present in the first
+	// response of a stream whose subscription was accepted
+	ApiVersion APIVersion `protobuf:"varint,5,opt,name=api_version,json=apiVersion,enum=capsule8.api.v0.APIVersion" json:"api_version,omitempty"`
+	// Set in the last response of a stream that the Sensor ends
+	// because it is shutting down, after all of the events that it
+	// held for the stream have been sent
+	EndOfStream bool `protobuf:"varint,6,opt,name=end_of_stream,json=endOfStream" json:"end_of_stream,omitempty"`
 }
 
 func (m *GetEventsResponse) Reset()                    { *m = GetEventsResponse{} }
@@ -65,6 +129,647 @@ func (m *GetEventsResponse) GetStatuses() []*google_rpc.Status {
 	return nil
 }
 
+func (m *GetEventsResponse) GetSubscriptionId() string {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return ""
+}
+
+func (m *GetEventsResponse) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+func (m *GetEventsResponse) GetApiVersion() APIVersion {
+	if m != nil {
+		return m.ApiVersion
+	}
+	return APIVersion_API_VERSION_UNSPECIFIED
+}
+
+func (m *GetEventsResponse) GetEndOfStream() bool {
+	if m != nil {
+		return m.EndOfStream
+	}
+	return false
+}
+
+// A request message to acknowledge the responses of a stream with at least
+// once delivery
+type AcknowledgeEventsRequest struct {
+	// The delivery_id of the stream's subscription
+	DeliveryId string `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId" json:"delivery_id,omitempty"`
+	// The sequence_number of the last response received; it and all
+	// responses before it are acknowledged
+	SequenceNumber uint64 `protobuf:"varint,2,opt,name=sequence_number,json=sequenceNumber" json:"sequence_number,omitempty"`
+}
+
+func (m *AcknowledgeEventsRequest) Reset()                    { *m = AcknowledgeEventsRequest{} }
+func (m *AcknowledgeEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*AcknowledgeEventsRequest) ProtoMessage()               {}
+func (*AcknowledgeEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+
+func (m *AcknowledgeEventsRequest) GetDeliveryId() string {
+	if m != nil {
+		return m.DeliveryId
+	}
+	return ""
+}
+
+func (m *AcknowledgeEventsRequest) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+// A response message for acknowledged events
+type AcknowledgeEventsResponse struct {
+	// The number of responses that are sent but not yet acknowledged
+	Pending uint64 `protobuf:"varint,1,opt,name=pending" json:"pending,omitempty"`
+}
+
+func (m *AcknowledgeEventsResponse) Reset()                    { *m = AcknowledgeEventsResponse{} }
+func (m *AcknowledgeEventsResponse) String() string            { return proto.CompactTextString(m) }
+func (*AcknowledgeEventsResponse) ProtoMessage()               {}
+func (*AcknowledgeEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
+
+func (m *AcknowledgeEventsResponse) GetPending() uint64 {
+	if m != nil {
+		return m.Pending
+	}
+	return 0
+}
+
+// A request message to replace the filters of an open stream of telemetry
+// events. The event_filter, container_filter, expression,
+// ring_buffer_pages, and capture_stack_traces of the stream's subscription
+// are replaced by those of the new subscription; the stream's modifier,
+// correlate_containers, and ttl_seconds are kept. The new filters are in
+// effect before the old ones are removed, so no events are lost, but events
+// matching both may be sent twice while they are replaced.
+type ModifySubscriptionRequest struct {
+	// The subscription_id sent on the stream to modify
+	SubscriptionId string `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId" json:"subscription_id,omitempty"`
+	// The new filters of the subscription
+	Subscription *Subscription `protobuf:"bytes,2,opt,name=subscription" json:"subscription,omitempty"`
+}
+
+func (m *ModifySubscriptionRequest) Reset()                    { *m = ModifySubscriptionRequest{} }
+func (m *ModifySubscriptionRequest) String() string            { return proto.CompactTextString(m) }
+func (*ModifySubscriptionRequest) ProtoMessage()               {}
+func (*ModifySubscriptionRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{4} }
+
+func (m *ModifySubscriptionRequest) GetSubscriptionId() string {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return ""
+}
+
+func (m *ModifySubscriptionRequest) GetSubscription() *Subscription {
+	if m != nil {
+		return m.Subscription
+	}
+	return nil
+}
+
+// A response message for a modified subscription
+type ModifySubscriptionResponse struct {
+	// The statuses of the new subscription, as sent at the start of a
+	// stream
+	Statuses []*google_rpc.Status `protobuf:"bytes,1,rep,name=statuses" json:"statuses,omitempty"`
+}
+
+func (m *ModifySubscriptionResponse) Reset()                    { *m = ModifySubscriptionResponse{} }
+func (m *ModifySubscriptionResponse) String() string            { return proto.CompactTextString(m) }
+func (*ModifySubscriptionResponse) ProtoMessage()               {}
+func (*ModifySubscriptionResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5} }
+
+func (m *ModifySubscriptionResponse) GetStatuses() []*google_rpc.Status {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+// A request message to list the open streams of telemetry events
+type ListSubscriptionsRequest struct {
+}
+
+func (m *ListSubscriptionsRequest) Reset()                    { *m = ListSubscriptionsRequest{} }
+func (m *ListSubscriptionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListSubscriptionsRequest) ProtoMessage()               {}
+func (*ListSubscriptionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{6} }
+
+// A response message listing the open streams of telemetry events
+type ListSubscriptionsResponse struct {
+	// The open streams, sorted by the time that they were opened
+	Subscriptions []*SubscriptionInfo `protobuf:"bytes,1,rep,name=subscriptions" json:"subscriptions,omitempty"`
+}
+
+func (m *ListSubscriptionsResponse) Reset()                    { *m = ListSubscriptionsResponse{} }
+func (m *ListSubscriptionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListSubscriptionsResponse) ProtoMessage()               {}
+func (*ListSubscriptionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{7} }
+
+func (m *ListSubscriptionsResponse) GetSubscriptions() []*SubscriptionInfo {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+// SubscriptionInfo describes an open stream of telemetry events.
+type SubscriptionInfo struct {
+	// The subscription_id sent on the stream
+	SubscriptionId string `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId" json:"subscription_id,omitempty"`
+	// The stream's subscription, including any filters replaced by
+	// ModifySubscription
+	Subscription *Subscription `protobuf:"bytes,2,opt,name=subscription" json:"subscription,omitempty"`
+	// The network address of the client
+	Peer string `protobuf:"bytes,3,opt,name=peer" json:"peer,omitempty"`
+	// The common name of the client's TLS certificate, if the client
+	// was authenticated with one
+	PeerIdentity string `protobuf:"bytes,4,opt,name=peer_identity,json=peerIdentity" json:"peer_identity,omitempty"`
+	// The time that the stream was opened (in micros since Unix epoch)
+	StartTimeMicros int64 `protobuf:"varint,5,opt,name=start_time_micros,json=startTimeMicros" json:"start_time_micros,omitempty"`
+	// The number of events delivered to the stream by the Sensor
+	EventsReceived uint64 `protobuf:"varint,6,opt,name=events_received,json=eventsReceived" json:"events_received,omitempty"`
+	// The number of events sent to the client after filtering and
+	// modifiers were applied
+	EventsSent uint64 `protobuf:"varint,7,opt,name=events_sent,json=eventsSent" json:"events_sent,omitempty"`
+	// The number of events dropped because the client was not reading
+	// them quickly enough
+	EventsDropped uint64 `protobuf:"varint,8,opt,name=events_dropped,json=eventsDropped" json:"events_dropped,omitempty"`
+	// The average number of events sent to the client per second since
+	// the stream was opened
+	EventsPerSecond float64 `protobuf:"fixed64,9,opt,name=events_per_second,json=eventsPerSecond" json:"events_per_second,omitempty"`
+	// The version of the API used for the stream
+	ApiVersion APIVersion `protobuf:"varint,10,opt,name=api_version,json=apiVersion,enum=capsule8.api.v0.APIVersion" json:"api_version,omitempty"`
+}
+
+func (m *SubscriptionInfo) Reset()                    { *m = SubscriptionInfo{} }
+func (m *SubscriptionInfo) String() string            { return proto.CompactTextString(m) }
+func (*SubscriptionInfo) ProtoMessage()               {}
+func (*SubscriptionInfo) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{8} }
+
+func (m *SubscriptionInfo) GetSubscriptionId() string {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return ""
+}
+
+func (m *SubscriptionInfo) GetSubscription() *Subscription {
+	if m != nil {
+		return m.Subscription
+	}
+	return nil
+}
+
+func (m *SubscriptionInfo) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *SubscriptionInfo) GetPeerIdentity() string {
+	if m != nil {
+		return m.PeerIdentity
+	}
+	return ""
+}
+
+func (m *SubscriptionInfo) GetStartTimeMicros() int64 {
+	if m != nil {
+		return m.StartTimeMicros
+	}
+	return 0
+}
+
+func (m *SubscriptionInfo) GetEventsReceived() uint64 {
+	if m != nil {
+		return m.EventsReceived
+	}
+	return 0
+}
+
+func (m *SubscriptionInfo) GetEventsSent() uint64 {
+	if m != nil {
+		return m.EventsSent
+	}
+	return 0
+}
+
+func (m *SubscriptionInfo) GetEventsDropped() uint64 {
+	if m != nil {
+		return m.EventsDropped
+	}
+	return 0
+}
+
+func (m *SubscriptionInfo) GetEventsPerSecond() float64 {
+	if m != nil {
+		return m.EventsPerSecond
+	}
+	return 0
+}
+
+func (m *SubscriptionInfo) GetApiVersion() APIVersion {
+	if m != nil {
+		return m.ApiVersion
+	}
+	return APIVersion_API_VERSION_UNSPECIFIED
+}
+
+// A request message for the Sensor's event statistics
+type GetStatisticsRequest struct {
+}
+
+func (m *GetStatisticsRequest) Reset()                    { *m = GetStatisticsRequest{} }
+func (m *GetStatisticsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetStatisticsRequest) ProtoMessage()               {}
+func (*GetStatisticsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{9} }
+
+// A response message containing the Sensor's event statistics. All counts
+// are since the Sensor started or the stream was opened.
+type GetStatisticsResponse struct {
+	// The counts for each type of event that the Sensor has received,
+	// sorted by type
+	EventSources []*EventSourceStatistics `protobuf:"bytes,1,rep,name=event_sources,json=eventSources" json:"event_sources,omitempty"`
+	// The counts for each open stream, sorted by the time that they
+	// were opened
+	Subscriptions []*SubscriptionStatistics `protobuf:"bytes,2,rep,name=subscriptions" json:"subscriptions,omitempty"`
+	// The number of records that the kernel dropped because a ring
+	// buffer was full. The type of their events is not known.
+	LostRecords uint64 `protobuf:"varint,3,opt,name=lost_records,json=lostRecords" json:"lost_records,omitempty"`
+	// The number of records that could not be decoded. The type of
+	// their events is not known.
+	DecodeErrors uint64 `protobuf:"varint,4,opt,name=decode_errors,json=decodeErrors" json:"decode_errors,omitempty"`
+}
+
+func (m *GetStatisticsResponse) Reset()                    { *m = GetStatisticsResponse{} }
+func (m *GetStatisticsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetStatisticsResponse) ProtoMessage()               {}
+func (*GetStatisticsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{10} }
+
+func (m *GetStatisticsResponse) GetEventSources() []*EventSourceStatistics {
+	if m != nil {
+		return m.EventSources
+	}
+	return nil
+}
+
+func (m *GetStatisticsResponse) GetSubscriptions() []*SubscriptionStatistics {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+func (m *GetStatisticsResponse) GetLostRecords() uint64 {
+	if m != nil {
+		return m.LostRecords
+	}
+	return 0
+}
+
+func (m *GetStatisticsResponse) GetDecodeErrors() uint64 {
+	if m != nil {
+		return m.DecodeErrors
+	}
+	return 0
+}
+
+// EventSourceStatistics counts the events of one type received by a Sensor
+// from its event sources. Events are received once, but are filtered,
+// dropped, and translated separately for each subscription.
+type EventSourceStatistics struct {
+	// The type of event, e.g. "ProcessExec" or "ContainerCreated"
+	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	// The number of events received from the Sensor's event sources
+	Received uint64 `protobuf:"varint,2,opt,name=received" json:"received,omitempty"`
+	// The number of times that an event was translated for a
+	// subscription
+	Translated uint64 `protobuf:"varint,3,opt,name=translated" json:"translated,omitempty"`
+	// The number of times that an event of a type requested by a
+	// subscription was excluded from it by the filter expression of
+	// one of its event filters, its container filter, or its sample
+	// rate
+	Filtered uint64 `protobuf:"varint,4,opt,name=filtered" json:"filtered,omitempty"`
+	// The number of times that an event was dropped from a
+	// subscription because its rate limit was exceeded
+	Dropped uint64 `protobuf:"varint,5,opt,name=dropped" json:"dropped,omitempty"`
+	// The number of times that a subscription's filter expression
+	// could not be evaluated for an event
+	Errored uint64 `protobuf:"varint,6,opt,name=errored" json:"errored,omitempty"`
+}
+
+func (m *EventSourceStatistics) Reset()                    { *m = EventSourceStatistics{} }
+func (m *EventSourceStatistics) String() string            { return proto.CompactTextString(m) }
+func (*EventSourceStatistics) ProtoMessage()               {}
+func (*EventSourceStatistics) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{11} }
+
+func (m *EventSourceStatistics) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *EventSourceStatistics) GetReceived() uint64 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
+
+func (m *EventSourceStatistics) GetTranslated() uint64 {
+	if m != nil {
+		return m.Translated
+	}
+	return 0
+}
+
+func (m *EventSourceStatistics) GetFiltered() uint64 {
+	if m != nil {
+		return m.Filtered
+	}
+	return 0
+}
+
+func (m *EventSourceStatistics) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+func (m *EventSourceStatistics) GetErrored() uint64 {
+	if m != nil {
+		return m.Errored
+	}
+	return 0
+}
+
+// SubscriptionStatistics counts the events handled for an open stream of
+// telemetry events.
+type SubscriptionStatistics struct {
+	// The subscription_id sent on the stream
+	SubscriptionId string `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId" json:"subscription_id,omitempty"`
+	// The number of events delivered to the stream by the Sensor
+	Received uint64 `protobuf:"varint,2,opt,name=received" json:"received,omitempty"`
+	// The number of events translated for the stream
+	Translated uint64 `protobuf:"varint,3,opt,name=translated" json:"translated,omitempty"`
+	// The number of events of types requested by the subscription that
+	// were excluded by the filter expressions of its event filters, its
+	// container filter, sample rate, expression, or throttle modifier
+	Filtered uint64 `protobuf:"varint,4,opt,name=filtered" json:"filtered,omitempty"`
+	// The number of events dropped because the subscription's rate
+	// limit was exceeded or because the client was not reading them
+	// quickly enough
+	Dropped uint64 `protobuf:"varint,5,opt,name=dropped" json:"dropped,omitempty"`
+	// The number of events for which the subscription's filter
+	// expressions could not be evaluated
+	Errored uint64 `protobuf:"varint,6,opt,name=errored" json:"errored,omitempty"`
+	// The number of events sent to the client
+	Sent uint64 `protobuf:"varint,7,opt,name=sent" json:"sent,omitempty"`
+}
+
+func (m *SubscriptionStatistics) Reset()                    { *m = SubscriptionStatistics{} }
+func (m *SubscriptionStatistics) String() string            { return proto.CompactTextString(m) }
+func (*SubscriptionStatistics) ProtoMessage()               {}
+func (*SubscriptionStatistics) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{12} }
+
+func (m *SubscriptionStatistics) GetSubscriptionId() string {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return ""
+}
+
+func (m *SubscriptionStatistics) GetReceived() uint64 {
+	if m != nil {
+		return m.Received
+	}
+	return 0
+}
+
+func (m *SubscriptionStatistics) GetTranslated() uint64 {
+	if m != nil {
+		return m.Translated
+	}
+	return 0
+}
+
+func (m *SubscriptionStatistics) GetFiltered() uint64 {
+	if m != nil {
+		return m.Filtered
+	}
+	return 0
+}
+
+func (m *SubscriptionStatistics) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
+func (m *SubscriptionStatistics) GetErrored() uint64 {
+	if m != nil {
+		return m.Errored
+	}
+	return 0
+}
+
+func (m *SubscriptionStatistics) GetSent() uint64 {
+	if m != nil {
+		return m.Sent
+	}
+	return 0
+}
+
+// A request message to change the verbosity of the Sensor's logging
+type SetLogLevelRequest struct {
+	// The level of the informational messages that are logged, as
+	// with the Sensor's -v flag. Higher levels are more verbose.
+	Verbosity int32 `protobuf:"varint,1,opt,name=verbosity" json:"verbosity,omitempty"`
+	// Optional; levels for individual source files that override
+	// verbosity, as with the Sensor's -vmodule flag (e.g.
+	// "telemetry=2,process*=3"). If empty, they are unchanged.
+	Vmodule string `protobuf:"bytes,2,opt,name=vmodule" json:"vmodule,omitempty"`
+}
+
+func (m *SetLogLevelRequest) Reset()                    { *m = SetLogLevelRequest{} }
+func (m *SetLogLevelRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetLogLevelRequest) ProtoMessage()               {}
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{13} }
+
+func (m *SetLogLevelRequest) GetVerbosity() int32 {
+	if m != nil {
+		return m.Verbosity
+	}
+	return 0
+}
+
+func (m *SetLogLevelRequest) GetVmodule() string {
+	if m != nil {
+		return m.Vmodule
+	}
+	return ""
+}
+
+// A response message describing the verbosity of the Sensor's logging
+// before it was changed
+type SetLogLevelResponse struct {
+	PreviousVerbosity int32  `protobuf:"varint,1,opt,name=previous_verbosity,json=previousVerbosity" json:"previous_verbosity,omitempty"`
+	PreviousVmodule   string `protobuf:"bytes,2,opt,name=previous_vmodule,json=previousVmodule" json:"previous_vmodule,omitempty"`
+}
+
+func (m *SetLogLevelResponse) Reset()                    { *m = SetLogLevelResponse{} }
+func (m *SetLogLevelResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetLogLevelResponse) ProtoMessage()               {}
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{14} }
+
+func (m *SetLogLevelResponse) GetPreviousVerbosity() int32 {
+	if m != nil {
+		return m.PreviousVerbosity
+	}
+	return 0
+}
+
+func (m *SetLogLevelResponse) GetPreviousVmodule() string {
+	if m != nil {
+		return m.PreviousVmodule
+	}
+	return ""
+}
+
+// A request message to replay the events in a Sensor's spool. The spool
+// holds the events matching the Sensor's configured spool subscription,
+// numbered in the order that they were written. When the spool reaches its
+// configured size, its oldest events are removed.
+type ReplayEventsRequest struct {
+	// Optional; only events with a greater sequence_number are sent
+	SinceSequenceNumber uint64 `protobuf:"varint,1,opt,name=since_sequence_number,json=sinceSequenceNumber" json:"since_sequence_number,omitempty"`
+	// Optional; only events written to the spool at or after this time
+	// (in micros since Unix epoch) are sent
+	SinceTimeMicros int64 `protobuf:"varint,2,opt,name=since_time_micros,json=sinceTimeMicros" json:"since_time_micros,omitempty"`
+}
+
+func (m *ReplayEventsRequest) Reset()                    { *m = ReplayEventsRequest{} }
+func (m *ReplayEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReplayEventsRequest) ProtoMessage()               {}
+func (*ReplayEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{15} }
+
+func (m *ReplayEventsRequest) GetSinceSequenceNumber() uint64 {
+	if m != nil {
+		return m.SinceSequenceNumber
+	}
+	return 0
+}
+
+func (m *ReplayEventsRequest) GetSinceTimeMicros() int64 {
+	if m != nil {
+		return m.SinceTimeMicros
+	}
+	return 0
+}
+
+// A response message containing an event replayed from a Sensor's spool
+type ReplayEventsResponse struct {
+	// The number of the event in the spool. The numbers continue across
+	// restarts of the Sensor.
+	SequenceNumber uint64 `protobuf:"varint,1,opt,name=sequence_number,json=sequenceNumber" json:"sequence_number,omitempty"`
+	// The event; its publish_time_micros is the time that it was
+	// written to the spool
+	Event *ReceivedTelemetryEvent `protobuf:"bytes,2,opt,name=event" json:"event,omitempty"`
+}
+
+func (m *ReplayEventsResponse) Reset()                    { *m = ReplayEventsResponse{} }
+func (m *ReplayEventsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReplayEventsResponse) ProtoMessage()               {}
+func (*ReplayEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{16} }
+
+func (m *ReplayEventsResponse) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+func (m *ReplayEventsResponse) GetEvent() *ReceivedTelemetryEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+// A request message to list the tracing events available on a Sensor's host
+type ListTracingEventsRequest struct {
+	// Optional; if set, only tracepoints and symbols whose names begin
+	// with this prefix are returned.
+	Prefix string `protobuf:"bytes,1,opt,name=prefix" json:"prefix,omitempty"`
+	// Optional; if true, the kernel text symbols that may be used with a
+	// KernelFunctionCallFilter are also returned. There are typically
+	// tens of thousands of these, so using a prefix is recommended.
+	IncludeKernelSymbols bool `protobuf:"varint,2,opt,name=include_kernel_symbols,json=includeKernelSymbols" json:"include_kernel_symbols,omitempty"`
+}
+
+func (m *ListTracingEventsRequest) Reset()                    { *m = ListTracingEventsRequest{} }
+func (m *ListTracingEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListTracingEventsRequest) ProtoMessage()               {}
+func (*ListTracingEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{17} }
+
+func (m *ListTracingEventsRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *ListTracingEventsRequest) GetIncludeKernelSymbols() bool {
+	if m != nil {
+		return m.IncludeKernelSymbols
+	}
+	return false
+}
+
+// A response message listing the tracing events available on a Sensor's host
+type ListTracingEventsResponse struct {
+	// The tracepoints available in the running kernel, sorted by name in
+	// the form "group/name". Probes created by Sensors are not included.
+	Tracepoints []string `protobuf:"bytes,1,rep,name=tracepoints" json:"tracepoints,omitempty"`
+	// The kernel text symbols available in the running kernel, sorted by
+	// name, if requested.
+	KernelSymbols []string `protobuf:"bytes,2,rep,name=kernel_symbols,json=kernelSymbols" json:"kernel_symbols,omitempty"`
+}
+
+func (m *ListTracingEventsResponse) Reset()                    { *m = ListTracingEventsResponse{} }
+func (m *ListTracingEventsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListTracingEventsResponse) ProtoMessage()               {}
+func (*ListTracingEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{18} }
+
+func (m *ListTracingEventsResponse) GetTracepoints() []string {
+	if m != nil {
+		return m.Tracepoints
+	}
+	return nil
+}
+
+func (m *ListTracingEventsResponse) GetKernelSymbols() []string {
+	if m != nil {
+		return m.KernelSymbols
+	}
+	return nil
+}
+
 // A telemetry event received from a Sensor or Recorder.
 type ReceivedTelemetryEvent struct {
 	// The time that the event was received by the backplane (in micros
@@ -78,12 +783,15 @@ type ReceivedTelemetryEvent struct {
 	// the PubsubService's Acknowledge method or else the TelemetryService
 	// will re-transmit the event.
 	Ack []byte `protobuf:"bytes,3,opt,name=ack,proto3" json:"ack,omitempty"`
+	// Present if the subscription has an AggregateModifier; describes
+	// the events that were collapsed into this one.
+	Aggregate *EventAggregate `protobuf:"bytes,4,opt,name=aggregate" json:"aggregate,omitempty"`
 }
 
 func (m *ReceivedTelemetryEvent) Reset()                    { *m = ReceivedTelemetryEvent{} }
 func (m *ReceivedTelemetryEvent) String() string            { return proto.CompactTextString(m) }
 func (*ReceivedTelemetryEvent) ProtoMessage()               {}
-func (*ReceivedTelemetryEvent) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+func (*ReceivedTelemetryEvent) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{19} }
 
 func (m *ReceivedTelemetryEvent) GetPublishTimeMicros() int64 {
 	if m != nil {
@@ -106,10 +814,72 @@ func (m *ReceivedTelemetryEvent) GetAck() []byte {
 	return nil
 }
 
+func (m *ReceivedTelemetryEvent) GetAggregate() *EventAggregate {
+	if m != nil {
+		return m.Aggregate
+	}
+	return nil
+}
+
+// An EventAggregate describes identical events that were collapsed into one
+// by an AggregateModifier.
+type EventAggregate struct {
+	// The number of events, including the one sent
+	Count uint64 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	// The sensor_monotime_nanos of the first and last events
+	FirstMonotimeNanos int64 `protobuf:"varint,2,opt,name=first_monotime_nanos,json=firstMonotimeNanos" json:"first_monotime_nanos,omitempty"`
+	LastMonotimeNanos  int64 `protobuf:"varint,3,opt,name=last_monotime_nanos,json=lastMonotimeNanos" json:"last_monotime_nanos,omitempty"`
+}
+
+func (m *EventAggregate) Reset()                    { *m = EventAggregate{} }
+func (m *EventAggregate) String() string            { return proto.CompactTextString(m) }
+func (*EventAggregate) ProtoMessage()               {}
+func (*EventAggregate) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{20} }
+
+func (m *EventAggregate) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *EventAggregate) GetFirstMonotimeNanos() int64 {
+	if m != nil {
+		return m.FirstMonotimeNanos
+	}
+	return 0
+}
+
+func (m *EventAggregate) GetLastMonotimeNanos() int64 {
+	if m != nil {
+		return m.LastMonotimeNanos
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*GetEventsRequest)(nil), "capsule8.api.v0.GetEventsRequest")
 	proto.RegisterType((*GetEventsResponse)(nil), "capsule8.api.v0.GetEventsResponse")
+	proto.RegisterType((*AcknowledgeEventsRequest)(nil), "capsule8.api.v0.AcknowledgeEventsRequest")
+	proto.RegisterType((*AcknowledgeEventsResponse)(nil), "capsule8.api.v0.AcknowledgeEventsResponse")
+	proto.RegisterType((*ModifySubscriptionRequest)(nil), "capsule8.api.v0.ModifySubscriptionRequest")
+	proto.RegisterType((*ModifySubscriptionResponse)(nil), "capsule8.api.v0.ModifySubscriptionResponse")
+	proto.RegisterType((*ListSubscriptionsRequest)(nil), "capsule8.api.v0.ListSubscriptionsRequest")
+	proto.RegisterType((*ListSubscriptionsResponse)(nil), "capsule8.api.v0.ListSubscriptionsResponse")
+	proto.RegisterType((*SubscriptionInfo)(nil), "capsule8.api.v0.SubscriptionInfo")
+	proto.RegisterType((*GetStatisticsRequest)(nil), "capsule8.api.v0.GetStatisticsRequest")
+	proto.RegisterType((*GetStatisticsResponse)(nil), "capsule8.api.v0.GetStatisticsResponse")
+	proto.RegisterType((*EventSourceStatistics)(nil), "capsule8.api.v0.EventSourceStatistics")
+	proto.RegisterType((*SubscriptionStatistics)(nil), "capsule8.api.v0.SubscriptionStatistics")
+	proto.RegisterType((*SetLogLevelRequest)(nil), "capsule8.api.v0.SetLogLevelRequest")
+	proto.RegisterType((*SetLogLevelResponse)(nil), "capsule8.api.v0.SetLogLevelResponse")
+	proto.RegisterType((*ReplayEventsRequest)(nil), "capsule8.api.v0.ReplayEventsRequest")
+	proto.RegisterType((*ReplayEventsResponse)(nil), "capsule8.api.v0.ReplayEventsResponse")
+	proto.RegisterType((*ListTracingEventsRequest)(nil), "capsule8.api.v0.ListTracingEventsRequest")
+	proto.RegisterType((*ListTracingEventsResponse)(nil), "capsule8.api.v0.ListTracingEventsResponse")
 	proto.RegisterType((*ReceivedTelemetryEvent)(nil), "capsule8.api.v0.ReceivedTelemetryEvent")
+	proto.RegisterType((*EventAggregate)(nil), "capsule8.api.v0.EventAggregate")
+	proto.RegisterEnum("capsule8.api.v0.APIVersion", APIVersion_name, APIVersion_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -125,6 +895,28 @@ const _ = grpc.SupportPackageIsVersion4
 type TelemetryServiceClient interface {
 	// Opens a new stream of telemetry events
 	GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (TelemetryService_GetEventsClient, error)
+	// Replaces the filters of an open stream of telemetry events
+	// without closing it
+	ModifySubscription(ctx context.Context, in *ModifySubscriptionRequest, opts ...grpc.CallOption) (*ModifySubscriptionResponse, error)
+	// Acknowledges the responses of a stream of telemetry events with
+	// at least once delivery
+	AcknowledgeEvents(ctx context.Context, in *AcknowledgeEventsRequest, opts ...grpc.CallOption) (*AcknowledgeEventsResponse, error)
+	// Sends the events held in the Sensor's on-disk spool, so that
+	// events are not lost while a collector cannot be reached
+	ReplayEvents(ctx context.Context, in *ReplayEventsRequest, opts ...grpc.CallOption) (TelemetryService_ReplayEventsClient, error)
+	// Lists the open streams of telemetry events, so that the clients
+	// responsible for a Sensor's load can be found
+	ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error)
+	// Returns counts of the events handled by the Sensor for each type
+	// of event and for each open stream, so that clients can verify
+	// that they are receiving everything that they should
+	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error)
+	// Changes the verbosity of the Sensor's logging without restarting
+	// it
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// Lists the tracepoints and kernel symbols available on the running
+	// kernel
+	ListTracingEvents(ctx context.Context, in *ListTracingEventsRequest, opts ...grpc.CallOption) (*ListTracingEventsResponse, error)
 }
 
 type telemetryServiceClient struct {
@@ -167,11 +959,119 @@ func (x *telemetryServiceGetEventsClient) Recv() (*GetEventsResponse, error) {
 	return m, nil
 }
 
+func (c *telemetryServiceClient) ModifySubscription(ctx context.Context, in *ModifySubscriptionRequest, opts ...grpc.CallOption) (*ModifySubscriptionResponse, error) {
+	out := new(ModifySubscriptionResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/ModifySubscription", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) AcknowledgeEvents(ctx context.Context, in *AcknowledgeEventsRequest, opts ...grpc.CallOption) (*AcknowledgeEventsResponse, error) {
+	out := new(AcknowledgeEventsResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/AcknowledgeEvents", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) ReplayEvents(ctx context.Context, in *ReplayEventsRequest, opts ...grpc.CallOption) (TelemetryService_ReplayEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_TelemetryService_serviceDesc.Streams[1], c.cc, "/capsule8.api.v0.TelemetryService/ReplayEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &telemetryServiceReplayEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TelemetryService_ReplayEventsClient interface {
+	Recv() (*ReplayEventsResponse, error)
+	grpc.ClientStream
+}
+
+type telemetryServiceReplayEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *telemetryServiceReplayEventsClient) Recv() (*ReplayEventsResponse, error) {
+	m := new(ReplayEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *telemetryServiceClient) ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error) {
+	out := new(ListSubscriptionsResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/ListSubscriptions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error) {
+	out := new(GetStatisticsResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/GetStatistics", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/SetLogLevel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *telemetryServiceClient) ListTracingEvents(ctx context.Context, in *ListTracingEventsRequest, opts ...grpc.CallOption) (*ListTracingEventsResponse, error) {
+	out := new(ListTracingEventsResponse)
+	err := grpc.Invoke(ctx, "/capsule8.api.v0.TelemetryService/ListTracingEvents", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for TelemetryService service
 
 type TelemetryServiceServer interface {
 	// Opens a new stream of telemetry events
 	GetEvents(*GetEventsRequest, TelemetryService_GetEventsServer) error
+	// Replaces the filters of an open stream of telemetry events
+	// without closing it
+	ModifySubscription(context.Context, *ModifySubscriptionRequest) (*ModifySubscriptionResponse, error)
+	// Acknowledges the responses of a stream of telemetry events with
+	// at least once delivery
+	AcknowledgeEvents(context.Context, *AcknowledgeEventsRequest) (*AcknowledgeEventsResponse, error)
+	// Sends the events held in the Sensor's on-disk spool, so that
+	// events are not lost while a collector cannot be reached
+	ReplayEvents(*ReplayEventsRequest, TelemetryService_ReplayEventsServer) error
+	// Lists the open streams of telemetry events, so that the clients
+	// responsible for a Sensor's load can be found
+	ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error)
+	// Returns counts of the events handled by the Sensor for each type
+	// of event and for each open stream, so that clients can verify
+	// that they are receiving everything that they should
+	GetStatistics(context.Context, *GetStatisticsRequest) (*GetStatisticsResponse, error)
+	// Changes the verbosity of the Sensor's logging without restarting
+	// it
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// Lists the tracepoints and kernel symbols available on the running
+	// kernel
+	ListTracingEvents(context.Context, *ListTracingEventsRequest) (*ListTracingEventsResponse, error)
 }
 
 func RegisterTelemetryServiceServer(s *grpc.Server, srv TelemetryServiceServer) {
@@ -199,16 +1099,175 @@ func (x *telemetryServiceGetEventsServer) Send(m *GetEventsResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TelemetryService_ModifySubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifySubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).ModifySubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/ModifySubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).ModifySubscription(ctx, req.(*ModifySubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_AcknowledgeEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).AcknowledgeEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/AcknowledgeEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).AcknowledgeEvents(ctx, req.(*AcknowledgeEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_ReplayEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReplayEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TelemetryServiceServer).ReplayEvents(m, &telemetryServiceReplayEventsServer{stream})
+}
+
+type TelemetryService_ReplayEventsServer interface {
+	Send(*ReplayEventsResponse) error
+	grpc.ServerStream
+}
+
+type telemetryServiceReplayEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *telemetryServiceReplayEventsServer) Send(m *ReplayEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TelemetryService_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).ListSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/ListSubscriptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).ListSubscriptions(ctx, req.(*ListSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_GetStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).GetStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/GetStatistics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).GetStatistics(ctx, req.(*GetStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TelemetryService_ListTracingEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTracingEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).ListTracingEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/capsule8.api.v0.TelemetryService/ListTracingEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).ListTracingEvents(ctx, req.(*ListTracingEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _TelemetryService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "capsule8.api.v0.TelemetryService",
 	HandlerType: (*TelemetryServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ModifySubscription",
+			Handler:    _TelemetryService_ModifySubscription_Handler,
+		},
+		{
+			MethodName: "AcknowledgeEvents",
+			Handler:    _TelemetryService_AcknowledgeEvents_Handler,
+		},
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _TelemetryService_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "GetStatistics",
+			Handler:    _TelemetryService_GetStatistics_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _TelemetryService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "ListTracingEvents",
+			Handler:    _TelemetryService_ListTracingEvents_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "GetEvents",
 			Handler:       _TelemetryService_GetEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ReplayEvents",
+			Handler:       _TelemetryService_ReplayEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "capsule8/api/v0/telemetry_service.proto",
 }
@@ -216,29 +1275,101 @@ var _TelemetryService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("capsule8/api/v0/telemetry_service.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 372 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xc1, 0x4a, 0xf3, 0x40,
-	0x14, 0x85, 0x99, 0x86, 0xbf, 0xfc, 0x4e, 0x0b, 0x6d, 0x47, 0xd4, 0x50, 0x14, 0x6b, 0x40, 0x5a,
-	0x5c, 0x4c, 0x4a, 0x45, 0x10, 0x37, 0xe2, 0x42, 0x5c, 0xb9, 0x49, 0xeb, 0xba, 0xa4, 0xe3, 0xa5,
-	0x0e, 0x4d, 0x32, 0x63, 0x66, 0x12, 0x70, 0x25, 0x88, 0x2f, 0x20, 0x3e, 0x9a, 0xaf, 0xe0, 0x83,
-	0x48, 0x66, 0xd2, 0x52, 0x53, 0x75, 0x37, 0x70, 0xce, 0xfd, 0x38, 0xf7, 0xcc, 0xc5, 0x7d, 0x16,
-	0x4a, 0x95, 0x45, 0x70, 0xee, 0x87, 0x92, 0xfb, 0xf9, 0xd0, 0xd7, 0x10, 0x41, 0x0c, 0x3a, 0x7d,
-	0x9a, 0x2a, 0x48, 0x73, 0xce, 0x80, 0xca, 0x54, 0x68, 0x41, 0x5a, 0x4b, 0x23, 0x0d, 0x25, 0xa7,
-	0xf9, 0xb0, 0xeb, 0x55, 0x27, 0x55, 0x36, 0x53, 0x2c, 0xe5, 0x52, 0x73, 0x91, 0xd8, 0xa1, 0xee,
-	0xf1, 0xef, 0x74, 0xc8, 0x21, 0xd1, 0xa5, 0x6d, 0x7f, 0x2e, 0xc4, 0x3c, 0x02, 0x63, 0x0a, 0x93,
-	0x44, 0xe8, 0xb0, 0x60, 0xa8, 0x52, 0xdd, 0x2b, 0xd5, 0x54, 0x32, 0x5f, 0xe9, 0x50, 0x67, 0xa5,
-	0xe0, 0xdd, 0xe1, 0xf6, 0x0d, 0xe8, 0xeb, 0x02, 0xa4, 0x02, 0x78, 0xcc, 0x40, 0x69, 0x72, 0x85,
-	0x9b, 0xeb, 0x39, 0x5c, 0xd4, 0x43, 0x83, 0xc6, 0xe8, 0x80, 0x56, 0xd2, 0xd3, 0xf1, 0x9a, 0x29,
-	0xf8, 0x36, 0xe2, 0xbd, 0x22, 0xdc, 0x59, 0xe3, 0x2a, 0x29, 0x12, 0x05, 0xe4, 0x12, 0xd7, 0x4d,
-	0x64, 0xe5, 0xa2, 0x9e, 0x33, 0x68, 0x8c, 0xfa, 0x1b, 0xc8, 0x00, 0x18, 0xf0, 0x1c, 0xee, 0x27,
-	0xcb, 0x1d, 0x0d, 0x21, 0x28, 0xc7, 0x08, 0xc5, 0xff, 0x6d, 0x7a, 0x50, 0x6e, 0xcd, 0x20, 0x08,
-	0xb5, 0x9b, 0xd1, 0x54, 0x32, 0x3a, 0x36, 0x5a, 0xb0, 0xf2, 0x78, 0x6f, 0x08, 0xef, 0xfe, 0x8c,
-	0x24, 0x14, 0x6f, 0xcb, 0x6c, 0x16, 0x71, 0xf5, 0x30, 0xd5, 0x3c, 0x86, 0x69, 0xcc, 0x59, 0x2a,
-	0x94, 0xd9, 0xd5, 0x09, 0x3a, 0xa5, 0x34, 0xe1, 0x31, 0xdc, 0x1a, 0x81, 0x9c, 0xe1, 0x7f, 0x26,
-	0x84, 0x5b, 0x33, 0x6d, 0x1c, 0x6e, 0x44, 0xaf, 0x44, 0xb6, 0x6e, 0xd2, 0xc6, 0x4e, 0xc8, 0x16,
-	0xae, 0xd3, 0x43, 0x83, 0x66, 0x50, 0x3c, 0x47, 0xcf, 0xb8, 0xbd, 0xb2, 0x8e, 0xed, 0x79, 0x90,
-	0x05, 0xde, 0x5a, 0xb5, 0x45, 0x8e, 0x36, 0xd0, 0xd5, 0x1f, 0xea, 0x7a, 0x7f, 0x59, 0x6c, 0xd9,
-	0xde, 0xce, 0xcb, 0xc7, 0xe7, 0x7b, 0xad, 0xe5, 0xe1, 0xe2, 0x66, 0x6c, 0x7f, 0x17, 0xe8, 0x64,
-	0x88, 0x66, 0x75, 0xf3, 0xf3, 0xa7, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x1e, 0x32, 0x1a, 0xb6,
-	0xb7, 0x02, 0x00, 0x00,
+	// 1521 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x6f, 0x1b, 0x47,
+	0x12, 0xde, 0xa1, 0x1e, 0x16, 0x8b, 0xa2, 0x44, 0xb6, 0x1e, 0xa6, 0x68, 0xef, 0x9a, 0x1e, 0x5b,
+	0x36, 0x2d, 0x63, 0x29, 0xad, 0x76, 0x0d, 0x2c, 0x8c, 0x35, 0x16, 0x42, 0xac, 0x18, 0x84, 0x25,
+	0x59, 0x18, 0x3a, 0xbe, 0x0e, 0x46, 0x33, 0x25, 0x7a, 0xa0, 0xe1, 0xf4, 0xa4, 0xbb, 0x49, 0x47,
+	0x30, 0x9c, 0x43, 0x80, 0x3c, 0xee, 0x3e, 0xe4, 0x94, 0xdf, 0x90, 0x63, 0x72, 0xca, 0x6f, 0x08,
+	0x90, 0x5f, 0x10, 0x20, 0xff, 0x22, 0x97, 0xa0, 0x7b, 0x9a, 0xd4, 0xbc, 0x68, 0xcb, 0x97, 0x20,
+	0x27, 0xce, 0x54, 0x55, 0xd7, 0xab, 0xbf, 0x7a, 0x0c, 0xe1, 0xae, 0xeb, 0x44, 0x7c, 0x18, 0xe0,
+	0x7f, 0xb7, 0x9d, 0xc8, 0xdf, 0x1e, 0xed, 0x6c, 0x0b, 0x0c, 0x70, 0x80, 0x82, 0x9d, 0xdb, 0x1c,
+	0xd9, 0xc8, 0x77, 0xb1, 0x13, 0x31, 0x2a, 0x28, 0x59, 0x1e, 0x0b, 0x76, 0x9c, 0xc8, 0xef, 0x8c,
+	0x76, 0x9a, 0x66, 0xf6, 0x24, 0x1f, 0x9e, 0x70, 0x97, 0xf9, 0x91, 0xf0, 0x69, 0x18, 0x1f, 0x6a,
+	0x6e, 0x4e, 0xd7, 0x8e, 0x23, 0x0c, 0x85, 0x16, 0xbb, 0xde, 0xa7, 0xb4, 0x1f, 0xa0, 0x12, 0x72,
+	0xc2, 0x90, 0x0a, 0x47, 0xea, 0xe0, 0x9a, 0x7b, 0x55, 0x73, 0x59, 0xe4, 0x6e, 0x73, 0xe1, 0x88,
+	0xa1, 0x66, 0x98, 0x6f, 0x0d, 0xa8, 0x3d, 0x41, 0xb1, 0x2f, 0x35, 0x71, 0x0b, 0x3f, 0x1d, 0x22,
+	0x17, 0x64, 0x0f, 0x16, 0x93, 0x8e, 0x34, 0x8c, 0x96, 0xd1, 0xae, 0xec, 0xfe, 0xbd, 0x93, 0x71,
+	0xbf, 0xd3, 0x4b, 0x08, 0x59, 0xa9, 0x23, 0xe4, 0x7f, 0x50, 0x71, 0x22, 0xdf, 0x1e, 0x21, 0xe3,
+	0x52, 0x43, 0xa9, 0x65, 0xb4, 0x97, 0x76, 0xaf, 0xe5, 0x34, 0xec, 0x1d, 0x77, 0x5f, 0xc4, 0x22,
+	0x16, 0x38, 0x91, 0xaf, 0x9f, 0xcd, 0x1f, 0x4b, 0x50, 0x4f, 0x78, 0xc5, 0x23, 0x1a, 0x72, 0x24,
+	0xff, 0x87, 0x79, 0x15, 0x31, 0x6f, 0x18, 0xad, 0x99, 0x76, 0x65, 0xf7, 0x6e, 0x4e, 0x9d, 0x85,
+	0x2e, 0xfa, 0x23, 0xf4, 0x9e, 0x8f, 0x53, 0xa4, 0x34, 0x58, 0xfa, 0x18, 0xe9, 0xc0, 0x42, 0x1c,
+	0x3c, 0xf2, 0x46, 0x49, 0xa9, 0x20, 0x9d, 0x38, 0x31, 0x1d, 0x16, 0xb9, 0x9d, 0x9e, 0xe2, 0x59,
+	0x13, 0x19, 0x72, 0x17, 0x96, 0x93, 0x41, 0xd9, 0xbe, 0xd7, 0x98, 0x69, 0x19, 0xed, 0xb2, 0xb5,
+	0x94, 0x24, 0x77, 0x3d, 0x25, 0x28, 0x73, 0x17, 0xba, 0x68, 0x87, 0xc3, 0xc1, 0x09, 0xb2, 0xc6,
+	0x6c, 0xcb, 0x68, 0xcf, 0x5a, 0x4b, 0x63, 0xf2, 0x91, 0xa2, 0x66, 0xd3, 0x32, 0xf7, 0x41, 0x69,
+	0x21, 0x26, 0x54, 0x31, 0xf4, 0x6c, 0x7a, 0x6a, 0x73, 0xc1, 0xd0, 0x19, 0x34, 0xe6, 0x5b, 0x46,
+	0x7b, 0xc1, 0xaa, 0x60, 0xe8, 0x3d, 0x3b, 0xed, 0x29, 0x92, 0xe9, 0x41, 0x63, 0xcf, 0x3d, 0x0b,
+	0xe9, 0xab, 0x00, 0xbd, 0x3e, 0xa6, 0xef, 0xf5, 0x06, 0x54, 0x3c, 0x0c, 0xfc, 0x11, 0xb2, 0x73,
+	0x19, 0x8b, 0xa1, 0x62, 0x81, 0x31, 0xa9, 0x38, 0x8e, 0x52, 0x51, 0x1c, 0xe6, 0x03, 0xd8, 0x28,
+	0xb0, 0xa2, 0xef, 0xa9, 0x01, 0x57, 0x22, 0x0c, 0x3d, 0x3f, 0xec, 0x2b, 0x13, 0xb3, 0xd6, 0xf8,
+	0xd5, 0xfc, 0xda, 0x80, 0x8d, 0x43, 0xea, 0xf9, 0xa7, 0xe7, 0x29, 0xe8, 0x68, 0xf7, 0x0a, 0xd2,
+	0x6d, 0x14, 0xa6, 0x3b, 0x8b, 0xcf, 0xd2, 0x07, 0xe3, 0xd3, 0x3c, 0x80, 0x66, 0x91, 0x23, 0x3a,
+	0x82, 0x24, 0x50, 0x8c, 0xf7, 0x03, 0xc5, 0x6c, 0x42, 0xe3, 0xc0, 0xe7, 0x22, 0xa9, 0x6b, 0x9c,
+	0x74, 0xd3, 0x83, 0x8d, 0x02, 0x9e, 0x36, 0xf4, 0x04, 0xaa, 0x49, 0xb7, 0xc6, 0xd6, 0x6e, 0xbe,
+	0x33, 0x94, 0x6e, 0x78, 0x4a, 0xad, 0xf4, 0x39, 0xf3, 0xa7, 0x19, 0xa8, 0x65, 0x65, 0xfe, 0xcc,
+	0x84, 0x12, 0x02, 0xb3, 0x11, 0x22, 0xd3, 0x05, 0xa2, 0x9e, 0xc9, 0x2d, 0xa8, 0xca, 0x5f, 0xdb,
+	0xf7, 0x30, 0x14, 0xbe, 0x38, 0x57, 0x45, 0x51, 0xb6, 0x16, 0x25, 0xb1, 0xab, 0x69, 0x64, 0x0b,
+	0xea, 0x5c, 0x38, 0x4c, 0xd8, 0xc2, 0x1f, 0xa0, 0x3d, 0xf0, 0x5d, 0x46, 0xb9, 0x2a, 0x8c, 0x19,
+	0x6b, 0x59, 0x31, 0x9e, 0xfb, 0x03, 0x3c, 0x54, 0x64, 0x19, 0x50, 0x5c, 0xca, 0x36, 0xd3, 0x95,
+	0xae, 0x4a, 0x60, 0xd6, 0x5a, 0x42, 0x0d, 0xc1, 0x98, 0x2a, 0x91, 0xae, 0x05, 0x39, 0x86, 0xa2,
+	0x71, 0x45, 0x09, 0x41, 0x4c, 0xea, 0x61, 0x28, 0xc8, 0x26, 0xe8, 0x23, 0xb6, 0xc7, 0x68, 0x14,
+	0xa1, 0xd7, 0x58, 0x50, 0x32, 0xd5, 0x98, 0xfa, 0x38, 0x26, 0x4a, 0xe7, 0xb4, 0x58, 0x84, 0xcc,
+	0xe6, 0xe8, 0xd2, 0xd0, 0x6b, 0x94, 0x5b, 0x46, 0xdb, 0xb0, 0xb4, 0x27, 0xc7, 0xc8, 0x7a, 0x8a,
+	0x9c, 0xad, 0x6d, 0xf8, 0xb0, 0x96, 0xb7, 0x0e, 0xab, 0x4f, 0x50, 0x48, 0x64, 0xf9, 0x5c, 0xf8,
+	0xee, 0x04, 0x3e, 0xbf, 0x1b, 0xb0, 0x96, 0x61, 0x68, 0xec, 0x3c, 0x85, 0xd8, 0x59, 0x9b, 0xd3,
+	0x21, 0x73, 0x27, 0x48, 0xbd, 0x93, 0xb3, 0xa8, 0xca, 0xb3, 0xa7, 0x84, 0x12, 0x6a, 0x16, 0xf1,
+	0x82, 0xcc, 0xc9, 0x61, 0x16, 0x88, 0xa5, 0x29, 0x2d, 0x36, 0x09, 0x81, 0x84, 0xb6, 0xf4, 0x69,
+	0x72, 0x13, 0x16, 0x03, 0xca, 0x85, 0xbc, 0x26, 0xca, 0x3c, 0xae, 0x50, 0x31, 0x6b, 0x55, 0x24,
+	0xcd, 0x8a, 0x49, 0x12, 0x1c, 0x1e, 0xba, 0xd4, 0x43, 0x1b, 0x19, 0xa3, 0x8c, 0xeb, 0x8e, 0xb9,
+	0x18, 0x13, 0xf7, 0x15, 0xcd, 0xfc, 0xc1, 0x80, 0xb5, 0x42, 0xf7, 0x25, 0xde, 0xc4, 0x79, 0x84,
+	0x1a, 0xd0, 0xea, 0x99, 0x34, 0x61, 0x61, 0x82, 0x8b, 0xb8, 0x6f, 0x4d, 0xde, 0xc9, 0x3f, 0x00,
+	0x04, 0x73, 0x42, 0x1e, 0x38, 0x02, 0x3d, 0xed, 0x4f, 0x82, 0x22, 0xcf, 0x9e, 0xfa, 0x81, 0x40,
+	0x86, 0x9e, 0xf6, 0x64, 0xf2, 0x2e, 0x1b, 0xda, 0x18, 0x25, 0x73, 0x71, 0x43, 0xd3, 0xaf, 0x92,
+	0xa3, 0xbc, 0x9f, 0x00, 0x71, 0xfc, 0x6a, 0xfe, 0x6a, 0xc0, 0x7a, 0x71, 0xae, 0x2e, 0x5f, 0x96,
+	0x7f, 0xa1, 0x78, 0x64, 0xbe, 0x13, 0xa5, 0xa4, 0x9e, 0xcd, 0x03, 0x20, 0x3d, 0x14, 0x07, 0xb4,
+	0x7f, 0x80, 0x23, 0x0c, 0xc6, 0x6d, 0xfc, 0x3a, 0x94, 0x47, 0xc8, 0x4e, 0x28, 0x97, 0x15, 0x2f,
+	0x03, 0x9b, 0xb3, 0x2e, 0x08, 0xd2, 0xc2, 0x68, 0x40, 0xbd, 0x61, 0x80, 0x2a, 0xa4, 0xb2, 0x35,
+	0x7e, 0x35, 0x29, 0xac, 0xa4, 0xb4, 0x69, 0x98, 0xff, 0x13, 0x48, 0xc4, 0x70, 0xe4, 0xd3, 0x21,
+	0xb7, 0xb3, 0x7a, 0xeb, 0x63, 0xce, 0x8b, 0x89, 0xfe, 0x7b, 0x50, 0xbb, 0x10, 0x4f, 0x19, 0x5a,
+	0x9e, 0x08, 0x6b, 0x83, 0x43, 0x58, 0xb1, 0x30, 0x0a, 0x9c, 0xf3, 0xf4, 0x94, 0xdc, 0x85, 0x35,
+	0xee, 0xcb, 0x09, 0x98, 0x1d, 0x85, 0xf1, 0x30, 0x5b, 0x51, 0xcc, 0x5e, 0x7a, 0xae, 0xcb, 0x26,
+	0xa6, 0xce, 0x24, 0x9b, 0x58, 0x49, 0x37, 0x31, 0xc9, 0xb8, 0x68, 0x62, 0xe6, 0xe7, 0xb0, 0x9a,
+	0x36, 0xab, 0x03, 0x2d, 0x18, 0xbe, 0x46, 0xe1, 0x12, 0xf1, 0x08, 0xe6, 0x54, 0xed, 0xea, 0x36,
+	0x7d, 0xe9, 0x35, 0x28, 0x3e, 0x65, 0xbe, 0x8c, 0x87, 0xd5, 0x73, 0xe6, 0xb8, 0x7e, 0xd8, 0x4f,
+	0xc7, 0xbe, 0x0e, 0xf3, 0x11, 0xc3, 0x53, 0xff, 0x33, 0x8d, 0x48, 0xfd, 0x46, 0xfe, 0x03, 0xeb,
+	0x7e, 0xe8, 0x06, 0x43, 0x0f, 0xed, 0x33, 0x64, 0x21, 0x06, 0x36, 0x3f, 0x1f, 0x9c, 0xd0, 0x20,
+	0x0e, 0x72, 0xc1, 0x5a, 0xd5, 0xdc, 0xa7, 0x8a, 0xd9, 0x8b, 0x79, 0xe3, 0xd1, 0x97, 0xb1, 0xa4,
+	0xc3, 0x6d, 0x41, 0x45, 0x30, 0xc7, 0xc5, 0x88, 0xfa, 0xe3, 0x95, 0xae, 0x6c, 0x25, 0x49, 0xb2,
+	0x47, 0xe7, 0x8c, 0x49, 0xa1, 0xea, 0x59, 0xca, 0xca, 0xcf, 0x06, 0xac, 0x17, 0x47, 0x4c, 0x3a,
+	0xb0, 0x12, 0x0d, 0x4f, 0x02, 0x9f, 0xbf, 0x4c, 0x5d, 0x8c, 0xa1, 0x2e, 0xa6, 0xae, 0x59, 0x89,
+	0xf9, 0xf2, 0x20, 0x9d, 0xd9, 0x1b, 0xb9, 0xcc, 0x16, 0x66, 0x94, 0xd4, 0x60, 0xc6, 0x71, 0xcf,
+	0x54, 0x11, 0x2e, 0x5a, 0xf2, 0x91, 0x3c, 0x82, 0xb2, 0xd3, 0xef, 0x33, 0xec, 0x3b, 0x02, 0x55,
+	0xf9, 0x15, 0x29, 0x53, 0x3a, 0xf6, 0xc6, 0x62, 0xd6, 0xc5, 0x09, 0xf3, 0x1b, 0x03, 0x96, 0xd2,
+	0x5c, 0xb2, 0x0a, 0x73, 0x2e, 0x1d, 0x86, 0x42, 0x63, 0x22, 0x7e, 0x21, 0x3b, 0xb0, 0x7a, 0xea,
+	0x33, 0x2e, 0xec, 0x01, 0x0d, 0xa9, 0x0a, 0x31, 0x74, 0xc2, 0x09, 0xf4, 0x88, 0xe2, 0x1d, 0x6a,
+	0xd6, 0x91, 0xe4, 0xc8, 0x94, 0x04, 0x4e, 0xfe, 0xc0, 0x4c, 0x9c, 0x12, 0xc9, 0x4a, 0xc9, 0x6f,
+	0x3d, 0x03, 0xb8, 0x98, 0x58, 0xe4, 0x1a, 0x5c, 0xdd, 0x3b, 0xee, 0xda, 0x2f, 0xf6, 0xad, 0x5e,
+	0xf7, 0xd9, 0x91, 0xfd, 0xc9, 0x51, 0xef, 0x78, 0xff, 0xa3, 0xee, 0xc7, 0xdd, 0xfd, 0xc7, 0xb5,
+	0xbf, 0x91, 0x3a, 0x54, 0x93, 0xcc, 0x7f, 0xd5, 0x8c, 0x2c, 0x69, 0xb7, 0x56, 0xda, 0xfd, 0x7e,
+	0x01, 0x6a, 0x93, 0x34, 0xf6, 0xe2, 0xef, 0x23, 0x72, 0x06, 0xe5, 0xc9, 0xbe, 0x4f, 0xf2, 0xdb,
+	0x4f, 0xf6, 0x0b, 0xa5, 0x69, 0xbe, 0x4b, 0x24, 0x06, 0x98, 0xb9, 0xf6, 0xc5, 0x2f, 0xbf, 0xbd,
+	0x2d, 0x2d, 0x9b, 0x20, 0x3f, 0x9a, 0xe2, 0x61, 0xfd, 0xd0, 0xd8, 0xda, 0x31, 0xc8, 0x77, 0x06,
+	0x90, 0xfc, 0xf2, 0x47, 0xb6, 0x72, 0x3a, 0xa7, 0xae, 0xaa, 0xcd, 0xfb, 0x97, 0x92, 0xd5, 0x8e,
+	0x74, 0x94, 0x23, 0xed, 0xdd, 0x5b, 0xd9, 0x2f, 0x3c, 0xbe, 0xfd, 0x3a, 0x33, 0x08, 0xde, 0x3c,
+	0x34, 0xb6, 0xc8, 0xb7, 0x06, 0xd4, 0x73, 0xdb, 0x35, 0xb9, 0x97, 0xdf, 0x24, 0xa6, 0xec, 0xf9,
+	0xcd, 0xad, 0xcb, 0x88, 0x6a, 0xe7, 0xee, 0x2b, 0xe7, 0x36, 0xcd, 0x96, 0x74, 0x4e, 0x7f, 0x0a,
+	0xf8, 0xc8, 0xb7, 0x5f, 0x27, 0xbe, 0x14, 0xde, 0x6c, 0x3b, 0xee, 0x99, 0xf4, 0xec, 0x15, 0x2c,
+	0x26, 0x5b, 0x17, 0xb9, 0x5d, 0xd0, 0x7a, 0x72, 0x0d, 0xb5, 0xb9, 0xf9, 0x1e, 0x29, 0xed, 0x49,
+	0x43, 0x79, 0x42, 0x48, 0x4d, 0xa5, 0x29, 0xa2, 0x34, 0xd0, 0xb7, 0xb6, 0x63, 0x90, 0x2f, 0x0d,
+	0xa8, 0xe7, 0xb6, 0xe8, 0x82, 0x94, 0x4c, 0xdb, 0xc2, 0x0b, 0x52, 0x32, 0x75, 0x29, 0x37, 0x37,
+	0x94, 0x23, 0x2b, 0xa4, 0x9e, 0xbb, 0x2f, 0x32, 0x82, 0x6a, 0x6a, 0x19, 0x23, 0x9b, 0x45, 0x40,
+	0xcc, 0x6d, 0x71, 0xcd, 0x3b, 0xef, 0x13, 0xd3, 0xa6, 0xd7, 0x95, 0xe9, 0x1a, 0x59, 0x52, 0xa6,
+	0x2f, 0xcc, 0x30, 0xa8, 0x24, 0x66, 0x23, 0xb9, 0x95, 0x5f, 0xcb, 0x72, 0x73, 0xb8, 0x79, 0xfb,
+	0xdd, 0x42, 0xe9, 0xac, 0x37, 0xab, 0xd2, 0x62, 0x40, 0xfb, 0x76, 0x20, 0xd9, 0xf2, 0xb2, 0xbf,
+	0xd2, 0x39, 0x4f, 0xb5, 0xef, 0x29, 0x39, 0x2f, 0x1a, 0x26, 0x53, 0x72, 0x5e, 0x38, 0x0d, 0xcc,
+	0xa6, 0x72, 0x63, 0x95, 0x10, 0xf5, 0x0f, 0x47, 0x2c, 0x12, 0xff, 0xbf, 0xc1, 0x4f, 0xe6, 0xd5,
+	0x5f, 0x15, 0xff, 0xfe, 0x23, 0x00, 0x00, 0xff, 0xff, 0xeb, 0x42, 0xfd, 0xa1, 0x68, 0x11, 0x00,
+	0x00,
 }
@@ -20,35 +20,88 @@ It has these top-level messages:
 	Credentials
 	TelemetryEvent
 	ChargenEvent
+	LostEventsEvent
+	SensorStatusEvent
 	TickerEvent
+	BpfEvent
 	ContainerEvent
+	ImageEvent
+	IoUringEvent
+	KernelModuleEvent
+	LsmEvent
+	MemoryEvent
+	MountEvent
 	ProcessEvent
+	SessionEvent
+	SignalEvent
 	SyscallEvent
+	TtyEvent
 	FileEvent
 	Process
+	Namespaces
+	ProcessInfo
+	StackTrace
+	StackFrame
 	KernelFunctionCallEvent
+	UserFunctionCallEvent
 	NetworkEvent
 	PerformanceEventValue
 	PerformanceEvent
 	GetEventsRequest
 	GetEventsResponse
+	AcknowledgeEventsRequest
+	AcknowledgeEventsResponse
+	ModifySubscriptionRequest
+	ModifySubscriptionResponse
+	ListSubscriptionsRequest
+	ListSubscriptionsResponse
+	SubscriptionInfo
+	GetStatisticsRequest
+	GetStatisticsResponse
+	EventSourceStatistics
+	SubscriptionStatistics
+	SetLogLevelRequest
+	SetLogLevelResponse
+	ReplayEventsRequest
+	ReplayEventsResponse
+	ListTracingEventsRequest
+	ListTracingEventsResponse
 	ReceivedTelemetryEvent
+	EventAggregate
 	Subscription
 	ContainerFilter
 	EventFilter
 	SyscallEventFilter
 	ProcessEventFilter
 	FileEventFilter
+	BpfEventFilter
+	IoUringEventFilter
+	UserFunctionCallFilter
+	KernelModuleEventFilter
+	LsmEventFilter
+	MemoryEventFilter
+	MountEventFilter
+	SessionEventFilter
+	SignalEventFilter
+	TtyEventFilter
 	KernelFunctionCallFilter
 	NetworkEventFilter
 	PerformanceEventCounter
 	PerformanceEventFilter
 	ContainerEventFilter
+	ImageEventFilter
 	ChargenEventFilter
 	TickerEventFilter
 	Modifier
+	BatchModifier
+	BufferModifier
+	ProjectionModifier
+	AggregateModifier
 	ThrottleModifier
+	KeyedThrottleModifier
 	LimitModifier
+	RateLimitModifier
+	SampleModifier
 	Value
 	BinaryOp
 	Expression
@@ -377,6 +430,14 @@ type Credentials struct {
 	Fsuid uint32 `protobuf:"varint,7,opt,name=fsuid" json:"fsuid,omitempty"`
 	// The group ID for filesystem operations
 	Fsgid uint32 `protobuf:"varint,8,opt,name=fsgid" json:"fsgid,omitempty"`
+	// The names of the real and effective users and groups, if they
+	// are known. Names are looked up in the /etc/passwd and /etc/group
+	// files of the process's container, or of the host's if the
+	// process is not in a container.
+	UidName  string `protobuf:"bytes,9,opt,name=uid_name,json=uidName" json:"uid_name,omitempty"`
+	GidName  string `protobuf:"bytes,10,opt,name=gid_name,json=gidName" json:"gid_name,omitempty"`
+	EuidName string `protobuf:"bytes,11,opt,name=euid_name,json=euidName" json:"euid_name,omitempty"`
+	EgidName string `protobuf:"bytes,12,opt,name=egid_name,json=egidName" json:"egid_name,omitempty"`
 }
 
 func (m *Credentials) Reset()                    { *m = Credentials{} }
@@ -440,6 +501,34 @@ func (m *Credentials) GetFsgid() uint32 {
 	return 0
 }
 
+func (m *Credentials) GetUidName() string {
+	if m != nil {
+		return m.UidName
+	}
+	return ""
+}
+
+func (m *Credentials) GetGidName() string {
+	if m != nil {
+		return m.GidName
+	}
+	return ""
+}
+
+func (m *Credentials) GetEuidName() string {
+	if m != nil {
+		return m.EuidName
+	}
+	return ""
+}
+
+func (m *Credentials) GetEgidName() string {
+	if m != nil {
+		return m.EgidName
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*IPv4Address)(nil), "capsule8.api.v0.IPv4Address")
 	proto.RegisterType((*IPv4AddressAndPort)(nil), "capsule8.api.v0.IPv4AddressAndPort")
@@ -453,34 +542,36 @@ func init() {
 func init() { proto.RegisterFile("capsule8/api/v0/types.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 449 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0x51, 0x6f, 0x9a, 0x50,
-	0x1c, 0xc5, 0x45, 0x5b, 0x5c, 0xff, 0xd4, 0x8e, 0xdc, 0xf8, 0x60, 0xd2, 0xa6, 0x23, 0x2c, 0xcd,
-	0xcc, 0x1e, 0xb0, 0x69, 0x1b, 0xb2, 0x97, 0x3d, 0xb0, 0xd6, 0x46, 0x53, 0x87, 0xe6, 0xea, 0x62,
-	0xf6, 0xc4, 0x98, 0xa0, 0xde, 0x8c, 0x09, 0xe1, 0x22, 0xc6, 0x0f, 0xb2, 0xe7, 0x7d, 0x82, 0x7d,
-	0xc7, 0xe5, 0x5e, 0x2e, 0x4c, 0x37, 0x35, 0x4b, 0xdf, 0xfe, 0xf7, 0xf0, 0xfb, 0x1f, 0x0e, 0xe7,
-	0x06, 0x38, 0x9f, 0xb8, 0x11, 0x5d, 0x06, 0xfe, 0xbb, 0x96, 0x1b, 0x91, 0x56, 0x7a, 0xdd, 0x4a,
-	0xd6, 0x91, 0x4f, 0x8d, 0x28, 0x0e, 0x93, 0x10, 0xbd, 0xcc, 0x1f, 0x1a, 0x6e, 0x44, 0x8c, 0xf4,
-	0x5a, 0x7f, 0x03, 0x4a, 0x77, 0x90, 0xde, 0x59, 0x9e, 0x17, 0xfb, 0x94, 0xa2, 0x06, 0x54, 0xdd,
-	0x6c, 0x6c, 0x48, 0x9a, 0xd4, 0xac, 0xe2, 0xfc, 0xa8, 0x7f, 0x01, 0xb4, 0x01, 0x5a, 0x0b, 0x6f,
-	0x10, 0xc6, 0x09, 0x32, 0xb7, 0x79, 0xe5, 0xe6, 0xc2, 0xf8, 0xeb, 0x0d, 0xc6, 0xc6, 0x56, 0xe1,
-	0x86, 0x10, 0x1c, 0x45, 0x61, 0x9c, 0x34, 0xca, 0x9a, 0xd4, 0xac, 0x61, 0x3e, 0xeb, 0xb7, 0x3c,
-	0x8a, 0x69, 0xfd, 0x41, 0xe6, 0x64, 0x36, 0xe7, 0xbe, 0x32, 0xe6, 0x33, 0x52, 0xa1, 0x12, 0x84,
-	0x2b, 0xbe, 0x25, 0x63, 0x36, 0x8a, 0x58, 0xe6, 0xb3, 0x62, 0x99, 0xff, 0x15, 0xeb, 0x47, 0x19,
-	0xce, 0x6c, 0x3f, 0x59, 0x85, 0xf1, 0xb7, 0x3c, 0xda, 0x7b, 0x90, 0xa7, 0xee, 0x77, 0x12, 0xac,
-	0xb9, 0xfb, 0xd9, 0xcd, 0xd5, 0x3f, 0xee, 0xdb, 0x0b, 0x8f, 0x1c, 0xc6, 0x62, 0x09, 0x75, 0xe0,
-	0x94, 0x44, 0xe9, 0x9d, 0x93, 0x47, 0x04, 0x1e, 0xf1, 0xf5, 0xa1, 0xe6, 0xc4, 0x87, 0x75, 0x4a,
-	0x58, 0x61, 0xab, 0x79, 0x90, 0xcc, 0xc9, 0x2c, 0x9c, 0xea, 0xfb, 0x9d, 0xcc, 0x9d, 0x4e, 0x45,
-	0xdb, 0x57, 0x50, 0x0b, 0xc2, 0x89, 0x1b, 0x14, 0x56, 0x97, 0x9a, 0xd4, 0x3c, 0xe9, 0x94, 0xf0,
-	0x29, 0x97, 0x05, 0xf6, 0xe1, 0xa4, 0x28, 0x56, 0xff, 0x25, 0x81, 0x72, 0x1f, 0xfb, 0x9e, 0xbf,
-	0x48, 0x88, 0x1b, 0x50, 0x76, 0x37, 0x4b, 0xe2, 0xf1, 0x46, 0x6a, 0x98, 0x8d, 0x4c, 0x99, 0x11,
-	0x4f, 0x94, 0xc9, 0x46, 0xd6, 0xaf, 0xcf, 0xa0, 0x4a, 0xd6, 0x2f, 0x9b, 0xb9, 0xc6, 0xb0, 0x23,
-	0xa1, 0x09, 0x8e, 0x32, 0xee, 0x38, 0xd3, 0xa8, 0xe0, 0x28, 0xe3, 0x64, 0xa1, 0x31, 0xae, 0x0e,
-	0xc7, 0x53, 0x0e, 0x56, 0xb9, 0x98, 0x1d, 0x32, 0x95, 0xa1, 0x2f, 0x72, 0x75, 0x46, 0xbc, 0xb7,
-	0x3f, 0x25, 0xa8, 0xef, 0xba, 0x16, 0xa4, 0xc3, 0xa5, 0xdd, 0x1e, 0x8d, 0xfb, 0xf8, 0xc9, 0xb1,
-	0x1e, 0x1e, 0x70, 0x7b, 0x38, 0x74, 0x1e, 0xad, 0x8f, 0xdd, 0xde, 0x67, 0xe7, 0x93, 0xfd, 0x64,
-	0xf7, 0xc7, 0xb6, 0x5a, 0x42, 0xaf, 0xe0, 0x7c, 0x0f, 0xd3, 0xb5, 0xdb, 0x23, 0x55, 0x42, 0x1a,
-	0x5c, 0x1c, 0x00, 0x4c, 0xb5, 0x7c, 0x80, 0xe8, 0xf5, 0xef, 0xad, 0x9e, 0x5a, 0xf9, 0x2a, 0xf3,
-	0x7f, 0xf4, 0xf6, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x05, 0xf4, 0xc6, 0xf8, 0xc2, 0x03, 0x00,
-	0x00,
+	// 493 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0x4f, 0x6f, 0xda, 0x40,
+	0x10, 0xc5, 0x31, 0x24, 0x06, 0xc6, 0x90, 0x5a, 0x2b, 0x0e, 0x54, 0x44, 0x29, 0x72, 0x15, 0x15,
+	0xf5, 0x60, 0xa2, 0x24, 0xb2, 0x7a, 0xe9, 0xc1, 0x4d, 0x88, 0x40, 0xa1, 0x26, 0xda, 0xa4, 0x8a,
+	0x7a, 0x72, 0xdd, 0x78, 0x43, 0x56, 0x35, 0xd8, 0xf2, 0x1f, 0xa2, 0x7c, 0x90, 0x1e, 0xab, 0x7e,
+	0xd5, 0x6a, 0xd6, 0x6b, 0x97, 0xb4, 0x09, 0xaa, 0x7a, 0x1b, 0xbf, 0xf9, 0xbd, 0xc7, 0xf3, 0x80,
+	0x80, 0xde, 0x8d, 0x17, 0x25, 0x59, 0xc0, 0xde, 0x0d, 0xbd, 0x88, 0x0f, 0x57, 0x07, 0xc3, 0xf4,
+	0x21, 0x62, 0x89, 0x19, 0xc5, 0x61, 0x1a, 0x92, 0x17, 0xc5, 0xd2, 0xf4, 0x22, 0x6e, 0xae, 0x0e,
+	0x8c, 0x37, 0xa0, 0x4d, 0x2e, 0x56, 0xc7, 0xb6, 0xef, 0xc7, 0x2c, 0x49, 0x48, 0x17, 0xea, 0x5e,
+	0x3e, 0x76, 0x95, 0xbe, 0x32, 0xa8, 0xd3, 0xe2, 0xd1, 0xf8, 0x02, 0x64, 0x0d, 0xb4, 0x97, 0xfe,
+	0x45, 0x18, 0xa7, 0xc4, 0x7a, 0xcc, 0x6b, 0x87, 0xbb, 0xe6, 0x1f, 0x9f, 0x60, 0xae, 0xb9, 0xca,
+	0x34, 0x42, 0x60, 0x2b, 0x0a, 0xe3, 0xb4, 0x5b, 0xed, 0x2b, 0x83, 0x36, 0x15, 0xb3, 0x71, 0x24,
+	0xaa, 0x58, 0xf6, 0x6f, 0xe4, 0x8e, 0xcf, 0xef, 0x44, 0xae, 0x4a, 0xc5, 0x4c, 0x74, 0xa8, 0x05,
+	0xe1, 0xbd, 0x70, 0xa9, 0x14, 0x47, 0x59, 0xcb, 0xfa, 0xaf, 0x5a, 0xd6, 0x3f, 0xd5, 0xfa, 0x5e,
+	0x85, 0x1d, 0x87, 0xa5, 0xf7, 0x61, 0xfc, 0xad, 0xa8, 0xf6, 0x1e, 0xd4, 0x5b, 0x6f, 0xc1, 0x83,
+	0x07, 0x91, 0xbe, 0x73, 0xb8, 0xff, 0x57, 0xfa, 0x63, 0xc3, 0x99, 0x80, 0xa9, 0x34, 0x91, 0x31,
+	0xb4, 0x78, 0xb4, 0x3a, 0x76, 0x8b, 0x8a, 0x20, 0x2a, 0xbe, 0xde, 0x74, 0x39, 0xf9, 0x62, 0xe3,
+	0x0a, 0xd5, 0xd0, 0x5a, 0x14, 0xc9, 0x93, 0xac, 0x32, 0xa9, 0xf3, 0x7c, 0x92, 0xf5, 0x64, 0x52,
+	0x79, 0xed, 0x7d, 0x68, 0x07, 0xe1, 0x8d, 0x17, 0x94, 0x51, 0x7b, 0x7d, 0x65, 0xd0, 0x1c, 0x57,
+	0x68, 0x4b, 0xc8, 0x12, 0xfb, 0xd0, 0x2c, 0x0f, 0x6b, 0xfc, 0xa8, 0x82, 0x76, 0x12, 0x33, 0x9f,
+	0x2d, 0x53, 0xee, 0x05, 0x09, 0x7e, 0x37, 0x19, 0xf7, 0xc5, 0x45, 0xda, 0x14, 0x47, 0x54, 0xe6,
+	0xdc, 0x97, 0xc7, 0xc4, 0x11, 0xef, 0xcb, 0x10, 0xaa, 0xe5, 0xf7, 0xc5, 0x59, 0x68, 0x88, 0x6d,
+	0x49, 0x4d, 0x72, 0x09, 0x72, 0xdb, 0xb9, 0x96, 0x48, 0x2e, 0x41, 0x4e, 0x95, 0x1a, 0x72, 0x1d,
+	0xd8, 0xbe, 0x15, 0x60, 0x5d, 0x88, 0xf9, 0x43, 0xae, 0x22, 0xda, 0x28, 0x54, 0x64, 0x5f, 0x42,
+	0x23, 0xe3, 0xbe, 0xbb, 0xf4, 0x16, 0xac, 0xdb, 0xc4, 0x97, 0xa3, 0xf5, 0x8c, 0xfb, 0x8e, 0xb7,
+	0x60, 0xb8, 0x9a, 0x17, 0x2b, 0xc8, 0x57, 0x73, 0xb9, 0xea, 0x41, 0x93, 0x95, 0x36, 0x4d, 0xec,
+	0x1a, 0x2c, 0x5b, 0x5b, 0x96, 0xc6, 0x96, 0x5c, 0x4a, 0xe7, 0xdb, 0x9f, 0x0a, 0x74, 0x9e, 0xfa,
+	0x19, 0x10, 0x03, 0xf6, 0x9c, 0xd1, 0xd5, 0xf5, 0x8c, 0x9e, 0xbb, 0xf6, 0xe9, 0x29, 0x1d, 0x5d,
+	0x5e, 0xba, 0x67, 0xf6, 0xc7, 0xc9, 0xf4, 0xb3, 0xfb, 0xc9, 0x39, 0x77, 0x66, 0xd7, 0x8e, 0x5e,
+	0x21, 0xaf, 0xa0, 0xf7, 0x0c, 0x33, 0x71, 0x46, 0x57, 0xba, 0x42, 0xfa, 0xb0, 0xbb, 0x01, 0xb0,
+	0xf4, 0xea, 0x06, 0x62, 0x3a, 0x3b, 0xb1, 0xa7, 0x7a, 0xed, 0xab, 0x2a, 0xfe, 0x13, 0x8e, 0x7e,
+	0x05, 0x00, 0x00, 0xff, 0xff, 0xfc, 0x4f, 0x62, 0x73, 0x32, 0x04, 0x00, 0x00,
 }
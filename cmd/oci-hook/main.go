@@ -0,0 +1,75 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// oci-hook is an OCI runtime hook that notifies a running sensor of container
+// lifecycle changes at the moment they happen. Configure it as a prestart,
+// poststart, and/or poststop hook, passing the name of the hook as the last
+// argument, e.g.:
+//
+//	"poststart": [{"path": "/usr/local/bin/oci-hook",
+//	               "args": ["oci-hook", "poststart"]}]
+//
+// The hook never fails, so that a missing or unresponsive sensor does not
+// prevent containers from starting.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/capsule8/capsule8/pkg/ocihook"
+)
+
+var (
+	socketPath = flag.String("socket", "/var/run/capsule8/oci-hook.sock",
+		"path to the sensor's OCI hook unix socket")
+	timeout = flag.Duration("timeout", 1*time.Second,
+		"maximum time to wait for the sensor")
+)
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] prestart|poststart|poststop\n",
+			os.Args[0])
+		os.Exit(0)
+	}
+
+	state, err := ocihook.ReadState(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not read container state: %s\n", err)
+		os.Exit(0)
+	}
+
+	msg := &ocihook.Message{
+		Hook:  flag.Arg(0),
+		State: state,
+	}
+	if len(state.Bundle) > 0 {
+		config, err := ioutil.ReadFile(filepath.Join(state.Bundle, "config.json"))
+		if err == nil {
+			msg.Config = string(config)
+		}
+	}
+
+	if err = ocihook.Send(*socketPath, msg, *timeout); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not notify sensor via %s: %s\n",
+			*socketPath, err)
+	}
+}
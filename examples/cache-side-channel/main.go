@@ -105,7 +105,7 @@ func main() {
 		},
 	}
 	sub := tracker.sensor.NewSubscription()
-	sub.RegisterPerformanceEventFilter(attr, counters)
+	sub.RegisterPerformanceEventFilter(attr, counters, nil)
 
 	glog.Info("Monitoring for cache side channels")
 	ctx, cancel := context.WithCancel(context.Background())
@@ -34,6 +34,7 @@ import (
 
 	"google.golang.org/genproto/googleapis/rpc/code"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
 )
 
 var config struct {
@@ -41,6 +42,7 @@ var config struct {
 	image       string
 	json        bool
 	prettyPrint bool
+	compress    bool
 }
 
 func init() {
@@ -54,6 +56,8 @@ func init() {
 		"Output telemetry events as JSON")
 	flag.BoolVar(&config.prettyPrint, "prettyprint", false,
 		"Pretty print JSON telemetry events")
+	flag.BoolVar(&config.compress, "compress", false,
+		"Request that telemetry events are compressed with gzip")
 }
 
 // Custom gRPC Dialer that understands "unix:/path/to/sock" as well as TCP addrs
@@ -232,11 +236,17 @@ func main() {
 	}()
 
 	// Create telemetry service client
-	conn, err := grpc.DialContext(ctx, config.server,
+	opts := []grpc.DialOption{
 		grpc.WithDialer(dialer),
 		grpc.WithBlock(),
-		grpc.WithTimeout(1*time.Second),
-		grpc.WithInsecure())
+		grpc.WithTimeout(1 * time.Second),
+		grpc.WithInsecure(),
+	}
+	if config.compress {
+		opts = append(opts, grpc.WithDefaultCallOptions(
+			grpc.UseCompressor(gzip.Name)))
+	}
+	conn, err := grpc.DialContext(ctx, config.server, opts...)
 
 	c := api.NewTelemetryServiceClient(conn)
 	if err != nil {
@@ -246,6 +256,7 @@ func main() {
 
 	stream, err := c.GetEvents(ctx, &api.GetEventsRequest{
 		Subscription: createSubscription(),
+		ApiVersion:   api.APIVersion_API_VERSION_2,
 	})
 
 	if err != nil {
@@ -15,6 +15,9 @@
 package config
 
 import (
+	"sync/atomic"
+	"time"
+
 	"github.com/golang/glog"
 	"github.com/kelseyhightower/envconfig"
 )
@@ -25,26 +28,133 @@ var Global struct {
 	RunDir string `split_words:"true" default:"/var/run/capsule8"`
 
 	// HTTP address and port for the pprof runtime profiling endpoint.
+	// Profiling is not served if it is empty.
 	ProfilingListenAddr string `split_words:"true"`
+
+	// If greater than zero, the block profile of the profiling endpoint
+	// samples one blocking event for about every this many nanoseconds
+	// spent blocked.
+	ProfilingBlockRate int `split_words:"true"`
+
+	// If greater than zero, the mutex profile of the profiling endpoint
+	// samples one in this many contended mutex events.
+	ProfilingMutexFraction int `split_words:"true"`
+
+	// ConfigFile is the path of a YAML file of configuration options,
+	// which are overridden by environment variables. It is not read if
+	// it is empty.
+	ConfigFile string `split_words:"true"`
+
+	// ConfigReloadInterval is how often ConfigFile is checked for
+	// changes. Changes to options that may be reloaded are applied
+	// without restarting the Sensor. Only the options tagged with
+	// `reload:"true"` may be; changes to others, including the
+	// listeners, sinks, and event sources, are logged and take effect
+	// when the Sensor is restarted. If 0, the file is only read at
+	// startup.
+	ConfigReloadInterval time.Duration `split_words:"true" default:"1m"`
+}
+
+// Sensor contains overridable configuration options for the sensor. They
+// are set at startup and are not changed when the configuration file is
+// reloaded. Options that may be reloaded are read through CurrentSensor.
+var Sensor SensorOptions
+
+// reloadedSensor holds a *SensorOptions with the changes made to Sensor
+// by reloading the configuration file
+var reloadedSensor atomic.Value
+
+// CurrentSensor returns the options of the sensor with the changes made by
+// the latest reload of the configuration file. The options must not be
+// modified.
+func CurrentSensor() *SensorOptions {
+	if s, ok := reloadedSensor.Load().(*SensorOptions); ok {
+		return s
+	}
+	return &Sensor
 }
 
-// Sensor contains overridable configuration options for the sensor
-var Sensor struct {
+// SensorOptions contains overridable configuration options for the sensor
+type SensorOptions struct {
 	// DockerContainerDir is the path to the directory used for docker
 	// container local storage areas (i.e. /var/lib/docker/containers)
 	DockerContainerDir string `split_words:"true" default:"/var/lib/docker/containers"`
 
+	// DockerBackend selects how Docker containers are monitored. It may
+	// be either "filesystem" to watch for changes to container
+	// configuration files in DockerContainerDir, or "events" to use the
+	// Docker Engine events API via DockerSocketPath. If the events API
+	// cannot be reached, the filesystem backend is used instead.
+	DockerBackend string `split_words:"true" default:"filesystem"`
+
+	// DockerSocketPath is the path to the Docker Engine API unix socket
+	// (i.e. /var/run/docker.sock)
+	DockerSocketPath string `split_words:"true" default:"/var/run/docker.sock"`
+
 	// OciContainerDir is the path to the directory used for the
 	// container runtime's container state directories
 	// (i.e. /var/run/docker/libcontainerd)
 	OciContainerDir string `split_words:"true" default:"/var/run/docker/libcontainerd"`
 
+	// OciHookSocketPath is the path to the unix socket on which the
+	// sensor listens for notifications from the oci-hook binary (i.e.
+	// /var/run/capsule8/oci-hook.sock). If empty, no listener is started.
+	OciHookSocketPath string `split_words:"true"`
+
+	// WtmpPath is the path to the login accounting file that is followed
+	// for session login and logout events (i.e. /var/log/wtmp). If empty,
+	// session events are not available.
+	WtmpPath string `split_words:"true" default:"/var/log/wtmp"`
+
+	// KernelBTFPath is the path to the running kernel's BTF type
+	// information, which is used to find kernel structure offsets for
+	// kprobes. If empty or unreadable, built-in offsets are used.
+	KernelBTFPath string `split_words:"true" default:"/sys/kernel/btf/vmlinux"`
+
+	// SockCommonV6DaddrOffset and SockCommonV6RcvSaddrOffset are the
+	// offsets of skc_v6_daddr and skc_v6_rcv_saddr in the running
+	// kernel's struct sock_common, which are read by the IPv6 network
+	// kprobes. They are only used if the offsets cannot be found in the
+	// kernel's BTF, and vary between kernel versions and configurations
+	// (i.e. as reported by "pahole -C sock_common"). If 0, offsets built
+	// into the Sensor for a kernel with CONFIG_NET_NS and CONFIG_IPV6
+	// are used.
+	SockCommonV6DaddrOffset    uint32 `split_words:"true"`
+	SockCommonV6RcvSaddrOffset uint32 `split_words:"true"`
+
 	// Sensor gRPC API Server listen address may be specified as any of:
 	//   unix:/path/to/socket
 	//   127.0.0.1:8484
 	//   :8484
 	ListenAddr string `split_words:"true" default:"unix:/var/run/capsule8/sensor.sock"`
 
+	// ListenSocketMode is the octal file mode of the socket when
+	// ListenAddr is a unix socket. Only processes with permission to
+	// write to the socket may connect to it.
+	ListenSocketMode string `split_words:"true" default:"0600"`
+
+	// ListenSocketGroup is the name or ID of the group of the socket when
+	// ListenAddr is a unix socket, i.e. to allow node-local agents in
+	// the group to connect with a ListenSocketMode of 0660. If empty,
+	// the group is that of the Sensor.
+	ListenSocketGroup string `split_words:"true"`
+
+	// MetricsListenAddr is the HTTP address and port of the /metrics
+	// endpoint for Prometheus, e.g. ":9484". Metrics are not served if
+	// it is empty.
+	MetricsListenAddr string `split_words:"true"`
+
+	// HealthListenAddr is the HTTP address and port of the /healthz and
+	// /readyz probe endpoints, e.g. ":9485". Probes are not served over
+	// HTTP if it is empty; the gRPC health checking service is always
+	// served with the telemetry service.
+	HealthListenAddr string `split_words:"true"`
+
+	// The event sources that must initialize for the Sensor to be
+	// ready: "perf" for kernel events, "docker" for Docker container
+	// monitoring, and "oci_hooks" for the OCI hook listener.
+	RequiredEventSources []string `split_words:"true" default:"perf"`
+
 	// UseTLS is the boolean switch to enable TLS use. By default it
 	// is false. If UseTLS is true, TLSCACertPath, TLSServerCertPath
 	// and TLSServerKeyPath will need to be set.
@@ -65,6 +175,23 @@ var Sensor struct {
 	// if UseTLS is true.
 	TLSServerKeyPath string `split_words:"true" default:"/var/lib/capsule8/tls/server.key"`
 
+	// AuthTokensPath is the path to a JSON file of the bearer tokens
+	// that clients of the telemetry server must present and the events
+	// that each token may subscribe to. Tokens are not required if this
+	// is empty.
+	AuthTokensPath string `split_words:"true"`
+
+	// MinAPIVersion is the oldest version of the Telemetry API that
+	// clients may use. Clients that do not send a version are treated
+	// as version 1.
+	MinAPIVersion int `split_words:"true" default:"1" reload:"true"`
+
+	// TLSReloadInterval is how often the files named by TLSCACertPath,
+	// TLSServerCertPath, and TLSServerKeyPath are checked for changes. Changed files are loaded and
+	// used for new connections, so certificates can be rotated without
+	// restarting the Sensor. If 0, the files are only loaded at startup.
+	TLSReloadInterval time.Duration `split_words:"true" default:"1m"`
+
 	// Names of cgroups to monitor for events. Each cgroup specified must
 	// exist within the perf_event cgroup hierarchy. For example, if this
 	// is set to "docker", the Sensor will monitor containers for events
@@ -72,12 +199,215 @@ var Sensor struct {
 	// the entire system, use "" or "/" as the cgroup name.
 	CgroupName []string `split_words:"true"`
 
+	// Containers whose events are never monitored, regardless of the
+	// subscriptions made, i.e. to enforce a privacy policy. Each entry
+	// is "name:pattern", "image:pattern", "label:key=pattern", or
+	// "label:key", where each pattern is a shell-style glob, i.e.
+	// "name:*-istio-proxy".
+	ContainerDenylist []string `split_words:"true"`
+
+	// If not empty, only events from containers that match one of these
+	// entries, which have the same form as those of ContainerDenylist,
+	// are monitored. Events from processes that are not in containers
+	// are always monitored.
+	ContainerAllowlist []string `split_words:"true"`
+
+	// If not empty, subscriptions may only request the events of these
+	// EventFilter fields, named as in the protobuf definition, i.e.
+	// "process_events" and "container_events". The events of all other
+	// fields are removed from subscriptions.
+	EnabledEventFilters []string `split_words:"true"`
+
+	// The EventFilter fields, named as in the protobuf definition, i.e.
+	// "syscall_events" or "tty_events", whose events are removed from all
+	// subscriptions.
+	DisabledEventFilters []string `split_words:"true"`
+
+	// UseBPFCgroupFilter restricts events to the cgroups named by
+	// CgroupName using an in-kernel BPF program attached to each
+	// tracepoint, kprobe, and uprobe, instead of opening perf_event
+	// sources for each cgroup. The cgroups are looked up in the cgroup2
+	// hierarchy and include their descendants. Only the filtering is
+	// done in BPF; events are collected as they are otherwise. Requires
+	// Linux 5.7+.
+	UseBPFCgroupFilter bool `split_words:"true" default:"false"`
+
+	// UseContainerCgroups restricts the kernel events generated for a
+	// subscription whose container filter names only container IDs to
+	// the perf_event cgroups of those containers, rather than filtering
+	// events for the whole system in the Sensor. Containers must be
+	// running when the subscription is made, and events from a container
+	// are no longer seen if its cgroup is recreated.
+	UseContainerCgroups bool `split_words:"true" default:"false"`
+
+	// UseAuditBackend selects the kernel audit subsystem instead of
+	// kprobes as the source of process exec, network connect attempt, and
+	// file open events. It is intended for hosts where tracing is locked
+	// down. Audit rules for the execve, execveat, connect, open, and
+	// openat system calls must be installed separately (i.e. by auditd).
+	UseAuditBackend bool `split_words:"true" default:"false"`
+
+	// ExecStackTraces captures the stack of the task calling execve(2)
+	// with every process exec event. Subscriptions that request stack
+	// traces only receive them with process exec events when this is
+	// enabled, since process exec events are shared by all subscriptions.
+	ExecStackTraces bool `split_words:"true" default:"false"`
+
 	// Ignore missing debugfs/tracefs mount (useful for automated testing)
 	DontMountTracing bool `split_words:"true"`
 
 	// Ignore missing perf_event cgroup filesystem mount
 	DontMountPerfEvent bool `split_words:"true"`
 
+	// The directory of the on-disk spool of events, which may be
+	// replayed with the TelemetryService's ReplayEvents. The spool is
+	// disabled if this is empty.
+	SpoolDir string `split_words:"true"`
+
+	// The path of a JSON file containing the Subscription whose events are
+	// written to the spool. Only its BufferModifier is used, which bounds
+	// the events waiting to be written.
+	SpoolSubscriptionPath string `split_words:"true"`
+
+	// The largest size of the spool in bytes. The oldest events are
+	// removed when the spool is full.
+	SpoolMaxSize int64 `split_words:"true" default:"67108864"`
+
+	// The path of a JSON file containing the Subscription whose events are
+	// published to each of the configured sinks. Only its BufferModifier is
+	// used, which bounds the events waiting for each sink.
+	SinkSubscriptionPath string `split_words:"true"`
+
+	// The largest number of events that a sink publishes together.
+	SinkBatchSize int `split_words:"true" default:"100"`
+
+	// The longest time that an event waits to be published by a sink
+	// with others.
+	SinkBatchInterval time.Duration `split_words:"true" default:"1s"`
+
+	// The number of times that a sink retries publishing a batch of
+	// events before dropping them.
+	SinkRetries int `split_words:"true" default:"3"`
+
+	// The addresses (host:port) of Kafka brokers to bootstrap from.
+	// Events are published to Kafka if this is not empty.
+	KafkaBrokers []string `split_words:"true"`
+
+	// The Kafka topic that events are published to.
+	KafkaTopic string `split_words:"true" default:"capsule8-events"`
+
+	// Kafka topics for specific types of events, each given as
+	// "type=topic" where type is a TelemetryEvent event field name, i.e.
+	// "process=capsule8-process-events". Other events are published to
+	// KafkaTopic.
+	KafkaTopics []string `split_words:"true"`
+
+	// Publish the events of each container to the same Kafka partition,
+	// keyed by container ID, so that they are consumed in order.
+	KafkaPartitionByContainer bool `split_words:"true" default:"false"`
+
+	// The serialization of events published to Kafka: "protobuf",
+	// "json", "cloudevents", "cef", or "leef".
+	KafkaEncoding string `split_words:"true" default:"protobuf"`
+
+	// The compression of record batches published to Kafka: "none" or
+	// "gzip".
+	KafkaCompression string `split_words:"true" default:"none"`
+
+	// The path of a file that events are appended to as newline-delimited
+	// JSON, or "-" for standard output.
+	JSONSinkPath string `split_words:"true"`
+
+	// The size in bytes at which the file named by JSONSinkPath is
+	// rotated. It is not rotated if this is 0.
+	JSONSinkMaxSize int64 `split_words:"true" default:"104857600"`
+
+	// The number of rotated files named by JSONSinkPath that are kept.
+	JSONSinkMaxFiles int `split_words:"true" default:"5"`
+
+	// The serialization of each line written to JSONSinkPath: "json" or
+	// "cloudevents" for JSON objects, or "cef" or "leef".
+	JSONSinkEncoding string `split_words:"true" default:"json"`
+
+	// The address (host:port) of a syslog server that events are sent
+	// to as RFC 5424 messages. Events are not sent to syslog if this is
+	// empty.
+	SyslogAddr string `split_words:"true"`
+
+	// The transport used to send messages to SyslogAddr: "udp", "tcp",
+	// or "tls".
+	SyslogTransport string `split_words:"true" default:"udp"`
+
+	// The syslog facility of messages, i.e. 16 for local0.
+	SyslogFacility int `split_words:"true" default:"16"`
+
+	// The serialization of events in syslog messages: "json",
+	// "cloudevents", "cef", or "leef".
+	SyslogEncoding string `split_words:"true" default:"json"`
+
+	// The path to the file that holds the certificate authority
+	// certificates used to verify the syslog server when SyslogTransport
+	// is "tls". If empty, the host's certificate authorities are used.
+	SyslogTLSCACertPath string `split_words:"true"`
+
+	// The address (host:port) of a fluentd or fluent-bit forward input
+	// that events are sent to. Events are not sent to fluentd if this is
+	// empty.
+	FluentdAddr string `split_words:"true"`
+
+	// The prefix of the tags of events sent to fluentd, which is followed
+	// by the event type, i.e. "capsule8.sensor.process".
+	FluentdTag string `split_words:"true" default:"capsule8.sensor"`
+
+	// The shared key used to authenticate with the fluentd forward input,
+	// if it requires one.
+	FluentdSharedKey string `split_words:"true"`
+
+	// The username and password used to authenticate with the fluentd
+	// forward input, if it requires user authentication.
+	FluentdUsername string `split_words:"true"`
+	FluentdPassword string `split_words:"true"`
+
+	// The URL of an Elasticsearch cluster that events are indexed in, i.e.
+	// "http://localhost:9200". Events are not indexed if this is empty.
+	ElasticsearchURL string `split_words:"true"`
+
+	// The prefix of the names of the daily per-event-type indices that
+	// events are indexed in.
+	ElasticsearchIndexPrefix string `split_words:"true" default:"capsule8"`
+
+	// The username and password used to authenticate with the
+	// Elasticsearch cluster, if it requires them.
+	ElasticsearchUsername string `split_words:"true"`
+	ElasticsearchPassword string `split_words:"true"`
+
+	// The compression of requests to Elasticsearch: "none" or "gzip".
+	ElasticsearchCompression string `split_words:"true" default:"none"`
+
+	// The URL that batches of events are POSTed to as JSON arrays.
+	// Events are not POSTed if this is empty.
+	WebhookURL string `split_words:"true"`
+
+	// The secret used to sign webhook requests with HMAC-SHA256. Requests
+	// are not signed if this is empty.
+	WebhookSecret string `split_words:"true"`
+
+	// Additional headers sent with webhook requests, each of the form
+	// "name=value".
+	WebhookHeaders []string `split_words:"true"`
+
+	// The serialization of events POSTed to the webhook: "json" or
+	// "cloudevents".
+	WebhookEncoding string `split_words:"true" default:"json"`
+
+	// The path of a file that batches of events that cannot be delivered
+	// to the webhook are appended to as newline-delimited JSON. If this
+	// is empty, they are retried by the sink's batcher and then dropped.
+	WebhookDeadLetterPath string `split_words:"true"`
+
+	// The compression of requests to the webhook: "none" or "gzip".
+	WebhookCompression string `split_words:"true" default:"none"`
+
 	//
 	// Performance knobs below here
 	//
@@ -87,11 +417,61 @@ var Sensor struct {
 	RingBufferPages int `split_words:"true" default:"8"`
 
 	// The default buffer length for Go channels used internally
-	ChannelBufferLength int `split_words:"true" default:"1024"`
+	ChannelBufferLength int `split_words:"true" default:"1024" reload:"true"`
+
+	// The number of unacknowledged responses held for a subscription
+	// with at least once delivery. A stream stops sending events while
+	// this many are unacknowledged, so its buffer overflows if the client
+	// does not acknowledge them.
+	DeliveryMaxPending int `split_words:"true" default:"4096" reload:"true"`
+
+	// How long the unacknowledged responses of a subscription with at
+	// least once delivery are kept after its stream is closed
+	DeliveryRetention time.Duration `split_words:"true" default:"5m" reload:"true"`
+
+	// How long the Sensor waits when it is stopped for each stream, the
+	// spool, and sinks to send, write, and publish the events that they
+	// hold, before closing them
+	ShutdownTimeout time.Duration `split_words:"true" default:"10s"`
+
+	// How often a SensorStatus event is sent on each stream using API
+	// version 2 or later. Status events are not sent if this is 0.
+	StatusInterval time.Duration `split_words:"true" default:"1m" reload:"true"`
 
 	// The size of the process info cache. If the system pid_max is greater
 	// than this size, a less performant method of caching will be used.
 	ProcessInfoCacheSize uint `split_words:"true" default:"131072"`
+
+	// The most processes in the process lineage of exec and network
+	// events, including the process associated with the event. Lineage
+	// is not included if this is zero.
+	ProcessLineageDepth int `split_words:"true" default:"8" reload:"true"`
+
+	// Whether to include the SHA-256 hash of the executed program in
+	// process exec events. Hashes are cached by file, so each program is
+	// only read once for as long as it is unchanged.
+	HashExecutables bool `split_words:"true"`
+
+	// The most hashes of executables that are cached
+	ExecutableHashCacheSize int `split_words:"true" default:"4096"`
+
+	// Executables larger than this many bytes are not hashed
+	ExecutableHashMaxSize int64 `split_words:"true" default:"268435456"`
+
+	// The names of the environment variables included in process exec
+	// events for subscriptions that capture them, where '*' matches any
+	// sequence of characters (e.g., "AWS_*"). All other variables are
+	// stripped, since environments often hold secrets.
+	ExecEnvironment []string `split_words:"true" default:"PATH,LD_PRELOAD,LD_LIBRARY_PATH"`
+
+	// Whether to include user and group names with the credentials in
+	// events. Names are looked up in the /etc/passwd and /etc/group files
+	// of the process's container, or of the host.
+	ResolveUserNames bool `split_words:"true" default:"true"`
+
+	// How long the user and group names of a container are cached before
+	// its /etc/passwd and /etc/group files are read again
+	UserNameCacheTTL time.Duration `split_words:"true" default:"1m"`
 }
 
 func init() {
@@ -102,4 +482,9 @@ func init() {
 	if err := envconfig.Process("CAPSULE8_SENSOR", &Sensor); err != nil {
 		glog.Fatal(err)
 	}
+	if len(Global.ConfigFile) > 0 {
+		if err := LoadFile(Global.ConfigFile); err != nil {
+			glog.Fatal(err)
+		}
+	}
 }
@@ -0,0 +1,396 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/golang/glog"
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Options may also be set by the YAML file named by Global.ConfigFile. Its
+// "global" and "sensor" sections hold the options of Global and Sensor,
+// named by their fields in snake case, e.g.:
+//
+//	sensor:
+//	  listen_addr: ":8484"
+//	  tls_server_cert_path: /etc/capsule8/server.crt
+//	  required_event_sources: [perf, docker]
+//	  exec_environment:
+//	    - PATH
+//	    - "AWS_*"
+//
+// Environment variables override the file. The file is checked for changes
+// every Global.ConfigReloadInterval, and the changed options tagged with
+// `reload:"true"` are applied to the running Sensor. Those are only
+// min_api_version, channel_buffer_length, delivery_max_pending,
+// delivery_retention, status_interval, and process_lineage_depth, which are
+// read through CurrentSensor whenever they are used. Each reload publishes
+// a new copy of the options, so they are never changed while being read.
+// Other changes, including those to listeners, sinks, and event sources,
+// are logged and take effect when the Sensor is restarted.
+
+// configSection is a section of the configuration file.
+type configSection struct {
+	name   string
+	prefix string      // of the section's environment variables
+	spec   interface{} // pointer to the section's options
+
+	// Holds a pointer to a copy of the section's options with the
+	// changes made by reloads, or nil if the section has no options that
+	// may be reloaded
+	reloaded *atomic.Value
+}
+
+var configSections = []configSection{
+	{"global", "CAPSULE8", &Global, nil},
+	{"sensor", "CAPSULE8_SENSOR", &Sensor, &reloadedSensor},
+}
+
+// current returns a section's options as of the latest reload.
+func (section configSection) current() reflect.Value {
+	if section.reloaded != nil {
+		if v := section.reloaded.Load(); v != nil {
+			return reflect.ValueOf(v).Elem()
+		}
+	}
+	return reflect.ValueOf(section.spec).Elem()
+}
+
+// The expression that envconfig uses to split the words of names
+var envWordsRegexp = regexp.MustCompile("([^A-Z]+|[A-Z][^A-Z]+|[A-Z]+)")
+
+// envSet returns whether the environment variable of a field is set. It
+// looks up the same names that envconfig does.
+func envSet(prefix string, f reflect.StructField) bool {
+	key := strings.ToUpper(f.Name)
+	if f.Tag.Get("split_words") == "true" {
+		key = strings.ToUpper(strings.Join(
+			envWordsRegexp.FindAllString(f.Name, -1), "_"))
+	}
+	alt := strings.ToUpper(f.Tag.Get("envconfig"))
+	if len(alt) > 0 {
+		key = alt
+	}
+	if _, ok := os.LookupEnv(prefix + "_" + key); ok {
+		return true
+	}
+	if len(alt) > 0 {
+		_, ok := os.LookupEnv(alt)
+		return ok
+	}
+	return false
+}
+
+// optionName returns the name of a field in the configuration file, which
+// is its name in snake case, e.g. "tls_server_cert_path" for
+// TLSServerCertPath.
+func optionName(f reflect.StructField) string {
+	if name := f.Tag.Get("envconfig"); len(name) > 0 {
+		return strings.ToLower(name)
+	}
+	var b strings.Builder
+	name := f.Name
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if isUpper(c) && i > 0 {
+			// A word begins at a capital letter that follows a
+			// lower case letter or that begins a word after an
+			// acronym, e.g. "Server" in "TLSServer"
+			if !isUpper(name[i-1]) ||
+				(i+1 < len(name) && isLower(name[i+1])) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteByte(c)
+	}
+	return strings.ToLower(b.String())
+}
+
+func isUpper(c byte) bool { return c >= 'A' && c <= 'Z' }
+func isLower(c byte) bool { return c >= 'a' && c <= 'z' }
+
+// setOption sets a field to a value from the configuration file, which is
+// either a string or, for lists, a []string.
+func setOption(field reflect.Value, value interface{}) error {
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String {
+		switch v := value.(type) {
+		case []string:
+			field.Set(reflect.ValueOf(v))
+		case string:
+			// As with environment variables, lists may be given as
+			// comma-separated strings
+			if len(v) == 0 {
+				field.Set(reflect.Zero(field.Type()))
+			} else {
+				field.Set(reflect.ValueOf(strings.Split(v, ",")))
+			}
+		default:
+			return fmt.Errorf("expected a list")
+		}
+		return nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("expected a single value")
+	}
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+// applySection sets the options of a section from the values in the
+// configuration file, except for those set by environment variables.
+func applySection(section configSection, spec reflect.Value, values interface{}) error {
+	m, ok := values.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("section %q must be a mapping", section.name)
+	}
+	fields := make(map[string]int)
+	t := spec.Type()
+	for i := 0; i < t.NumField(); i++ {
+		fields[optionName(t.Field(i))] = i
+	}
+	for key, value := range m {
+		i, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("unknown option %s.%s", section.name, key)
+		}
+		if envSet(section.prefix, t.Field(i)) {
+			continue
+		}
+		if err := setOption(spec.Field(i), value); err != nil {
+			return fmt.Errorf("invalid option %s.%s: %v",
+				section.name, key, err)
+		}
+	}
+	return nil
+}
+
+// loadFile reads a configuration file and sets the options of its sections.
+// The sections' specs are replaced by new values, so an error leaves them
+// unchanged.
+func loadFile(path string, sections []configSection) ([]reflect.Value, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := parseYAML(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	for name := range file {
+		found := false
+		for _, section := range sections {
+			if section.name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s: unknown section %q", path, name)
+		}
+	}
+
+	specs := make([]reflect.Value, len(sections))
+	for i, section := range sections {
+		// Start from the defaults and the environment
+		spec := reflect.New(reflect.TypeOf(section.spec).Elem())
+		if err = envconfig.Process(section.prefix, spec.Interface()); err != nil {
+			return nil, err
+		}
+		if values, ok := file[section.name]; ok {
+			if err = applySection(section, spec.Elem(), values); err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+		}
+		specs[i] = spec.Elem()
+	}
+	return specs, nil
+}
+
+// LoadFile sets the options in a configuration file, except for those set
+// by environment variables.
+func LoadFile(path string) error {
+	specs, err := loadFile(path, configSections)
+	if err != nil {
+		return err
+	}
+	for i, section := range configSections {
+		reflect.ValueOf(section.spec).Elem().Set(specs[i])
+	}
+	return nil
+}
+
+// reloadable returns whether a field of a section may be changed while the
+// Sensor runs.
+func reloadable(section configSection, f reflect.StructField) bool {
+	return section.reloaded != nil && f.Tag.Get("reload") == "true"
+}
+
+// reloadFile reads a configuration file again and publishes a new copy of
+// the options of each section with the changes to reloadable options
+// applied. It returns the names of the options that changed and were
+// applied, and of those that changed but need a restart.
+func reloadFile(path string, sections []configSection) ([]string, []string, error) {
+	specs, err := loadFile(path, sections)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var applied, restart []string
+	for i, section := range sections {
+		current := section.current()
+		t := current.Type()
+		var next reflect.Value
+		for j := 0; j < t.NumField(); j++ {
+			f := t.Field(j)
+			v := specs[i].Field(j)
+			if reflect.DeepEqual(current.Field(j).Interface(), v.Interface()) {
+				continue
+			}
+			name := section.name + "." + optionName(f)
+			if reloadable(section, f) {
+				if !next.IsValid() {
+					next = reflect.New(t)
+					next.Elem().Set(current)
+				}
+				next.Elem().Field(j).Set(v)
+				applied = append(applied, name)
+			} else {
+				restart = append(restart, name)
+			}
+		}
+		if next.IsValid() {
+			section.reloaded.Store(next.Interface())
+		}
+	}
+	sort.Strings(applied)
+	sort.Strings(restart)
+	return applied, restart, nil
+}
+
+// reloadLock serializes reloads of the configuration file
+var reloadLock sync.Mutex
+
+// Reload reads the configuration file named by Global.ConfigFile again and
+// applies the changes to options that may be changed while the Sensor
+// runs. Other changes are logged.
+func Reload() error {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	applied, restart, err := reloadFile(Global.ConfigFile, configSections)
+	if err != nil {
+		return err
+	}
+	if len(applied) > 0 {
+		glog.Infof("Reloaded configuration options %s",
+			strings.Join(applied, ", "))
+	}
+	if len(restart) > 0 {
+		glog.Warningf("Configuration options %s changed, but the Sensor must be restarted to use them",
+			strings.Join(restart, ", "))
+	}
+	return nil
+}
+
+// configFileState identifies a version of the configuration file by its
+// modification time and size, so that files replaced by renaming them or by
+// changing a symbolic link, as is done for mounted Kubernetes config maps,
+// are noticed.
+type configFileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func statConfigFile(path string) configFileState {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return configFileState{}
+	}
+	return configFileState{
+		modTime: fi.ModTime(),
+		size:    fi.Size(),
+	}
+}
+
+// Watch reloads the configuration file named by Global.ConfigFile whenever
+// it changes, checking at an interval until ctx is done.
+func Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	state := statConfigFile(Global.ConfigFile)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			newState := statConfigFile(Global.ConfigFile)
+			if newState == state {
+				continue
+			}
+			state = newState
+			if err := Reload(); err != nil {
+				glog.Warningf("Could not reload configuration: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOptions struct {
+	ListenAddr        string        `split_words:"true" default:"unix:/tmp/sensor.sock"`
+	TLSServerCertPath string        `split_words:"true"`
+	UseTLS            bool          `split_words:"true"`
+	Retries           int           `default:"3" reload:"true"`
+	CacheSize         uint          `split_words:"true"`
+	Interval          time.Duration `default:"1m" reload:"true"`
+	Sources           []string      `default:"perf"`
+	Named             string        `envconfig:"other_name"`
+}
+
+func writeConfigFile(t *testing.T, dir, contents string) string {
+	path := filepath.Join(dir, "config.yaml")
+	err := ioutil.WriteFile(path, []byte(contents), 0600)
+	require.NoError(t, err)
+	return path
+}
+
+func TestOptionName(t *testing.T) {
+	expected := map[string]string{
+		"ListenAddr":        "listen_addr",
+		"TLSServerCertPath": "tls_server_cert_path",
+		"UseTLS":            "use_tls",
+		"Retries":           "retries",
+		"CacheSize":         "cache_size",
+		"Named":             "other_name",
+	}
+	typ := reflect.TypeOf(testOptions{})
+	for name, key := range expected {
+		f, ok := typ.FieldByName(name)
+		require.True(t, ok)
+		assert.Equal(t, key, optionName(f), name)
+	}
+
+	f, ok := reflect.TypeOf(Sensor).FieldByName("KernelBTFPath")
+	require.True(t, ok)
+	assert.Equal(t, "kernel_btf_path", optionName(f))
+}
+
+func TestEnvSet(t *testing.T) {
+	typ := reflect.TypeOf(testOptions{})
+	f, _ := typ.FieldByName("TLSServerCertPath")
+	assert.False(t, envSet("CAPSULE8_CONFIG_TEST", f))
+
+	// Found by the same name as envconfig uses
+	os.Setenv("CAPSULE8_CONFIG_TEST_TLSS_ERVER_CERT_PATH", "")
+	defer os.Unsetenv("CAPSULE8_CONFIG_TEST_TLSS_ERVER_CERT_PATH")
+	assert.True(t, envSet("CAPSULE8_CONFIG_TEST", f))
+
+	f, _ = typ.FieldByName("Named")
+	os.Setenv("OTHER_NAME", "x")
+	defer os.Unsetenv("OTHER_NAME")
+	assert.True(t, envSet("CAPSULE8_CONFIG_TEST", f))
+}
+
+func TestLoadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var options testOptions
+	sections := []configSection{
+		{"test", "CAPSULE8_CONFIG_TEST", &options, nil},
+	}
+
+	os.Setenv("CAPSULE8_CONFIG_TEST_USE_TLS", "false")
+	defer os.Unsetenv("CAPSULE8_CONFIG_TEST_USE_TLS")
+
+	path := writeConfigFile(t, dir, `
+test:
+  listen_addr: ":8484"
+  tls_server_cert_path: /etc/server.crt
+  use_tls: true
+  cache_size: 0x100
+  interval: 30s
+  sources: [perf, docker]
+  other_name: named
+`)
+	specs, err := loadFile(path, sections)
+	require.NoError(t, err)
+	assert.Equal(t, testOptions{
+		ListenAddr:        ":8484",
+		TLSServerCertPath: "/etc/server.crt",
+		UseTLS:            false, // set by the environment
+		Retries:           3,
+		CacheSize:         256,
+		Interval:          30 * time.Second,
+		Sources:           []string{"perf", "docker"},
+		Named:             "named",
+	}, specs[0].Interface())
+	assert.Equal(t, testOptions{}, options)
+
+	for _, contents := range []string{
+		"other:\n  retries: 1\n",
+		"test:\n  unknown: 1\n",
+		"test: 1\n",
+		"test:\n  retries: many\n",
+		"test:\n  retries: [1, 2]\n",
+		"test:\n  interval: 5\n",
+		"test:\n  cache_size: -1\n",
+		"test:\n  sources:\n    nested: 1\n",
+	} {
+		path = writeConfigFile(t, dir, contents)
+		_, err = loadFile(path, sections)
+		assert.Error(t, err, contents)
+	}
+
+	_, err = loadFile(filepath.Join(dir, "missing.yaml"), sections)
+	assert.Error(t, err)
+}
+
+func TestReloadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		options  testOptions
+		reloaded atomic.Value
+	)
+	sections := []configSection{
+		{"test", "CAPSULE8_CONFIG_TEST", &options, &reloaded},
+	}
+
+	path := writeConfigFile(t, dir, "test:\n  retries: 5\n")
+	specs, err := loadFile(path, sections)
+	require.NoError(t, err)
+	options = specs[0].Interface().(testOptions)
+	assert.Equal(t, options, sections[0].current().Interface())
+
+	path = writeConfigFile(t, dir, `
+test:
+  retries: 7
+  interval: 10s
+  listen_addr: ":8484"
+  sources: perf,docker
+`)
+	applied, restart, err := reloadFile(path, sections)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test.interval", "test.retries"}, applied)
+	assert.Equal(t, []string{"test.listen_addr", "test.sources"}, restart)
+	current := reloaded.Load().(*testOptions)
+	assert.Equal(t, 7, current.Retries)
+	assert.Equal(t, 10*time.Second, current.Interval)
+	assert.Equal(t, "unix:/tmp/sensor.sock", current.ListenAddr)
+	assert.Equal(t, []string{"perf"}, current.Sources)
+
+	// Reloads publish new copies of the options rather than changing them
+	assert.Equal(t, 5, options.Retries)
+
+	// An invalid file changes nothing
+	path = writeConfigFile(t, dir, "test:\n  retries: 9\n  interval: x\n")
+	_, _, err = reloadFile(path, sections)
+	assert.Error(t, err)
+	assert.Equal(t, current, reloaded.Load())
+	assert.Equal(t, 7, current.Retries)
+
+	applied, restart, err = reloadFile(
+		writeConfigFile(t, dir, "test:\n  retries: 7\n  interval: 10s\n  listen_addr: \":8484\"\n  sources: perf,docker\n"),
+		sections)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Equal(t, []string{"test.listen_addr", "test.sources"}, restart)
+	assert.Equal(t, current, reloaded.Load())
+
+	// Sections without reloaded copies are never changed
+	sections[0].reloaded = nil
+	applied, restart, err = reloadFile(
+		writeConfigFile(t, dir, "test:\n  retries: 8\n"), sections)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Equal(t, []string{"test.retries"}, restart)
+}
+
+func TestReloadableOptions(t *testing.T) {
+	// Options that may be reloaded must be in sections that publish
+	// reloaded copies of their options
+	for _, section := range configSections {
+		typ := reflect.TypeOf(section.spec).Elem()
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("reload") == "true" {
+				assert.True(t, reloadable(section, f), f.Name)
+			}
+		}
+	}
+}
+
+func TestCurrentSensor(t *testing.T) {
+	assert.True(t, CurrentSensor() == &Sensor)
+
+	defer func() { reloadedSensor = atomic.Value{} }()
+
+	reloaded := Sensor
+	reloaded.StatusInterval = Sensor.StatusInterval + time.Second
+	reloadedSensor.Store(&reloaded)
+	assert.Equal(t, reloaded.StatusInterval, CurrentSensor().StatusInterval)
+	assert.NotEqual(t, reloaded.StatusInterval, Sensor.StatusInterval)
+}
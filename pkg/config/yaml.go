@@ -0,0 +1,263 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Configuration files are written in the subset of YAML needed for the
+// Sensor's options, which are all scalars or lists of scalars: block
+// mappings, block sequences ("- item"), flow sequences ("[a, b]"), plain,
+// single-quoted, and double-quoted scalars, and comments. Anchors, tags,
+// multi-line scalars, and flow mappings are not supported. Scalars are
+// returned as strings, sequences as []string, and mappings as
+// map[string]interface{}.
+
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func yamlError(number int, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", number, fmt.Sprintf(format, args...))
+}
+
+// parseYAML parses a YAML document whose top level is a mapping.
+func parseYAML(b []byte) (map[string]interface{}, error) {
+	p := &yamlParser{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := stripYAMLComment(scanner.Text())
+		text := strings.TrimLeft(line, " \t")
+		if len(text) == 0 || (n == 1 && text == "---") {
+			continue
+		}
+		indent := line[:len(line)-len(text)]
+		if strings.ContainsRune(indent, '\t') {
+			return nil, yamlError(n, "tabs may not be used for indentation")
+		}
+		p.lines = append(p.lines, yamlLine{
+			number: n,
+			indent: len(indent),
+			text:   text,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(p.lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	if p.lines[0].isSequenceItem() {
+		return nil, yamlError(p.lines[0].number, "expected a mapping")
+	}
+	m, err := p.parseMapping(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, yamlError(p.lines[p.pos].number, "unexpected indentation")
+	}
+	return m, nil
+}
+
+// stripYAMLComment removes a comment and trailing space from a line. A '#'
+// begins a comment when it is outside of quotes and at the start of the line
+// or after whitespace.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			line = line[:i]
+		}
+	}
+	return strings.TrimRight(line, " \t\r")
+}
+
+func (l yamlLine) isSequenceItem() bool {
+	return l.text == "-" || strings.HasPrefix(l.text, "- ")
+}
+
+func (p *yamlParser) parseMapping(indent int) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, yamlError(l.number, "unexpected indentation")
+		}
+		if l.isSequenceItem() {
+			return nil, yamlError(l.number, "expected a key")
+		}
+
+		var key, value string
+		if i := strings.Index(l.text, ": "); i >= 0 {
+			key, value = l.text[:i], strings.TrimSpace(l.text[i+2:])
+		} else if strings.HasSuffix(l.text, ":") {
+			key = l.text[:len(l.text)-1]
+		} else {
+			return nil, yamlError(l.number, "expected \"key: value\"")
+		}
+		key = strings.TrimSpace(key)
+		if len(key) == 0 {
+			return nil, yamlError(l.number, "empty key")
+		}
+		if _, ok := m[key]; ok {
+			return nil, yamlError(l.number, "duplicate key %q", key)
+		}
+		p.pos++
+
+		var (
+			v   interface{}
+			err error
+		)
+		switch {
+		case len(value) > 0 && value[0] == '[':
+			v, err = parseYAMLFlowSequence(value, l.number)
+		case len(value) > 0:
+			v, err = parseYAMLScalar(value, l.number)
+		case p.pos == len(p.lines):
+			v = ""
+		case p.lines[p.pos].isSequenceItem() && p.lines[p.pos].indent >= indent:
+			// Sequences may be indented by the same amount as
+			// their key
+			v, err = p.parseSequence(p.lines[p.pos].indent)
+		case p.lines[p.pos].indent > indent:
+			v, err = p.parseMapping(p.lines[p.pos].indent)
+		default:
+			v = ""
+		}
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+	return m, nil
+}
+
+func (p *yamlParser) parseSequence(indent int) ([]string, error) {
+	items := []string{}
+	for p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.indent < indent || !l.isSequenceItem() {
+			break
+		}
+		if l.indent > indent {
+			return nil, yamlError(l.number, "unexpected indentation")
+		}
+		item, err := parseYAMLScalar(strings.TrimSpace(l.text[1:]), l.number)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		p.pos++
+	}
+	return items, nil
+}
+
+// parseYAMLFlowSequence parses a sequence of scalars such as "[a, 'b, c']".
+func parseYAMLFlowSequence(s string, number int) ([]string, error) {
+	if !strings.HasSuffix(s, "]") {
+		return nil, yamlError(number, "unterminated sequence")
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	items := []string{}
+	if len(s) == 0 {
+		return items, nil
+	}
+
+	var (
+		quote byte
+		start int
+	)
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) {
+			c := s[i]
+			switch {
+			case quote == '"' && c == '\\':
+				i++
+				continue
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+				continue
+			case c == '"' || c == '\'':
+				quote = c
+				continue
+			case c != ',':
+				continue
+			}
+		}
+		item, err := parseYAMLScalar(strings.TrimSpace(s[start:i]), number)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		start = i + 1
+	}
+	return items, nil
+}
+
+// parseYAMLScalar parses a plain or quoted scalar. Null is returned as an
+// empty string.
+func parseYAMLScalar(s string, number int) (string, error) {
+	if len(s) == 0 {
+		return "", nil
+	}
+	switch s[0] {
+	case '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return "", yamlError(number, "invalid double-quoted string %s", s)
+		}
+		return v, nil
+	case '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return "", yamlError(number, "unterminated single-quoted string %s", s)
+		}
+		return strings.Replace(s[1:len(s)-1], "''", "'", -1), nil
+	case '[', '{', '&', '*', '!', '|', '>':
+		return "", yamlError(number, "unsupported value %s", s)
+	}
+	if s == "~" || s == "null" {
+		return "", nil
+	}
+	return s, nil
+}
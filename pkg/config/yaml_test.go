@@ -0,0 +1,84 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseYAML(t *testing.T) {
+	m, err := parseYAML([]byte(`---
+# Sensor options
+sensor:
+  listen_addr: ":8484"   # comment
+  use_tls: true
+  url: http://example.com/#fragment
+  empty:
+  null_value: ~
+  sources: [perf, 'docker, oci', "a\tb"]
+  none: []
+  environment:
+    - PATH
+    - 'AWS_*'
+  same_indent:
+  - a
+  - b
+global:
+  run_dir: '/var/run/it''s'
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"sensor": map[string]interface{}{
+			"listen_addr": ":8484",
+			"use_tls":     "true",
+			"url":         "http://example.com/#fragment",
+			"empty":       "",
+			"null_value":  "",
+			"sources":     []string{"perf", "docker, oci", "a\tb"},
+			"none":        []string{},
+			"environment": []string{"PATH", "AWS_*"},
+			"same_indent": []string{"a", "b"},
+		},
+		"global": map[string]interface{}{
+			"run_dir": "/var/run/it's",
+		},
+	}, m)
+
+	m, err = parseYAML([]byte("\n# nothing\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, m)
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for _, doc := range []string{
+		"- a\n",
+		"a: 1\n  b: 2\n",
+		"a:\n\tb: 1\n",
+		"a: 1\na: 2\n",
+		"a\n",
+		": 1\n",
+		"a: [b, c\n",
+		"a: {b: c}\n",
+		"a: &anchor b\n",
+		"a: \"unterminated\n",
+		"a: 'unterminated\n",
+		"a:\n  b: 1\n - c\n",
+	} {
+		_, err := parseYAML([]byte(doc))
+		assert.Error(t, err, doc)
+	}
+}
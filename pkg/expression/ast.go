@@ -123,7 +123,8 @@ func (e binaryExpr) isValid() bool {
 	switch e.op {
 	case binaryOpLogicalAnd, binaryOpLogicalOr, binaryOpEQ, binaryOpNE,
 		binaryOpLT, binaryOpLE, binaryOpGT, binaryOpGE, binaryOpLike,
-		binaryOpBitwiseAnd:
+		binaryOpBitwiseAnd, binaryOpStartsWith, binaryOpEndsWith,
+		binaryOpContains, binaryOpMatches:
 	default:
 		return false
 	}
@@ -170,6 +171,11 @@ func (e unaryExpr) String() string {
 	switch e.op {
 	case unaryOpIsNull, unaryOpIsNotNull:
 		return fmt.Sprintf("%s %s", e.x, unaryOpStrings[e.op])
+	case unaryOpLogicalNot:
+		if _, ok := e.x.(binaryExpr); ok {
+			return fmt.Sprintf("%s (%s)", unaryOpStrings[e.op], e.x)
+		}
+		return fmt.Sprintf("%s %s", unaryOpStrings[e.op], e.x)
 	}
 	panic("internal error: invalid unaryExpr")
 }
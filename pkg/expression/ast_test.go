@@ -230,7 +230,8 @@ func TestBinaryExprIsValid(t *testing.T) {
 		binaryOpLogicalAnd, binaryOpLogicalOr,
 		binaryOpEQ, binaryOpNE, binaryOpLT, binaryOpLE,
 		binaryOpGT, binaryOpGE, binaryOpLike,
-		binaryOpBitwiseAnd,
+		binaryOpBitwiseAnd, binaryOpStartsWith, binaryOpEndsWith,
+		binaryOpContains, binaryOpMatches,
 	}
 
 	for _, op := range binaryOps {
@@ -383,8 +384,9 @@ func TestBinaryExprString(t *testing.T) {
 
 func TestUnaryExprString(t *testing.T) {
 	unaryOps := map[unaryOp]string{
-		unaryOpIsNull:    "foo IS NULL",
-		unaryOpIsNotNull: "foo IS NOT NULL",
+		unaryOpIsNull:     "foo IS NULL",
+		unaryOpIsNotNull:  "foo IS NOT NULL",
+		unaryOpLogicalNot: "NOT foo",
 	}
 	for op, want := range unaryOps {
 		ue := unaryExpr{
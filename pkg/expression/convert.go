@@ -161,6 +161,8 @@ func convertNode(node *api.Expression, logical bool) (r expr) {
 		r = convertBinaryOp(node, binaryOpLogicalAnd)
 	case api.Expression_LOGICAL_OR:
 		r = convertBinaryOp(node, binaryOpLogicalOr)
+	case api.Expression_LOGICAL_NOT:
+		r = convertUnaryOp(node, unaryOpLogicalNot)
 	case api.Expression_EQ:
 		r = convertBinaryOp(node, binaryOpEQ)
 	case api.Expression_NE:
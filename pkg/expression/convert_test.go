@@ -162,6 +162,7 @@ func TestConvertUnaryOp(t *testing.T) {
 	ops := map[api.Expression_ExpressionType]unaryOp{
 		api.Expression_IS_NULL:     unaryOpIsNull,
 		api.Expression_IS_NOT_NULL: unaryOpIsNotNull,
+		api.Expression_LOGICAL_NOT: unaryOpLogicalNot,
 	}
 	for apiOp, op := range ops {
 		expr = newUnaryExpr(apiOp, operand)
@@ -17,6 +17,7 @@ package expression
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -136,22 +137,50 @@ func compareGreaterThanEqualTo(lhs, rhs interface{}) (r bool) {
 	return
 }
 
+// likeMatch reports whether s matches the LIKE pattern, in which '*' matches
+// any sequence of characters (including none) and '?' matches any single
+// character.
+func likeMatch(s, pattern string) bool {
+	// Track the position of the last '*' seen so that matching can
+	// resume after it if a later part of the pattern fails to match.
+	star, mark := -1, 0
+	i, j := 0, 0
+	for i < len(s) {
+		if j < len(pattern) && (pattern[j] == '?' || pattern[j] == s[i]) {
+			i++
+			j++
+		} else if j < len(pattern) && pattern[j] == '*' {
+			star, mark = j, i
+			j++
+		} else if star >= 0 {
+			mark++
+			i, j = mark, star+1
+		} else {
+			return false
+		}
+	}
+	for j < len(pattern) && pattern[j] == '*' {
+		j++
+	}
+	return j == len(pattern)
+}
+
 func compareLike(lhs, rhs interface{}) (r bool) {
 	switch lhs.(type) {
 	case string:
-		s := lhs.(string)
-		pattern := rhs.(string)
-		if strings.HasPrefix(pattern, "*") {
-			if strings.HasSuffix(pattern, "*") {
-				r = strings.Contains(s, pattern[1:len(pattern)-1])
-			} else {
-				r = strings.HasSuffix(s, pattern[1:])
-			}
-		} else if strings.HasSuffix(pattern, "*") {
-			r = strings.HasPrefix(s, pattern[:len(pattern)-1])
-		} else {
-			r = s == pattern
-		}
+		r = likeMatch(lhs.(string), rhs.(string))
+	case int8, int16, int32, int64, uint8, uint16, uint32, uint64, bool, float64, time.Time:
+		exprRaise(fmt.Errorf("Cannot compare %s types", reflect.TypeOf(lhs)))
+	default:
+		exprRaise(fmt.Errorf("Unknown value type %s", reflect.TypeOf(lhs)))
+	}
+	return
+}
+
+func compareStrings(lhs, rhs interface{}, f func(s, substr string) bool) (r bool) {
+	switch lhs.(type) {
+	case string:
+		r = f(lhs.(string), rhs.(string))
 	case int8, int16, int32, int64, uint8, uint16, uint32, uint64, bool, float64, time.Time:
 		exprRaise(fmt.Errorf("Cannot compare %s types", reflect.TypeOf(lhs)))
 	default:
@@ -161,9 +190,25 @@ func compareLike(lhs, rhs interface{}) (r bool) {
 }
 
 type evalContext struct {
-	types  FieldTypeMap
-	values FieldValueMap
-	stack  []interface{}
+	types   FieldTypeMap
+	values  FieldValueMap
+	regexps map[string]*regexp.Regexp
+	stack   []interface{}
+}
+
+// matchRegexp uses the patterns compiled by Parse when they're available.
+// The map is shared by concurrent evaluations, so patterns that were not
+// compiled ahead of time are compiled for each match.
+func (c *evalContext) matchRegexp(s, pattern string) bool {
+	re, ok := c.regexps[pattern]
+	if !ok {
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			exprRaise(fmt.Errorf("Invalid regular expression %q: %v",
+				pattern, err))
+		}
+	}
+	return re.MatchString(s)
 }
 
 func (c *evalContext) pushIdentifier(ident string) {
@@ -210,7 +255,8 @@ func (c *evalContext) evaluateBinaryExpr(e binaryExpr) {
 			c.evaluateNode(e.y)
 		}
 
-	case binaryOpEQ, binaryOpNE, binaryOpLT, binaryOpLE, binaryOpGT, binaryOpGE, binaryOpLike:
+	case binaryOpEQ, binaryOpNE, binaryOpLT, binaryOpLE, binaryOpGT, binaryOpGE, binaryOpLike,
+		binaryOpStartsWith, binaryOpEndsWith, binaryOpContains, binaryOpMatches:
 		c.evaluateNode(e.x)
 		c.evaluateNode(e.y)
 
@@ -244,6 +290,14 @@ func (c *evalContext) evaluateBinaryExpr(e binaryExpr) {
 				result = compareGreaterThanEqualTo(lhs, rhs)
 			case binaryOpLike:
 				result = compareLike(lhs, rhs)
+			case binaryOpStartsWith:
+				result = compareStrings(lhs, rhs, strings.HasPrefix)
+			case binaryOpEndsWith:
+				result = compareStrings(lhs, rhs, strings.HasSuffix)
+			case binaryOpContains:
+				result = compareStrings(lhs, rhs, strings.Contains)
+			case binaryOpMatches:
+				result = compareStrings(lhs, rhs, c.matchRegexp)
 			default:
 				panic("internal error: unreachable condition in evaluateBinaryExpr")
 			}
@@ -300,6 +354,20 @@ func (c *evalContext) evaluateUnaryExpr(e unaryExpr) {
 	case unaryOpIsNotNull:
 		c.evaluateNode(e.x)
 		c.stack[len(c.stack)-1] = c.stack[len(c.stack)-1] != nil
+
+	case unaryOpLogicalNot:
+		// NULL is treated as FALSE, the same as any comparison against
+		// NULL, so its negation is also FALSE.
+		c.evaluateNode(e.x)
+		switch v := c.stack[len(c.stack)-1].(type) {
+		case nil:
+			c.stack[len(c.stack)-1] = false
+		case bool:
+			c.stack[len(c.stack)-1] = !v
+		default:
+			exprRaise(fmt.Errorf("Type mismatch in logical-not: bool vs. %s",
+				reflect.TypeOf(v)))
+		}
 	default:
 		panic("internal error: unreachable condition in evaluateUnaryExpr")
 	}
@@ -171,12 +171,16 @@ func TestCompareLike(t *testing.T) {
 		"*brown fox*",
 		"the quick brown fox*",
 		"the quick brown fox jumped over the lazy dog",
+		"the * fox * the ?azy dog",
+		"*",
 	}
 	falsePatterns := []string{
 		"*the brown fox",
 		"*aloof cat*",
 		"the lazy dog*",
 		"the lazy dog jumped over the quick brown fox",
+		"the * cat *",
+		"?",
 	}
 
 	for i := range truePatterns {
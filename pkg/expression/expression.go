@@ -16,6 +16,7 @@ package expression
 
 import (
 	"reflect"
+	"regexp"
 	"time"
 
 	api "github.com/capsule8/capsule8/api/v0"
@@ -32,7 +33,8 @@ type FieldValueMap map[string]interface{}
 // Expression is a wrapper around expressions around the API. It may contain
 // internal information that is used to better support the raw representation.
 type Expression struct {
-	ast expr
+	ast     expr
+	regexps map[string]*regexp.Regexp
 }
 
 // NewExpression instantiates a new Expression instance. The expression tree
@@ -67,7 +69,9 @@ func (expr *Expression) String() string {
 // types map, but not present in the values map is considered to be NULL; all
 // comparisons against NULL will always evaluate FALSE.
 func (expr *Expression) Evaluate(types FieldTypeMap, values FieldValueMap) (interface{}, error) {
-	return evaluateExpression(expr.ast, types, values)
+	c := newEvalContext(types, values)
+	c.regexps = expr.regexps
+	return c.evaluateExpression(expr.ast)
 }
 
 // Validate ensures that an expression is properly constructed with the
@@ -265,6 +269,15 @@ func LogicalOr(lhs, rhs *api.Expression) *api.Expression {
 	return newBinaryExpr(api.Expression_LOGICAL_OR, lhs, rhs)
 }
 
+// LogicalNot creates a new LOGICAL_NOT unary Expression node. If operand is
+// nil, nil will be returned
+func LogicalNot(operand *api.Expression) *api.Expression {
+	if operand == nil {
+		return nil
+	}
+	return newUnaryExpr(api.Expression_LOGICAL_NOT, operand)
+}
+
 // BitwiseAnd creates a new BINARY_AND binary Expression node.
 func BitwiseAnd(lhs, rhs *api.Expression) *api.Expression {
 	return newBinaryExpr(api.Expression_BITWISE_AND, lhs, rhs)
@@ -260,6 +260,19 @@ func TestExpressionNodes(t *testing.T) {
 		}
 	}
 
+	e = LogicalNot(Identifier("foo"))
+	if e.GetType() != api.Expression_LOGICAL_NOT {
+		t.Error("LogicalNot failure")
+	} else {
+		e2 := e.GetUnaryOp()
+		if e2.GetType() != api.Expression_IDENTIFIER || e2.GetIdentifier() != "foo" {
+			t.Error("LogicalNot failure")
+		}
+	}
+	if LogicalNot(nil) != nil {
+		t.Error("LogicalNot failure")
+	}
+
 	// Binary ops
 
 	i := Identifier("foo")
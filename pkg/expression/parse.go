@@ -0,0 +1,562 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Parse parses the textual form of an expression, which uses a syntax similar
+// to CEL or C:
+//
+//	event.image_name.startsWith("redis") && event.credentials.uid == 0
+//
+// Identifiers may contain dots. Operators are, from lowest to highest
+// precedence, ||, &&, comparisons (== != < <= > >=), &, and !. Strings may be
+// compared with the startsWith, endsWith, and contains methods, and matched
+// against a regular expression (RE2 syntax) with matches. Comparing
+// with null tests whether a field is present. Integer literals take the type
+// of the field that they are compared with; otherwise they are int64, or
+// uint64 if they have a "u" suffix.
+//
+// The types of identifiers are taken from the types map, and the expression
+// is validated using it.
+func Parse(text string, types FieldTypeMap) (*Expression, error) {
+	p := parser{types: types}
+	ast, err := p.parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = validateTypes(ast, types); err != nil {
+		return nil, err
+	}
+	regexps, err := compileRegexps(ast)
+	if err != nil {
+		return nil, err
+	}
+	return &Expression{
+		ast:     ast,
+		regexps: regexps,
+	}, nil
+}
+
+// compileRegexps compiles the patterns of all MATCHES operators in an
+// expression, which must be string values.
+func compileRegexps(ast expr) (regexps map[string]*regexp.Regexp, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if ee, ok := r.(exprError); ok {
+				err = ee.error
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	var walk func(e expr)
+	walk = func(e expr) {
+		switch node := e.(type) {
+		case binaryExpr:
+			walk(node.x)
+			walk(node.y)
+			if node.op != binaryOpMatches {
+				return
+			}
+			v, ok := node.y.(valueExpr)
+			if !ok || !v.isString() {
+				exprRaise(errors.New("Pattern for MATCHES must be a string value"))
+			}
+			pattern := v.v.(string)
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				exprRaise(fmt.Errorf("Invalid regular expression %q: %v",
+					pattern, err))
+			}
+			if regexps == nil {
+				regexps = make(map[string]*regexp.Regexp)
+			}
+			regexps[pattern] = re
+		case unaryExpr:
+			walk(node.x)
+		}
+	}
+	walk(ast)
+	return
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenString
+	tokenInteger
+	tokenUnsigned
+	tokenDouble
+	tokenOperator
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Operators are matched longest first
+var operators = []string{
+	"&&", "||", "==", "!=", "<=", ">=",
+	"<", ">", "!", "&", "(", ")", ".", "-",
+}
+
+var stringEscapes = map[byte]byte{
+	'\\': '\\', '"': '"', '\'': '\'', 'n': '\n', 'r': '\r', 't': '\t',
+}
+
+func tokenize(text string) []token {
+	var tokens []token
+	i := 0
+	for i < len(text) {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case isIdentifierStart(c):
+			start := i
+			for i < len(text) && (isIdentifierStart(text[i]) || isDigit(text[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdentifier, text[start:i], start})
+
+		case isDigit(c):
+			start, kind := i, tokenInteger
+			if strings.HasPrefix(text[i:], "0x") || strings.HasPrefix(text[i:], "0X") {
+				i += 2
+				for i < len(text) && strings.IndexByte("0123456789abcdefABCDEF", text[i]) >= 0 {
+					i++
+				}
+			} else {
+				for i < len(text) && (isDigit(text[i]) || text[i] == '.' ||
+					text[i] == 'e' || text[i] == 'E') {
+					if !isDigit(text[i]) {
+						kind = tokenDouble
+					}
+					i++
+				}
+			}
+			value := text[start:i]
+			if kind == tokenInteger && i < len(text) && (text[i] == 'u' || text[i] == 'U') {
+				kind = tokenUnsigned
+				i++
+			}
+			tokens = append(tokens, token{kind, value, start})
+
+		case c == '"' || c == '\'':
+			start := i
+			var b strings.Builder
+			for i++; i < len(text) && text[i] != c; i++ {
+				if text[i] == '\\' {
+					if i+1 == len(text) {
+						break
+					}
+					e, ok := stringEscapes[text[i+1]]
+					if !ok {
+						exprRaise(fmt.Errorf("Invalid escape sequence at offset %d", i))
+					}
+					b.WriteByte(e)
+					i++
+					continue
+				}
+				b.WriteByte(text[i])
+			}
+			if i == len(text) {
+				exprRaise(fmt.Errorf("Unterminated string at offset %d", start))
+			}
+			i++
+			tokens = append(tokens, token{tokenString, b.String(), start})
+
+		default:
+			var op string
+			for _, o := range operators {
+				if strings.HasPrefix(text[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				exprRaise(fmt.Errorf("Unexpected character %q at offset %d", c, i))
+			}
+			tokens = append(tokens, token{tokenOperator, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(text)})
+}
+
+type parser struct {
+	types  FieldTypeMap
+	tokens []token
+	pos    int
+}
+
+// nullExpr is a placeholder for the null literal. It only appears in the
+// tree while it is being built, since comparisons with it are turned into
+// unary IS NULL and IS NOT NULL expressions.
+type nullExpr struct{}
+
+func (e nullExpr) exprNode()            {}
+func (e nullExpr) String() string       { return "NULL" }
+func (e nullExpr) KernelString() string { return "" }
+
+// untypedInteger is an integer literal that has not yet been given the type
+// of the field that it is compared with.
+type untypedInteger struct {
+	valueExpr
+	text string
+}
+
+func (p *parser) parse(text string) (ast expr, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(exprError); ok {
+				err = e.error
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	p.tokens = tokenize(text)
+	ast = p.finish(p.parseOr())
+	if t := p.peek(); t.kind != tokenEOF {
+		exprRaise(fmt.Errorf("Unexpected %q at offset %d", t.text, t.offset))
+	}
+	return
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) accept(op string) bool {
+	if t := p.peek(); t.kind == tokenOperator && t.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) {
+	if !p.accept(op) {
+		t := p.peek()
+		exprRaise(fmt.Errorf("Expected %q at offset %d", op, t.offset))
+	}
+}
+
+// finish gives any remaining untyped integer literal its default type and
+// rejects a null literal used anywhere other than in a comparison.
+func (p *parser) finish(e expr) expr {
+	switch node := e.(type) {
+	case untypedInteger:
+		return node.valueExpr
+	case nullExpr:
+		exprRaise(fmt.Errorf("null may only be compared with == or !="))
+	}
+	return e
+}
+
+func (p *parser) parseOr() expr {
+	e := p.parseAnd()
+	for p.accept("||") {
+		e = binaryExpr{op: binaryOpLogicalOr, x: p.finish(e), y: p.finish(p.parseAnd())}
+	}
+	return e
+}
+
+func (p *parser) parseAnd() expr {
+	e := p.parseComparison()
+	for p.accept("&&") {
+		e = binaryExpr{op: binaryOpLogicalAnd, x: p.finish(e), y: p.finish(p.parseComparison())}
+	}
+	return e
+}
+
+var comparisonOps = map[string]binaryOp{
+	"==": binaryOpEQ,
+	"!=": binaryOpNE,
+	"<":  binaryOpLT,
+	"<=": binaryOpLE,
+	">":  binaryOpGT,
+	">=": binaryOpGE,
+}
+
+func (p *parser) parseComparison() expr {
+	x := p.parseBitwiseAnd()
+	t := p.peek()
+	op, ok := comparisonOps[t.text]
+	if t.kind != tokenOperator || !ok {
+		return x
+	}
+	p.next()
+	y := p.parseBitwiseAnd()
+
+	_, xNull := x.(nullExpr)
+	_, yNull := y.(nullExpr)
+	if xNull || yNull {
+		operand := x
+		if xNull {
+			operand = y
+		}
+		switch op {
+		case binaryOpEQ:
+			return unaryExpr{op: unaryOpIsNull, x: p.finish(operand)}
+		case binaryOpNE:
+			return unaryExpr{op: unaryOpIsNotNull, x: p.finish(operand)}
+		}
+		exprRaise(fmt.Errorf("null may only be compared with == or != (offset %d)",
+			t.offset))
+	}
+
+	x, y = p.typeIntegers(x, y)
+	return binaryExpr{op: op, x: x, y: y}
+}
+
+func (p *parser) parseBitwiseAnd() expr {
+	e := p.parseUnary()
+	for p.accept("&") {
+		x, y := p.typeIntegers(e, p.parseUnary())
+		e = binaryExpr{op: binaryOpBitwiseAnd, x: x, y: y}
+	}
+	return e
+}
+
+func (p *parser) parseUnary() expr {
+	if p.accept("!") {
+		return unaryExpr{op: unaryOpLogicalNot, x: p.finish(p.parseUnary())}
+	}
+	return p.parsePostfix()
+}
+
+var methodOps = map[string]binaryOp{
+	"startsWith": binaryOpStartsWith,
+	"endsWith":   binaryOpEndsWith,
+	"contains":   binaryOpContains,
+	"matches":    binaryOpMatches,
+}
+
+func (p *parser) parsePostfix() expr {
+	e := p.parsePrimary()
+	for p.accept(".") {
+		t := p.next()
+		if t.kind != tokenIdentifier {
+			exprRaise(fmt.Errorf("Expected a name at offset %d", t.offset))
+		}
+		if !p.accept("(") {
+			ident, ok := e.(identExpr)
+			if !ok {
+				exprRaise(fmt.Errorf("Unexpected field %q at offset %d",
+					t.text, t.offset))
+			}
+			e = identExpr{name: ident.name + "." + t.text}
+			continue
+		}
+
+		op, ok := methodOps[t.text]
+		if !ok {
+			exprRaise(fmt.Errorf("Unknown method %q at offset %d",
+				t.text, t.offset))
+		}
+		arg := p.finish(p.parseOr())
+		p.expect(")")
+		e = binaryExpr{op: op, x: p.finish(e), y: arg}
+	}
+	return e
+}
+
+func (p *parser) parsePrimary() expr {
+	t := p.next()
+	switch t.kind {
+	case tokenIdentifier:
+		switch t.text {
+		case "true":
+			return valueExpr{v: true}
+		case "false":
+			return valueExpr{v: false}
+		case "null":
+			return nullExpr{}
+		}
+		return identExpr{name: t.text}
+
+	case tokenString:
+		return valueExpr{v: t.text}
+
+	case tokenInteger, tokenUnsigned, tokenDouble:
+		return p.parseNumber(t.kind, t.text, t.offset)
+
+	case tokenOperator:
+		switch t.text {
+		case "(":
+			e := p.parseOr()
+			p.expect(")")
+			return e
+		case "-":
+			if n := p.next(); n.kind == tokenInteger || n.kind == tokenDouble {
+				return p.parseNumber(n.kind, "-"+n.text, t.offset)
+			}
+		}
+	}
+	if t.kind == tokenEOF {
+		exprRaise(fmt.Errorf("Unexpected end of expression"))
+	}
+	exprRaise(fmt.Errorf("Unexpected %q at offset %d", t.text, t.offset))
+	return nil
+}
+
+func (p *parser) parseNumber(kind tokenKind, text string, offset int) expr {
+	switch kind {
+	case tokenInteger:
+		if v, err := strconv.ParseInt(text, 0, 64); err == nil {
+			return untypedInteger{valueExpr: valueExpr{v: v}, text: text}
+		}
+		// Allow values that only fit in an unsigned integer
+		if v, err := strconv.ParseUint(text, 0, 64); err == nil {
+			return untypedInteger{valueExpr: valueExpr{v: v}, text: text}
+		}
+
+	case tokenUnsigned:
+		if v, err := strconv.ParseUint(text, 0, 64); err == nil {
+			return valueExpr{v: v}
+		}
+
+	case tokenDouble:
+		if v, err := strconv.ParseFloat(text, 64); err == nil {
+			return valueExpr{v: v}
+		}
+	}
+	exprRaise(fmt.Errorf("Invalid number %q at offset %d", text, offset))
+	return nil
+}
+
+// typeIntegers gives an untyped integer literal compared with an expression
+// of a known integer type that type.
+func (p *parser) typeIntegers(x, y expr) (expr, expr) {
+	if ix, ok := x.(untypedInteger); ok {
+		if iy, ok := y.(untypedInteger); ok {
+			return ix.valueExpr, iy.valueExpr
+		}
+		y = p.finish(y)
+		return p.typeInteger(ix, y), y
+	}
+	x = p.finish(x)
+	if iy, ok := y.(untypedInteger); ok {
+		return x, p.typeInteger(iy, x)
+	}
+	return x, p.finish(y)
+}
+
+func (p *parser) typeInteger(i untypedInteger, other expr) expr {
+	t := validateExprTypes(other, p.types)
+	if t == ValueTypeDouble {
+		v, _ := strconv.ParseFloat(i.text, 64)
+		return valueExpr{v: v}
+	}
+	if !t.IsInteger() {
+		return i.valueExpr
+	}
+
+	var (
+		v   interface{}
+		err error
+	)
+	switch t {
+	case ValueTypeSignedInt8, ValueTypeSignedInt16, ValueTypeSignedInt32,
+		ValueTypeSignedInt64:
+		var n int64
+		if n, err = strconv.ParseInt(i.text, 0, 64); err == nil {
+			v, err = signedValue(n, t)
+		}
+	default:
+		var n uint64
+		if n, err = strconv.ParseUint(i.text, 0, 64); err == nil {
+			v, err = unsignedValue(n, t)
+		}
+	}
+	if err != nil {
+		exprRaise(fmt.Errorf("Integer %s is out of range for %s",
+			i.text, ValueTypeStrings[t]))
+	}
+	return valueExpr{v: v}
+}
+
+func signedValue(n int64, t ValueType) (interface{}, error) {
+	switch t {
+	case ValueTypeSignedInt8:
+		if n >= math.MinInt8 && n <= math.MaxInt8 {
+			return int8(n), nil
+		}
+	case ValueTypeSignedInt16:
+		if n >= math.MinInt16 && n <= math.MaxInt16 {
+			return int16(n), nil
+		}
+	case ValueTypeSignedInt32:
+		if n >= math.MinInt32 && n <= math.MaxInt32 {
+			return int32(n), nil
+		}
+	case ValueTypeSignedInt64:
+		return n, nil
+	}
+	return nil, strconv.ErrRange
+}
+
+func unsignedValue(n uint64, t ValueType) (interface{}, error) {
+	switch t {
+	case ValueTypeUnsignedInt8:
+		if n <= math.MaxUint8 {
+			return uint8(n), nil
+		}
+	case ValueTypeUnsignedInt16:
+		if n <= math.MaxUint16 {
+			return uint16(n), nil
+		}
+	case ValueTypeUnsignedInt32:
+		if n <= math.MaxUint32 {
+			return uint32(n), nil
+		}
+	case ValueTypeUnsignedInt64:
+		return n, nil
+	}
+	return nil, strconv.ErrRange
+}
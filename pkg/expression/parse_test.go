@@ -0,0 +1,221 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import "testing"
+
+var parseTestTypes = FieldTypeMap{
+	"event.image_name":      ValueTypeString,
+	"event.credentials.uid": ValueTypeUnsignedInt32,
+	"event.process.pid":     ValueTypeSignedInt32,
+	"event.syscall.ret":     ValueTypeSignedInt64,
+	"event.flags":           ValueTypeUnsignedInt16,
+	"event.load":            ValueTypeDouble,
+	"event.exited":          ValueTypeBool,
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]string{
+		`event.image_name.startsWith("redis") && event.credentials.uid == 0`: `event.image_name STARTS WITH "redis" AND event.credentials.uid = 0`,
+
+		`event.image_name.endsWith('-alpine') || event.image_name.contains("\"x\"")`: `event.image_name ENDS WITH "-alpine" OR event.image_name CONTAINS "\"x\""`,
+
+		`a || b && c`:              `a OR (b AND c)`,
+		`(event.exited || false)`:  `event.exited OR FALSE`,
+		`!event.exited`:            `NOT event.exited`,
+		`!(event.process.pid > 1)`: `NOT (event.process.pid > 1)`,
+		`event.syscall.ret < -1`:   `event.syscall.ret < -1`,
+		`event.flags & 0x10 != 0`:  `event.flags & 16 != 0`,
+		`event.load >= 1`:          `event.load >= 1.000000`,
+		`event.load < 0.5`:         `event.load < 0.500000`,
+		`event.image_name == null`: `event.image_name IS NULL`,
+		`null != event.image_name`: `event.image_name IS NOT NULL`,
+
+		`event.image_name.matches("^redis:[0-9]+$")`: `event.image_name MATCHES "^redis:[0-9]+$"`,
+	}
+	types := FieldTypeMap{
+		"a": ValueTypeBool,
+		"b": ValueTypeBool,
+		"c": ValueTypeBool,
+	}
+	for k, v := range parseTestTypes {
+		types[k] = v
+	}
+
+	for text, expected := range tests {
+		expr, err := Parse(text, types)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", text, err)
+			continue
+		}
+		if got := expr.String(); got != expected {
+			t.Errorf("Parse(%q): expected %q; got %q", text, expected, got)
+		}
+	}
+}
+
+func TestParseIntegerTypes(t *testing.T) {
+	tests := map[string]interface{}{
+		`event.credentials.uid == 1000`: uint32(1000),
+		`event.process.pid == 1`:        int32(1),
+		`1 == event.process.pid`:        int32(1),
+		`event.flags & 0xffff`:          uint16(0xffff),
+		`event.syscall.ret == -5`:       int64(-5),
+	}
+	for text, expected := range tests {
+		expr, err := Parse(text, parseTestTypes)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", text, err)
+			continue
+		}
+		be := expr.ast.(binaryExpr)
+		v, ok := be.y.(valueExpr)
+		if !ok {
+			v = be.x.(valueExpr)
+		}
+		if v.v != expected {
+			t.Errorf("Parse(%q): expected %#v; got %#v", text, expected, v.v)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		``,
+		`event.image_name ==`,
+		`event.image_name == "unterminated`,
+		`event.image_name == "bad \q escape"`,
+		`event.image_name # 1`,
+		`(event.exited`,
+		`event.exited)`,
+		`event.missing == 1`,
+		`event.image_name == 1`,
+		`event.image_name.search("x")`,
+		`event.image_name.startsWith(1)`,
+		`event.credentials.uid.startsWith("1")`,
+		`event.credentials.uid == -1`,
+		`event.flags == 65536`,
+		`event.process.pid == 99999999999`,
+		`event.image_name < null`,
+		`null`,
+		`!event.image_name`,
+		`event.exited && event.image_name`,
+		`("a").b == 1`,
+		`event. == 1`,
+		`- == 1`,
+		`1 == 99999999999999999999`,
+		`event.image_name.matches("(unclosed")`,
+		`event.image_name.matches(event.image_name)`,
+		`event.credentials.uid.matches("0")`,
+	}
+	for _, text := range tests {
+		if _, err := Parse(text, parseTestTypes); err == nil {
+			t.Errorf("Parse(%q) did not fail as expected", text)
+		}
+	}
+}
+
+func TestParseEvaluate(t *testing.T) {
+	expr, err := Parse(`event.image_name.startsWith("redis") && `+
+		`!(event.credentials.uid != 0)`, parseTestTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		values FieldValueMap
+		result bool
+	}{
+		{FieldValueMap{"event.image_name": "redis:4", "event.credentials.uid": uint32(0)}, true},
+		{FieldValueMap{"event.image_name": "redis:4", "event.credentials.uid": uint32(1)}, false},
+		{FieldValueMap{"event.image_name": "nginx", "event.credentials.uid": uint32(0)}, false},
+		{FieldValueMap{"event.image_name": "redis:4"}, true},
+		{FieldValueMap{}, false},
+	}
+	for _, tc := range tests {
+		r, err := expr.Evaluate(parseTestTypes, tc.values)
+		if err != nil {
+			t.Errorf("Evaluate(%v) failed: %v", tc.values, err)
+		} else if r != tc.result {
+			t.Errorf("Evaluate(%v): expected %v; got %v", tc.values, tc.result, r)
+		}
+	}
+
+	// NOT of NULL is FALSE
+	expr, err = Parse(`!event.exited`, parseTestTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, err := expr.Evaluate(parseTestTypes, FieldValueMap{}); err != nil || r != false {
+		t.Errorf("Evaluate of NOT NULL: expected false; got %v, %v", r, err)
+	}
+
+	if err = expr.ValidateKernelFilter(); err == nil {
+		t.Error("NOT expression passed kernel filter validation")
+	}
+	expr, err = Parse(`event.image_name.endsWith("x")`, parseTestTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = expr.ValidateKernelFilter(); err == nil {
+		t.Error("endsWith expression passed kernel filter validation")
+	}
+}
+
+func TestParseMatches(t *testing.T) {
+	expr, err := Parse(`event.image_name.matches("^(redis|memcached):4")`,
+		parseTestTypes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expr.regexps) != 1 {
+		t.Errorf("Expected 1 compiled pattern; got %d", len(expr.regexps))
+	}
+
+	tests := map[string]bool{
+		"redis:4":       true,
+		"memcached:4.1": true,
+		"redis:3":       false,
+		"my/redis:4":    false,
+	}
+	for name, expected := range tests {
+		values := FieldValueMap{"event.image_name": name}
+		r, err := expr.Evaluate(parseTestTypes, values)
+		if err != nil {
+			t.Errorf("Evaluate(%q) failed: %v", name, err)
+		} else if r != expected {
+			t.Errorf("Evaluate(%q): expected %v; got %v", name, expected, r)
+		}
+	}
+
+	if err = expr.ValidateKernelFilter(); err == nil {
+		t.Error("matches expression passed kernel filter validation")
+	}
+
+	// Patterns are compiled when they were not compiled by Parse
+	be := binaryExpr{
+		op: binaryOpMatches,
+		x:  identExpr{name: "event.image_name"},
+		y:  valueExpr{v: "[0-9]$"},
+	}
+	values := FieldValueMap{"event.image_name": "redis:4"}
+	if r, err := evaluateExpression(be, parseTestTypes, values); err != nil || r != true {
+		t.Errorf("Evaluate of uncompiled pattern: expected true; got %v, %v", r, err)
+	}
+	be.y = valueExpr{v: "[0-9"}
+	if _, err := evaluateExpression(be, parseTestTypes, values); err == nil {
+		t.Error("Evaluate of invalid pattern did not fail as expected")
+	}
+}
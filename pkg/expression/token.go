@@ -150,6 +150,13 @@ const (
 	binaryOpLike
 
 	binaryOpBitwiseAnd
+
+	// These are only produced by Parse and cannot be used in kernel
+	// filters.
+	binaryOpStartsWith
+	binaryOpEndsWith
+	binaryOpContains
+	binaryOpMatches
 )
 
 var binaryOpStrings = map[binaryOp]string{
@@ -163,6 +170,10 @@ var binaryOpStrings = map[binaryOp]string{
 	binaryOpGE:         ">=",
 	binaryOpLike:       "LIKE",
 	binaryOpBitwiseAnd: "&",
+	binaryOpStartsWith: "STARTS WITH",
+	binaryOpEndsWith:   "ENDS WITH",
+	binaryOpContains:   "CONTAINS",
+	binaryOpMatches:    "MATCHES",
 }
 
 var binaryOpKernelStrings = map[binaryOp]string{
@@ -185,9 +196,13 @@ const (
 
 	unaryOpIsNull
 	unaryOpIsNotNull
+
+	// This cannot be used in kernel filters.
+	unaryOpLogicalNot
 )
 
 var unaryOpStrings = map[unaryOp]string{
-	unaryOpIsNull:    "IS NULL",
-	unaryOpIsNotNull: "IS NOT NULL",
+	unaryOpIsNull:     "IS NULL",
+	unaryOpIsNotNull:  "IS NOT NULL",
+	unaryOpLogicalNot: "NOT",
 }
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"unicode"
 )
 
@@ -51,6 +52,15 @@ func validateBitwiseAnd(e binaryExpr) {
 	}
 }
 
+// isKernelLikePattern reports whether a LIKE pattern can be evaluated by all
+// supported kernels, which only handle a '*' at the start and/or end of the
+// pattern.
+func isKernelLikePattern(pattern string) bool {
+	pattern = strings.TrimPrefix(pattern, "*")
+	pattern = strings.TrimSuffix(pattern, "*")
+	return !strings.ContainsAny(pattern, "*?")
+}
+
 func validateKernelFilterNode(e expr) {
 	switch node := e.(type) {
 	case identExpr:
@@ -132,9 +142,16 @@ func validateKernelFilterNode(e expr) {
 			}
 			if v, ok := node.y.(valueExpr); !ok || !v.isString() {
 				exprRaise(errors.New("Comparison rhs must be a string value"))
+			} else if !isKernelLikePattern(v.v.(string)) {
+				exprRaise(errors.New("LIKE pattern has wildcards not supported by the kernel"))
 			}
 			validateKernelFilterNode(node.x)
 			validateKernelFilterNode(node.y)
+
+		case binaryOpStartsWith, binaryOpEndsWith, binaryOpContains,
+			binaryOpMatches:
+			exprRaise(fmt.Errorf("%s is not supported by the kernel",
+				binaryOpStrings[node.op]))
 		}
 
 	default:
@@ -207,11 +224,12 @@ func validateBinaryExprTypes(e binaryExpr, types FieldTypeMap) (r ValueType) {
 		}
 		r = ValueTypeBool
 
-	case binaryOpLike:
+	case binaryOpLike, binaryOpStartsWith, binaryOpEndsWith, binaryOpContains,
+		binaryOpMatches:
 		lhs := validateExprTypes(e.x, types)
 		if !lhs.IsString() {
-			exprRaise(fmt.Errorf("Type for LIKE must be STRING; got %s",
-				ValueTypeStrings[lhs]))
+			exprRaise(fmt.Errorf("Type for %s must be STRING; got %s",
+				binaryOpStrings[e.op], ValueTypeStrings[lhs]))
 		}
 		rhs := validateExprTypes(e.y, types)
 		if lhs != rhs {
@@ -243,6 +261,12 @@ func validateUnaryExprTypes(e unaryExpr, types FieldTypeMap) (r ValueType) {
 	case unaryOpIsNull, unaryOpIsNotNull:
 		validateExprTypes(e.x, types)
 		r = ValueTypeBool
+	case unaryOpLogicalNot:
+		if t := validateExprTypes(e.x, types); t != ValueTypeBool {
+			exprRaise(fmt.Errorf("Operand of NOT must be type BOOL; got %s",
+				ValueTypeStrings[t]))
+		}
+		r = ValueTypeBool
 	default:
 		exprRaise(fmt.Errorf("Illegal unary op type %d", e.op))
 	}
@@ -141,6 +141,20 @@ func TestValidateKernelFilter(t *testing.T) {
 		t.Errorf("validateKernelFilterTree failure for %s",
 			binaryOpStrings[op])
 	}
+	for _, pattern := range []string{"*string", "string*", "*string*"} {
+		be.y = valueExpr{v: pattern}
+		if err := validateKernelFilterTree(be); err != nil {
+			t.Errorf("validateKernelFilterTree failure for %s %q: %v",
+				binaryOpStrings[op], pattern, err)
+		}
+	}
+	for _, pattern := range []string{"str*ing", "s?ring", "*st*ring"} {
+		be.y = valueExpr{v: pattern}
+		if validateKernelFilterTree(be) == nil {
+			t.Errorf("validateKernelFilterTree failure for %s %q",
+				binaryOpStrings[op], pattern)
+		}
+	}
 
 	be = binaryExpr{
 		op: binaryOpNE,
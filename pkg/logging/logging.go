@@ -0,0 +1,84 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging adds key/value fields and runtime changes of verbosity
+// to the glog logging used throughout the Sensor.
+package logging
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	// Make sure that glog's flags are registered
+	_ "github.com/golang/glog"
+)
+
+// Fields formats alternating keys and values as "key=value" pairs separated
+// by spaces, so that log messages can be parsed by machines as well as read
+// by people. Values that are empty or contain spaces, quotes, or '=' are
+// quoted. A key without a value is paired with "MISSING".
+//
+//	glog.Warningf("Cannot update container: %s",
+//		logging.Fields("container_id", id, "field", name))
+func Fields(keysAndValues ...interface{}) string {
+	var b bytes.Buffer
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprint(&b, keysAndValues[i])
+		b.WriteByte('=')
+		if i+1 == len(keysAndValues) {
+			b.WriteString("MISSING")
+			break
+		}
+		v := fmt.Sprint(keysAndValues[i+1])
+		if len(v) == 0 || strings.ContainsAny(v, " \t\n\"=") {
+			v = strconv.Quote(v)
+		}
+		b.WriteString(v)
+	}
+	return b.String()
+}
+
+// Verbosity returns the level of the informational messages that are logged,
+// which is set by the -v flag.
+func Verbosity() int {
+	v, _ := strconv.Atoi(flag.Lookup("v").Value.String())
+	return v
+}
+
+// SetVerbosity changes the level of the informational messages that are
+// logged.
+func SetVerbosity(level int) error {
+	if level < 0 {
+		return fmt.Errorf("Invalid log verbosity %d", level)
+	}
+	return flag.Set("v", strconv.Itoa(level))
+}
+
+// VModule returns the levels of individual source files that override the
+// verbosity, which are set by the -vmodule flag.
+func VModule() string {
+	return flag.Lookup("vmodule").Value.String()
+}
+
+// SetVModule changes the levels of individual source files that override the
+// verbosity, e.g. "telemetry=2,process*=3".
+func SetVModule(spec string) error {
+	return flag.Set("vmodule", spec)
+}
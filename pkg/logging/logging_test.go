@@ -0,0 +1,55 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/glog"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, "", Fields())
+	assert.Equal(t, "pid=1234 comm=bash", Fields("pid", 1234, "comm", "bash"))
+	assert.Equal(t, `error="no such file" path=""`,
+		Fields("error", errors.New("no such file"), "path", ""))
+	assert.Equal(t, `expr="a=b" quote="\"x\""`,
+		Fields("expr", "a=b", "quote", `"x"`))
+	assert.Equal(t, "id=abc state=MISSING", Fields("id", "abc", "state"))
+}
+
+func TestSetVerbosity(t *testing.T) {
+	old := Verbosity()
+	defer SetVerbosity(old)
+
+	assert.NoError(t, SetVerbosity(3))
+	assert.Equal(t, 3, Verbosity())
+	assert.True(t, bool(glog.V(3)))
+	assert.False(t, bool(glog.V(4)))
+
+	assert.Error(t, SetVerbosity(-1))
+	assert.Equal(t, 3, Verbosity())
+
+	oldVModule := VModule()
+	defer SetVModule(oldVModule)
+
+	assert.NoError(t, SetVModule("logging_test=5"))
+	assert.Equal(t, "logging_test=5", VModule())
+	assert.True(t, bool(glog.V(5)))
+	assert.Error(t, SetVModule("logging_test"))
+}
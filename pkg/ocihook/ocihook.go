@@ -0,0 +1,92 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ocihook defines the protocol used by the OCI runtime hook to notify
+// the sensor of container lifecycle changes as they happen.
+package ocihook
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"time"
+)
+
+const (
+	// HookPrestart is the OCI hook run after the container's init
+	// process is created, but before the user process is started.
+	HookPrestart = "prestart"
+
+	// HookPoststart is the OCI hook run after the user process is
+	// started.
+	HookPoststart = "poststart"
+
+	// HookPoststop is the OCI hook run after the container is deleted.
+	HookPoststop = "poststop"
+)
+
+// State is the container state passed to OCI hooks on stdin, as defined by
+// the OCI runtime specification.
+type State struct {
+	Version     string            `json:"ociVersion"`
+	ID          string            `json:"id"`
+	Status      string            `json:"status"`
+	Pid         int               `json:"pid,omitempty"`
+	Bundle      string            `json:"bundle"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Message is sent by the hook to the sensor for each hook invocation.
+type Message struct {
+	// The name of the hook that was run (HookPrestart, etc.)
+	Hook string `json:"hook"`
+
+	// The container state passed to the hook by the runtime
+	State State `json:"state"`
+
+	// The contents of the bundle's config.json, if it could be read
+	Config string `json:"config,omitempty"`
+}
+
+// ReadState reads the container state passed to an OCI hook.
+func ReadState(r io.Reader) (State, error) {
+	var state State
+	err := json.NewDecoder(r).Decode(&state)
+	return state, err
+}
+
+// Send delivers a message to the sensor listening on the specified unix
+// socket. The timeout applies to the whole exchange so that a hook never
+// blocks the container runtime for long.
+func Send(socketPath string, msg *Message, timeout time.Duration) error {
+	conn, err := net.DialTimeout("unix", socketPath, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	return json.NewEncoder(conn).Encode(msg)
+}
+
+// Receive reads a message sent by Send.
+func Receive(r io.Reader) (*Message, error) {
+	msg := &Message{}
+	if err := json.NewDecoder(r).Decode(msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ocihook
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadState(t *testing.T) {
+	stateJSON := `{"ociVersion":"1.0.0","id":"abc123","status":"created","pid":1234,"bundle":"/run/bundle","annotations":{"k":"v"}}`
+	state, err := ReadState(strings.NewReader(stateJSON))
+	require.NoError(t, err)
+
+	expState := State{
+		Version:     "1.0.0",
+		ID:          "abc123",
+		Status:      "created",
+		Pid:         1234,
+		Bundle:      "/run/bundle",
+		Annotations: map[string]string{"k": "v"},
+	}
+	assert.Equal(t, expState, state)
+
+	_, err = ReadState(strings.NewReader("not json"))
+	assert.Error(t, err)
+}
+
+func TestSendReceive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ocihook_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socketPath := filepath.Join(dir, "hook.sock")
+
+	// Nothing is listening yet
+	msg := &Message{
+		Hook: HookPoststart,
+		State: State{
+			ID:     "abc123",
+			Status: "running",
+			Pid:    1234,
+		},
+		Config: `{"ociVersion":"1.0.0"}`,
+	}
+	err = Send(socketPath, msg, time.Second)
+	assert.Error(t, err)
+
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer l.Close()
+
+	received := make(chan *Message)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			close(received)
+			return
+		}
+		defer conn.Close()
+		m, _ := Receive(conn)
+		received <- m
+	}()
+
+	err = Send(socketPath, msg, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, msg, <-received)
+}
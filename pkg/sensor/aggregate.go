@@ -0,0 +1,151 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// Fields that differ for every event and are not compared when an
+// AggregateModifier has no keys
+var aggregateIgnoredFields = map[string]bool{
+	eventExpressionRoot + ".id":                     true,
+	eventExpressionRoot + ".sensor_sequence_number": true,
+	eventExpressionRoot + ".sensor_monotime_nanos":  true,
+}
+
+type aggregateEntry struct {
+	key       string
+	event     *api.TelemetryEvent
+	aggregate api.EventAggregate
+	windowEnd time.Time
+}
+
+// eventAggregator collapses identical translated events into one for each
+// window. Every window has the same length, so windows end in the order that
+// they begin and pending aggregates are kept in a queue in that order.
+type eventAggregator struct {
+	keys     []string
+	interval time.Duration
+
+	pending map[string]*aggregateEntry
+	queue   []*aggregateEntry
+}
+
+func newEventAggregator(m *api.AggregateModifier) (*eventAggregator, error) {
+	types := getEventExpressionTypes()
+	for _, key := range m.Keys {
+		if _, ok := types[key]; !ok {
+			return nil, fmt.Errorf("key %q is not an event field", key)
+		}
+	}
+	interval, err := throttleInterval(m.Interval, m.IntervalType)
+	if err != nil {
+		return nil, err
+	}
+	return &eventAggregator{
+		keys:     m.Keys,
+		interval: interval,
+		pending:  make(map[string]*aggregateEntry),
+	}, nil
+}
+
+func (a *eventAggregator) eventKey(event *api.TelemetryEvent) string {
+	values := eventExpressionValues(event)
+	var parts []string
+	if len(a.keys) > 0 {
+		parts = make([]string, len(a.keys))
+		for i, key := range a.keys {
+			if v, ok := values[key]; ok {
+				parts[i] = fmt.Sprint(v)
+			}
+		}
+	} else {
+		parts = make([]string, 0, len(values))
+		for name, v := range values {
+			if !aggregateIgnoredFields[name] {
+				parts = append(parts, fmt.Sprintf("%s=%v", name, v))
+			}
+		}
+		sort.Strings(parts)
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// add adds an event to the aggregate for its key, beginning a new window for
+// the key if there is none. The aggregator takes ownership of the event, which
+// is released if it is counted in an existing aggregate.
+func (a *eventAggregator) add(event *api.TelemetryEvent, now time.Time) {
+	key := a.eventKey(event)
+	if e, ok := a.pending[key]; ok {
+		e.aggregate.Count++
+		e.aggregate.LastMonotimeNanos = event.SensorMonotimeNanos
+		releaseTelemetryEvent(event)
+		return
+	}
+
+	e := &aggregateEntry{
+		key:   key,
+		event: event,
+		aggregate: api.EventAggregate{
+			Count:              1,
+			FirstMonotimeNanos: event.SensorMonotimeNanos,
+			LastMonotimeNanos:  event.SensorMonotimeNanos,
+		},
+		windowEnd: now.Add(a.interval),
+	}
+	a.pending[key] = e
+	a.queue = append(a.queue, e)
+}
+
+// nextWindowEnd returns the time at which the oldest pending window ends.
+func (a *eventAggregator) nextWindowEnd() (time.Time, bool) {
+	if len(a.queue) == 0 {
+		return time.Time{}, false
+	}
+	return a.queue[0].windowEnd, true
+}
+
+// flush removes and returns all of the pending aggregates, whether or not
+// their windows have ended.
+func (a *eventAggregator) flush() []*api.ReceivedTelemetryEvent {
+	if len(a.queue) == 0 {
+		return nil
+	}
+	return a.expire(a.queue[len(a.queue)-1].windowEnd)
+}
+
+// expire removes and returns the aggregates whose windows have ended.
+func (a *eventAggregator) expire(now time.Time) []*api.ReceivedTelemetryEvent {
+	var events []*api.ReceivedTelemetryEvent
+	n := 0
+	for ; n < len(a.queue) && !now.Before(a.queue[n].windowEnd); n++ {
+		e := a.queue[n]
+		delete(a.pending, e.key)
+		aggregate := e.aggregate
+		events = append(events, &api.ReceivedTelemetryEvent{
+			Event:     e.event,
+			Aggregate: &aggregate,
+		})
+		a.queue[n] = nil
+	}
+	a.queue = a.queue[n:]
+	return events
+}
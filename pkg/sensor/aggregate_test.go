@@ -0,0 +1,135 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAggregateTestEvent(id string, monotime int64, filename string) *api.TelemetryEvent {
+	return &api.TelemetryEvent{
+		Id:                  id,
+		SensorMonotimeNanos: monotime,
+		ContainerId:         "c1",
+		Event: &api.TelemetryEvent_File{
+			File: &api.FileEvent{
+				Type:     api.FileEventType_FILE_EVENT_TYPE_OPEN,
+				Filename: filename,
+			},
+		},
+	}
+}
+
+func TestNewEventAggregator(t *testing.T) {
+	bad := []*api.AggregateModifier{
+		&api.AggregateModifier{
+			Interval: 0,
+		},
+		&api.AggregateModifier{
+			Interval:     1,
+			IntervalType: 8888,
+		},
+		&api.AggregateModifier{
+			Interval: 1,
+			Keys:     []string{"event.no_such_field"},
+		},
+	}
+	for _, m := range bad {
+		_, err := newEventAggregator(m)
+		assert.Error(t, err, "%+v", m)
+	}
+}
+
+func TestEventAggregator(t *testing.T) {
+	a, err := newEventAggregator(&api.AggregateModifier{
+		Interval:     1,
+		IntervalType: api.ThrottleModifier_SECOND,
+	})
+	require.NoError(t, err)
+
+	_, ok := a.nextWindowEnd()
+	assert.False(t, ok)
+
+	now := time.Now()
+	a.add(newAggregateTestEvent("1", 100, "/etc/passwd"), now)
+	a.add(newAggregateTestEvent("2", 200, "/etc/shadow"), now.Add(time.Millisecond))
+	duplicate := newAggregateTestEvent("3", 300, "/etc/passwd")
+	a.add(duplicate, now.Add(2*time.Millisecond))
+	a.add(newAggregateTestEvent("4", 400, "/etc/passwd"), now.Add(3*time.Millisecond))
+
+	// Events counted in an existing aggregate are released
+	assert.Equal(t, api.TelemetryEvent{}, *duplicate)
+
+	end, ok := a.nextWindowEnd()
+	require.True(t, ok)
+	assert.Equal(t, now.Add(time.Second), end)
+	assert.Len(t, a.expire(now), 0)
+
+	events := a.expire(end)
+	require.Len(t, events, 1)
+	assert.Equal(t, "1", events[0].Event.Id)
+	assert.Equal(t, api.EventAggregate{
+		Count:              3,
+		FirstMonotimeNanos: 100,
+		LastMonotimeNanos:  400,
+	}, *events[0].Aggregate)
+
+	// An event for an expired aggregate begins a new window
+	a.add(newAggregateTestEvent("5", 500, "/etc/passwd"), end)
+
+	events = a.expire(end.Add(time.Second))
+	require.Len(t, events, 2)
+	assert.Equal(t, "2", events[0].Event.Id)
+	assert.Equal(t, uint64(1), events[0].Aggregate.Count)
+	assert.Equal(t, "5", events[1].Event.Id)
+	assert.Equal(t, uint64(1), events[1].Aggregate.Count)
+
+	_, ok = a.nextWindowEnd()
+	assert.False(t, ok)
+	assert.Len(t, a.pending, 0)
+
+	// Flushing sends aggregates before their windows end
+	assert.Len(t, a.flush(), 0)
+	a.add(newAggregateTestEvent("6", 600, "/etc/passwd"), end)
+	a.add(newAggregateTestEvent("7", 700, "/etc/shadow"), end.Add(time.Millisecond))
+	events = a.flush()
+	require.Len(t, events, 2)
+	assert.Equal(t, "6", events[0].Event.Id)
+	assert.Equal(t, "7", events[1].Event.Id)
+	assert.Len(t, a.pending, 0)
+}
+
+func TestEventAggregatorKeys(t *testing.T) {
+	a, err := newEventAggregator(&api.AggregateModifier{
+		Interval:     1,
+		IntervalType: api.ThrottleModifier_SECOND,
+		Keys:         []string{"event.container_id"},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	a.add(newAggregateTestEvent("1", 100, "/etc/passwd"), now)
+	a.add(newAggregateTestEvent("2", 200, "/etc/shadow"), now)
+
+	events := a.expire(now.Add(time.Second))
+	require.Len(t, events, 1)
+	assert.Equal(t, uint64(2), events[0].Aggregate.Count)
+}
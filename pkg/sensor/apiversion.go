@@ -0,0 +1,144 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The newest version of the Telemetry API that the sensor supports
+const maxAPIVersion = api.APIVersion_API_VERSION_2
+
+// The newest event type of each event family in API version 1. Types added
+// after these are only available in version 2.
+const (
+	v1MaxProcessEventType   = api.ProcessEventType_PROCESS_EVENT_TYPE_UPDATE
+	v1MaxFileEventType      = api.FileEventType_FILE_EVENT_TYPE_OPEN
+	v1MaxNetworkEventType   = api.NetworkEventType_NETWORK_EVENT_TYPE_RECVFROM_RESULT
+	v1MaxContainerEventType = api.ContainerEventType_CONTAINER_EVENT_TYPE_UPDATED
+)
+
+// negotiateAPIVersion returns the version of the Telemetry API used for a
+// stream whose client requested a version. Clients newer than the sensor
+// are downgraded to the newest version that it supports, and clients older
+// than the configured MinAPIVersion are refused.
+func negotiateAPIVersion(requested api.APIVersion) (api.APIVersion, error) {
+	if requested == api.APIVersion_API_VERSION_UNSPECIFIED {
+		requested = api.APIVersion_API_VERSION_1
+	}
+	minVersion := api.APIVersion(config.CurrentSensor().MinAPIVersion)
+	if requested < minVersion {
+		return 0, fmt.Errorf("API version %d is not supported (the Sensor supports versions %d to %d)",
+			requested, minVersion, maxAPIVersion)
+	}
+	if requested > maxAPIVersion {
+		return maxAPIVersion, nil
+	}
+	return requested, nil
+}
+
+// checkAPIVersion returns an InvalidArgument error naming the first event
+// filter of a subscription that is not available at an API version, either
+// because its event family or its event type was added later, so that a
+// client does not silently receive nothing for it.
+func checkAPIVersion(sub *api.Subscription, version api.APIVersion) error {
+	if version >= api.APIVersion_API_VERSION_2 || sub.EventFilter == nil {
+		return nil
+	}
+	requiresVersion2 := func(name string) error {
+		return status.Errorf(codes.InvalidArgument,
+			"EventFilter %s requires API version %d (the stream uses version %d)",
+			name, api.APIVersion_API_VERSION_2, version)
+	}
+
+	f := sub.EventFilter
+	for _, x := range []struct {
+		name string
+		n    int
+	}{
+		{"kernel_module_events", len(f.KernelModuleEvents)},
+		{"mount_events", len(f.MountEvents)},
+		{"memory_events", len(f.MemoryEvents)},
+		{"signal_events", len(f.SignalEvents)},
+		{"lsm_events", len(f.LsmEvents)},
+		{"tty_events", len(f.TtyEvents)},
+		{"io_uring_events", len(f.IoUringEvents)},
+		{"bpf_events", len(f.BpfEvents)},
+		{"user_events", len(f.UserEvents)},
+		{"image_events", len(f.ImageEvents)},
+		{"session_events", len(f.SessionEvents)},
+	} {
+		if x.n > 0 {
+			return requiresVersion2(x.name)
+		}
+	}
+
+	for _, pef := range f.ProcessEvents {
+		if pef.Type > v1MaxProcessEventType {
+			return requiresVersion2(pef.Type.String())
+		}
+	}
+	for _, fef := range f.FileEvents {
+		if fef.Type > v1MaxFileEventType {
+			return requiresVersion2(fef.Type.String())
+		}
+	}
+	for _, nef := range f.NetworkEvents {
+		if nef.Type > v1MaxNetworkEventType {
+			return requiresVersion2(nef.Type.String())
+		}
+	}
+	for _, cef := range f.ContainerEvents {
+		if cef.Type > v1MaxContainerEventType {
+			return requiresVersion2(cef.Type.String())
+		}
+	}
+	return nil
+}
+
+// eventInAPIVersion returns whether an event existed at an API version.
+// Events that did not, including those of types added to an event family
+// in version 2, are not sent to streams using the version, since their
+// clients cannot decode them.
+func eventInAPIVersion(e *api.TelemetryEvent, version api.APIVersion) bool {
+	if version >= api.APIVersion_API_VERSION_2 {
+		return true
+	}
+	switch event := e.Event.(type) {
+	case *api.TelemetryEvent_Syscall, *api.TelemetryEvent_KernelCall,
+		*api.TelemetryEvent_Performance, *api.TelemetryEvent_Chargen,
+		*api.TelemetryEvent_Ticker:
+		return true
+	case *api.TelemetryEvent_Process:
+		return event.Process != nil &&
+			event.Process.Type <= v1MaxProcessEventType
+	case *api.TelemetryEvent_File:
+		return event.File != nil &&
+			event.File.Type <= v1MaxFileEventType
+	case *api.TelemetryEvent_Network:
+		return event.Network != nil &&
+			event.Network.Type <= v1MaxNetworkEventType
+	case *api.TelemetryEvent_Container:
+		return event.Container != nil &&
+			event.Container.Type <= v1MaxContainerEventType
+	}
+	return false
+}
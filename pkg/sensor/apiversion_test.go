@@ -0,0 +1,198 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	saved := config.Sensor.MinAPIVersion
+	defer func() { config.Sensor.MinAPIVersion = saved }()
+
+	config.Sensor.MinAPIVersion = 1
+	for requested, expected := range map[api.APIVersion]api.APIVersion{
+		api.APIVersion_API_VERSION_UNSPECIFIED: api.APIVersion_API_VERSION_1,
+		api.APIVersion_API_VERSION_1:           api.APIVersion_API_VERSION_1,
+		api.APIVersion_API_VERSION_2:           api.APIVersion_API_VERSION_2,
+		maxAPIVersion + 1:                      maxAPIVersion,
+	} {
+		v, err := negotiateAPIVersion(requested)
+		require.NoError(t, err, "%d", requested)
+		assert.Equal(t, expected, v, "%d", requested)
+	}
+
+	config.Sensor.MinAPIVersion = 2
+	_, err := negotiateAPIVersion(api.APIVersion_API_VERSION_UNSPECIFIED)
+	assert.Error(t, err)
+	v, err := negotiateAPIVersion(api.APIVersion_API_VERSION_2)
+	require.NoError(t, err)
+	assert.Equal(t, api.APIVersion_API_VERSION_2, v)
+}
+
+func TestCheckAPIVersion(t *testing.T) {
+	sub := &api.Subscription{
+		EventFilter: &api.EventFilter{
+			ProcessEvents: []*api.ProcessEventFilter{
+				&api.ProcessEventFilter{},
+			},
+		},
+	}
+	assert.NoError(t, checkAPIVersion(sub, api.APIVersion_API_VERSION_1))
+
+	sub.EventFilter.MountEvents = []*api.MountEventFilter{
+		&api.MountEventFilter{},
+	}
+	err := checkAPIVersion(sub, api.APIVersion_API_VERSION_1)
+	if assert.Error(t, err) {
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "mount_events")
+	}
+	assert.NoError(t, checkAPIVersion(sub, api.APIVersion_API_VERSION_2))
+
+	// Event types added to an event family in version 2 are refused too
+	subs := []*api.Subscription{
+		{EventFilter: &api.EventFilter{
+			ProcessEvents: []*api.ProcessEventFilter{{
+				Type: api.ProcessEventType_PROCESS_EVENT_TYPE_CRED_CHANGE,
+			}},
+		}},
+		{EventFilter: &api.EventFilter{
+			FileEvents: []*api.FileEventFilter{{
+				Type: api.FileEventType_FILE_EVENT_TYPE_WRITE,
+			}},
+		}},
+		{EventFilter: &api.EventFilter{
+			NetworkEvents: []*api.NetworkEventFilter{{
+				Type: api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_CONNECT,
+			}},
+		}},
+		{EventFilter: &api.EventFilter{
+			ContainerEvents: []*api.ContainerEventFilter{{
+				Type: api.ContainerEventType_CONTAINER_EVENT_TYPE_HEALTH,
+			}},
+		}},
+	}
+	for _, sub := range subs {
+		err = checkAPIVersion(sub, api.APIVersion_API_VERSION_1)
+		if assert.Error(t, err, "%v", sub) {
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, err.Error(), "requires API version 2")
+		}
+		assert.NoError(t, checkAPIVersion(sub, api.APIVersion_API_VERSION_2))
+	}
+
+	// The newest version 1 types are accepted
+	sub = &api.Subscription{
+		EventFilter: &api.EventFilter{
+			ProcessEvents: []*api.ProcessEventFilter{{
+				Type: api.ProcessEventType_PROCESS_EVENT_TYPE_UPDATE,
+			}},
+			FileEvents: []*api.FileEventFilter{{
+				Type: api.FileEventType_FILE_EVENT_TYPE_OPEN,
+			}},
+			NetworkEvents: []*api.NetworkEventFilter{{
+				Type: api.NetworkEventType_NETWORK_EVENT_TYPE_RECVFROM_RESULT,
+			}},
+			ContainerEvents: []*api.ContainerEventFilter{{
+				Type: api.ContainerEventType_CONTAINER_EVENT_TYPE_UPDATED,
+			}},
+		},
+	}
+	assert.NoError(t, checkAPIVersion(sub, api.APIVersion_API_VERSION_1))
+}
+
+func TestEventInAPIVersion(t *testing.T) {
+	v1Events := []*api.TelemetryEvent{
+		{Event: &api.TelemetryEvent_Syscall{Syscall: &api.SyscallEvent{}}},
+		{Event: &api.TelemetryEvent_KernelCall{KernelCall: &api.KernelFunctionCallEvent{}}},
+		{Event: &api.TelemetryEvent_Performance{Performance: &api.PerformanceEvent{}}},
+		{Event: &api.TelemetryEvent_Chargen{Chargen: &api.ChargenEvent{}}},
+		{Event: &api.TelemetryEvent_Ticker{Ticker: &api.TickerEvent{}}},
+	}
+	v2Events := []*api.TelemetryEvent{
+		{Event: &api.TelemetryEvent_Mount{Mount: &api.MountEvent{}}},
+	}
+
+	// Event families that gained types in version 2
+	for typ := api.ProcessEventType_PROCESS_EVENT_TYPE_FORK; typ <= api.ProcessEventType_PROCESS_EVENT_TYPE_SECCOMP_VIOLATION; typ++ {
+		e := &api.TelemetryEvent{
+			Event: &api.TelemetryEvent_Process{
+				Process: &api.ProcessEvent{Type: typ},
+			},
+		}
+		if typ <= api.ProcessEventType_PROCESS_EVENT_TYPE_UPDATE {
+			v1Events = append(v1Events, e)
+		} else {
+			v2Events = append(v2Events, e)
+		}
+	}
+	for typ := api.FileEventType_FILE_EVENT_TYPE_OPEN; typ <= api.FileEventType_FILE_EVENT_TYPE_MEMFD_CREATE; typ++ {
+		e := &api.TelemetryEvent{
+			Event: &api.TelemetryEvent_File{
+				File: &api.FileEvent{Type: typ},
+			},
+		}
+		if typ <= api.FileEventType_FILE_EVENT_TYPE_OPEN {
+			v1Events = append(v1Events, e)
+		} else {
+			v2Events = append(v2Events, e)
+		}
+	}
+	for typ := api.NetworkEventType_NETWORK_EVENT_TYPE_CONNECT_ATTEMPT; typ <= api.NetworkEventType_NETWORK_EVENT_TYPE_UDP_BIND; typ++ {
+		e := &api.TelemetryEvent{
+			Event: &api.TelemetryEvent_Network{
+				Network: &api.NetworkEvent{Type: typ},
+			},
+		}
+		if typ <= api.NetworkEventType_NETWORK_EVENT_TYPE_RECVFROM_RESULT {
+			v1Events = append(v1Events, e)
+		} else {
+			v2Events = append(v2Events, e)
+		}
+	}
+	for typ := api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED; typ <= api.ContainerEventType_CONTAINER_EVENT_TYPE_RESUMED; typ++ {
+		e := &api.TelemetryEvent{
+			Event: &api.TelemetryEvent_Container{
+				Container: &api.ContainerEvent{Type: typ},
+			},
+		}
+		if typ <= api.ContainerEventType_CONTAINER_EVENT_TYPE_UPDATED {
+			v1Events = append(v1Events, e)
+		} else {
+			v2Events = append(v2Events, e)
+		}
+	}
+
+	v1 := api.APIVersion_API_VERSION_1
+	v2 := api.APIVersion_API_VERSION_2
+	for _, e := range v1Events {
+		assert.True(t, eventInAPIVersion(e, v1), "%v", e)
+		assert.True(t, eventInAPIVersion(e, v2), "%v", e)
+	}
+	for _, e := range v2Events {
+		assert.False(t, eventInAPIVersion(e, v1), "%v", e)
+		assert.True(t, eventInAPIVersion(e, v2), "%v", e)
+	}
+}
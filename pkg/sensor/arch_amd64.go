@@ -0,0 +1,56 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+// Kprobe and uprobe fetch args throughout the sensor are written using the
+// x86_64 register names for the arguments of the probed function, in the
+// order of the x86_64 C ABI: %di, %si, %dx, %cx, %r8, %r9, with the return
+// value in %ax. They are used as-is on x86_64.
+
+// syscallHandlerPrefix is the prefix added to system call handler symbols in
+// Linux 4.17+ kernels.
+const syscallHandlerPrefix = "__x64_"
+
+// These offsets index into the x86_64 version of struct pt_regs
+// in the kernel. This is a stable structure.
+const syscallEnterKprobeFetchargs string = "id=+120(%di):s64 " + // orig_ax
+	"arg0=+112(%di):u64 " + // di
+	"arg1=+104(%di):u64 " + // si
+	"arg2=+96(%di):u64 " + // dx
+	"arg3=+56(%di):u64 " + // r10
+	"arg4=+72(%di):u64 " + // r8
+	"arg5=+64(%di):u64" // r9
+
+// Map for rewriting kprobe fetch args in kernel 4.17+
+// N.B. %di must come first to avoid replacing a %di in an already replaced
+// expression.
+// N.B. %cx actually needs to be replaced with pt_regs->r10. Since the syscall
+// handlers used to have "real" arguments, registers were setup according to the
+// x64 _C_ ABI, however now the syscalls only get a pointer to the register state
+// at the time the syscall entered, which means the registers are setup in the
+// x64 _syscall_ ABI.
+var fetchArgsReplacements = [][2]string{
+	{"%di", "+0x70(%di)"}, // pt_regs+0x70
+	{"%si", "+0x68(%di)"},
+	{"%dx", "+0x60(%di)"},
+	{"%cx", "+0x38(%di)"}, // This is actually replacing RCX with R10
+	{"%r8", "+0x48(%di)"},
+	{"%r9", "+0x40(%di)"},
+	{"%ax", "+0x50(%di)"},
+}
+
+// fetchargRegisterReplacements maps the x86_64 register names used in fetch
+// args to the native register names. There is nothing to replace on x86_64.
+var fetchargRegisterReplacements [][2]string
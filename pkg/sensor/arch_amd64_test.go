@@ -0,0 +1,51 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteSyscallFetchargs(t *testing.T) {
+	args := map[string]string{
+		"a=+0(%di):string": "a=+0(+0x70(%di)):string",
+		"b=%si:s32":        "b=+0x68(%di):s32",
+		"c=%dx:u64":        "c=+0x60(%di):u64",
+		"d=%cx:u16":        "d=+0x38(%di):u16",
+		"e=%r8:s8":         "e=+0x48(%di):s8",
+		"f=+0(%r9):string": "f=+0(+0x40(%di)):string",
+		"g=%ax:s32":        "g=+0x50(%di):s32",
+	}
+
+	var inputArray, expArray []string
+	for k, v := range args {
+		inputArray = append(inputArray, k)
+		expArray = append(expArray, v)
+	}
+	input := strings.Join(inputArray, " ")
+	exp := strings.Join(expArray, " ")
+	got := rewriteSyscallFetchargs(input)
+	assert.Equal(t, exp, got)
+}
+
+func TestTranslateFetchargRegisters(t *testing.T) {
+	fetchargs := "a=+0(%di):string b=%si:s32 c=+8(+0(%dx)):u64 d=%ax:s32"
+	assert.Equal(t, fetchargs, translateFetchargRegisters(fetchargs))
+	assert.Equal(t, syscallEnterKprobeFetchargs,
+		translateFetchargRegisters(syscallEnterKprobeFetchargs))
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+// Kprobe and uprobe fetch args throughout the sensor are written using the
+// x86_64 register names for the arguments of the probed function. On arm64,
+// they are replaced with the registers that hold the same arguments in the
+// AArch64 procedure call standard before the probe is registered.
+
+// syscallHandlerPrefix is the prefix added to system call handler symbols in
+// Linux 4.19+ kernels.
+const syscallHandlerPrefix = "__arm64_"
+
+// These offsets index into the arm64 version of struct pt_regs in the kernel.
+// The first 31 members are the general purpose registers, followed by sp, pc,
+// pstate, orig_x0, and syscallno. This is a stable structure.
+const syscallEnterKprobeFetchargs string = "id=+280(%x0):s32 " + // syscallno
+	"arg0=+272(%x0):u64 " + // orig_x0
+	"arg1=+8(%x0):u64 " + // x1
+	"arg2=+16(%x0):u64 " + // x2
+	"arg3=+24(%x0):u64 " + // x3
+	"arg4=+32(%x0):u64 " + // x4
+	"arg5=+40(%x0):u64" // x5
+
+// Map for rewriting kprobe fetch args for syscall handlers, which only get a
+// pointer to the register state at the time the syscall entered. The arm64
+// C ABI and syscall ABI both pass arguments in x0 to x5, so each argument is
+// read from the corresponding pt_regs->regs slot.
+// N.B. %di must come first to avoid replacing a %di in an already replaced
+// expression.
+var fetchArgsReplacements = [][2]string{
+	{"%di", "+0x0(%x0)"},
+	{"%si", "+0x8(%x0)"},
+	{"%dx", "+0x10(%x0)"},
+	{"%cx", "+0x18(%x0)"},
+	{"%r8", "+0x20(%x0)"},
+	{"%r9", "+0x28(%x0)"},
+	{"%ax", "+0x0(%x0)"},
+}
+
+// fetchargRegisterReplacements maps the x86_64 register names used in fetch
+// args to the arm64 registers that hold the same function arguments. Return
+// values are in x0.
+var fetchargRegisterReplacements = [][2]string{
+	{"%di", "%x0"},
+	{"%si", "%x1"},
+	{"%dx", "%x2"},
+	{"%cx", "%x3"},
+	{"%r8", "%x4"},
+	{"%r9", "%x5"},
+	{"%ax", "%x0"},
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteSyscallFetchargs(t *testing.T) {
+	args := map[string]string{
+		"a=+0(%di):string": "a=+0(+0x0(%x0)):string",
+		"b=%si:s32":        "b=+0x8(%x0):s32",
+		"c=%dx:u64":        "c=+0x10(%x0):u64",
+		"d=%cx:u16":        "d=+0x18(%x0):u16",
+		"e=%r8:s8":         "e=+0x20(%x0):s8",
+		"f=+0(%r9):string": "f=+0(+0x28(%x0)):string",
+		"g=%ax:s32":        "g=+0x0(%x0):s32",
+	}
+
+	var inputArray, expArray []string
+	for k, v := range args {
+		inputArray = append(inputArray, k)
+		expArray = append(expArray, v)
+	}
+	input := strings.Join(inputArray, " ")
+	exp := strings.Join(expArray, " ")
+	got := rewriteSyscallFetchargs(input)
+	assert.Equal(t, exp, got)
+}
+
+func TestTranslateFetchargRegisters(t *testing.T) {
+	assert.Equal(t, "a=+0(%x0):string b=%x1:s32 c=+8(+0(%x2)):u64 "+
+		"d=%x3:u16 e=%x4:s8 f=%x5:u32 g=%x0:s32",
+		translateFetchargRegisters("a=+0(%di):string b=%si:s32 "+
+			"c=+8(+0(%dx)):u64 d=%cx:u16 e=%r8:s8 f=%r9:u32 g=%ax:s32"))
+
+	// Fetch args that already use arm64 registers are left alone
+	assert.Equal(t, syscallEnterKprobeFetchargs,
+		translateFetchargRegisters(syscallEnterKprobeFetchargs))
+}
@@ -0,0 +1,297 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// AUDIT_NLGRP_READLOG is the netlink multicast group to which the
+	// kernel sends a copy of every audit record. Reading from it does not
+	// interfere with auditd, which receives records over unicast.
+	auditNetlinkGroupReadLog = 1
+
+	// MAX_AUDIT_MESSAGE_LENGTH from include/uapi/linux/audit.h
+	auditMaxMessageLength = 8970
+
+	// The receive timeout bounds how long stop() waits for the receive
+	// loop to notice that it should exit.
+	auditReceiveTimeout = 250 // milliseconds
+
+	auditRecordTypeSyscall  = 1300
+	auditRecordTypePath     = 1302
+	auditRecordTypeSockaddr = 1306
+	auditRecordTypeExecve   = 1309
+	auditRecordTypeEOE      = 1320
+	auditRecordTypeAVC      = 1400
+)
+
+// auditRecord is a single record received from the kernel audit subsystem.
+// Records that are part of the same event share the same serial number.
+type auditRecord struct {
+	Type   uint16
+	Serial uint64
+	Text   string
+}
+
+// parseAuditRecord parses the text of an audit record, which has the form
+// "audit(<seconds>.<milliseconds>:<serial>): <fields>".
+func parseAuditRecord(recordType uint16, data []byte) (*auditRecord, error) {
+	text := strings.TrimRight(string(data), "\x00\n")
+	if !strings.HasPrefix(text, "audit(") {
+		return nil, fmt.Errorf("Malformed audit record %q", text)
+	}
+	end := strings.Index(text, "): ")
+	if end < 0 {
+		return nil, fmt.Errorf("Malformed audit record %q", text)
+	}
+	stamp := text[len("audit("):end]
+	colon := strings.IndexByte(stamp, ':')
+	if colon < 0 {
+		return nil, fmt.Errorf("Malformed audit record %q", text)
+	}
+	serial, err := strconv.ParseUint(stamp[colon+1:], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Malformed audit record %q", text)
+	}
+
+	return &auditRecord{
+		Type:   recordType,
+		Serial: serial,
+		Text:   text[end+3:],
+	}, nil
+}
+
+// Fields that the kernel hex encodes when their values contain spaces, quotes,
+// or control characters. Values that are quoted are never encoded.
+var auditUntrustedFields = map[string]bool{
+	"comm":    true,
+	"exe":     true,
+	"name":    true,
+	"path":    true,
+	"profile": true,
+}
+
+// fields returns the key=value pairs in the record text. Quotes are removed
+// from quoted values and hex encoded values are decoded. Tokens that are not
+// key=value pairs are skipped.
+func (r *auditRecord) fields() map[string]string {
+	return r.parseFields(func(key string) bool {
+		return auditUntrustedFields[key]
+	})
+}
+
+// parseFields returns the key=value pairs in the record text, hex decoding
+// the unquoted values of the keys for which untrusted returns true.
+func (r *auditRecord) parseFields(untrusted func(key string) bool) map[string]string {
+	fields := make(map[string]string)
+	text := r.Text
+	for len(text) > 0 {
+		text = strings.TrimLeft(text, " ")
+		eq := strings.IndexAny(text, "= ")
+		if eq < 0 {
+			break
+		}
+		if text[eq] == ' ' {
+			text = text[eq:]
+			continue
+		}
+		key := text[:eq]
+		text = text[eq+1:]
+
+		var value string
+		if strings.HasPrefix(text, "\"") {
+			end := strings.IndexByte(text[1:], '"')
+			if end < 0 {
+				value, text = text[1:], ""
+			} else {
+				value, text = text[1:end+1], text[end+2:]
+			}
+		} else {
+			end := strings.IndexByte(text, ' ')
+			if end < 0 {
+				value, text = text, ""
+			} else {
+				value, text = text[:end], text[end:]
+			}
+			if untrusted(key) {
+				if b, err := hex.DecodeString(value); err == nil {
+					value = string(b)
+				}
+			}
+		}
+		fields[key] = value
+	}
+	return fields
+}
+
+// auditRecordHandler is called for each audit record of a type for which it
+// has been registered. The sample ID records the time at which the record was
+// received.
+type auditRecordHandler func(sampleID perf.SampleID, record *auditRecord)
+
+// auditListener receives audit records from the kernel's audit read log
+// multicast group and dispatches them to registered handlers.
+type auditListener struct {
+	sensor *Sensor
+	fd     int
+
+	handlersLock sync.Mutex
+	handlers     map[uint16][]auditRecordHandler
+
+	stopping  int32
+	waitGroup sync.WaitGroup
+}
+
+// newAuditListener opens a netlink socket bound to the audit read log
+// multicast group. This requires Linux 3.16 or later and CAP_AUDIT_READ.
+func newAuditListener(sensor *Sensor) (*auditListener, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK,
+		unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_AUDIT)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := &unix.SockaddrNetlink{
+		Family: unix.AF_NETLINK,
+		Groups: 1 << (auditNetlinkGroupReadLog - 1),
+	}
+	if err = unix.Bind(fd, addr); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	tv := unix.NsecToTimeval(auditReceiveTimeout * 1000 * 1000)
+	err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv)
+	if err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	return &auditListener{
+		sensor:   sensor,
+		fd:       fd,
+		handlers: make(map[uint16][]auditRecordHandler),
+	}, nil
+}
+
+// handleRecordType registers a handler for audit records of the specified
+// type. Handlers may be registered at any time.
+func (l *auditListener) handleRecordType(recordType uint16, handler auditRecordHandler) {
+	l.handlersLock.Lock()
+	l.handlers[recordType] = append(l.handlers[recordType], handler)
+	l.handlersLock.Unlock()
+}
+
+func (l *auditListener) start() {
+	l.waitGroup.Add(1)
+	go func() {
+		defer l.waitGroup.Done()
+		l.receiveLoop()
+	}()
+}
+
+func (l *auditListener) stop() {
+	atomic.StoreInt32(&l.stopping, 1)
+	l.waitGroup.Wait()
+	unix.Close(l.fd)
+}
+
+func (l *auditListener) receiveLoop() {
+	buf := make([]byte, auditMaxMessageLength+unix.NLMSG_HDRLEN)
+	for atomic.LoadInt32(&l.stopping) == 0 {
+		n, _, err := unix.Recvfrom(l.fd, buf, 0)
+		if err != nil {
+			switch err {
+			case unix.EAGAIN, unix.EINTR:
+			case unix.ENOBUFS:
+				// The kernel drops records for multicast
+				// listeners that fall behind.
+				glog.V(1).Info("Audit records were lost")
+			default:
+				glog.Warningf("Could not receive audit records: %v", err)
+				return
+			}
+			continue
+		}
+
+		sampleID := perf.SampleID{
+			Time: uint64(sys.CurrentMonotonicRaw()),
+		}
+		if err = l.dispatch(sampleID, buf[:n]); err != nil {
+			glog.V(1).Infof("Could not process audit records: %v", err)
+		}
+	}
+}
+
+func (l *auditListener) dispatch(sampleID perf.SampleID, b []byte) error {
+	msgs, err := syscall.ParseNetlinkMessage(b)
+	if err != nil {
+		return err
+	}
+	if len(msgs) == 0 {
+		return errors.New("Empty netlink message")
+	}
+
+	for _, m := range msgs {
+		l.handlersLock.Lock()
+		handlers := l.handlers[m.Header.Type]
+		l.handlersLock.Unlock()
+		if len(handlers) == 0 {
+			continue
+		}
+
+		record, err := parseAuditRecord(m.Header.Type, m.Data)
+		if err != nil {
+			return err
+		}
+		for _, h := range handlers {
+			h(sampleID, record)
+		}
+	}
+	return nil
+}
+
+// auditListener returns the sensor's audit listener, creating and starting it
+// the first time it is needed.
+func (s *Sensor) auditListener() (*auditListener, error) {
+	s.auditLock.Lock()
+	defer s.auditLock.Unlock()
+
+	if s.audit == nil {
+		l, err := newAuditListener(s)
+		if err != nil {
+			return nil, err
+		}
+		s.audit = l
+		s.audit.start()
+	}
+	return s.audit, nil
+}
@@ -0,0 +1,373 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
+
+	"golang.org/x/sys/unix"
+)
+
+// When the sensor is configured to use the audit backend, process exec,
+// network connect attempt, and file open events are built from the records
+// that the kernel audit subsystem emits for audited system calls rather than
+// from kprobes. The sensor does not install audit rules itself, because doing
+// so would interfere with auditd; rules such as
+//
+//	-a always,exit -F arch=b64 -S execve,execveat,connect,open,openat
+//
+// must already be loaded for any of these events to be reported.
+
+type auditSyscallEventKind int
+
+const (
+	auditSyscallExec auditSyscallEventKind = iota
+	auditSyscallConnect
+	auditSyscallOpen
+	auditSyscallEventKindCount
+)
+
+// Records for an event that is never completed by an EOE record, which can
+// happen when the kernel drops records, are discarded after this long.
+const auditSyscallEventTimeout = 5 * 1000 * 1000 * 1000 // nanoseconds
+
+// Not every architecture has open(2). Where it is missing, this is -1, which
+// never matches an audit record.
+var auditSysOpen = syscallNumber("open")
+
+// auditSyscallEvent collects the records emitted for a single audited system
+// call. All of its records share the same serial number.
+type auditSyscallEvent struct {
+	sampleID perf.SampleID
+	syscall  map[string]string
+	execve   map[string]string
+	paths    []map[string]string
+	sockaddr string
+}
+
+// auditSyscallEvents assembles audit records into system call events and
+// enqueues the ones that the sensor reports.
+type auditSyscallEvents struct {
+	sensor   *Sensor
+	eventIDs [auditSyscallEventKindCount]uint64
+
+	pendingLock sync.Mutex
+	pending     map[uint64]*auditSyscallEvent
+}
+
+func newAuditSyscallEvents(sensor *Sensor) *auditSyscallEvents {
+	a := &auditSyscallEvents{
+		sensor:  sensor,
+		pending: make(map[uint64]*auditSyscallEvent),
+	}
+
+	monitor := sensor.Monitor()
+	a.eventIDs[auditSyscallExec] = monitor.RegisterExternalEvent(
+		"AUDIT_PROCESS_EXEC", sensor.ProcessCache.decodeProcessExecEvent)
+	a.eventIDs[auditSyscallConnect] = monitor.RegisterExternalEvent(
+		"AUDIT_NETWORK_CONNECT_ATTEMPT", sensor.decodeAuditConnect)
+	a.eventIDs[auditSyscallOpen] = monitor.RegisterExternalEvent(
+		"AUDIT_FILE_OPEN", sensor.decodeAuditOpen)
+
+	return a
+}
+
+func (a *auditSyscallEvents) handleRecord(sampleID perf.SampleID, record *auditRecord) {
+	a.pendingLock.Lock()
+	event, ok := a.pending[record.Serial]
+	if !ok {
+		if record.Type == auditRecordTypeEOE {
+			a.pendingLock.Unlock()
+			return
+		}
+		event = &auditSyscallEvent{sampleID: sampleID}
+		a.pending[record.Serial] = event
+	}
+
+	switch record.Type {
+	case auditRecordTypeSyscall:
+		event.syscall = record.fields()
+	case auditRecordTypeExecve:
+		event.execve = record.parseFields(auditExecveUntrusted)
+	case auditRecordTypePath:
+		event.paths = append(event.paths, record.fields())
+	case auditRecordTypeSockaddr:
+		event.sockaddr = record.fields()["saddr"]
+	case auditRecordTypeEOE:
+		delete(a.pending, record.Serial)
+		for serial, e := range a.pending {
+			if sampleID.Time-e.sampleID.Time > auditSyscallEventTimeout {
+				delete(a.pending, serial)
+			}
+		}
+		a.pendingLock.Unlock()
+
+		a.enqueue(event)
+		return
+	}
+	a.pendingLock.Unlock()
+}
+
+func (a *auditSyscallEvents) enqueue(event *auditSyscallEvent) {
+	kind, data, ok := event.sampleData()
+	if !ok {
+		return
+	}
+	if pid, ok := data["common_pid"].(int32); ok && kind == auditSyscallExec {
+		data["exec_sha256"] = a.sensor.ProcessCache.executableHash(int(pid))
+		data["exec_environment"] = a.sensor.ProcessCache.execEnvironment(int(pid))
+	}
+	err := a.sensor.Monitor().EnqueueExternalSample(a.eventIDs[kind],
+		event.sampleID, data)
+	if err != nil {
+		glog.V(1).Infof("Could not enqueue audit event: %v", err)
+	}
+}
+
+// The arguments of an EXECVE record are either quoted or hex encoded, so all
+// of them are decoded. Only the argument count and lengths are not.
+func auditExecveUntrusted(key string) bool {
+	return key != "argc" && !strings.HasSuffix(key, "_len")
+}
+
+func (e *auditSyscallEvent) argument(n int) uint64 {
+	// Arguments are logged in hex without a prefix
+	v, _ := strconv.ParseUint(e.syscall[fmt.Sprintf("a%d", n)], 16, 64)
+	return v
+}
+
+// filename returns the name of the file that the system call operated on.
+// When a file is created, the first PATH record is for its parent directory.
+func (e *auditSyscallEvent) filename() string {
+	for _, path := range e.paths {
+		if path["nametype"] != "PARENT" {
+			return path["name"]
+		}
+	}
+	return ""
+}
+
+// commandLine returns the arguments from the EXECVE record. Long arguments
+// are split across multiple fields named a<n>[<i>].
+func (e *auditSyscallEvent) commandLine() []string {
+	argc, _ := strconv.Atoi(e.execve["argc"])
+	commandLine := make([]string, 0, argc)
+	for n := 0; n < argc; n++ {
+		key := fmt.Sprintf("a%d", n)
+		if arg, ok := e.execve[key]; ok {
+			commandLine = append(commandLine, arg)
+			continue
+		}
+		var parts []string
+		for i := 0; ; i++ {
+			part, ok := e.execve[fmt.Sprintf("%s[%d]", key, i)]
+			if !ok {
+				break
+			}
+			parts = append(parts, part)
+		}
+		commandLine = append(commandLine, strings.Join(parts, ""))
+	}
+	return commandLine
+}
+
+// sockaddrData sets the sample data for the struct sockaddr in a SOCKADDR
+// record, using the same representation as the connect kprobe. All fields are
+// present regardless of the address family.
+func (e *auditSyscallEvent) sockaddrData(data perf.TraceEventSampleData) {
+	data["sa_family"] = uint16(0)
+	data["sin_port"] = uint16(0)
+	data["sin_addr"] = uint32(0)
+	data["sun_path"] = ""
+	data["sin6_port"] = uint16(0)
+	data["sin6_addr_high"] = uint64(0)
+	data["sin6_addr_low"] = uint64(0)
+
+	b, err := hex.DecodeString(e.sockaddr)
+	if err != nil || len(b) < 2 {
+		return
+	}
+	family := binary.LittleEndian.Uint16(b[0:2])
+	data["sa_family"] = family
+	switch family {
+	case unix.AF_LOCAL:
+		path := b[2:]
+		for i, c := range path {
+			if c == 0 {
+				path = path[:i]
+				break
+			}
+		}
+		data["sun_path"] = string(path)
+	case unix.AF_INET:
+		if len(b) >= 8 {
+			data["sin_port"] = binary.LittleEndian.Uint16(b[2:4])
+			data["sin_addr"] = binary.LittleEndian.Uint32(b[4:8])
+		}
+	case unix.AF_INET6:
+		if len(b) >= 24 {
+			data["sin6_port"] = binary.LittleEndian.Uint16(b[2:4])
+			data["sin6_addr_high"] = binary.LittleEndian.Uint64(b[8:16])
+			data["sin6_addr_low"] = binary.LittleEndian.Uint64(b[16:24])
+		}
+	}
+}
+
+// sampleData returns the kind of event and the sample data to enqueue for it.
+// System calls that the sensor does not report are ignored, as are execs
+// that failed.
+func (e *auditSyscallEvent) sampleData() (auditSyscallEventKind, perf.TraceEventSampleData, bool) {
+	if e.syscall == nil {
+		return 0, nil, false
+	}
+	syscall, err := strconv.ParseInt(e.syscall["syscall"], 10, 64)
+	if err != nil {
+		return 0, nil, false
+	}
+
+	data := perf.TraceEventSampleData{}
+	if pid, err := strconv.ParseInt(e.syscall["pid"], 10, 32); err == nil {
+		data["common_pid"] = int32(pid)
+	}
+
+	switch syscall {
+	case unix.SYS_EXECVE, unix.SYS_EXECVEAT:
+		if e.syscall["success"] != "yes" {
+			return 0, nil, false
+		}
+		commandLine := e.commandLine()
+		filename := e.filename()
+		if len(filename) == 0 && len(commandLine) > 0 {
+			filename = commandLine[0]
+		}
+		data["filename"] = filename
+		data["exec_command_line"] = commandLine
+
+		// Audit records do not identify anonymous memory files.
+		data["exec_fileless"] = false
+		data["exec_sha256"] = ""
+		data["exec_environment"] = []string(nil)
+		return auditSyscallExec, data, true
+
+	case unix.SYS_CONNECT:
+		data["fd"] = e.argument(0)
+		e.sockaddrData(data)
+		return auditSyscallConnect, data, true
+
+	case auditSysOpen, unix.SYS_OPENAT:
+		flags, mode := e.argument(1), e.argument(2)
+		if syscall == unix.SYS_OPENAT {
+			flags, mode = e.argument(2), e.argument(3)
+		}
+		data["filename"] = e.filename()
+		data["flags"] = int32(flags)
+		data["mode"] = int32(mode)
+		return auditSyscallOpen, data, true
+	}
+	return 0, nil, false
+}
+
+func (s *Sensor) decodeAuditConnect(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e NetworkConnectAttemptTelemetryEvent
+	if !e.InitWithSample(s, sample, data) {
+		return nil, nil
+	}
+	e.NetworkAttemptTelemetryEventData.initWithSample(sample, data)
+	e.NetworkAddressTelemetryEventData.initWithSample(sample, data)
+	return e, nil
+}
+
+func (s *Sensor) decodeAuditOpen(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e FileOpenTelemetryEvent
+	if !e.InitWithSample(s, sample, data) {
+		return nil, nil
+	}
+	e.Filename = data["filename"].(string)
+	e.Flags = data["flags"].(int32)
+	e.Mode = data["mode"].(int32)
+	return e, nil
+}
+
+// registerAuditSyscallEvents returns the events built from audited system
+// calls, registering them and starting the audit listener the first time
+// they are needed.
+func (s *Sensor) registerAuditSyscallEvents() (*auditSyscallEvents, error) {
+	l, err := s.auditListener()
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLock.Lock()
+	defer s.auditLock.Unlock()
+
+	if s.auditSyscalls == nil {
+		a := newAuditSyscallEvents(s)
+		for _, t := range []uint16{
+			auditRecordTypeSyscall,
+			auditRecordTypePath,
+			auditRecordTypeSockaddr,
+			auditRecordTypeExecve,
+			auditRecordTypeEOE,
+		} {
+			l.handleRecordType(t, a.handleRecord)
+		}
+		s.auditSyscalls = a
+	}
+	return s.auditSyscalls, nil
+}
+
+// registerAuditSyscallEventFilter registers an event filter for events built
+// from audited system calls. Filter expressions are always evaluated in the
+// sensor.
+func (s *Subscription) registerAuditSyscallEventFilter(
+	kind auditSyscallEventKind,
+	expr *expression.Expression,
+	filterTypes expression.FieldTypeMap,
+) {
+	if expr != nil {
+		if err := expr.Validate(filterTypes); err != nil {
+			s.logStatus(
+				fmt.Sprintf("Invalid filter expression: %v", err))
+			return
+		}
+	}
+
+	a, err := s.sensor.registerAuditSyscallEvents()
+	if err != nil {
+		s.logStatus(
+			fmt.Sprintf("Could not monitor audit records: %v", err))
+		return
+	}
+	if _, err = s.addEventSink(a.eventIDs[kind], expr, filterTypes); err != nil {
+		s.logStatus(
+			fmt.Sprintf("Invalid filter expression: %v", err))
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
+)
+
+func newTestAuditSyscallEvent(syscall int, success string, args ...uint64) *auditSyscallEvent {
+	text := fmt.Sprintf("arch=c000003e syscall=%d success=%s exit=0", syscall, success)
+	for i, a := range args {
+		text += fmt.Sprintf(" a%d=%x", i, a)
+	}
+	text += ` items=1 ppid=111324 pid=111343 comm="bash" exe="/bin/bash" key=(null)`
+	return &auditSyscallEvent{
+		syscall: (&auditRecord{Text: text}).fields(),
+	}
+}
+
+func TestAuditSyscallEventExec(t *testing.T) {
+	e := newTestAuditSyscallEvent(unix.SYS_EXECVE, "yes", 0x55d0, 0x55d1, 0x55d2, 0)
+	e.execve = (&auditRecord{
+		Text: `argc=4 a0="ls" a1="-l" a2=2F746D702F6120622E747874 ` +
+			`a3_len=6 a3[0]="abc" a3[1]="def"`,
+	}).parseFields(auditExecveUntrusted)
+	e.paths = []map[string]string{
+		(&auditRecord{Text: `item=0 name="/bin/ls" inode=1234 nametype=NORMAL`}).fields(),
+	}
+
+	kind, data, ok := e.sampleData()
+	require.True(t, ok)
+	assert.Equal(t, auditSyscallExec, kind)
+	assert.Equal(t, int32(111343), data["common_pid"])
+	assert.Equal(t, "/bin/ls", data["filename"])
+	assert.Equal(t, []string{"ls", "-l", "/tmp/a b.txt", "abcdef"},
+		data["exec_command_line"])
+
+	// The filename falls back to the first argument
+	e.paths = nil
+	_, data, ok = e.sampleData()
+	require.True(t, ok)
+	assert.Equal(t, "ls", data["filename"])
+
+	// Failed execs are not reported
+	e = newTestAuditSyscallEvent(unix.SYS_EXECVE, "no", 0x55d0, 0x55d1, 0x55d2, 0)
+	_, _, ok = e.sampleData()
+	assert.False(t, ok)
+}
+
+func TestAuditSyscallEventConnect(t *testing.T) {
+	e := newTestAuditSyscallEvent(unix.SYS_CONNECT, "no", 3, 0x7ffc, 16, 0)
+	e.sockaddr = "020000507F0000010000000000000000"
+
+	kind, data, ok := e.sampleData()
+	require.True(t, ok)
+	assert.Equal(t, auditSyscallConnect, kind)
+	assert.Equal(t, uint64(3), data["fd"])
+	assert.Equal(t, uint16(unix.AF_INET), data["sa_family"])
+	assert.Equal(t, uint16(0x5000), data["sin_port"])
+	assert.Equal(t, uint32(0x0100007f), data["sin_addr"])
+	assert.Equal(t, uint64(0), data["sin6_addr_high"])
+
+	e.sockaddr = "0A000050000000000000000000000000000000000000000100000000"
+	_, data, ok = e.sampleData()
+	require.True(t, ok)
+	assert.Equal(t, uint16(unix.AF_INET6), data["sa_family"])
+	assert.Equal(t, uint16(0x5000), data["sin6_port"])
+	assert.Equal(t, uint64(0), data["sin6_addr_high"])
+	assert.Equal(t, uint64(0x0100000000000000), data["sin6_addr_low"])
+
+	e.sockaddr = "01002F7661722F72756E2F646F636B65722E736F636B00"
+	_, data, ok = e.sampleData()
+	require.True(t, ok)
+	assert.Equal(t, uint16(unix.AF_LOCAL), data["sa_family"])
+	assert.Equal(t, "/var/run/docker.sock", data["sun_path"])
+
+	// A missing address still produces every field
+	e.sockaddr = ""
+	_, data, ok = e.sampleData()
+	require.True(t, ok)
+	assert.Equal(t, uint16(0), data["sa_family"])
+	assert.Equal(t, "", data["sun_path"])
+}
+
+func TestAuditSyscallEventOpen(t *testing.T) {
+	e := newTestAuditSyscallEvent(int(auditSysOpen), "yes", 0x7ffc,
+		unix.O_RDONLY, 0)
+	e.paths = []map[string]string{
+		(&auditRecord{Text: `item=0 name="/etc/passwd" nametype=NORMAL`}).fields(),
+	}
+
+	kind, data, ok := e.sampleData()
+	require.True(t, ok)
+	assert.Equal(t, auditSyscallOpen, kind)
+	assert.Equal(t, "/etc/passwd", data["filename"])
+	assert.Equal(t, int32(unix.O_RDONLY), data["flags"])
+	assert.Equal(t, int32(0), data["mode"])
+
+	// New files are reported by name rather than by parent directory
+	e = newTestAuditSyscallEvent(unix.SYS_OPENAT, "yes",
+		uint64(0xffffff9c), 0x7ffc, unix.O_WRONLY|unix.O_CREAT, 0644)
+	e.paths = []map[string]string{
+		(&auditRecord{Text: `item=0 name="/tmp/" nametype=PARENT`}).fields(),
+		(&auditRecord{Text: `item=1 name="/tmp/new" nametype=CREATE`}).fields(),
+	}
+	_, data, ok = e.sampleData()
+	require.True(t, ok)
+	assert.Equal(t, "/tmp/new", data["filename"])
+	assert.Equal(t, int32(unix.O_WRONLY|unix.O_CREAT), data["flags"])
+	assert.Equal(t, int32(0644), data["mode"])
+
+	e = newTestAuditSyscallEvent(unix.SYS_CLOSE, "yes", 3)
+	_, _, ok = e.sampleData()
+	assert.False(t, ok)
+
+	e = &auditSyscallEvent{}
+	_, _, ok = e.sampleData()
+	assert.False(t, ok)
+}
+
+func TestDecodeAuditSyscallEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+
+	e := newTestAuditSyscallEvent(unix.SYS_CONNECT, "yes", 3, 0x7ffc, 16, 0)
+	e.sockaddr = "020000507F0000010000000000000000"
+	_, data, ok := e.sampleData()
+	require.True(t, ok)
+
+	i, err := sensor.decodeAuditConnect(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, NetworkConnectAttemptTelemetryEvent{}, i)
+
+	ce := i.(NetworkConnectAttemptTelemetryEvent)
+	ok = testCommonTelemetryEventData(t, sensor, ce)
+	require.True(t, ok)
+	assert.Equal(t, 111343, ce.PID)
+	assert.Equal(t, uint64(3), ce.FD)
+	assert.Equal(t, uint16(unix.AF_INET), ce.Family)
+	assert.Equal(t, uint32(0x0100007f), ce.IPv4Address)
+	assert.Equal(t, uint16(0x5000), ce.IPv4Port)
+
+	e = newTestAuditSyscallEvent(int(auditSysOpen), "yes", 0x7ffc,
+		unix.O_RDONLY, 0)
+	e.paths = []map[string]string{{"name": "/etc/passwd"}}
+	_, data, ok = e.sampleData()
+	require.True(t, ok)
+
+	i, err = sensor.decodeAuditOpen(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, FileOpenTelemetryEvent{}, i)
+
+	fe := i.(FileOpenTelemetryEvent)
+	ok = testCommonTelemetryEventData(t, sensor, fe)
+	require.True(t, ok)
+	assert.Equal(t, "/etc/passwd", fe.Filename)
+
+	data["common_pid"] = int32(sensorPID)
+	i, err = sensor.decodeAuditOpen(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+}
+
+func TestAuditSyscallEventAssembly(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	l := newTestAuditListener(sensor)
+	a, err := sensor.registerAuditSyscallEvents()
+	require.NoError(t, err)
+
+	sampleID := perf.SampleID{Time: uint64(sys.CurrentMonotonicRaw())}
+	records := []struct {
+		recordType uint16
+		text       string
+	}{
+		{auditRecordTypeSyscall, "audit(1539500000.123:100): arch=c000003e syscall=2 success=yes exit=3 a0=7ffc a1=0 a2=0 a3=0 items=1 ppid=111324 pid=111343"},
+		{auditRecordTypePath, `audit(1539500000.123:100): item=0 name="/etc/passwd" nametype=NORMAL`},
+		{auditRecordTypeSyscall, "audit(1539500000.124:101): arch=c000003e syscall=42 success=no exit=-111 a0=3 a1=7ffc a2=10 a3=0 items=0 ppid=111324 pid=111343"},
+	}
+	for _, r := range records {
+		err = l.dispatch(sampleID, newTestNetlinkMessage(r.recordType, r.text))
+		require.NoError(t, err)
+	}
+	require.Len(t, a.pending, 2)
+	assert.Len(t, a.pending[100].paths, 1)
+
+	err = l.dispatch(sampleID, newTestNetlinkMessage(auditRecordTypeEOE,
+		"audit(1539500000.123:100): "))
+	require.NoError(t, err)
+	assert.Len(t, a.pending, 1)
+
+	// Incomplete events are eventually discarded
+	sampleID.Time += auditSyscallEventTimeout + 1
+	err = l.dispatch(sampleID, newTestNetlinkMessage(auditRecordTypeSyscall,
+		"audit(1539500005.200:102): arch=c000003e syscall=3 success=yes exit=0 a0=3 items=0 pid=111343"))
+	require.NoError(t, err)
+	err = l.dispatch(sampleID, newTestNetlinkMessage(auditRecordTypeEOE,
+		"audit(1539500005.200:102): "))
+	require.NoError(t, err)
+	assert.Len(t, a.pending, 0)
+
+	// An EOE record for an unknown event is ignored
+	err = l.dispatch(sampleID, newTestNetlinkMessage(auditRecordTypeEOE,
+		"audit(1539500005.300:103): "))
+	require.NoError(t, err)
+	assert.Len(t, a.pending, 0)
+}
+
+func TestAuditSyscallEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	l := newTestAuditListener(sensor)
+	sensor.useAuditBackend = true
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterFileOpenEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+	assert.Len(t, l.handlers, 0)
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("filename"),
+		expression.Value("/etc/passwd"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterFileOpenEventFilter(expr)
+	s.RegisterProcessExecEventFilter(expr)
+	s.RegisterNetworkConnectAttemptEventFilter(nil)
+	assert.Len(t, s.eventSinks, 3)
+	assert.Len(t, s.status, 0)
+	for _, es := range s.eventSinks {
+		assert.NotEqual(t, sensor.ProcessCache.ProcessExecEventID, es.eventID)
+	}
+
+	// The audit record handlers are only installed once
+	s = newTestSubscription(t, sensor)
+	s.RegisterProcessExecEventFilter(nil)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, l.handlers[auditRecordTypeSyscall], 1)
+	assert.Len(t, l.handlers[auditRecordTypeEOE], 1)
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
+)
+
+// newTestAuditListener installs an audit listener that is not connected to
+// the kernel, so that records can be dispatched to it directly.
+func newTestAuditListener(sensor *Sensor) *auditListener {
+	l := &auditListener{
+		sensor:   sensor,
+		fd:       -1,
+		handlers: make(map[uint16][]auditRecordHandler),
+	}
+	sensor.auditLock.Lock()
+	sensor.audit = l
+	sensor.auditLock.Unlock()
+	return l
+}
+
+func newTestNetlinkMessage(recordType uint16, text string) []byte {
+	length := unix.NLMSG_HDRLEN + len(text)
+	b := make([]byte, (length+unix.NLMSG_ALIGNTO-1) & ^(unix.NLMSG_ALIGNTO-1))
+	binary.LittleEndian.PutUint32(b[0:4], uint32(length))
+	binary.LittleEndian.PutUint16(b[4:6], recordType)
+	copy(b[unix.NLMSG_HDRLEN:], text)
+	return b
+}
+
+func TestParseAuditRecord(t *testing.T) {
+	r, err := parseAuditRecord(auditRecordTypeAVC,
+		[]byte("audit(1539500000.123:4567): apparmor=\"DENIED\"\x00"))
+	require.NoError(t, err)
+	assert.Equal(t, uint16(auditRecordTypeAVC), r.Type)
+	assert.Equal(t, uint64(4567), r.Serial)
+	assert.Equal(t, `apparmor="DENIED"`, r.Text)
+
+	badRecords := []string{
+		"apparmor=\"DENIED\"",
+		"audit(1539500000.123:4567) apparmor=\"DENIED\"",
+		"audit(1539500000.123): apparmor=\"DENIED\"",
+		"audit(1539500000.123:abc): apparmor=\"DENIED\"",
+	}
+	for _, text := range badRecords {
+		_, err = parseAuditRecord(auditRecordTypeAVC, []byte(text))
+		assert.Error(t, err, text)
+	}
+}
+
+func TestAuditRecordFields(t *testing.T) {
+	r := auditRecord{
+		Text: `avc:  denied  { read } for  pid=111343 comm="my prog" ` +
+			`name=2F746D702F6120622E747874 exe=/bin/bash key=(null) ` +
+			`bare "x" tclass=file permissive=0`,
+	}
+	expected := map[string]string{
+		"pid":        "111343",
+		"comm":       "my prog",
+		"name":       "/tmp/a b.txt",
+		"exe":        "/bin/bash",
+		"key":        "(null)",
+		"tclass":     "file",
+		"permissive": "0",
+	}
+	assert.Equal(t, expected, r.fields())
+}
+
+func TestAuditListenerDispatch(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	l := newTestAuditListener(sensor)
+	l2, err := sensor.auditListener()
+	require.NoError(t, err)
+	require.Equal(t, l, l2)
+
+	var records []*auditRecord
+	l.handleRecordType(auditRecordTypeAVC,
+		func(_ perf.SampleID, r *auditRecord) {
+			records = append(records, r)
+		})
+
+	var b []byte
+	b = append(b, newTestNetlinkMessage(1300, "audit(1.0:1): arch=c000003e")...)
+	b = append(b, newTestNetlinkMessage(auditRecordTypeAVC, "audit(1.0:1): apparmor=\"DENIED\"")...)
+	err = l.dispatch(perf.SampleID{Time: 1}, b)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(1), records[0].Serial)
+
+	err = l.dispatch(perf.SampleID{Time: 1},
+		newTestNetlinkMessage(auditRecordTypeAVC, "garbage"))
+	assert.Error(t, err)
+}
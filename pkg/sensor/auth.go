@@ -0,0 +1,297 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Clients authenticate to the telemetry service with a bearer token in the
+// "authorization" metadata of each call when the sensor is configured with
+// a tokens file, i.e.
+//
+//	{
+//		"tokens": [
+//			{"name": "containers", "token": "...",
+//			 "event_filters": ["container_events"]},
+//			{"name": "no-tty", "token": "...",
+//			 "exclude_event_filters": ["tty_events"]},
+//			{"name": "operators", "token": "...", "admin": true}
+//		]
+//	}
+//
+// Each token is limited to the EventFilter fields, named as in the protobuf
+// definition, in its event_filters, if any, and not in its
+// exclude_event_filters. Only admin tokens may list the subscriptions of
+// all clients, get their statistics, modify those of other clients, replay
+// the spool, and change the Sensor's log level.
+
+const telemetryServiceMethodPrefix = "/capsule8.api.v0.TelemetryService/"
+
+// Methods that require an admin token
+var adminMethods = map[string]bool{
+	telemetryServiceMethodPrefix + "ListSubscriptions": true,
+	telemetryServiceMethodPrefix + "GetStatistics":     true,
+	telemetryServiceMethodPrefix + "ReplayEvents":      true,
+	telemetryServiceMethodPrefix + "SetLogLevel":       true,
+}
+
+var (
+	eventFilterNamesOnce sync.Once
+	eventFilterNames     map[string]int
+)
+
+func initEventFilterNames() {
+	eventFilterNames = make(map[string]int)
+	t := reflect.TypeOf(api.EventFilter{})
+	for i := 0; i < t.NumField(); i++ {
+		if name := protobufFieldName(t.Field(i)); name != "" {
+			eventFilterNames[name] = i
+		}
+	}
+}
+
+// tokenScope is the access granted to a token.
+type tokenScope struct {
+	name                string
+	eventFilters        map[string]bool
+	excludeEventFilters map[string]bool
+	admin               bool
+}
+
+func newEventFilterSet(names []string) (map[string]bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	eventFilterNamesOnce.Do(initEventFilterNames)
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, ok := eventFilterNames[name]; !ok {
+			return nil, fmt.Errorf("event filter %q is invalid", name)
+		}
+		set[name] = true
+	}
+	return set, nil
+}
+
+// authorize returns an error if a subscription requests events that are
+// not permitted by the scope. Subscriptions that correlate containers
+// request container events. A nil scope permits all events.
+func (s *tokenScope) authorize(sub *api.Subscription) error {
+	if s == nil {
+		return nil
+	}
+	eventFilterNamesOnce.Do(initEventFilterNames)
+	requested := make(map[string]bool)
+	if sub.EventFilter != nil {
+		v := reflect.ValueOf(sub.EventFilter).Elem()
+		for name, i := range eventFilterNames {
+			if v.Field(i).Len() > 0 {
+				requested[name] = true
+			}
+		}
+	}
+	if sub.CorrelateContainers {
+		// Correlated streams are sent container events, including
+		// their configurations, whether or not they are requested
+		requested["container_events"] = true
+	}
+	var denied []string
+	for name := range requested {
+		if (s.eventFilters != nil && !s.eventFilters[name]) ||
+			s.excludeEventFilters[name] {
+			denied = append(denied, name)
+		}
+	}
+	if len(denied) > 0 {
+		sort.Strings(denied)
+		return permissionDenied("Subscription is not permitted (%s)",
+			strings.Join(denied, ", "))
+	}
+	return nil
+}
+
+// permits reports whether a scope may act on subscriptions made with
+// another scope. A nil scope permits everything.
+func (s *tokenScope) permits(other *tokenScope) bool {
+	return s == nil || s.admin || other == nil || s.name == other.name
+}
+
+// permissionDenied returns the error for a call that its token does not
+// permit.
+func permissionDenied(format string, a ...interface{}) error {
+	return status.Errorf(codes.PermissionDenied, format, a...)
+}
+
+type tokenScopeKey struct{}
+
+// tokenScopeFromContext returns the scope of the token that a call was
+// authenticated with, or nil if tokens are not used.
+func tokenScopeFromContext(ctx context.Context) *tokenScope {
+	s, _ := ctx.Value(tokenScopeKey{}).(*tokenScope)
+	return s
+}
+
+// tokenAuthenticator authenticates calls to the telemetry service.
+type tokenAuthenticator struct {
+	// Tokens are looked up by their hashes so that the time taken does
+	// not depend on how much of a token matches
+	scopes map[[sha256.Size]byte]*tokenScope
+}
+
+type tokensFile struct {
+	Tokens []struct {
+		Name                string   `json:"name"`
+		Token               string   `json:"token"`
+		EventFilters        []string `json:"event_filters"`
+		ExcludeEventFilters []string `json:"exclude_event_filters"`
+		Admin               bool     `json:"admin"`
+	} `json:"tokens"`
+}
+
+func newTokenAuthenticator(path string) (*tokenAuthenticator, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f tokensFile
+	if err = json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	a := &tokenAuthenticator{
+		scopes: make(map[[sha256.Size]byte]*tokenScope),
+	}
+	names := make(map[string]bool)
+	for _, t := range f.Tokens {
+		if t.Name == "" || names[t.Name] {
+			return nil, fmt.Errorf("%s: token name %q is invalid",
+				path, t.Name)
+		}
+		names[t.Name] = true
+		if t.Token == "" {
+			return nil, fmt.Errorf("%s: token %q is empty", path, t.Name)
+		}
+		s := &tokenScope{
+			name:  t.Name,
+			admin: t.Admin,
+		}
+		if s.eventFilters, err = newEventFilterSet(t.EventFilters); err != nil {
+			return nil, fmt.Errorf("%s: token %q %v", path, t.Name, err)
+		}
+		if s.excludeEventFilters, err = newEventFilterSet(t.ExcludeEventFilters); err != nil {
+			return nil, fmt.Errorf("%s: token %q %v", path, t.Name, err)
+		}
+		a.scopes[sha256.Sum256([]byte(t.Token))] = s
+	}
+	if len(a.scopes) == 0 {
+		return nil, fmt.Errorf("%s: no tokens", path)
+	}
+	glog.V(1).Infof("Loaded %d telemetry service tokens from %s",
+		len(a.scopes), path)
+	return a, nil
+}
+
+// authenticate returns a context carrying the scope of the bearer token
+// of a call.
+func (a *tokenAuthenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md["authorization"] {
+			if strings.HasPrefix(v, "Bearer ") {
+				token = strings.TrimPrefix(v, "Bearer ")
+				break
+			}
+		}
+	}
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated,
+			"No bearer token")
+	}
+	s, ok := a.scopes[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated,
+			"Bearer token is invalid")
+	}
+	if adminMethods[method] && !s.admin {
+		return nil, permissionDenied("Token %q may not call %s", s.name,
+			strings.TrimPrefix(method, telemetryServiceMethodPrefix))
+	}
+	return context.WithValue(ctx, tokenScopeKey{}, s), nil
+}
+
+func (a *tokenAuthenticator) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authenticatedStream replaces the context of a stream with one carrying
+// the scope of its token.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *tokenAuthenticator) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{
+		ServerStream: ss,
+		ctx:          ctx,
+	})
+}
+
+func (a *tokenAuthenticator) serverOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(a.unaryInterceptor),
+		grpc.StreamInterceptor(a.streamInterceptor),
+	}
+}
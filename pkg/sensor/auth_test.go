@@ -0,0 +1,225 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testTokens = `{
+	"tokens": [
+		{"name": "tickers", "token": "ticker-token",
+		 "event_filters": ["ticker_events"]},
+		{"name": "no-tty", "token": "no-tty-token",
+		 "exclude_event_filters": ["tty_events"]},
+		{"name": "admin", "token": "admin-token", "admin": true}
+	]
+}`
+
+func writeTestTokens(t *testing.T, dir, contents string) string {
+	path := filepath.Join(dir, "tokens.json")
+	writeFile(t, path, []byte(contents))
+	return path
+}
+
+func TestNewTokenAuthenticator(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	_, err := newTokenAuthenticator(filepath.Join(sensor.runtimeDir, "missing"))
+	assert.Error(t, err)
+
+	bad := []string{
+		`not json`,
+		`{"tokens": []}`,
+		`{"tokens": [{"token": "x"}]}`,
+		`{"tokens": [{"name": "a"}]}`,
+		`{"tokens": [{"name": "a", "token": "x"}, {"name": "a", "token": "y"}]}`,
+		`{"tokens": [{"name": "a", "token": "x", "event_filters": ["no_such_events"]}]}`,
+		`{"tokens": [{"name": "a", "token": "x", "exclude_event_filters": ["no_such_events"]}]}`,
+	}
+	for _, contents := range bad {
+		_, err = newTokenAuthenticator(writeTestTokens(t, sensor.runtimeDir, contents))
+		assert.Error(t, err, contents)
+	}
+
+	a, err := newTokenAuthenticator(writeTestTokens(t, sensor.runtimeDir, testTokens))
+	require.NoError(t, err)
+	assert.Len(t, a.scopes, 3)
+}
+
+func authenticatedContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestTokenAuthenticate(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	a, err := newTokenAuthenticator(writeTestTokens(t, sensor.runtimeDir, testTokens))
+	require.NoError(t, err)
+
+	getEvents := telemetryServiceMethodPrefix + "GetEvents"
+	listSubscriptions := telemetryServiceMethodPrefix + "ListSubscriptions"
+
+	_, err = a.authenticate(context.Background(), getEvents)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = a.authenticate(authenticatedContext("wrong"), getEvents)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx, err := a.authenticate(authenticatedContext("ticker-token"), getEvents)
+	require.NoError(t, err)
+	tickers := tokenScopeFromContext(ctx)
+	require.NotNil(t, tickers)
+	assert.Equal(t, "tickers", tickers.name)
+
+	_, err = a.authenticate(authenticatedContext("ticker-token"), listSubscriptions)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	ctx, err = a.authenticate(authenticatedContext("admin-token"), listSubscriptions)
+	require.NoError(t, err)
+	admin := tokenScopeFromContext(ctx)
+
+	ctx, err = a.authenticate(authenticatedContext("no-tty-token"), getEvents)
+	require.NoError(t, err)
+	noTTY := tokenScopeFromContext(ctx)
+
+	tickerSub := &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{Interval: 1},
+			},
+		},
+	}
+	ttySub := &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{Interval: 1},
+			},
+			TtyEvents: []*api.TtyEventFilter{
+				&api.TtyEventFilter{},
+			},
+		},
+	}
+	var none *tokenScope
+	assert.NoError(t, none.authorize(ttySub))
+	assert.NoError(t, tickers.authorize(tickerSub))
+	assert.Equal(t, codes.PermissionDenied, status.Code(tickers.authorize(ttySub)))
+	assert.NoError(t, noTTY.authorize(tickerSub))
+	assert.Error(t, noTTY.authorize(ttySub))
+	assert.NoError(t, admin.authorize(ttySub))
+
+	// Correlated streams are sent container events
+	correlatedSub := &api.Subscription{
+		EventFilter:         tickerSub.EventFilter,
+		CorrelateContainers: true,
+	}
+	err = tickers.authorize(correlatedSub)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Contains(t, err.Error(), "container_events")
+	assert.Error(t, tickers.authorize(&api.Subscription{
+		CorrelateContainers: true,
+	}))
+	assert.NoError(t, noTTY.authorize(correlatedSub))
+	assert.NoError(t, admin.authorize(correlatedSub))
+
+	assert.True(t, none.permits(tickers))
+	assert.True(t, tickers.permits(tickers))
+	assert.True(t, admin.permits(tickers))
+	assert.False(t, noTTY.permits(tickers))
+}
+
+func TestTelemetryServiceTokens(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	config.Sensor.UseTLS = false
+	config.Sensor.AuthTokensPath = writeTestTokens(t, sensor.runtimeDir, testTokens)
+	defer func() { config.Sensor.AuthTokensPath = "" }()
+
+	address := "unix:" + filepath.Join(sensor.runtimeDir, "socket")
+	service := NewTelemetryService(sensor, address)
+	go service.Serve()
+	defer service.Stop()
+	time.Sleep(200 * time.Millisecond)
+
+	conn, err := grpc.Dial(address,
+		grpc.WithDialer(dialer),
+		grpc.WithBlock(),
+		grpc.WithTimeout(1*time.Second),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := api.NewTelemetryServiceClient(conn)
+
+	tokenContext := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(),
+			"authorization", "Bearer "+token)
+	}
+	sub := &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{
+					Interval: int64(10 * time.Millisecond),
+				},
+			},
+		},
+	}
+	getEvents := func(ctx context.Context, sub *api.Subscription) (*api.GetEventsResponse, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := client.GetEvents(ctx, &api.GetEventsRequest{
+			Subscription: sub,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return stream.Recv()
+	}
+
+	_, err = getEvents(context.Background(), sub)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	r, err := getEvents(tokenContext("ticker-token"), sub)
+	require.NoError(t, err)
+	assert.NotZero(t, r.SubscriptionId)
+
+	sub.EventFilter.ChargenEvents = []*api.ChargenEventFilter{
+		&api.ChargenEventFilter{Length: 1},
+	}
+	_, err = getEvents(tokenContext("ticker-token"), sub)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.ListSubscriptions(tokenContext("ticker-token"),
+		&api.ListSubscriptionsRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.ListSubscriptions(tokenContext("admin-token"),
+		&api.ListSubscriptionsRequest{})
+	assert.NoError(t, err)
+}
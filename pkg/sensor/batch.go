@@ -0,0 +1,84 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+const (
+	// The most events that a BatchModifier may send in one response
+	maxEventBatchSize = 10000
+
+	// The longest max latency that a BatchModifier may have
+	maxEventBatchLatency = 10 * time.Second
+
+	// The max latency of a BatchModifier that does not set one
+	defaultEventBatchLatency = 100 * time.Millisecond
+)
+
+// eventBatch collects the events sent to a GetEvents stream with a
+// BatchModifier until they are sent together in one response.
+type eventBatch struct {
+	maxEvents  int
+	maxLatency time.Duration
+	events     []*api.ReceivedTelemetryEvent
+}
+
+func newEventBatch(m *api.BatchModifier) (*eventBatch, error) {
+	if m.MaxEvents < 1 || m.MaxEvents > maxEventBatchSize {
+		return nil, fmt.Errorf("max events is invalid (%d)", m.MaxEvents)
+	}
+	b := &eventBatch{
+		maxEvents:  int(m.MaxEvents),
+		maxLatency: defaultEventBatchLatency,
+	}
+	if m.MaxLatency != 0 {
+		d, err := throttleInterval(m.MaxLatency, m.MaxLatencyType)
+		if err != nil {
+			return nil, fmt.Errorf("max latency %v", err)
+		}
+		if d > maxEventBatchLatency {
+			return nil, fmt.Errorf("max latency is invalid (%s)", d)
+		}
+		b.maxLatency = d
+	}
+	return b, nil
+}
+
+// add adds an event to the batch, returning whether the batch is full and
+// must be sent.
+func (b *eventBatch) add(re *api.ReceivedTelemetryEvent) bool {
+	if b.events == nil {
+		b.events = make([]*api.ReceivedTelemetryEvent, 0, b.maxEvents)
+	}
+	b.events = append(b.events, re)
+	return len(b.events) >= b.maxEvents
+}
+
+// empty returns whether the batch has no events.
+func (b *eventBatch) empty() bool {
+	return len(b.events) == 0
+}
+
+// flush returns the events in the batch and empties it.
+func (b *eventBatch) flush() []*api.ReceivedTelemetryEvent {
+	events := b.events
+	b.events = nil
+	return events
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventBatch(t *testing.T) {
+	for _, m := range []*api.BatchModifier{
+		&api.BatchModifier{},
+		&api.BatchModifier{MaxEvents: maxEventBatchSize + 1},
+		&api.BatchModifier{MaxEvents: 10, MaxLatency: -1},
+		&api.BatchModifier{MaxEvents: 10, MaxLatency: 11,
+			MaxLatencyType: api.ThrottleModifier_SECOND},
+		&api.BatchModifier{MaxEvents: 10, MaxLatency: 1,
+			MaxLatencyType: 8888},
+	} {
+		_, err := newEventBatch(m)
+		assert.Error(t, err, "%+v", m)
+	}
+
+	b, err := newEventBatch(&api.BatchModifier{MaxEvents: 10})
+	require.NoError(t, err)
+	assert.Equal(t, defaultEventBatchLatency, b.maxLatency)
+
+	b, err = newEventBatch(&api.BatchModifier{
+		MaxEvents:      10,
+		MaxLatency:     5,
+		MaxLatencyType: api.ThrottleModifier_MILLISECOND,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Millisecond, b.maxLatency)
+}
+
+func TestEventBatch(t *testing.T) {
+	b, err := newEventBatch(&api.BatchModifier{MaxEvents: 3})
+	require.NoError(t, err)
+	assert.True(t, b.empty())
+
+	events := []*api.ReceivedTelemetryEvent{
+		&api.ReceivedTelemetryEvent{},
+		&api.ReceivedTelemetryEvent{},
+		&api.ReceivedTelemetryEvent{},
+	}
+	assert.False(t, b.add(events[0]))
+	assert.False(t, b.add(events[1]))
+	assert.False(t, b.empty())
+	assert.True(t, b.add(events[2]))
+	assert.Equal(t, events, b.flush())
+	assert.True(t, b.empty())
+
+	assert.False(t, b.add(events[0]))
+	assert.Equal(t, events[:1], b.flush())
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// BPFProgramLoadEventTypes defines the field types that can be used with
+// filters on BPF program load telemetry events.
+var BPFProgramLoadEventTypes = expression.FieldTypeMap{
+	"prog_type": expression.ValueTypeUnsignedInt32,
+	"insn_cnt":  expression.ValueTypeUnsignedInt32,
+	"prog_name": expression.ValueTypeString,
+}
+
+// BPFProgramAttachEventTypes defines the field types that can be used with
+// filters on BPF program attach telemetry events.
+var BPFProgramAttachEventTypes = expression.FieldTypeMap{
+	"attach_type": expression.ValueTypeUnsignedInt32,
+	"prog_fd":     expression.ValueTypeSignedInt32,
+	"target_fd":   expression.ValueTypeSignedInt32,
+}
+
+// BPFProgramLoadTelemetryEvent is a telemetry event generated by the BPF
+// event source when a process loads a BPF program into the kernel.
+type BPFProgramLoadTelemetryEvent struct {
+	TelemetryEventData
+
+	ProgramType      uint32
+	ProgramName      string
+	InstructionCount uint32
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a BPF program load telemetry event.
+func (e BPFProgramLoadTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// BPFProgramAttachTelemetryEvent is a telemetry event generated by the BPF
+// event source when a process attaches a loaded BPF program to a hook, either
+// directly or by creating a link.
+type BPFProgramAttachTelemetryEvent struct {
+	TelemetryEventData
+
+	AttachType uint32
+	ProgramFD  int32
+	TargetFD   int32
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a BPF program attach telemetry event.
+func (e BPFProgramAttachTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// bpf(2) commands from include/uapi/linux/bpf.h
+const (
+	bpfCmdProgLoad   = 5
+	bpfCmdProgAttach = 8
+	bpfCmdLinkCreate = 28
+
+	// The length of bpf_attr.prog_name, including its terminator
+	bpfObjNameLen = 16
+)
+
+// security_bpf() is called for every bpf(2) command once the attributes have
+// been copied into the kernel, so the attributes can be fetched without racing
+// against user memory. The offsets are those of the union bpf_attr members
+// used by each command. BPF_PROG_ATTACH places the target first, whereas
+// BPF_LINK_CREATE places the program first; the decoder swaps them for the
+// latter. security_bpf() first appeared in Linux 4.15.
+const (
+	bpfKprobeSymbol          = "security_bpf"
+	bpfLoadKprobeFetchargs   = "cmd=%di:s32 prog_type=+0(%si):u32 insn_cnt=+4(%si):u32 prog_name=+48(%si):string"
+	bpfAttachKprobeFetchargs = "cmd=%di:s32 target_fd=+0(%si):s32 prog_fd=+4(%si):s32 attach_type=+8(%si):u32"
+)
+
+var (
+	bpfLoadKprobeFilter   = fmt.Sprintf("cmd == %d", bpfCmdProgLoad)
+	bpfAttachKprobeFilter = fmt.Sprintf("cmd == %d || cmd == %d",
+		bpfCmdProgAttach, bpfCmdLinkCreate)
+)
+
+func (s *Subscription) decodeBPFProgLoad(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e BPFProgramLoadTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+
+	// prog_name is a fixed size array that the kernel has not validated
+	// yet, so it may not be terminated.
+	name := data["prog_name"].(string)
+	if len(name) >= bpfObjNameLen {
+		name = name[:bpfObjNameLen-1]
+	}
+	e.ProgramType = data["prog_type"].(uint32)
+	e.ProgramName = name
+	e.InstructionCount = data["insn_cnt"].(uint32)
+
+	// Make the truncated name visible to filter expressions, which are
+	// evaluated against the sample data after decoding.
+	data["prog_name"] = name
+
+	return e, nil
+}
+
+func (s *Subscription) decodeBPFProgAttach(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e BPFProgramAttachTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+
+	e.AttachType = data["attach_type"].(uint32)
+	e.ProgramFD = data["prog_fd"].(int32)
+	e.TargetFD = data["target_fd"].(int32)
+	if data["cmd"].(int32) == bpfCmdLinkCreate {
+		e.ProgramFD, e.TargetFD = e.TargetFD, e.ProgramFD
+	}
+
+	// Make the file descriptors visible to filter expressions, which are
+	// evaluated against the sample data after decoding.
+	data["prog_fd"] = e.ProgramFD
+	data["target_fd"] = e.TargetFD
+
+	return e, nil
+}
+
+// RegisterBPFProgramLoadEventFilter registers a BPF program load event filter
+// with a subscription.
+func (s *Subscription) RegisterBPFProgramLoadEventFilter(expr *expression.Expression) {
+	s.registerWithSensorFilter("BPF", expr, BPFProgramLoadEventTypes,
+		func() (*eventSink, error) {
+			// The kernel filter is needed to select the command
+			return s.registerKprobe(bpfKprobeSymbol, false,
+				bpfLoadKprobeFetchargs, s.decodeBPFProgLoad, nil,
+				BPFProgramLoadEventTypes, perf.WithFilter(bpfLoadKprobeFilter))
+		})
+}
+
+// RegisterBPFProgramAttachEventFilter registers a BPF program attach event
+// filter with a subscription.
+func (s *Subscription) RegisterBPFProgramAttachEventFilter(expr *expression.Expression) {
+	s.registerWithSensorFilter("BPF", expr, BPFProgramAttachEventTypes,
+		func() (*eventSink, error) {
+			// The kernel filter is needed to select the commands
+			return s.registerKprobe(bpfKprobeSymbol, false,
+				bpfAttachKprobeFetchargs, s.decodeBPFProgAttach, nil,
+				BPFProgramAttachEventTypes, perf.WithFilter(bpfAttachKprobeFilter))
+		})
+}
@@ -0,0 +1,200 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBPFProgLoad(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"cmd":        int32(bpfCmdProgLoad),
+		"prog_type":  uint32(2),
+		"insn_cnt":   uint32(64),
+		"prog_name":  "kprobe_execve",
+	}
+
+	i, err := s.decodeBPFProgLoad(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	data["common_pid"] = int32(111343)
+	i, err = s.decodeBPFProgLoad(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, BPFProgramLoadTelemetryEvent{}, i)
+
+	e := i.(BPFProgramLoadTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+		e.Container.ID)
+	assert.Equal(t, uint32(2), e.ProgramType)
+	assert.Equal(t, "kprobe_execve", e.ProgramName)
+	assert.Equal(t, uint32(64), e.InstructionCount)
+
+	// Names that are not terminated are read past the end of the array
+	data["prog_name"] = "abcdefghijklmnopqrstuvwxyz"
+	i, err = s.decodeBPFProgLoad(sample, data)
+	require.NoError(t, err)
+	e = i.(BPFProgramLoadTelemetryEvent)
+	assert.Equal(t, "abcdefghijklmno", e.ProgramName)
+	assert.Equal(t, "abcdefghijklmno", data["prog_name"])
+}
+
+func TestDecodeBPFProgAttach(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid":  int32(111343),
+		"cmd":         int32(bpfCmdProgAttach),
+		"target_fd":   int32(3),
+		"prog_fd":     int32(4),
+		"attach_type": uint32(1),
+	}
+
+	i, err := s.decodeBPFProgAttach(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, BPFProgramAttachTelemetryEvent{}, i)
+
+	e := i.(BPFProgramAttachTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, uint32(1), e.AttachType)
+	assert.Equal(t, int32(4), e.ProgramFD)
+	assert.Equal(t, int32(3), e.TargetFD)
+
+	// BPF_LINK_CREATE places the program first
+	data["cmd"] = int32(bpfCmdLinkCreate)
+	i, err = s.decodeBPFProgAttach(sample, data)
+	require.NoError(t, err)
+	e = i.(BPFProgramAttachTelemetryEvent)
+	assert.Equal(t, int32(3), e.ProgramFD)
+	assert.Equal(t, int32(4), e.TargetFD)
+	assert.Equal(t, int32(3), data["prog_fd"])
+	assert.Equal(t, int32(4), data["target_fd"])
+}
+
+func prepareForRegisterBPFProgramLoadEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:s32 cmd;	offset:16;	size:4;	signed:1;
+	field:u32 prog_type;	offset:20;	size:4;	signed:0;
+	field:u32 insn_cnt;	offset:24;	size:4;	signed:0;
+	field:__data_loc char[] prog_name;	offset:28;	size:4;	signed:1;
+
+print fmt: "(%lx) cmd=%d prog_type=%u insn_cnt=%u prog_name=\"%s\"", REC->__probe_ip, REC->cmd, REC->prog_type, REC->insn_cnt, __get_str(prog_name)`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func prepareForRegisterBPFProgramAttachEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:s32 cmd;	offset:16;	size:4;	signed:1;
+	field:s32 target_fd;	offset:20;	size:4;	signed:1;
+	field:s32 prog_fd;	offset:24;	size:4;	signed:1;
+	field:u32 attach_type;	offset:28;	size:4;	signed:0;
+
+print fmt: "(%lx) cmd=%d target_fd=%d prog_fd=%d attach_type=%u", REC->__probe_ip, REC->cmd, REC->target_fd, REC->prog_fd, REC->attach_type`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func TestBPFProgramLoadEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("prog_type"),
+		expression.Value(uint32(2)))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	prepareForRegisterBPFProgramLoadEventFilter(t, s, 0)
+	s.RegisterBPFProgramLoadEventFilter(expr)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterBPFProgramLoadEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
+
+func TestBPFProgramAttachEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	prepareForRegisterBPFProgramAttachEventFilter(t, s, 0)
+	s.RegisterBPFProgramAttachEventFilter(nil)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+
+	s = newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterBPFProgramAttachEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
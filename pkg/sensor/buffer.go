@@ -0,0 +1,111 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/config"
+)
+
+const (
+	// The largest buffer a subscription may request
+	maxEventBufferLength = 1 << 20
+
+	// The longest time that delivery may block on a full buffer
+	maxEventBufferBlockTimeout = time.Second
+)
+
+// eventBuffer holds the events delivered to a subscription by the sensor
+// until they are sent to the client. Events are added by the sensor's
+// dispatch, which must not be held up by a slow client for longer than the
+// buffer's overflow policy allows.
+type eventBuffer struct {
+	events       chan TelemetryEvent
+	policy       api.BufferModifier_OverflowPolicy
+	blockTimeout time.Duration
+}
+
+func newEventBuffer(m *api.BufferModifier) (*eventBuffer, error) {
+	b := &eventBuffer{}
+	length := config.CurrentSensor().ChannelBufferLength
+	if m != nil {
+		if m.Length > maxEventBufferLength {
+			return nil, fmt.Errorf("length is invalid (%d)", m.Length)
+		} else if m.Length > 0 {
+			length = int(m.Length)
+		}
+
+		switch m.OverflowPolicy {
+		case api.BufferModifier_DROP_NEWEST, api.BufferModifier_DROP_OLDEST:
+		case api.BufferModifier_BLOCK:
+			d, err := throttleInterval(m.BlockTimeout, m.BlockTimeoutType)
+			if err != nil {
+				return nil, fmt.Errorf("block timeout %v", err)
+			}
+			if d > maxEventBufferBlockTimeout {
+				return nil, fmt.Errorf("block timeout is invalid (%s)", d)
+			}
+			b.blockTimeout = d
+		default:
+			return nil, fmt.Errorf("overflow policy is invalid (%d)",
+				m.OverflowPolicy)
+		}
+		b.policy = m.OverflowPolicy
+	}
+	b.events = make(chan TelemetryEvent, length)
+	return b, nil
+}
+
+// add adds an event to the buffer, returning the number of events that were
+// dropped to do so, which is either the event itself or the oldest buffered
+// event.
+func (b *eventBuffer) add(e TelemetryEvent) int {
+	select {
+	case b.events <- e:
+		return 0
+	default:
+	}
+
+	switch b.policy {
+	case api.BufferModifier_DROP_OLDEST:
+		// The client may empty the buffer or another event may fill it
+		// between these, so keep trying until there is room
+		dropped := 0
+		for {
+			select {
+			case b.events <- e:
+				return dropped
+			default:
+			}
+			select {
+			case <-b.events:
+				dropped++
+			default:
+			}
+		}
+	case api.BufferModifier_BLOCK:
+		timer := time.NewTimer(b.blockTimeout)
+		defer timer.Stop()
+		select {
+		case b.events <- e:
+			return 0
+		case <-timer.C:
+		}
+	}
+	return 1
+}
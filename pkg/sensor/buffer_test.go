@@ -0,0 +1,116 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventBuffer(t *testing.T) {
+	b, err := newEventBuffer(nil)
+	require.NoError(t, err)
+	assert.Equal(t, config.Sensor.ChannelBufferLength, cap(b.events))
+	assert.Equal(t, api.BufferModifier_DROP_NEWEST, b.policy)
+
+	b, err = newEventBuffer(&api.BufferModifier{
+		Length:           8,
+		OverflowPolicy:   api.BufferModifier_BLOCK,
+		BlockTimeout:     10,
+		BlockTimeoutType: api.ThrottleModifier_MILLISECOND,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 8, cap(b.events))
+	assert.Equal(t, 10*time.Millisecond, b.blockTimeout)
+
+	bad := []*api.BufferModifier{
+		&api.BufferModifier{
+			Length: maxEventBufferLength + 1,
+		},
+		&api.BufferModifier{
+			OverflowPolicy: 8888,
+		},
+		&api.BufferModifier{
+			OverflowPolicy: api.BufferModifier_BLOCK,
+		},
+		&api.BufferModifier{
+			OverflowPolicy:   api.BufferModifier_BLOCK,
+			BlockTimeout:     2,
+			BlockTimeoutType: api.ThrottleModifier_SECOND,
+		},
+	}
+	for _, m := range bad {
+		_, err = newEventBuffer(m)
+		assert.Error(t, err, "%+v", m)
+	}
+}
+
+func bufferedTickerSeconds(b *eventBuffer) []int64 {
+	var seconds []int64
+	for len(b.events) > 0 {
+		e := <-b.events
+		seconds = append(seconds, e.(TickerTelemetryEvent).Seconds)
+	}
+	return seconds
+}
+
+func TestEventBufferOverflow(t *testing.T) {
+	tests := []struct {
+		policy   api.BufferModifier_OverflowPolicy
+		expected []int64
+	}{
+		{api.BufferModifier_DROP_NEWEST, []int64{0, 1}},
+		{api.BufferModifier_DROP_OLDEST, []int64{2, 3}},
+		{api.BufferModifier_BLOCK, []int64{0, 1}},
+	}
+	for _, tc := range tests {
+		b, err := newEventBuffer(&api.BufferModifier{
+			Length:           2,
+			OverflowPolicy:   tc.policy,
+			BlockTimeout:     1,
+			BlockTimeoutType: api.ThrottleModifier_MILLISECOND,
+		})
+		require.NoError(t, err)
+
+		dropped := 0
+		for i := int64(0); i < 4; i++ {
+			dropped += b.add(TickerTelemetryEvent{Seconds: i})
+		}
+		assert.Equal(t, 2, dropped, "%s", tc.policy)
+		assert.Equal(t, tc.expected, bufferedTickerSeconds(b), "%s", tc.policy)
+	}
+
+	// A blocked event is added when the client makes room for it
+	b, err := newEventBuffer(&api.BufferModifier{
+		Length:           1,
+		OverflowPolicy:   api.BufferModifier_BLOCK,
+		BlockTimeout:     1,
+		BlockTimeoutType: api.ThrottleModifier_SECOND,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, b.add(TickerTelemetryEvent{Seconds: 0}))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		<-b.events
+	}()
+	assert.Equal(t, 0, b.add(TickerTelemetryEvent{Seconds: 1}))
+	assert.Equal(t, []int64{1}, bufferedTickerSeconds(b))
+}
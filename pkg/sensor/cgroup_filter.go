@@ -0,0 +1,158 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/capsule8/capsule8/pkg/sys/bpf"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
+
+	"golang.org/x/sys/unix"
+)
+
+// cgroupFilter is a set of BPF programs that drop tracing events in the kernel
+// unless they occur in one of a set of cgroups. A single system-wide set of
+// event sources is then used rather than a set for each cgroup. The programs
+// only filter; the events that they keep are recorded and decoded exactly as
+// they are without them.
+type cgroupFilter struct {
+	mapFD    int
+	programs map[perf.EventType]int
+}
+
+// cgroup2Dir returns the mountpoint of the cgroup2 hierarchy, or the empty
+// string if none is mounted.
+func cgroup2Dir(procFS proc.FileSystem) string {
+	for _, mi := range procFS.Mounts() {
+		if mi.FilesystemType == "cgroup2" {
+			return mi.MountPoint
+		}
+	}
+	return ""
+}
+
+// cgroupFilterKeys returns the cgroup IDs of the named cgroups relative to the
+// cgroup2 mountpoint, along with the distinct hierarchy levels at which they
+// occur.
+func cgroupFilterKeys(dir string, names []string) ([]uint64, []int, error) {
+	ids := make([]uint64, 0, len(names))
+	seen := make(map[int]bool)
+	var levels []int
+	for _, name := range names {
+		var stat unix.Stat_t
+		if err := unix.Stat(filepath.Join(dir, name), &stat); err != nil {
+			return nil, nil, fmt.Errorf("cgroup %s: %v", name, err)
+		}
+		if stat.Mode&unix.S_IFMT != unix.S_IFDIR {
+			return nil, nil, fmt.Errorf("cgroup %s is not a directory",
+				name)
+		}
+		ids = append(ids, stat.Ino)
+
+		level := len(strings.Split(strings.Trim(filepath.Clean(name), "/"), "/"))
+		if !seen[level] {
+			seen[level] = true
+			levels = append(levels, level)
+		}
+	}
+	sort.Ints(levels)
+	return ids, levels, nil
+}
+
+func newCgroupFilter(procFS proc.FileSystem, cgroups []string) (*cgroupFilter, error) {
+	dir := cgroup2Dir(procFS)
+	if len(dir) == 0 {
+		return nil, errors.New("No cgroup2 filesystem is mounted")
+	}
+	ids, levels, err := cgroupFilterKeys(dir, cgroups)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &cgroupFilter{
+		programs: make(map[perf.EventType]int),
+	}
+	if f.mapFD, err = bpf.CreateHashMap(8, 1, uint32(len(ids))); err != nil {
+		return nil, err
+	}
+	key := make([]byte, 8)
+	for _, id := range ids {
+		binary.LittleEndian.PutUint64(key, id)
+		if err = bpf.UpdateElement(f.mapFD, key, []byte{1}); err != nil {
+			f.close()
+			return nil, err
+		}
+	}
+
+	// The kernel requires the program type to match the type of event
+	// that it is attached to. Kprobes and uprobes share a type.
+	insns := bpf.CgroupFilterProgram(f.mapFD, levels)
+	kprobeFD, err := bpf.LoadProgram(bpf.ProgramTypeKprobe, insns,
+		"Apache-2.0")
+	if err != nil {
+		f.close()
+		return nil, err
+	}
+	f.programs[perf.EventTypeKprobe] = kprobeFD
+	f.programs[perf.EventTypeUprobe] = kprobeFD
+
+	tracepointFD, err := bpf.LoadProgram(bpf.ProgramTypeTracepoint, insns,
+		"Apache-2.0")
+	if err != nil {
+		f.close()
+		return nil, err
+	}
+	f.programs[perf.EventTypeTracepoint] = tracepointFD
+
+	return f, nil
+}
+
+func (f *cgroupFilter) eventMonitorOptions() []perf.EventMonitorOption {
+	options := make([]perf.EventMonitorOption, 0, len(f.programs))
+	for eventType, fd := range f.programs {
+		options = append(options, perf.WithBPFFilterProgram(eventType, fd))
+	}
+	return options
+}
+
+func (f *cgroupFilter) close() {
+	closed := make(map[int]bool)
+	for _, fd := range f.programs {
+		if !closed[fd] {
+			closed[fd] = true
+			unix.Close(fd)
+		}
+	}
+	f.programs = nil
+	unix.Close(f.mapFD)
+}
+
+// monitorsSystem returns true if the sensor is configured to monitor the whole
+// system in addition to any specific cgroups.
+func (s *Sensor) monitorsSystem() bool {
+	for _, cgroup := range s.cgroupNames {
+		if len(cgroup) == 0 || cgroup == "/" {
+			return true
+		}
+	}
+	return false
+}
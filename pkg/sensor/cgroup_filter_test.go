@@ -0,0 +1,97 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/proc/procfs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestCgroup2Dir(t *testing.T) {
+	procFS, err := procfs.NewFileSystem("testdata")
+	require.NoError(t, err)
+	assert.Equal(t, "", cgroup2Dir(procFS))
+
+	// newCgroupFilter must fail before touching the kernel
+	_, err = newCgroupFilter(procFS, []string{"docker"})
+	assert.Error(t, err)
+
+	procDir, err := ioutil.TempDir("", "capsule8_")
+	require.NoError(t, err)
+	defer os.RemoveAll(procDir)
+
+	mountinfo := `19 24 0:18 / /sys rw,nosuid,nodev,noexec,relatime shared:7 - sysfs sysfs rw
+25 19 0:22 / /sys/fs/cgroup rw,nosuid,nodev,noexec,relatime shared:9 - cgroup2 cgroup2 rw
+`
+	writeFile(t, filepath.Join(procDir, "self", "mountinfo"),
+		[]byte(mountinfo))
+	procFS, err = procfs.NewFileSystem(procDir)
+	require.NoError(t, err)
+	assert.Equal(t, "/sys/fs/cgroup", cgroup2Dir(procFS))
+}
+
+func TestCgroupFilterKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capsule8_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	names := []string{"docker", "docker/abc", "system.slice"}
+	for _, name := range names {
+		err = os.MkdirAll(filepath.Join(dir, name), 0777)
+		require.NoError(t, err)
+	}
+	writeFile(t, filepath.Join(dir, "cgroup.procs"), []byte{})
+
+	var expIDs []uint64
+	for _, name := range names {
+		var stat unix.Stat_t
+		err = unix.Stat(filepath.Join(dir, name), &stat)
+		require.NoError(t, err)
+		expIDs = append(expIDs, stat.Ino)
+	}
+
+	ids, levels, err := cgroupFilterKeys(dir, names)
+	require.NoError(t, err)
+	assert.Equal(t, expIDs, ids)
+	assert.Equal(t, []int{1, 2}, levels)
+
+	// Leading and trailing slashes do not change the level
+	_, levels, err = cgroupFilterKeys(dir, []string{"/docker/abc/"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, levels)
+
+	_, _, err = cgroupFilterKeys(dir, []string{"missing"})
+	assert.Error(t, err)
+
+	_, _, err = cgroupFilterKeys(dir, []string{"cgroup.procs"})
+	assert.Error(t, err)
+}
+
+func TestMonitorsSystem(t *testing.T) {
+	s := Sensor{cgroupNames: []string{"docker"}}
+	assert.False(t, s.monitorsSystem())
+
+	s.cgroupNames = append(s.cgroupNames, "/")
+	assert.True(t, s.monitorsSystem())
+}
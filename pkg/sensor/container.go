@@ -17,14 +17,19 @@ package sensor
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
 	"sync"
 	"unicode"
 
 	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/logging"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 
 	"github.com/gobwas/glob"
 	"github.com/golang/glog"
+
+	"golang.org/x/sys/unix"
 )
 
 // ContainerEventTypes defines the field types that can be used with filters on
@@ -33,11 +38,13 @@ var ContainerEventTypes = expression.FieldTypeMap{
 	"name":             expression.ValueTypeString,
 	"image_id":         expression.ValueTypeString,
 	"image_name":       expression.ValueTypeString,
+	"image_digest":     expression.ValueTypeString,
 	"host_pid":         expression.ValueTypeSignedInt32,
 	"exit_code":        expression.ValueTypeSignedInt32,
 	"exit_status":      expression.ValueTypeUnsignedInt32,
 	"exit_signal":      expression.ValueTypeUnsignedInt32,
 	"exit_core_dumped": expression.ValueTypeBool,
+	"health_status":    expression.ValueTypeString,
 }
 
 // ContainerCreatedTelemetryEvent is a telemetry event generated by the
@@ -100,6 +107,42 @@ func (e ContainerUpdatedTelemetryEvent) CommonTelemetryEventData() TelemetryEven
 	return e.TelemetryEventData
 }
 
+// ContainerHealthTelemetryEvent is a telemetry event generated by the
+// container event source when the health status of a container changes.
+type ContainerHealthTelemetryEvent struct {
+	TelemetryEventData
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a container health telemetry event.
+func (e ContainerHealthTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// ContainerPausedTelemetryEvent is a telemetry event generated by the
+// container event source when a container is paused.
+type ContainerPausedTelemetryEvent struct {
+	TelemetryEventData
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a container paused telemetry event.
+func (e ContainerPausedTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// ContainerResumedTelemetryEvent is a telemetry event generated by the
+// container event source when a paused container is resumed.
+type ContainerResumedTelemetryEvent struct {
+	TelemetryEventData
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a container resumed telemetry event.
+func (e ContainerResumedTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
 // ContainerCache is a cache of container information
 type ContainerCache struct {
 	sync.Mutex
@@ -115,6 +158,9 @@ type ContainerCache struct {
 	ContainerExitedEventID    uint64
 	ContainerDestroyedEventID uint64
 	ContainerUpdatedEventID   uint64
+	ContainerHealthEventID    uint64
+	ContainerPausedEventID    uint64
+	ContainerResumedEventID   uint64
 }
 
 // ContainerState represents the state of a container (created, running, etc.)
@@ -176,12 +222,26 @@ type ContainerInfo struct {
 	ImageID   string
 	ImageName string
 
+	// ImageDigest and ImageLabels are resolved from the image cache, if
+	// the container's image is known to it.
+	ImageDigest string
+	ImageLabels map[string]string
+
+	// Labels are the container's labels, including those inherited from
+	// its image, if they are reported by the container runtime.
+	Labels map[string]string
+
 	Pid      int
 	ExitCode int
 
 	Runtime ContainerRuntime
 	State   ContainerState
 
+	// Health is the result of the most recent health check performed by
+	// the container runtime (i.e. "starting", "healthy", "unhealthy").
+	// It is empty if the container has no health check.
+	Health string
+
 	JSONConfig string
 	OCIConfig  string
 }
@@ -209,6 +269,15 @@ func NewContainerCache(sensor *Sensor) *ContainerCache {
 	cache.ContainerUpdatedEventID = monitor.RegisterExternalEvent(
 		"CONTAINER_UPDATED", cache.decodeContainerUpdatedEvent)
 
+	cache.ContainerHealthEventID = monitor.RegisterExternalEvent(
+		"CONTAINER_HEALTH", cache.decodeContainerHealthEvent)
+
+	cache.ContainerPausedEventID = monitor.RegisterExternalEvent(
+		"CONTAINER_PAUSED", cache.decodeContainerPausedEvent)
+
+	cache.ContainerResumedEventID = monitor.RegisterExternalEvent(
+		"CONTAINER_RESUMED", cache.decodeContainerResumedEvent)
+
 	return cache
 }
 
@@ -259,13 +328,70 @@ func (cc *ContainerCache) LookupContainer(containerID string, create bool) *Cont
 	return info
 }
 
+// ContainerCgroups returns the perf_event cgroups of the specified containers,
+// relative to the perf_event cgroup mountpoint. Every container must be
+// running and have a known pid.
+func (cc *ContainerCache) ContainerCgroups(containerIDs []string) ([]string, error) {
+	cgroups := make([]string, 0, len(containerIDs))
+	for _, containerID := range containerIDs {
+		info := cc.LookupContainer(containerID, false)
+		if info == nil || info.State != ContainerStateRunning ||
+			info.Pid == 0 {
+			return nil, fmt.Errorf("Container %s is not running",
+				containerID)
+		}
+
+		groups, err := cc.sensor.ProcFS.TaskControlGroups(info.Pid,
+			info.Pid)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, cg := range groups {
+			for _, controller := range cg.Controllers {
+				if controller == "perf_event" {
+					cgroups = append(cgroups, cg.Path)
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("No perf_event cgroup for container %s",
+				containerID)
+		}
+	}
+	return cgroups, nil
+}
+
 func (cc *ContainerCache) enqueueContainerEvent(
 	eventID uint64,
 	sampleID perf.SampleID,
 	info *ContainerInfo,
 ) error {
+	var exitSignal, exitStatus uint32
+	ws := unix.WaitStatus(info.ExitCode)
+	if ws.Exited() {
+		exitStatus = uint32(ws.ExitStatus())
+	}
+	if ws.Signaled() {
+		exitSignal = uint32(ws.Signal())
+	}
+
+	// Include the fields in ContainerEventTypes so that filter
+	// expressions can be evaluated against the sample.
 	data := map[string]interface{}{
-		"__container__": *info,
+		"__container__":    *info,
+		"name":             info.Name,
+		"image_id":         info.ImageID,
+		"image_name":       info.ImageName,
+		"image_digest":     info.ImageDigest,
+		"host_pid":         int32(info.Pid),
+		"exit_code":        int32(info.ExitCode),
+		"exit_status":      exitStatus,
+		"exit_signal":      exitSignal,
+		"exit_core_dumped": ws.CoreDump(),
+		"health_status":    info.Health,
 	}
 	return cc.sensor.Monitor().EnqueueExternalSample(eventID, sampleID, data)
 }
@@ -330,6 +456,62 @@ func (cc *ContainerCache) decodeContainerUpdatedEvent(
 	return e, nil
 }
 
+func (cc *ContainerCache) decodeContainerHealthEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ContainerHealthTelemetryEvent
+	if !e.InitWithSample(cc.sensor, sample, data) {
+		return nil, nil
+	}
+	e.TelemetryEventData.Container = data["__container__"].(ContainerInfo)
+	return e, nil
+}
+
+func (cc *ContainerCache) decodeContainerPausedEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ContainerPausedTelemetryEvent
+	if !e.InitWithSample(cc.sensor, sample, data) {
+		return nil, nil
+	}
+	e.TelemetryEventData.Container = data["__container__"].(ContainerInfo)
+	return e, nil
+}
+
+func (cc *ContainerCache) decodeContainerResumedEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ContainerResumedTelemetryEvent
+	if !e.InitWithSample(cc.sensor, sample, data) {
+		return nil, nil
+	}
+	e.TelemetryEventData.Container = data["__container__"].(ContainerInfo)
+	return e, nil
+}
+
+// snapshot returns a copy of the information that shares no maps with it,
+// so that the copy can still be used once the cache is unlocked.
+func (info *ContainerInfo) snapshot() ContainerInfo {
+	snapshot := *info
+	snapshot.ImageLabels = copyLabels(info.ImageLabels)
+	snapshot.Labels = copyLabels(info.Labels)
+	return snapshot
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	c := make(map[string]string, len(labels))
+	for k, v := range labels {
+		c[k] = v
+	}
+	return c
+}
+
 // Update updates the data cached for a container with new information. Some
 // new information may trigger telemetry events to fire.
 func (info *ContainerInfo) Update(
@@ -338,11 +520,17 @@ func (info *ContainerInfo) Update(
 	sampleID perf.SampleID,
 	data map[string]interface{},
 ) {
+	// The ContainerInfo is shared via the cache, so all changes to it
+	// must be made with the cache locked. Events are enqueued from a
+	// snapshot taken while still locked.
+	cache.Lock()
+
 	if info.Runtime == ContainerRuntimeUnknown {
 		info.Runtime = runtime
 	}
 
 	oldState := info.State
+	oldHealth := info.Health
 	dataChanged := false
 
 	s := reflect.ValueOf(info).Elem()
@@ -357,44 +545,69 @@ func (info *ContainerInfo) Update(
 			continue
 		}
 		if !reflect.TypeOf(v).AssignableTo(f.Type) {
-			glog.Fatalf("Cannot assign %v to %s %s",
-				v, f.Name, f.Type)
+			// A runtime reported something unexpected. Ignore it
+			// rather than bringing down the sensor.
+			glog.Errorf("Cannot update container: %s", logging.Fields(
+				"container_id", info.ID, "field", f.Name,
+				"type", f.Type, "value", v))
+			continue
 		}
 
-		if s.Field(i).Interface() != v {
-			if f.Name != "State" {
-				dataChanged = true
-			} else if info.Runtime != runtime {
+		if !reflect.DeepEqual(s.Field(i).Interface(), v) {
+			if f.Name == "State" {
 				// Only allow state changes from the runtime
 				// known to be managing the container.
-				continue
+				if info.Runtime != runtime {
+					continue
+				}
+			} else if f.Name != "Health" {
+				// Health changes have their own event
+				dataChanged = true
 			}
 			s.Field(i).Set(reflect.ValueOf(v))
 		}
 	}
 
-	if info.State != oldState {
+	snapshot := info.snapshot()
+	cache.Unlock()
+
+	if snapshot.State != oldState {
 		if oldState < ContainerStateCreated {
-			glog.V(2).Infof("Sending CONTAINER_CREATED for %s", info.ID)
+			glog.V(2).Infof("Sending CONTAINER_CREATED for %s", snapshot.ID)
 			cache.enqueueContainerEvent(
-				cache.ContainerCreatedEventID, sampleID, info)
+				cache.ContainerCreatedEventID, sampleID, &snapshot)
 		}
-		if oldState < ContainerStateRunning &&
-			info.State >= ContainerStateRunning {
-			glog.V(2).Infof("Sending CONTAINER_RUNNING for %s", info.ID)
+		if snapshot.State == ContainerStatePaused {
+			glog.V(2).Infof("Sending CONTAINER_PAUSED for %s", snapshot.ID)
 			cache.enqueueContainerEvent(
-				cache.ContainerRunningEventID, sampleID, info)
+				cache.ContainerPausedEventID, sampleID, &snapshot)
+		} else if oldState == ContainerStatePaused &&
+			snapshot.State == ContainerStateRunning {
+			glog.V(2).Infof("Sending CONTAINER_RESUMED for %s", snapshot.ID)
+			cache.enqueueContainerEvent(
+				cache.ContainerResumedEventID, sampleID, &snapshot)
+		} else if oldState < ContainerStateRunning &&
+			snapshot.State >= ContainerStateRunning {
+			glog.V(2).Infof("Sending CONTAINER_RUNNING for %s", snapshot.ID)
+			cache.enqueueContainerEvent(
+				cache.ContainerRunningEventID, sampleID, &snapshot)
 		}
 		if oldState < ContainerStateRestarting &&
-			info.State >= ContainerStateRestarting {
-			glog.V(2).Infof("Sending CONTAINER_EXITED for %s", info.ID)
+			snapshot.State >= ContainerStateRestarting {
+			glog.V(2).Infof("Sending CONTAINER_EXITED for %s", snapshot.ID)
 			cache.enqueueContainerEvent(
-				cache.ContainerExitedEventID, sampleID, info)
+				cache.ContainerExitedEventID, sampleID, &snapshot)
 		}
 	} else if dataChanged {
-		glog.V(2).Infof("Sending CONTAINER_UPDATED for %s", info.ID)
+		glog.V(2).Infof("Sending CONTAINER_UPDATED for %s", snapshot.ID)
+		cache.enqueueContainerEvent(
+			cache.ContainerUpdatedEventID, sampleID, &snapshot)
+	}
+	if snapshot.Health != oldHealth {
+		glog.V(2).Infof("Sending CONTAINER_HEALTH for %s (%s)",
+			snapshot.ID, snapshot.Health)
 		cache.enqueueContainerEvent(
-			cache.ContainerUpdatedEventID, sampleID, info)
+			cache.ContainerHealthEventID, sampleID, &snapshot)
 	}
 }
 
@@ -448,6 +661,30 @@ func (s *Subscription) RegisterContainerUpdatedEventFilter(expr *expression.Expr
 		expr)
 }
 
+// RegisterContainerHealthEventFilter registers a container health event
+// filter with a subscription.
+func (s *Subscription) RegisterContainerHealthEventFilter(expr *expression.Expression) {
+	s.registerContainerEventFilter(
+		s.sensor.ContainerCache.ContainerHealthEventID,
+		expr)
+}
+
+// RegisterContainerPausedEventFilter registers a container paused event
+// filter with a subscription.
+func (s *Subscription) RegisterContainerPausedEventFilter(expr *expression.Expression) {
+	s.registerContainerEventFilter(
+		s.sensor.ContainerCache.ContainerPausedEventID,
+		expr)
+}
+
+// RegisterContainerResumedEventFilter registers a container resumed event
+// filter with a subscription.
+func (s *Subscription) RegisterContainerResumedEventFilter(expr *expression.Expression) {
+	s.registerContainerEventFilter(
+		s.sensor.ContainerCache.ContainerResumedEventID,
+		expr)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 // NewContainerFilter creates a new container filter
@@ -462,12 +699,31 @@ type ContainerFilter struct {
 	containerNames map[string]struct{}
 	imageIDs       map[string]struct{}
 	imageGlobs     map[string]glob.Glob
+	nameRegexps    map[string]*regexp.Regexp
+	imageRegexps   map[string]*regexp.Regexp
+	exclude        *ContainerFilter
 }
 
 // Len returns the number of filters that are active within a ContainerFilter.
 func (c *ContainerFilter) Len() int {
+	n := c.includeLen()
+	if c.exclude != nil {
+		n += c.exclude.Len()
+	}
+	return n
+}
+
+func (c *ContainerFilter) includeLen() int {
 	return len(c.containerIDs) + len(c.containerNames) +
-		len(c.imageIDs) + len(c.imageGlobs)
+		len(c.imageIDs) + len(c.imageGlobs) + len(c.nameRegexps) +
+		len(c.imageRegexps)
+}
+
+// SetExclusion sets a filter for containers to be excluded. Events from
+// containers that match the exclusion are never matched. If no other filters
+// are active, all other events match, including those not in a container.
+func (c *ContainerFilter) SetExclusion(x *ContainerFilter) {
+	c.exclude = x
 }
 
 // AddContainerID adds a container ID to a container filter.
@@ -500,7 +756,9 @@ func (c *ContainerFilter) AddImageID(iid string) {
 	}
 }
 
-// AddImageName adds and image name to a container filter.
+// AddImageName adds an image name to a container filter. The name may be a
+// shell-style glob, in which "*" and "?" do not match "/" and "**" matches
+// any sequence of characters, i.e. "registry.internal/*/nginx:*".
 func (c *ContainerFilter) AddImageName(iname string) error {
 	if len(iname) > 0 {
 		if c.imageGlobs == nil {
@@ -517,12 +775,76 @@ func (c *ContainerFilter) AddImageName(iname string) error {
 	return nil
 }
 
+// imageNameWithTag returns an image name with the implied "latest" tag added
+// if it has neither a tag nor a digest, so that a pattern such as "nginx:*"
+// matches containers started from "nginx".
+func imageNameWithTag(name string) string {
+	if strings.ContainsRune(name, '@') {
+		return name
+	}
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		if strings.ContainsRune(name[i+1:], ':') {
+			return name
+		}
+	} else if strings.ContainsRune(name, ':') {
+		return name
+	}
+	return name + ":latest"
+}
+
+func addRegexp(regexps *map[string]*regexp.Regexp, pattern string) error {
+	if len(pattern) > 0 {
+		if *regexps == nil {
+			*regexps = make(map[string]*regexp.Regexp)
+		} else if _, ok := (*regexps)[pattern]; ok {
+			return nil
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+		(*regexps)[pattern] = re
+	}
+	return nil
+}
+
+func matchRegexps(regexps map[string]*regexp.Regexp, s string) bool {
+	if s != "" {
+		for _, re := range regexps {
+			if re.MatchString(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AddContainerNameRegexp adds a regular expression to match container names
+// to a container filter.
+func (c *ContainerFilter) AddContainerNameRegexp(pattern string) error {
+	return addRegexp(&c.nameRegexps, pattern)
+}
+
+// AddImageNameRegexp adds a regular expression to match image names to a
+// container filter.
+func (c *ContainerFilter) AddImageNameRegexp(pattern string) error {
+	return addRegexp(&c.imageRegexps, pattern)
+}
+
 // Match evaluates a container filter for a ContainerInfo struct and determines
 // whether it matches the criteria set forth by the filter.
 func (c *ContainerFilter) Match(info ContainerInfo) bool {
 	if c == nil {
 		return true
 	}
+	if c.exclude != nil {
+		if c.exclude.Len() > 0 && c.exclude.Match(info) {
+			return false
+		}
+		if c.includeLen() == 0 {
+			return true
+		}
+	}
 	if len(info.ID) == 0 {
 		return false
 	}
@@ -543,13 +865,19 @@ func (c *ContainerFilter) Match(info ContainerInfo) bool {
 		return true
 	}
 	if c.imageGlobs != nil && info.ImageName != "" {
+		tagged := imageNameWithTag(info.ImageName)
 		for _, g := range c.imageGlobs {
-			if g.Match(info.ImageName) {
+			if g.Match(info.ImageName) || g.Match(tagged) {
 				c.AddContainerID(info.ID)
 				return true
 			}
 		}
 	}
+	if matchRegexps(c.nameRegexps, info.Name) ||
+		matchRegexps(c.imageRegexps, info.ImageName) {
+		c.AddContainerID(info.ID)
+		return true
+	}
 
 	return false
 }
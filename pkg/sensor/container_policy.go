@@ -0,0 +1,152 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// containerPattern matches containers by name, image name, or label.
+type containerPattern struct {
+	field string // "name", "image", or "label"
+	label string
+
+	// The pattern of the name, image name, or label value. A nil glob
+	// matches containers with the label, whatever its value.
+	glob glob.Glob
+}
+
+// parseContainerPattern parses a pattern of the form "name:pattern",
+// "image:pattern", "label:key=pattern", or "label:key", where each pattern
+// is a shell-style glob. Image name patterns are matched as they are by
+// ContainerFilter.AddImageName, in which "*" does not match "/".
+func parseContainerPattern(s string) (containerPattern, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return containerPattern{}, fmt.Errorf("invalid container pattern %q", s)
+	}
+
+	p := containerPattern{field: s[:i]}
+	pattern := s[i+1:]
+	var separators []rune
+	switch p.field {
+	case "name":
+	case "image":
+		separators = []rune{'/'}
+	case "label":
+		j := strings.IndexByte(pattern, '=')
+		if j < 0 {
+			p.label = pattern
+			pattern = ""
+		} else {
+			p.label, pattern = pattern[:j], pattern[j+1:]
+		}
+		if len(p.label) == 0 {
+			return containerPattern{},
+				fmt.Errorf("invalid container pattern %q", s)
+		}
+		if j < 0 {
+			return p, nil
+		}
+	default:
+		return containerPattern{},
+			fmt.Errorf("invalid container pattern %q: unknown field %q",
+				s, p.field)
+	}
+
+	g, err := glob.Compile(pattern, separators...)
+	if err != nil {
+		return containerPattern{},
+			fmt.Errorf("invalid container pattern %q: %v", s, err)
+	}
+	p.glob = g
+	return p, nil
+}
+
+func (p containerPattern) match(info ContainerInfo) bool {
+	switch p.field {
+	case "name":
+		// Docker container names have a leading '/'
+		name := strings.TrimPrefix(info.Name, "/")
+		return len(name) > 0 && p.glob.Match(name)
+	case "image":
+		return len(info.ImageName) > 0 &&
+			(p.glob.Match(info.ImageName) ||
+				p.glob.Match(imageNameWithTag(info.ImageName)))
+	case "label":
+		v, ok := info.Labels[p.label]
+		return ok && (p.glob == nil || p.glob.Match(v))
+	}
+	return false
+}
+
+// containerPolicy is the sensor-wide policy of which containers are
+// monitored. Events from containers that it does not allow are dropped
+// before they are dispatched to any subscription.
+type containerPolicy struct {
+	allow []containerPattern
+	deny  []containerPattern
+}
+
+// newContainerPolicy creates a containerPolicy from allowlist and denylist
+// patterns. It returns nil if there are no patterns.
+func newContainerPolicy(allowlist, denylist []string) (*containerPolicy, error) {
+	policy := &containerPolicy{}
+	for _, s := range allowlist {
+		p, err := parseContainerPattern(s)
+		if err != nil {
+			return nil, err
+		}
+		policy.allow = append(policy.allow, p)
+	}
+	for _, s := range denylist {
+		p, err := parseContainerPattern(s)
+		if err != nil {
+			return nil, err
+		}
+		policy.deny = append(policy.deny, p)
+	}
+	if len(policy.allow) == 0 && len(policy.deny) == 0 {
+		return nil, nil
+	}
+	return policy, nil
+}
+
+// monitored returns whether events from a container are allowed by the
+// policy. Containers are not monitored if they match any denylist pattern,
+// or if there is an allowlist and they match none of its patterns. Events
+// that are not from a container are always allowed.
+func (p *containerPolicy) monitored(info ContainerInfo) bool {
+	if p == nil || len(info.ID) == 0 {
+		return true
+	}
+	for _, pattern := range p.deny {
+		if pattern.match(info) {
+			return false
+		}
+	}
+	if len(p.allow) == 0 {
+		return true
+	}
+	for _, pattern := range p.allow {
+		if pattern.match(info) {
+			return true
+		}
+	}
+	return false
+}
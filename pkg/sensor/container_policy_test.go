@@ -0,0 +1,152 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"golang.org/x/net/context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContainerPattern(t *testing.T) {
+	for _, s := range []string{
+		"name:*-istio-proxy",
+		"image:registry.internal/*/nginx:*",
+		"label:io.kubernetes.pod.namespace=kube-*",
+		"label:privacy",
+	} {
+		_, err := parseContainerPattern(s)
+		assert.NoError(t, err, s)
+	}
+
+	for _, s := range []string{
+		"nginx",
+		"id:abc",
+		"label:",
+		"label:=value",
+		"name:[",
+	} {
+		_, err := parseContainerPattern(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestContainerPolicy(t *testing.T) {
+	policy, err := newContainerPolicy(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+	assert.True(t, policy.monitored(ContainerInfo{ID: "abc"}))
+
+	_, err = newContainerPolicy([]string{"bad"}, nil)
+	assert.Error(t, err)
+	_, err = newContainerPolicy(nil, []string{"bad"})
+	assert.Error(t, err)
+
+	proxy := ContainerInfo{
+		ID:        "1",
+		Name:      "/web-istio-proxy",
+		ImageName: "istio/proxyv2:1.0",
+	}
+	web := ContainerInfo{
+		ID:        "2",
+		Name:      "/web",
+		ImageName: "nginx",
+		Labels:    map[string]string{"tier": "frontend"},
+	}
+	private := ContainerInfo{
+		ID:        "3",
+		Name:      "/hr",
+		ImageName: "registry.internal/hr/app:2",
+		Labels:    map[string]string{"privacy": ""},
+	}
+	host := ContainerInfo{}
+
+	policy, err = newContainerPolicy(nil, []string{
+		"name:*-istio-proxy",
+		"label:privacy",
+	})
+	require.NoError(t, err)
+	assert.False(t, policy.monitored(proxy))
+	assert.True(t, policy.monitored(web))
+	assert.False(t, policy.monitored(private))
+	assert.True(t, policy.monitored(host))
+
+	policy, err = newContainerPolicy([]string{
+		"image:nginx:*",
+		"image:registry.internal/*/app:*",
+	}, []string{
+		"label:privacy",
+	})
+	require.NoError(t, err)
+	assert.False(t, policy.monitored(proxy))
+	assert.True(t, policy.monitored(web))
+	assert.False(t, policy.monitored(private))
+	assert.True(t, policy.monitored(host))
+
+	policy, err = newContainerPolicy([]string{"label:tier=front*"}, nil)
+	require.NoError(t, err)
+	assert.False(t, policy.monitored(proxy))
+	assert.True(t, policy.monitored(web))
+	assert.False(t, policy.monitored(private))
+}
+
+func TestDispatchContainerPolicy(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	policy, err := newContainerPolicy(nil, []string{"name:denied"})
+	require.NoError(t, err)
+	sensor.containerPolicy = policy
+
+	eventID := sensor.Monitor().RegisterExternalEvent("policy test", nil)
+
+	s := newTestSubscription(t, sensor)
+	_, err = s.addEventSink(eventID, nil, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var dispatched []string
+	_, err = s.Run(ctx, func(e TelemetryEvent) {
+		dispatched = append(dispatched,
+			e.CommonTelemetryEventData().Container.Name)
+	})
+	require.NoError(t, err)
+
+	var samples []perf.EventMonitorSample
+	for _, name := range []string{"allowed", "denied"} {
+		e := TickerTelemetryEvent{}
+		e.Container = ContainerInfo{ID: name, Name: name}
+		samples = append(samples, perf.EventMonitorSample{
+			EventID:       eventID,
+			DecodedSample: e,
+		})
+	}
+	sensor.dispatchQueuedSamples(samples)
+
+	assert.Equal(t, []string{"allowed"}, dispatched)
+	assert.Equal(t, []namedEventCounters{
+		{
+			name:          "Ticker",
+			eventCounters: eventCounters{received: 2, filtered: 1},
+		},
+	}, sensor.eventCounts.snapshot())
+}
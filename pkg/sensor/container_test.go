@@ -15,12 +15,20 @@
 package sensor
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/capsule8/capsule8/pkg/sys"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/capsule8/capsule8/pkg/sys/proc/procfs"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -75,6 +83,18 @@ func TestContainerDecoders(t *testing.T) {
 			decoder:      sensor.ContainerCache.decodeContainerUpdatedEvent,
 			expectedType: ContainerUpdatedTelemetryEvent{},
 		},
+		testCase{
+			decoder:      sensor.ContainerCache.decodeContainerHealthEvent,
+			expectedType: ContainerHealthTelemetryEvent{},
+		},
+		testCase{
+			decoder:      sensor.ContainerCache.decodeContainerPausedEvent,
+			expectedType: ContainerPausedTelemetryEvent{},
+		},
+		testCase{
+			decoder:      sensor.ContainerCache.decodeContainerResumedEvent,
+			expectedType: ContainerResumedTelemetryEvent{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -167,6 +187,39 @@ func TestContainerCache(t *testing.T) {
 	}
 	info.Update(cache, ContainerRuntimeUnknown, sampleID, changes)
 	assert.Equal(t, ContainerStateExited, info.State)
+
+	changes = map[string]interface{}{
+		"Health": "unhealthy",
+	}
+	info.Update(cache, ContainerRuntimeDocker, sampleID, changes)
+	assert.Equal(t, "unhealthy", info.Health)
+
+	// Changes of the wrong type are ignored rather than being fatal
+	changes = map[string]interface{}{
+		"State": "running",
+		"Name":  "capsule8-sensor-2",
+	}
+	info.Update(cache, ContainerRuntimeDocker, sampleID, changes)
+	assert.Equal(t, ContainerStateExited, info.State)
+	assert.Equal(t, "capsule8-sensor-2", info.Name)
+}
+
+func TestContainerInfoSnapshot(t *testing.T) {
+	info := ContainerInfo{
+		ID:          "abc",
+		ImageLabels: map[string]string{"maintainer": "ops"},
+		Labels:      map[string]string{"app": "web"},
+	}
+	snapshot := info.snapshot()
+	assert.Equal(t, info, snapshot)
+
+	// Snapshots do not share the labels of the live information
+	info.ImageLabels["maintainer"] = "dev"
+	info.Labels["app"] = "db"
+	assert.Equal(t, "ops", snapshot.ImageLabels["maintainer"])
+	assert.Equal(t, "web", snapshot.Labels["app"])
+
+	assert.Nil(t, (&ContainerInfo{}).snapshot().Labels)
 }
 
 func verifyContainerEventRegistration(t *testing.T, s *Subscription, count int) {
@@ -178,6 +231,47 @@ func verifyContainerEventRegistration(t *testing.T, s *Subscription, count int)
 	}
 }
 
+func TestContainerCgroups(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	procDir, err := ioutil.TempDir("", "capsule8_")
+	require.NoError(t, err)
+	defer os.RemoveAll(procDir)
+	writeFile(t, filepath.Join(procDir, "1234", "task", "1234", "cgroup"),
+		[]byte("7:hugetlb:/\n6:perf_event:/docker/abc\n4:memory:/docker/abc\n"))
+	writeFile(t, filepath.Join(procDir, "5678", "task", "5678", "cgroup"),
+		[]byte("0::/system.slice/docker-def.scope\n"))
+	procFS, err := procfs.NewFileSystem(procDir)
+	require.NoError(t, err)
+	sensor.ProcFS = procFS
+
+	cache := sensor.ContainerCache
+	info := cache.LookupContainer("abc", true)
+	info.Pid = 1234
+	info.State = ContainerStateRunning
+	info = cache.LookupContainer("def", true)
+	info.Pid = 5678
+	info.State = ContainerStateRunning
+	cache.LookupContainer("ghi", true)
+
+	cgroups, err := cache.ContainerCgroups([]string{"abc"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/docker/abc"}, cgroups)
+
+	// Only cgroup2 is available
+	_, err = cache.ContainerCgroups([]string{"abc", "def"})
+	assert.Error(t, err)
+
+	// Not running
+	_, err = cache.ContainerCgroups([]string{"ghi"})
+	assert.Error(t, err)
+
+	// Unknown
+	_, err = cache.ContainerCgroups([]string{"jkl"})
+	assert.Error(t, err)
+}
+
 func TestContainerEventRegistration(t *testing.T) {
 	sensor := newUnitTestSensor(t)
 	defer sensor.Stop()
@@ -193,6 +287,9 @@ func TestContainerEventRegistration(t *testing.T) {
 		"RegisterContainerExitedEventFilter",
 		"RegisterContainerDestroyedEventFilter",
 		"RegisterContainerUpdatedEventFilter",
+		"RegisterContainerHealthEventFilter",
+		"RegisterContainerPausedEventFilter",
+		"RegisterContainerResumedEventFilter",
 	}
 	for _, name := range names {
 		s := newTestSubscription(t, sensor)
@@ -232,6 +329,24 @@ func TestContainerFilterLen(t *testing.T) {
 
 	err = cf.AddImageName("*.[ch")
 	assert.Error(t, err)
+
+	err = cf.AddContainerNameRegexp("^abc")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 5, cf.Len())
+	}
+	err = cf.AddImageNameRegexp("^abc")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 6, cf.Len())
+	}
+	err = cf.AddImageNameRegexp("^abc")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 6, cf.Len())
+	}
+
+	err = cf.AddContainerNameRegexp("(abc")
+	assert.Error(t, err)
+	err = cf.AddImageNameRegexp("[abc")
+	assert.Error(t, err)
 }
 
 func TestContainerMatch(t *testing.T) {
@@ -300,6 +415,96 @@ func TestFilterContainerImageNames(t *testing.T) {
 	assert.False(t, cf.Match(fail))
 }
 
+func TestFilterContainerImageNameGlobs(t *testing.T) {
+	cf := NewContainerFilter()
+	require.NoError(t, cf.AddImageName("registry.internal/*/nginx:*"))
+	require.NoError(t, cf.AddImageName("mirror.internal/**-sidecar"))
+
+	pass := []string{
+		"registry.internal/team/nginx:1.15",
+		"registry.internal/team/nginx",
+		"mirror.internal/a/b/envoy-sidecar",
+	}
+	for i, name := range pass {
+		info := ContainerInfo{
+			ID:        fmt.Sprintf("pass%d", i),
+			ImageName: name,
+		}
+		assert.True(t, cf.Match(info), name)
+	}
+
+	fail := []string{
+		"registry.internal/nginx:1.15",
+		"registry.internal/a/b/nginx:1.15",
+		"registry.internal/team/nginx-exporter:1.0",
+		"mirror.internal/a/envoy",
+	}
+	for i, name := range fail {
+		info := ContainerInfo{
+			ID:        fmt.Sprintf("fail%d", i),
+			ImageName: name,
+		}
+		assert.False(t, cf.Match(info), name)
+	}
+}
+
+func TestFilterContainerExclusion(t *testing.T) {
+	x := NewContainerFilter()
+	require.NoError(t, x.AddImageName("*-sidecar:*"))
+	x.AddContainerName("pause")
+
+	// Only an exclusion matches everything else, including the host
+	cf := NewContainerFilter()
+	cf.SetExclusion(x)
+	assert.Equal(t, 2, cf.Len())
+
+	tests := []struct {
+		info     ContainerInfo
+		expected bool
+	}{
+		{ContainerInfo{}, true},
+		{ContainerInfo{ID: "a", ImageName: "nginx:1.15"}, true},
+		{ContainerInfo{ID: "b", ImageName: "envoy-sidecar:1"}, false},
+		{ContainerInfo{ID: "c", ImageName: "envoy-sidecar"}, false},
+		{ContainerInfo{ID: "d", Name: "pause"}, false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, cf.Match(tc.info), "%+v", tc.info)
+	}
+
+	// Exclusions take precedence over inclusions
+	cf = NewContainerFilter()
+	require.NoError(t, cf.AddImageName("envoy*"))
+	cf.SetExclusion(x)
+
+	tests = []struct {
+		info     ContainerInfo
+		expected bool
+	}{
+		{ContainerInfo{}, false},
+		{ContainerInfo{ID: "a", ImageName: "envoy:1.8"}, true},
+		{ContainerInfo{ID: "b", ImageName: "envoy-sidecar:1"}, false},
+		{ContainerInfo{ID: "c", ImageName: "nginx:1.15"}, false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, cf.Match(tc.info), "%+v", tc.info)
+	}
+}
+
+func TestImageNameWithTag(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                    "nginx:latest",
+		"nginx:1.15":               "nginx:1.15",
+		"localhost:5000/nginx":     "localhost:5000/nginx:latest",
+		"localhost:5000/nginx:1.0": "localhost:5000/nginx:1.0",
+		"nginx@sha256:abcd":        "nginx@sha256:abcd",
+		"sha256:abcd":              "sha256:abcd",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, imageNameWithTag(name))
+	}
+}
+
 func TestFilterContainerNames(t *testing.T) {
 	cf := NewContainerFilter()
 	cf.AddContainerName("alice")
@@ -317,3 +522,88 @@ func TestFilterContainerNames(t *testing.T) {
 	}
 	assert.False(t, cf.Match(fail))
 }
+
+func TestContainerPauseResume(t *testing.T) {
+	const id = "9a05e9a05e9a05e9a05e9a05e9a05e9a05e9a05e9a05e9a05e9a05e9a05e9a05"
+
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	var (
+		events []string
+		lock   sync.Mutex
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newTestSubscription(t, sensor)
+	s.RegisterContainerRunningEventFilter(nil)
+	s.RegisterContainerPausedEventFilter(nil)
+	s.RegisterContainerResumedEventFilter(nil)
+	status, err := s.Run(ctx, func(event TelemetryEvent) {
+		lock.Lock()
+		events = append(events, reflect.TypeOf(event).Name())
+		lock.Unlock()
+	})
+	assert.Len(t, status, 0)
+	require.NoError(t, err)
+
+	cache := sensor.ContainerCache
+	info := cache.LookupContainer(id, true)
+	require.NotNil(t, info)
+
+	states := []ContainerState{
+		ContainerStateCreated,
+		ContainerStateRunning,
+		ContainerStatePaused,
+		ContainerStateRunning,
+		ContainerStateExited,
+	}
+	for _, state := range states {
+		sampleID := perf.SampleID{Time: uint64(sys.CurrentMonotonicRaw())}
+		info.Update(cache, ContainerRuntimeDocker, sampleID,
+			map[string]interface{}{"State": state})
+		assert.Equal(t, state, info.State)
+	}
+
+	expEvents := []string{
+		"ContainerRunningTelemetryEvent",
+		"ContainerPausedTelemetryEvent",
+		"ContainerResumedTelemetryEvent",
+	}
+	for i := 0; i < 100; i++ {
+		lock.Lock()
+		n := len(events)
+		lock.Unlock()
+		if n >= len(expEvents) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	lock.Lock()
+	assert.Equal(t, expEvents, events)
+	lock.Unlock()
+}
+
+func TestFilterContainerRegexps(t *testing.T) {
+	cf := NewContainerFilter()
+	cf.AddContainerNameRegexp("^/web-[0-9]+$")
+	cf.AddImageNameRegexp(`^registry\.internal/.*/nginx:`)
+
+	pass := []ContainerInfo{
+		ContainerInfo{ID: "pass1", Name: "/web-12"},
+		ContainerInfo{ID: "pass2", ImageName: "registry.internal/team/nginx:1.15"},
+	}
+	for _, info := range pass {
+		assert.True(t, cf.Match(info), info.ID)
+	}
+
+	fail := []ContainerInfo{
+		ContainerInfo{ID: "fail1", Name: "/web-x"},
+		ContainerInfo{ID: "fail2", ImageName: "registry.internal/nginx:1.15"},
+		ContainerInfo{ID: "fail3", ImageName: "registryXinternal/team/nginx:1.15"},
+	}
+	for _, info := range fail {
+		assert.False(t, cf.Match(info), info.ID)
+	}
+}
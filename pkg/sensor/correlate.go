@@ -0,0 +1,259 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+const (
+	// The longest time that events are held waiting for their container
+	// to be announced
+	correlationHoldTime = 5 * time.Second
+
+	// The maximum number of events held for a container. When more arrive,
+	// the held events are released without waiting for the container.
+	correlationMaxHeld = 1024
+)
+
+// snapshotContainer returns a copy of the information in the cache for a
+// container, if there is any.
+func (cc *ContainerCache) snapshotContainer(containerID string) (ContainerInfo, bool) {
+	cc.Lock()
+	defer cc.Unlock()
+
+	if info, ok := cc.cache[containerID]; ok {
+		return info.snapshot(), true
+	}
+	return ContainerInfo{}, false
+}
+
+// registerCorrelationEvents registers the container created and running
+// events used to correlate events with their containers, without a filter,
+// if they have not been registered already.
+func (s *Subscription) registerCorrelationEvents() {
+	cache := s.sensor.ContainerCache
+	if _, ok := s.eventSinks[cache.ContainerCreatedEventID]; !ok {
+		s.RegisterContainerCreatedEventFilter(nil)
+	}
+	if _, ok := s.eventSinks[cache.ContainerRunningEventID]; !ok {
+		s.RegisterContainerRunningEventFilter(nil)
+	}
+}
+
+// mergeContainerMetadata fills in container metadata missing from a
+// translated event, which may have been decoded before the container runtime
+// reported it.
+func mergeContainerMetadata(event *api.TelemetryEvent, info ContainerInfo) {
+	if event.ContainerName == "" {
+		event.ContainerName = info.Name
+	}
+	if event.ImageId == "" {
+		event.ImageId = info.ImageID
+	}
+	if event.ImageName == "" {
+		event.ImageName = info.ImageName
+	}
+}
+
+func isContainerAnnouncement(event *api.TelemetryEvent) bool {
+	if c, ok := event.Event.(*api.TelemetryEvent_Container); ok {
+		switch c.Container.Type {
+		case api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED,
+			api.ContainerEventType_CONTAINER_EVENT_TYPE_RUNNING:
+			return true
+		}
+	}
+	return false
+}
+
+func isContainerDestroyed(event *api.TelemetryEvent) bool {
+	c, ok := event.Event.(*api.TelemetryEvent_Container)
+	return ok && c.Container.Type == api.ContainerEventType_CONTAINER_EVENT_TYPE_DESTROYED
+}
+
+type heldEvents struct {
+	containerID string
+	events      []*api.ReceivedTelemetryEvent
+	releaseTime time.Time
+}
+
+// containerCorrelator orders the events sent on a stream so that no event
+// referring to a container is sent before the container's created or running
+// event. Events for a container that is not yet announced are held until its
+// event is sent, or, if the container is already known to the container
+// cache, a running or created event is made for it from the cache. Events
+// that have been held for correlationHoldTime are released regardless.
+type containerCorrelator struct {
+	subscription *Subscription
+
+	announced map[string]bool
+	held      map[string]*heldEvents
+
+	// Held events in the order that they must be released
+	queue []*heldEvents
+}
+
+func newContainerCorrelator(s *Subscription) *containerCorrelator {
+	return &containerCorrelator{
+		subscription: s,
+		announced:    make(map[string]bool),
+		held:         make(map[string]*heldEvents),
+	}
+}
+
+// announce makes a container event for a container known to the cache.
+func (c *containerCorrelator) announce(info ContainerInfo) *api.ReceivedTelemetryEvent {
+	var ev TelemetryEvent
+	if info.State >= ContainerStateRunning {
+		var e ContainerRunningTelemetryEvent
+		e.Init(c.subscription.sensor)
+		e.Container = info
+		ev = e
+	} else {
+		var e ContainerCreatedTelemetryEvent
+		e.Init(c.subscription.sensor)
+		e.Container = info
+		ev = e
+	}
+	return &api.ReceivedTelemetryEvent{
+		Event: c.subscription.translateEvent(ev),
+	}
+}
+
+func (c *containerCorrelator) lookup(containerID string) (ContainerInfo, bool) {
+	info, ok := c.subscription.sensor.ContainerCache.snapshotContainer(containerID)
+	if !ok || info.State < ContainerStateCreated {
+		return info, false
+	}
+	return info, true
+}
+
+func (c *containerCorrelator) unhold(containerID string) []*api.ReceivedTelemetryEvent {
+	h, ok := c.held[containerID]
+	if !ok {
+		return nil
+	}
+	delete(c.held, containerID)
+	for i, q := range c.queue {
+		if q == h {
+			c.queue = append(c.queue[:i], c.queue[i+1:]...)
+			break
+		}
+	}
+	return h.events
+}
+
+// add returns the events that may be sent, in order, now that an event has
+// passed the stream's filters and modifiers.
+func (c *containerCorrelator) add(
+	re *api.ReceivedTelemetryEvent,
+	now time.Time,
+) []*api.ReceivedTelemetryEvent {
+	containerID := re.Event.ContainerId
+	if containerID == "" {
+		return []*api.ReceivedTelemetryEvent{re}
+	}
+	if isContainerAnnouncement(re.Event) {
+		c.announced[containerID] = true
+		return append([]*api.ReceivedTelemetryEvent{re},
+			c.release(containerID, false)...)
+	}
+	if isContainerDestroyed(re.Event) {
+		delete(c.announced, containerID)
+		return append(c.release(containerID, true), re)
+	}
+
+	info, known := c.lookup(containerID)
+	if known {
+		mergeContainerMetadata(re.Event, info)
+	}
+	if c.announced[containerID] {
+		return []*api.ReceivedTelemetryEvent{re}
+	}
+	if known {
+		c.announced[containerID] = true
+		return []*api.ReceivedTelemetryEvent{c.announce(info), re}
+	}
+
+	h, ok := c.held[containerID]
+	if !ok {
+		h = &heldEvents{
+			containerID: containerID,
+			releaseTime: now.Add(correlationHoldTime),
+		}
+		c.held[containerID] = h
+		c.queue = append(c.queue, h)
+	}
+	h.events = append(h.events, re)
+	if len(h.events) >= correlationMaxHeld {
+		return c.release(containerID, true)
+	}
+	return nil
+}
+
+// release returns the events held for a container, preceded by a container
+// event made from the cache if the container has become known to it and
+// announce is true.
+func (c *containerCorrelator) release(
+	containerID string,
+	announce bool,
+) []*api.ReceivedTelemetryEvent {
+	events := c.unhold(containerID)
+	if len(events) == 0 {
+		return nil
+	}
+	info, known := c.lookup(containerID)
+	if known {
+		for _, re := range events {
+			mergeContainerMetadata(re.Event, info)
+		}
+		if announce && !c.announced[containerID] {
+			c.announced[containerID] = true
+			events = append([]*api.ReceivedTelemetryEvent{c.announce(info)},
+				events...)
+		}
+	}
+	return events
+}
+
+// nextReleaseTime returns the time at which the oldest held events must be
+// released.
+func (c *containerCorrelator) nextReleaseTime() (time.Time, bool) {
+	if len(c.queue) == 0 {
+		return time.Time{}, false
+	}
+	return c.queue[0].releaseTime, true
+}
+
+// flush returns all of the held events, however long they have been held.
+func (c *containerCorrelator) flush() []*api.ReceivedTelemetryEvent {
+	var events []*api.ReceivedTelemetryEvent
+	for len(c.queue) > 0 {
+		events = append(events, c.release(c.queue[0].containerID, true)...)
+	}
+	return events
+}
+
+// expire returns the held events that have been held for too long.
+func (c *containerCorrelator) expire(now time.Time) []*api.ReceivedTelemetryEvent {
+	var events []*api.ReceivedTelemetryEvent
+	for len(c.queue) > 0 && !now.Before(c.queue[0].releaseTime) {
+		events = append(events, c.release(c.queue[0].containerID, true)...)
+	}
+	return events
+}
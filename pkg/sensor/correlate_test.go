@@ -0,0 +1,175 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCorrelationTestEvent(containerID string) *api.ReceivedTelemetryEvent {
+	return &api.ReceivedTelemetryEvent{
+		Event: &api.TelemetryEvent{
+			ContainerId: containerID,
+			Event: &api.TelemetryEvent_Process{
+				Process: &api.ProcessEvent{
+					Type: api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+				},
+			},
+		},
+	}
+}
+
+func newContainerTestEvent(
+	containerID string,
+	eventType api.ContainerEventType,
+) *api.ReceivedTelemetryEvent {
+	return &api.ReceivedTelemetryEvent{
+		Event: &api.TelemetryEvent{
+			ContainerId: containerID,
+			Event: &api.TelemetryEvent_Container{
+				Container: &api.ContainerEvent{
+					Type: eventType,
+				},
+			},
+		},
+	}
+}
+
+func TestRegisterCorrelationEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	s.registerCorrelationEvents()
+	assert.Contains(t, s.eventSinks, sensor.ContainerCache.ContainerCreatedEventID)
+	assert.Contains(t, s.eventSinks, sensor.ContainerCache.ContainerRunningEventID)
+}
+
+func TestContainerCorrelator(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	c := newContainerCorrelator(s)
+	now := time.Now()
+
+	// Events without containers are never held
+	re := newCorrelationTestEvent("")
+	assert.Equal(t, []*api.ReceivedTelemetryEvent{re}, c.add(re, now))
+
+	// Events for unknown containers are held until their container is
+	// announced
+	held1 := newCorrelationTestEvent("new")
+	held2 := newCorrelationTestEvent("new")
+	assert.Len(t, c.add(held1, now), 0)
+	assert.Len(t, c.add(held2, now), 0)
+	releaseTime, ok := c.nextReleaseTime()
+	require.True(t, ok)
+	assert.Equal(t, now.Add(correlationHoldTime), releaseTime)
+
+	info := sensor.ContainerCache.LookupContainer("new", true)
+	info.Name = "/new"
+	info.ImageName = "busybox"
+	info.State = ContainerStateCreated
+
+	created := newContainerTestEvent("new",
+		api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED)
+	assert.Equal(t, []*api.ReceivedTelemetryEvent{created, held1, held2},
+		c.add(created, now))
+	assert.Equal(t, "/new", held1.Event.ContainerName)
+	assert.Equal(t, "busybox", held2.Event.ImageName)
+	_, ok = c.nextReleaseTime()
+	assert.False(t, ok)
+
+	re = newCorrelationTestEvent("new")
+	assert.Equal(t, []*api.ReceivedTelemetryEvent{re}, c.add(re, now))
+
+	// Containers already in the cache are announced from it
+	info = sensor.ContainerCache.LookupContainer("old", true)
+	info.Name = "/old"
+	info.State = ContainerStateRunning
+
+	re = newCorrelationTestEvent("old")
+	events := c.add(re, now)
+	require.Len(t, events, 2)
+	container := events[0].Event.GetContainer()
+	require.NotNil(t, container)
+	assert.Equal(t, api.ContainerEventType_CONTAINER_EVENT_TYPE_RUNNING, container.Type)
+	assert.Equal(t, "/old", container.Name)
+	assert.Equal(t, "old", events[0].Event.ContainerId)
+	assert.Equal(t, re, events[1])
+	assert.Equal(t, "/old", re.Event.ContainerName)
+
+	// Destroyed containers are forgotten
+	destroyed := newContainerTestEvent("old",
+		api.ContainerEventType_CONTAINER_EVENT_TYPE_DESTROYED)
+	assert.Equal(t, []*api.ReceivedTelemetryEvent{destroyed},
+		c.add(destroyed, now))
+	assert.NotContains(t, c.announced, "old")
+}
+
+func TestContainerCorrelatorRelease(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	c := newContainerCorrelator(s)
+	now := time.Now()
+
+	// Held events are released after the hold time
+	re := newCorrelationTestEvent("unknown")
+	assert.Len(t, c.add(re, now), 0)
+	assert.Len(t, c.expire(now), 0)
+	assert.Equal(t, []*api.ReceivedTelemetryEvent{re},
+		c.expire(now.Add(correlationHoldTime)))
+	assert.Len(t, c.held, 0)
+
+	// Held events are released when too many are held
+	for i := 0; i < correlationMaxHeld-1; i++ {
+		assert.Len(t, c.add(newCorrelationTestEvent("busy"), now), 0)
+	}
+	assert.Len(t, c.add(newCorrelationTestEvent("busy"), now),
+		correlationMaxHeld)
+	assert.Len(t, c.held, 0)
+	assert.Len(t, c.queue, 0)
+
+	// Released events are preceded by an announcement if the container
+	// has become known while they were held
+	re = newCorrelationTestEvent("late")
+	assert.Len(t, c.add(re, now), 0)
+	info := sensor.ContainerCache.LookupContainer("late", true)
+	info.State = ContainerStateCreated
+
+	events := c.expire(now.Add(correlationHoldTime))
+	require.Len(t, events, 2)
+	container := events[0].Event.GetContainer()
+	require.NotNil(t, container)
+	assert.Equal(t, api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED, container.Type)
+	assert.Equal(t, re, events[1])
+	assert.True(t, c.announced["late"])
+
+	// Flushing releases events however long they have been held
+	re = newCorrelationTestEvent("unknown")
+	assert.Len(t, c.add(re, now), 0)
+	assert.Equal(t, []*api.ReceivedTelemetryEvent{re}, c.flush())
+	assert.Len(t, c.queue, 0)
+	assert.Len(t, c.flush(), 0)
+}
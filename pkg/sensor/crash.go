@@ -0,0 +1,95 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// ProcessCrashEventTypes defines the field types that can be used with
+// filters on process crash telemetry events.
+var ProcessCrashEventTypes = expression.FieldTypeMap{
+	"signal":     expression.ValueTypeSignedInt32,
+	"code":       expression.ValueTypeSignedInt32,
+	"executable": expression.ValueTypeString,
+}
+
+// ProcessCrashTelemetryEvent is a telemetry event generated by the process
+// crash event source when a process is killed by a signal whose default
+// action is to dump core.
+type ProcessCrashTelemetryEvent struct {
+	TelemetryEventData
+
+	Signal int32
+	Code   int32
+
+	// Executable is the path of the executable that crashed. It is empty
+	// if the process exited before the event was decoded.
+	Executable string
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a process crash telemetry event.
+func (e ProcessCrashTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// do_coredump is called in the context of the crashing task for every fatal
+// signal in the core dumping class (SIGSEGV, SIGABRT, SIGBUS, etc.), even when
+// RLIMIT_CORE prevents a core file from being written. Its only argument is
+// the siginfo for the signal; si_signo and si_code are at offsets 0 and 8.
+const (
+	crashKprobeSymbol    = "do_coredump"
+	crashKprobeFetchargs = "signal=+0(%di):s32 code=+8(%di):s32"
+)
+
+func (s *Subscription) decodeDoCoredump(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ProcessCrashTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Signal = data["signal"].(int32)
+	e.Code = data["code"].(int32)
+
+	// The crashing task cannot finish exiting until do_coredump returns,
+	// which gives the sensor a good chance of seeing it in /proc. Failures
+	// here can be ignored; the task has exited in the meantime.
+	if pid, ok := data["common_pid"].(int32); ok {
+		t := s.sensor.ProcessCache.LookupTask(int(pid))
+		e.Executable, _ = s.sensor.ProcFS.TaskExecutable(t.TGID, t.PID)
+	}
+
+	// Make the executable visible to filter expressions, which are
+	// evaluated against the sample data after decoding.
+	data["executable"] = e.Executable
+
+	return e, nil
+}
+
+// RegisterProcessCrashEventFilter registers a process crash event filter with
+// a subscription.
+func (s *Subscription) RegisterProcessCrashEventFilter(expr *expression.Expression) {
+	s.registerWithSensorFilter("crash", expr, ProcessCrashEventTypes,
+		func() (*eventSink, error) {
+			// The executable is only known after decoding
+			return s.registerKprobe(crashKprobeSymbol, false,
+				crashKprobeFetchargs, s.decodeDoCoredump, nil,
+				ProcessCrashEventTypes)
+		})
+}
@@ -0,0 +1,130 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestDecodeDoCoredump(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"signal":     int32(unix.SIGSEGV),
+		"code":       int32(1),
+	}
+
+	i, err := s.decodeDoCoredump(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	data["common_pid"] = int32(111343)
+	i, err = s.decodeDoCoredump(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessCrashTelemetryEvent{}, i)
+
+	e := i.(ProcessCrashTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+		e.Container.ID)
+	assert.Equal(t, int32(unix.SIGSEGV), e.Signal)
+	assert.Equal(t, int32(1), e.Code)
+	assert.Equal(t, "/bin/bash", e.Executable)
+	assert.Equal(t, "/bin/bash", data["executable"])
+
+	// The executable is not known once the task has gone from /proc
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(2),
+		"signal":     int32(unix.SIGABRT),
+		"code":       int32(-6),
+	}
+	i, err = s.decodeDoCoredump(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessCrashTelemetryEvent{}, i)
+
+	e = i.(ProcessCrashTelemetryEvent)
+	assert.Equal(t, int32(unix.SIGABRT), e.Signal)
+	assert.Equal(t, "", e.Executable)
+}
+
+func prepareForRegisterProcessCrashEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:s32 signal;	offset:16;	size:4;	signed:1;
+	field:s32 code;	offset:20;	size:4;	signed:1;
+
+print fmt: "(%lx) signal=%d code=%d", REC->__probe_ip, REC->signal, REC->code`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func TestProcessCrashEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("executable"),
+		expression.Value("/bin/bash"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	prepareForRegisterProcessCrashEventFilter(t, s, 0)
+	s.RegisterProcessCrashEventFilter(expr)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	prepareForRegisterProcessCrashEventFilter(t, s, 0)
+	s.RegisterProcessCrashEventFilter(nil)
+	assert.Len(t, s.eventSinks, 1)
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterProcessCrashEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
@@ -0,0 +1,180 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/golang/glog"
+)
+
+// eventDelivery holds the responses sent for a subscription with at least
+// once delivery until the client acknowledges them, so that they can be
+// sent again on a new stream if the client reconnects.
+type eventDelivery struct {
+	id         string
+	scope      *tokenScope
+	maxPending int
+
+	// These are protected by telemetryServiceServer.deliveriesMutex
+	attached   bool
+	detachTime time.Time
+
+	mutex    sync.Mutex
+	sequence uint64
+	pending  []*api.GetEventsResponse
+
+	// acked is closed and replaced when responses are acknowledged
+	acked chan struct{}
+}
+
+func newEventDelivery(id string, scope *tokenScope) *eventDelivery {
+	return &eventDelivery{
+		id:         id,
+		scope:      scope,
+		maxPending: config.CurrentSensor().DeliveryMaxPending,
+		acked:      make(chan struct{}),
+	}
+}
+
+// add numbers a response and holds it until it is acknowledged. If the
+// maximum number of responses is already pending, add waits for some to be
+// acknowledged.
+func (d *eventDelivery) add(ctx context.Context, r *api.GetEventsResponse) error {
+	d.mutex.Lock()
+	for d.maxPending > 0 && len(d.pending) >= d.maxPending {
+		acked := d.acked
+		d.mutex.Unlock()
+		select {
+		case <-acked:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		d.mutex.Lock()
+	}
+	d.sequence++
+	r.SequenceNumber = d.sequence
+	d.pending = append(d.pending, r)
+	d.mutex.Unlock()
+	return nil
+}
+
+// acknowledge releases the responses numbered up to and including sequence,
+// returning the number of responses still pending.
+func (d *eventDelivery) acknowledge(sequence uint64) (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if sequence > d.sequence {
+		return len(d.pending), fmt.Errorf("Sequence number %d has not been sent",
+			sequence)
+	}
+	n := 0
+	for n < len(d.pending) && d.pending[n].SequenceNumber <= sequence {
+		n++
+	}
+	if n > 0 {
+		m := copy(d.pending, d.pending[n:])
+		for i := m; i < len(d.pending); i++ {
+			d.pending[i] = nil
+		}
+		d.pending = d.pending[:m]
+		close(d.acked)
+		d.acked = make(chan struct{})
+	}
+	return len(d.pending), nil
+}
+
+// unacknowledged returns the responses that are pending, in order.
+func (d *eventDelivery) unacknowledged() []*api.GetEventsResponse {
+	d.mutex.Lock()
+	r := make([]*api.GetEventsResponse, len(d.pending))
+	copy(r, d.pending)
+	d.mutex.Unlock()
+	return r
+}
+
+// attachDelivery returns the delivery for a stream, making it if it does
+// not exist. Deliveries whose streams were closed longer ago than the
+// configured retention are discarded.
+func (t *telemetryServiceServer) attachDelivery(
+	id string,
+	scope *tokenScope,
+	now time.Time,
+) (*eventDelivery, error) {
+	t.deliveriesMutex.Lock()
+	defer t.deliveriesMutex.Unlock()
+
+	retention := config.CurrentSensor().DeliveryRetention
+	for k, d := range t.deliveries {
+		if !d.attached && now.Sub(d.detachTime) > retention {
+			delete(t.deliveries, k)
+		}
+	}
+
+	d, ok := t.deliveries[id]
+	if !ok {
+		if t.deliveries == nil {
+			t.deliveries = make(map[string]*eventDelivery)
+		}
+		d = newEventDelivery(id, scope)
+		t.deliveries[id] = d
+	} else if !scope.permits(d.scope) {
+		return nil, permissionDenied("Delivery %q belongs to another token", id)
+	} else if d.attached {
+		return nil, fmt.Errorf("Delivery %q is in use", id)
+	}
+	d.attached = true
+	return d, nil
+}
+
+func (t *telemetryServiceServer) detachDelivery(d *eventDelivery, now time.Time) {
+	t.deliveriesMutex.Lock()
+	d.attached = false
+	d.detachTime = now
+	t.deliveriesMutex.Unlock()
+}
+
+func (t *telemetryServiceServer) AcknowledgeEvents(
+	ctx context.Context,
+	req *api.AcknowledgeEventsRequest,
+) (*api.AcknowledgeEventsResponse, error) {
+	glog.V(2).Infof("AcknowledgeEvents(%+v)", req)
+
+	t.deliveriesMutex.Lock()
+	d, ok := t.deliveries[req.DeliveryId]
+	t.deliveriesMutex.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("Delivery %q does not exist", req.DeliveryId)
+	}
+	if !tokenScopeFromContext(ctx).permits(d.scope) {
+		return nil, permissionDenied("Delivery %q belongs to another token",
+			req.DeliveryId)
+	}
+
+	pending, err := d.acknowledge(req.SequenceNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &api.AcknowledgeEventsResponse{
+		Pending: uint64(pending),
+	}, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pendingSequenceNumbers(d *eventDelivery) []uint64 {
+	var r []uint64
+	for _, p := range d.unacknowledged() {
+		r = append(r, p.SequenceNumber)
+	}
+	return r
+}
+
+func TestEventDelivery(t *testing.T) {
+	d := newEventDelivery("test", nil)
+	d.maxPending = 3
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, d.add(ctx, &api.GetEventsResponse{}))
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, pendingSequenceNumbers(d))
+
+	_, err := d.acknowledge(4)
+	assert.Error(t, err)
+
+	pending, err := d.acknowledge(2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pending)
+	assert.Equal(t, []uint64{3}, pendingSequenceNumbers(d))
+
+	// Acknowledging again is harmless
+	pending, err = d.acknowledge(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pending)
+
+	require.NoError(t, d.add(ctx, &api.GetEventsResponse{}))
+	require.NoError(t, d.add(ctx, &api.GetEventsResponse{}))
+	assert.Equal(t, []uint64{3, 4, 5}, pendingSequenceNumbers(d))
+
+	// Adding waits for an acknowledgement while too many are pending
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	err = d.add(timeoutCtx, &api.GetEventsResponse{})
+	cancel()
+	assert.Error(t, err)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		d.acknowledge(3)
+	}()
+	r := &api.GetEventsResponse{}
+	require.NoError(t, d.add(ctx, r))
+	assert.Equal(t, uint64(6), r.SequenceNumber)
+	assert.Equal(t, []uint64{4, 5, 6}, pendingSequenceNumbers(d))
+}
+
+func TestAttachDelivery(t *testing.T) {
+	ts := &telemetryServiceServer{}
+	now := time.Now()
+
+	d, err := ts.attachDelivery("a", nil, now)
+	require.NoError(t, err)
+	_, err = ts.attachDelivery("a", nil, now)
+	assert.Error(t, err)
+
+	ts.detachDelivery(d, now)
+	d2, err := ts.attachDelivery("a", nil, now)
+	require.NoError(t, err)
+	assert.True(t, d == d2)
+
+	// Deliveries are discarded once they have been detached for longer
+	// than the retention
+	ts.detachDelivery(d, now)
+	later := now.Add(config.Sensor.DeliveryRetention + time.Second)
+	_, err = ts.attachDelivery("b", nil, later)
+	require.NoError(t, err)
+	assert.NotContains(t, ts.deliveries, "a")
+	assert.Contains(t, ts.deliveries, "b")
+
+	_, err = ts.AcknowledgeEvents(context.Background(),
+		&api.AcknowledgeEventsRequest{DeliveryId: "a"})
+	assert.Error(t, err)
+	r, err := ts.AcknowledgeEvents(context.Background(),
+		&api.AcknowledgeEventsRequest{DeliveryId: "b"})
+	require.NoError(t, err)
+	assert.Zero(t, r.Pending)
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"reflect"
+	"sort"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// Event sources are enabled and disabled for the whole Sensor by the
+// EventFilter fields that request their events, named as in the protobuf
+// definition (i.e. "container_events" or "tty_events"), just as they are
+// for tokens. Disabled events are removed from every subscription, and the
+// subscriber is told so in a status message. The Sensor still monitors
+// processes and containers itself to attribute other events to them.
+
+// newDisabledEventFilters returns the set of EventFilter fields that are
+// disabled by the names of the enabled and disabled fields. If any are
+// enabled, all others are disabled. It returns nil if none are disabled.
+func newDisabledEventFilters(enabled, disabled []string) (map[string]bool, error) {
+	enabledSet, err := newEventFilterSet(enabled)
+	if err != nil {
+		return nil, err
+	}
+	disabledSet, err := newEventFilterSet(disabled)
+	if err != nil {
+		return nil, err
+	}
+	if enabledSet != nil {
+		for name := range eventFilterNames {
+			if !enabledSet[name] {
+				if disabledSet == nil {
+					disabledSet = make(map[string]bool)
+				}
+				disabledSet[name] = true
+			}
+		}
+	}
+	return disabledSet, nil
+}
+
+// removeDisabledEventFilters returns a copy of an EventFilter without the
+// disabled fields, and the sorted names of those that were not empty.
+func removeDisabledEventFilters(
+	filter *api.EventFilter,
+	disabled map[string]bool,
+) (*api.EventFilter, []string) {
+	eventFilterNamesOnce.Do(initEventFilterNames)
+
+	var (
+		removed []string
+		v       reflect.Value
+	)
+	for name := range disabled {
+		i := eventFilterNames[name]
+		if reflect.ValueOf(filter).Elem().Field(i).Len() == 0 {
+			continue
+		}
+		if !v.IsValid() {
+			c := *filter
+			filter = &c
+			v = reflect.ValueOf(filter).Elem()
+		}
+		v.Field(i).Set(reflect.Zero(v.Field(i).Type()))
+		removed = append(removed, name)
+	}
+	sort.Strings(removed)
+	return filter, removed
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDisabledEventFilters(t *testing.T) {
+	disabled, err := newDisabledEventFilters(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, disabled)
+
+	disabled, err = newDisabledEventFilters(nil, []string{"tty_events"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"tty_events": true}, disabled)
+
+	disabled, err = newDisabledEventFilters(
+		[]string{"process_events", "container_events"},
+		[]string{"container_events"})
+	require.NoError(t, err)
+	assert.False(t, disabled["process_events"])
+	assert.True(t, disabled["container_events"])
+	assert.True(t, disabled["syscall_events"])
+	assert.True(t, disabled["network_events"])
+	assert.Len(t, disabled, len(eventFilterNames)-1)
+
+	_, err = newDisabledEventFilters([]string{"processes"}, nil)
+	assert.Error(t, err)
+	_, err = newDisabledEventFilters(nil, []string{"tty"})
+	assert.Error(t, err)
+}
+
+func TestRemoveDisabledEventFilters(t *testing.T) {
+	filter := &api.EventFilter{
+		SyscallEvents: []*api.SyscallEventFilter{
+			&api.SyscallEventFilter{},
+		},
+		TtyEvents: []*api.TtyEventFilter{
+			&api.TtyEventFilter{},
+		},
+	}
+	disabled := map[string]bool{
+		"network_events": true,
+		"tty_events":     true,
+	}
+
+	f, removed := removeDisabledEventFilters(filter, disabled)
+	assert.Equal(t, []string{"tty_events"}, removed)
+	assert.Len(t, f.SyscallEvents, 1)
+	assert.Len(t, f.TtyEvents, 0)
+	assert.Len(t, filter.TtyEvents, 1)
+
+	filter.TtyEvents = nil
+	f, removed = removeDisabledEventFilters(filter, disabled)
+	assert.Empty(t, removed)
+	assert.True(t, f == filter)
+}
@@ -0,0 +1,196 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"strings"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"golang.org/x/sys/unix"
+)
+
+// NetworkDNSQueryEventTypes defines the field types that can be used with
+// filters on network DNS query telemetry events.
+var NetworkDNSQueryEventTypes = expression.FieldTypeMap{
+	"id":         expression.ValueTypeUnsignedInt16,
+	"query_name": expression.ValueTypeString,
+}
+
+// NetworkDNSQueryTelemetryEvent is a telemetry event generated by the network
+// DNS query event source when a DNS query is sent to a name server.
+type NetworkDNSQueryTelemetryEvent struct {
+	TelemetryEventData
+
+	// Server is the address of the name server that the query was sent
+	// to.
+	Server NetworkAddressTelemetryEventData
+
+	QueryID   uint16
+	QueryName string
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a network DNS query telemetry event.
+func (e NetworkDNSQueryTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// DNS queries are captured by reading the first bytes of the message payload
+// from the struct msghdr passed to udp_sendmsg, udpv6_sendmsg, and
+// tcp_sendmsg. The payload is found via msg->msg_iter.iov->iov_base, where
+// msg_iter is at offset 16 and iov is at offset 24 within it (Linux 3.19 and
+// later). The query name is the first question in the message, which follows
+// the 12 byte header. Queries sent over TCP are additionally prefixed by a 2
+// byte length.
+//
+// The destination is taken from msg->msg_name if present, or from the
+// socket's struct sock_common if it is connected (see the offsets documented
+// with the TCP kprobes in network.go). Filtering on the destination port
+// happens in the kernel, so only messages sent to port 53 are recorded.
+const (
+	networkKprobeUDPSendmsgSymbol    = "udp_sendmsg"
+	networkKprobeUDPSendmsgFetchargs = "id=+0(+0(+40(%si))):u16 " +
+		"flags=+2(+0(+40(%si))):u16 query_name=+12(+0(+40(%si))):string " +
+		"sa_family=+0(+0(%si)):u16 sin_port=+2(+0(%si)):u16 sin_addr=+4(+0(%si)):u32 " +
+		"sk_family=+16(%di):u16 sk_dport=+12(%di):u16 sk_daddr=+0(%di):u32"
+
+	networkKprobeUDPv6SendmsgSymbol    = "udpv6_sendmsg"
+	networkKprobeUDPv6SendmsgFetchargs = "id=+0(+0(+40(%si))):u16 " +
+		"flags=+2(+0(+40(%si))):u16 query_name=+12(+0(+40(%si))):string " +
+		"sa_family=+0(+0(%si)):u16 sin_port=+2(+0(%si)):u16 " +
+		"sin6_addr_high=+8(+0(%si)):u64 sin6_addr_low=+16(+0(%si)):u64 " +
+		"sk_family=+16(%di):u16 sk_dport=+12(%di):u16 " +
+		"sk_daddr6_high=+56(%di):u64 sk_daddr6_low=+64(%di):u64"
+
+	networkKprobeUDPSendmsgFilter = "sin_port == 0x3500 || sk_dport == 0x3500"
+
+	networkKprobeTCPSendmsgSymbol    = "tcp_sendmsg"
+	networkKprobeTCPSendmsgFetchargs = "id=+2(+0(+40(%si))):u16 " +
+		"flags=+4(+0(+40(%si))):u16 query_name=+14(+0(+40(%si))):string " +
+		"sk_family=+16(%di):u16 sk_dport=+12(%di):u16 sk_daddr=+0(%di):u32 " +
+		"sk_daddr6_high=+56(%di):u64 sk_daddr6_low=+64(%di):u64"
+
+	networkKprobeTCPSendmsgFilter = "sk_dport == 0x3500"
+)
+
+// dnsHeaderFlagQR is the bit in the DNS header flags that is set for
+// responses. Flags are fetched in network byte order, so this is the high bit
+// of the first byte.
+const dnsHeaderFlagQR = 0x0080
+
+// decodeDNSName converts a name in DNS wire format (a sequence of length
+// prefixed labels) into dotted form. Label compression is never used for the
+// first name in a message, so it is not supported.
+func decodeDNSName(wire string) (string, bool) {
+	if len(wire) == 0 {
+		return "", false
+	}
+
+	labels := make([]string, 0, 4)
+	for i := 0; i < len(wire); {
+		n := int(wire[i])
+		if n > 63 || i+1+n > len(wire) {
+			return "", false
+		}
+		labels = append(labels, wire[i+1:i+1+n])
+		i += 1 + n
+	}
+	return strings.Join(labels, "."), true
+}
+
+func (s *Subscription) decodeDNSQuery(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	if data["flags"].(uint16)&dnsHeaderFlagQR != 0 {
+		return nil, nil
+	}
+	name, ok := decodeDNSName(data["query_name"].(string))
+	if !ok {
+		return nil, nil
+	}
+
+	var e NetworkDNSQueryTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+
+	id := data["id"].(uint16)
+	e.QueryID = id<<8 | id>>8
+	e.QueryName = name
+
+	// Make the decoded name visible to filter expressions, which are
+	// evaluated against the sample data after decoding.
+	data["query_name"] = name
+
+	// Prefer the explicit destination address if there is one. Not all
+	// fields are fetched by every kprobe.
+	keys := [...]string{"sin_port", "sin_addr", "sin6_addr_high", "sin6_addr_low"}
+	family, _ := data["sa_family"].(uint16)
+	if family == 0 {
+		family, _ = data["sk_family"].(uint16)
+		keys = [...]string{"sk_dport", "sk_daddr", "sk_daddr6_high", "sk_daddr6_low"}
+	}
+	e.Server.Family = family
+	switch family {
+	case unix.AF_INET:
+		e.Server.IPv4Port, _ = data[keys[0]].(uint16)
+		e.Server.IPv4Address, _ = data[keys[1]].(uint32)
+	case unix.AF_INET6:
+		e.Server.IPv6Port, _ = data[keys[0]].(uint16)
+		e.Server.IPv6AddressHigh, _ = data[keys[2]].(uint64)
+		e.Server.IPv6AddressLow, _ = data[keys[3]].(uint64)
+	}
+
+	return e, nil
+}
+
+// RegisterNetworkDNSQueryEventFilter registers a network DNS query event
+// filter with a subscription.
+func (s *Subscription) RegisterNetworkDNSQueryEventFilter(expr *expression.Expression) {
+	for _, k := range []struct {
+		symbol, fetchargs, filter string
+	}{
+		{
+			networkKprobeUDPSendmsgSymbol,
+			networkKprobeUDPSendmsgFetchargs,
+			networkKprobeUDPSendmsgFilter,
+		},
+		{
+			networkKprobeUDPv6SendmsgSymbol,
+			networkKprobeUDPv6SendmsgFetchargs,
+			networkKprobeUDPSendmsgFilter,
+		},
+		{
+			networkKprobeTCPSendmsgSymbol,
+			networkKprobeTCPSendmsgFetchargs,
+			networkKprobeTCPSendmsgFilter,
+		},
+	} {
+		ok := s.registerWithSensorFilter("DNS query", expr,
+			NetworkDNSQueryEventTypes,
+			func() (*eventSink, error) {
+				// The query name must be decoded before it can
+				// be filtered, and the kernel filter is needed
+				// to select messages sent to port 53
+				return s.registerKprobe(k.symbol, false,
+					k.fetchargs, s.decodeDNSQuery, nil,
+					NetworkDNSQueryEventTypes,
+					perf.WithFilter(k.filter))
+			})
+		if !ok {
+			return
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestDecodeDNSName(t *testing.T) {
+	type testCase struct {
+		wire  string
+		name  string
+		valid bool
+	}
+	testCases := []testCase{
+		testCase{"\x03www\x07example\x03com", "www.example.com", true},
+		testCase{"\x09localhost", "localhost", true},
+		testCase{"", "", false},
+		testCase{"\x07example\x03co", "", false},
+		testCase{"\xc0\x0c", "", false},
+	}
+	for _, tc := range testCases {
+		name, ok := decodeDNSName(tc.wire)
+		assert.Equal(t, tc.valid, ok, "%q", tc.wire)
+		assert.Equal(t, tc.name, name, "%q", tc.wire)
+	}
+}
+
+func TestDNSQueryDecoder(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+
+	// Responses and non-DNS payloads are ignored
+	data := perf.TraceEventSampleData{
+		"id":         uint16(0x3412),
+		"flags":      uint16(0x8081),
+		"query_name": "\x03www\x07example\x03com",
+	}
+	i, err := s.decodeDNSQuery(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
+	data["flags"] = uint16(0x0001)
+	data["query_name"] = "\x40garbage"
+	i, err = s.decodeDNSQuery(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
+	// Unconnected UDP socket; the address comes from msg_name
+	data = perf.TraceEventSampleData{
+		"id":         uint16(0x3412),
+		"flags":      uint16(0x0001),
+		"query_name": "\x03www\x07example\x03com",
+		"sa_family":  uint16(unix.AF_INET),
+		"sin_port":   uint16(0x3500),
+		"sin_addr":   uint32(0x08080808),
+		"sk_family":  uint16(unix.AF_INET),
+		"sk_dport":   uint16(0),
+		"sk_daddr":   uint32(0),
+	}
+	data["common_pid"] = int32(sensorPID)
+	i, err = s.decodeDNSQuery(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
+	delete(data, "common_pid")
+	i, err = s.decodeDNSQuery(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, NetworkDNSQueryTelemetryEvent{}, i)
+
+	e := i.(NetworkDNSQueryTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+
+	assert.Equal(t, uint16(0x1234), e.QueryID)
+	assert.Equal(t, "www.example.com", e.QueryName)
+	assert.Equal(t, "www.example.com", data["query_name"])
+	assert.Equal(t, uint16(unix.AF_INET), e.Server.Family)
+	assert.Equal(t, uint32(0x08080808), e.Server.IPv4Address)
+	assert.Equal(t, uint16(0x3500), e.Server.IPv4Port)
+
+	// Connected TCP socket; the address comes from the socket
+	data = perf.TraceEventSampleData{
+		"id":             uint16(0x3412),
+		"flags":          uint16(0x0001),
+		"query_name":     "\x07example\x03org",
+		"sk_family":      uint16(unix.AF_INET6),
+		"sk_dport":       uint16(0x3500),
+		"sk_daddr":       uint32(0),
+		"sk_daddr6_high": uint64(0x20014860),
+		"sk_daddr6_low":  uint64(0x88880000),
+	}
+	i, err = s.decodeDNSQuery(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, NetworkDNSQueryTelemetryEvent{}, i)
+
+	e = i.(NetworkDNSQueryTelemetryEvent)
+	assert.Equal(t, "example.org", e.QueryName)
+	assert.Equal(t, uint16(unix.AF_INET6), e.Server.Family)
+	assert.Equal(t, uint64(0x20014860), e.Server.IPv6AddressHigh)
+	assert.Equal(t, uint64(0x88880000), e.Server.IPv6AddressLow)
+	assert.Equal(t, uint16(0x3500), e.Server.IPv6Port)
+}
+
+const dnsKprobeFormat = `name: ^^NAME^^
+ID: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:u16 id;	offset:8;	size:2;	signed:0;
+	field:u16 flags;	offset:10;	size:2;	signed:0;
+	field:__data_loc char[] query_name;	offset:12;	size:4;	signed:1;
+	field:u16 sk_family;	offset:16;	size:2;	signed:0;
+	field:u16 sk_dport;	offset:18;	size:2;	signed:0;
+	field:u32 sk_daddr;	offset:20;	size:4;	signed:0;
+
+print fmt: "id=%u flags=%u query_name=\"%s\" sk_family=%u sk_dport=%u sk_daddr=%u", REC->id, REC->flags, __get_str(query_name), REC->sk_family, REC->sk_dport, REC->sk_daddr`
+
+func prepareForRegisterNetworkDNSQueryEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	newUnitTestKprobe(t, s.sensor, delta, dnsKprobeFormat)
+	newUnitTestKprobe(t, s.sensor, delta+1, dnsKprobeFormat)
+	newUnitTestKprobe(t, s.sensor, delta+2, dnsKprobeFormat)
+}
+
+func TestDNSQueryEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Like(expression.Identifier("query_name"),
+		expression.Value("*.example.com"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	prepareForRegisterNetworkDNSQueryEventFilter(t, s, 0)
+	s.RegisterNetworkDNSQueryEventFilter(expr)
+	assert.Len(t, s.eventSinks, 3)
+	assert.Len(t, s.status, 0)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterNetworkDNSQueryEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
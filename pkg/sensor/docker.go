@@ -34,22 +34,28 @@ import (
 // ----------------------------------------------------------------------------
 
 type dockerConfigState struct {
-	Running           bool      `json:"Running"`
-	Paused            bool      `json:"Paused"`
-	Restarting        bool      `json:"Restarting"`
-	OOMKilled         bool      `json:"OOMKilled"`
-	RemovalInProgress bool      `json:"RemovalInProgress"`
-	Dead              bool      `json:"Dead"`
-	Pid               int       `json:"Pid"`
-	StartedAt         time.Time `json:"StartedAt"`
-	FinishedAt        time.Time `json:"FinishedAt"`
-	Health            string    `json:"Health"`
-	ExitCode          int       `json:"ExitCode"`
+	Running           bool                `json:"Running"`
+	Paused            bool                `json:"Paused"`
+	Restarting        bool                `json:"Restarting"`
+	OOMKilled         bool                `json:"OOMKilled"`
+	RemovalInProgress bool                `json:"RemovalInProgress"`
+	Dead              bool                `json:"Dead"`
+	Pid               int                 `json:"Pid"`
+	StartedAt         time.Time           `json:"StartedAt"`
+	FinishedAt        time.Time           `json:"FinishedAt"`
+	Health            *dockerConfigHealth `json:"Health"`
+	ExitCode          int                 `json:"ExitCode"`
+}
+
+type dockerConfigHealth struct {
+	Status        string `json:"Status"`
+	FailingStreak int    `json:"FailingStreak"`
 }
 
 type dockerConfigConfig struct {
 	// XXX: Fill in as needed ...
-	Image string `json:"Image"`
+	Image  string            `json:"Image"`
+	Labels map[string]string `json:"Labels"`
 	// XXX: ...
 }
 
@@ -183,12 +189,29 @@ func (dm *dockerMonitor) processDockerConfig(
 	if err != nil {
 		return err
 	}
-	JSONString := string(configJSON)
 
 	paths := strings.Split(configFilename, "/")
 	containerID := paths[len(paths)-2]
 
-	containerCache := dm.sensor.ContainerCache
+	err = processDockerContainerJSON(dm.sensor.ContainerCache, sampleID,
+		containerID, configJSON)
+	if err != nil {
+		glog.V(1).Infof("Could not unmarshal %s: %s", configFilename, err)
+	}
+	return err
+}
+
+// processDockerContainerJSON updates the container cache from a Docker
+// container's JSON state. This may either be the contents of a config.v2.json
+// file or the response from the Docker Engine container inspect API, which
+// share the same layout for the fields that are used.
+func processDockerContainerJSON(
+	containerCache *ContainerCache,
+	sampleID perf.SampleID,
+	containerID string,
+	configJSON []byte,
+) error {
+	JSONString := string(configJSON)
 	containerInfo := containerCache.LookupContainer(containerID, false)
 	if containerInfo != nil && containerInfo.JSONConfig == JSONString {
 		// No change; do nothing more
@@ -196,9 +219,7 @@ func (dm *dockerMonitor) processDockerConfig(
 	}
 
 	var config dockerConfigV2
-	err = json.Unmarshal(configJSON, &config)
-	if err != nil {
-		glog.V(1).Infof("Could not unmarshal %s: %s", configFilename, err)
+	if err := json.Unmarshal(configJSON, &config); err != nil {
 		return err
 	}
 
@@ -210,22 +231,37 @@ func (dm *dockerMonitor) processDockerConfig(
 	}
 	data["JSONConfig"] = JSONString
 	data["Name"] = config.Name
-	data["ImageID"] = strings.TrimPrefix(config.Image, "sha256:")
+	imageID := strings.TrimPrefix(config.Image, "sha256:")
+	data["ImageID"] = imageID
 	data["ImageName"] = config.Config.Image
+	data["Labels"] = config.Config.Labels
+	if imageCache := containerCache.sensor.ImageCache; imageCache != nil {
+		if image, ok := imageCache.LookupImage(imageID); ok {
+			data["ImageDigest"] = imageDigest(config.Config.Image,
+				image.RepoDigests)
+			data["ImageLabels"] = image.Labels
+		}
+	}
 	data["Pid"] = config.State.Pid
 	data["ExitCode"] = config.State.ExitCode
+	if config.State.Health != nil {
+		data["Health"] = config.State.Health.Status
+	} else {
+		data["Health"] = ""
+	}
 
 	var newState ContainerState
 	if !config.State.Running && config.State.StartedAt.IsZero() {
 		newState = ContainerStateCreated
 	} else if config.State.Restarting {
 		newState = ContainerStateRestarting
+	} else if config.State.Paused {
+		// Paused containers are also reported as running
+		newState = ContainerStatePaused
 	} else if config.State.Running && !config.State.StartedAt.IsZero() {
 		newState = ContainerStateRunning
 	} else if config.State.RemovalInProgress {
 		newState = ContainerStateRemoving
-	} else if config.State.Paused {
-		newState = ContainerStatePaused
 	} else if !config.State.Running && !config.State.FinishedAt.IsZero() {
 		newState = ContainerStateExited
 	}
@@ -0,0 +1,364 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// DockerBackendFilesystem selects monitoring of Docker containers via
+	// changes to their configuration files in the container directory.
+	DockerBackendFilesystem = "filesystem"
+
+	// DockerBackendEvents selects monitoring of Docker containers via the
+	// Docker Engine events API.
+	DockerBackendEvents = "events"
+)
+
+// The host portion of URLs is ignored since all requests are made over the
+// Docker Engine unix socket, but it must be present and valid.
+const dockerEventsBaseURL = "http://docker"
+
+const dockerEventsReconnectDelay = 1 * time.Second
+
+type dockerEventActor struct {
+	ID         string            `json:"ID"`
+	Attributes map[string]string `json:"Attributes"`
+}
+
+type dockerEventMessage struct {
+	Type     string           `json:"Type"`
+	Action   string           `json:"Action"`
+	Actor    dockerEventActor `json:"Actor"`
+	TimeNano int64            `json:"timeNano"`
+}
+
+type dockerContainerSummary struct {
+	ID string `json:"Id"`
+}
+
+type dockerImageSummary struct {
+	ID          string            `json:"Id"`
+	RepoTags    []string          `json:"RepoTags"`
+	RepoDigests []string          `json:"RepoDigests"`
+	Labels      map[string]string `json:"Labels"`
+}
+
+type dockerImageConfig struct {
+	Labels map[string]string `json:"Labels"`
+}
+
+type dockerImageInspect struct {
+	ID          string            `json:"Id"`
+	RepoTags    []string          `json:"RepoTags"`
+	RepoDigests []string          `json:"RepoDigests"`
+	Config      dockerImageConfig `json:"Config"`
+}
+
+// dockerEventsMonitor monitors the Docker Engine events API for container
+// lifecycle events and image activity.
+type dockerEventsMonitor struct {
+	sensor     *Sensor
+	socketPath string
+	client     *http.Client
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	waitGroup sync.WaitGroup
+}
+
+// newDockerEventsMonitor creates a new Docker monitor that uses the Docker
+// Engine API listening on the specified unix socket. When changes occur, the
+// sensor's container cache is updated.
+func newDockerEventsMonitor(sensor *Sensor, socketPath string) *dockerEventsMonitor {
+	dem := &dockerEventsMonitor{
+		sensor:     sensor,
+		socketPath: socketPath,
+	}
+	dem.client = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", dem.socketPath)
+			},
+		},
+	}
+	dem.ctx, dem.cancel = context.WithCancel(context.Background())
+
+	return dem
+}
+
+// start connects to the Docker Engine API, scans for existing containers, and
+// begins streaming events. An error is returned if the API cannot be reached,
+// in which case the caller may fall back to another backend.
+func (dem *dockerEventsMonitor) start() error {
+	// Open the event stream before scanning for existing containers so
+	// that nothing is missed in between.
+	resp, err := dem.openEventStream()
+	if err != nil {
+		return err
+	}
+
+	var images []dockerImageSummary
+	if err = dem.getJSON("/images/json", &images); err != nil {
+		resp.Body.Close()
+		return err
+	}
+	for _, i := range images {
+		info := ImageInfo{
+			ID:          strings.TrimPrefix(i.ID, "sha256:"),
+			RepoTags:    i.RepoTags,
+			RepoDigests: i.RepoDigests,
+			Labels:      i.Labels,
+		}
+		var name string
+		if len(i.RepoTags) > 0 {
+			name = i.RepoTags[0]
+		}
+		dem.sensor.ImageCache.UpdateImage(0, perf.SampleID{}, name, info)
+	}
+
+	var containers []dockerContainerSummary
+	if err = dem.getJSON("/containers/json?all=1", &containers); err != nil {
+		resp.Body.Close()
+		return err
+	}
+	for _, c := range containers {
+		if err = dem.inspectContainer(perf.SampleID{}, c.ID); err == nil {
+			glog.V(2).Infof("{DOCKER} Found existing container %s", c.ID)
+		}
+	}
+
+	dem.waitGroup.Add(1)
+	go dem.eventLoop(resp)
+
+	return nil
+}
+
+// stop terminates the event stream and waits for the monitor to finish
+// processing events.
+func (dem *dockerEventsMonitor) stop() {
+	dem.cancel()
+	dem.waitGroup.Wait()
+}
+
+func (dem *dockerEventsMonitor) get(path string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", dockerEventsBaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := dem.client.Do(req.WithContext(dem.ctx))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("Docker API request %s failed: %s",
+			path, resp.Status)
+	}
+	return resp, nil
+}
+
+func (dem *dockerEventsMonitor) getJSON(path string, v interface{}) error {
+	resp, err := dem.get(path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (dem *dockerEventsMonitor) openEventStream() (*http.Response, error) {
+	filters := url.QueryEscape(`{"type":["container","image"]}`)
+	return dem.get("/events?filters=" + filters)
+}
+
+func (dem *dockerEventsMonitor) eventLoop(resp *http.Response) {
+	defer dem.waitGroup.Done()
+
+	for {
+		dem.readEvents(resp)
+		resp.Body.Close()
+
+		// The stream ends if the Docker daemon restarts. Keep trying
+		// to reconnect until the monitor is stopped.
+		for {
+			select {
+			case <-dem.ctx.Done():
+				return
+			case <-time.After(dockerEventsReconnectDelay):
+			}
+
+			var err error
+			if resp, err = dem.openEventStream(); err == nil {
+				break
+			}
+			glog.V(2).Infof("Could not reconnect to Docker API at %s: %s",
+				dem.socketPath, err)
+		}
+	}
+}
+
+func (dem *dockerEventsMonitor) readEvents(resp *http.Response) {
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var msg dockerEventMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if dem.ctx.Err() == nil {
+				glog.V(1).Infof("Docker event stream ended: %s", err)
+			}
+			return
+		}
+		dem.handleEvent(&msg)
+	}
+}
+
+func (dem *dockerEventsMonitor) handleEvent(msg *dockerEventMessage) {
+	if len(msg.Actor.ID) == 0 {
+		return
+	}
+
+	// Docker event times are wall clock times, but the sensor orders
+	// samples by the monotonic clock. Use the time that the event was
+	// received instead.
+	sampleID := perf.SampleID{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+
+	switch msg.Type {
+	case "container":
+		dem.handleContainerEvent(sampleID, msg)
+	case "image":
+		dem.handleImageEvent(sampleID, msg)
+	}
+}
+
+func (dem *dockerEventsMonitor) handleContainerEvent(
+	sampleID perf.SampleID,
+	msg *dockerEventMessage,
+) {
+
+	// Health status changes are reported with the new status appended to
+	// the action (i.e. "health_status: healthy")
+	action := msg.Action
+	if strings.HasPrefix(action, "health_status") {
+		action = "health_status"
+	}
+
+	switch action {
+	case "create", "start", "die", "pause", "unpause", "health_status":
+		if err := dem.inspectContainer(sampleID, msg.Actor.ID); err != nil {
+			glog.V(1).Infof("Could not inspect container %s: %s",
+				msg.Actor.ID, err)
+		}
+	case "destroy":
+		dem.sensor.ContainerCache.DeleteContainer(msg.Actor.ID,
+			ContainerRuntimeDocker, sampleID)
+	}
+}
+
+func (dem *dockerEventsMonitor) inspectContainer(
+	sampleID perf.SampleID,
+	containerID string,
+) error {
+	resp, err := dem.get("/containers/" + url.PathEscape(containerID) + "/json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	configJSON, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return processDockerContainerJSON(dem.sensor.ContainerCache, sampleID,
+		containerID, configJSON)
+}
+
+func (dem *dockerEventsMonitor) handleImageEvent(
+	sampleID perf.SampleID,
+	msg *dockerEventMessage,
+) {
+	imageCache := dem.sensor.ImageCache
+
+	// For pull events, the actor is the image reference that was pulled.
+	// For all others, it is the image ID.
+	switch msg.Action {
+	case "pull":
+		info, err := dem.inspectImage(msg.Actor.ID)
+		if err != nil {
+			glog.V(1).Infof("Could not inspect image %s: %s",
+				msg.Actor.ID, err)
+			return
+		}
+		imageCache.UpdateImage(imageCache.ImagePulledEventID, sampleID,
+			msg.Actor.ID, info)
+	case "tag":
+		info, err := dem.inspectImage(msg.Actor.ID)
+		if err != nil {
+			glog.V(1).Infof("Could not inspect image %s: %s",
+				msg.Actor.ID, err)
+			return
+		}
+		imageCache.UpdateImage(imageCache.ImageTaggedEventID, sampleID,
+			msg.Actor.Attributes["name"], info)
+	case "untag":
+		imageID := strings.TrimPrefix(msg.Actor.ID, "sha256:")
+		info, err := dem.inspectImage(msg.Actor.ID)
+		if err != nil {
+			// The image may already be gone if this was its
+			// last tag.
+			info, _ = imageCache.LookupImage(imageID)
+			info.ID = imageID
+		}
+		imageCache.UpdateImage(imageCache.ImageUntaggedEventID, sampleID,
+			"", info)
+	case "delete":
+		imageID := strings.TrimPrefix(msg.Actor.ID, "sha256:")
+		imageCache.DeleteImage(imageID, sampleID)
+	}
+}
+
+func (dem *dockerEventsMonitor) inspectImage(name string) (ImageInfo, error) {
+	var image dockerImageInspect
+	err := dem.getJSON("/images/"+url.PathEscape(name)+"/json", &image)
+	if err != nil {
+		return ImageInfo{}, err
+	}
+
+	return ImageInfo{
+		ID:          strings.TrimPrefix(image.ID, "sha256:"),
+		RepoTags:    image.RepoTags,
+		RepoDigests: image.RepoDigests,
+		Labels:      image.Config.Labels,
+	}, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dockerEventsTestContainerID = "feedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedface"
+
+const dockerEventsTestImageID = "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b"
+
+const dockerEventsTestInspectFormat = `{"Id":"feedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedfacefeedface","Created":"2018-07-29T13:03:15.475112279Z","Path":"bash","Args":[],"State":{"Status":"%s","Running":%t,"Paused":false,"Restarting":false,"OOMKilled":false,"Dead":false,"Pid":%d,"ExitCode":0,"Error":"","StartedAt":"%s","FinishedAt":"0001-01-01T00:00:00Z"},"Image":"sha256:59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b","Name":"/sleepy_turing","Config":{"Image":"bash"}}`
+
+type dockerEventsTestServer struct {
+	sync.Mutex
+	inspect string
+	events  chan string
+}
+
+func (s *dockerEventsTestServer) setInspect(status string, running bool, pid int, startedAt string) {
+	s.Lock()
+	s.inspect = fmt.Sprintf(dockerEventsTestInspectFormat, status, running,
+		pid, startedAt)
+	s.Unlock()
+}
+
+func (s *dockerEventsTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/containers/json":
+		fmt.Fprintf(w, `[{"Id":"%s"}]`, dockerEventsTestContainerID)
+	case r.URL.Path == "/images/json":
+		fmt.Fprintf(w, `[{"Id":"sha256:%s","RepoTags":["bash:latest"],"RepoDigests":[],"Labels":null}]`,
+			dockerEventsTestImageID)
+	case r.URL.Path == "/images/nginx:latest/json", r.URL.Path == "/images/sha256:"+dockerEventsTestImageID+"/json":
+		fmt.Fprintf(w, `{"Id":"sha256:%s","RepoTags":["nginx:latest","registry.example.com:5000/web/nginx:1.15"],"RepoDigests":["nginx@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424"],"Config":{"Labels":{"maintainer":"NGINX Docker Maintainers"}}}`,
+			dockerEventsTestImageID)
+	case r.URL.Path == "/containers/"+dockerEventsTestContainerID+"/json":
+		s.Lock()
+		w.Write([]byte(s.inspect))
+		s.Unlock()
+	case r.URL.Path == "/events":
+		if !strings.Contains(r.URL.Query().Get("filters"), "container") {
+			http.Error(w, "missing filters", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case event := <-s.events:
+				w.Write([]byte(event))
+				w.(http.Flusher).Flush()
+			}
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// waitForContainerState waits for a snapshot of a container's cached info
+// to satisfy f, which is called with false if the container is not cached.
+// Snapshots are taken with the cache locked, since the info is updated by
+// the monitors' goroutines.
+func waitForContainerState(
+	sensor *Sensor,
+	containerID string,
+	f func(ContainerInfo, bool) bool,
+) bool {
+	for i := 0; i < 100; i++ {
+		if f(sensor.ContainerCache.snapshotContainer(containerID)) {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestDockerEventsMonitor(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	// With nothing listening on the socket, start should fail
+	socketPath := filepath.Join(sensor.runtimeDir, "docker.sock")
+	dem := newDockerEventsMonitor(sensor, socketPath)
+	assert.Error(t, dem.start())
+
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	handler := &dockerEventsTestServer{events: make(chan string)}
+	handler.setInspect("created", false, 0, "0001-01-01T00:00:00Z")
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = l
+	server.Start()
+	defer server.Close()
+
+	dem = newDockerEventsMonitor(sensor, socketPath)
+	require.NoError(t, dem.start())
+	defer dem.stop()
+
+	// The existing container should have been found by start
+	info, ok := sensor.ContainerCache.snapshotContainer(dockerEventsTestContainerID)
+	if assert.True(t, ok) {
+		assert.Equal(t, ContainerStateCreated, info.State)
+		assert.Equal(t, "/sleepy_turing", info.Name)
+		assert.Equal(t, "bash", info.ImageName)
+		assert.Equal(t, "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b", info.ImageID)
+	}
+
+	handler.setInspect("running", true, 31337, "2018-07-29T10:28:00Z")
+	handler.events <- fmt.Sprintf(`{"Type":"container","Action":"start","Actor":{"ID":"%s","Attributes":{}}}`,
+		dockerEventsTestContainerID)
+	ok = waitForContainerState(sensor, dockerEventsTestContainerID,
+		func(info ContainerInfo, ok bool) bool {
+			return ok && info.State == ContainerStateRunning
+		})
+	assert.True(t, ok)
+
+	handler.setInspect("running", true, 31337, "2018-07-29T10:28:00Z")
+	handler.Lock()
+	handler.inspect = strings.Replace(handler.inspect, `"ExitCode"`,
+		`"Health":{"Status":"unhealthy","FailingStreak":3},"ExitCode"`, 1)
+	handler.Unlock()
+	handler.events <- fmt.Sprintf(`{"Type":"container","Action":"health_status: unhealthy","Actor":{"ID":"%s","Attributes":{}}}`,
+		dockerEventsTestContainerID)
+	ok = waitForContainerState(sensor, dockerEventsTestContainerID,
+		func(info ContainerInfo, ok bool) bool {
+			return ok && info.Health == "unhealthy"
+		})
+	assert.True(t, ok)
+
+	// Events for other object types should be ignored
+	handler.events <- fmt.Sprintf(`{"Type":"network","Action":"destroy","Actor":{"ID":"%s","Attributes":{}}}`,
+		dockerEventsTestContainerID)
+
+	handler.events <- fmt.Sprintf(`{"Type":"container","Action":"destroy","Actor":{"ID":"%s","Attributes":{}}}`,
+		dockerEventsTestContainerID)
+	ok = waitForContainerState(sensor, dockerEventsTestContainerID,
+		func(info ContainerInfo, ok bool) bool {
+			return !ok
+		})
+	assert.True(t, ok)
+
+	// Image events
+	image, ok := sensor.ImageCache.LookupImage(dockerEventsTestImageID)
+	if assert.True(t, ok) {
+		assert.Equal(t, "bash:latest", image.Name)
+	}
+
+	handler.events <- `{"Type":"image","Action":"pull","Actor":{"ID":"nginx:latest","Attributes":{"name":"nginx"}}}`
+	ok = waitForImage(sensor, dockerEventsTestImageID,
+		func(info ImageInfo) bool {
+			return info.Name == "nginx:latest"
+		})
+	if assert.True(t, ok) {
+		image, _ = sensor.ImageCache.LookupImage(dockerEventsTestImageID)
+		assert.Equal(t, "docker.io", image.Registry)
+		assert.Equal(t, "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424", image.Digest)
+		assert.Equal(t, "NGINX Docker Maintainers", image.Labels["maintainer"])
+	}
+
+	handler.events <- fmt.Sprintf(`{"Type":"image","Action":"tag","Actor":{"ID":"sha256:%s","Attributes":{"name":"registry.example.com:5000/web/nginx:1.15"}}}`,
+		dockerEventsTestImageID)
+	ok = waitForImage(sensor, dockerEventsTestImageID,
+		func(info ImageInfo) bool {
+			return info.Registry == "registry.example.com:5000"
+		})
+	assert.True(t, ok)
+
+	handler.events <- fmt.Sprintf(`{"Type":"image","Action":"delete","Actor":{"ID":"sha256:%s","Attributes":{"name":"sha256:%s"}}}`,
+		dockerEventsTestImageID, dockerEventsTestImageID)
+	for i := 0; i < 100; i++ {
+		if _, ok = sensor.ImageCache.LookupImage(dockerEventsTestImageID); !ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(t, ok)
+}
+
+func waitForImage(
+	sensor *Sensor,
+	imageID string,
+	f func(ImageInfo) bool,
+) bool {
+	for i := 0; i < 100; i++ {
+		if info, ok := sensor.ImageCache.LookupImage(imageID); ok && f(info) {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
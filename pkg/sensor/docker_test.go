@@ -16,6 +16,7 @@ package sensor
 
 import (
 	"bytes"
+	"fmt"
 	"path/filepath"
 	"testing"
 
@@ -164,3 +165,67 @@ func (e ErrorReader) Read(p []byte) (n int, err error) {
 	err = unix.EBADF
 	return
 }
+
+func TestDockerContainerHealth(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	containerID := "4ea1744ea1744ea1744ea1744ea1744ea1744ea1744ea1744ea1744ea1744ea1"
+	configFormat := `{"ID":"4ea1744ea1744ea1744ea1744ea1744ea1744ea1744ea1744ea1744ea1744ea1","State":{"Running":true,"Pid":1234,"StartedAt":"2018-07-29T10:28:00Z","FinishedAt":"0001-01-01T00:00:00Z","Health":%s},"Config":{"Image":"bash"}}`
+	sampleID := perf.SampleID{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+
+	healths := map[string]string{
+		`null`: "",
+		`{"Status":"starting","FailingStreak":0,"Log":[]}`:  "starting",
+		`{"Status":"unhealthy","FailingStreak":3,"Log":[]}`: "unhealthy",
+		`{"Status":"healthy","FailingStreak":0,"Log":[]}`:   "healthy",
+	}
+	for health, status := range healths {
+		configJSON := fmt.Sprintf(configFormat, health)
+		err := processDockerContainerJSON(sensor.ContainerCache, sampleID,
+			containerID, []byte(configJSON))
+		require.NoError(t, err)
+
+		info := sensor.ContainerCache.LookupContainer(containerID, false)
+		if assert.NotNil(t, info) {
+			assert.Equal(t, ContainerStateRunning, info.State)
+			assert.Equal(t, status, info.Health)
+		}
+	}
+}
+
+func TestDockerContainerImageInfo(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	imageID := "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b"
+	sampleID := perf.SampleID{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	sensor.ImageCache.UpdateImage(0, sampleID, "bash:latest", ImageInfo{
+		ID:       imageID,
+		RepoTags: []string{"bash:latest"},
+		RepoDigests: []string{
+			"bash@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+		},
+		Labels: map[string]string{"maintainer": "someone"},
+	})
+
+	containerID := "1ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab3"
+	configJSON := `{"ID":"1ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab31ab3","Image":"sha256:` + imageID + `","State":{"Running":false,"StartedAt":"0001-01-01T00:00:00Z","FinishedAt":"0001-01-01T00:00:00Z"},"Config":{"Image":"bash:latest","Labels":{"app":"shell"}}}`
+	err := processDockerContainerJSON(sensor.ContainerCache, sampleID,
+		containerID, []byte(configJSON))
+	require.NoError(t, err)
+
+	info := sensor.ContainerCache.LookupContainer(containerID, false)
+	if assert.NotNil(t, info) {
+		assert.Equal(t, imageID, info.ImageID)
+		assert.Equal(t, "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+			info.ImageDigest)
+		assert.Equal(t, map[string]string{"maintainer": "someone"},
+			info.ImageLabels)
+		assert.Equal(t, map[string]string{"app": "shell"}, info.Labels)
+	}
+}
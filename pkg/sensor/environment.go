@@ -0,0 +1,67 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"path"
+	"strings"
+
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/golang/glog"
+)
+
+// SetCaptureExecEnvironment sets whether the subscription's process exec
+// events carry the environment variables of the process that are allowed by
+// the sensor's configuration.
+func (s *Subscription) SetCaptureExecEnvironment(captureExecEnvironment bool) {
+	s.captureExecEnvironment = captureExecEnvironment
+}
+
+// filterEnvironment returns the "NAME=value" environment variables whose
+// names match any of the allowed patterns.
+func filterEnvironment(environment, allowed []string) []string {
+	var filtered []string
+	for _, v := range environment {
+		name := v
+		if i := strings.IndexByte(v, '='); i >= 0 {
+			name = v[:i]
+		}
+		for _, pattern := range allowed {
+			if ok, _ := path.Match(pattern, name); ok {
+				filtered = append(filtered, v)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// execEnvironment returns the allowed environment variables of a process
+// that has just exec'd, or nil if none are allowed or they cannot be read.
+// Everything else is stripped before it leaves this function so that it is
+// never held by the sensor.
+func (pc *ProcessInfoCache) execEnvironment(pid int) []string {
+	allowed := config.Sensor.ExecEnvironment
+	if len(allowed) == 0 {
+		return nil
+	}
+	environment, err := pc.sensor.ProcFS.ProcessEnvironment(pid)
+	if err != nil {
+		glog.V(2).Infof("Couldn't read environment of pid %d: %v", pid, err)
+		return nil
+	}
+	return filterEnvironment(environment, allowed)
+}
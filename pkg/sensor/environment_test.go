@@ -0,0 +1,89 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterEnvironment(t *testing.T) {
+	environment := []string{
+		"PATH=/usr/bin:/bin",
+		"AWS_REGION=us-east-1",
+		"AWS_SECRET_ACCESS_KEY=secret",
+		"HOME=/root",
+		"NOVALUE",
+		"LD_PRELOAD=",
+	}
+
+	assert.Equal(t, []string{
+		"PATH=/usr/bin:/bin",
+		"AWS_REGION=us-east-1",
+		"AWS_SECRET_ACCESS_KEY=secret",
+		"LD_PRELOAD=",
+	}, filterEnvironment(environment, []string{"PATH", "AWS_*", "LD_PRELOAD"}))
+	assert.Equal(t, []string{"NOVALUE"},
+		filterEnvironment(environment, []string{"NOVALUE"}))
+	assert.Empty(t, filterEnvironment(environment, []string{"PAT", "[", "*_PATH"}))
+	assert.Empty(t, filterEnvironment(environment, nil))
+}
+
+func TestExecEnvironment(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	allowed := config.Sensor.ExecEnvironment
+	defer func() {
+		config.Sensor.ExecEnvironment = allowed
+	}()
+
+	config.Sensor.ExecEnvironment = []string{"PATH", "LD_PRELOAD", "AWS_*"}
+	assert.Equal(t, []string{
+		"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+		"LD_PRELOAD=/usr/lib/libjemalloc.so.2",
+		"AWS_REGION=us-east-1",
+		"AWS_SECRET_ACCESS_KEY=wJalrXUtnFEMI",
+	}, sensor.ProcessCache.execEnvironment(111343))
+
+	// Processes that cannot be read have no environment
+	assert.Nil(t, sensor.ProcessCache.execEnvironment(0x7fffffff))
+
+	// Nothing is read when no variables are allowed
+	config.Sensor.ExecEnvironment = nil
+	assert.Nil(t, sensor.ProcessCache.execEnvironment(111343))
+}
+
+func TestTranslateExecEnvironment(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	e := ProcessExecTelemetryEvent{
+		Filename:    "/usr/bin/redis-server",
+		Environment: []string{"PATH=/usr/bin:/bin"},
+	}
+
+	s := newTestSubscription(t, sensor)
+	got := s.translateEvent(e)
+	assert.Nil(t, got.GetProcess().ExecEnvironment)
+
+	s.SetCaptureExecEnvironment(true)
+	got = s.translateEvent(e)
+	assert.Equal(t, []string{"PATH=/usr/bin:/bin"},
+		got.GetProcess().ExecEnvironment)
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/capsule8/capsule8/pkg/sys"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
 )
 
 // TelemetryEvent is an interface defining an event generated by the sensor in
@@ -44,11 +45,19 @@ type TelemetryEventData struct {
 	ProcessID      string
 	PID            int
 	TGID           int
+	NamespacePID   int
+	NamespaceTGID  int
 	CPU            uint32
 	HasCredentials bool
 	Credentials    Cred
+	HasNamespaces  bool
+	Namespaces     proc.Namespaces
 
 	Container ContainerInfo
+
+	// The raw PERF_SAMPLE_CALLCHAIN callchain for the event, if one was
+	// captured
+	Callchain []uint64
 }
 
 // Init initializes a telemetry event with common sensor-specific fields
@@ -96,11 +105,20 @@ func (e *TelemetryEventData) InitWithSample(
 	e.Init(sensor)
 	e.MonotimeNanos = int64(sample.Time) - sensor.bootMonotimeNanos
 	e.CPU = sample.CPU
+	if callchain, ok := data["__callchain__"].([]uint64); ok {
+		e.Callchain = callchain
+	} else {
+		e.Callchain = sample.IPs
+	}
 
 	if task != nil {
 		e.ProcessID = task.ProcessID
 		e.PID = task.PID
 		e.TGID = task.TGID
+		e.NamespacePID, e.NamespaceTGID =
+			sensor.ProcessCache.LookupTaskNamespacePIDs(task)
+		e.Namespaces, e.HasNamespaces =
+			sensor.ProcessCache.LookupTaskNamespaces(task)
 		if task.Creds != nil {
 			e.HasCredentials = true
 			e.Credentials = *task.Creds
@@ -0,0 +1,205 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/expression"
+
+	"github.com/golang/glog"
+)
+
+// Subscription expressions are evaluated against the translated
+// api.TelemetryEvent, which is flattened into a set of fields named by their
+// protobuf field paths, i.e. "event.process.exec_filename". Only scalar fields
+// are included; repeated fields and bytes are not. Fields of messages in a
+// oneof are present only when that member of the oneof is set.
+
+const eventExpressionRoot = "event"
+
+var (
+	eventExpressionOnce  sync.Once
+	eventExpressionTypes expression.FieldTypeMap
+)
+
+func eventExpressionValueType(t reflect.Type) expression.ValueType {
+	switch t.Kind() {
+	case reflect.String:
+		return expression.ValueTypeString
+	case reflect.Int32:
+		// This includes enums
+		return expression.ValueTypeSignedInt32
+	case reflect.Int64:
+		return expression.ValueTypeSignedInt64
+	case reflect.Uint32:
+		return expression.ValueTypeUnsignedInt32
+	case reflect.Uint64:
+		return expression.ValueTypeUnsignedInt64
+	case reflect.Bool:
+		return expression.ValueTypeBool
+	case reflect.Float64:
+		return expression.ValueTypeDouble
+	}
+	return expression.ValueTypeUnspecified
+}
+
+// protobufFieldName returns the protobuf name of a generated struct field,
+// or "" if the field is not a protobuf field.
+func protobufFieldName(f reflect.StructField) string {
+	for _, part := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(part, "name=") {
+			return part[len("name="):]
+		}
+	}
+	return ""
+}
+
+// oneofWrappers returns the wrapper types of the members of the oneofs in a
+// generated message type.
+func oneofWrappers(t reflect.Type) []reflect.Type {
+	m, ok := reflect.PtrTo(t).MethodByName("XXX_OneofFuncs")
+	if !ok {
+		return nil
+	}
+	results := m.Func.Call([]reflect.Value{reflect.New(t)})
+	wrappers := results[len(results)-1].Interface().([]interface{})
+	types := make([]reflect.Type, len(wrappers))
+	for i, w := range wrappers {
+		types[i] = reflect.TypeOf(w).Elem()
+	}
+	return types
+}
+
+func addEventExpressionTypes(
+	types expression.FieldTypeMap,
+	prefix string,
+	t reflect.Type,
+	visiting map[reflect.Type]bool,
+) {
+	// Messages that contain themselves cannot be flattened
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if _, ok := f.Tag.Lookup("protobuf_oneof"); ok {
+			for _, w := range oneofWrappers(t) {
+				addEventExpressionTypes(types, prefix, w, visiting)
+			}
+			continue
+		}
+		name := protobufFieldName(f)
+		if name == "" {
+			continue
+		}
+		if f.Type.Kind() == reflect.Ptr && f.Type.Elem().Kind() == reflect.Struct {
+			addEventExpressionTypes(types, prefix+"."+name,
+				f.Type.Elem(), visiting)
+		} else if vt := eventExpressionValueType(f.Type); vt != expression.ValueTypeUnspecified {
+			types[prefix+"."+name] = vt
+		}
+	}
+}
+
+func initEventExpressionTypes() {
+	eventExpressionTypes = make(expression.FieldTypeMap)
+	addEventExpressionTypes(eventExpressionTypes, eventExpressionRoot,
+		reflect.TypeOf(api.TelemetryEvent{}),
+		make(map[reflect.Type]bool))
+}
+
+func addEventExpressionValues(
+	values expression.FieldValueMap,
+	prefix string,
+	v reflect.Value,
+) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if _, ok := f.Tag.Lookup("protobuf_oneof"); ok {
+			// The wrapper's only field holds the member of the oneof
+			if w := v.Field(i); !w.IsNil() {
+				addEventExpressionValues(values, prefix, w.Elem().Elem())
+			}
+			continue
+		}
+		name := protobufFieldName(f)
+		if name == "" {
+			continue
+		}
+		field := v.Field(i)
+		if f.Type.Kind() == reflect.Ptr && f.Type.Elem().Kind() == reflect.Struct {
+			if !field.IsNil() {
+				addEventExpressionValues(values, prefix+"."+name,
+					field.Elem())
+			}
+			continue
+		}
+		switch eventExpressionValueType(f.Type) {
+		case expression.ValueTypeString:
+			values[prefix+"."+name] = field.String()
+		case expression.ValueTypeSignedInt32:
+			values[prefix+"."+name] = int32(field.Int())
+		case expression.ValueTypeSignedInt64:
+			values[prefix+"."+name] = field.Int()
+		case expression.ValueTypeUnsignedInt32:
+			values[prefix+"."+name] = uint32(field.Uint())
+		case expression.ValueTypeUnsignedInt64:
+			values[prefix+"."+name] = field.Uint()
+		case expression.ValueTypeBool:
+			values[prefix+"."+name] = field.Bool()
+		case expression.ValueTypeDouble:
+			values[prefix+"."+name] = field.Float()
+		}
+	}
+}
+
+// getEventExpressionTypes returns the types of the fields of a translated
+// event.
+func getEventExpressionTypes() expression.FieldTypeMap {
+	eventExpressionOnce.Do(initEventExpressionTypes)
+	return eventExpressionTypes
+}
+
+// eventExpressionValues flattens a translated event into its field values.
+func eventExpressionValues(event *api.TelemetryEvent) expression.FieldValueMap {
+	values := make(expression.FieldValueMap)
+	addEventExpressionValues(values, eventExpressionRoot,
+		reflect.ValueOf(event).Elem())
+	return values
+}
+
+// newEventExpression parses and validates a subscription expression.
+func newEventExpression(text string) (*expression.Expression, error) {
+	return expression.Parse(text, getEventExpressionTypes())
+}
+
+// matchEventExpression evaluates a subscription expression against a
+// translated event.
+func matchEventExpression(expr *expression.Expression, event *api.TelemetryEvent) bool {
+	v, err := expr.Evaluate(eventExpressionTypes, eventExpressionValues(event))
+	if err != nil {
+		glog.V(1).Infof("Expression evaluation error: %s", err)
+		return false
+	}
+	return expression.IsValueTrue(v)
+}
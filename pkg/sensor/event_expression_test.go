@@ -0,0 +1,80 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/expression"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventExpressionTypes(t *testing.T) {
+	_, err := newEventExpression("event.image_name == \"\"")
+	require.NoError(t, err)
+
+	expectedTypes := expression.FieldTypeMap{
+		"event.image_name":             expression.ValueTypeString,
+		"event.process_pid":            expression.ValueTypeSignedInt32,
+		"event.credentials.uid":        expression.ValueTypeUnsignedInt32,
+		"event.process.type":           expression.ValueTypeSignedInt32,
+		"event.process.exec_filename":  expression.ValueTypeString,
+		"event.syscall.id":             expression.ValueTypeSignedInt64,
+		"event.sensor_sequence_number": expression.ValueTypeUnsignedInt64,
+	}
+	for name, expectedType := range expectedTypes {
+		assert.Equal(t, expectedType, eventExpressionTypes[name], name)
+	}
+	assert.NotContains(t, eventExpressionTypes, "event.process_lineage")
+}
+
+func TestMatchEventExpression(t *testing.T) {
+	expr, err := newEventExpression(
+		`event.image_name.startsWith("redis") && event.credentials.uid == 0`)
+	require.NoError(t, err)
+
+	event := &api.TelemetryEvent{
+		ImageName:   "redis:4",
+		Credentials: &api.Credentials{},
+		Event: &api.TelemetryEvent_Process{
+			Process: &api.ProcessEvent{
+				Type:         api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+				ExecFilename: "/usr/local/bin/redis-server",
+			},
+		},
+	}
+	assert.True(t, matchEventExpression(expr, event))
+
+	event.Credentials.Uid = 1000
+	assert.False(t, matchEventExpression(expr, event))
+
+	// Fields of unset messages are NULL
+	event.Credentials = nil
+	assert.False(t, matchEventExpression(expr, event))
+
+	expr, err = newEventExpression(
+		`event.process.exec_filename.endsWith("/redis-server")`)
+	require.NoError(t, err)
+	assert.False(t, matchEventExpression(expr, &api.TelemetryEvent{}))
+	assert.True(t, matchEventExpression(expr, event))
+
+	_, err = newEventExpression(`event.credentials.uid.contains("0")`)
+	assert.Error(t, err)
+	_, err = newEventExpression(`event.no_such_field == 1`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// executableKey identifies a version of an executable file. A file that is
+// modified in place gets a new modification time, and a file that is
+// replaced gets a new inode, so either way it is hashed again.
+type executableKey struct {
+	dev   uint64
+	ino   uint64
+	mtime unix.Timespec
+}
+
+// executableHashCache caches the SHA-256 hashes of executables so that each
+// program is only read once for as long as it is unchanged.
+type executableHashCache struct {
+	sync.Mutex
+	maxEntries int
+	maxSize    int64
+	entries    map[executableKey]string
+}
+
+func newExecutableHashCache(maxEntries int, maxSize int64) *executableHashCache {
+	return &executableHashCache{
+		maxEntries: maxEntries,
+		maxSize:    maxSize,
+		entries:    make(map[executableKey]string),
+	}
+}
+
+// hash returns the hex encoded SHA-256 hash of an open executable file.
+// Files larger than the cache's max size are not hashed.
+func (c *executableHashCache) hash(f *os.File) (string, error) {
+	var stat unix.Stat_t
+	if err := unix.Fstat(int(f.Fd()), &stat); err != nil {
+		return "", err
+	}
+	key := executableKey{
+		dev:   uint64(stat.Dev),
+		ino:   uint64(stat.Ino),
+		mtime: stat.Mtim,
+	}
+
+	c.Lock()
+	h, ok := c.entries[key]
+	c.Unlock()
+	if ok {
+		return h, nil
+	}
+
+	if c.maxSize > 0 && stat.Size > c.maxSize {
+		return "", fmt.Errorf("executable is too large to hash (%d bytes)",
+			stat.Size)
+	}
+	sha := sha256.New()
+	if _, err := io.Copy(sha, f); err != nil {
+		return "", err
+	}
+	h = hex.EncodeToString(sha.Sum(nil))
+
+	c.Lock()
+	if len(c.entries) >= c.maxEntries {
+		// Evict an arbitrary entry. Programs that are exec'd often
+		// will be hashed again soon enough.
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = h
+	c.Unlock()
+
+	return h, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutableHashCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exechash_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "test")
+	require.NoError(t, ioutil.WriteFile(path, []byte("test"), 0755))
+
+	c := newExecutableHashCache(1, 8)
+	hash := func() (string, error) {
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+		return c.hash(f)
+	}
+
+	const testHash = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	h, err := hash()
+	require.NoError(t, err)
+	assert.Equal(t, testHash, h)
+	assert.Len(t, c.entries, 1)
+
+	// Unchanged files are not read again
+	for k := range c.entries {
+		c.entries[k] = "cached"
+	}
+	h, err = hash()
+	require.NoError(t, err)
+	assert.Equal(t, "cached", h)
+
+	// Modified files are, and the cache is limited
+	require.NoError(t, ioutil.WriteFile(path, []byte("modified"), 0755))
+	mtime := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(path, mtime, mtime))
+	h, err = hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, "cached", h)
+	assert.Len(t, c.entries, 1)
+
+	// Large files are not hashed
+	require.NoError(t, ioutil.WriteFile(path, []byte("too large"), 0755))
+	_, err = hash()
+	assert.Error(t, err)
+}
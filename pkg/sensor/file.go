@@ -15,6 +15,9 @@
 package sensor
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 )
@@ -43,11 +46,87 @@ func (e FileOpenTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
 	return e.TelemetryEventData
 }
 
+// FileWriteEventTypes defines the field types that can be used with filters
+// on file write telemetry events.
+var FileWriteEventTypes = expression.FieldTypeMap{
+	"filename": expression.ValueTypeString,
+	"count":    expression.ValueTypeUnsignedInt64,
+}
+
+// FileWriteTelemetryEvent is a telemetry event generated by the file event
+// source when a file is written.
+type FileWriteTelemetryEvent struct {
+	TelemetryEventData
+
+	Filename string
+	Count    uint64
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a file write telemetry event.
+func (e FileWriteTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// FileMemfdCreateEventTypes defines the field types that can be used with
+// filters on memory file creation telemetry events.
+var FileMemfdCreateEventTypes = expression.FieldTypeMap{
+	"filename": expression.ValueTypeString,
+	"flags":    expression.ValueTypeUnsignedInt32,
+}
+
+// FileMemfdCreateTelemetryEvent is a telemetry event generated by the file
+// event source when an anonymous memory file is created. Programs that are
+// executed from these files never exist on a filesystem.
+type FileMemfdCreateTelemetryEvent struct {
+	TelemetryEventData
+
+	Filename string
+	Flags    uint32
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a memory file creation telemetry event.
+func (e FileMemfdCreateTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
 const (
 	fsDoSysOpenKprobeAddress   = "do_sys_open"
 	fsDoSysOpenKprobeFetchargs = "filename=+0(%si):string flags=%dx:s32 mode=%cx:s32"
+
+	fsMemfdCreateKprobeAddress   = "sys_memfd_create"
+	fsMemfdCreateKprobeFetchargs = "filename=+0(%di):string flags=%si:u32"
+)
+
+// vfs_write is only passed a struct file, so the filename must be rebuilt from
+// the chain of dentries starting at file->f_path.dentry (offset 24). Each
+// dentry has its parent at offset 24 and d_name.name at offset 40. The root
+// dentry is its own parent and is named "/". Paths nested more deeply than
+// fsVfsWriteKprobeDepth components are truncated at the start.
+const (
+	fsVfsWriteKprobeAddress = "vfs_write"
+	fsVfsWriteKprobeDepth   = 12
 )
 
+var (
+	fsVfsWriteKprobeFetchargs string
+	fsVfsWriteKprobeNames     [fsVfsWriteKprobeDepth]string
+)
+
+func init() {
+	args := make([]string, 0, fsVfsWriteKprobeDepth+1)
+	dentry := "+24(%di)"
+	for i := range fsVfsWriteKprobeNames {
+		fsVfsWriteKprobeNames[i] = fmt.Sprintf("name%d", i)
+		args = append(args, fmt.Sprintf("%s=+0(+40(%s)):string",
+			fsVfsWriteKprobeNames[i], dentry))
+		dentry = fmt.Sprintf("+24(%s)", dentry)
+	}
+	args = append(args, "count=%dx:u64")
+	fsVfsWriteKprobeFetchargs = strings.Join(args, " ")
+}
+
 func (s *Subscription) decodeDoSysOpen(
 	sample *perf.SampleRecord,
 	data perf.TraceEventSampleData,
@@ -62,10 +141,101 @@ func (s *Subscription) decodeDoSysOpen(
 	return e, nil
 }
 
+func (s *Subscription) decodeSysMemfdCreate(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e FileMemfdCreateTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Filename = data["filename"].(string)
+	e.Flags = data["flags"].(uint32)
+	return e, nil
+}
+
+// dentryPath rebuilds a filename from the dentry names fetched by the
+// vfs_write kprobe, which are ordered from the file up towards the root.
+func dentryPath(data perf.TraceEventSampleData) (string, bool) {
+	components := make([]string, 0, fsVfsWriteKprobeDepth)
+	rooted := false
+	for _, key := range fsVfsWriteKprobeNames {
+		name, _ := data[key].(string)
+		if name == "/" {
+			rooted = true
+			break
+		}
+		if len(name) == 0 {
+			break
+		}
+		components = append(components, name)
+	}
+	if len(components) == 0 {
+		return "", false
+	}
+
+	for i, j := 0, len(components)-1; i < j; i, j = i+1, j-1 {
+		components[i], components[j] = components[j], components[i]
+	}
+	path := strings.Join(components, "/")
+	if rooted {
+		return "/" + path, true
+	}
+	return ".../" + path, true
+}
+
+func (s *Subscription) decodeVfsWrite(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	filename, ok := dentryPath(data)
+	if !ok {
+		return nil, nil
+	}
+
+	var e FileWriteTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Filename = filename
+	e.Count = data["count"].(uint64)
+
+	// Make the filename visible to filter expressions, which are
+	// evaluated against the sample data after decoding.
+	data["filename"] = filename
+
+	return e, nil
+}
+
 // RegisterFileOpenEventFilter registers a file open event filter with a
 // subscription.
 func (s *Subscription) RegisterFileOpenEventFilter(filter *expression.Expression) {
+	if s.sensor.useAuditBackend {
+		s.registerAuditSyscallEventFilter(auditSyscallOpen, filter,
+			FileOpenEventTypes)
+		return
+	}
 	s.registerKprobe(fsDoSysOpenKprobeAddress, false,
 		fsDoSysOpenKprobeFetchargs, s.decodeDoSysOpen,
 		filter, FileOpenEventTypes)
 }
+
+// RegisterFileWriteEventFilter registers a file write event filter with a
+// subscription.
+func (s *Subscription) RegisterFileWriteEventFilter(expr *expression.Expression) {
+	s.registerWithSensorFilter("file write", expr, FileWriteEventTypes,
+		func() (*eventSink, error) {
+			// The filename is only known after decoding
+			return s.registerKprobe(fsVfsWriteKprobeAddress, false,
+				fsVfsWriteKprobeFetchargs, s.decodeVfsWrite, nil,
+				FileWriteEventTypes)
+		})
+}
+
+// RegisterFileMemfdCreateEventFilter registers a memory file creation event
+// filter with a subscription.
+func (s *Subscription) RegisterFileMemfdCreateEventFilter(expr *expression.Expression) {
+	s.registerKprobe(fsMemfdCreateKprobeAddress, false,
+		fsMemfdCreateKprobeFetchargs, s.decodeSysMemfdCreate,
+		expr, FileMemfdCreateEventTypes)
+}
@@ -105,3 +105,202 @@ func TestRegisterFileOpenEventFilter(t *testing.T) {
 	s.RegisterFileOpenEventFilter(nil)
 	verifyRegisterFileOpenEventFilter(t, s, 1)
 }
+
+func TestDentryPath(t *testing.T) {
+	type testCase struct {
+		names []string
+		path  string
+		valid bool
+	}
+	testCases := []testCase{
+		testCase{[]string{"passwd", "etc", "/"}, "/etc/passwd", true},
+		testCase{[]string{"foo", "/", "/"}, "/foo", true},
+		testCase{[]string{"c", "b", "a"}, ".../a/b/c", true},
+		testCase{[]string{"pipe:[1234]", ""}, ".../pipe:[1234]", true},
+		testCase{[]string{"", "etc", "/"}, "", false},
+		testCase{[]string{"/"}, "", false},
+	}
+	for _, tc := range testCases {
+		data := perf.TraceEventSampleData{}
+		for i, name := range tc.names {
+			data[fsVfsWriteKprobeNames[i]] = name
+		}
+		path, ok := dentryPath(data)
+		assert.Equal(t, tc.valid, ok, "%v", tc.names)
+		assert.Equal(t, tc.path, path, "%v", tc.names)
+	}
+}
+
+func TestDecodeVfsWrite(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"name0":      "foo.bar",
+		"name1":      "to",
+		"name2":      "path",
+		"name3":      "/",
+		"count":      uint64(4096),
+	}
+
+	i, err := s.decodeVfsWrite(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	delete(data, "common_pid")
+	i, err = s.decodeVfsWrite(sample, data)
+	require.NotNil(t, i)
+	require.NoError(t, err)
+	e, ok := i.(FileWriteTelemetryEvent)
+	require.True(t, ok)
+
+	ok = testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, "/path/to/foo.bar", e.Filename)
+	assert.Equal(t, "/path/to/foo.bar", data["filename"])
+	assert.Equal(t, data["count"], e.Count)
+}
+
+func prepareForRegisterFileWriteEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:__data_loc char[] name0;	offset:8;	size:4;	signed:1;
+	field:__data_loc char[] name1;	offset:12;	size:4;	signed:1;
+	field:__data_loc char[] name2;	offset:16;	size:4;	signed:1;
+	field:__data_loc char[] name3;	offset:20;	size:4;	signed:1;
+	field:__data_loc char[] name4;	offset:24;	size:4;	signed:1;
+	field:__data_loc char[] name5;	offset:28;	size:4;	signed:1;
+	field:__data_loc char[] name6;	offset:32;	size:4;	signed:1;
+	field:__data_loc char[] name7;	offset:36;	size:4;	signed:1;
+	field:__data_loc char[] name8;	offset:40;	size:4;	signed:1;
+	field:__data_loc char[] name9;	offset:44;	size:4;	signed:1;
+	field:__data_loc char[] name10;	offset:48;	size:4;	signed:1;
+	field:__data_loc char[] name11;	offset:52;	size:4;	signed:1;
+	field:u64 count;	offset:56;	size:8;	signed:0;
+
+print fmt: "name0=\"%s\" name1=\"%s\" name2=\"%s\" name3=\"%s\" name4=\"%s\" name5=\"%s\" name6=\"%s\" name7=\"%s\" name8=\"%s\" name9=\"%s\" name10=\"%s\" name11=\"%s\" count=%Lu", __get_str(name0), __get_str(name1), __get_str(name2), __get_str(name3), __get_str(name4), __get_str(name5), __get_str(name6), __get_str(name7), __get_str(name8), __get_str(name9), __get_str(name10), __get_str(name11), REC->count`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func TestRegisterFileWriteEventFilter(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	e := expression.Equal(expression.Identifier("foo"), expression.Value("bar"))
+	expr, err := expression.NewExpression(e)
+	require.NotNil(t, expr)
+	require.NoError(t, err)
+
+	s := newTestSubscription(t, sensor)
+	prepareForRegisterFileWriteEventFilter(t, s, 0)
+	s.RegisterFileWriteEventFilter(expr)
+	verifyRegisterFileOpenEventFilter(t, s, -1)
+
+	e = expression.Like(expression.Identifier("filename"),
+		expression.Value("/etc/*"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s = newTestSubscription(t, sensor)
+	prepareForRegisterFileWriteEventFilter(t, s, 0)
+	s.RegisterFileWriteEventFilter(expr)
+	verifyRegisterFileOpenEventFilter(t, s, 1)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	prepareForRegisterFileWriteEventFilter(t, s, 0)
+	s.RegisterFileWriteEventFilter(nil)
+	verifyRegisterFileOpenEventFilter(t, s, 1)
+}
+
+func TestDecodeSysMemfdCreate(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"filename":   "payload",
+		"flags":      uint32(1),
+	}
+
+	i, err := s.decodeSysMemfdCreate(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	data["common_pid"] = int32(111343)
+	i, err = s.decodeSysMemfdCreate(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, FileMemfdCreateTelemetryEvent{}, i)
+
+	e := i.(FileMemfdCreateTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+		e.Container.ID)
+	assert.Equal(t, "payload", e.Filename)
+	assert.Equal(t, uint32(1), e.Flags)
+}
+
+func prepareForRegisterFileMemfdCreateEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:__data_loc char[] filename;	offset:16;	size:4;	signed:1;
+	field:u32 flags;	offset:20;	size:4;	signed:0;
+
+print fmt: "(%lx) filename=\"%s\" flags=%u", REC->__probe_ip, __get_str(filename), REC->flags`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func TestRegisterFileMemfdCreateEventFilter(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	e := expression.Equal(expression.Identifier("filename"),
+		expression.Value("payload"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s := newTestSubscription(t, sensor)
+	prepareForRegisterFileMemfdCreateEventFilter(t, s, 0)
+	s.RegisterFileMemfdCreateEventFilter(expr)
+	verifyRegisterFileOpenEventFilter(t, s, 1)
+
+	e = expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s = newTestSubscription(t, sensor)
+	prepareForRegisterFileMemfdCreateEventFilter(t, s, 0)
+	s.RegisterFileMemfdCreateEventFilter(expr)
+	verifyRegisterFileOpenEventFilter(t, s, -1)
+}
@@ -0,0 +1,209 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"golang.org/x/net/context"
+
+	"github.com/golang/glog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Names of the event sources whose initialization is tracked for readiness
+const (
+	eventSourcePerf     = "perf"
+	eventSourceDocker   = "docker"
+	eventSourceOciHooks = "oci_hooks"
+)
+
+// The name of the telemetry service for gRPC health checks
+const telemetryServiceName = "capsule8.api.v0.TelemetryService"
+
+// How often the gRPC health checking service's status is updated
+const healthCheckInterval = 5 * time.Second
+
+// setEventSourceStatus records whether an event source initialized.
+func (s *Sensor) setEventSourceStatus(name string, err error) {
+	s.eventSourcesLock.Lock()
+	if s.eventSources == nil {
+		s.eventSources = make(map[string]error)
+	}
+	s.eventSources[name] = err
+	s.eventSourcesLock.Unlock()
+}
+
+// live returns an error if the sensor is not dispatching events.
+func (s *Sensor) live() error {
+	s.dispatchMutex.Lock()
+	running := s.dispatchRunning
+	s.dispatchMutex.Unlock()
+	if !running {
+		return errors.New("Sensor is not running")
+	}
+	return nil
+}
+
+// ready returns an error describing each of the configured
+// RequiredEventSources that did not initialize, or nil if they all did.
+func (s *Sensor) ready() error {
+	if err := s.live(); err != nil {
+		return err
+	}
+
+	var problems []string
+	s.eventSourcesLock.Lock()
+	for _, name := range config.Sensor.RequiredEventSources {
+		err, ok := s.eventSources[name]
+		if !ok {
+			err = errors.New("not started")
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	s.eventSourcesLock.Unlock()
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("Event sources are not ready (%s)",
+			strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// healthStatus keeps the status of the standard gRPC health checking service
+// up to date with the sensor's readiness. The sensor's telemetry service, and
+// the server as a whole, are serving when the sensor is ready.
+type healthStatus struct {
+	server *health.Server
+	sensor *Sensor
+}
+
+// registerHealthServer registers the gRPC health checking service with a
+// server and updates its status until ctx is done.
+func registerHealthServer(ctx context.Context, server *grpc.Server, sensor *Sensor) {
+	h := &healthStatus{
+		server: health.NewServer(),
+		sensor: sensor,
+	}
+	healthpb.RegisterHealthServer(server, h.server)
+	h.update()
+	go h.watch(ctx, healthCheckInterval)
+}
+
+func (h *healthStatus) update() {
+	st := healthpb.HealthCheckResponse_SERVING
+	if err := h.sensor.ready(); err != nil {
+		glog.V(2).Infof("Health check: %v", err)
+		st = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	h.server.SetServingStatus("", st)
+	h.server.SetServingStatus(telemetryServiceName, st)
+}
+
+func (h *healthStatus) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.update()
+		}
+	}
+}
+
+// HealthService is a service that serves liveness and readiness probes over
+// HTTP. /healthz succeeds as long as the sensor is dispatching events, and
+// /readyz succeeds once all of the sensor's required event sources have
+// initialized.
+type HealthService struct {
+	server *http.Server
+
+	sensor *Sensor
+
+	address string
+}
+
+// NewHealthService creates a new HealthService instance bound to a specified
+// address.
+func NewHealthService(sensor *Sensor, address string) *HealthService {
+	return &HealthService{
+		sensor:  sensor,
+		address: address,
+	}
+}
+
+// Name returns a human-readable name for a HealthService.
+func (hs *HealthService) Name() string {
+	return "Health HTTP endpoint"
+}
+
+// Serve runs a HealthService. It serves /healthz and /readyz until the
+// service is stopped. It runs on the calling Goroutine.
+func (hs *HealthService) Serve() error {
+	glog.V(1).Infof("Serving health HTTP endpoints on %s", hs.address)
+
+	hs.server = &http.Server{
+		Addr:    hs.address,
+		Handler: hs.handler(),
+	}
+
+	err := hs.server.ListenAndServe()
+	if err != nil {
+		glog.Errorf("Health HTTP error: %s", err)
+	}
+
+	return err
+}
+
+// Stop stops a running HealthService.
+func (hs *HealthService) Stop() {
+	hs.server.Shutdown(context.Background())
+}
+
+func (hs *HealthService) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeProbe(w, hs.sensor.live())
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		writeProbe(w, hs.sensor.ready())
+	})
+	return mux
+}
+
+func writeProbe(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"golang.org/x/net/context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestSensorReady(t *testing.T) {
+	required := config.Sensor.RequiredEventSources
+	defer func() {
+		config.Sensor.RequiredEventSources = required
+	}()
+
+	sensor := newUnitTestSensor(t)
+
+	config.Sensor.RequiredEventSources = []string{eventSourcePerf}
+	assert.NoError(t, sensor.live())
+	assert.NoError(t, sensor.ready())
+
+	sensor.setEventSourceStatus(eventSourceDocker, errors.New("failed"))
+	config.Sensor.RequiredEventSources = []string{eventSourcePerf,
+		eventSourceDocker, eventSourceOciHooks}
+	err := sensor.ready()
+	if assert.Error(t, err) {
+		assert.Equal(t, "Event sources are not ready (docker: failed; oci_hooks: not started)",
+			err.Error())
+	}
+
+	sensor.setEventSourceStatus(eventSourceDocker, nil)
+	sensor.setEventSourceStatus(eventSourceOciHooks, nil)
+	assert.NoError(t, sensor.ready())
+
+	sensor.Stop()
+	assert.Error(t, sensor.live())
+	assert.Error(t, sensor.ready())
+}
+
+func TestHealthServerCheck(t *testing.T) {
+	required := config.Sensor.RequiredEventSources
+	defer func() {
+		config.Sensor.RequiredEventSources = required
+	}()
+
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	h := &healthStatus{
+		server: health.NewServer(),
+		sensor: sensor,
+	}
+	ctx := context.Background()
+
+	config.Sensor.RequiredEventSources = []string{eventSourcePerf}
+	h.update()
+	for _, service := range []string{"", telemetryServiceName} {
+		r, err := h.server.Check(ctx, &healthpb.HealthCheckRequest{
+			Service: service,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, r.Status)
+	}
+
+	config.Sensor.RequiredEventSources = []string{eventSourceOciHooks}
+	h.update()
+	r, err := h.server.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, r.Status)
+}
+
+func TestHealthServiceProbes(t *testing.T) {
+	required := config.Sensor.RequiredEventSources
+	defer func() {
+		config.Sensor.RequiredEventSources = required
+	}()
+
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	handler := NewHealthService(sensor, "").handler()
+	probe := func(path string) (int, string) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w.Code, w.Body.String()
+	}
+
+	config.Sensor.RequiredEventSources = []string{eventSourcePerf}
+	code, body := probe("/healthz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok\n", body)
+	code, _ = probe("/readyz")
+	assert.Equal(t, http.StatusOK, code)
+
+	config.Sensor.RequiredEventSources = []string{eventSourceOciHooks}
+	code, _ = probe("/healthz")
+	assert.Equal(t, http.StatusOK, code)
+	code, body = probe("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, body, "oci_hooks: not started")
+}
@@ -0,0 +1,354 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
+)
+
+// ImageEventTypes defines the field types that can be used with filters on
+// image telemetry events.
+var ImageEventTypes = expression.FieldTypeMap{
+	"image_id":   expression.ValueTypeString,
+	"image_name": expression.ValueTypeString,
+	"registry":   expression.ValueTypeString,
+	"digest":     expression.ValueTypeString,
+}
+
+// ImagePulledTelemetryEvent is a telemetry event generated by the image event
+// source when an image is pulled.
+type ImagePulledTelemetryEvent struct {
+	TelemetryEventData
+
+	Image ImageInfo
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for an image pulled telemetry event.
+func (e ImagePulledTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// ImageTaggedTelemetryEvent is a telemetry event generated by the image event
+// source when an image is tagged.
+type ImageTaggedTelemetryEvent struct {
+	TelemetryEventData
+
+	Image ImageInfo
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for an image tagged telemetry event.
+func (e ImageTaggedTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// ImageUntaggedTelemetryEvent is a telemetry event generated by the image
+// event source when a tag is removed from an image.
+type ImageUntaggedTelemetryEvent struct {
+	TelemetryEventData
+
+	Image ImageInfo
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for an image untagged telemetry event.
+func (e ImageUntaggedTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// ImageDeletedTelemetryEvent is a telemetry event generated by the image event
+// source when an image is removed from the host.
+type ImageDeletedTelemetryEvent struct {
+	TelemetryEventData
+
+	Image ImageInfo
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for an image deleted telemetry event.
+func (e ImageDeletedTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// ImageInfo records interesting information known about a container image.
+type ImageInfo struct {
+	ID string
+
+	// Name is the image reference most recently associated with the
+	// image (i.e. the name that was pulled or tagged), and Registry and
+	// Digest are derived from it.
+	Name     string
+	Registry string
+	Digest   string
+
+	RepoTags    []string
+	RepoDigests []string
+	Labels      map[string]string
+}
+
+// ImageCache is a cache of container image information
+type ImageCache struct {
+	sync.Mutex
+	cache map[string]*ImageInfo
+
+	sensor *Sensor
+
+	// These are external event IDs registered with the sensor's event
+	// monitor instance. The cache will enqueue these events as appropriate
+	// as the cache is updated.
+	ImagePulledEventID   uint64
+	ImageTaggedEventID   uint64
+	ImageUntaggedEventID uint64
+	ImageDeletedEventID  uint64
+}
+
+// NewImageCache creates a new image cache.
+func NewImageCache(sensor *Sensor) *ImageCache {
+	cache := &ImageCache{
+		cache:  make(map[string]*ImageInfo),
+		sensor: sensor,
+	}
+
+	monitor := sensor.Monitor()
+	cache.ImagePulledEventID = monitor.RegisterExternalEvent(
+		"IMAGE_PULLED", cache.decodeImagePulledEvent)
+
+	cache.ImageTaggedEventID = monitor.RegisterExternalEvent(
+		"IMAGE_TAGGED", cache.decodeImageTaggedEvent)
+
+	cache.ImageUntaggedEventID = monitor.RegisterExternalEvent(
+		"IMAGE_UNTAGGED", cache.decodeImageUntaggedEvent)
+
+	cache.ImageDeletedEventID = monitor.RegisterExternalEvent(
+		"IMAGE_DELETED", cache.decodeImageDeletedEvent)
+
+	return cache
+}
+
+// LookupImage searches the cache for an image by ID and returns a copy of any
+// information found.
+func (ic *ImageCache) LookupImage(imageID string) (ImageInfo, bool) {
+	ic.Lock()
+	defer ic.Unlock()
+
+	if info, ok := ic.cache[imageID]; ok {
+		return *info, true
+	}
+	return ImageInfo{}, false
+}
+
+// UpdateImage replaces the information cached for an image. If eventID is
+// non-zero, the corresponding telemetry event is generated for the image, as
+// referenced by name.
+func (ic *ImageCache) UpdateImage(
+	eventID uint64,
+	sampleID perf.SampleID,
+	name string,
+	info ImageInfo,
+) {
+	ic.Lock()
+	if len(name) == 0 {
+		// Keep referring to the image by the name it was last known
+		// by, if any.
+		if old, ok := ic.cache[info.ID]; ok {
+			name = old.Name
+		}
+	}
+	if len(name) > 0 {
+		info.Name = name
+		info.Registry = imageRegistry(name)
+		info.Digest = imageDigest(name, info.RepoDigests)
+	}
+	ic.cache[info.ID] = &info
+	ic.Unlock()
+
+	if eventID != 0 {
+		ic.enqueueImageEvent(eventID, sampleID, &info)
+	}
+}
+
+// DeleteImage removes an image from the cache.
+func (ic *ImageCache) DeleteImage(imageID string, sampleID perf.SampleID) {
+	ic.Lock()
+	info, ok := ic.cache[imageID]
+	if ok {
+		delete(ic.cache, imageID)
+	} else {
+		info = &ImageInfo{ID: imageID}
+	}
+	ic.Unlock()
+
+	glog.V(2).Infof("Sending IMAGE_DELETED for %s", imageID)
+	ic.enqueueImageEvent(ic.ImageDeletedEventID, sampleID, info)
+}
+
+func (ic *ImageCache) enqueueImageEvent(
+	eventID uint64,
+	sampleID perf.SampleID,
+	info *ImageInfo,
+) error {
+	// Include the fields in ImageEventTypes so that filter expressions
+	// can be evaluated against the sample.
+	data := map[string]interface{}{
+		"__image__":  *info,
+		"image_id":   info.ID,
+		"image_name": info.Name,
+		"registry":   info.Registry,
+		"digest":     info.Digest,
+	}
+	return ic.sensor.Monitor().EnqueueExternalSample(eventID, sampleID, data)
+}
+
+func (ic *ImageCache) decodeImagePulledEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ImagePulledTelemetryEvent
+	if !e.InitWithSample(ic.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Image = data["__image__"].(ImageInfo)
+	return e, nil
+}
+
+func (ic *ImageCache) decodeImageTaggedEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ImageTaggedTelemetryEvent
+	if !e.InitWithSample(ic.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Image = data["__image__"].(ImageInfo)
+	return e, nil
+}
+
+func (ic *ImageCache) decodeImageUntaggedEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ImageUntaggedTelemetryEvent
+	if !e.InitWithSample(ic.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Image = data["__image__"].(ImageInfo)
+	return e, nil
+}
+
+func (ic *ImageCache) decodeImageDeletedEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ImageDeletedTelemetryEvent
+	if !e.InitWithSample(ic.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Image = data["__image__"].(ImageInfo)
+	return e, nil
+}
+
+// imageRepository returns the repository portion of an image reference, which
+// is everything before the tag or digest.
+func imageRepository(name string) string {
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	// A ':' after the last '/' separates the tag; any before it is part
+	// of the registry host:port.
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name
+}
+
+// imageRegistry returns the registry portion of an image reference. As with
+// Docker, the first path component is only treated as a registry if it looks
+// like a hostname; otherwise the image comes from Docker Hub.
+func imageRegistry(name string) string {
+	i := strings.Index(name, "/")
+	if i < 0 {
+		return "docker.io"
+	}
+	host := name[:i]
+	if host != "localhost" && !strings.ContainsAny(host, ".:") {
+		return "docker.io"
+	}
+	return host
+}
+
+// imageDigest returns the digest from repoDigests that belongs to the same
+// repository as the image reference name.
+func imageDigest(name string, repoDigests []string) string {
+	if i := strings.Index(name, "@"); i >= 0 {
+		return name[i+1:]
+	}
+	repo := imageRepository(name)
+	for _, rd := range repoDigests {
+		if i := strings.Index(rd, "@"); i >= 0 && rd[:i] == repo {
+			return rd[i+1:]
+		}
+	}
+	return ""
+}
+
+func (s *Subscription) registerImageEventFilter(
+	eventID uint64,
+	expr *expression.Expression,
+) {
+	if _, err := s.addEventSink(eventID, expr, ImageEventTypes); err != nil {
+		s.logStatus(
+			fmt.Sprintf("Invalid image filter expression: %v", err))
+	}
+}
+
+// RegisterImagePulledEventFilter registers an image pulled event filter with
+// a subscription.
+func (s *Subscription) RegisterImagePulledEventFilter(expr *expression.Expression) {
+	s.registerImageEventFilter(
+		s.sensor.ImageCache.ImagePulledEventID,
+		expr)
+}
+
+// RegisterImageTaggedEventFilter registers an image tagged event filter with
+// a subscription.
+func (s *Subscription) RegisterImageTaggedEventFilter(expr *expression.Expression) {
+	s.registerImageEventFilter(
+		s.sensor.ImageCache.ImageTaggedEventID,
+		expr)
+}
+
+// RegisterImageUntaggedEventFilter registers an image untagged event filter
+// with a subscription.
+func (s *Subscription) RegisterImageUntaggedEventFilter(expr *expression.Expression) {
+	s.registerImageEventFilter(
+		s.sensor.ImageCache.ImageUntaggedEventID,
+		expr)
+}
+
+// RegisterImageDeletedEventFilter registers an image deleted event filter
+// with a subscription.
+func (s *Subscription) RegisterImageDeletedEventFilter(expr *expression.Expression) {
+	s.registerImageEventFilter(
+		s.sensor.ImageCache.ImageDeletedEventID,
+		expr)
+}
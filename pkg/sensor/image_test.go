@@ -0,0 +1,204 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageDecoders(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"__image__": ImageInfo{
+			ID:          "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b",
+			Name:        "bash:latest",
+			Registry:    "docker.io",
+			Digest:      "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+			RepoTags:    []string{"bash:latest"},
+			RepoDigests: []string{"bash@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424"},
+		},
+	}
+
+	type testCase struct {
+		decoder      perf.TraceEventDecoderFn
+		expectedType interface{}
+	}
+	testCases := []testCase{
+		testCase{
+			decoder:      sensor.ImageCache.decodeImagePulledEvent,
+			expectedType: ImagePulledTelemetryEvent{},
+		},
+		testCase{
+			decoder:      sensor.ImageCache.decodeImageTaggedEvent,
+			expectedType: ImageTaggedTelemetryEvent{},
+		},
+		testCase{
+			decoder:      sensor.ImageCache.decodeImageUntaggedEvent,
+			expectedType: ImageUntaggedTelemetryEvent{},
+		},
+		testCase{
+			decoder:      sensor.ImageCache.decodeImageDeletedEvent,
+			expectedType: ImageDeletedTelemetryEvent{},
+		},
+	}
+
+	for _, tc := range testCases {
+		data["common_pid"] = int32(sensorPID)
+		i, err := tc.decoder(sample, data)
+		require.Nil(t, i)
+		require.NoError(t, err)
+
+		delete(data, "common_pid")
+		i, err = tc.decoder(sample, data)
+		require.NotNil(t, i)
+		require.NoError(t, err)
+
+		e, ok := i.(TelemetryEvent)
+		require.True(t, ok)
+		require.IsType(t, tc.expectedType, i)
+
+		ok = testCommonTelemetryEventData(t, sensor, e)
+		require.True(t, ok)
+
+		image := reflect.ValueOf(i).FieldByName("Image").Interface()
+		assert.Equal(t, data["__image__"], image)
+	}
+}
+
+func TestImageCache(t *testing.T) {
+	const id = "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b"
+
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	cache := sensor.ImageCache
+
+	_, ok := cache.LookupImage(id)
+	assert.False(t, ok)
+
+	sampleID := perf.SampleID{Time: uint64(sys.CurrentMonotonicRaw())}
+	info := ImageInfo{
+		ID:          id,
+		RepoTags:    []string{"gcr.io/google_containers/pause:3.1"},
+		RepoDigests: []string{"gcr.io/google_containers/pause@sha256:f78411e19d84a252e53bff71a4407a5686c46983a2c2eeed83929b888179acea"},
+	}
+	cache.UpdateImage(cache.ImagePulledEventID, sampleID,
+		"gcr.io/google_containers/pause:3.1", info)
+
+	info, ok = cache.LookupImage(id)
+	if assert.True(t, ok) {
+		assert.Equal(t, "gcr.io/google_containers/pause:3.1", info.Name)
+		assert.Equal(t, "gcr.io", info.Registry)
+		assert.Equal(t, "sha256:f78411e19d84a252e53bff71a4407a5686c46983a2c2eeed83929b888179acea", info.Digest)
+	}
+
+	// Updating without a name keeps the existing name
+	info.RepoTags = nil
+	cache.UpdateImage(cache.ImageUntaggedEventID, sampleID, "", info)
+	info, ok = cache.LookupImage(id)
+	if assert.True(t, ok) {
+		assert.Equal(t, "gcr.io/google_containers/pause:3.1", info.Name)
+		assert.Len(t, info.RepoTags, 0)
+	}
+
+	cache.DeleteImage(id, sampleID)
+	_, ok = cache.LookupImage(id)
+	assert.False(t, ok)
+}
+
+func TestImageNames(t *testing.T) {
+	type testCase struct {
+		name       string
+		repository string
+		registry   string
+	}
+	testCases := []testCase{
+		testCase{"busybox", "busybox", "docker.io"},
+		testCase{"busybox:latest", "busybox", "docker.io"},
+		testCase{"library/busybox:1.29", "library/busybox", "docker.io"},
+		testCase{"gcr.io/google_containers/pause:3.1", "gcr.io/google_containers/pause", "gcr.io"},
+		testCase{"localhost/foo", "localhost/foo", "localhost"},
+		testCase{"localhost:5000/foo:bar", "localhost:5000/foo", "localhost:5000"},
+		testCase{"quay.io/coreos/etcd@sha256:f78411e19d84a252e53bff71a4407a5686c46983a2c2eeed83929b888179acea",
+			"quay.io/coreos/etcd", "quay.io"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.repository, imageRepository(tc.name), tc.name)
+		assert.Equal(t, tc.registry, imageRegistry(tc.name), tc.name)
+	}
+
+	repoDigests := []string{
+		"busybox@sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		"quay.io/busybox@sha256:2222222222222222222222222222222222222222222222222222222222222222",
+	}
+	assert.Equal(t, "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		imageDigest("busybox:latest", repoDigests))
+	assert.Equal(t, "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+		imageDigest("quay.io/busybox:latest", repoDigests))
+	assert.Equal(t, "sha256:3333333333333333333333333333333333333333333333333333333333333333",
+		imageDigest("busybox@sha256:3333333333333333333333333333333333333333333333333333333333333333", repoDigests))
+	assert.Equal(t, "", imageDigest("alpine", repoDigests))
+}
+
+func verifyImageEventRegistration(t *testing.T, s *Subscription, count int) {
+	if count > 0 {
+		assert.Len(t, s.eventSinks, count)
+	} else {
+		assert.Len(t, s.status, -count)
+		assert.Len(t, s.eventSinks, 0)
+	}
+}
+
+func TestImageEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	e := expression.Equal(expression.Identifier("foo"), expression.Value("bar"))
+	expr, err := expression.NewExpression(e)
+	require.NotNil(t, expr)
+	require.NoError(t, err)
+
+	names := []string{
+		"RegisterImagePulledEventFilter",
+		"RegisterImageTaggedEventFilter",
+		"RegisterImageUntaggedEventFilter",
+		"RegisterImageDeletedEventFilter",
+	}
+	for _, name := range names {
+		s := newTestSubscription(t, sensor)
+		v := reflect.ValueOf(s)
+		m := v.MethodByName(name)
+
+		m.Call([]reflect.Value{reflect.ValueOf(expr)})
+		verifyImageEventRegistration(t, s, -1)
+
+		var nilExpr *expression.Expression
+		m.Call([]reflect.Value{reflect.ValueOf(nilExpr)})
+		verifyImageEventRegistration(t, s, 1)
+	}
+}
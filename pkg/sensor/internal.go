@@ -0,0 +1,120 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/expression"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// readSubscriptionFile reads a Subscription from a JSON file.
+func readSubscriptionFile(path string) (*api.Subscription, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sub := &api.Subscription{}
+	if err = jsonpb.Unmarshal(f, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// runInternalSubscription runs a subscription made by the sensor itself
+// rather than by a client of the telemetry service, i.e. for the spool.
+// Its translated events are sent to the returned channel, which is closed
+// once ctx is done and the events buffered for the subscription have been
+// sent, so the channel must be read until it is closed. Events are buffered so that a slow consumer does not
+// hold up the sensor's delivery of events to other subscriptions; the
+// size of the buffer is set by the subscription's BufferModifier, and its
+// other modifiers are not used. The name is used in errors and logs.
+func runInternalSubscription(
+	ctx context.Context,
+	sensor *Sensor,
+	name string,
+	sub *api.Subscription,
+) (<-chan *api.TelemetryEvent, error) {
+	if sub.EventFilter == nil {
+		return nil, fmt.Errorf("%s subscription has no EventFilter", name)
+	}
+	var expr *expression.Expression
+	if sub.Expression != "" {
+		var err error
+		if expr, err = newEventExpression(sub.Expression); err != nil {
+			return nil, fmt.Errorf("%s subscription expression is invalid: %v",
+				name, err)
+		}
+	}
+	buffer, err := newEventBuffer(sub.GetModifier().GetBuffer())
+	if err != nil {
+		return nil, fmt.Errorf("%s subscription BufferModifier is invalid: %v",
+			name, err)
+	}
+
+	subscr := sensor.NewSubscription()
+	subscr.translateTelemetryServiceSubscription(sub)
+	if len(subscr.eventSinks) == 0 {
+		return nil, fmt.Errorf("%s subscription has no events", name)
+	}
+	statuses, err := subscr.Run(ctx, func(e TelemetryEvent) {
+		if buffer.add(e) > 0 {
+			glog.V(2).Infof("%s buffer is full, dropping event", name)
+		}
+	})
+	for _, st := range statuses {
+		glog.Warningf("%s subscription: %s", name, st)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *api.TelemetryEvent)
+	go func() {
+		defer close(events)
+		send := func(e TelemetryEvent) {
+			event := subscr.translateEvent(e)
+			if expr == nil || matchEventExpression(expr, event) {
+				events <- event
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				// The subscription is closed, so send what
+				// remains in the buffer
+				for {
+					select {
+					case e := <-buffer.events:
+						send(e)
+					default:
+						return
+					}
+				}
+			case e := <-buffer.events:
+				send(e)
+			}
+		}
+	}()
+	return events, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// IOUringSetupEventTypes defines the field types that can be used with
+// filters on io_uring setup telemetry events.
+var IOUringSetupEventTypes = expression.FieldTypeMap{
+	"fd":         expression.ValueTypeSignedInt32,
+	"sq_entries": expression.ValueTypeUnsignedInt32,
+	"cq_entries": expression.ValueTypeUnsignedInt32,
+	"flags":      expression.ValueTypeUnsignedInt32,
+}
+
+// IOUringSubmitEventTypes defines the field types that can be used with
+// filters on io_uring submit telemetry events.
+var IOUringSubmitEventTypes = expression.FieldTypeMap{
+	"opcode":    expression.ValueTypeUnsignedInt8,
+	"user_data": expression.ValueTypeUnsignedInt64,
+	"sq_thread": expression.ValueTypeUnsignedInt8,
+}
+
+// IOUringSetupTelemetryEvent is a telemetry event generated by the io_uring
+// event source when a process creates an io_uring instance.
+type IOUringSetupTelemetryEvent struct {
+	TelemetryEventData
+
+	FD        int32
+	SQEntries uint32
+	CQEntries uint32
+	Flags     uint32
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for an io_uring setup telemetry event.
+func (e IOUringSetupTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// IOUringSubmitTelemetryEvent is a telemetry event generated by the io_uring
+// event source when an operation is submitted to an io_uring instance. These
+// operations are performed by the kernel on behalf of the process without
+// the system calls that would otherwise be made, so they are not reported by
+// the other event sources.
+type IOUringSubmitTelemetryEvent struct {
+	TelemetryEventData
+
+	Opcode   uint8
+	UserData uint64
+	SQThread bool
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for an io_uring submit telemetry event.
+func (e IOUringSubmitTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// The io_uring tracepoints are used rather than kprobes on io_uring_setup()
+// and io_uring_enter(): operations can be submitted without entering the
+// kernel at all when the ring is polled by a kernel thread (SQPOLL), and the
+// tracepoints are reported for each operation either way. The submit
+// tracepoint was renamed in Linux 6.1, but the fields used here did not
+// change. The io_uring tracepoints first appeared in Linux 5.5.
+const (
+	ioUringSetupTracepoint     = "io_uring/io_uring_create"
+	ioUringSubmitTracepoint    = "io_uring/io_uring_submit_req"
+	ioUringSubmitTracepointOld = "io_uring/io_uring_submit_sqe"
+)
+
+func (s *Subscription) decodeIOUringCreate(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e IOUringSetupTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.FD = data["fd"].(int32)
+	e.SQEntries = data["sq_entries"].(uint32)
+	e.CQEntries = data["cq_entries"].(uint32)
+	e.Flags = data["flags"].(uint32)
+	return e, nil
+}
+
+func (s *Subscription) decodeIOUringSubmit(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e IOUringSubmitTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Opcode = data["opcode"].(uint8)
+	e.UserData = data["user_data"].(uint64)
+	e.SQThread = data["sq_thread"].(uint8) != 0
+	return e, nil
+}
+
+// RegisterIOUringSetupEventFilter registers an io_uring setup event filter
+// with a subscription.
+func (s *Subscription) RegisterIOUringSetupEventFilter(expr *expression.Expression) {
+	s.registerTracepoint(ioUringSetupTracepoint, s.decodeIOUringCreate,
+		expr, IOUringSetupEventTypes)
+}
+
+// RegisterIOUringSubmitEventFilter registers an io_uring submit event filter
+// with a subscription.
+func (s *Subscription) RegisterIOUringSubmitEventFilter(expr *expression.Expression) {
+	name := ioUringSubmitTracepoint
+	if !s.sensor.Monitor().DoesTracepointExist(name) {
+		name = ioUringSubmitTracepointOld
+	}
+	s.registerTracepoint(name, s.decodeIOUringSubmit,
+		expr, IOUringSubmitEventTypes)
+}
@@ -0,0 +1,149 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeIOUringCreate(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"fd":         int32(5),
+		"sq_entries": uint32(256),
+		"cq_entries": uint32(512),
+		"flags":      uint32(2),
+	}
+
+	i, err := s.decodeIOUringCreate(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	data["common_pid"] = int32(111343)
+	i, err = s.decodeIOUringCreate(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, IOUringSetupTelemetryEvent{}, i)
+
+	e := i.(IOUringSetupTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+		e.Container.ID)
+	assert.Equal(t, int32(5), e.FD)
+	assert.Equal(t, uint32(256), e.SQEntries)
+	assert.Equal(t, uint32(512), e.CQEntries)
+	assert.Equal(t, uint32(2), e.Flags)
+}
+
+func TestDecodeIOUringSubmit(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(111343),
+		"opcode":     uint8(16),
+		"user_data":  uint64(0xdeadbeef),
+		"sq_thread":  uint8(1),
+	}
+
+	i, err := s.decodeIOUringSubmit(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, IOUringSubmitTelemetryEvent{}, i)
+
+	e := i.(IOUringSubmitTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, uint8(16), e.Opcode)
+	assert.Equal(t, uint64(0xdeadbeef), e.UserData)
+	assert.True(t, e.SQThread)
+}
+
+func TestIOUringEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	s.RegisterIOUringSetupEventFilter(nil)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+
+	e := expression.Equal(expression.Identifier("opcode"),
+		expression.Value(uint8(16)))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	// Only io_uring_submit_sqe is in the test tracing directory
+	s = newTestSubscription(t, sensor)
+	s.RegisterIOUringSubmitEventFilter(expr)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+
+	// io_uring_submit_req is preferred when it exists
+	format := `name: io_uring_submit_req
+ID: 1501
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;	signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:void * ctx;	offset:8;	size:8;	signed:0;
+	field:void * req;	offset:16;	size:8;	signed:0;
+	field:unsigned long long user_data;	offset:24;	size:8;	signed:0;
+	field:u8 opcode;	offset:32;	size:1;	signed:0;
+	field:u32 flags;	offset:36;	size:4;	signed:0;
+	field:bool sq_thread;	offset:40;	size:1;	signed:0;
+	field:__data_loc char[] op_str;	offset:44;	size:4;	signed:0;
+
+print fmt: "ring %p, req %p, user_data 0x%llx, opcode %s, flags 0x%x, sq_thread %d", REC->ctx, REC->req, REC->user_data, __get_str(op_str), REC->flags, REC->sq_thread`
+	writeFile(t, filepath.Join(sensor.tracingDir, "events", "io_uring",
+		"io_uring_submit_req", "format"), []byte(format))
+
+	s = newTestSubscription(t, sensor)
+	s.RegisterIOUringSubmitEventFilter(expr)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterIOUringSubmitEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// KernelModuleEventTypes defines the field types that can be used with
+// filters on kernel module telemetry events.
+var KernelModuleEventTypes = expression.FieldTypeMap{
+	"name": expression.ValueTypeString,
+}
+
+// KernelModuleLoadTelemetryEvent is a telemetry event generated by the kernel
+// module event source when a module is loaded.
+type KernelModuleLoadTelemetryEvent struct {
+	TelemetryEventData
+
+	Name string
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a kernel module load telemetry event.
+func (e KernelModuleLoadTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// KernelModuleUnloadTelemetryEvent is a telemetry event generated by the
+// kernel module event source when a module is unloaded.
+type KernelModuleUnloadTelemetryEvent struct {
+	TelemetryEventData
+
+	Name string
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a kernel module unload telemetry event.
+func (e KernelModuleUnloadTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// These tracepoints fire in the context of the task that called
+// init_module(2) or finit_module(2) (module_load), or delete_module(2)
+// (module_free). module_free also fires when a module that has just been
+// loaded fails to initialize.
+const (
+	kernelModuleLoadTracepoint   = "module/module_load"
+	kernelModuleUnloadTracepoint = "module/module_free"
+)
+
+func (s *Subscription) decodeModuleLoad(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e KernelModuleLoadTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Name = data["name"].(string)
+	return e, nil
+}
+
+func (s *Subscription) decodeModuleFree(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e KernelModuleUnloadTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Name = data["name"].(string)
+	return e, nil
+}
+
+// RegisterKernelModuleLoadEventFilter registers a kernel module load event
+// filter with a subscription.
+func (s *Subscription) RegisterKernelModuleLoadEventFilter(expr *expression.Expression) {
+	s.registerTracepoint(kernelModuleLoadTracepoint, s.decodeModuleLoad,
+		expr, KernelModuleEventTypes, s.stackTraceOptions()...)
+}
+
+// RegisterKernelModuleUnloadEventFilter registers a kernel module unload
+// event filter with a subscription.
+func (s *Subscription) RegisterKernelModuleUnloadEventFilter(expr *expression.Expression) {
+	s.registerTracepoint(kernelModuleUnloadTracepoint, s.decodeModuleFree,
+		expr, KernelModuleEventTypes)
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKernelModuleDecoders(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"name":       "nf_conntrack",
+		"taints":     uint32(0),
+	}
+
+	decoders := []perf.TraceEventDecoderFn{
+		s.decodeModuleLoad,
+		s.decodeModuleFree,
+	}
+	for _, decoder := range decoders {
+		data["common_pid"] = int32(sensorPID)
+		i, err := decoder(sample, data)
+		require.Nil(t, i)
+		require.NoError(t, err)
+
+		delete(data, "common_pid")
+		i, err = decoder(sample, data)
+		require.NotNil(t, i)
+		require.NoError(t, err)
+
+		e, ok := i.(TelemetryEvent)
+		require.True(t, ok)
+		ok = testCommonTelemetryEventData(t, sensor, e)
+		require.True(t, ok)
+
+		switch e := i.(type) {
+		case KernelModuleLoadTelemetryEvent:
+			assert.Equal(t, "nf_conntrack", e.Name)
+		case KernelModuleUnloadTelemetryEvent:
+			assert.Equal(t, "nf_conntrack", e.Name)
+		default:
+			t.Fatalf("Unexpected event type %T", i)
+		}
+	}
+}
+
+func verifyKernelModuleEventRegistration(t *testing.T, s *Subscription, count int) {
+	if count > 0 {
+		assert.Len(t, s.eventSinks, count)
+	} else {
+		assert.Len(t, s.status, -count)
+		assert.Len(t, s.eventSinks, 0)
+	}
+}
+
+func TestKernelModuleEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	e := expression.Equal(expression.Identifier("foo"), expression.Value("bar"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	registerFuncs := []func(*Subscription, *expression.Expression){
+		(*Subscription).RegisterKernelModuleLoadEventFilter,
+		(*Subscription).RegisterKernelModuleUnloadEventFilter,
+	}
+	for _, f := range registerFuncs {
+		s := newTestSubscription(t, sensor)
+		f(s, expr)
+		verifyKernelModuleEventRegistration(t, s, -1)
+
+		s = newTestSubscription(t, sensor)
+		f(s, nil)
+		verifyKernelModuleEventRegistration(t, s, 1)
+	}
+}
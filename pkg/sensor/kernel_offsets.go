@@ -0,0 +1,190 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+// A fetchargRelocation names the kernel structure member that a kprobe fetch
+// arg reads. When the member's offset is known, either from the running
+// kernel's BTF or from the Sensor's configuration, the offset in the fetch arg
+// is replaced with it plus the addend. Otherwise the offset built into the
+// fetch arg is used unchanged.
+type fetchargRelocation struct {
+	structName string
+	member     string
+	addend     uint32
+}
+
+var (
+	relocSkcDaddr       = fetchargRelocation{"sock_common", "skc_daddr", 0}
+	relocSkcRcvSaddr    = fetchargRelocation{"sock_common", "skc_rcv_saddr", 0}
+	relocSkcDport       = fetchargRelocation{"sock_common", "skc_dport", 0}
+	relocSkcNum         = fetchargRelocation{"sock_common", "skc_num", 0}
+	relocSkcFamily      = fetchargRelocation{"sock_common", "skc_family", 0}
+	relocSkcV6DaddrHigh = fetchargRelocation{"sock_common", "skc_v6_daddr", 0}
+	relocSkcV6DaddrLow  = fetchargRelocation{"sock_common", "skc_v6_daddr", 8}
+	relocSkcV6SaddrHigh = fetchargRelocation{"sock_common", "skc_v6_rcv_saddr", 0}
+	relocSkcV6SaddrLow  = fetchargRelocation{"sock_common", "skc_v6_rcv_saddr", 8}
+)
+
+// kprobeFetchargRelocations maps kprobe symbols to the fetch args of theirs
+// that read structure members at fixed offsets. Only fetch args of the form
+// +offset(base) are relocated.
+var kprobeFetchargRelocations = map[string]map[string]fetchargRelocation{
+	memoryMprotectKprobeSymbol: {
+		"old_flags": {"vm_area_struct", "vm_flags", 0},
+	},
+
+	networkKprobeTCPv4ConnectSymbol: {
+		"local_port": relocSkcNum,
+		"local_addr": relocSkcRcvSaddr,
+	},
+	networkKprobeTCPv6ConnectSymbol: {
+		"local_port":       relocSkcNum,
+		"local_addr6_high": relocSkcV6SaddrHigh,
+		"local_addr6_low":  relocSkcV6SaddrLow,
+	},
+	networkKprobeTCPAcceptSymbol: {
+		"family":            relocSkcFamily,
+		"remote_port":       relocSkcDport,
+		"remote_addr":       relocSkcDaddr,
+		"remote_addr6_high": relocSkcV6DaddrHigh,
+		"remote_addr6_low":  relocSkcV6DaddrLow,
+		"local_port":        relocSkcNum,
+		"local_addr":        relocSkcRcvSaddr,
+		"local_addr6_high":  relocSkcV6SaddrHigh,
+		"local_addr6_low":   relocSkcV6SaddrLow,
+	},
+	networkKprobeTCPListenSymbol: {
+		"family":           relocSkcFamily,
+		"local_port":       relocSkcNum,
+		"local_addr":       relocSkcRcvSaddr,
+		"local_addr6_high": relocSkcV6SaddrHigh,
+		"local_addr6_low":  relocSkcV6SaddrLow,
+	},
+	networkKprobeUDPBindSymbol: {
+		"family":           relocSkcFamily,
+		"local_addr":       relocSkcRcvSaddr,
+		"local_addr6_high": relocSkcV6SaddrHigh,
+		"local_addr6_low":  relocSkcV6SaddrLow,
+	},
+
+	networkKprobeUDPSendmsgSymbol: {
+		"sk_family": relocSkcFamily,
+		"sk_dport":  relocSkcDport,
+		"sk_daddr":  relocSkcDaddr,
+	},
+	networkKprobeUDPv6SendmsgSymbol: {
+		"sk_family":      relocSkcFamily,
+		"sk_dport":       relocSkcDport,
+		"sk_daddr6_high": relocSkcV6DaddrHigh,
+		"sk_daddr6_low":  relocSkcV6DaddrLow,
+	},
+	networkKprobeTCPSendmsgSymbol: {
+		"sk_family":      relocSkcFamily,
+		"sk_dport":       relocSkcDport,
+		"sk_daddr":       relocSkcDaddr,
+		"sk_daddr6_high": relocSkcV6DaddrHigh,
+		"sk_daddr6_low":  relocSkcV6DaddrLow,
+	},
+}
+
+type memberOffsetFn func(structName, member string) (uint32, error)
+
+func relocateFetchargs(
+	fetchargs string,
+	relocations map[string]fetchargRelocation,
+	memberOffset memberOffsetFn,
+) string {
+	args := strings.Split(fetchargs, " ")
+	for i, arg := range args {
+		eq := strings.IndexByte(arg, '=')
+		if eq < 0 {
+			continue
+		}
+		r, ok := relocations[arg[:eq]]
+		if !ok {
+			continue
+		}
+
+		// Nested dereferences would need a relocation for each level
+		expr := arg[eq+1:]
+		paren := strings.IndexByte(expr, '(')
+		if !strings.HasPrefix(expr, "+") || paren < 0 ||
+			strings.IndexByte(expr[paren+1:], '(') >= 0 {
+			continue
+		}
+
+		offset, err := memberOffset(r.structName, r.member)
+		if err != nil {
+			glog.V(1).Infof("Using built-in offset for %s: %v",
+				arg[:eq], err)
+			continue
+		}
+		args[i] = fmt.Sprintf("%s+%d%s", arg[:eq+1], offset+r.addend,
+			expr[paren:])
+	}
+	return strings.Join(args, " ")
+}
+
+// kernelMemberOffset returns the offset of a kernel structure member from
+// the running kernel's BTF, if it is available. The offsets of the IPv6
+// members of struct sock_common, which vary between kernel versions and
+// configurations, may otherwise be configured.
+func (s *Sensor) kernelMemberOffset(structName, member string) (uint32, error) {
+	if s.kernelBTF != nil {
+		offset, err := s.kernelBTF.MemberOffset(structName, member)
+		if err == nil {
+			return offset, nil
+		}
+		glog.V(2).Infof("Could not find %s.%s in kernel BTF: %v",
+			structName, member, err)
+	}
+
+	var offset uint32
+	if structName == "sock_common" {
+		switch member {
+		case "skc_v6_daddr":
+			offset = s.sockCommonV6Offsets[0]
+		case "skc_v6_rcv_saddr":
+			offset = s.sockCommonV6Offsets[1]
+		}
+	}
+	if offset == 0 {
+		return 0, fmt.Errorf("No offset for %s.%s", structName, member)
+	}
+	return offset, nil
+}
+
+// relocateKprobeFetchargs rewrites the structure member offsets in a kprobe's
+// fetch args with those of the running kernel (see kernelMemberOffset).
+func (s *Sensor) relocateKprobeFetchargs(symbol, fetchargs string) string {
+	relocations, ok := kprobeFetchargRelocations[symbol]
+	if !ok {
+		return fetchargs
+	}
+	relocated := relocateFetchargs(fetchargs, relocations,
+		s.kernelMemberOffset)
+	if relocated != fetchargs {
+		glog.V(2).Infof("Relocated kprobe fetch args for %s to %q",
+			symbol, relocated)
+	}
+	return relocated
+}
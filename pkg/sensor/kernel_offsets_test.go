@@ -0,0 +1,112 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelocateFetchargs(t *testing.T) {
+	offsets := map[string]uint32{
+		"sock_common.skc_family":       20,
+		"sock_common.skc_v6_rcv_saddr": 96,
+	}
+	memberOffset := func(structName, member string) (uint32, error) {
+		if offset, ok := offsets[structName+"."+member]; ok {
+			return offset, nil
+		}
+		return 0, errors.New("not found")
+	}
+
+	relocated := relocateFetchargs(networkKprobeTCPListenFetchargs,
+		kprobeFetchargRelocations[networkKprobeTCPListenSymbol],
+		memberOffset)
+	assert.Equal(t, "family=+20(%di):u16 local_port=+14(%di):u16 "+
+		"local_addr=+4(%di):u32 "+
+		"local_addr6_high=+96(%di):u64 local_addr6_low=+104(%di):u64",
+		relocated)
+
+	// Nested dereferences and plain registers are left alone
+	relocations := map[string]fetchargRelocation{
+		"a": relocSkcFamily,
+		"b": relocSkcFamily,
+	}
+	relocated = relocateFetchargs("a=+0(+40(%si)):u16 b=%si:u16",
+		relocations, memberOffset)
+	assert.Equal(t, "a=+0(+40(%si)):u16 b=%si:u16", relocated)
+
+	s := Sensor{}
+	assert.Equal(t, networkKprobeTCPListenFetchargs,
+		s.relocateKprobeFetchargs(networkKprobeTCPListenSymbol,
+			networkKprobeTCPListenFetchargs))
+}
+
+func TestKprobeFetchargRelocations(t *testing.T) {
+	fetchargs := map[string]string{
+		memoryMprotectKprobeSymbol:      memoryMprotectKprobeFetchargs,
+		networkKprobeTCPv4ConnectSymbol: networkKprobeTCPv4ConnectFetchargs,
+		networkKprobeTCPv6ConnectSymbol: networkKprobeTCPv6ConnectFetchargs,
+		networkKprobeTCPAcceptSymbol:    networkKprobeTCPAcceptFetchargs,
+		networkKprobeTCPListenSymbol:    networkKprobeTCPListenFetchargs,
+		networkKprobeUDPBindSymbol:      networkKprobeUDPBindFetchargs,
+		networkKprobeUDPSendmsgSymbol:   networkKprobeUDPSendmsgFetchargs,
+		networkKprobeUDPv6SendmsgSymbol: networkKprobeUDPv6SendmsgFetchargs,
+		networkKprobeTCPSendmsgSymbol:   networkKprobeTCPSendmsgFetchargs,
+	}
+
+	// Every relocation must name a fetch arg that it can rewrite
+	for symbol, relocations := range kprobeFetchargRelocations {
+		args, ok := fetchargs[symbol]
+		if !assert.True(t, ok, symbol) {
+			continue
+		}
+		var found []string
+		relocated := relocateFetchargs(args, relocations,
+			func(structName, member string) (uint32, error) {
+				found = append(found, member)
+				return 1000, nil
+			})
+		assert.Len(t, found, len(relocations), symbol)
+		assert.Equal(t, len(relocations),
+			strings.Count(relocated, "=+100"), symbol)
+	}
+}
+
+func TestKernelMemberOffset(t *testing.T) {
+	// Without BTF only configured offsets are known
+	s := Sensor{}
+	_, err := s.kernelMemberOffset("sock_common", "skc_v6_daddr")
+	assert.Error(t, err)
+
+	s.sockCommonV6Offsets = [2]uint32{64, 80}
+	offset, err := s.kernelMemberOffset("sock_common", "skc_v6_daddr")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(64), offset)
+	offset, err = s.kernelMemberOffset("sock_common", "skc_v6_rcv_saddr")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(80), offset)
+	_, err = s.kernelMemberOffset("sock_common", "skc_family")
+	assert.Error(t, err)
+
+	assert.Equal(t, "family=+16(%di):u16 local_port=+14(%di):u16 "+
+		"local_addr=+4(%di):u32 "+
+		"local_addr6_high=+80(%di):u64 local_addr6_low=+88(%di):u64",
+		s.relocateKprobeFetchargs(networkKprobeTCPListenSymbol,
+			networkKprobeTCPListenFetchargs))
+}
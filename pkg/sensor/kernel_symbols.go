@@ -0,0 +1,125 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+
+	"github.com/golang/glog"
+)
+
+// A kprobeSymbolAlternative is a kernel function that replaces a function
+// that the sensor probes in newer kernels, where the original is either gone
+// or no longer called on the paths that the sensor is interested in.
+type kprobeSymbolAlternative struct {
+	symbol string
+
+	// The kernel version in which the alternative first appeared. This is
+	// only used to choose an alternative when kallsyms cannot be read.
+	major, minor int
+
+	// Replacement expressions for fetch args whose location differs in
+	// the alternative, keyed by fetch arg name
+	fetchargs map[string]string
+}
+
+// kprobeSymbolAlternatives maps kprobe symbols to their alternatives, oldest
+// first. The original symbol is used if it's available.
+var kprobeSymbolAlternatives = map[string][]kprobeSymbolAlternative{
+	// Linux 5.6 moved open(2) to do_sys_openat2, which takes its flags
+	// and mode in a struct open_how. do_sys_open is still there, but
+	// it's usually inlined.
+	fsDoSysOpenKprobeAddress: {
+		{"do_sys_openat2", 5, 6, map[string]string{
+			"flags": "+0(%dx):s32",
+			"mode":  "+8(%dx):s32",
+		}},
+	},
+
+	// Linux 4.2 renamed do_fork to _do_fork, and Linux 5.10 replaced it
+	// with kernel_clone, which takes a struct kernel_clone_args.
+	doForkAddress: {
+		{"_do_fork", 4, 2, nil},
+		{"kernel_clone", 5, 10, map[string]string{
+			"clone_flags": "+0(%di):u64",
+		}},
+	},
+}
+
+// resolveKprobeSymbols chooses the probe target for each symbol that has
+// alternatives. If kallsyms could be read, the first available of the
+// original symbol and its alternatives is chosen. Otherwise, the newest
+// alternative that is no newer than the running kernel is chosen.
+func (s *Sensor) resolveKprobeSymbols() {
+	major, minor, _ := sys.KernelVersion()
+	s.kprobeSymbols = make(map[string]kprobeSymbolAlternative)
+	for symbol, alternatives := range kprobeSymbolAlternatives {
+		var chosen *kprobeSymbolAlternative
+		if s.kallsyms == nil {
+			for i := range alternatives {
+				a := &alternatives[i]
+				if major > a.major || (major == a.major && minor >= a.minor) {
+					chosen = a
+				}
+			}
+		} else if _, ok := s.kallsyms[symbol]; !ok {
+			for i := range alternatives {
+				if _, ok = s.kallsyms[alternatives[i].symbol]; ok {
+					chosen = &alternatives[i]
+					break
+				}
+			}
+			if chosen == nil {
+				glog.Warningf("Kernel symbol %s and its alternatives are not available",
+					symbol)
+			}
+		}
+		if chosen != nil {
+			glog.V(1).Infof("Using kernel symbol %s instead of %s",
+				chosen.symbol, symbol)
+			s.kprobeSymbols[symbol] = *chosen
+		}
+	}
+}
+
+func replaceFetchargs(fetchargs string, replacements map[string]string) string {
+	if len(replacements) == 0 {
+		return fetchargs
+	}
+	args := strings.Split(fetchargs, " ")
+	for i, arg := range args {
+		eq := strings.IndexByte(arg, '=')
+		if eq < 0 {
+			continue
+		}
+		if expr, ok := replacements[arg[:eq]]; ok {
+			args[i] = fmt.Sprintf("%s=%s", arg[:eq], expr)
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// alternativeKprobeSymbol returns the probe target and fetch args to use for
+// a kprobe symbol on the running kernel.
+func (s *Sensor) alternativeKprobeSymbol(symbol, fetchargs string) (string, string) {
+	a, ok := s.kprobeSymbols[symbol]
+	if !ok {
+		return symbol, fetchargs
+	}
+	return a.symbol, replaceFetchargs(fetchargs, a.fetchargs)
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveKprobeSymbols(t *testing.T) {
+	s := Sensor{}
+	s.kallsyms = map[string]string{
+		"do_sys_openat2": "do_sys_openat2",
+		"_do_fork":       "_do_fork",
+		"kernel_clone":   "kernel_clone",
+	}
+	s.resolveKprobeSymbols()
+
+	symbol, fetchargs := s.alternativeKprobeSymbol(fsDoSysOpenKprobeAddress,
+		fsDoSysOpenKprobeFetchargs)
+	assert.Equal(t, "do_sys_openat2", symbol)
+	assert.Equal(t, "filename=+0(%si):string flags=+0(%dx):s32 mode=+8(%dx):s32",
+		fetchargs)
+
+	// The oldest available alternative is preferred
+	symbol, fetchargs = s.alternativeKprobeSymbol(doForkAddress,
+		doForkFetchargs)
+	assert.Equal(t, "_do_fork", symbol)
+	assert.Equal(t, doForkFetchargs, fetchargs)
+	assert.True(t, s.IsKernelSymbolAvailable(doForkAddress))
+
+	// Symbols that are available are used as they are
+	s.kallsyms[fsDoSysOpenKprobeAddress] = fsDoSysOpenKprobeAddress
+	s.resolveKprobeSymbols()
+	symbol, fetchargs = s.alternativeKprobeSymbol(fsDoSysOpenKprobeAddress,
+		fsDoSysOpenKprobeFetchargs)
+	assert.Equal(t, fsDoSysOpenKprobeAddress, symbol)
+	assert.Equal(t, fsDoSysOpenKprobeFetchargs, fetchargs)
+
+	symbol, _ = s.alternativeKprobeSymbol("vfs_write", "")
+	assert.Equal(t, "vfs_write", symbol)
+
+	// Nothing is available
+	s.kallsyms = map[string]string{}
+	s.resolveKprobeSymbols()
+	symbol, _ = s.alternativeKprobeSymbol(doForkAddress, doForkFetchargs)
+	assert.Equal(t, doForkAddress, symbol)
+	assert.False(t, s.IsKernelSymbolAvailable(doForkAddress))
+}
+
+func TestReplaceFetchargs(t *testing.T) {
+	assert.Equal(t, "a=%di:u64 b=+8(%si):u32",
+		replaceFetchargs("a=%di:u64 b=%si:u32",
+			map[string]string{"b": "+8(%si):u32", "c": "%dx:u8"}))
+	assert.Equal(t, "a=%di:u64", replaceFetchargs("a=%di:u64", nil))
+}
@@ -28,6 +28,8 @@ import (
 type KernelFunctionCallTelemetryEvent struct {
 	TelemetryEventData
 
+	Symbol    string
+	OnReturn  bool
 	Arguments perf.TraceEventSampleData
 }
 
@@ -42,17 +44,23 @@ var validSymbolRegex = regexp.MustCompile("^[A-Za-z_]{1}[\\w]*$")
 func (s *Subscription) decodeKprobe(
 	sample *perf.SampleRecord,
 	data perf.TraceEventSampleData,
+	symbol string,
+	onReturn bool,
 ) (interface{}, error) {
 	var e KernelFunctionCallTelemetryEvent
 	if !e.InitWithSample(s.sensor, sample, data) {
 		return nil, nil
 	}
+	e.Symbol = symbol
+	e.OnReturn = onReturn
 	e.Arguments = data
 	return e, nil
 }
 
 // RegisterKernelFunctionCallEventFilter registers a kernel function call event
-// filter with a subscription.
+// filter with a subscription. If onReturn is true, a kretprobe is used so that
+// the event is generated when the function returns, and the return value may
+// be fetched using $retval in arguments.
 func (s *Subscription) RegisterKernelFunctionCallEventFilter(
 	symbol string,
 	onReturn bool,
@@ -73,9 +81,13 @@ func (s *Subscription) RegisterKernelFunctionCallEventFilter(
 	}
 	fetchargs := strings.Join(l, " ")
 
+	decoder := func(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+		return s.decodeKprobe(sample, data, symbol, onReturn)
+	}
+
 	// Pass nil for filterTypes here to force the filter types to be
 	// determined dynamically after the kprobe is registered with the
 	// kernel.
-	s.registerKprobe(symbol, onReturn, fetchargs, s.decodeKprobe,
+	s.registerKprobe(symbol, onReturn, fetchargs, decoder,
 		filter, nil)
 }
@@ -48,12 +48,12 @@ func TestDecodeKprobe(t *testing.T) {
 		"uint64":     uint64(64),
 	}
 
-	i, err := s.decodeKprobe(sample, data)
+	i, err := s.decodeKprobe(sample, data, "do_sys_open", false)
 	require.Nil(t, i)
 	require.NoError(t, err)
 
 	delete(data, "common_pid")
-	i, err = s.decodeKprobe(sample, data)
+	i, err = s.decodeKprobe(sample, data, "do_sys_open", false)
 	require.NotNil(t, i)
 	require.NoError(t, err)
 	e, ok := i.(KernelFunctionCallTelemetryEvent)
@@ -61,7 +61,16 @@ func TestDecodeKprobe(t *testing.T) {
 
 	ok = testCommonTelemetryEventData(t, sensor, e)
 	require.True(t, ok)
+	assert.Equal(t, "do_sys_open", e.Symbol)
+	assert.False(t, e.OnReturn)
 	assert.Equal(t, data, e.Arguments)
+
+	data["ret"] = int64(-13)
+	i, err = s.decodeKprobe(sample, data, "do_sys_open", true)
+	require.NoError(t, err)
+	e = i.(KernelFunctionCallTelemetryEvent)
+	assert.True(t, e.OnReturn)
+	assert.Equal(t, int64(-13), e.Arguments["ret"])
 }
 
 func prepareForRegisterKernelFunctionCallEventFilter(t *testing.T, s *Subscription) {
@@ -0,0 +1,47 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+// LostEventsTelemetryEvent is a telemetry event generated when the kernel
+// reports that events for a subscription were dropped because a ring buffer
+// was full. It is not subject to the subscription's filters.
+type LostEventsTelemetryEvent struct {
+	TelemetryEventData
+
+	// The number of events lost since WindowStartMonotimeNanos. The
+	// window ends at the event's MonotimeNanos.
+	Count                    uint64
+	WindowStartMonotimeNanos int64
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a lost events telemetry event.
+func (e LostEventsTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// dispatchLostEvents reports lost events to a subscription. The window for
+// each report begins where the previous one ended, or when the subscription
+// was created. It is only called from the sensor's sample dispatch loop.
+func (s *Subscription) dispatchLostEvents(count, sampleTime uint64) {
+	var e LostEventsTelemetryEvent
+	e.Init(s.sensor)
+	e.MonotimeNanos = int64(sampleTime) - s.sensor.bootMonotimeNanos
+	e.Count = count
+	e.WindowStartMonotimeNanos = s.lostEventsWindowStart
+	s.lostEventsWindowStart = e.MonotimeNanos
+
+	s.dispatchFn(e)
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatchLostEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var events []TelemetryEvent
+	s := newTestSubscription(t, sensor)
+	s.RegisterTickerEventFilter(int64(3600e9), nil)
+	require.Len(t, s.eventSinks, 1)
+	var eventID uint64
+	for eventID = range s.eventSinks {
+	}
+	_, err := s.Run(ctx, func(e TelemetryEvent) {
+		events = append(events, e)
+	})
+	require.NoError(t, err)
+	windowStart := s.lostEventsWindowStart
+
+	lostSample := func(lost, time uint64) perf.EventMonitorSample {
+		esm := perf.EventMonitorSample{EventID: eventID}
+		esm.RawSample.Record = &perf.LostRecord{Lost: lost}
+		esm.RawSample.Time = time
+		return esm
+	}
+
+	// Lost records in a batch are reported together
+	t1 := uint64(sensor.bootMonotimeNanos + windowStart + 1000)
+	sensor.dispatchQueuedSamples([]perf.EventMonitorSample{
+		lostSample(3, t1-500),
+		lostSample(4, t1),
+	})
+	require.Len(t, events, 1)
+	e := events[0].(LostEventsTelemetryEvent)
+	assert.Equal(t, uint64(7), e.Count)
+	assert.Equal(t, windowStart, e.WindowStartMonotimeNanos)
+	assert.Equal(t, windowStart+1000, e.MonotimeNanos)
+
+	// The next window begins where the last one ended
+	sensor.dispatchQueuedSamples([]perf.EventMonitorSample{
+		lostSample(1, t1+2000),
+	})
+	require.Len(t, events, 2)
+	e = events[1].(LostEventsTelemetryEvent)
+	assert.Equal(t, uint64(1), e.Count)
+	assert.Equal(t, windowStart+1000, e.WindowStartMonotimeNanos)
+	assert.Equal(t, windowStart+3000, e.MonotimeNanos)
+
+	assert.Equal(t, uint64(8), sensor.Metrics.LostEvents)
+}
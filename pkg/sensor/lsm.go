@@ -0,0 +1,196 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// LSMModuleSELinux identifies denials made by SELinux.
+	LSMModuleSELinux = "selinux"
+
+	// LSMModuleAppArmor identifies denials made by AppArmor.
+	LSMModuleAppArmor = "apparmor"
+)
+
+// LSMDenialEventTypes defines the field types that can be used with filters
+// on LSM denial telemetry events.
+var LSMDenialEventTypes = expression.FieldTypeMap{
+	"module":       expression.ValueTypeString,
+	"operation":    expression.ValueTypeString,
+	"subject":      expression.ValueTypeString,
+	"object":       expression.ValueTypeString,
+	"object_class": expression.ValueTypeString,
+	"name":         expression.ValueTypeString,
+}
+
+// LSMDenialTelemetryEvent is a telemetry event generated by the LSM event
+// source when SELinux or AppArmor denies an access. The process information
+// is that of the process whose access was denied.
+type LSMDenialTelemetryEvent struct {
+	TelemetryEventData
+
+	Module      string
+	Operation   string
+	Subject     string
+	Object      string
+	ObjectClass string
+	Name        string
+	Message     string
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for an LSM denial telemetry event.
+func (e LSMDenialTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// parseLSMDenial extracts the sample data for an LSM denial event from an AVC
+// audit record. Both SELinux and AppArmor report through AVC records; records
+// for accesses that were granted or merely audited are ignored. The pid in
+// the record is in the initial PID namespace. For example:
+//
+//	avc:  denied  { read } for  pid=1 comm="cat" name="shadow" ...
+//	apparmor="DENIED" operation="open" profile="docker-default" ...
+func parseLSMDenial(record *auditRecord) (perf.TraceEventSampleData, bool) {
+	fields := record.fields()
+	data := perf.TraceEventSampleData{
+		"name":    fields["name"],
+		"message": record.Text,
+	}
+
+	if apparmor, ok := fields["apparmor"]; ok {
+		if apparmor != "DENIED" {
+			return nil, false
+		}
+		data["module"] = LSMModuleAppArmor
+		data["operation"] = fields["operation"]
+		data["subject"] = fields["profile"]
+		data["object"] = ""
+		data["object_class"] = fields["class"]
+	} else if strings.HasPrefix(record.Text, "avc:  denied ") {
+		var operation string
+		if start := strings.Index(record.Text, "{ "); start >= 0 {
+			if end := strings.Index(record.Text[start:], " }"); end >= 0 {
+				operation = record.Text[start+2 : start+end]
+			}
+		}
+		data["module"] = LSMModuleSELinux
+		data["operation"] = operation
+		data["subject"] = fields["scontext"]
+		data["object"] = fields["tcontext"]
+		data["object_class"] = fields["tclass"]
+		if len(fields["path"]) > 0 {
+			data["name"] = fields["path"]
+		}
+	} else {
+		return nil, false
+	}
+
+	if pid, err := strconv.ParseInt(fields["pid"], 10, 32); err == nil {
+		data["common_pid"] = int32(pid)
+	}
+	return data, true
+}
+
+func (s *Sensor) handleAVCRecord(
+	eventID uint64,
+	sampleID perf.SampleID,
+	record *auditRecord,
+) {
+	data, ok := parseLSMDenial(record)
+	if !ok {
+		return
+	}
+	err := s.Monitor().EnqueueExternalSample(eventID, sampleID, data)
+	if err != nil {
+		glog.V(1).Infof("Could not enqueue LSM denial: %v", err)
+	}
+}
+
+func (s *Sensor) decodeLSMDenial(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e LSMDenialTelemetryEvent
+	if !e.InitWithSample(s, sample, data) {
+		return nil, nil
+	}
+	e.Module = data["module"].(string)
+	e.Operation = data["operation"].(string)
+	e.Subject = data["subject"].(string)
+	e.Object = data["object"].(string)
+	e.ObjectClass = data["object_class"].(string)
+	e.Name = data["name"].(string)
+	e.Message = data["message"].(string)
+	return e, nil
+}
+
+// registerLSMDenialEvent returns the external event ID used for LSM denial
+// events, registering the event and starting the audit listener the first
+// time it is needed.
+func (s *Sensor) registerLSMDenialEvent() (uint64, error) {
+	l, err := s.auditListener()
+	if err != nil {
+		return 0, err
+	}
+
+	s.auditLock.Lock()
+	defer s.auditLock.Unlock()
+
+	if s.lsmDenialEventRegistered {
+		return s.lsmDenialEventID, nil
+	}
+	eventID := s.Monitor().RegisterExternalEvent("LSM_DENIAL",
+		s.decodeLSMDenial)
+	l.handleRecordType(auditRecordTypeAVC,
+		func(sampleID perf.SampleID, record *auditRecord) {
+			s.handleAVCRecord(eventID, sampleID, record)
+		})
+	s.lsmDenialEventID = eventID
+	s.lsmDenialEventRegistered = true
+	return eventID, nil
+}
+
+// RegisterLSMDenialEventFilter registers an LSM denial event filter with a
+// subscription.
+func (s *Subscription) RegisterLSMDenialEventFilter(expr *expression.Expression) {
+	if expr != nil {
+		if err := expr.Validate(LSMDenialEventTypes); err != nil {
+			s.logStatus(
+				fmt.Sprintf("Invalid LSM filter expression: %v", err))
+			return
+		}
+	}
+
+	eventID, err := s.sensor.registerLSMDenialEvent()
+	if err != nil {
+		s.logStatus(
+			fmt.Sprintf("Could not monitor audit records: %v", err))
+		return
+	}
+	if _, err = s.addEventSink(eventID, expr, LSMDenialEventTypes); err != nil {
+		s.logStatus(
+			fmt.Sprintf("Invalid LSM filter expression: %v", err))
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	lsmTestAppArmorDenial = `apparmor="DENIED" operation="open" ` +
+		`profile="docker-default" name="/etc/shadow" pid=111343 ` +
+		`comm="cat" requested_mask="r" denied_mask="r" fsuid=0 ouid=0`
+
+	lsmTestSELinuxDenial = `avc:  denied  { read write } for  pid=111343 ` +
+		`comm="cat" path="/etc/shadow" dev="dm-0" ino=1234 ` +
+		`scontext=system_u:system_r:container_t:s0:c1,c2 ` +
+		`tcontext=system_u:object_r:shadow_t:s0 tclass=file permissive=0`
+)
+
+func TestParseLSMDenial(t *testing.T) {
+	data, ok := parseLSMDenial(&auditRecord{Text: lsmTestAppArmorDenial})
+	require.True(t, ok)
+	assert.Equal(t, perf.TraceEventSampleData{
+		"common_pid":   int32(111343),
+		"module":       LSMModuleAppArmor,
+		"operation":    "open",
+		"subject":      "docker-default",
+		"object":       "",
+		"object_class": "",
+		"name":         "/etc/shadow",
+		"message":      lsmTestAppArmorDenial,
+	}, data)
+
+	data, ok = parseLSMDenial(&auditRecord{Text: lsmTestSELinuxDenial})
+	require.True(t, ok)
+	assert.Equal(t, perf.TraceEventSampleData{
+		"common_pid":   int32(111343),
+		"module":       LSMModuleSELinux,
+		"operation":    "read write",
+		"subject":      "system_u:system_r:container_t:s0:c1,c2",
+		"object":       "system_u:object_r:shadow_t:s0",
+		"object_class": "file",
+		"name":         "/etc/shadow",
+		"message":      lsmTestSELinuxDenial,
+	}, data)
+
+	ignoredRecords := []string{
+		`apparmor="ALLOWED" operation="open" profile="test" pid=1`,
+		`apparmor="STATUS" operation="profile_load" name="test" pid=1`,
+		`avc:  granted  { read } for  pid=1 comm="cat" tclass=file`,
+		`avc:  received setenforce notice (enforcing=1)`,
+	}
+	for _, text := range ignoredRecords {
+		_, ok = parseLSMDenial(&auditRecord{Text: text})
+		assert.False(t, ok, text)
+	}
+}
+
+func TestDecodeLSMDenial(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data, ok := parseLSMDenial(&auditRecord{Text: lsmTestSELinuxDenial})
+	require.True(t, ok)
+
+	i, err := sensor.decodeLSMDenial(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, LSMDenialTelemetryEvent{}, i)
+
+	e := i.(LSMDenialTelemetryEvent)
+	ok = testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, 111343, e.PID)
+	assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+		e.Container.ID)
+	assert.Equal(t, LSMModuleSELinux, e.Module)
+	assert.Equal(t, "read write", e.Operation)
+	assert.Equal(t, "system_u:system_r:container_t:s0:c1,c2", e.Subject)
+	assert.Equal(t, "system_u:object_r:shadow_t:s0", e.Object)
+	assert.Equal(t, "file", e.ObjectClass)
+	assert.Equal(t, "/etc/shadow", e.Name)
+	assert.Equal(t, lsmTestSELinuxDenial, e.Message)
+
+	data["common_pid"] = int32(sensorPID)
+	i, err = sensor.decodeLSMDenial(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+}
+
+func TestLSMDenialEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	l := newTestAuditListener(sensor)
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterLSMDenialEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+	assert.Len(t, l.handlers, 0)
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("module"),
+		expression.Value(LSMModuleAppArmor))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterLSMDenialEventFilter(expr)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+	assert.Len(t, l.handlers[auditRecordTypeAVC], 1)
+
+	// The audit record handler is only installed once
+	s = newTestSubscription(t, sensor)
+	s.RegisterLSMDenialEventFilter(nil)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, l.handlers[auditRecordTypeAVC], 1)
+}
@@ -18,14 +18,24 @@ import (
 	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/services"
 	"github.com/golang/glog"
+
+	"golang.org/x/net/context"
 )
 
 // Main is the main entrypoint for the sensor
 func Main() {
+	if len(config.Global.ConfigFile) > 0 && config.Global.ConfigReloadInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go config.Watch(ctx, config.Global.ConfigReloadInterval)
+	}
+
 	manager := services.NewServiceManager()
 	if len(config.Global.ProfilingListenAddr) > 0 {
 		service := services.NewProfilingService(
-			config.Global.ProfilingListenAddr)
+			config.Global.ProfilingListenAddr,
+			services.WithBlockProfileRate(config.Global.ProfilingBlockRate),
+			services.WithMutexProfileFraction(config.Global.ProfilingMutexFraction))
 		manager.RegisterService(service)
 	}
 
@@ -40,6 +50,15 @@ func Main() {
 		defer sensor.Stop()
 		service := NewTelemetryService(sensor, config.Sensor.ListenAddr)
 		manager.RegisterService(service)
+
+		if len(config.Sensor.HealthListenAddr) > 0 {
+			manager.RegisterService(NewHealthService(sensor,
+				config.Sensor.HealthListenAddr))
+		}
+		if len(config.Sensor.MetricsListenAddr) > 0 {
+			manager.RegisterService(NewMetricsService(sensor,
+				service, config.Sensor.MetricsListenAddr))
+		}
 	}
 
 	manager.Run()
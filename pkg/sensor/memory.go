@@ -0,0 +1,169 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"golang.org/x/sys/unix"
+)
+
+// MemoryMmapExecEventTypes defines the field types that can be used with
+// filters on executable anonymous memory mapping telemetry events.
+var MemoryMmapExecEventTypes = expression.FieldTypeMap{
+	"addr":   expression.ValueTypeUnsignedInt64,
+	"length": expression.ValueTypeUnsignedInt64,
+	"prot":   expression.ValueTypeUnsignedInt32,
+	"flags":  expression.ValueTypeUnsignedInt32,
+}
+
+// MemoryMmapExecTelemetryEvent is a telemetry event generated by the memory
+// event source when a process requests an anonymous memory mapping with
+// PROT_EXEC.
+type MemoryMmapExecTelemetryEvent struct {
+	TelemetryEventData
+
+	Address uint64
+	Length  uint64
+	Prot    uint32
+	Flags   uint32
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for an executable memory mapping telemetry event.
+func (e MemoryMmapExecTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// MemoryMprotectExecEventTypes defines the field types that can be used with
+// filters on memory protection change telemetry events.
+var MemoryMprotectExecEventTypes = expression.FieldTypeMap{
+	"addr":     expression.ValueTypeUnsignedInt64,
+	"length":   expression.ValueTypeUnsignedInt64,
+	"prot":     expression.ValueTypeUnsignedInt32,
+	"old_prot": expression.ValueTypeUnsignedInt32,
+}
+
+// MemoryMprotectExecTelemetryEvent is a telemetry event generated by the
+// memory event source when writable memory is made executable.
+type MemoryMprotectExecTelemetryEvent struct {
+	TelemetryEventData
+
+	Address uint64
+	Length  uint64
+	Prot    uint32
+	OldProt uint32
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a memory protection change telemetry event.
+func (e MemoryMprotectExecTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// Only anonymous mappings are of interest; executable file mappings are made
+// all the time by the dynamic loader. Selecting them happens in the kernel.
+const (
+	memoryMmapKprobeSymbol    = "sys_mmap"
+	memoryMmapKprobeFetchargs = "addr=%di:u64 length=%si:u64 prot=%dx:u32 flags=%cx:u32"
+)
+
+var memoryMmapKprobeFilter = fmt.Sprintf("(prot & %d) && (flags & %d)",
+	unix.PROT_EXEC, unix.MAP_ANONYMOUS)
+
+// mprotect_fixup is called by mprotect(2) once for each VMA in the region
+// being changed, with the new VMA flags. The current flags are found in
+// vma->vm_flags, which is at offset 80 unless the running kernel's BTF says
+// otherwise. The low bits of the VMA flags are the same as the PROT_* flags. Selecting changes from writable to executable happens in the
+// kernel.
+const (
+	memoryMprotectKprobeSymbol    = "mprotect_fixup"
+	memoryMprotectKprobeFetchargs = "start=%dx:u64 end=%cx:u64 " +
+		"old_flags=+80(%di):u64 new_flags=%r8:u64"
+
+	memoryVMAProtMask = unix.PROT_READ | unix.PROT_WRITE | unix.PROT_EXEC
+)
+
+var memoryMprotectKprobeFilter = fmt.Sprintf(
+	"(old_flags & %d) && (new_flags & %d)", unix.PROT_WRITE, unix.PROT_EXEC)
+
+func (s *Subscription) decodeSysMmap(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e MemoryMmapExecTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Address = data["addr"].(uint64)
+	e.Length = data["length"].(uint64)
+	e.Prot = data["prot"].(uint32)
+	e.Flags = data["flags"].(uint32)
+	return e, nil
+}
+
+func (s *Subscription) decodeMprotectFixup(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e MemoryMprotectExecTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	start := data["start"].(uint64)
+	e.Address = start
+	e.Length = data["end"].(uint64) - start
+	e.Prot = uint32(data["new_flags"].(uint64) & memoryVMAProtMask)
+	e.OldProt = uint32(data["old_flags"].(uint64) & memoryVMAProtMask)
+
+	// Make the derived fields visible to filter expressions, which are
+	// evaluated against the sample data after decoding.
+	data["addr"] = e.Address
+	data["length"] = e.Length
+	data["prot"] = e.Prot
+	data["old_prot"] = e.OldProt
+
+	return e, nil
+}
+
+// RegisterMemoryMmapExecEventFilter registers an executable anonymous memory
+// mapping event filter with a subscription.
+func (s *Subscription) RegisterMemoryMmapExecEventFilter(expr *expression.Expression) {
+	s.registerWithSensorFilter("mmap", expr, MemoryMmapExecEventTypes,
+		func() (*eventSink, error) {
+			// The kernel filter is needed to select executable anonymous
+			// mappings
+			return s.registerKprobe(memoryMmapKprobeSymbol, false,
+				memoryMmapKprobeFetchargs, s.decodeSysMmap, nil,
+				MemoryMmapExecEventTypes,
+				s.stackTraceOptions(perf.WithFilter(memoryMmapKprobeFilter))...)
+		})
+}
+
+// RegisterMemoryMprotectExecEventFilter registers a memory protection change
+// event filter with a subscription.
+func (s *Subscription) RegisterMemoryMprotectExecEventFilter(expr *expression.Expression) {
+	s.registerWithSensorFilter("mprotect", expr, MemoryMprotectExecEventTypes,
+		func() (*eventSink, error) {
+			// The fields are derived from the VMA flags after decoding
+			return s.registerKprobe(memoryMprotectKprobeSymbol, false,
+				memoryMprotectKprobeFetchargs, s.decodeMprotectFixup, nil,
+				MemoryMprotectExecEventTypes,
+				s.stackTraceOptions(perf.WithFilter(memoryMprotectKprobeFilter))...)
+		})
+}
@@ -0,0 +1,211 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestDecodeSysMmap(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"addr":       uint64(0),
+		"length":     uint64(4096),
+		"prot":       uint32(unix.PROT_READ | unix.PROT_WRITE | unix.PROT_EXEC),
+		"flags":      uint32(unix.MAP_PRIVATE | unix.MAP_ANONYMOUS),
+	}
+
+	i, err := s.decodeSysMmap(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	delete(data, "common_pid")
+	i, err = s.decodeSysMmap(sample, data)
+	require.NotNil(t, i)
+	require.NoError(t, err)
+	e, ok := i.(MemoryMmapExecTelemetryEvent)
+	require.True(t, ok)
+
+	ok = testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, uint64(0), e.Address)
+	assert.Equal(t, uint64(4096), e.Length)
+	assert.Equal(t, uint32(unix.PROT_READ|unix.PROT_WRITE|unix.PROT_EXEC), e.Prot)
+	assert.Equal(t, uint32(unix.MAP_PRIVATE|unix.MAP_ANONYMOUS), e.Flags)
+}
+
+func TestDecodeMprotectFixup(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"start":      uint64(0x7f0000000000),
+		"end":        uint64(0x7f0000002000),
+		"old_flags":  uint64(0x100073),
+		"new_flags":  uint64(0x100075),
+	}
+
+	i, err := s.decodeMprotectFixup(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	delete(data, "common_pid")
+	i, err = s.decodeMprotectFixup(sample, data)
+	require.NotNil(t, i)
+	require.NoError(t, err)
+	e, ok := i.(MemoryMprotectExecTelemetryEvent)
+	require.True(t, ok)
+
+	ok = testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, uint64(0x7f0000000000), e.Address)
+	assert.Equal(t, uint64(0x2000), e.Length)
+	assert.Equal(t, uint32(unix.PROT_READ|unix.PROT_EXEC), e.Prot)
+	assert.Equal(t, uint32(unix.PROT_READ|unix.PROT_WRITE), e.OldProt)
+	assert.Equal(t, uint64(0x2000), data["length"])
+	assert.Equal(t, uint32(unix.PROT_READ|unix.PROT_EXEC), data["prot"])
+}
+
+func prepareForRegisterMemoryMmapExecEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:u64 addr;	offset:16;	size:8;	signed:0;
+	field:u64 length;	offset:24;	size:8;	signed:0;
+	field:u32 prot;	offset:32;	size:4;	signed:0;
+	field:u32 flags;	offset:36;	size:4;	signed:0;
+
+print fmt: "(%lx) addr=%Lu length=%Lu prot=%u flags=%u", REC->__probe_ip, REC->addr, REC->length, REC->prot, REC->flags`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func prepareForRegisterMemoryMprotectExecEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:u64 start;	offset:16;	size:8;	signed:0;
+	field:u64 end;	offset:24;	size:8;	signed:0;
+	field:u64 old_flags;	offset:32;	size:8;	signed:0;
+	field:u64 new_flags;	offset:40;	size:8;	signed:0;
+
+print fmt: "(%lx) start=%Lu end=%Lu old_flags=%Lu new_flags=%Lu", REC->__probe_ip, REC->start, REC->end, REC->old_flags, REC->new_flags`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func verifyMemoryEventRegistration(t *testing.T, s *Subscription, count int) {
+	if count > 0 {
+		assert.Len(t, s.eventSinks, count)
+	} else {
+		assert.Len(t, s.status, -count)
+		assert.Len(t, s.eventSinks, 0)
+	}
+}
+
+func TestMemoryMmapExecEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	e := expression.GreaterThanEqualTo(expression.Identifier("length"),
+		expression.Value(uint64(1<<20)))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	prepareForRegisterMemoryMmapExecEventFilter(t, s, 0)
+	s.RegisterMemoryMmapExecEventFilter(expr)
+	verifyMemoryEventRegistration(t, s, 1)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	prepareForRegisterMemoryMmapExecEventFilter(t, s, 0)
+	s.RegisterMemoryMmapExecEventFilter(nil)
+	verifyMemoryEventRegistration(t, s, 1)
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("foo"), expression.Value("bar"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterMemoryMmapExecEventFilter(expr)
+	verifyMemoryEventRegistration(t, s, -1)
+}
+
+func TestMemoryMprotectExecEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("old_prot"),
+		expression.Value(uint32(unix.PROT_READ|unix.PROT_WRITE)))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	prepareForRegisterMemoryMprotectExecEventFilter(t, s, 0)
+	s.RegisterMemoryMprotectExecEventFilter(expr)
+	verifyMemoryEventRegistration(t, s, 1)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("flags"),
+		expression.Value(uint32(unix.MAP_ANONYMOUS)))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterMemoryMprotectExecEventFilter(expr)
+	verifyMemoryEventRegistration(t, s, -1)
+}
@@ -21,4 +21,16 @@ type MetricsCounters struct {
 
 	// Number of subscriptions
 	Subscriptions uint64
+
+	// Number of events that the kernel dropped because a ring buffer was
+	// full
+	LostEvents uint64
+
+	// Number of events that were dropped because a subscription's rate
+	// limit was exceeded
+	RateLimitedEvents uint64
+
+	// Number of records from the sensor's event sources that could not
+	// be decoded
+	DecodeErrors uint64
 }
@@ -0,0 +1,207 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+// +build ignore
+
+// mksyscalls generates the table of system call names for an architecture
+// from the kernel's uapi headers. The headers are run through the C
+// preprocessor so that the architecture's selection of optional system calls
+// is applied, and every __NR_ macro that names a system call number is
+// collected. Kernel headers for another architecture, such as those installed
+// by `make ARCH=arm64 headers_install`, may be used with -I.
+//
+// Usage:
+//
+//	go run mksyscalls.go -arch amd64 -o syscall_names_amd64.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The source preprocessed for each architecture. The arm64 definitions are
+// those made by arch/arm64/include/uapi/asm/unistd.h before it includes the
+// generic system call table.
+var archSources = map[string]string{
+	"amd64": "#include <asm/unistd_64.h>\n",
+	"arm64": "#define __ARCH_WANT_RENAMEAT\n" +
+		"#define __ARCH_WANT_NEW_STAT\n" +
+		"#define __ARCH_WANT_SET_GET_RLIMIT\n" +
+		"#define __ARCH_WANT_TIME32_SYSCALLS\n" +
+		"#define __ARCH_WANT_SYS_CLONE3\n" +
+		"#define __ARCH_WANT_MEMFD_SECRET\n" +
+		"#include <asm-generic/unistd.h>\n",
+}
+
+// Macros with the __NR_ prefix that are not system calls
+var ignoredNames = map[string]bool{
+	"syscalls":              true,
+	"arch_specific_syscall": true,
+}
+
+const license = `// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+`
+
+var (
+	arch    = flag.String("arch", "", "architecture to generate (amd64 or arm64)")
+	include = flag.String("I", "", "kernel header include directory")
+	output  = flag.String("o", "", "output file (default stdout)")
+)
+
+func preprocess(source string) ([]byte, error) {
+	cc := os.Getenv("CC")
+	if cc == "" {
+		cc = "cc"
+	}
+	args := []string{"-E", "-dM"}
+	if *include != "" {
+		args = append(args, "-I", *include)
+	}
+	args = append(args, "-")
+
+	cmd := exec.Command(cc, args...)
+	cmd.Stdin = strings.NewReader(source)
+	cmd.Stderr = os.Stderr
+	return cmd.Output()
+}
+
+// parseSyscalls collects system call numbers from the output of the C
+// preprocessor. Macros may be defined in terms of others, such as
+// __NR_fcntl being defined as __NR3264_fcntl in the generic table.
+func parseSyscalls(defines []byte) (map[string]int64, error) {
+	macros := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(defines))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 3 && fields[0] == "#define" {
+			macros[fields[1]] = fields[2]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	syscalls := make(map[string]int64)
+	for macro, value := range macros {
+		if !strings.HasPrefix(macro, "__NR_") {
+			continue
+		}
+		name := macro[len("__NR_"):]
+		if ignoredNames[name] {
+			continue
+		}
+		for i := 0; i < 8; i++ {
+			next, ok := macros[value]
+			if !ok {
+				break
+			}
+			value = next
+		}
+		nr, err := strconv.ParseInt(value, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", macro, err)
+		}
+		syscalls[name] = nr
+	}
+	return syscalls, nil
+}
+
+func generate(syscalls map[string]int64) ([]byte, error) {
+	var names []string
+	for name := range syscalls {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if syscalls[names[i]] != syscalls[names[j]] {
+			return syscalls[names[i]] < syscalls[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by mksyscalls.go -arch %s; DO NOT EDIT.\n\n", *arch)
+	fmt.Fprintf(buf, "%s\n", license)
+	fmt.Fprintf(buf, "package sensor\n\n")
+	fmt.Fprintf(buf, "// syscallNames maps %s system call numbers to names.\n", *arch)
+	fmt.Fprintf(buf, "var syscallNames = map[int64]string{\n")
+	seen := make(map[int64]bool)
+	for _, name := range names {
+		// Keep the first name for numbers with aliases
+		if nr := syscalls[name]; !seen[nr] {
+			fmt.Fprintf(buf, "%d: %q,\n", nr, name)
+			seen[nr] = true
+		}
+	}
+	fmt.Fprintf(buf, "}\n")
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	flag.Parse()
+	source, ok := archSources[*arch]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "mksyscalls: unsupported architecture %q\n", *arch)
+		os.Exit(2)
+	}
+
+	defines, err := preprocess(source)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mksyscalls: %v\n", err)
+		os.Exit(1)
+	}
+	syscalls, err := parseSyscalls(defines)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mksyscalls: %v\n", err)
+		os.Exit(1)
+	}
+	if len(syscalls) == 0 {
+		fmt.Fprintf(os.Stderr, "mksyscalls: no system calls found\n")
+		os.Exit(1)
+	}
+	data, err := generate(syscalls)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mksyscalls: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(data)
+	} else if err = ioutil.WriteFile(*output, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "mksyscalls: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// MountEventTypes defines the field types that can be used with filters on
+// mount telemetry events.
+var MountEventTypes = expression.FieldTypeMap{
+	"source": expression.ValueTypeString,
+	"target": expression.ValueTypeString,
+	"fstype": expression.ValueTypeString,
+	"flags":  expression.ValueTypeUnsignedInt64,
+}
+
+// UnmountEventTypes defines the field types that can be used with filters on
+// unmount telemetry events.
+var UnmountEventTypes = expression.FieldTypeMap{
+	"target": expression.ValueTypeString,
+	"flags":  expression.ValueTypeUnsignedInt32,
+}
+
+// MountTelemetryEvent is a telemetry event generated by the mount event
+// source when mount(2) is called.
+type MountTelemetryEvent struct {
+	TelemetryEventData
+
+	Source string
+	Target string
+	FSType string
+	Flags  uint64
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a mount telemetry event.
+func (e MountTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// UnmountTelemetryEvent is a telemetry event generated by the mount event
+// source when umount2(2) is called.
+type UnmountTelemetryEvent struct {
+	TelemetryEventData
+
+	Target string
+	Flags  uint32
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for an unmount telemetry event.
+func (e UnmountTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// The arguments are fetched from the system call handlers rather than from
+// do_mount and do_umount so that the paths are exactly as given by the caller.
+// The source and filesystem type may be NULL (e.g. for a remount or a bind
+// mount), in which case they are empty.
+const (
+	mountKprobeSymbol    = "sys_mount"
+	mountKprobeFetchargs = "source=+0(%di):string target=+0(%si):string " +
+		"fstype=+0(%dx):string flags=%cx:u64"
+
+	unmountKprobeSymbol    = "sys_umount"
+	unmountKprobeFetchargs = "target=+0(%di):string flags=%si:u32"
+)
+
+func (s *Subscription) decodeSysMount(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e MountTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Source, _ = data["source"].(string)
+	e.Target, _ = data["target"].(string)
+	e.FSType, _ = data["fstype"].(string)
+	e.Flags = data["flags"].(uint64)
+	return e, nil
+}
+
+func (s *Subscription) decodeSysUmount(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e UnmountTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Target, _ = data["target"].(string)
+	e.Flags = data["flags"].(uint32)
+	return e, nil
+}
+
+// RegisterMountEventFilter registers a mount event filter with a
+// subscription.
+func (s *Subscription) RegisterMountEventFilter(expr *expression.Expression) {
+	s.registerKprobe(mountKprobeSymbol, false, mountKprobeFetchargs,
+		s.decodeSysMount, expr, MountEventTypes)
+}
+
+// RegisterUnmountEventFilter registers an unmount event filter with a
+// subscription.
+func (s *Subscription) RegisterUnmountEventFilter(expr *expression.Expression) {
+	s.registerKprobe(unmountKprobeSymbol, false, unmountKprobeFetchargs,
+		s.decodeSysUmount, expr, UnmountEventTypes)
+}
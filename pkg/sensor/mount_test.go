@@ -0,0 +1,198 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestDecodeSysMount(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"source":     "proc",
+		"target":     "/mnt/proc",
+		"fstype":     "proc",
+		"flags":      uint64(unix.MS_NOSUID | unix.MS_NODEV),
+	}
+
+	i, err := s.decodeSysMount(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	delete(data, "common_pid")
+	i, err = s.decodeSysMount(sample, data)
+	require.NotNil(t, i)
+	require.NoError(t, err)
+	e, ok := i.(MountTelemetryEvent)
+	require.True(t, ok)
+
+	ok = testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, "proc", e.Source)
+	assert.Equal(t, "/mnt/proc", e.Target)
+	assert.Equal(t, "proc", e.FSType)
+	assert.Equal(t, uint64(unix.MS_NOSUID|unix.MS_NODEV), e.Flags)
+
+	// A bind mount has no filesystem type
+	delete(data, "fstype")
+	data["flags"] = uint64(unix.MS_BIND)
+	i, err = s.decodeSysMount(sample, data)
+	require.NoError(t, err)
+	e, ok = i.(MountTelemetryEvent)
+	require.True(t, ok)
+	assert.Equal(t, "", e.FSType)
+	assert.Equal(t, uint64(unix.MS_BIND), e.Flags)
+}
+
+func TestDecodeSysUmount(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"target":     "/mnt/proc",
+		"flags":      uint32(unix.MNT_DETACH),
+	}
+
+	i, err := s.decodeSysUmount(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	delete(data, "common_pid")
+	i, err = s.decodeSysUmount(sample, data)
+	require.NotNil(t, i)
+	require.NoError(t, err)
+	e, ok := i.(UnmountTelemetryEvent)
+	require.True(t, ok)
+
+	ok = testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, "/mnt/proc", e.Target)
+	assert.Equal(t, uint32(unix.MNT_DETACH), e.Flags)
+}
+
+func prepareForRegisterMountEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:__data_loc char[] source;	offset:16;	size:4;	signed:1;
+	field:__data_loc char[] target;	offset:20;	size:4;	signed:1;
+	field:__data_loc char[] fstype;	offset:24;	size:4;	signed:1;
+	field:u64 flags;	offset:32;	size:8;	signed:0;
+
+print fmt: "(%lx) source=\"%s\" target=\"%s\" fstype=\"%s\" flags=%Lu", REC->__probe_ip, __get_str(source), __get_str(target), __get_str(fstype), REC->flags`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func prepareForRegisterUnmountEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:__data_loc char[] target;	offset:16;	size:4;	signed:1;
+	field:u32 flags;	offset:20;	size:4;	signed:0;
+
+print fmt: "(%lx) target=\"%s\" flags=%u", REC->__probe_ip, __get_str(target), REC->flags`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func verifyMountEventRegistration(t *testing.T, s *Subscription, count int) {
+	if count > 0 {
+		assert.Len(t, s.eventSinks, count)
+	} else {
+		assert.Len(t, s.status, -count)
+		assert.Len(t, s.eventSinks, 0)
+	}
+}
+
+func TestMountEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	e := expression.Equal(expression.Identifier("foo"), expression.Value("bar"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	type testCase struct {
+		prepare  func(*testing.T, *Subscription, uint64)
+		register func(*Subscription, *expression.Expression)
+	}
+	testCases := []testCase{
+		testCase{prepareForRegisterMountEventFilter, (*Subscription).RegisterMountEventFilter},
+		testCase{prepareForRegisterUnmountEventFilter, (*Subscription).RegisterUnmountEventFilter},
+	}
+	for _, tc := range testCases {
+		s := newTestSubscription(t, sensor)
+		tc.prepare(t, s, 0)
+		tc.register(s, expr)
+		verifyMountEventRegistration(t, s, -1)
+
+		s = newTestSubscription(t, sensor)
+		tc.prepare(t, s, 0)
+		tc.register(s, nil)
+		verifyMountEventRegistration(t, s, 1)
+	}
+
+	// Filters on the mount source are only valid for mount events
+	e = expression.Equal(expression.Identifier("source"),
+		expression.Value("/var/run/docker.sock"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s := newTestSubscription(t, sensor)
+	prepareForRegisterMountEventFilter(t, s, 0)
+	s.RegisterMountEventFilter(expr)
+	verifyMountEventRegistration(t, s, 1)
+
+	s = newTestSubscription(t, sensor)
+	prepareForRegisterUnmountEventFilter(t, s, 0)
+	s.RegisterUnmountEventFilter(expr)
+	verifyMountEventRegistration(t, s, -1)
+}
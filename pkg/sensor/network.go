@@ -55,6 +55,38 @@ var NetworkResultEventTypes = expression.FieldTypeMap{
 	"ret": expression.ValueTypeSignedInt64,
 }
 
+// NetworkTCPEventTypes defines the field types that can be used with filters
+// on TCP connection telemetry events. Only the address fields for the address
+// family of the connection are present in any given event. Filters are
+// evaluated in the kernel on the values read from the socket, so local_port
+// is in host byte order, whereas remote_port is in network byte order. The
+// ports of NetworkTCPTelemetryEventData are both in network byte order.
+var NetworkTCPEventTypes = expression.FieldTypeMap{
+	"family":            expression.ValueTypeUnsignedInt16,
+	"local_port":        expression.ValueTypeUnsignedInt16,
+	"local_addr":        expression.ValueTypeUnsignedInt32,
+	"local_addr6_high":  expression.ValueTypeUnsignedInt64,
+	"local_addr6_low":   expression.ValueTypeUnsignedInt64,
+	"remote_port":       expression.ValueTypeUnsignedInt16,
+	"remote_addr":       expression.ValueTypeUnsignedInt32,
+	"remote_addr6_high": expression.ValueTypeUnsignedInt64,
+	"remote_addr6_low":  expression.ValueTypeUnsignedInt64,
+}
+
+// NetworkListenerEventTypes defines the field types that can be used with
+// filters on TCP listen and UDP bind telemetry events. Only the address fields
+// for the address family of the socket are present in any given event.
+// Filters are evaluated in the kernel on the values read from the socket, so
+// local_port is in host byte order. The port of
+// NetworkListenerTelemetryEventData is in network byte order.
+var NetworkListenerEventTypes = expression.FieldTypeMap{
+	"family":           expression.ValueTypeUnsignedInt16,
+	"local_port":       expression.ValueTypeUnsignedInt16,
+	"local_addr":       expression.ValueTypeUnsignedInt32,
+	"local_addr6_high": expression.ValueTypeUnsignedInt64,
+	"local_addr6_low":  expression.ValueTypeUnsignedInt64,
+}
+
 // NetworkAttemptTelemetryEventData is the data common to all network attempt
 // telemetry events.
 type NetworkAttemptTelemetryEventData struct {
@@ -272,6 +304,132 @@ func (e NetworkSendtoResultTelemetryEvent) CommonTelemetryEventData() TelemetryE
 	return e.TelemetryEventData
 }
 
+// NetworkTCPTelemetryEventData is the data common to all TCP connection
+// telemetry events. All ports are in network byte order; the local port is
+// converted from the host byte order in which the socket stores it, which is
+// the byte order used by filters (see NetworkTCPEventTypes).
+type NetworkTCPTelemetryEventData struct {
+	Local  NetworkAddressTelemetryEventData
+	Remote NetworkAddressTelemetryEventData
+}
+
+func (ted *NetworkTCPTelemetryEventData) initWithSample(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) bool {
+	family := data["family"].(uint16)
+	ted.Local.Family = family
+	ted.Remote.Family = family
+
+	// The local port is recorded from the socket in host byte order.
+	localPort := data["local_port"].(uint16)
+	localPort = localPort<<8 | localPort>>8
+
+	switch family {
+	case unix.AF_INET:
+		ted.Local.IPv4Address = data["local_addr"].(uint32)
+		ted.Local.IPv4Port = localPort
+		ted.Remote.IPv4Address = data["remote_addr"].(uint32)
+		ted.Remote.IPv4Port = data["remote_port"].(uint16)
+	case unix.AF_INET6:
+		ted.Local.IPv6AddressHigh = data["local_addr6_high"].(uint64)
+		ted.Local.IPv6AddressLow = data["local_addr6_low"].(uint64)
+		ted.Local.IPv6Port = localPort
+		ted.Remote.IPv6AddressHigh = data["remote_addr6_high"].(uint64)
+		ted.Remote.IPv6AddressLow = data["remote_addr6_low"].(uint64)
+		ted.Remote.IPv6Port = data["remote_port"].(uint16)
+	default:
+		return false
+	}
+	return true
+}
+
+// NetworkTCPConnectTelemetryEvent is a telemetry event generated by the
+// network TCP connect event source when an outbound TCP connection is
+// initiated.
+type NetworkTCPConnectTelemetryEvent struct {
+	TelemetryEventData
+	NetworkTCPTelemetryEventData
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a network TCP connect telemetry event.
+func (e NetworkTCPConnectTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// NetworkTCPAcceptTelemetryEvent is a telemetry event generated by the
+// network TCP accept event source when an inbound TCP connection is accepted.
+type NetworkTCPAcceptTelemetryEvent struct {
+	TelemetryEventData
+	NetworkTCPTelemetryEventData
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a network TCP accept telemetry event.
+func (e NetworkTCPAcceptTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// NetworkListenerTelemetryEventData is the data common to all TCP listen and
+// UDP bind telemetry events. The port is in network byte order; it is
+// converted from the host byte order in which the socket stores it, which is
+// the byte order used by filters (see NetworkListenerEventTypes).
+type NetworkListenerTelemetryEventData struct {
+	Local NetworkAddressTelemetryEventData
+}
+
+func (ted *NetworkListenerTelemetryEventData) initWithSample(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) bool {
+	// The local port is recorded in host byte order.
+	localPort := data["local_port"].(uint16)
+	localPort = localPort<<8 | localPort>>8
+
+	ted.Local.Family = data["family"].(uint16)
+	switch ted.Local.Family {
+	case unix.AF_INET:
+		ted.Local.IPv4Address = data["local_addr"].(uint32)
+		ted.Local.IPv4Port = localPort
+	case unix.AF_INET6:
+		ted.Local.IPv6AddressHigh = data["local_addr6_high"].(uint64)
+		ted.Local.IPv6AddressLow = data["local_addr6_low"].(uint64)
+		ted.Local.IPv6Port = localPort
+	default:
+		return false
+	}
+	return true
+}
+
+// NetworkTCPListenTelemetryEvent is a telemetry event generated by the
+// network TCP listen event source when a TCP socket begins listening for
+// connections.
+type NetworkTCPListenTelemetryEvent struct {
+	TelemetryEventData
+	NetworkListenerTelemetryEventData
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a network TCP listen telemetry event.
+func (e NetworkTCPListenTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// NetworkUDPBindTelemetryEvent is a telemetry event generated by the network
+// UDP bind event source when a UDP socket is bound to a local port to receive
+// datagrams.
+type NetworkUDPBindTelemetryEvent struct {
+	TelemetryEventData
+	NetworkListenerTelemetryEventData
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a network UDP bind telemetry event.
+func (e NetworkUDPBindTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
 const (
 	networkKprobeBindSymbol    = "sys_bind"
 	networkKprobeBindFetchargs = "fd=%di sa_family=+0(%si):u16 " +
@@ -296,6 +454,66 @@ const (
 		"sin_port=+2(%r8):u16 sin_addr=+4(%r8):u32 " +
 		"sun_path=+2(%r8):string " +
 		"sin6_port=+2(%r8):u16 sin6_addr_high=+8(%r8):u64 sin6_addr_low=+16(%r8):u64"
+
+	// The TCP kprobes read addresses directly from struct sock_common.
+	// The built-in offsets are:
+	//
+	//	+0	skc_daddr
+	//	+4	skc_rcv_saddr
+	//	+12	skc_dport (network byte order)
+	//	+14	skc_num (host byte order)
+	//	+16	skc_family
+	//	+56	skc_v6_daddr
+	//	+72	skc_v6_rcv_saddr
+	//
+	// The IPv4 members are at the start of the structure and have not
+	// moved in a long time, but the IPv6 members follow members whose
+	// presence and size depend on the kernel version and configuration
+	// (i.e. CONFIG_NET_NS). The offsets are resolved from the running
+	// kernel's BTF when it is available, and the IPv6 offsets may
+	// otherwise be configured (see kernelMemberOffset).
+	//
+	// When tcp_v4_connect and tcp_v6_connect are called, the remote
+	// address has not yet been stored in the socket, so it is taken from
+	// the sockaddr argument instead. The local address and port are only
+	// known at this point if the socket was explicitly bound. A connect
+	// on an IPv6 socket to an IPv4-mapped address is handed by
+	// tcp_v6_connect to tcp_v4_connect, so it is only reported by the
+	// latter (see decodeTCPConnect).
+	networkKprobeTCPv4ConnectSymbol    = "tcp_v4_connect"
+	networkKprobeTCPv4ConnectFetchargs = "family=+0(%si):u16 " +
+		"remote_port=+2(%si):u16 remote_addr=+4(%si):u32 " +
+		"local_port=+14(%di):u16 local_addr=+4(%di):u32"
+
+	networkKprobeTCPv6ConnectSymbol    = "tcp_v6_connect"
+	networkKprobeTCPv6ConnectFetchargs = "family=+0(%si):u16 " +
+		"remote_port=+2(%si):u16 remote_addr6_high=+8(%si):u64 remote_addr6_low=+16(%si):u64 " +
+		"local_port=+14(%di):u16 local_addr6_high=+72(%di):u64 local_addr6_low=+80(%di):u64"
+
+	networkKprobeTCPAcceptSymbol    = "inet_csk_accept"
+	networkKprobeTCPAcceptFetchargs = "family=+16($retval):u16 " +
+		"remote_port=+12($retval):u16 remote_addr=+0($retval):u32 " +
+		"remote_addr6_high=+56($retval):u64 remote_addr6_low=+64($retval):u64 " +
+		"local_port=+14($retval):u16 local_addr=+4($retval):u32 " +
+		"local_addr6_high=+72($retval):u64 local_addr6_low=+80($retval):u64"
+
+	// inet_csk_listen_start is called for both IPv4 and IPv6 sockets once
+	// listen(2) has been called. Sockets that were not explicitly bound
+	// are assigned a port after this point, so local_port is 0 for them.
+	networkKprobeTCPListenSymbol    = "inet_csk_listen_start"
+	networkKprobeTCPListenFetchargs = "family=+16(%di):u16 local_port=+14(%di):u16 " +
+		"local_addr=+4(%di):u32 " +
+		"local_addr6_high=+72(%di):u64 local_addr6_low=+80(%di):u64"
+
+	// udp_lib_get_port is called for both IPv4 and IPv6 sockets with the
+	// requested port, once the local address has been stored in the
+	// socket by bind(2). It is also called with port 0 when an unbound
+	// socket is used to send, which the kernel filter excludes.
+	networkKprobeUDPBindSymbol    = "udp_lib_get_port"
+	networkKprobeUDPBindFetchargs = "family=+16(%di):u16 local_port=%si:u16 " +
+		"local_addr=+4(%di):u32 " +
+		"local_addr6_high=+72(%di):u64 local_addr6_low=+80(%di):u64"
+	networkKprobeUDPBindFilter = "local_port != 0"
 )
 
 func (s *Subscription) decodeSysEnterAccept(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
@@ -410,6 +628,66 @@ func (s *Subscription) decodeSysExitSendto(sample *perf.SampleRecord, data perf.
 	return e, nil
 }
 
+func (s *Subscription) decodeTCPConnect(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	var e NetworkTCPConnectTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	if !e.NetworkTCPTelemetryEventData.initWithSample(sample, data) {
+		return nil, nil
+	}
+	// tcp_v6_connect calls tcp_v4_connect for IPv4-mapped addresses,
+	// which reports the connection with its IPv4 address.
+	if e.Remote.Family == unix.AF_INET6 &&
+		isIPv4MappedAddress(e.Remote.IPv6AddressHigh, e.Remote.IPv6AddressLow) {
+		return nil, nil
+	}
+	return e, nil
+}
+
+// isIPv4MappedAddress returns whether an IPv6 address read by a kprobe is an
+// IPv4-mapped address (::ffff:a.b.c.d). The halves of the address are read
+// as u64 in host byte order, which is little endian on all architectures
+// supported by the Sensor.
+func isIPv4MappedAddress(high, low uint64) bool {
+	return high == 0 && low&0xffffffff == 0xffff0000
+}
+
+func (s *Subscription) decodeTCPAccept(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	var e NetworkTCPAcceptTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	// inet_csk_accept returns NULL on failure, in which case nothing
+	// could be read from the socket and family will be 0.
+	if !e.NetworkTCPTelemetryEventData.initWithSample(sample, data) {
+		return nil, nil
+	}
+	return e, nil
+}
+
+func (s *Subscription) decodeTCPListen(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	var e NetworkTCPListenTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	if !e.NetworkListenerTelemetryEventData.initWithSample(sample, data) {
+		return nil, nil
+	}
+	return e, nil
+}
+
+func (s *Subscription) decodeUDPBind(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	var e NetworkUDPBindTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	if !e.NetworkListenerTelemetryEventData.initWithSample(sample, data) {
+		return nil, nil
+	}
+	return e, nil
+}
+
 // RegisterNetworkAcceptAttemptEventFilter registers a network accept attempt
 // event filter with a subscription.
 func (s *Subscription) RegisterNetworkAcceptAttemptEventFilter(expr *expression.Expression) {
@@ -451,6 +729,11 @@ func (s *Subscription) RegisterNetworkBindResultEventFilter(expr *expression.Exp
 // RegisterNetworkConnectAttemptEventFilter registers a network connect attempt
 // event filter with a subscription.
 func (s *Subscription) RegisterNetworkConnectAttemptEventFilter(expr *expression.Expression) {
+	if s.sensor.useAuditBackend {
+		s.registerAuditSyscallEventFilter(auditSyscallConnect, expr,
+			NetworkAttemptWithAddressEventTypes)
+		return
+	}
 	s.registerKprobe(networkKprobeConnectSymbol, false,
 		networkKprobeConnectFetchargs, s.decodeSysConnect,
 		expr, NetworkAttemptWithAddressEventTypes)
@@ -523,3 +806,42 @@ func (s *Subscription) RegisterNetworkSendtoResultEventFilter(expr *expression.E
 		s.decodeSysExitSendto,
 		expr, NetworkResultEventTypes)
 }
+
+// RegisterNetworkTCPConnectEventFilter registers a network TCP connect event
+// filter with a subscription.
+func (s *Subscription) RegisterNetworkTCPConnectEventFilter(expr *expression.Expression) {
+	s.registerKprobe(networkKprobeTCPv4ConnectSymbol, false,
+		networkKprobeTCPv4ConnectFetchargs, s.decodeTCPConnect,
+		expr, NetworkTCPEventTypes)
+	s.registerKprobe(networkKprobeTCPv6ConnectSymbol, false,
+		networkKprobeTCPv6ConnectFetchargs, s.decodeTCPConnect,
+		expr, NetworkTCPEventTypes)
+}
+
+// RegisterNetworkTCPAcceptEventFilter registers a network TCP accept event
+// filter with a subscription.
+func (s *Subscription) RegisterNetworkTCPAcceptEventFilter(expr *expression.Expression) {
+	s.registerKprobe(networkKprobeTCPAcceptSymbol, true,
+		networkKprobeTCPAcceptFetchargs, s.decodeTCPAccept,
+		expr, NetworkTCPEventTypes)
+}
+
+// RegisterNetworkTCPListenEventFilter registers a network TCP listen event
+// filter with a subscription.
+func (s *Subscription) RegisterNetworkTCPListenEventFilter(expr *expression.Expression) {
+	s.registerKprobe(networkKprobeTCPListenSymbol, false,
+		networkKprobeTCPListenFetchargs, s.decodeTCPListen,
+		expr, NetworkListenerEventTypes)
+}
+
+// RegisterNetworkUDPBindEventFilter registers a network UDP bind event filter
+// with a subscription.
+func (s *Subscription) RegisterNetworkUDPBindEventFilter(expr *expression.Expression) {
+	s.registerWithSensorFilter("UDP bind", expr, NetworkListenerEventTypes,
+		func() (*eventSink, error) {
+			// The kernel filter is needed to exclude implicit binds
+			return s.registerKprobe(networkKprobeUDPBindSymbol, false,
+				networkKprobeUDPBindFetchargs, s.decodeUDPBind, nil,
+				NetworkListenerEventTypes, perf.WithFilter(networkKprobeUDPBindFilter))
+		})
+}
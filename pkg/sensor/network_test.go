@@ -238,6 +238,198 @@ func TestNetworkDecoders(t *testing.T) {
 	}
 }
 
+func TestNetworkTCPDecoders(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"local_port":        uint16(0x3039),
+		"local_addr":        uint32(0x0100007f),
+		"local_addr6_high":  uint64(0x1122334455667788),
+		"local_addr6_low":   uint64(0x9900aabbccddeeff),
+		"remote_port":       uint16(0x5000),
+		"remote_addr":       uint32(0x0200007f),
+		"remote_addr6_high": uint64(0x8877665544332211),
+		"remote_addr6_low":  uint64(0xffeeddccbbaa0099),
+	}
+
+	type testCase struct {
+		decoder      perf.TraceEventDecoderFn
+		expectedType interface{}
+	}
+	testCases := []testCase{
+		testCase{
+			decoder:      s.decodeTCPConnect,
+			expectedType: NetworkTCPConnectTelemetryEvent{},
+		},
+		testCase{
+			decoder:      s.decodeTCPAccept,
+			expectedType: NetworkTCPAcceptTelemetryEvent{},
+		},
+	}
+
+	for _, tc := range testCases {
+		// Unsupported address families (i.e. a failed accept) are
+		// ignored
+		data["family"] = uint16(0)
+		i, err := tc.decoder(sample, data)
+		assert.Nil(t, i)
+		assert.NoError(t, err)
+
+		for _, family := range []uint16{unix.AF_INET, unix.AF_INET6} {
+			data["family"] = family
+
+			data["common_pid"] = int32(sensorPID)
+			i, err = tc.decoder(sample, data)
+			assert.Nil(t, i)
+			assert.NoError(t, err)
+
+			delete(data, "common_pid")
+			i, err = tc.decoder(sample, data)
+			require.NotNil(t, i)
+			require.NoError(t, err)
+
+			e, ok := i.(TelemetryEvent)
+			require.True(t, ok)
+			require.IsType(t, tc.expectedType, i)
+
+			ok = testCommonTelemetryEventData(t, sensor, e)
+			require.True(t, ok)
+
+			ted := reflect.ValueOf(i).FieldByName("NetworkTCPTelemetryEventData").Interface().(NetworkTCPTelemetryEventData)
+			assert.Equal(t, family, ted.Local.Family)
+			assert.Equal(t, family, ted.Remote.Family)
+			switch family {
+			case unix.AF_INET:
+				assert.Equal(t, data["local_addr"], ted.Local.IPv4Address)
+				assert.Equal(t, uint16(0x3930), ted.Local.IPv4Port)
+				assert.Equal(t, data["remote_addr"], ted.Remote.IPv4Address)
+				assert.Equal(t, data["remote_port"], ted.Remote.IPv4Port)
+			case unix.AF_INET6:
+				assert.Equal(t, data["local_addr6_high"], ted.Local.IPv6AddressHigh)
+				assert.Equal(t, data["local_addr6_low"], ted.Local.IPv6AddressLow)
+				assert.Equal(t, uint16(0x3930), ted.Local.IPv6Port)
+				assert.Equal(t, data["remote_addr6_high"], ted.Remote.IPv6AddressHigh)
+				assert.Equal(t, data["remote_addr6_low"], ted.Remote.IPv6AddressLow)
+				assert.Equal(t, data["remote_port"], ted.Remote.IPv6Port)
+			}
+		}
+	}
+}
+
+func TestNetworkTCPConnectIPv4Mapped(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"family":            uint16(unix.AF_INET6),
+		"local_port":        uint16(0),
+		"local_addr6_high":  uint64(0),
+		"local_addr6_low":   uint64(0),
+		"remote_port":       uint16(0x5000),
+		"remote_addr6_high": uint64(0),
+		"remote_addr6_low":  uint64(0x0100007fffff0000),
+	}
+
+	// ::ffff:127.0.0.1 is reported by tcp_v4_connect instead
+	i, err := s.decodeTCPConnect(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
+	// ::1 is not mapped
+	data["remote_addr6_low"] = uint64(0x0100000000000000)
+	i, err = s.decodeTCPConnect(sample, data)
+	assert.NotNil(t, i)
+	assert.NoError(t, err)
+
+	assert.True(t, isIPv4MappedAddress(0, 0x0100007fffff0000))
+	assert.False(t, isIPv4MappedAddress(0, 0x0100000000000000))
+	assert.False(t, isIPv4MappedAddress(0x00000000000080fe, 0x0100007fffff0000))
+}
+
+func TestNetworkListenerDecoders(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"local_port":       uint16(0x3039),
+		"local_addr":       uint32(0x0100007f),
+		"local_addr6_high": uint64(0x1122334455667788),
+		"local_addr6_low":  uint64(0x9900aabbccddeeff),
+	}
+
+	type testCase struct {
+		decoder      perf.TraceEventDecoderFn
+		expectedType interface{}
+	}
+	testCases := []testCase{
+		testCase{
+			decoder:      s.decodeTCPListen,
+			expectedType: NetworkTCPListenTelemetryEvent{},
+		},
+		testCase{
+			decoder:      s.decodeUDPBind,
+			expectedType: NetworkUDPBindTelemetryEvent{},
+		},
+	}
+
+	for _, tc := range testCases {
+		data["family"] = uint16(0)
+		i, err := tc.decoder(sample, data)
+		assert.Nil(t, i)
+		assert.NoError(t, err)
+
+		for _, family := range []uint16{unix.AF_INET, unix.AF_INET6} {
+			data["family"] = family
+
+			data["common_pid"] = int32(sensorPID)
+			i, err = tc.decoder(sample, data)
+			assert.Nil(t, i)
+			assert.NoError(t, err)
+
+			data["common_pid"] = int32(111343)
+			i, err = tc.decoder(sample, data)
+			require.NotNil(t, i)
+			require.NoError(t, err)
+			require.IsType(t, tc.expectedType, i)
+
+			e, ok := i.(TelemetryEvent)
+			require.True(t, ok)
+			ok = testCommonTelemetryEventData(t, sensor, e)
+			require.True(t, ok)
+			assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+				e.CommonTelemetryEventData().Container.ID)
+
+			ted := reflect.ValueOf(i).FieldByName("NetworkListenerTelemetryEventData").Interface().(NetworkListenerTelemetryEventData)
+			assert.Equal(t, family, ted.Local.Family)
+			switch family {
+			case unix.AF_INET:
+				assert.Equal(t, data["local_addr"], ted.Local.IPv4Address)
+				assert.Equal(t, uint16(0x3930), ted.Local.IPv4Port)
+			case unix.AF_INET6:
+				assert.Equal(t, data["local_addr6_high"], ted.Local.IPv6AddressHigh)
+				assert.Equal(t, data["local_addr6_low"], ted.Local.IPv6AddressLow)
+				assert.Equal(t, uint16(0x3930), ted.Local.IPv6Port)
+			}
+		}
+	}
+}
+
 const networkKprobeFormat = `name: ^^NAME^^
 ID: ^^ID^^
 format:
@@ -257,6 +449,39 @@ format:
 
 print fmt: "fd=%d sa_family=%d sin_port=%d sin_addr=%d sun_path=\"%s\" sin6_port=%d sin6_addr_high=%d sin6_addr_low=%d", REC->fd, REC->sa_family, REC->sin_port, REC->sin_addr, __get_str(sun_path), REC->sin6_port, REC->sin6_addr_high, REC->sin6_addr_low`
 
+const networkTCPKprobeFormat = `name: ^^NAME^^
+ID: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:u16 family;	offset:8;	size:2;	signed:0;
+	field:u16 remote_port;	offset:10;	size:2;	signed:0;
+	field:u32 remote_addr;	offset:12;	size:4;	signed:0;
+	field:u16 local_port;	offset:16;	size:2;	signed:0;
+	field:u32 local_addr;	offset:20;	size:4;	signed:0;
+
+print fmt: "family=%u remote_port=%u remote_addr=%u local_port=%u local_addr=%u", REC->family, REC->remote_port, REC->remote_addr, REC->local_port, REC->local_addr`
+
+const networkListenerKprobeFormat = `name: ^^NAME^^
+ID: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:u16 family;	offset:16;	size:2;	signed:0;
+	field:u16 local_port;	offset:18;	size:2;	signed:0;
+	field:u32 local_addr;	offset:20;	size:4;	signed:0;
+	field:u64 local_addr6_high;	offset:24;	size:8;	signed:0;
+	field:u64 local_addr6_low;	offset:32;	size:8;	signed:0;
+
+print fmt: "(%lx) family=%u local_port=%u local_addr=%u local_addr6_high=%llu local_addr6_low=%llu", REC->__probe_ip, REC->family, REC->local_port, REC->local_addr, REC->local_addr6_high, REC->local_addr6_low`
+
 func prepareForRegisterNetworkBindAttemptEventFilter(t *testing.T, s *Subscription, delta uint64) {
 	newUnitTestKprobe(t, s.sensor, delta, networkKprobeFormat)
 }
@@ -270,6 +495,23 @@ func prepareForRegisterNetworkSendtoAttemptEventFilter(t *testing.T, s *Subscrip
 	newUnitTestKprobe(t, s.sensor, delta+1, networkKprobeFormat)
 }
 
+func prepareForRegisterNetworkTCPConnectEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	newUnitTestKprobe(t, s.sensor, delta, networkTCPKprobeFormat)
+	newUnitTestKprobe(t, s.sensor, delta+1, networkTCPKprobeFormat)
+}
+
+func prepareForRegisterNetworkTCPAcceptEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	newUnitTestKprobe(t, s.sensor, delta, networkTCPKprobeFormat)
+}
+
+func prepareForRegisterNetworkTCPListenEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	newUnitTestKprobe(t, s.sensor, delta, networkListenerKprobeFormat)
+}
+
+func prepareForRegisterNetworkUDPBindEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	newUnitTestKprobe(t, s.sensor, delta, networkListenerKprobeFormat)
+}
+
 func verifyNetworkEventRegistration(t *testing.T, s *Subscription, name string, count int) {
 	if count > 0 {
 		assert.Len(t, s.eventSinks, count, name)
@@ -306,6 +548,10 @@ func TestNetworkEventRegistration(t *testing.T) {
 		testCase{"RegisterNetworkRecvfromResultEventFilter", nil, 2},
 		testCase{"RegisterNetworkSendtoAttemptEventFilter", prepareForRegisterNetworkSendtoAttemptEventFilter, 2},
 		testCase{"RegisterNetworkSendtoResultEventFilter", nil, 2},
+		testCase{"RegisterNetworkTCPConnectEventFilter", prepareForRegisterNetworkTCPConnectEventFilter, 2},
+		testCase{"RegisterNetworkTCPAcceptEventFilter", prepareForRegisterNetworkTCPAcceptEventFilter, 1},
+		testCase{"RegisterNetworkTCPListenEventFilter", prepareForRegisterNetworkTCPListenEventFilter, 1},
+		testCase{"RegisterNetworkUDPBindEventFilter", prepareForRegisterNetworkUDPBindEventFilter, 1},
 	}
 	for _, tc := range testCases {
 		s := newTestSubscription(t, sensor)
@@ -0,0 +1,161 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/capsule8/capsule8/pkg/ocihook"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
+)
+
+const ociHookReadTimeout = 5 * time.Second
+
+// ociHookListener accepts notifications from the oci-hook binary run by the
+// container runtime. Since hooks run synchronously with the runtime, these
+// notifications carry the exact init pid of the container at the moment it
+// starts, rather than whenever the runtime gets around to writing its state.
+type ociHookListener struct {
+	sensor     *Sensor
+	socketPath string
+	listener   net.Listener
+	waitGroup  sync.WaitGroup
+}
+
+// newOciHookListener creates a new listener for OCI hook notifications on the
+// specified unix socket path.
+func newOciHookListener(sensor *Sensor, socketPath string) (*ociHookListener, error) {
+	// Remove a stale socket left behind by an earlier sensor
+	os.Remove(socketPath)
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return &ociHookListener{
+		sensor:     sensor,
+		socketPath: socketPath,
+		listener:   listener,
+	}, nil
+}
+
+func (l *ociHookListener) start() {
+	l.waitGroup.Add(1)
+	go func() {
+		defer l.waitGroup.Done()
+		for {
+			conn, err := l.listener.Accept()
+			if err != nil {
+				// The listener has been closed
+				return
+			}
+			l.waitGroup.Add(1)
+			go func() {
+				defer l.waitGroup.Done()
+				l.handleConnection(conn)
+			}()
+		}
+	}()
+}
+
+func (l *ociHookListener) stop() {
+	l.listener.Close()
+	l.waitGroup.Wait()
+}
+
+func (l *ociHookListener) handleConnection(conn net.Conn) {
+	defer conn.Close()
+
+	// Capture the time now, since this is as close to the hook running as
+	// we can get.
+	sampleID := perf.SampleID{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+
+	conn.SetReadDeadline(time.Now().Add(ociHookReadTimeout))
+	msg, err := ocihook.Receive(conn)
+	if err != nil {
+		glog.V(1).Infof("Could not read OCI hook message: %s", err)
+		return
+	}
+	if err = l.processMessage(sampleID, msg); err != nil {
+		glog.V(1).Infof("Could not process OCI hook message: %s", err)
+	}
+}
+
+func (l *ociHookListener) processMessage(
+	sampleID perf.SampleID,
+	msg *ocihook.Message,
+) error {
+	containerID := msg.State.ID
+	if len(containerID) == 0 {
+		return fmt.Errorf("OCI %s hook message has no container id",
+			msg.Hook)
+	}
+
+	data := make(map[string]interface{})
+	if len(msg.Config) > 0 {
+		data["OCIConfig"] = msg.Config
+	}
+
+	containerCache := l.sensor.ContainerCache
+	containerInfo := containerCache.LookupContainer(containerID, true)
+
+	// The hook is run by the runtime managing the container, so its
+	// notifications are authoritative for whichever runtime that is.
+	containerCache.Lock()
+	runtime := containerInfo.Runtime
+	state := containerInfo.State
+	containerCache.Unlock()
+
+	switch msg.Hook {
+	case ocihook.HookPrestart:
+		data["Pid"] = msg.State.Pid
+		if state < ContainerStateCreated {
+			data["State"] = ContainerStateCreated
+		}
+	case ocihook.HookPoststart:
+		data["Pid"] = msg.State.Pid
+		data["State"] = ContainerStateRunning
+	case ocihook.HookPoststop:
+		data["State"] = ContainerStateExited
+	default:
+		return fmt.Errorf("Unknown OCI hook %q", msg.Hook)
+	}
+
+	glog.V(2).Infof("{OCI} %s hook for container %s", msg.Hook, containerID)
+	containerInfo.Update(containerCache, runtime, sampleID, data)
+
+	if msg.Hook == ocihook.HookPoststop {
+		// Containers managed by another runtime will be removed when
+		// that runtime reports them destroyed.
+		containerCache.DeleteContainer(containerID,
+			ContainerRuntimeUnknown, sampleID)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/capsule8/capsule8/pkg/ocihook"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOciHookListener(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	socketPath := filepath.Join(sensor.runtimeDir, "oci-hook.sock")
+	l, err := newOciHookListener(sensor, socketPath)
+	require.NoError(t, err)
+	l.start()
+	defer l.stop()
+
+	containerID := "0cab0cab0cab0cab0cab0cab0cab0cab0cab0cab0cab0cab0cab0cab0cab0cab"
+	msg := &ocihook.Message{
+		Hook: ocihook.HookPoststart,
+		State: ocihook.State{
+			ID:     containerID,
+			Status: "running",
+			Pid:    8888,
+		},
+		Config: `{"ociVersion":"1.0.0"}`,
+	}
+	err = ocihook.Send(socketPath, msg, time.Second)
+	require.NoError(t, err)
+
+	ok := waitForContainerState(sensor, containerID,
+		func(info ContainerInfo, ok bool) bool {
+			return ok && info.State == ContainerStateRunning
+		})
+	if assert.True(t, ok) {
+		info, _ := sensor.ContainerCache.snapshotContainer(containerID)
+		assert.Equal(t, 8888, info.Pid)
+		assert.Equal(t, msg.Config, info.OCIConfig)
+	}
+
+	// poststop removes containers not managed by another runtime
+	msg.Hook = ocihook.HookPoststop
+	err = ocihook.Send(socketPath, msg, time.Second)
+	require.NoError(t, err)
+
+	ok = waitForContainerState(sensor, containerID,
+		func(info ContainerInfo, ok bool) bool {
+			return !ok
+		})
+	assert.True(t, ok)
+}
+
+func TestOciHookProcessMessage(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	l := &ociHookListener{sensor: sensor}
+	sampleID := perf.SampleID{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+
+	err := l.processMessage(sampleID, &ocihook.Message{
+		Hook: ocihook.HookPrestart,
+	})
+	assert.Error(t, err)
+
+	containerID := "d0c4e2d0c4e2d0c4e2d0c4e2d0c4e2d0c4e2d0c4e2d0c4e2d0c4e2d0c4e2d0c4"
+	err = l.processMessage(sampleID, &ocihook.Message{
+		Hook:  "bogus",
+		State: ocihook.State{ID: containerID},
+	})
+	assert.Error(t, err)
+
+	// Docker is managing this container, so its state is updated by the
+	// hook but it is not removed by poststop.
+	info := sensor.ContainerCache.LookupContainer(containerID, true)
+	info.Update(sensor.ContainerCache, ContainerRuntimeDocker, sampleID,
+		map[string]interface{}{"State": ContainerStateCreated})
+
+	err = l.processMessage(sampleID, &ocihook.Message{
+		Hook:  ocihook.HookPrestart,
+		State: ocihook.State{ID: containerID, Pid: 1234},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ContainerStateCreated, info.State)
+	assert.Equal(t, 1234, info.Pid)
+
+	err = l.processMessage(sampleID, &ocihook.Message{
+		Hook:  ocihook.HookPoststart,
+		State: ocihook.State{ID: containerID, Pid: 1234},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ContainerStateRunning, info.State)
+
+	err = l.processMessage(sampleID, &ocihook.Message{
+		Hook:  ocihook.HookPoststop,
+		State: ocihook.State{ID: containerID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ContainerStateExited, info.State)
+	assert.NotNil(t, sensor.ContainerCache.LookupContainer(containerID, false))
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// ProcessOOMKillEventTypes defines the field types that can be used with
+// filters on process OOM kill telemetry events. Only the victim's PID is
+// reported by every kernel version.
+var ProcessOOMKillEventTypes = expression.FieldTypeMap{
+	"pid": expression.ValueTypeSignedInt32,
+}
+
+// ProcessOOMKillTelemetryEvent is a telemetry event generated by the process
+// OOM kill event source when the kernel's out of memory killer selects a
+// process to be killed. The process information is that of the victim.
+type ProcessOOMKillTelemetryEvent struct {
+	TelemetryEventData
+
+	// TriggerPID is the PID of the task whose memory allocation caused
+	// the OOM killer to run.
+	TriggerPID int32
+
+	// Memory usage of the victim in kilobytes. These are only reported
+	// by Linux 6.2 and later; they are 0 otherwise.
+	TotalVM  uint64
+	AnonRSS  uint64
+	FileRSS  uint64
+	ShmemRSS uint64
+
+	OOMScoreAdj int32
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a process OOM kill telemetry event.
+func (e ProcessOOMKillTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// oom/mark_victim fires in the context of the task that triggered the OOM
+// killer (or the cgroup memory controller) just after SIGKILL has been sent to
+// the victim. It is present in Linux 4.8 and later.
+const processOOMKillTracepoint = "oom/mark_victim"
+
+func (s *Subscription) decodeMarkVictim(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ProcessOOMKillTelemetryEvent
+
+	e.TriggerPID, _ = data["common_pid"].(int32)
+	pid := data["pid"].(int32)
+	data["__task__"] = s.sensor.ProcessCache.LookupTask(int(pid))
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+
+	e.TotalVM, _ = data["total_vm"].(uint64)
+	e.AnonRSS, _ = data["anon_rss"].(uint64)
+	e.FileRSS, _ = data["file_rss"].(uint64)
+	e.ShmemRSS, _ = data["shmem_rss"].(uint64)
+	if adj, ok := data["oom_score_adj"].(int16); ok {
+		e.OOMScoreAdj = int32(adj)
+	}
+
+	return e, nil
+}
+
+// RegisterProcessOOMKillEventFilter registers a process OOM kill event filter
+// with a subscription.
+func (s *Subscription) RegisterProcessOOMKillEventFilter(expr *expression.Expression) {
+	s.registerTracepoint(processOOMKillTracepoint, s.decodeMarkVictim,
+		expr, ProcessOOMKillEventTypes)
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeMarkVictim(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+
+	// The sensor itself is never reported as a victim
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(405),
+		"pid":        int32(sensorPID),
+	}
+	i, err := s.decodeMarkVictim(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	// Older kernels only report the victim's PID
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(405),
+		"pid":        int32(111343),
+	}
+	i, err = s.decodeMarkVictim(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessOOMKillTelemetryEvent{}, i)
+
+	e := i.(ProcessOOMKillTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, 111343, e.PID)
+	assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+		e.Container.ID)
+	assert.Equal(t, int32(405), e.TriggerPID)
+	assert.Equal(t, uint64(0), e.TotalVM)
+
+	data = perf.TraceEventSampleData{
+		"common_pid":    int32(405),
+		"pid":           int32(111343),
+		"comm":          "bash",
+		"total_vm":      uint64(4404),
+		"anon_rss":      uint64(188),
+		"file_rss":      uint64(1724),
+		"shmem_rss":     uint64(0),
+		"uid":           uint32(0),
+		"pgtables":      uint64(40),
+		"oom_score_adj": int16(-500),
+	}
+	i, err = s.decodeMarkVictim(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessOOMKillTelemetryEvent{}, i)
+
+	e = i.(ProcessOOMKillTelemetryEvent)
+	assert.Equal(t, uint64(4404), e.TotalVM)
+	assert.Equal(t, uint64(188), e.AnonRSS)
+	assert.Equal(t, uint64(1724), e.FileRSS)
+	assert.Equal(t, uint64(0), e.ShmemRSS)
+	assert.Equal(t, int32(-500), e.OOMScoreAdj)
+}
+
+func TestProcessOOMKillEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	e := expression.Equal(expression.Identifier("foo"), expression.Value("bar"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s := newTestSubscription(t, sensor)
+	s.RegisterProcessOOMKillEventFilter(expr)
+	assert.Len(t, s.status, 1)
+	assert.Len(t, s.eventSinks, 0)
+
+	s = newTestSubscription(t, sensor)
+	s.RegisterProcessOOMKillEventFilter(nil)
+	assert.Len(t, s.eventSinks, 1)
+}
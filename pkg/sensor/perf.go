@@ -53,15 +53,28 @@ func (s *Subscription) decodePerfCounterEvent(
 }
 
 // RegisterPerformanceEventFilter registers a performance event filter with a
-// subscription.
+// subscription. If cgroups is not empty, events are only counted for tasks in
+// those perf_event cgroups rather than for everything the sensor monitors.
+// Otherwise, the subscription's cgroups are used, if it has any.
 func (s *Subscription) RegisterPerformanceEventFilter(
 	attr perf.EventAttr,
 	counters []perf.CounterEventGroupMember,
+	cgroups []string,
 ) {
 	eventName := "Performance Counters"
+	options := []perf.RegisterEventOption{perf.WithEventAttr(&attr)}
+	if len(cgroups) == 0 {
+		cgroups = s.cgroups
+	}
+	if len(cgroups) > 0 {
+		options = append(options, perf.WithEventCgroups(cgroups))
+	}
+	if s.ringBufferNumPages > 0 {
+		options = append(options,
+			perf.WithEventRingBufferNumPages(s.ringBufferNumPages))
+	}
 	groupID, eventID, err := s.sensor.Monitor().RegisterCounterEventGroup(
-		eventName, counters, s.decodePerfCounterEvent,
-		perf.WithEventAttr(&attr))
+		eventName, counters, s.decodePerfCounterEvent, options...)
 	if err != nil {
 		s.logStatus(
 			fmt.Sprintf("Could not register %s performance event: %v",
@@ -79,7 +79,7 @@ func TestRegisterPerformanceEventFilter(t *testing.T) {
 
 	attr := perf.EventAttr{}
 	s := newTestSubscription(t, sensor)
-	s.RegisterPerformanceEventFilter(attr, nil)
+	s.RegisterPerformanceEventFilter(attr, nil, nil)
 	verifyRegisterPerformanceEventFilter(t, s, -1)
 
 	counters := []perf.CounterEventGroupMember{
@@ -89,6 +89,11 @@ func TestRegisterPerformanceEventFilter(t *testing.T) {
 		},
 	}
 	s = newTestSubscription(t, sensor)
-	s.RegisterPerformanceEventFilter(attr, counters)
+	s.RegisterPerformanceEventFilter(attr, counters, nil)
 	verifyRegisterPerformanceEventFilter(t, s, 1)
+
+	// The unit test sensor has no perf_event cgroupfs
+	s = newTestSubscription(t, sensor)
+	s.RegisterPerformanceEventFilter(attr, counters, []string{"docker"})
+	verifyRegisterPerformanceEventFilter(t, s, -1)
 }
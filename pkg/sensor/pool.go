@@ -0,0 +1,108 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// Translated events are made from pools of reusable protobuf messages so
+// that the Sensor does not allocate several of them for every kernel record.
+// An event is returned to the pools by releaseTelemetryEvent, which must
+// only be called once the event has been serialized and nothing refers to
+// it any more. Events that are never released are simply garbage collected,
+// so only the event stream releases them, once they have been sent and if
+// they are not held for redelivery.
+
+var (
+	telemetryEventPool = sync.Pool{
+		New: func() interface{} { return new(api.TelemetryEvent) },
+	}
+	credentialsPool = sync.Pool{
+		New: func() interface{} { return new(api.Credentials) },
+	}
+	namespacesPool = sync.Pool{
+		New: func() interface{} { return new(api.Namespaces) },
+	}
+	processEventPool = sync.Pool{
+		New: func() interface{} {
+			return &api.TelemetryEvent_Process{
+				Process: new(api.ProcessEvent),
+			}
+		},
+	}
+	containerEventPool = sync.Pool{
+		New: func() interface{} {
+			return &api.TelemetryEvent_Container{
+				Container: new(api.ContainerEvent),
+			}
+		},
+	}
+)
+
+// newProcessEvent returns a pooled process event set to pe.
+func newProcessEvent(pe api.ProcessEvent) *api.TelemetryEvent_Process {
+	e := processEventPool.Get().(*api.TelemetryEvent_Process)
+	*e.Process = pe
+	return e
+}
+
+// newContainerEvent returns a pooled container event set to ce.
+func newContainerEvent(ce api.ContainerEvent) *api.TelemetryEvent_Container {
+	e := containerEventPool.Get().(*api.TelemetryEvent_Container)
+	*e.Container = ce
+	return e
+}
+
+// releaseTelemetryEvent resets an event and the messages that it is made of
+// and returns them to their pools. The event must not be used afterwards.
+func releaseTelemetryEvent(event *api.TelemetryEvent) {
+	if event == nil {
+		return
+	}
+	if event.Credentials != nil {
+		*event.Credentials = api.Credentials{}
+		credentialsPool.Put(event.Credentials)
+	}
+	if event.Namespaces != nil {
+		*event.Namespaces = api.Namespaces{}
+		namespacesPool.Put(event.Namespaces)
+	}
+	switch e := event.Event.(type) {
+	case *api.TelemetryEvent_Process:
+		if e.Process != nil {
+			*e.Process = api.ProcessEvent{}
+			processEventPool.Put(e)
+		}
+	case *api.TelemetryEvent_Container:
+		if e.Container != nil {
+			*e.Container = api.ContainerEvent{}
+			containerEventPool.Put(e)
+		}
+	}
+	*event = api.TelemetryEvent{}
+	telemetryEventPool.Put(event)
+}
+
+// releaseReceivedTelemetryEvents releases the events of a response once it
+// has been sent.
+func releaseReceivedTelemetryEvents(events []*api.ReceivedTelemetryEvent) {
+	for _, re := range events {
+		releaseTelemetryEvent(re.Event)
+		re.Event = nil
+	}
+}
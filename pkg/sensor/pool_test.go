@@ -0,0 +1,110 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseTelemetryEvent(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	e := ProcessExitTelemetryEvent{
+		TelemetryEventData: TelemetryEventData{
+			EventID:        "exit",
+			HasCredentials: true,
+			Credentials:    Cred{UID: 1000, GID: 1000},
+			HasNamespaces:  true,
+			Namespaces:     proc.Namespaces{PID: 4026531836},
+		},
+		ExitCode: 1,
+	}
+	event := s.translateEvent(e)
+	require.IsType(t, &api.TelemetryEvent_Process{}, event.Event)
+	process := event.Event.(*api.TelemetryEvent_Process)
+	credentials, namespaces := event.Credentials, event.Namespaces
+
+	releaseTelemetryEvent(event)
+	assert.Equal(t, api.TelemetryEvent{}, *event)
+	assert.Equal(t, api.ProcessEvent{}, *process.Process)
+	assert.Equal(t, api.Credentials{}, *credentials)
+	assert.Equal(t, api.Namespaces{}, *namespaces)
+
+	// Events made from released messages are the same as new ones
+	for i := 0; i < 4; i++ {
+		c := ContainerCreatedTelemetryEvent{}
+		c.Container = ContainerInfo{ID: "abc", Name: "web"}
+		container := s.translateEvent(c)
+		assert.Equal(t, &api.TelemetryEvent{
+			ContainerId:   "abc",
+			ContainerName: "web",
+			Event: &api.TelemetryEvent_Container{
+				Container: &api.ContainerEvent{
+					Type: api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED,
+					Name: "web",
+				},
+			},
+		}, container)
+
+		exit := s.translateEvent(ProcessExitTelemetryEvent{ExitCode: 2})
+		assert.Equal(t, &api.TelemetryEvent{
+			Event: &api.TelemetryEvent_Process{
+				Process: &api.ProcessEvent{
+					Type:     api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT,
+					ExitCode: 2,
+				},
+			},
+		}, exit)
+
+		releaseReceivedTelemetryEvents([]*api.ReceivedTelemetryEvent{
+			&api.ReceivedTelemetryEvent{Event: container},
+			&api.ReceivedTelemetryEvent{Event: exit},
+		})
+	}
+
+	// Events that are not made from pools may be released too
+	releaseTelemetryEvent(nil)
+	releaseTelemetryEvent(&api.TelemetryEvent{
+		Event: &api.TelemetryEvent_Ticker{
+			Ticker: &api.TickerEvent{Seconds: 1},
+		},
+	})
+}
+
+func TestTranslateCredentialsTo(t *testing.T) {
+	c := Cred{
+		UID: 1, GID: 2, EUID: 3, EGID: 4,
+		SUID: 5, SGID: 6, FSUID: 7, FSGID: 8,
+	}
+	creds := credentialsPool.Get().(*api.Credentials)
+	allocs := testing.AllocsPerRun(10, func() {
+		translateCredentialsTo(creds, c)
+	})
+	assert.Equal(t, float64(0), allocs)
+	assert.Equal(t, *translateCredentials(c), *creds)
+	assert.Equal(t, api.Credentials{
+		Uid: 1, Gid: 2, Euid: 3, Egid: 4,
+		Suid: 5, Sgid: 6, Fsuid: 7, Fsgid: 8,
+	}, *creds)
+}
@@ -21,6 +21,7 @@ package sensor
 // the LookupTask and LookupTaskAndLeader methods.
 //
 // glog levels used:
+//   2 = executables that could not be hashed
 //   10 = cache operation level tracing for debugging
 
 import (
@@ -38,6 +39,7 @@ import (
 
 	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/logging"
 	"github.com/capsule8/capsule8/pkg/sys"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 	"github.com/capsule8/capsule8/pkg/sys/proc"
@@ -50,7 +52,9 @@ import (
 // ProcessExecEventTypes defines the field types that can be used with filters
 // on process exec telemetry events.
 var ProcessExecEventTypes = expression.FieldTypeMap{
-	"filename": expression.ValueTypeString,
+	"filename":      expression.ValueTypeString,
+	"exec_fileless": expression.ValueTypeBool,
+	"exec_sha256":   expression.ValueTypeString,
 }
 
 // ProcessExitEventTypes defines the field types that can be used with filters
@@ -60,13 +64,20 @@ var ProcessExitEventTypes = expression.FieldTypeMap{
 	"exit_status":      expression.ValueTypeUnsignedInt32,
 	"exit_signal":      expression.ValueTypeUnsignedInt32,
 	"exit_core_dumped": expression.ValueTypeBool,
+	"exit_tgid":        expression.ValueTypeSignedInt32,
+	"exit_parent_pid":  expression.ValueTypeSignedInt32,
+	"exit_parent_tgid": expression.ValueTypeSignedInt32,
 }
 
 // ProcessForkEventTypes defines the field types that can be used with filters
 // on process fork telemetry events.
 var ProcessForkEventTypes = expression.FieldTypeMap{
-	"fork_child_pid": expression.ValueTypeSignedInt32,
-	"fork_child_id":  expression.ValueTypeString,
+	"fork_child_pid":   expression.ValueTypeSignedInt32,
+	"fork_child_id":    expression.ValueTypeString,
+	"fork_child_tgid":  expression.ValueTypeSignedInt32,
+	"fork_clone_flags": expression.ValueTypeUnsignedInt64,
+	"fork_parent_pid":  expression.ValueTypeSignedInt32,
+	"fork_parent_tgid": expression.ValueTypeSignedInt32,
 }
 
 // ProcessUpdateEventTypes defines the field types that can be used with
@@ -75,6 +86,38 @@ var ProcessUpdateEventTypes = expression.FieldTypeMap{
 	"cwd": expression.ValueTypeString,
 }
 
+// ProcessCredChangeEventTypes defines the field types that can be used with
+// filters on process credential change telemetry events. The unprefixed
+// fields are the new credentials.
+var ProcessCredChangeEventTypes = expression.FieldTypeMap{
+	"uid":       expression.ValueTypeUnsignedInt32,
+	"gid":       expression.ValueTypeUnsignedInt32,
+	"euid":      expression.ValueTypeUnsignedInt32,
+	"egid":      expression.ValueTypeUnsignedInt32,
+	"suid":      expression.ValueTypeUnsignedInt32,
+	"sgid":      expression.ValueTypeUnsignedInt32,
+	"fsuid":     expression.ValueTypeUnsignedInt32,
+	"fsgid":     expression.ValueTypeUnsignedInt32,
+	"old_uid":   expression.ValueTypeUnsignedInt32,
+	"old_gid":   expression.ValueTypeUnsignedInt32,
+	"old_euid":  expression.ValueTypeUnsignedInt32,
+	"old_egid":  expression.ValueTypeUnsignedInt32,
+	"old_suid":  expression.ValueTypeUnsignedInt32,
+	"old_sgid":  expression.ValueTypeUnsignedInt32,
+	"old_fsuid": expression.ValueTypeUnsignedInt32,
+	"old_fsgid": expression.ValueTypeUnsignedInt32,
+}
+
+// ProcessCapabilityChangeEventTypes defines the field types that can be used
+// with filters on process capability change telemetry events.
+var ProcessCapabilityChangeEventTypes = expression.FieldTypeMap{
+	"cap_gained":        expression.ValueTypeUnsignedInt64,
+	"cap_permitted":     expression.ValueTypeUnsignedInt64,
+	"cap_effective":     expression.ValueTypeUnsignedInt64,
+	"old_cap_permitted": expression.ValueTypeUnsignedInt64,
+	"old_cap_effective": expression.ValueTypeUnsignedInt64,
+}
+
 // ProcessExecTelemetryEvent is a telemetry event generated by the process
 // exec event source.
 type ProcessExecTelemetryEvent struct {
@@ -82,6 +125,19 @@ type ProcessExecTelemetryEvent struct {
 
 	Filename    string
 	CommandLine []string
+
+	// Fileless is true if the program was executed from an anonymous
+	// memory file created by memfd_create(2) rather than from a file on
+	// a filesystem.
+	Fileless bool
+
+	// SHA256 is the hex encoded SHA-256 hash of the executed program, if
+	// executables are hashed and the program could be read.
+	SHA256 string
+
+	// Environment holds the environment variables of the process that
+	// the sensor is configured to capture, as "NAME=value" strings.
+	Environment []string
 }
 
 // CommonTelemetryEventData returns the telemtry event data common to all
@@ -99,6 +155,9 @@ type ProcessExitTelemetryEvent struct {
 	ExitStatus     uint32
 	ExitSignal     uint32
 	ExitCoreDumped bool
+	TGID           int32
+	ParentPID      int32
+	ParentTGID     int32
 }
 
 // CommonTelemetryEventData returns the telemtry event data common to all
@@ -114,6 +173,10 @@ type ProcessForkTelemetryEvent struct {
 
 	ChildPID       int32
 	ChildProcessID string
+	ChildTGID      int32
+	CloneFlags     uint64
+	ParentPID      int32
+	ParentTGID     int32
 }
 
 // CommonTelemetryEventData returns the telemtry event data common to all
@@ -136,6 +199,39 @@ func (e ProcessUpdateTelemetryEvent) CommonTelemetryEventData() TelemetryEventDa
 	return e.TelemetryEventData
 }
 
+// ProcessCredChangeTelemetryEvent is a telemetry event generated by the
+// process credential change event source.
+type ProcessCredChangeTelemetryEvent struct {
+	TelemetryEventData
+
+	OldCreds Cred
+	NewCreds Cred
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a process credential change telemetry event.
+func (e ProcessCredChangeTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// ProcessCapabilityChangeTelemetryEvent is a telemetry event generated by the
+// process capability change event source.
+type ProcessCapabilityChangeTelemetryEvent struct {
+	TelemetryEventData
+
+	Gained       uint64
+	Permitted    uint64
+	Effective    uint64
+	OldPermitted uint64
+	OldEffective uint64
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a process capability change telemetry event.
+func (e ProcessCapabilityChangeTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
 const taskReuseThreshold = int64(10 * time.Millisecond)
 
 const (
@@ -143,7 +239,8 @@ const (
 	commitCredsArgs    = "uid=+4(%di):u32 gid=+8(%di):u32 " +
 		"suid=+12(%di):u32 sgid=+16(%di):u32 " +
 		"euid=+20(%di):u32 egid=+24(%di):u32 " +
-		"fsuid=+28(%di):u32 fsgid=+32(%di):u32"
+		"fsuid=+28(%di):u32 fsgid=+32(%di):u32 " +
+		"cap_permitted=+48(%di):u64 cap_effective=+56(%di):u64"
 
 	// Kernel versions 3.16 through 4.16 should all work with this symbol
 	// and fetchargs. Older kernels will need to use attach_task_by_pid,
@@ -175,6 +272,15 @@ const (
 	doExecveAddress         = "do_execve"
 	doExecveArgs            = "filename=+0(%di):string "
 
+	// void would_dump(struct linux_binprm *bprm, struct file *file)
+	// is called for the program being executed and for its interpreter.
+	// name comes from file->f_path.dentry->d_name.name, which is
+	// "memfd:" followed by the name given to memfd_create(2) for
+	// anonymous memory files.
+	wouldDumpAddress = "would_dump"
+	wouldDumpArgs    = "name=+0(+40(+24(%si))):string"
+	memfdNamePrefix  = "memfd:"
+
 	doExitAddress = "do_exit"
 	doExitArgs    = "code=%di:s64"
 
@@ -185,6 +291,11 @@ const (
 	// a kretprobe that's used to trigger a lookup in /proc to get the
 	// needed data.
 	doSetFsPwd = "set_fs_pwd"
+
+	// This is called when a task's namespaces are changed by setns(2) or
+	// unshare(2). It's used to trigger a new lookup in /proc the next
+	// time that the task's namespaces are needed.
+	switchTaskNamespacesAddress = "switch_task_namespaces"
 )
 
 // Cred contains task credential information
@@ -269,6 +380,13 @@ type Task struct {
 	// leader process's PID will be the same as its TGID.
 	TGID int
 
+	// NamespacePID and NamespaceTGID are the PID and TGID of the task as
+	// seen inside its own PID namespace, which differ from PID and TGID
+	// for tasks in containers. They are zero until they are known. Use
+	// LookupTaskNamespacePIDs to get them.
+	NamespacePID  int
+	NamespaceTGID int
+
 	// Command is the kernel's comm field, which is initialized to the
 	// first 15 characters of the basename of the executable being run.
 	// It is also set via pthread_setname_np(3) and prctl(2) PR_SET_NAME.
@@ -276,6 +394,11 @@ type Task struct {
 	// NUL byte).
 	Command string
 
+	// Executable is the path of the program that the process is running.
+	// It is the filename passed to execve() for programs exec'd while the
+	// sensor is running, so it may be relative to the process's CWD.
+	Executable string
+
 	// CommandLine is the command-line used when the process was exec'd via
 	// execve(). It is composed of the first 6 elements of argv. It may
 	// not be complete if argv contained more than 6 elements.
@@ -286,6 +409,12 @@ type Task struct {
 	// commit_creds().
 	Creds *Cred
 
+	// CapPermitted and CapEffective are the task's permitted and
+	// effective capability sets. Like Creds, these are kept up-to-date
+	// via a kprobe on commit_creds().
+	CapPermitted uint64
+	CapEffective uint64
+
 	// ContainerID is the ID of the container to which the task belongs,
 	// if any.
 	ContainerID string
@@ -312,10 +441,30 @@ type Task struct {
 	// Parent() to get the parent of a container.
 	parent *Task
 
+	// namespaces is used internally to cache the task's namespaces, which
+	// are read from /proc the first time they are needed and again after
+	// they may have changed. Use LookupTaskNamespaces to get them.
+	namespaces *proc.Namespaces
+
 	// pendingClone is used internally for tracking information about a
 	// task clone executed by the clone(2) system call. In kernels >= 3.9
 	// this is not necessary
 	pendingClone *cloneEvent
+
+	// pendingExecCommandLine is used internally to hold the command-line
+	// captured on entry to execve() until the exec is known to have
+	// succeeded via the sched_process_exec tracepoint.
+	pendingExecCommandLine []string
+
+	// pendingExecFileless is used internally to note that the program
+	// being executed or its interpreter is an anonymous memory file
+	// until the exec is known to have succeeded.
+	pendingExecFileless bool
+
+	// pendingExecCallchain is used internally to hold the callchain
+	// captured on entry to execve(), if any, until the exec is known to
+	// have succeeded.
+	pendingExecCallchain []uint64
 }
 
 var rootTask = Task{}
@@ -346,7 +495,8 @@ func (t *Task) Parent() *Task {
 	if t.parent == nil {
 		// t.parent may not be set yet if events arrive out of order.
 		if t.TGID != 0 {
-			glog.Fatalf("Internal error: t.PID == %d && t.TGID == %d && t.parent == nil", t.PID, t.TGID)
+			glog.Errorf("Task has no parent: %s", logging.Fields(
+				"pid", t.PID, "tgid", t.TGID))
 		}
 		// We don't know anything about the parent, so return this task
 		// as the parent. Don't store the reference, though.
@@ -379,8 +529,10 @@ func (t *Task) Update(
 			continue
 		}
 		if !reflect.TypeOf(v).AssignableTo(f.Type) {
-			glog.Fatalf("Cannot assign %v to %s %v",
-				v, f.Name, f.Type)
+			glog.Errorf("Cannot update task: %s", logging.Fields(
+				"pid", t.PID, "field", f.Name, "type", f.Type,
+				"value", v))
+			continue
 		}
 
 		// Assume field types that are not compareable always change
@@ -428,6 +580,21 @@ func (t *Task) Update(
 
 type taskCache interface {
 	LookupTask(int) *Task
+
+	// PeekTask returns the cached task for a PID without creating one,
+	// or nil if there is none. The task may have exited.
+	PeekTask(int) *Task
+
+	// Len returns the number of cached tasks whose PIDs may not be
+	// reused yet.
+	Len() int
+}
+
+// isReusable returns true if a cached task has exited long enough ago that
+// its PID may have been reused.
+func (t *Task) isReusable() bool {
+	return t.ExitTime != 0 &&
+		sys.CurrentMonotonicRaw()-t.ExitTime >= taskReuseThreshold
 }
 
 type arrayTaskCache struct {
@@ -470,6 +637,26 @@ func (c *arrayTaskCache) LookupTask(pid int) *Task {
 	return t
 }
 
+func (c *arrayTaskCache) PeekTask(pid int) *Task {
+	if pid <= 0 || pid > len(c.entries) {
+		return nil
+	}
+	return (*Task)(atomic.LoadPointer(
+		(*unsafe.Pointer)(unsafe.Pointer(&c.entries[pid-1]))))
+}
+
+func (c *arrayTaskCache) Len() int {
+	n := 0
+	for i := range c.entries {
+		t := (*Task)(atomic.LoadPointer(
+			(*unsafe.Pointer)(unsafe.Pointer(&c.entries[i]))))
+		if t != nil && !t.isReusable() {
+			n++
+		}
+	}
+	return n
+}
+
 type mapTaskCache struct {
 	sync.Mutex
 	entries map[int]*Task
@@ -492,8 +679,7 @@ func (c *mapTaskCache) LookupTask(pid int) *Task {
 
 	c.Lock()
 	t, ok := c.entries[pid]
-	if !ok || (t.ExitTime != 0 &&
-		sys.CurrentMonotonicRaw()-t.ExitTime >= taskReuseThreshold) {
+	if !ok || t.isReusable() {
 		t = newTask(pid)
 		c.entries[pid] = t
 	}
@@ -502,6 +688,25 @@ func (c *mapTaskCache) LookupTask(pid int) *Task {
 	return t
 }
 
+func (c *mapTaskCache) PeekTask(pid int) *Task {
+	c.Lock()
+	t := c.entries[pid]
+	c.Unlock()
+	return t
+}
+
+func (c *mapTaskCache) Len() int {
+	n := 0
+	c.Lock()
+	for _, t := range c.entries {
+		if !t.isReusable() {
+			n++
+		}
+	}
+	c.Unlock()
+	return n
+}
+
 // ProcessInfoCache is an object that caches process information. It is
 // maintained automatically via an existing sensor object.
 type ProcessInfoCache struct {
@@ -512,10 +717,21 @@ type ProcessInfoCache struct {
 	// These are external event IDs registered with the sensor's event
 	// monitor instance. The cache will enqueue these events as appropriate
 	// as the cache is updated.
-	ProcessExecEventID   uint64
-	ProcessForkEventID   uint64
-	ProcessExitEventID   uint64
-	ProcessUpdateEventID uint64
+	ProcessExecEventID       uint64
+	ProcessForkEventID       uint64
+	ProcessExitEventID       uint64
+	ProcessUpdateEventID     uint64
+	ProcessCredChangeEventID uint64
+	ProcessCapChangeEventID  uint64
+
+	// execTracepoint is true if the sched_process_exec tracepoint is
+	// used to generate process exec events. Otherwise they are
+	// generated on entry to execve(), whether it succeeds or not.
+	execTracepoint bool
+
+	// execHashes caches the hashes of executed programs. It is nil if
+	// executables are not hashed.
+	execHashes *executableHashCache
 
 	startLock  sync.Mutex
 	startQueue []scannerDeferredAction
@@ -531,6 +747,11 @@ func NewProcessInfoCache(sensor *Sensor) *ProcessInfoCache {
 	cache := &ProcessInfoCache{
 		sensor: sensor,
 	}
+	if config.Sensor.HashExecutables {
+		cache.execHashes = newExecutableHashCache(
+			config.Sensor.ExecutableHashCacheSize,
+			config.Sensor.ExecutableHashMaxSize)
+	}
 
 	maxPID := sensor.ProcFS.MaxPID()
 	if maxPID > config.Sensor.ProcessInfoCacheSize {
@@ -552,6 +773,12 @@ func NewProcessInfoCache(sensor *Sensor) *ProcessInfoCache {
 	cache.ProcessUpdateEventID = monitor.RegisterExternalEvent(
 		"PROCESS_UPDATE", cache.decodeProcessUpdateEvent)
 
+	cache.ProcessCredChangeEventID = monitor.RegisterExternalEvent(
+		"PROCESS_CRED_CHANGE", cache.decodeProcessCredChangeEvent)
+
+	cache.ProcessCapChangeEventID = monitor.RegisterExternalEvent(
+		"PROCESS_CAPABILITY_CHANGE", cache.decodeProcessCapChangeEvent)
+
 	// Register with the sensor's global event monitor...
 	eventName := "task/task_newtask"
 	_, err := monitor.RegisterTracepoint(eventName,
@@ -564,15 +791,8 @@ func NewProcessInfoCache(sensor *Sensor) *ProcessInfoCache {
 			perf.WithTracingEventName("dofork"),
 			perf.WithEventEnabled())
 		if err != nil {
-			eventName = "_" + eventName
-			_, err = sensor.RegisterKprobe(eventName, false,
-				doForkFetchargs, cache.decodeDoFork,
-				perf.WithTracingEventName("_dofork"),
-				perf.WithEventEnabled())
-			if err != nil {
-				glog.Fatalf("Couldn't register kprobe %s: %s",
-					eventName, err)
-			}
+			glog.Fatalf("Couldn't register kprobe %s: %s",
+				eventName, err)
 		}
 
 		eventName = "sched/sched_process_fork"
@@ -606,6 +826,16 @@ func NewProcessInfoCache(sensor *Sensor) *ProcessInfoCache {
 		perf.WithTracingEventName("setfspwd"),
 		perf.WithEventEnabled())
 
+	// Attach kprobe on switch_task_namespaces to track namespace changes
+	_, err = sensor.RegisterKprobe(switchTaskNamespacesAddress, false,
+		"", cache.decodeSwitchTaskNamespaces,
+		perf.WithTracingEventName("setns"),
+		perf.WithEventEnabled())
+	if err != nil {
+		glog.Infof("Couldn't register kprobe %s: %s",
+			switchTaskNamespacesAddress, err)
+	}
+
 	// Attach a probe to capture exec events in the kernel. There are three
 	// possibilities, in descending order of preference:
 	//      do_execveat_common (Linux 3.19+)
@@ -624,6 +854,10 @@ func NewProcessInfoCache(sensor *Sensor) *ProcessInfoCache {
 	}
 	compatMode := sensor.IsKernelSymbolAvailable("compat_sys_execve") ||
 		sensor.IsKernelSymbolAvailable("__ia32_compat_sys_execve")
+	execveOptions := []perf.RegisterEventOption{perf.WithEventEnabled()}
+	if sensor.execStackTraces {
+		execveOptions = append(execveOptions, perf.WithEventCallchain())
+	}
 	for i, probe := range execveProbes {
 		var execveArgs string
 		if compatMode {
@@ -636,8 +870,8 @@ func NewProcessInfoCache(sensor *Sensor) *ProcessInfoCache {
 		_, err = sensor.RegisterKprobe(probe.address, false,
 			probe.args+execveArgs,
 			cache.decodeExecve,
-			perf.WithTracingEventName(eventName),
-			perf.WithEventEnabled())
+			append(execveOptions,
+				perf.WithTracingEventName(eventName))...)
 		if err == nil {
 			break
 		}
@@ -648,6 +882,47 @@ func NewProcessInfoCache(sensor *Sensor) *ProcessInfoCache {
 			doExecveAddress)
 	}
 
+	// sched_process_exec (Linux 3.4+) only fires once an exec has
+	// succeeded and reports the filename that was actually executed.
+	eventName = "sched/sched_process_exec"
+	_, err = monitor.RegisterTracepoint(eventName,
+		cache.decodeSchedProcessExec,
+		perf.WithEventEnabled())
+	if err == nil {
+		cache.execTracepoint = true
+	} else {
+		glog.Infof("Couldn't register tracepoint %s: %s", eventName, err)
+	}
+
+	// Fileless execs can only be recognized if the outcome of the exec
+	// is reported by sched_process_exec.
+	if cache.execTracepoint {
+		_, err = sensor.RegisterKprobe(wouldDumpAddress, false,
+			wouldDumpArgs, cache.decodeWouldDump,
+			perf.WithTracingEventName("execfile"),
+			perf.WithEventEnabled())
+		if err != nil {
+			glog.Infof("Couldn't register kprobe %s: %s",
+				wouldDumpAddress, err)
+		}
+
+		// sched_process_exec never consumes what was captured on
+		// entry to an exec that fails.
+		for _, name := range []string{
+			"syscalls/sys_exit_execve",
+			"syscalls/sys_exit_execveat",
+		} {
+			_, err = monitor.RegisterTracepoint(name,
+				cache.decodeExecveFailed,
+				perf.WithFilter("ret < 0"),
+				perf.WithEventEnabled())
+			if err != nil {
+				glog.Infof("Couldn't register tracepoint %s: %s",
+					name, err)
+			}
+		}
+	}
+
 	if err = cache.installCgroupMonitor(); err != nil {
 		glog.Fatalf("Could not install cgroup monitoring: %v", err)
 	}
@@ -691,12 +966,16 @@ func (pc *ProcessInfoCache) Start() {
 
 func (pc *ProcessInfoCache) cacheTaskFromProc(tgid, pid int) error {
 	var s struct {
-		Name string   `Name`
-		PID  int      `Pid`
-		PPID int      `PPid`
-		TGID int      `Tgid`
-		UID  []uint32 `Uid`
-		GID  []uint32 `Gid`
+		Name   string   `Name`
+		PID    int      `Pid`
+		PPID   int      `PPid`
+		TGID   int      `Tgid`
+		UID    []uint32 `Uid`
+		GID    []uint32 `Gid`
+		CapPrm string   `CapPrm`
+		CapEff string   `CapEff`
+		NSpid  []int    `NSpid`
+		NStgid []int    `NStgid`
 	}
 	procFS := pc.sensor.ProcFS
 	err := procFS.ReadTaskStatus(tgid, pid, &s)
@@ -707,9 +986,14 @@ func (pc *ProcessInfoCache) cacheTaskFromProc(tgid, pid int) error {
 
 	t := pc.cache.LookupTask(s.PID)
 	t.TGID = s.TGID
+	t.NamespacePID, t.NamespaceTGID = namespacePIDs(s.PID, s.TGID,
+		s.NSpid, s.NStgid)
 	t.Command = s.Name
 	t.Creds = newCredentials(s.UID[0], s.UID[1], s.UID[2], s.UID[3],
 		s.GID[0], s.GID[1], s.GID[2], s.GID[3])
+	// Capability sets are reported in hex without a leading 0x
+	t.CapPermitted, _ = strconv.ParseUint(s.CapPrm, 16, 64)
+	t.CapEffective, _ = strconv.ParseUint(s.CapEff, 16, 64)
 	t.StartTime, err = procFS.TaskStartTime(tgid, pid)
 	if err != nil {
 		t.StartTime = sys.CurrentMonotonicRaw()
@@ -725,6 +1009,7 @@ func (pc *ProcessInfoCache) cacheTaskFromProc(tgid, pid int) error {
 		}
 		// Failures here can be ignored; the task has completed while
 		// we've been processing it.
+		t.Executable, _ = procFS.TaskExecutable(t.TGID, t.PID)
 		t.CommandLine, _ = procFS.ProcessCommandLine(t.TGID)
 		t.CWD, _ = procFS.TaskCWD(t.TGID, t.PID)
 		t.ContainerID, _ = procFS.ProcessContainerID(tgid)
@@ -808,6 +1093,92 @@ func (pc *ProcessInfoCache) LookupTask(pid int) *Task {
 	return pc.cache.LookupTask(pid)
 }
 
+// LookupKnownTask finds the task information for the given PID if the cache
+// has any. Unlike LookupTask, it does not create an entry for an unknown PID,
+// so it returns nil for PIDs that the kernel has not reported.
+func (pc *ProcessInfoCache) LookupKnownTask(pid int) *Task {
+	t := pc.cache.PeekTask(pid)
+	if t == nil || t.ProcessID == "" || t.isReusable() {
+		return nil
+	}
+	return t
+}
+
+// LookupTaskLineage returns the task with the given PID and unique process
+// ID followed by its ancestor processes, nearest first, up to depth tasks in
+// all. The task may have exited, since events are often translated after
+// the task that caused them has exited. The return is nil if the task is not
+// known.
+func (pc *ProcessInfoCache) LookupTaskLineage(
+	pid int,
+	processID string,
+	depth int,
+) []*Task {
+	t := pc.cache.PeekTask(pid)
+	if t == nil || processID == "" || t.ProcessID != processID {
+		return nil
+	}
+
+	var lineage []*Task
+	for t != nil && t != &rootTask && len(lineage) < depth {
+		lineage = append(lineage, t)
+		// The parent of a thread is its thread group leader, which is
+		// skipped, since it is the same process.
+		if t = t.parent; t != nil && t.PID == lineage[len(lineage)-1].TGID {
+			t = t.parent
+		}
+	}
+	return lineage
+}
+
+// LookupTaskNamespacePIDs returns the PID and TGID of a task as seen inside
+// its own PID namespace. They are read from /proc the first time that they
+// are needed, so zeros are returned if the task has exited before then.
+func (pc *ProcessInfoCache) LookupTaskNamespacePIDs(t *Task) (int, int) {
+	if t.NamespacePID != 0 || t.TGID == 0 || t.ExitTime != 0 {
+		return t.NamespacePID, t.NamespaceTGID
+	}
+
+	var s struct {
+		NSpid  []int `NSpid`
+		NStgid []int `NStgid`
+	}
+	if pc.sensor.ProcFS.ReadTaskStatus(t.TGID, t.PID, &s) != nil {
+		return 0, 0
+	}
+	t.NamespacePID, t.NamespaceTGID = namespacePIDs(t.PID, t.TGID,
+		s.NSpid, s.NStgid)
+	return t.NamespacePID, t.NamespaceTGID
+}
+
+// LookupTaskNamespaces returns the namespaces of a task. They are read from
+// /proc the first time that they are needed and again after they may have
+// changed, so false is returned if the task has exited before then.
+func (pc *ProcessInfoCache) LookupTaskNamespaces(t *Task) (proc.Namespaces, bool) {
+	if ns := t.namespaces; ns != nil {
+		return *ns, true
+	}
+	if t.TGID == 0 || t.ExitTime != 0 {
+		return proc.Namespaces{}, false
+	}
+	ns, err := pc.sensor.ProcFS.TaskNamespaces(t.TGID, t.PID)
+	if err != nil {
+		return proc.Namespaces{}, false
+	}
+	t.namespaces = &ns
+	return ns, true
+}
+
+// namespacePIDs returns the innermost PID and TGID reported by the NSpid and
+// NStgid fields of a task's status. If they are not reported (Linux < 4.1),
+// the PID and TGID are assumed to be the same in every namespace.
+func namespacePIDs(pid, tgid int, nsPID, nsTGID []int) (int, int) {
+	if len(nsPID) == 0 || len(nsTGID) == 0 {
+		return pid, tgid
+	}
+	return nsPID[len(nsPID)-1], nsTGID[len(nsTGID)-1]
+}
+
 // LookupTaskAndLeader finds the task information for both a given PID and the
 // thread group leader.
 func (pc *ProcessInfoCache) LookupTaskAndLeader(pid int) (*Task, *Task) {
@@ -836,6 +1207,35 @@ func (pc *ProcessInfoCache) LookupTaskContainerInfo(t *Task) *ContainerInfo {
 	return nil
 }
 
+// taskContainerID returns the ID of the container that a task belongs to, or
+// an empty string if it is not in a container.
+func (pc *ProcessInfoCache) taskContainerID(t *Task) string {
+	if i := pc.LookupTaskContainerInfo(t.Leader()); i != nil {
+		return i.ID
+	}
+	return t.Leader().ContainerID
+}
+
+// executableHash returns the hash of the program being run by a process, or
+// an empty string if executables are not hashed or it cannot be read.
+func (pc *ProcessInfoCache) executableHash(pid int) string {
+	if pc.execHashes == nil {
+		return ""
+	}
+	f, err := pc.sensor.ProcFS.OpenTaskExecutable(pid, pid)
+	if err != nil {
+		glog.V(2).Infof("Couldn't open executable of pid %d: %v", pid, err)
+		return ""
+	}
+	defer f.Close()
+	h, err := pc.execHashes.hash(f)
+	if err != nil {
+		glog.V(2).Infof("Couldn't hash executable of pid %d: %v", pid, err)
+		return ""
+	}
+	return h
+}
+
 func (pc *ProcessInfoCache) maybeDeferAction(f func()) {
 	if !pc.started {
 		pc.startLock.Lock()
@@ -860,6 +1260,9 @@ func (pc *ProcessInfoCache) decodeProcessExecEvent(
 	}
 	e.Filename = data["filename"].(string)
 	e.CommandLine = data["exec_command_line"].([]string)
+	e.Fileless = data["exec_fileless"].(bool)
+	e.SHA256 = data["exec_sha256"].(string)
+	e.Environment, _ = data["exec_environment"].([]string)
 	return e, nil
 }
 
@@ -875,6 +1278,9 @@ func (pc *ProcessInfoCache) decodeProcessExitEvent(
 	e.ExitStatus = data["exit_status"].(uint32)
 	e.ExitSignal = data["exit_signal"].(uint32)
 	e.ExitCoreDumped = data["exit_core_dumped"].(bool)
+	e.TGID = data["exit_tgid"].(int32)
+	e.ParentPID = data["exit_parent_pid"].(int32)
+	e.ParentTGID = data["exit_parent_tgid"].(int32)
 	return e, nil
 }
 
@@ -888,6 +1294,10 @@ func (pc *ProcessInfoCache) decodeProcessForkEvent(
 	}
 	e.ChildPID = data["fork_child_pid"].(int32)
 	e.ChildProcessID = data["fork_child_id"].(string)
+	e.ChildTGID = data["fork_child_tgid"].(int32)
+	e.CloneFlags = data["fork_clone_flags"].(uint64)
+	e.ParentPID = data["fork_parent_pid"].(int32)
+	e.ParentTGID = data["fork_parent_tgid"].(int32)
 	return e, nil
 }
 
@@ -903,6 +1313,35 @@ func (pc *ProcessInfoCache) decodeProcessUpdateEvent(
 	return e, nil
 }
 
+func (pc *ProcessInfoCache) decodeProcessCredChangeEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ProcessCredChangeTelemetryEvent
+	if !e.InitWithSample(pc.sensor, sample, data) {
+		return nil, nil
+	}
+	e.OldCreds = data["__old_creds__"].(Cred)
+	e.NewCreds = data["__new_creds__"].(Cred)
+	return e, nil
+}
+
+func (pc *ProcessInfoCache) decodeProcessCapChangeEvent(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e ProcessCapabilityChangeTelemetryEvent
+	if !e.InitWithSample(pc.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Gained = data["cap_gained"].(uint64)
+	e.Permitted = data["cap_permitted"].(uint64)
+	e.Effective = data["cap_effective"].(uint64)
+	e.OldPermitted = data["old_cap_permitted"].(uint64)
+	e.OldEffective = data["old_cap_effective"].(uint64)
+	return e, nil
+}
+
 func sampleIDFromSample(sample *perf.SampleRecord) perf.SampleID {
 	return perf.SampleID{
 		Time: sample.Time,
@@ -919,8 +1358,10 @@ func (pc *ProcessInfoCache) handleSysClone(
 	sample *perf.SampleRecord,
 ) {
 	changes := map[string]interface{}{
-		"Command": childComm,
-		"Creds":   parentTask.Creds,
+		"Command":      childComm,
+		"Creds":        parentTask.Creds,
+		"CapPermitted": parentTask.CapPermitted,
+		"CapEffective": parentTask.CapEffective,
 	}
 
 	if (cloneFlags & CLONE_THREAD) != 0 {
@@ -928,6 +1369,8 @@ func (pc *ProcessInfoCache) handleSysClone(
 	} else {
 		// This is a new thread group leader, tgid is the new pid
 		changes["TGID"] = childTask.PID
+		changes["Executable"] = parentLeader.Executable
+		changes["CommandLine"] = parentLeader.CommandLine
 		changes["ContainerID"] = parentLeader.ContainerID
 		changes["ContainerInfo"] = parentLeader.ContainerInfo
 	}
@@ -938,9 +1381,13 @@ func (pc *ProcessInfoCache) handleSysClone(
 	childTask.Update(changes, sample.Time, pc.sensor.ProcFS)
 
 	eventData := map[string]interface{}{
-		"__task__":       parentTask,
-		"fork_child_pid": int32(childTask.PID),
-		"fork_child_id":  childTask.ProcessID,
+		"__task__":         parentTask,
+		"fork_child_pid":   int32(childTask.PID),
+		"fork_child_id":    childTask.ProcessID,
+		"fork_child_tgid":  int32(childTask.TGID),
+		"fork_clone_flags": cloneFlags,
+		"fork_parent_pid":  int32(parentTask.PID),
+		"fork_parent_tgid": int32(parentTask.TGID),
 	}
 	pc.sensor.Monitor().EnqueueExternalSample(
 		pc.ProcessForkEventID,
@@ -1002,6 +1449,18 @@ func (pc *ProcessInfoCache) decodeDoExit(
 		t := pc.LookupTask(pid)
 		t.Update(changes, sample.Time, pc.sensor.ProcFS)
 		eventData["__task__"] = t
+		eventData["exit_tgid"] = int32(t.TGID)
+
+		// Parent() can't be used here, because the parent of the
+		// thread group may still be unknown.
+		if parent := t.Leader().parent; parent != nil {
+			eventData["exit_parent_pid"] = int32(parent.PID)
+			eventData["exit_parent_tgid"] = int32(parent.TGID)
+		} else {
+			eventData["exit_parent_pid"] = int32(0)
+			eventData["exit_parent_tgid"] = int32(0)
+		}
+
 		pc.sensor.Monitor().EnqueueExternalSample(
 			pc.ProcessExitEventID,
 			sampleIDFromSample(sample),
@@ -1016,28 +1475,97 @@ func (pc *ProcessInfoCache) decodeCommitCreds(
 	data perf.TraceEventSampleData,
 ) (interface{}, error) {
 	pid := int(data["common_pid"].(int32))
+	newCreds := &Cred{
+		UID:   data["uid"].(uint32),
+		GID:   data["gid"].(uint32),
+		EUID:  data["euid"].(uint32),
+		EGID:  data["egid"].(uint32),
+		SUID:  data["suid"].(uint32),
+		SGID:  data["sgid"].(uint32),
+		FSUID: data["fsuid"].(uint32),
+		FSGID: data["fsgid"].(uint32),
+	}
+
+	capPermitted := data["cap_permitted"].(uint64)
+	capEffective := data["cap_effective"].(uint64)
 
 	changes := map[string]interface{}{
-		"Creds": &Cred{
-			UID:   data["uid"].(uint32),
-			GID:   data["gid"].(uint32),
-			EUID:  data["euid"].(uint32),
-			EGID:  data["egid"].(uint32),
-			SUID:  data["suid"].(uint32),
-			SGID:  data["sgid"].(uint32),
-			FSUID: data["fsuid"].(uint32),
-			FSGID: data["fsgid"].(uint32),
-		},
+		"Creds":        newCreds,
+		"CapPermitted": capPermitted,
+		"CapEffective": capEffective,
 	}
 
 	pc.maybeDeferAction(func() {
 		t := pc.LookupTask(pid)
+		oldCreds := t.Creds
+		oldPermitted, oldEffective := t.CapPermitted, t.CapEffective
 		t.Update(changes, sample.Time, pc.sensor.ProcFS)
+
+		// Entering a user namespace commits new credentials.
+		t.namespaces = nil
+
+		// commit_creds() is called often without any change, e.g. on
+		// every exec. If the old credentials are not known, there is
+		// nothing meaningful to report either.
+		if oldCreds == nil {
+			return
+		}
+		if *oldCreds != *newCreds {
+			pc.enqueueCredChangeEvent(t, *oldCreds, *newCreds, sample)
+		}
+		gained := (capPermitted &^ oldPermitted) |
+			(capEffective &^ oldEffective)
+		if gained != 0 {
+			eventData := map[string]interface{}{
+				"__task__":          t,
+				"cap_gained":        gained,
+				"cap_permitted":     capPermitted,
+				"cap_effective":     capEffective,
+				"old_cap_permitted": oldPermitted,
+				"old_cap_effective": oldEffective,
+			}
+			pc.sensor.Monitor().EnqueueExternalSample(
+				pc.ProcessCapChangeEventID,
+				sampleIDFromSample(sample),
+				eventData)
+		}
 	})
 
 	return nil, nil
 }
 
+func (pc *ProcessInfoCache) enqueueCredChangeEvent(
+	t *Task,
+	oldCreds, newCreds Cred,
+	sample *perf.SampleRecord,
+) {
+	eventData := map[string]interface{}{
+		"__task__":      t,
+		"__old_creds__": oldCreds,
+		"__new_creds__": newCreds,
+		"uid":           newCreds.UID,
+		"gid":           newCreds.GID,
+		"euid":          newCreds.EUID,
+		"egid":          newCreds.EGID,
+		"suid":          newCreds.SUID,
+		"sgid":          newCreds.SGID,
+		"fsuid":         newCreds.FSUID,
+		"fsgid":         newCreds.FSGID,
+		"old_uid":       oldCreds.UID,
+		"old_gid":       oldCreds.GID,
+		"old_euid":      oldCreds.EUID,
+		"old_egid":      oldCreds.EGID,
+		"old_suid":      oldCreds.SUID,
+		"old_sgid":      oldCreds.SGID,
+		"old_fsuid":     oldCreds.FSUID,
+		"old_fsgid":     oldCreds.FSGID,
+	}
+	pc.sensor.Monitor().EnqueueExternalSample(
+		pc.ProcessCredChangeEventID,
+		sampleIDFromSample(sample),
+		eventData)
+}
+
 func (pc *ProcessInfoCache) decodeDoSetFsPwd(
 	sample *perf.SampleRecord,
 	data perf.TraceEventSampleData,
@@ -1069,6 +1597,22 @@ func (pc *ProcessInfoCache) decodeDoSetFsPwd(
 	return nil, nil
 }
 
+// decodeSwitchTaskNamespaces notes that a task's namespaces may have
+// changed, so that they are read again the next time they are needed.
+func (pc *ProcessInfoCache) decodeSwitchTaskNamespaces(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	pid := int(data["common_pid"].(int32))
+
+	pc.maybeDeferAction(func() {
+		t := pc.LookupTask(pid)
+		t.namespaces = nil
+	})
+
+	return nil, nil
+}
+
 // decodeDoExecve decodes sys_execve() and sys_execveat() events to obtain the
 // command-line for the process.
 func (pc *ProcessInfoCache) decodeExecve(
@@ -1085,13 +1629,27 @@ func (pc *ProcessInfoCache) decodeExecve(
 		commandLine = append(commandLine, s)
 	}
 
+	if pc.execTracepoint {
+		pc.maybeDeferAction(func() {
+			t := pc.LookupTask(pid)
+			t.pendingExecCommandLine = commandLine
+			t.pendingExecCallchain = sample.IPs
+		})
+		return nil, nil
+	}
+
 	changes := map[string]interface{}{
+		"Executable":  data["filename"].(string),
 		"CommandLine": commandLine,
 	}
 
 	eventData := map[string]interface{}{
 		"filename":          data["filename"].(string),
 		"exec_command_line": commandLine,
+		"exec_fileless":     false,
+		"exec_sha256":       "",
+		"exec_environment":  []string(nil),
+		"__callchain__":     sample.IPs,
 	}
 
 	pc.maybeDeferAction(func() {
@@ -1107,6 +1665,96 @@ func (pc *ProcessInfoCache) decodeExecve(
 	return nil, nil
 }
 
+// decodeSchedProcessExec decodes sched_process_exec events, which are only
+// generated for successful execs. The command-line is the one captured on
+// entry to execve() by the execing task, which may not be the thread group
+// leader whose PID the new program takes over.
+func (pc *ProcessInfoCache) decodeSchedProcessExec(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	pid := int(data["pid"].(int32))
+	oldPid := int(data["old_pid"].(int32))
+	filename := data["filename"].(string)
+
+	pc.maybeDeferAction(func() {
+		oldTask := pc.LookupTask(oldPid)
+		commandLine := oldTask.pendingExecCommandLine
+		oldTask.pendingExecCommandLine = nil
+		callchain := oldTask.pendingExecCallchain
+		oldTask.pendingExecCallchain = nil
+		if commandLine == nil {
+			commandLine = []string{}
+		}
+
+		// The interpreter is checked before the execing task takes
+		// over the thread group leader's PID and the program after.
+		t := pc.LookupTask(pid)
+		fileless := oldTask.pendingExecFileless || t.pendingExecFileless
+		oldTask.pendingExecFileless = false
+		t.pendingExecFileless = false
+		changes := map[string]interface{}{
+			"Executable":  filename,
+			"CommandLine": commandLine,
+		}
+		t.Update(changes, sample.Time, pc.sensor.ProcFS)
+
+		eventData := map[string]interface{}{
+			"__task__":          t,
+			"filename":          filename,
+			"exec_command_line": commandLine,
+			"exec_fileless":     fileless,
+			"exec_sha256":       pc.executableHash(pid),
+			"exec_environment":  pc.execEnvironment(pid),
+			"__callchain__":     callchain,
+		}
+		pc.sensor.Monitor().EnqueueExternalSample(
+			pc.ProcessExecEventID,
+			sampleIDFromSample(sample),
+			eventData)
+	})
+
+	return nil, nil
+}
+
+// decodeExecveFailed decodes failed returns from execve() and execveat() to
+// discard what was captured on entry, so that it is not reported for the
+// task's next exec.
+func (pc *ProcessInfoCache) decodeExecveFailed(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	pid := int(data["common_pid"].(int32))
+
+	pc.maybeDeferAction(func() {
+		t := pc.LookupTask(pid)
+		t.pendingExecCommandLine = nil
+		t.pendingExecCallchain = nil
+		t.pendingExecFileless = false
+	})
+
+	return nil, nil
+}
+
+// decodeWouldDump decodes would_dump() events to learn whether a program is
+// being executed from an anonymous memory file.
+func (pc *ProcessInfoCache) decodeWouldDump(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	if !strings.HasPrefix(data["name"].(string), memfdNamePrefix) {
+		return nil, nil
+	}
+
+	pid := int(data["common_pid"].(int32))
+	pc.maybeDeferAction(func() {
+		t := pc.LookupTask(pid)
+		t.pendingExecFileless = true
+	})
+
+	return nil, nil
+}
+
 func (pc *ProcessInfoCache) decodeDoFork(
 	sample *perf.SampleRecord,
 	data perf.TraceEventSampleData,
@@ -1168,11 +1816,10 @@ func (pc *ProcessInfoCache) decodeCgroupProcsWrite(
 	sample *perf.SampleRecord,
 	data perf.TraceEventSampleData,
 ) (interface{}, error) {
-	// We're looking for container IDs, which are normally hex encoded
-	// sha256 hashes. Basically just ensure that we've got exactly 64
-	// characters. That should be good enough for now.
-	containerID := data["container_id"].(string)
-	if len(containerID) != 64 {
+	// The name of the cgroup that the task is entering names the
+	// container for the container runtimes that we know about.
+	containerID := proc.ContainerIDFromCgroupPath(data["container_id"].(string))
+	if containerID == "" {
 		return nil, nil
 	}
 
@@ -1257,6 +1904,11 @@ func (s *Subscription) registerProcessEventFilter(
 // RegisterProcessExecEventFilter registers a process exec event filter with a
 // subscription.
 func (s *Subscription) RegisterProcessExecEventFilter(expr *expression.Expression) {
+	if s.sensor.useAuditBackend {
+		s.registerAuditSyscallEventFilter(auditSyscallExec, expr,
+			ProcessExecEventTypes)
+		return
+	}
 	s.registerProcessEventFilter(
 		s.sensor.ProcessCache.ProcessExecEventID,
 		expr, ProcessExecEventTypes)
@@ -1285,3 +1937,19 @@ func (s *Subscription) RegisterProcessUpdateEventFilter(expr *expression.Express
 		s.sensor.ProcessCache.ProcessUpdateEventID,
 		expr, ProcessUpdateEventTypes)
 }
+
+// RegisterProcessCredChangeEventFilter registers a process credential change
+// event filter with a subscription.
+func (s *Subscription) RegisterProcessCredChangeEventFilter(expr *expression.Expression) {
+	s.registerProcessEventFilter(
+		s.sensor.ProcessCache.ProcessCredChangeEventID,
+		expr, ProcessCredChangeEventTypes)
+}
+
+// RegisterProcessCapabilityChangeEventFilter registers a process capability
+// change event filter with a subscription.
+func (s *Subscription) RegisterProcessCapabilityChangeEventFilter(expr *expression.Expression) {
+	s.registerProcessEventFilter(
+		s.sensor.ProcessCache.ProcessCapChangeEventID,
+		expr, ProcessCapabilityChangeEventTypes)
+}
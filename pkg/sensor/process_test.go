@@ -24,6 +24,7 @@ import (
 	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/capsule8/capsule8/pkg/sys"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
 	"github.com/capsule8/capsule8/pkg/sys/proc/procfs"
 
 	"github.com/stretchr/testify/assert"
@@ -77,11 +78,20 @@ func TestTask(t *testing.T) {
 	assert.Equal(t, parentTask, task.Leader())
 
 	// Handling out of order events ... if task.parent is nil, the return
-	// should be task. In this case, TGID must also be nil, but we can't
-	// test for that, because glog.Fatal cannot be caught
+	// should be task. TGID should also be 0, but if it isn't, the error
+	// is logged rather than being fatal.
 	task.parent = nil
 	task.TGID = 0
 	assert.Equal(t, task, task.Parent())
+	task.TGID = parentTask.PID
+	assert.Equal(t, task, task.Parent())
+
+	// Changes of the wrong type are ignored rather than being fatal
+	task = newTask(1467)
+	assert.False(t, task.Update(map[string]interface{}{
+		"TGID": "1467",
+	}, 0, procFS))
+	assert.Zero(t, task.TGID)
 
 	task = &Task{}
 	changes := map[string]interface{}{
@@ -146,6 +156,10 @@ func testTaskCacheImplementation(t *testing.T, cache taskCache) {
 		task := cache.LookupTask(i + 1)
 		assert.NotEqual(t, tasks[i], task)
 	}
+
+	assert.Nil(t, cache.PeekTask(int(testCacheSize)+1))
+	task := cache.LookupTask(1)
+	assert.Exactly(t, task, cache.PeekTask(1))
 }
 
 func TestArrayTaskCache(t *testing.T) {
@@ -196,6 +210,49 @@ func TestLookupTaskContainerInfo(t *testing.T) {
 	assert.Equal(t, ci, info)
 }
 
+func TestLookupTaskLineage(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	cache := sensor.ProcessCache
+	newProcess := func(pid int, parent *Task, command string) *Task {
+		task := cache.LookupTask(pid)
+		task.parent = parent
+		changes := map[string]interface{}{
+			"TGID":       pid,
+			"Command":    command,
+			"Executable": "/usr/bin/" + command,
+		}
+		task.Update(changes, uint64(sys.CurrentMonotonicRaw()), sensor.ProcFS)
+		return task
+	}
+	java := newProcess(3001, &rootTask, "java")
+	bash := newProcess(3002, java, "bash")
+	curl := newProcess(3003, bash, "curl")
+
+	// Threads are followed by their thread group's parent
+	thread := cache.LookupTask(3004)
+	thread.parent = curl
+	changes := map[string]interface{}{
+		"TGID": curl.PID,
+	}
+	thread.Update(changes, uint64(sys.CurrentMonotonicRaw()), sensor.ProcFS)
+
+	assert.Equal(t, []*Task{curl, bash, java},
+		cache.LookupTaskLineage(curl.PID, curl.ProcessID, 8))
+	assert.Equal(t, []*Task{curl, bash},
+		cache.LookupTaskLineage(curl.PID, curl.ProcessID, 2))
+	assert.Equal(t, []*Task{thread, bash, java},
+		cache.LookupTaskLineage(thread.PID, thread.ProcessID, 8))
+
+	// Tasks that have exited are still known by their process ID
+	curl.ExitTime = sys.CurrentMonotonicRaw() - taskReuseThreshold
+	assert.Equal(t, []*Task{curl, bash, java},
+		cache.LookupTaskLineage(curl.PID, curl.ProcessID, 8))
+	assert.Nil(t, cache.LookupTaskLineage(curl.PID, "reused", 8))
+	assert.Nil(t, cache.LookupTaskLineage(3005, "", 8))
+}
+
 func TestProcessInfoCache(t *testing.T) {
 	sensor := newUnitTestSensor(t)
 	defer sensor.Stop()
@@ -222,16 +279,35 @@ func TestProcessDecoders(t *testing.T) {
 	data := perf.TraceEventSampleData{
 		"filename":          "/bin/bash",
 		"exec_command_line": []string{"bash", "-l"},
+		"exec_fileless":     true,
+		"exec_sha256":       "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		"exec_environment":  []string{"PATH=/usr/bin:/bin"},
 
 		"code":             int32(234987),
 		"exit_status":      uint32(495678),
 		"exit_signal":      uint32(11),
 		"exit_core_dumped": true,
+		"exit_tgid":        int32(8734),
+		"exit_parent_pid":  int32(8730),
+		"exit_parent_tgid": int32(8729),
 
-		"fork_child_pid": int32(9485),
-		"fork_child_id":  "some string that is a child process id",
+		"fork_child_pid":   int32(9485),
+		"fork_child_id":    "some string that is a child process id",
+		"fork_child_tgid":  int32(9485),
+		"fork_clone_flags": uint64(CLONE_VFORK | CLONE_VM),
+		"fork_parent_pid":  int32(9480),
+		"fork_parent_tgid": int32(9480),
 
 		"cwd": "/var/run/capsule8",
+
+		"cap_gained":        uint64(1 << CAP_SYS_ADMIN),
+		"cap_permitted":     uint64(1<<CAP_SYS_ADMIN | 1<<CAP_NET_RAW),
+		"cap_effective":     uint64(1<<CAP_SYS_ADMIN | 1<<CAP_NET_RAW),
+		"old_cap_permitted": uint64(1 << CAP_NET_RAW),
+		"old_cap_effective": uint64(1 << CAP_NET_RAW),
+
+		"__old_creds__": Cred{1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000},
+		"__new_creds__": Cred{0, 0, 0, 0, 0, 0, 0, 0},
 	}
 
 	type testCase struct {
@@ -246,6 +322,9 @@ func TestProcessDecoders(t *testing.T) {
 			fieldChecks: map[string]string{
 				"filename":          "Filename",
 				"exec_command_line": "CommandLine",
+				"exec_fileless":     "Fileless",
+				"exec_sha256":       "SHA256",
+				"exec_environment":  "Environment",
 			},
 		},
 		testCase{
@@ -256,14 +335,40 @@ func TestProcessDecoders(t *testing.T) {
 				"exit_status":      "ExitStatus",
 				"exit_signal":      "ExitSignal",
 				"exit_core_dumped": "ExitCoreDumped",
+				"exit_tgid":        "TGID",
+				"exit_parent_pid":  "ParentPID",
+				"exit_parent_tgid": "ParentTGID",
 			},
 		},
 		testCase{
 			decoder:      sensor.ProcessCache.decodeProcessForkEvent,
 			expectedType: ProcessForkTelemetryEvent{},
 			fieldChecks: map[string]string{
-				"fork_child_pid": "ChildPID",
-				"fork_child_id":  "ChildProcessID",
+				"fork_child_pid":   "ChildPID",
+				"fork_child_id":    "ChildProcessID",
+				"fork_child_tgid":  "ChildTGID",
+				"fork_clone_flags": "CloneFlags",
+				"fork_parent_pid":  "ParentPID",
+				"fork_parent_tgid": "ParentTGID",
+			},
+		},
+		testCase{
+			decoder:      sensor.ProcessCache.decodeProcessCredChangeEvent,
+			expectedType: ProcessCredChangeTelemetryEvent{},
+			fieldChecks: map[string]string{
+				"__old_creds__": "OldCreds",
+				"__new_creds__": "NewCreds",
+			},
+		},
+		testCase{
+			decoder:      sensor.ProcessCache.decodeProcessCapChangeEvent,
+			expectedType: ProcessCapabilityChangeTelemetryEvent{},
+			fieldChecks: map[string]string{
+				"cap_gained":        "Gained",
+				"cap_permitted":     "Permitted",
+				"cap_effective":     "Effective",
+				"old_cap_permitted": "OldPermitted",
+				"old_cap_effective": "OldEffective",
 			},
 		},
 		testCase{
@@ -337,11 +442,12 @@ func TestHandleSysClone(t *testing.T) {
 	cache := sensor.ProcessCache
 	parentTask := cache.LookupTask(88888)
 	changes := map[string]interface{}{
-		"TGID":      parentTask.PID,
-		"Command":   "/bin/bash",
-		"StartTime": int64(sys.CurrentMonotonicRaw()),
-		"CWD":       sensor.runtimeDir,
-		"Creds":     &Cred{500, 500, 500, 500, 500, 500, 500, 500},
+		"TGID":       parentTask.PID,
+		"Command":    "/bin/bash",
+		"Executable": "/bin/bash",
+		"StartTime":  int64(sys.CurrentMonotonicRaw()),
+		"CWD":        sensor.runtimeDir,
+		"Creds":      &Cred{500, 500, 500, 500, 500, 500, 500, 500},
 	}
 	parentTask.Update(changes, uint64(sys.CurrentMonotonicRaw()), sensor.ProcFS)
 	parentLeader := parentTask.Leader()
@@ -364,6 +470,10 @@ func TestHandleSysClone(t *testing.T) {
 		// Make sure the fork event contains the right information
 		assert.Equal(t, int32(childTask.PID), forkEvent.ChildPID)
 		assert.Equal(t, childTask.ProcessID, forkEvent.ChildProcessID)
+		assert.Equal(t, int32(parentTask.TGID), forkEvent.ChildTGID)
+		assert.Equal(t, uint64(CLONE_THREAD), forkEvent.CloneFlags)
+		assert.Equal(t, int32(parentTask.PID), forkEvent.ParentPID)
+		assert.Equal(t, int32(parentTask.TGID), forkEvent.ParentTGID)
 		forkEvent = nil
 	}
 	lock.Unlock()
@@ -391,6 +501,10 @@ func TestHandleSysClone(t *testing.T) {
 		// Make sure the fork event contains the right information
 		assert.Equal(t, int32(aNewTask.PID), forkEvent.ChildPID)
 		assert.Equal(t, aNewTask.ProcessID, forkEvent.ChildProcessID)
+		assert.Equal(t, int32(aNewTask.PID), forkEvent.ChildTGID)
+		assert.Equal(t, uint64(0), forkEvent.CloneFlags)
+		assert.Equal(t, int32(childTask.PID), forkEvent.ParentPID)
+		assert.Equal(t, int32(parentTask.TGID), forkEvent.ParentTGID)
 		forkEvent = nil
 	}
 	lock.Unlock()
@@ -399,6 +513,7 @@ func TestHandleSysClone(t *testing.T) {
 	assert.Equal(t, childComm, aNewTask.Command)
 	assert.Equal(t, childTask.Creds, aNewTask.Creds)
 	assert.Equal(t, parentLeader, aNewTask.parent)
+	assert.Equal(t, "/bin/bash", aNewTask.Executable)
 }
 
 func TestDecodeNewTask(t *testing.T) {
@@ -506,6 +621,9 @@ func TestDecodeDoExit(t *testing.T) {
 			assert.Equal(t, tc.exitStatus, exitEvent.ExitStatus)
 			assert.Equal(t, tc.exitSignal, exitEvent.ExitSignal)
 			assert.Equal(t, tc.exitCoreDumped, exitEvent.ExitCoreDumped)
+			assert.Equal(t, int32(410), exitEvent.TGID)
+			assert.Equal(t, int32(0), exitEvent.ParentPID)
+			assert.Equal(t, int32(0), exitEvent.ParentTGID)
 		}
 		lock.Unlock()
 	}
@@ -535,12 +653,86 @@ func TestDecodeCommitCreds(t *testing.T) {
 		"sgid":       expected.SGID,
 		"fsuid":      expected.FSUID,
 		"fsgid":      expected.FSGID,
+
+		"cap_permitted": uint64(0x3fffffffff),
+		"cap_effective": uint64(0x3fffffffff),
 	}
 	i, err := sensor.ProcessCache.decodeCommitCreds(sample, data)
 	assert.Nil(t, i)
 	assert.NoError(t, err)
 
 	assert.Equal(t, expected, task.Creds)
+	assert.Equal(t, uint64(0x3fffffffff), task.CapPermitted)
+	assert.Equal(t, uint64(0x3fffffffff), task.CapEffective)
+}
+
+func TestProcessCredChangeEvent(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	var (
+		credEvents []ProcessCredChangeTelemetryEvent
+		lock       sync.Mutex
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newTestSubscription(t, sensor)
+	s.RegisterProcessCredChangeEventFilter(nil)
+	status, err := s.Run(ctx, func(event TelemetryEvent) {
+		if e, ok := event.(ProcessCredChangeTelemetryEvent); ok {
+			lock.Lock()
+			credEvents = append(credEvents, e)
+			lock.Unlock()
+		}
+	})
+	assert.Len(t, status, 0)
+	require.NoError(t, err)
+
+	oldCreds := Cred{1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000}
+	newCreds := Cred{1000, 1000, 0, 1000, 0, 1000, 0, 1000}
+
+	task := sensor.ProcessCache.LookupTask(410)
+	task.TGID = task.PID
+	task.Creds = &oldCreds
+
+	// The samples are decoded by the EventMonitor, which also decodes
+	// the cred change events made from them and updates the task
+	commitCredsEventID := sensor.Monitor().RegisterExternalEvent(
+		"commit_creds test", sensor.ProcessCache.decodeCommitCreds)
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(410),
+		"uid":        newCreds.UID,
+		"gid":        newCreds.GID,
+		"euid":       newCreds.EUID,
+		"egid":       newCreds.EGID,
+		"suid":       newCreds.SUID,
+		"sgid":       newCreds.SGID,
+		"fsuid":      newCreds.FSUID,
+		"fsgid":      newCreds.FSGID,
+
+		"cap_permitted": uint64(0),
+		"cap_effective": uint64(0),
+	}
+
+	// The second commit doesn't change anything and is not reported
+	for n := 0; n < 2; n++ {
+		err = sensor.Monitor().EnqueueExternalSample(commitCredsEventID,
+			perf.SampleID{
+				Time: uint64(sys.CurrentMonotonicRaw()),
+				PID:  410,
+				TID:  410,
+			}, data)
+		require.NoError(t, err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	lock.Lock()
+	if assert.Len(t, credEvents, 1) {
+		assert.Equal(t, oldCreds, credEvents[0].OldCreds)
+		assert.Equal(t, newCreds, credEvents[0].NewCreds)
+	}
+	lock.Unlock()
 }
 
 func TestDecodeDoSetFsPwd(t *testing.T) {
@@ -570,6 +762,8 @@ func TestDecodeExecve(t *testing.T) {
 	sensor := newUnitTestSensor(t)
 	defer sensor.Stop()
 
+	require.True(t, sensor.ProcessCache.execTracepoint)
+
 	var (
 		execEvent *ProcessExecTelemetryEvent
 		lock      sync.Mutex
@@ -598,7 +792,7 @@ func TestDecodeExecve(t *testing.T) {
 	}
 	data := perf.TraceEventSampleData{
 		"common_pid": int32(410),
-		"filename":   "/bin/ls",
+		"filename":   "ls",
 		"argv0":      "ls",
 		"argv1":      "-F",
 		"argv2":      "/etc",
@@ -610,16 +804,138 @@ func TestDecodeExecve(t *testing.T) {
 	assert.Nil(t, i)
 	assert.NoError(t, err)
 
+	// No event is generated until the exec succeeds
+	time.Sleep(100 * time.Millisecond)
+	lock.Lock()
+	assert.Nil(t, execEvent)
+	lock.Unlock()
+
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(410),
+		"filename":   "/bin/ls",
+		"pid":        int32(410),
+		"old_pid":    int32(410),
+	}
+	i, err = sensor.ProcessCache.decodeSchedProcessExec(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
 	time.Sleep(100 * time.Millisecond)
 	lock.Lock()
 	if assert.NotNil(t, execEvent) {
-		assert.Equal(t, data["filename"], execEvent.Filename)
+		assert.Equal(t, "/bin/ls", execEvent.Filename)
 
 		commandLine := []string{"ls", "-F", "/etc"}
 		assert.Equal(t, commandLine, execEvent.CommandLine)
 
 		task = sensor.ProcessCache.LookupTask(410)
 		assert.Equal(t, commandLine, task.CommandLine)
+		assert.Equal(t, "/bin/ls", task.Executable)
+		assert.Nil(t, task.pendingExecCommandLine)
+		assert.False(t, execEvent.Fileless)
+	}
+	execEvent = nil
+	lock.Unlock()
+
+	// Programs executed from anonymous memory files are flagged
+	for _, name := range []string{"ld-2.27.so", "memfd:payload"} {
+		data = perf.TraceEventSampleData{
+			"common_pid": int32(410),
+			"name":       name,
+		}
+		i, err = sensor.ProcessCache.decodeWouldDump(sample, data)
+		assert.Nil(t, i)
+		assert.NoError(t, err)
+	}
+
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(410),
+		"filename":   "/dev/fd/3",
+		"pid":        int32(410),
+		"old_pid":    int32(410),
+	}
+	i, err = sensor.ProcessCache.decodeSchedProcessExec(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	lock.Lock()
+	if assert.NotNil(t, execEvent) {
+		assert.Equal(t, "/dev/fd/3", execEvent.Filename)
+		assert.True(t, execEvent.Fileless)
+		assert.Empty(t, execEvent.SHA256)
+
+		task = sensor.ProcessCache.LookupTask(410)
+		assert.False(t, task.pendingExecFileless)
+	}
+	execEvent = nil
+	lock.Unlock()
+
+	// A failed exec's command line is not reported for the next exec
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(410),
+		"filename":   "/bin/nope",
+		"argv0":      "nope",
+		"argv1":      "",
+		"argv2":      "",
+		"argv3":      "",
+		"argv4":      "",
+		"argv5":      "",
+	}
+	i, err = sensor.ProcessCache.decodeExecve(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(410),
+		"ret":        int64(-2),
+	}
+	i, err = sensor.ProcessCache.decodeExecveFailed(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(410),
+		"filename":   "/bin/true",
+		"pid":        int32(410),
+		"old_pid":    int32(410),
+	}
+	i, err = sensor.ProcessCache.decodeSchedProcessExec(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	lock.Lock()
+	if assert.NotNil(t, execEvent) {
+		assert.Equal(t, "/bin/true", execEvent.Filename)
+		assert.Empty(t, execEvent.CommandLine)
+	}
+	execEvent = nil
+	lock.Unlock()
+
+	// Without the tracepoint, events are generated by the kprobe
+	sensor.ProcessCache.execTracepoint = false
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(410),
+		"filename":   "/bin/cat",
+		"argv0":      "cat",
+		"argv1":      "/etc/passwd",
+		"argv2":      "",
+		"argv3":      "",
+		"argv4":      "",
+		"argv5":      "",
+	}
+	i, err = sensor.ProcessCache.decodeExecve(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	lock.Lock()
+	if assert.NotNil(t, execEvent) {
+		assert.Equal(t, "/bin/cat", execEvent.Filename)
+		assert.Equal(t, []string{"cat", "/etc/passwd"},
+			execEvent.CommandLine)
+		assert.Equal(t, "/bin/cat",
+			sensor.ProcessCache.LookupTask(410).Executable)
 	}
 	lock.Unlock()
 }
@@ -826,6 +1142,16 @@ func TestDecodeCgroupProcsWrite(t *testing.T) {
 		assert.Equal(t, validContainerID, task.ContainerID)
 	}
 
+	// Scope units created by the systemd cgroup driver name containers
+	childTask.ContainerID = ""
+	data = perf.TraceEventSampleData{
+		"container_id": "cri-containerd-" + validContainerID + ".scope",
+		"pid":          uint64(4120),
+	}
+	i, err = sensor.ProcessCache.decodeCgroupProcsWrite(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+	assert.Equal(t, validContainerID, childTask.ContainerID)
 }
 
 var commAsBytes = []interface{}{
@@ -882,3 +1208,185 @@ func TestProcessEventRegistration(t *testing.T) {
 		verifyProcessEventRegistration(t, s, 1)
 	}
 }
+
+func TestProcessCapabilityChangeEvent(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	var (
+		capEvents []ProcessCapabilityChangeTelemetryEvent
+		lock      sync.Mutex
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newTestSubscription(t, sensor)
+	s.RegisterProcessCapabilityChangeEventFilter(nil)
+	status, err := s.Run(ctx, func(event TelemetryEvent) {
+		if e, ok := event.(ProcessCapabilityChangeTelemetryEvent); ok {
+			lock.Lock()
+			capEvents = append(capEvents, e)
+			lock.Unlock()
+		}
+	})
+	assert.Len(t, status, 0)
+	require.NoError(t, err)
+
+	creds := Cred{1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000}
+	task := sensor.ProcessCache.LookupTask(410)
+	task.TGID = task.PID
+	task.Creds = &creds
+	task.CapPermitted = 1 << CAP_NET_RAW
+	task.CapEffective = 1 << CAP_NET_RAW
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+		Pid:  410,
+		Tid:  410,
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(410),
+		"uid":        creds.UID,
+		"gid":        creds.GID,
+		"euid":       creds.EUID,
+		"egid":       creds.EGID,
+		"suid":       creds.SUID,
+		"sgid":       creds.SGID,
+		"fsuid":      creds.FSUID,
+		"fsgid":      creds.FSGID,
+	}
+
+	type testCase struct {
+		permitted, effective uint64
+		gained               uint64
+	}
+	testCases := []testCase{
+		// Gaining CAP_SYS_ADMIN is reported
+		testCase{
+			permitted: 1<<CAP_SYS_ADMIN | 1<<CAP_NET_RAW,
+			effective: 1<<CAP_SYS_ADMIN | 1<<CAP_NET_RAW,
+			gained:    1 << CAP_SYS_ADMIN,
+		},
+		// Dropping capabilities is not
+		testCase{
+			permitted: 1 << CAP_SYS_ADMIN,
+			effective: 0,
+		},
+		// Raising a permitted capability into the effective set is
+		testCase{
+			permitted: 1 << CAP_SYS_ADMIN,
+			effective: 1 << CAP_SYS_ADMIN,
+			gained:    1 << CAP_SYS_ADMIN,
+		},
+	}
+	for _, tc := range testCases {
+		capEvents = nil
+		data["cap_permitted"] = tc.permitted
+		data["cap_effective"] = tc.effective
+		i, err := sensor.ProcessCache.decodeCommitCreds(sample, data)
+		assert.Nil(t, i)
+		assert.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+		lock.Lock()
+		if tc.gained == 0 {
+			assert.Len(t, capEvents, 0)
+		} else if assert.Len(t, capEvents, 1) {
+			assert.Equal(t, tc.gained, capEvents[0].Gained)
+			assert.Equal(t, tc.permitted, capEvents[0].Permitted)
+			assert.Equal(t, tc.effective, capEvents[0].Effective)
+		}
+		lock.Unlock()
+	}
+}
+
+func TestCacheTaskFromProcCapabilities(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	err := sensor.ProcessCache.cacheTaskFromProc(111343, 111343)
+	require.NoError(t, err)
+
+	task := sensor.ProcessCache.LookupTask(111343)
+	assert.Equal(t, uint64(0xa80425fb), task.CapPermitted)
+	assert.Equal(t, uint64(0xa80425fb), task.CapEffective)
+	assert.Equal(t, 1, task.NamespacePID)
+	assert.Equal(t, 1, task.NamespaceTGID)
+}
+
+func TestLookupTaskNamespaces(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	cache := sensor.ProcessCache
+	task := cache.LookupTask(111343)
+	task.TGID = task.PID
+	expected := proc.Namespaces{
+		Net:  4026532299,
+		Mnt:  4026532296,
+		PID:  4026532298,
+		User: 4026531837,
+	}
+	ns, ok := cache.LookupTaskNamespaces(task)
+	assert.True(t, ok)
+	assert.Equal(t, expected, ns)
+
+	// The namespaces are kept until they may have changed
+	task.namespaces.Net = 1
+	ns, _ = cache.LookupTaskNamespaces(task)
+	assert.Equal(t, uint64(1), ns.Net)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(111343),
+	}
+	i, err := cache.decodeSwitchTaskNamespaces(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+	assert.Nil(t, task.namespaces)
+	ns, _ = cache.LookupTaskNamespaces(task)
+	assert.Equal(t, expected, ns)
+
+	// Tasks that are not in /proc are not known
+	task = cache.LookupTask(3322)
+	task.TGID = task.PID
+	_, ok = cache.LookupTaskNamespaces(task)
+	assert.False(t, ok)
+}
+
+func TestLookupTaskNamespacePIDs(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	cache := sensor.ProcessCache
+
+	// Tasks in containers are numbered from 1 in their namespace
+	task := cache.LookupTask(111343)
+	task.TGID = task.PID
+	nsPID, nsTGID := cache.LookupTaskNamespacePIDs(task)
+	assert.Equal(t, 1, nsPID)
+	assert.Equal(t, 1, nsTGID)
+
+	// The result is kept
+	task.NamespacePID, task.NamespaceTGID = 7, 7
+	nsPID, nsTGID = cache.LookupTaskNamespacePIDs(task)
+	assert.Equal(t, 7, nsPID)
+	assert.Equal(t, 7, nsTGID)
+
+	// Tasks that are not in /proc are not known
+	task = cache.LookupTask(3322)
+	task.TGID = task.PID
+	nsPID, nsTGID = cache.LookupTaskNamespacePIDs(task)
+	assert.Equal(t, 0, nsPID)
+	assert.Equal(t, 0, nsTGID)
+
+	// Kernels without NSpid have only one PID for each task
+	nsPID, nsTGID = namespacePIDs(405, 404, nil, nil)
+	assert.Equal(t, 405, nsPID)
+	assert.Equal(t, 404, nsTGID)
+	nsPID, nsTGID = namespacePIDs(405, 404, []int{405, 3}, []int{404, 2})
+	assert.Equal(t, 3, nsPID)
+	assert.Equal(t, 2, nsTGID)
+}
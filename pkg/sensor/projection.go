@@ -0,0 +1,232 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// Projections name fields of the translated api.TelemetryEvent by their
+// protobuf field paths in the same way as subscription expressions, but any
+// field may be named, including messages, repeated fields, and bytes. Fields
+// of repeated messages cannot be named individually.
+
+var (
+	projectionFieldsOnce sync.Once
+	projectionFields     map[string]bool
+)
+
+// Fields that identify an event and are always sent
+var projectionRequiredFields = map[string]bool{
+	"id":                     true,
+	"sensor_id":              true,
+	"sensor_sequence_number": true,
+	"sensor_monotime_nanos":  true,
+}
+
+func addProjectionFields(
+	fields map[string]bool,
+	prefix string,
+	t reflect.Type,
+	visiting map[reflect.Type]bool,
+) {
+	// Messages that contain themselves cannot be flattened
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if _, ok := f.Tag.Lookup("protobuf_oneof"); ok {
+			for _, w := range oneofWrappers(t) {
+				addProjectionFields(fields, prefix, w, visiting)
+			}
+			continue
+		}
+		name := protobufFieldName(f)
+		if name == "" {
+			continue
+		}
+		fields[prefix+"."+name] = true
+		if f.Type.Kind() == reflect.Ptr && f.Type.Elem().Kind() == reflect.Struct {
+			addProjectionFields(fields, prefix+"."+name,
+				f.Type.Elem(), visiting)
+		}
+	}
+}
+
+func initProjectionFields() {
+	projectionFields = make(map[string]bool)
+	addProjectionFields(projectionFields, eventExpressionRoot,
+		reflect.TypeOf(api.TelemetryEvent{}),
+		make(map[reflect.Type]bool))
+}
+
+// projectionTree holds the fields named by a projection. A field that is
+// present with no children names the entire field.
+type projectionTree map[string]projectionTree
+
+func (t projectionTree) add(names []string) {
+	for i, name := range names {
+		child, ok := t[name]
+		if ok && child == nil {
+			// The entire field is already named
+			return
+		}
+		if i == len(names)-1 {
+			t[name] = nil
+			return
+		}
+		if child == nil {
+			child = make(projectionTree)
+			t[name] = child
+		}
+		t = child
+	}
+}
+
+// clearField removes the value of a field. The members of oneofs are
+// emptied rather than removed so that the kind of event is kept.
+func clearField(v reflect.Value, oneof bool) {
+	if oneof && v.Kind() == reflect.Ptr {
+		if !v.IsNil() {
+			v.Elem().Set(reflect.Zero(v.Type().Elem()))
+		}
+		return
+	}
+	v.Set(reflect.Zero(v.Type()))
+}
+
+// visitFields calls fn for each protobuf field of a generated message,
+// including the set member of each of its oneofs.
+func visitFields(v reflect.Value, fn func(name string, field reflect.Value, oneof bool)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if _, ok := f.Tag.Lookup("protobuf_oneof"); ok {
+			// The wrapper's only field holds the member of the oneof
+			if w := v.Field(i); !w.IsNil() {
+				wrapper := w.Elem().Elem()
+				name := protobufFieldName(wrapper.Type().Field(0))
+				fn(name, wrapper.Field(0), true)
+			}
+			continue
+		}
+		if name := protobufFieldName(f); name != "" {
+			fn(name, v.Field(i), false)
+		}
+	}
+}
+
+func isMessageField(v reflect.Value) bool {
+	return v.Kind() == reflect.Ptr && !v.IsNil() &&
+		v.Elem().Kind() == reflect.Struct
+}
+
+func includeFields(v reflect.Value, t projectionTree, root bool) {
+	visitFields(v, func(name string, field reflect.Value, oneof bool) {
+		if root && projectionRequiredFields[name] {
+			return
+		}
+		child, ok := t[name]
+		if !ok {
+			clearField(field, oneof)
+		} else if child != nil && isMessageField(field) {
+			includeFields(field.Elem(), child, false)
+		}
+	})
+}
+
+func excludeFields(v reflect.Value, t projectionTree) {
+	visitFields(v, func(name string, field reflect.Value, oneof bool) {
+		child, ok := t[name]
+		if !ok {
+			return
+		}
+		if child == nil {
+			clearField(field, oneof)
+		} else if isMessageField(field) {
+			excludeFields(field.Elem(), child)
+		}
+	})
+}
+
+// eventProjection removes fields from translated events according to a
+// ProjectionModifier.
+type eventProjection struct {
+	include projectionTree
+	exclude projectionTree
+}
+
+func newProjectionTree(paths []string) (projectionTree, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	projectionFieldsOnce.Do(initProjectionFields)
+	t := make(projectionTree)
+	for _, path := range paths {
+		if !projectionFields[path] {
+			return nil, fmt.Errorf("field %q is invalid", path)
+		}
+		t.add(strings.Split(path, ".")[1:])
+	}
+	return t, nil
+}
+
+func newEventProjection(m *api.ProjectionModifier) (*eventProjection, error) {
+	if len(m.Include) == 0 && len(m.Exclude) == 0 {
+		return nil, errors.New("has no fields")
+	}
+
+	include, err := newProjectionTree(m.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := newProjectionTree(m.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	for name := range exclude {
+		if projectionRequiredFields[name] {
+			return nil, fmt.Errorf("field %q is always sent",
+				eventExpressionRoot+"."+name)
+		}
+	}
+
+	return &eventProjection{
+		include: include,
+		exclude: exclude,
+	}, nil
+}
+
+// apply removes the fields that are not to be sent from an event.
+func (p *eventProjection) apply(event *api.TelemetryEvent) {
+	v := reflect.ValueOf(event).Elem()
+	if p.include != nil {
+		includeFields(v, p.include, true)
+	}
+	if p.exclude != nil {
+		excludeFields(v, p.exclude)
+	}
+}
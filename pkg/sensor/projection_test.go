@@ -0,0 +1,142 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newProjectionTestEvent() *api.TelemetryEvent {
+	return &api.TelemetryEvent{
+		Id:                   "e1",
+		SensorId:             "s1",
+		SensorSequenceNumber: 8,
+		SensorMonotimeNanos:  1234,
+		ContainerId:          "c1",
+		ImageName:            "nginx:1.15",
+		Credentials: &api.Credentials{
+			Uid: 1000,
+			Gid: 1000,
+		},
+		Event: &api.TelemetryEvent_Container{
+			Container: &api.ContainerEvent{
+				Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED,
+				ImageName:        "nginx:1.15",
+				DockerConfigJson: "{}",
+				OciConfigJson:    "{}",
+			},
+		},
+	}
+}
+
+func TestNewEventProjection(t *testing.T) {
+	bad := []*api.ProjectionModifier{
+		&api.ProjectionModifier{},
+		&api.ProjectionModifier{
+			Include: []string{"event.no_such_field"},
+		},
+		&api.ProjectionModifier{
+			Exclude: []string{"container.image_name"},
+		},
+		&api.ProjectionModifier{
+			Exclude: []string{"event.id"},
+		},
+	}
+	for _, m := range bad {
+		_, err := newEventProjection(m)
+		assert.Error(t, err, "%+v", m)
+	}
+
+	// Repeated fields, maps, and messages may be named
+	_, err := newEventProjection(&api.ProjectionModifier{
+		Include: []string{"event.process_lineage", "event.credentials",
+			"event.container.image_labels"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestEventProjectionExclude(t *testing.T) {
+	p, err := newEventProjection(&api.ProjectionModifier{
+		Exclude: []string{
+			"event.container.docker_config_json",
+			"event.container.oci_config_json",
+			"event.credentials",
+		},
+	})
+	require.NoError(t, err)
+
+	e := newProjectionTestEvent()
+	p.apply(e)
+
+	expected := newProjectionTestEvent()
+	expected.Credentials = nil
+	expected.GetContainer().DockerConfigJson = ""
+	expected.GetContainer().OciConfigJson = ""
+	assert.Equal(t, expected, e)
+}
+
+func TestEventProjectionInclude(t *testing.T) {
+	p, err := newEventProjection(&api.ProjectionModifier{
+		Include: []string{
+			"event.container_id",
+			"event.container.image_name",
+			"event.credentials.uid",
+			"event.credentials",
+		},
+		Exclude: []string{"event.container_id"},
+	})
+	require.NoError(t, err)
+
+	e := newProjectionTestEvent()
+	p.apply(e)
+
+	// Naming a message names all of its fields, and the kind of event is
+	// kept even if it is not named
+	expected := &api.TelemetryEvent{
+		Id:                   "e1",
+		SensorId:             "s1",
+		SensorSequenceNumber: 8,
+		SensorMonotimeNanos:  1234,
+		Credentials: &api.Credentials{
+			Uid: 1000,
+			Gid: 1000,
+		},
+		Event: &api.TelemetryEvent_Container{
+			Container: &api.ContainerEvent{
+				ImageName: "nginx:1.15",
+			},
+		},
+	}
+	assert.Equal(t, expected, e)
+
+	p, err = newEventProjection(&api.ProjectionModifier{
+		Include: []string{"event.image_name"},
+	})
+	require.NoError(t, err)
+
+	e = newProjectionTestEvent()
+	p.apply(e)
+	assert.Equal(t, "nginx:1.15", e.ImageName)
+	assert.Equal(t, "", e.ContainerId)
+	assert.Nil(t, e.Credentials)
+	if assert.NotNil(t, e.GetContainer()) {
+		assert.Equal(t, api.ContainerEvent{}, *e.GetContainer())
+	}
+}
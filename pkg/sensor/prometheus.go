@@ -0,0 +1,243 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+
+	"golang.org/x/net/context"
+
+	"github.com/golang/glog"
+)
+
+// The metrics are written in the Prometheus text exposition format, which is
+// simple enough that the Prometheus client libraries are not needed for the
+// handful of counters, gauges, and histograms exported here.
+
+// The upper bounds of the translation latency histogram buckets
+var translationLatencyBuckets = [...]time.Duration{
+	100 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// latencyHistogram is a histogram of the time between events occurring and
+// their translation for a subscription, which grows when the sensor falls
+// behind.
+type latencyHistogram struct {
+	// The last count is for latencies above the largest bucket
+	counts [len(translationLatencyBuckets) + 1]uint64
+	sum    uint64 // nanoseconds
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	i := sort.Search(len(translationLatencyBuckets), func(i int) bool {
+		return d <= translationLatencyBuckets[i]
+	})
+	atomic.AddUint64(&h.counts[i], 1)
+	atomic.AddUint64(&h.sum, uint64(d))
+}
+
+// observeEvent records the translation latency of an event.
+func (h *latencyHistogram) observeEvent(s *Sensor, data TelemetryEventData) {
+	now := sys.CurrentMonotonicRaw() - s.bootMonotimeNanos
+	h.observe(time.Duration(now - data.MonotimeNanos))
+}
+
+// MetricsService is a service that serves the sensor's metrics over HTTP for
+// Prometheus to scrape.
+type MetricsService struct {
+	server *http.Server
+
+	sensor    *Sensor
+	telemetry *TelemetryService
+
+	address string
+}
+
+// NewMetricsService creates a new MetricsService instance bound to a specified
+// address. Counts for subscriptions are included for the streams of the
+// telemetry service, if it is not nil.
+func NewMetricsService(
+	sensor *Sensor,
+	telemetry *TelemetryService,
+	address string,
+) *MetricsService {
+	return &MetricsService{
+		sensor:    sensor,
+		telemetry: telemetry,
+		address:   address,
+	}
+}
+
+// Name returns a human-readable name for a MetricsService.
+func (ms *MetricsService) Name() string {
+	return "Metrics HTTP endpoint"
+}
+
+// Serve runs a MetricsService. It serves /metrics until the service is
+// stopped. It runs on the calling Goroutine.
+func (ms *MetricsService) Serve() error {
+	glog.V(1).Infof("Serving metrics HTTP endpoint on %s", ms.address)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", ms.serveMetrics)
+	ms.server = &http.Server{
+		Addr:    ms.address,
+		Handler: mux,
+	}
+
+	err := ms.server.ListenAndServe()
+	if err != nil {
+		glog.Errorf("Metrics HTTP error: %s", err)
+	}
+
+	return err
+}
+
+// Stop stops a running MetricsService.
+func (ms *MetricsService) Stop() {
+	ms.server.Shutdown(context.Background())
+}
+
+func (ms *MetricsService) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	bw := bufio.NewWriter(w)
+	ms.writeMetrics(bw)
+	bw.Flush()
+}
+
+// metricsWriter writes metrics in the Prometheus text exposition format.
+type metricsWriter struct {
+	w io.Writer
+}
+
+func (mw metricsWriter) header(name, help, kind string) {
+	fmt.Fprintf(mw.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (mw metricsWriter) sample(name, labels string, value interface{}) {
+	if len(labels) > 0 {
+		fmt.Fprintf(mw.w, "%s{%s} %v\n", name, labels, value)
+	} else {
+		fmt.Fprintf(mw.w, "%s %v\n", name, value)
+	}
+}
+
+// label returns a label pair with its value quoted and escaped.
+func label(name, value string) string {
+	return fmt.Sprintf("%s=%q", name, value)
+}
+
+func (ms *MetricsService) writeMetrics(w io.Writer) {
+	mw := metricsWriter{w: w}
+	s := ms.sensor
+
+	const received = "capsule8_sensor_events_received_total"
+	mw.header(received, "Events received from the sensor's event sources, by type of event.", "counter")
+	for _, c := range s.eventCounts.snapshot() {
+		mw.sample(received, label("type", c.name), c.received)
+	}
+
+	const lost = "capsule8_sensor_perf_records_lost_total"
+	mw.header(lost, "Records that the kernel dropped because a perf ring buffer was full.", "counter")
+	mw.sample(lost, "", atomic.LoadUint64(&s.Metrics.LostEvents))
+
+	const rateLimited = "capsule8_sensor_events_rate_limited_total"
+	mw.header(rateLimited, "Events dropped because a subscription's rate limit was exceeded.", "counter")
+	mw.sample(rateLimited, "", atomic.LoadUint64(&s.Metrics.RateLimitedEvents))
+
+	if ms.telemetry != nil {
+		streams := ms.telemetry.handler.listStreams()
+		sort.Slice(streams, func(i, j int) bool {
+			return streams[i].id < streams[j].id
+		})
+
+		const active = "capsule8_sensor_subscriptions"
+		mw.header(active, "Active event streams.", "gauge")
+		mw.sample(active, "", len(streams))
+
+		const published = "capsule8_sensor_events_published_total"
+		mw.header(published, "Events sent to subscribers, by subscription.", "counter")
+		for _, ts := range streams {
+			mw.sample(published, label("subscription", ts.id),
+				atomic.LoadUint64(&ts.eventsSent))
+		}
+
+		const dropped = "capsule8_sensor_events_dropped_total"
+		mw.header(dropped, "Events dropped because a subscriber fell behind, by subscription.", "counter")
+		for _, ts := range streams {
+			mw.sample(dropped, label("subscription", ts.id),
+				atomic.LoadUint64(&ts.eventsDropped))
+		}
+	}
+
+	const entries = "capsule8_sensor_cache_entries"
+	mw.header(entries, "Entries in the sensor's caches.", "gauge")
+	if s.ProcessCache != nil {
+		mw.sample(entries, label("cache", "process"), s.ProcessCache.cache.Len())
+		if c := s.ProcessCache.execHashes; c != nil {
+			c.Lock()
+			n := len(c.entries)
+			c.Unlock()
+			mw.sample(entries, label("cache", "executable_hash"), n)
+		}
+	}
+	if s.ContainerCache != nil {
+		s.ContainerCache.Lock()
+		n := len(s.ContainerCache.cache)
+		s.ContainerCache.Unlock()
+		mw.sample(entries, label("cache", "container"), n)
+	}
+	if c := s.userNames; c != nil {
+		c.Lock()
+		n := len(c.entries)
+		c.Unlock()
+		mw.sample(entries, label("cache", "user_names"), n)
+	}
+
+	const latency = "capsule8_sensor_translation_latency_seconds"
+	mw.header(latency, "Time between events occurring and their translation for a subscription.", "histogram")
+	h := &s.translationLatency
+	var count uint64
+	for i, bound := range translationLatencyBuckets {
+		count += atomic.LoadUint64(&h.counts[i])
+		mw.sample(latency+"_bucket",
+			label("le", fmt.Sprintf("%g", bound.Seconds())), count)
+	}
+	count += atomic.LoadUint64(&h.counts[len(translationLatencyBuckets)])
+	mw.sample(latency+"_bucket", label("le", "+Inf"), count)
+	mw.sample(latency+"_sum", "",
+		time.Duration(atomic.LoadUint64(&h.sum)).Seconds())
+	mw.sample(latency+"_count", "", count)
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyHistogram(t *testing.T) {
+	var h latencyHistogram
+	h.observe(-time.Millisecond)
+	h.observe(100 * time.Microsecond)
+	h.observe(2 * time.Millisecond)
+	h.observe(time.Minute)
+
+	assert.Equal(t, uint64(2), h.counts[0])
+	assert.Equal(t, uint64(1), h.counts[3])
+	assert.Equal(t, uint64(1), h.counts[len(translationLatencyBuckets)])
+	assert.Equal(t, uint64(time.Minute+2100*time.Microsecond), h.sum)
+}
+
+func TestServeMetrics(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	sensor.eventCounts.get(ProcessExecTelemetryEvent{}).received++
+	sensor.Metrics.LostEvents = 7
+	sensor.translationLatency.observe(2 * time.Millisecond)
+
+	ts := NewTelemetryService(sensor, "")
+	ts.handler.addStream(&getEventsStream{
+		id:            "stream",
+		eventsSent:    12,
+		eventsDropped: 3,
+	})
+	ms := NewMetricsService(sensor, ts, "")
+
+	w := httptest.NewRecorder()
+	ms.serveMetrics(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+	for _, s := range []string{
+		"# TYPE capsule8_sensor_events_received_total counter\n",
+		"capsule8_sensor_events_received_total{type=\"ProcessExec\"} 1\n",
+		"capsule8_sensor_perf_records_lost_total 7\n",
+		"capsule8_sensor_subscriptions 1\n",
+		"capsule8_sensor_events_published_total{subscription=\"stream\"} 12\n",
+		"capsule8_sensor_events_dropped_total{subscription=\"stream\"} 3\n",
+		"capsule8_sensor_cache_entries{cache=\"container\"} ",
+		"capsule8_sensor_cache_entries{cache=\"process\"} ",
+		"# TYPE capsule8_sensor_translation_latency_seconds histogram\n",
+		"capsule8_sensor_translation_latency_seconds_bucket{le=\"0.001\"} 0\n",
+		"capsule8_sensor_translation_latency_seconds_bucket{le=\"0.005\"} 1\n",
+		"capsule8_sensor_translation_latency_seconds_bucket{le=\"+Inf\"} 1\n",
+		"capsule8_sensor_translation_latency_seconds_sum 0.002\n",
+		"capsule8_sensor_translation_latency_seconds_count 1\n",
+	} {
+		assert.Contains(t, body, s)
+	}
+}
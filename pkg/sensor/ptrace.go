@@ -0,0 +1,162 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"golang.org/x/sys/unix"
+)
+
+// ProcessPtraceAttachEventTypes defines the field types that can be used with
+// filters on process ptrace attach telemetry events.
+var ProcessPtraceAttachEventTypes = expression.FieldTypeMap{
+	"request":             expression.ValueTypeSignedInt64,
+	"tracer_pid":          expression.ValueTypeSignedInt32,
+	"tracer_container_id": expression.ValueTypeString,
+	"tracee_pid":          expression.ValueTypeSignedInt32,
+	"tracee_container_id": expression.ValueTypeString,
+}
+
+// ProcessPtraceAttachTelemetryEvent is a telemetry event generated by the
+// process ptrace event source when a process attaches to another process with
+// PTRACE_ATTACH or PTRACE_SEIZE, or asks to be traced by its parent with
+// PTRACE_TRACEME.
+type ProcessPtraceAttachTelemetryEvent struct {
+	TelemetryEventData
+
+	Request int64
+
+	TracerPID         int32
+	TracerProcessID   string
+	TracerContainerID string
+
+	TraceePID         int32
+	TraceeProcessID   string
+	TraceeContainerID string
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a process ptrace attach telemetry event.
+func (e ProcessPtraceAttachTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// The pid passed to ptrace(2) is in the caller's PID namespace, so it must be
+// translated before it can be used to find the tracee. Only the requests that
+// establish a tracing relationship are recorded.
+const (
+	ptraceKprobeSymbol    = "sys_ptrace"
+	ptraceKprobeFetchargs = "request=%di:s64 pid=%si:s32"
+)
+
+var ptraceKprobeFilter = fmt.Sprintf(
+	"request == %d || request == %d || request == %d",
+	unix.PTRACE_TRACEME, unix.PTRACE_ATTACH, unix.PTRACE_SEIZE)
+
+// hostTaskPID returns the PID in the sensor's PID namespace of the task known
+// as nsPID inside the PID namespace of t. Candidate tasks must be nested
+// equally deeply and belong to the same container as t. If NSpid is not
+// reported in /proc (Linux < 4.1) or t is in the sensor's PID namespace, nsPID
+// is returned unchanged. Zero is returned if no matching task exists.
+func (pc *ProcessInfoCache) hostTaskPID(t *Task, nsPID int) int {
+	type taskStatus struct {
+		NSpid []int `NSpid`
+	}
+
+	procFS := pc.sensor.ProcFS
+	var status taskStatus
+	err := procFS.ReadTaskStatus(t.TGID, t.PID, &status)
+	if err != nil || len(status.NSpid) < 2 {
+		return nsPID
+	}
+	depth := len(status.NSpid)
+	containerID := t.Leader().ContainerID
+
+	hostPID := 0
+	procFS.WalkTasks(func(tgid, pid int) bool {
+		var s taskStatus
+		if procFS.ReadTaskStatus(tgid, pid, &s) != nil ||
+			len(s.NSpid) != depth || s.NSpid[depth-1] != nsPID {
+			return true
+		}
+		if _, leader := pc.LookupTaskAndLeader(pid); leader.ContainerID != containerID {
+			return true
+		}
+		hostPID = pid
+		return false
+	})
+	return hostPID
+}
+
+func (s *Subscription) decodeSysPtrace(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	pid, _ := data["common_pid"].(int32)
+	if pid == 0 {
+		return nil, nil
+	}
+
+	var e ProcessPtraceAttachTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Request = data["request"].(int64)
+
+	cache := s.sensor.ProcessCache
+	caller := cache.LookupTask(int(pid))
+	var tracer, tracee *Task
+	if e.Request == unix.PTRACE_TRACEME {
+		tracer, tracee = caller.Leader().Parent(), caller
+	} else {
+		hostPID := cache.hostTaskPID(caller, int(data["pid"].(int32)))
+		if hostPID == 0 {
+			return nil, nil
+		}
+		tracer, tracee = caller, cache.LookupTask(hostPID)
+	}
+
+	e.TracerPID = int32(tracer.PID)
+	e.TracerProcessID = tracer.ProcessID
+	e.TracerContainerID = cache.taskContainerID(tracer)
+	e.TraceePID = int32(tracee.PID)
+	e.TraceeProcessID = tracee.ProcessID
+	e.TraceeContainerID = cache.taskContainerID(tracee)
+
+	// Make the resolved tasks visible to filter expressions, which are
+	// evaluated against the sample data after decoding.
+	data["tracer_pid"] = e.TracerPID
+	data["tracer_container_id"] = e.TracerContainerID
+	data["tracee_pid"] = e.TraceePID
+	data["tracee_container_id"] = e.TraceeContainerID
+
+	return e, nil
+}
+
+// RegisterProcessPtraceAttachEventFilter registers a process ptrace attach
+// event filter with a subscription.
+func (s *Subscription) RegisterProcessPtraceAttachEventFilter(expr *expression.Expression) {
+	s.registerWithSensorFilter("ptrace attach", expr, ProcessPtraceAttachEventTypes,
+		func() (*eventSink, error) {
+			// The tracer and tracee are only known after decoding
+			return s.registerKprobe(ptraceKprobeSymbol, false,
+				ptraceKprobeFetchargs, s.decodeSysPtrace, nil,
+				ProcessPtraceAttachEventTypes, perf.WithFilter(ptraceKprobeFilter))
+		})
+}
@@ -0,0 +1,164 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
+)
+
+const ptraceTestContainerID = "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae"
+
+func TestHostTaskPID(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	cache := sensor.ProcessCache
+
+	// PID 1 is in the sensor's PID namespace, so no translation is done
+	assert.Equal(t, 405, cache.hostTaskPID(cache.LookupTask(1), 405))
+
+	// PID 111343 is PID 1 in its container's PID namespace
+	tracer := cache.LookupTask(111343)
+	assert.Equal(t, 111343, cache.hostTaskPID(tracer, 1))
+	assert.Equal(t, 0, cache.hostTaskPID(tracer, 2))
+}
+
+func TestDecodeSysPtrace(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"request":    int64(unix.PTRACE_ATTACH),
+		"pid":        int32(405),
+	}
+	i, err := s.decodeSysPtrace(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	data["common_pid"] = int32(1)
+	i, err = s.decodeSysPtrace(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessPtraceAttachTelemetryEvent{}, i)
+
+	e := i.(ProcessPtraceAttachTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+
+	assert.Equal(t, int64(unix.PTRACE_ATTACH), e.Request)
+	assert.Equal(t, int32(1), e.TracerPID)
+	assert.Equal(t, "", e.TracerContainerID)
+	assert.Equal(t, int32(405), e.TraceePID)
+	assert.Equal(t, "", e.TraceeContainerID)
+	assert.Equal(t, int32(405), data["tracee_pid"])
+
+	// The tracee PID is in the tracer's PID namespace
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(111343),
+		"request":    int64(unix.PTRACE_SEIZE),
+		"pid":        int32(1),
+	}
+	i, err = s.decodeSysPtrace(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessPtraceAttachTelemetryEvent{}, i)
+
+	e = i.(ProcessPtraceAttachTelemetryEvent)
+	assert.Equal(t, int32(111343), e.TracerPID)
+	assert.Equal(t, ptraceTestContainerID, e.TracerContainerID)
+	assert.Equal(t, int32(111343), e.TraceePID)
+	assert.Equal(t, ptraceTestContainerID, e.TraceeContainerID)
+	assert.Equal(t, ptraceTestContainerID, data["tracee_container_id"])
+
+	data["pid"] = int32(2)
+	i, err = s.decodeSysPtrace(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
+	// PTRACE_TRACEME makes the parent the tracer
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(405),
+		"request":    int64(unix.PTRACE_TRACEME),
+		"pid":        int32(0),
+	}
+	i, err = s.decodeSysPtrace(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessPtraceAttachTelemetryEvent{}, i)
+
+	e = i.(ProcessPtraceAttachTelemetryEvent)
+	assert.Equal(t, int32(1), e.TracerPID)
+	assert.Equal(t, int32(405), e.TraceePID)
+}
+
+func prepareForRegisterProcessPtraceAttachEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:s64 request;	offset:16;	size:8;	signed:1;
+	field:s32 pid;	offset:24;	size:4;	signed:1;
+
+print fmt: "(%lx) request=%Ld pid=%d", REC->__probe_ip, REC->request, REC->pid`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func TestProcessPtraceAttachEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("tracee_container_id"),
+		expression.Value(ptraceTestContainerID))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	prepareForRegisterProcessPtraceAttachEventFilter(t, s, 0)
+	s.RegisterProcessPtraceAttachEventFilter(expr)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterProcessPtraceAttachEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import "time"
+
+// rateLimiter is a token bucket that limits the rate at which events are
+// dispatched to a subscription. It is only used from the sensor's sample
+// dispatch loop, so it is not safe for concurrent use.
+type rateLimiter struct {
+	// Tokens added per nanosecond and the size of the bucket
+	rate  float64
+	burst float64
+
+	tokens float64
+	last   int64
+}
+
+func newRateLimiter(eventsPerSecond float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   eventsPerSecond / float64(time.Second),
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// allow reports whether an event with the specified monotonic timestamp may
+// be dispatched, consuming a token if so. Timestamps that go backwards, as
+// they may between events from different CPUs, do not add tokens.
+func (r *rateLimiter) allow(monotimeNanos int64) bool {
+	if r.last == 0 {
+		r.last = monotimeNanos
+	} else if monotimeNanos > r.last {
+		r.tokens += float64(monotimeNanos-r.last) * r.rate
+		if r.tokens > r.burst {
+			r.tokens = r.burst
+		}
+		r.last = monotimeNanos
+	}
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	// 10 events per second with a burst of 3
+	r := newRateLimiter(10, 3)
+	start := int64(1e9)
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, r.allow(start), "burst event %d", i)
+	}
+	assert.False(t, r.allow(start))
+
+	// One token is added every 100ms
+	assert.False(t, r.allow(start+50e6))
+	assert.True(t, r.allow(start+100e6))
+	assert.False(t, r.allow(start+100e6))
+
+	// Timestamps that go backwards don't add tokens
+	assert.False(t, r.allow(start))
+
+	// The bucket never holds more than the burst
+	later := start + 10e9
+	for i := 0; i < 3; i++ {
+		assert.True(t, r.allow(later), "burst event %d", i)
+	}
+	assert.False(t, r.allow(later))
+
+	// The burst is at least 1
+	r = newRateLimiter(0.5, 0)
+	assert.True(t, r.allow(start))
+	assert.False(t, r.allow(start+1e9))
+	assert.True(t, r.allow(start+2e9))
+}
+
+func TestDispatchRateLimited(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	eventID := sensor.Monitor().RegisterExternalEvent("rate limit test", nil)
+
+	s := newTestSubscription(t, sensor)
+	_, err := s.addEventSink(eventID, nil, nil)
+	require.NoError(t, err)
+	s.SetRateLimit(1, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var events []TelemetryEvent
+	_, err = s.Run(ctx, func(e TelemetryEvent) {
+		events = append(events, e)
+	})
+	require.NoError(t, err)
+
+	sample := func(monotimeNanos int64) perf.EventMonitorSample {
+		e := TickerTelemetryEvent{}
+		e.MonotimeNanos = monotimeNanos
+		return perf.EventMonitorSample{
+			EventID:       eventID,
+			DecodedSample: e,
+		}
+	}
+	sensor.dispatchQueuedSamples([]perf.EventMonitorSample{
+		sample(1e9),
+		sample(1e9 + 1),
+		sample(1e9 + 2),
+		sample(1e9 + 3),
+		sample(2e9 + 3),
+	})
+	assert.Len(t, events, 3)
+	assert.Equal(t, uint64(2), sensor.Metrics.RateLimitedEvents)
+}
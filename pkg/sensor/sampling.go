@@ -0,0 +1,38 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import "math/rand"
+
+// eventSampler randomly selects one in n events for dispatch to a
+// subscription. Like rateLimiter, it is only used from the sensor's sample
+// dispatch loop, so it has its own source of random numbers that is not safe
+// for concurrent use.
+type eventSampler struct {
+	oneIn int64
+	rand  *rand.Rand
+}
+
+func newEventSampler(oneIn int64, seed int64) *eventSampler {
+	return &eventSampler{
+		oneIn: oneIn,
+		rand:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+// sample reports whether an event is selected.
+func (s *eventSampler) sample() bool {
+	return s.rand.Int63n(s.oneIn) == 0
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSampler(t *testing.T) {
+	s := newEventSampler(10, 1)
+	n := 0
+	for i := 0; i < 100000; i++ {
+		if s.sample() {
+			n++
+		}
+	}
+	// Allow for 5 standard deviations
+	assert.InDelta(t, 10000, n, 500)
+}
+
+func TestDispatchSampled(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	eventID := sensor.Monitor().RegisterExternalEvent("sampling test", nil)
+
+	s := newTestSubscription(t, sensor)
+	_, err := s.addEventSink(eventID, nil, nil)
+	require.NoError(t, err)
+
+	s.SetSampleRate(1)
+	assert.Nil(t, s.sampler)
+	s.SetSampleRate(4)
+	require.NotNil(t, s.sampler)
+	s.sampler = newEventSampler(4, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var events []TelemetryEvent
+	_, err = s.Run(ctx, func(e TelemetryEvent) {
+		events = append(events, e)
+	})
+	require.NoError(t, err)
+
+	samples := make([]perf.EventMonitorSample, 1000)
+	for i := range samples {
+		samples[i] = perf.EventMonitorSample{
+			EventID:       eventID,
+			DecodedSample: TickerTelemetryEvent{},
+		}
+	}
+	sensor.dispatchQueuedSamples(samples)
+	assert.InDelta(t, 250, len(events), 70)
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// SeccompAction represents the action taken by a seccomp filter that stopped
+// a system call.
+type SeccompAction int32
+
+const (
+	// SeccompActionUnknown indicates that the action is not known.
+	SeccompActionUnknown SeccompAction = iota
+
+	// SeccompActionTrap indicates that the system call was stopped and
+	// SIGSYS was sent to the process (SECCOMP_RET_TRAP).
+	SeccompActionTrap
+
+	// SeccompActionKill indicates that the process was killed with SIGSYS
+	// (SECCOMP_RET_KILL_PROCESS or SECCOMP_RET_KILL_THREAD).
+	SeccompActionKill
+)
+
+// ProcessSeccompViolationEventTypes defines the field types that can be used
+// with filters on process seccomp violation telemetry events.
+var ProcessSeccompViolationEventTypes = expression.FieldTypeMap{
+	"syscall": expression.ValueTypeSignedInt32,
+	"action":  expression.ValueTypeSignedInt32,
+	"data":    expression.ValueTypeUnsignedInt32,
+}
+
+// ProcessSeccompViolationTelemetryEvent is a telemetry event generated by the
+// process seccomp event source when a seccomp filter traps or kills a process.
+type ProcessSeccompViolationTelemetryEvent struct {
+	TelemetryEventData
+
+	Syscall int32
+	Action  SeccompAction
+	Data    uint32
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a process seccomp violation telemetry event.
+func (e ProcessSeccompViolationTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// force_sig_seccomp is called in the context of the offending task for both
+// SECCOMP_RET_TRAP and SECCOMP_RET_KILL_*, with force_coredump set only when
+// the task is being killed. The one exception is SECCOMP_RET_KILL_THREAD for
+// a thread that is not the last in its process, which exits without a signal
+// and is not reported.
+const (
+	seccompKprobeSymbol    = "force_sig_seccomp"
+	seccompKprobeFetchargs = "syscall=%di:s32 reason=%si:s32 force_coredump=%dx:u8"
+)
+
+// Older kernels do not have force_sig_seccomp. On those, the seccomp audit
+// path is used instead, which only reports actions while the audit subsystem
+// is enabled. The code is the full filter return value.
+const (
+	seccompAuditKprobeSymbol    = "__audit_seccomp"
+	seccompAuditKprobeFetchargs = "syscall=%di:s32 signr=%si:s64 code=%dx:u32"
+
+	seccompRetActionFull  = 0xffff0000
+	seccompRetKillProcess = 0x80000000
+	seccompRetKillThread  = 0x00000000
+	seccompRetTrap        = 0x00030000
+	seccompRetData        = 0x0000ffff
+)
+
+func (s *Subscription) decodeSeccompViolation(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+	syscall int32,
+	action SeccompAction,
+	filterData uint32,
+) (interface{}, error) {
+	var e ProcessSeccompViolationTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Syscall = syscall
+	e.Action = action
+	e.Data = filterData
+
+	// Make the derived fields visible to filter expressions, which are
+	// evaluated against the sample data after decoding.
+	data["action"] = int32(e.Action)
+	data["data"] = e.Data
+
+	return e, nil
+}
+
+func (s *Subscription) decodeForceSigSeccomp(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	action := SeccompActionTrap
+	if data["force_coredump"].(uint8) != 0 {
+		action = SeccompActionKill
+	}
+
+	// The reason is the SECCOMP_RET_DATA portion of the filter result.
+	return s.decodeSeccompViolation(sample, data, data["syscall"].(int32),
+		action, uint32(data["reason"].(int32))&seccompRetData)
+}
+
+func (s *Subscription) decodeAuditSeccomp(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	code := data["code"].(uint32)
+
+	var action SeccompAction
+	switch code & seccompRetActionFull {
+	case seccompRetKillProcess, seccompRetKillThread:
+		action = SeccompActionKill
+	case seccompRetTrap:
+		action = SeccompActionTrap
+	default:
+		// Other actions, such as SECCOMP_RET_ERRNO, are also audited
+		// but are not violations that stop the process.
+		return nil, nil
+	}
+
+	return s.decodeSeccompViolation(sample, data, data["syscall"].(int32),
+		action, code&seccompRetData)
+}
+
+// RegisterProcessSeccompViolationEventFilter registers a process seccomp
+// violation event filter with a subscription.
+func (s *Subscription) RegisterProcessSeccompViolationEventFilter(expr *expression.Expression) {
+	symbol := seccompKprobeSymbol
+	fetchargs := seccompKprobeFetchargs
+	decoder := s.decodeForceSigSeccomp
+	if !s.sensor.IsKernelSymbolAvailable(symbol) {
+		symbol = seccompAuditKprobeSymbol
+		fetchargs = seccompAuditKprobeFetchargs
+		decoder = s.decodeAuditSeccomp
+	}
+
+	s.registerWithSensorFilter("seccomp", expr,
+		ProcessSeccompViolationEventTypes,
+		func() (*eventSink, error) {
+			// The action is derived after decoding
+			return s.registerKprobe(symbol, false, fetchargs,
+				decoder, nil, ProcessSeccompViolationEventTypes)
+		})
+}
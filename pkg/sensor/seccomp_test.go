@@ -0,0 +1,175 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestDecodeForceSigSeccomp(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid":     int32(sensorPID),
+		"syscall":        int32(unix.SYS_PTRACE),
+		"reason":         int32(0),
+		"force_coredump": uint8(1),
+	}
+
+	i, err := s.decodeForceSigSeccomp(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	delete(data, "common_pid")
+	i, err = s.decodeForceSigSeccomp(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessSeccompViolationTelemetryEvent{}, i)
+
+	e := i.(ProcessSeccompViolationTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, int32(unix.SYS_PTRACE), e.Syscall)
+	assert.Equal(t, SeccompActionKill, e.Action)
+	assert.Equal(t, uint32(0), e.Data)
+	assert.Equal(t, int32(SeccompActionKill), data["action"])
+
+	// Only the low 16 bits of the reason are filter data
+	data["reason"] = int32(0x10042)
+	data["force_coredump"] = uint8(0)
+	i, err = s.decodeForceSigSeccomp(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessSeccompViolationTelemetryEvent{}, i)
+
+	e = i.(ProcessSeccompViolationTelemetryEvent)
+	assert.Equal(t, SeccompActionTrap, e.Action)
+	assert.Equal(t, uint32(0x42), e.Data)
+	assert.Equal(t, uint32(0x42), data["data"])
+}
+
+func TestDecodeAuditSeccomp(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"syscall": int32(unix.SYS_PTRACE),
+		"signr":   int64(unix.SIGSYS),
+		"code":    uint32(seccompRetKillProcess),
+	}
+
+	i, err := s.decodeAuditSeccomp(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessSeccompViolationTelemetryEvent{}, i)
+
+	e := i.(ProcessSeccompViolationTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, int32(unix.SYS_PTRACE), e.Syscall)
+	assert.Equal(t, SeccompActionKill, e.Action)
+
+	data["signr"] = int64(0)
+	data["code"] = uint32(seccompRetTrap | 0x42)
+	i, err = s.decodeAuditSeccomp(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, ProcessSeccompViolationTelemetryEvent{}, i)
+
+	e = i.(ProcessSeccompViolationTelemetryEvent)
+	assert.Equal(t, SeccompActionTrap, e.Action)
+	assert.Equal(t, uint32(0x42), e.Data)
+
+	// SECCOMP_RET_ERRNO is audited, but is not reported
+	data["code"] = uint32(0x00050000 | unix.EPERM)
+	i, err = s.decodeAuditSeccomp(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+}
+
+func prepareForRegisterProcessSeccompViolationEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:s32 syscall;	offset:16;	size:4;	signed:1;
+	field:s64 signr;	offset:24;	size:8;	signed:1;
+	field:u32 code;	offset:32;	size:4;	signed:0;
+
+print fmt: "(%lx) syscall=%d signr=%Ld code=%u", REC->__probe_ip, REC->syscall, REC->signr, REC->code`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func TestProcessSeccompViolationEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("action"),
+		expression.Value(int32(SeccompActionKill)))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	// force_sig_seccomp is not in the test kallsyms, so the audit path
+	// is used
+	prepareForRegisterProcessSeccompViolationEventFilter(t, s, 0)
+	s.RegisterProcessSeccompViolationEventFilter(expr)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	sensor.kallsyms[seccompKprobeSymbol] = seccompKprobeSymbol
+	s.RegisterProcessSeccompViolationEventFilter(expr)
+	delete(sensor.kallsyms, seccompKprobeSymbol)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+	assert.Contains(t, s.status[0], seccompKprobeSymbol)
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("reason"),
+		expression.Value(int32(0)))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterProcessSeccompViolationEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
@@ -19,8 +19,10 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -28,6 +30,7 @@ import (
 	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/btf"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 	"github.com/capsule8/capsule8/pkg/sys/proc"
 	"github.com/capsule8/capsule8/pkg/sys/proc/procfs"
@@ -42,11 +45,26 @@ type newSensorOptions struct {
 	perfEventDir          string
 	tracingDir            string
 	dockerContainerDir    string
+	dockerBackend         string
+	dockerSocketPath      string
 	ociContainerDir       string
+	ociHookSocketPath     string
 	procFS                proc.FileSystem
 	eventSourceController perf.EventSourceController
 	cleanupFuncs          []func()
 	cgroupNames           []string
+	useBPFCgroupFilter    bool
+	useContainerCgroups   bool
+	useAuditBackend       bool
+	wtmpPath              string
+	kernelBTFPath         string
+	sockCommonV6Offsets   [2]uint32
+	ringBufferNumPages    int
+	execStackTraces       bool
+	containerAllowlist    []string
+	containerDenylist     []string
+	enabledEventFilters   []string
+	disabledEventFilters  []string
 }
 
 // NewSensorOption is used to implement optional arguments for NewSensor.
@@ -69,6 +87,23 @@ func WithDockerContainerDir(dockerContainerDir string) NewSensorOption {
 	}
 }
 
+// WithDockerBackend is used to select the mechanism used to monitor Docker
+// container activity. It must be one of DockerBackendFilesystem or
+// DockerBackendEvents.
+func WithDockerBackend(dockerBackend string) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.dockerBackend = dockerBackend
+	}
+}
+
+// WithDockerSocketPath is used to set the path to the Docker Engine API unix
+// socket used by the DockerBackendEvents backend.
+func WithDockerSocketPath(dockerSocketPath string) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.dockerSocketPath = dockerSocketPath
+	}
+}
+
 // WithOciContainerDir is used to set the directory to monitor for OCI
 // container activity.
 func WithOciContainerDir(ociContainerDir string) NewSensorOption {
@@ -77,6 +112,121 @@ func WithOciContainerDir(ociContainerDir string) NewSensorOption {
 	}
 }
 
+// WithOciHookSocketPath is used to set the path to the unix socket on which to
+// listen for notifications from the OCI runtime hook.
+func WithOciHookSocketPath(ociHookSocketPath string) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.ociHookSocketPath = ociHookSocketPath
+	}
+}
+
+// WithWtmpPath is used to set the path to the login accounting file to follow
+// for session events.
+func WithWtmpPath(wtmpPath string) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.wtmpPath = wtmpPath
+	}
+}
+
+// WithBPFCgroupFilter is used to restrict events to the cgroups named with
+// WithCgroupName using an in-kernel BPF program instead of perf_event sources
+// for each cgroup.
+func WithBPFCgroupFilter(useBPFCgroupFilter bool) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.useBPFCgroupFilter = useBPFCgroupFilter
+	}
+}
+
+// WithKernelBTFPath is used to set the path of the file from which the running
+// kernel's BTF type information is read. If empty, kernel structure offsets
+// built into the sensor are used.
+func WithKernelBTFPath(kernelBTFPath string) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.kernelBTFPath = kernelBTFPath
+	}
+}
+
+// WithSockCommonV6Offsets is used to set the offsets of the skc_v6_daddr and
+// skc_v6_rcv_saddr members of the running kernel's struct sock_common, which
+// are used by the IPv6 network kprobes when the kernel does not provide BTF.
+// If 0, the offsets built into the sensor are used.
+func WithSockCommonV6Offsets(daddr, rcvSaddr uint32) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.sockCommonV6Offsets = [2]uint32{daddr, rcvSaddr}
+	}
+}
+
+// WithRingBufferNumPages is used to set the default size in pages of the perf
+// ring buffers used by the sensor's event monitor. Subscriptions may override
+// it.
+func WithRingBufferNumPages(ringBufferNumPages int) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.ringBufferNumPages = ringBufferNumPages
+	}
+}
+
+// WithContainerCgroups is used to restrict the kernel events generated for
+// subscriptions filtered to specific container IDs to the perf_event cgroups
+// of those containers.
+func WithContainerCgroups(useContainerCgroups bool) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.useContainerCgroups = useContainerCgroups
+	}
+}
+
+// WithExecStackTraces is used to capture the stack of the task calling
+// execve(2) for every process exec event, so that subscriptions that request
+// stack traces receive them with process exec events.
+func WithExecStackTraces(execStackTraces bool) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.execStackTraces = execStackTraces
+	}
+}
+
+// WithContainerAllowlist is used to only monitor containers that match one of
+// a set of patterns, regardless of subscriptions. Each pattern is of the form
+// "name:glob", "image:glob", "label:key=glob", or "label:key".
+func WithContainerAllowlist(patterns []string) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.containerAllowlist = patterns
+	}
+}
+
+// WithContainerDenylist is used to never monitor containers that match any
+// of a set of patterns, regardless of subscriptions. The patterns are of the
+// same form as those of WithContainerAllowlist.
+func WithContainerDenylist(patterns []string) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.containerDenylist = patterns
+	}
+}
+
+// WithEnabledEventFilters is used to only allow subscriptions to request the
+// events of the named EventFilter fields, i.e. "process_events". Events of
+// other fields are removed from subscriptions.
+func WithEnabledEventFilters(names []string) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.enabledEventFilters = names
+	}
+}
+
+// WithDisabledEventFilters is used to remove the events of the named
+// EventFilter fields, i.e. "tty_events", from all subscriptions.
+func WithDisabledEventFilters(names []string) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.disabledEventFilters = names
+	}
+}
+
+// WithAuditBackend is used to select the kernel audit subsystem instead of
+// kprobes as the source of process exec, network connect attempt, and file
+// open events.
+func WithAuditBackend(useAuditBackend bool) NewSensorOption {
+	return func(o *newSensorOptions) {
+		o.useAuditBackend = useAuditBackend
+	}
+}
+
 // WithProcFileSystem is used to set the proc.FileSystem to use. The system
 // default will be used if one is not specified.
 func WithProcFileSystem(procFS proc.FileSystem) NewSensorOption {
@@ -147,6 +297,15 @@ type Sensor struct {
 	// Metrics counters for this sensor
 	Metrics MetricsCounters
 
+	// Whether each of the sensor's event sources initialized during
+	// Start, by name. Sources that failed have an error.
+	eventSourcesLock sync.Mutex
+	eventSources     map[string]error
+
+	// Further metrics exported by the MetricsService
+	eventCounts        eventTypeCounters
+	translationLatency latencyHistogram
+
 	// If temporary fs mounts are made at startup, they're stored here.
 	perfEventDir string
 	tracingDir   string
@@ -168,11 +327,48 @@ type Sensor struct {
 	// sometimes differ due to compiler name mangling.
 	kallsyms map[string]string
 
+	// Replacements for kprobe symbols that have changed across kernel
+	// versions, chosen during Start
+	kprobeSymbols map[string]kprobeSymbolAlternative
+
+	// The running kernel's BTF type information, which is used to find
+	// structure member offsets for kprobe fetch args. If nil, built-in
+	// offsets are used.
+	kernelBTF *btf.Spec
+
+	// The offsets of skc_v6_daddr and skc_v6_rcv_saddr in struct
+	// sock_common to use if they are not found in the kernel's BTF, or
+	// 0 to use the built-in offsets
+	sockCommonV6Offsets [2]uint32
+
+	// Kernel text symbols sorted by address, which are used to symbolize
+	// kernel stack traces. They're only loaded once a stack trace needs
+	// them.
+	kernelSymbolsOnce sync.Once
+	kernelSymbols     []proc.KernelSymbol
+
 	// Per-sensor caches and monitors
 	ProcessCache   *ProcessInfoCache
 	ContainerCache *ContainerCache
+	ImageCache     *ImageCache
+	userNames      *userNameCache
 	dockerMonitor  *dockerMonitor
+	dockerEvents   *dockerEventsMonitor
 	ociMonitor     *ociMonitor
+	ociHooks       *ociHookListener
+
+	// The audit listener is started when the first subscription needs
+	// audit records, and the events built on it are registered then too.
+	auditLock                sync.Mutex
+	audit                    *auditListener
+	lsmDenialEventID         uint64
+	lsmDenialEventRegistered bool
+	auditSyscalls            *auditSyscallEvents
+
+	// The session monitor is started when the first subscription needs
+	// session events.
+	sessionLock sync.Mutex
+	sessions    *sessionMonitor
 
 	// Mapping of event ids to subscriptions
 	eventMap *safeSubscriptionMap
@@ -195,10 +391,32 @@ type Sensor struct {
 
 	// Runtime options configured during NewSensor, but not used until
 	// later
-	runtimeDir         string
-	dockerContainerDir string
-	ociContainerDir    string
-	cgroupNames        []string
+	runtimeDir          string
+	dockerContainerDir  string
+	dockerBackend       string
+	dockerSocketPath    string
+	ociContainerDir     string
+	ociHookSocketPath   string
+	cgroupNames         []string
+	useBPFCgroupFilter  bool
+	useContainerCgroups bool
+	useAuditBackend     bool
+	wtmpPath            string
+	kernelBTFPath       string
+	ringBufferNumPages  int
+	execStackTraces     bool
+
+	// The sensor-wide policy of which containers are monitored, or nil
+	// if all containers are
+	containerPolicy *containerPolicy
+
+	// The EventFilter fields whose events are removed from every
+	// subscription, or nil if none are
+	disabledEventFilters map[string]bool
+
+	// The in-kernel cgroup filter attached to the event monitor's
+	// tracing events, if one is in use
+	cgroupFilter *cgroupFilter
 
 	// Cleanup functions to be run (in reverse order) when the sensor is
 	// stopped.
@@ -213,10 +431,25 @@ type queuedSamples struct {
 // NewSensor creates a new Sensor instance.
 func NewSensor(options ...NewSensorOption) (*Sensor, error) {
 	opts := newSensorOptions{
-		runtimeDir:         config.Global.RunDir,
-		dockerContainerDir: config.Sensor.DockerContainerDir,
-		ociContainerDir:    config.Sensor.OciContainerDir,
-		cgroupNames:        config.Sensor.CgroupName,
+		runtimeDir:           config.Global.RunDir,
+		dockerContainerDir:   config.Sensor.DockerContainerDir,
+		dockerBackend:        config.Sensor.DockerBackend,
+		dockerSocketPath:     config.Sensor.DockerSocketPath,
+		ociContainerDir:      config.Sensor.OciContainerDir,
+		ociHookSocketPath:    config.Sensor.OciHookSocketPath,
+		cgroupNames:          config.Sensor.CgroupName,
+		useBPFCgroupFilter:   config.Sensor.UseBPFCgroupFilter,
+		useContainerCgroups:  config.Sensor.UseContainerCgroups,
+		useAuditBackend:      config.Sensor.UseAuditBackend,
+		wtmpPath:             config.Sensor.WtmpPath,
+		kernelBTFPath:        config.Sensor.KernelBTFPath,
+		sockCommonV6Offsets:  [2]uint32{config.Sensor.SockCommonV6DaddrOffset, config.Sensor.SockCommonV6RcvSaddrOffset},
+		ringBufferNumPages:   config.Sensor.RingBufferPages,
+		execStackTraces:      config.Sensor.ExecStackTraces,
+		containerAllowlist:   config.Sensor.ContainerAllowlist,
+		containerDenylist:    config.Sensor.ContainerDenylist,
+		enabledEventFilters:  config.Sensor.EnabledEventFilters,
+		disabledEventFilters: config.Sensor.DisabledEventFilters,
 	}
 	for _, option := range options {
 		option(&opts)
@@ -239,6 +472,17 @@ func NewSensor(options ...NewSensorOption) (*Sensor, error) {
 		opts.tracingDir = opts.procFS.TracingDir()
 	}
 
+	policy, err := newContainerPolicy(opts.containerAllowlist,
+		opts.containerDenylist)
+	if err != nil {
+		return nil, err
+	}
+	disabledEventFilters, err := newDisabledEventFilters(
+		opts.enabledEventFilters, opts.disabledEventFilters)
+	if err != nil {
+		return nil, err
+	}
+
 	randomBytes := make([]byte, sensorIDLengthBytes)
 	rand.Read(randomBytes)
 	sensorID := hex.EncodeToString(randomBytes)
@@ -253,7 +497,21 @@ func NewSensor(options ...NewSensorOption) (*Sensor, error) {
 		EventSourceController: opts.eventSourceController,
 		runtimeDir:            opts.runtimeDir,
 		dockerContainerDir:    opts.dockerContainerDir,
+		dockerBackend:         opts.dockerBackend,
+		dockerSocketPath:      opts.dockerSocketPath,
 		ociContainerDir:       opts.ociContainerDir,
+		ociHookSocketPath:     opts.ociHookSocketPath,
+		cgroupNames:           opts.cgroupNames,
+		useBPFCgroupFilter:    opts.useBPFCgroupFilter,
+		useContainerCgroups:   opts.useContainerCgroups,
+		useAuditBackend:       opts.useAuditBackend,
+		wtmpPath:              opts.wtmpPath,
+		kernelBTFPath:         opts.kernelBTFPath,
+		sockCommonV6Offsets:   opts.sockCommonV6Offsets,
+		ringBufferNumPages:    opts.ringBufferNumPages,
+		execStackTraces:       opts.execStackTraces,
+		containerPolicy:       policy,
+		disabledEventFilters:  disabledEventFilters,
 		cleanupFuncs:          opts.cleanupFuncs,
 	}
 	s.dispatchCond = sync.Cond{L: &s.dispatchMutex}
@@ -322,17 +580,56 @@ func (s *Sensor) Start() error {
 	if err != nil {
 		glog.Warning("Could not load kernel symbols: %v", err)
 	}
+	s.resolveKprobeSymbols()
+
+	if len(s.kernelBTFPath) > 0 {
+		s.kernelBTF, err = btf.Load(s.kernelBTFPath)
+		if err != nil {
+			glog.V(1).Infof("Using built-in kernel structure offsets: %v",
+				err)
+			s.kernelBTF = nil
+		}
+	}
 
 	s.ContainerCache = NewContainerCache(s)
+	s.ImageCache = NewImageCache(s)
 	s.ProcessCache = NewProcessInfoCache(s)
 	s.ProcessCache.Start()
+	if config.Sensor.ResolveUserNames {
+		s.userNames = newUserNameCache(s.ProcFS,
+			config.Sensor.UserNameCacheTTL)
+	}
 
-	if len(s.dockerContainerDir) > 0 {
+	if s.dockerBackend == DockerBackendEvents && len(s.dockerSocketPath) > 0 {
+		s.dockerEvents = newDockerEventsMonitor(s, s.dockerSocketPath)
+		if err = s.dockerEvents.start(); err != nil {
+			glog.Warningf("Docker events API monitoring of %s disabled, falling back to %s: %s",
+				s.dockerSocketPath, DockerBackendFilesystem, err)
+			s.dockerEvents = nil
+		}
+	}
+	if s.dockerEvents == nil && len(s.dockerContainerDir) > 0 {
 		s.dockerMonitor = newDockerMonitor(s, s.dockerContainerDir)
 		if s.dockerMonitor != nil {
 			s.dockerMonitor.start()
 		}
 	}
+	if s.dockerEvents != nil || s.dockerMonitor != nil {
+		s.setEventSourceStatus(eventSourceDocker, nil)
+	} else {
+		s.setEventSourceStatus(eventSourceDocker,
+			errors.New("Docker monitoring is disabled"))
+	}
+	if len(s.ociHookSocketPath) > 0 {
+		s.ociHooks, err = newOciHookListener(s, s.ociHookSocketPath)
+		if err != nil {
+			glog.Warningf("OCI hook listener on %s disabled: %s",
+				s.ociHookSocketPath, err)
+		} else {
+			s.ociHooks.start()
+		}
+		s.setEventSourceStatus(eventSourceOciHooks, err)
+	}
 	/* Temporarily disable the OCI monitor until a better means of
 	   supporting it is found.
 	if len(s.ociContainerDir) > 0 {
@@ -344,6 +641,7 @@ func (s *Sensor) Start() error {
 	// Make sure that all events registered with the sensor's event monitor
 	// are active
 	s.Monitor().EnableGroup(0)
+	s.setEventSourceStatus(eventSourcePerf, nil)
 
 	// Start dispatch goroutine(s). We'll just spin one up for now, but we
 	// can run multiples if we want. The sensor needs to keep samples in
@@ -372,12 +670,36 @@ func (s *Sensor) Stop() {
 			s.dispatchMutex.Unlock()
 		}
 	}
+	if s.dockerEvents != nil {
+		s.dockerEvents.stop()
+		s.dockerEvents = nil
+	}
+	if s.ociHooks != nil {
+		s.ociHooks.stop()
+		s.ociHooks = nil
+	}
+	s.auditLock.Lock()
+	if s.audit != nil {
+		s.audit.stop()
+		s.audit = nil
+	}
+	s.auditLock.Unlock()
+	s.sessionLock.Lock()
+	if s.sessions != nil {
+		s.sessions.stop()
+		s.sessions = nil
+	}
+	s.sessionLock.Unlock()
 	if monitor := s.Monitor(); monitor != nil {
 		glog.V(2).Info("Stopping sensor-global EventMonitor")
 		monitor.Close()
 		s.monitor.Store((*perf.EventMonitor)(nil))
 		glog.V(2).Info("Sensor-global EventMonitor stopped successfully")
 	}
+	if s.cgroupFilter != nil {
+		s.cgroupFilter.close()
+		s.cgroupFilter = nil
+	}
 
 	for x := len(s.cleanupFuncs) - 1; x >= 0; x-- {
 		s.cleanupFuncs[x]()
@@ -455,6 +777,18 @@ func (s *Sensor) createEventMonitor() error {
 			perf.WithTracingDir(s.tracingDir))
 	}
 
+	// The perf_event cgroupfs is also used by subscriptions that count
+	// performance events for specific cgroups.
+	if len(s.perfEventDir) > 0 {
+		eventMonitorOptions = append(eventMonitorOptions,
+			perf.WithPerfEventDir(s.perfEventDir))
+	}
+
+	if s.ringBufferNumPages > 0 {
+		eventMonitorOptions = append(eventMonitorOptions,
+			perf.WithRingBufferNumPages(s.ringBufferNumPages))
+	}
+
 	cgroups, pids, err := s.buildMonitorGroups()
 	if err != nil {
 		return err
@@ -464,6 +798,25 @@ func (s *Sensor) createEventMonitor() error {
 		glog.Fatal("Can't create event monitor with no cgroups or pids")
 	}
 
+	// An in-kernel cgroup filter replaces the per-cgroup sources with a
+	// single system-wide set.
+	if s.useBPFCgroupFilter && len(cgroups) > 0 && !s.monitorsSystem() {
+		var filter *cgroupFilter
+		filter, err = newCgroupFilter(s.ProcFS, cgroups)
+		if err != nil {
+			glog.Warningf("Couldn't create BPF cgroup filter on cgroups %s: %s",
+				strings.Join(cgroups, ","), err)
+		} else {
+			glog.V(1).Infof("Filtering events in-kernel on cgroups %s",
+				strings.Join(cgroups, ","))
+			s.cgroupFilter = filter
+			eventMonitorOptions = append(eventMonitorOptions,
+				filter.eventMonitorOptions()...)
+			cgroups = nil
+			pids = []int{-1}
+		}
+	}
+
 	if len(pids) > 0 {
 		glog.V(1).Info("Creating new system-wide event monitor")
 		eventMonitorOptions = append(eventMonitorOptions,
@@ -478,7 +831,6 @@ func (s *Sensor) createEventMonitor() error {
 			strings.Join(cgroups, ","))
 
 		eventMonitorOptions = append(eventMonitorOptions,
-			perf.WithPerfEventDir(s.perfEventDir),
 			perf.WithCgroups(cgroups))
 	}
 
@@ -520,10 +872,51 @@ func (s *Sensor) IsKernelSymbolAvailable(symbol string) bool {
 	ok := true
 	if s.kallsyms != nil {
 		_, ok = s.kallsyms[symbol]
+		if !ok {
+			_, ok = s.kprobeSymbols[symbol]
+		}
 	}
 	return ok
 }
 
+// AvailableKernelSymbols returns the names of the kernel text symbols in the
+// running kernel that may be used with kprobes, sorted by name. If the kallsyms
+// table could not be loaded, the result is nil.
+func (s *Sensor) AvailableKernelSymbols() []string {
+	if s.kallsyms == nil {
+		return nil
+	}
+	symbols := make([]string, 0, len(s.kallsyms))
+	for symbol := range s.kallsyms {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// AvailableTracepoints returns the names of the tracepoints in the running
+// kernel, sorted by name in the "group/name" form used to register them.
+// Probes created by sensors are not included.
+func (s *Sensor) AvailableTracepoints() ([]string, error) {
+	data, err := ioutil.ReadFile(
+		filepath.Join(s.tracingDir, "available_events"))
+	if err != nil {
+		return nil, err
+	}
+
+	var tracepoints []string
+	for _, line := range strings.Split(string(data), "\n") {
+		// Each line is of the form "group:name"
+		parts := strings.SplitN(strings.TrimSpace(line), ":", 2)
+		if len(parts) != 2 || parts[0] == "capsule8" {
+			continue
+		}
+		tracepoints = append(tracepoints, parts[0]+"/"+parts[1])
+	}
+	sort.Strings(tracepoints)
+	return tracepoints, nil
+}
+
 // ActualKernelSymbol returns the actual kernel symbol to use. For some symbols,
 // the linker does some rewriting and system calls have different prefixes in
 // Linux 4.17+ kernels.
@@ -536,11 +929,12 @@ func (s *Sensor) ActualKernelSymbol(symbol string) (string, error) {
 				return actual, nil
 			}
 		} else if strings.HasPrefix(symbol, "sys_") {
-			// Linux 4.17 changes how syscall handlers are done. It adds a `__x64_`
-			// prefix and also changes how arguments are handled in the syscall handler.
-			// Automatically try to prepend `__x64_` if we're registering a kprobe
-			// on a syscall handler, and if it succeeds, rewrite the kprobe fetch args.
-			if actual, ok := s.kallsyms["__x64_"+symbol]; ok {
+			// Linux 4.17 changes how syscall handlers are done. It adds an
+			// architecture prefix (i.e. `__x64_`) and also changes how arguments
+			// are handled in the syscall handler. Automatically try to prepend
+			// the prefix if we're registering a kprobe on a syscall handler, and
+			// if it succeeds, rewrite the kprobe fetch args.
+			if actual, ok := s.kallsyms[syscallHandlerPrefix+symbol]; ok {
 				glog.V(2).Infof("Using %q for kprobe symbol %q",
 					actual, symbol)
 				return actual, nil
@@ -552,24 +946,6 @@ func (s *Sensor) ActualKernelSymbol(symbol string) (string, error) {
 	return symbol, nil
 }
 
-// Map for rewriting kprobe fetch args in kernel 4.17+
-// N.B. %di must come first to avoid replacing a %di in an already replaced
-// expression.
-// N.B. %cx actually needs to be replaced with pt_regs->r10. Since the syscall
-// handlers used to have "real" arguments, registers were setup according to the
-// x64 _C_ ABI, however now the syscalls only get a pointer to the register state
-// at the time the syscall entered, which means the registers are setup in the
-// x64 _syscall_ ABI.
-var fetchArgsReplacements = [][2]string{
-	{"%di", "+0x70(%di)"}, // pt_regs+0x70
-	{"%si", "+0x68(%di)"},
-	{"%dx", "+0x60(%di)"},
-	{"%cx", "+0x38(%di)"}, // This is actually replacing RCX with R10
-	{"%r8", "+0x48(%di)"},
-	{"%r9", "+0x40(%di)"},
-	{"%ax", "+0x50(%di)"},
-}
-
 func rewriteSyscallFetchargs(fetchargs string) string {
 	// rewrite `output` (the kprobe fetch args) to account for
 	// the only argument to the syscall handler being `pt_regs *regs`
@@ -582,6 +958,17 @@ func rewriteSyscallFetchargs(fetchargs string) string {
 	return fetchargs
 }
 
+// translateFetchargRegisters rewrites the x86_64 register names in kprobe and
+// uprobe fetch args to the registers that hold the same function arguments on
+// the architecture that the sensor is running on.
+func translateFetchargRegisters(fetchargs string) string {
+	for _, rewritePair := range fetchargRegisterReplacements {
+		fetchargs = strings.Replace(fetchargs, rewritePair[0],
+			rewritePair[1], -1)
+	}
+	return fetchargs
+}
+
 // RegisterKprobe registers a kprobe with the sensor's EventMonitor instance,
 // but before doing so, ensures that the kernel symbol is available and potentially
 // transforms it to account for new kernel changes.
@@ -592,13 +979,16 @@ func (s *Sensor) RegisterKprobe(
 	fn perf.TraceEventDecoderFn,
 	options ...perf.RegisterEventOption,
 ) (uint64, error) {
+	output = s.relocateKprobeFetchargs(address, output)
+	address, output = s.alternativeKprobeSymbol(address, output)
 	address, err := s.ActualKernelSymbol(address)
 	if err != nil {
 		return 0, err
 	}
-	if strings.HasPrefix(address, "__x64_sys_") {
+	if strings.HasPrefix(address, syscallHandlerPrefix+"sys_") {
 		output = rewriteSyscallFetchargs(output)
 	}
+	output = translateFetchargRegisters(output)
 	return s.Monitor().RegisterKprobe(address, onReturn, output, fn, options...)
 }
 
@@ -613,9 +1003,10 @@ func (s *Sensor) NewSubscription() *Subscription {
 	// when it's so easy to handle otherwise during the subscription
 	// window.
 	return &Subscription{
-		sensor:         s,
-		subscriptionID: subscriptionID,
-		dispatchFn:     func(e TelemetryEvent) {},
+		sensor:                s,
+		subscriptionID:        subscriptionID,
+		dispatchFn:            func(e TelemetryEvent) {},
+		lostEventsWindowStart: sys.CurrentMonotonicRaw() - s.bootMonotimeNanos,
 	}
 }
 
@@ -660,17 +1051,49 @@ func (s *Sensor) popSamples() []perf.EventMonitorSample {
 }
 
 func (s *Sensor) dispatchQueuedSamples(samples []perf.EventMonitorSample) {
+	var (
+		lost     map[*Subscription]uint64
+		lostTime uint64
+	)
+
 	eventMap := s.eventMap.getMap()
 	for _, esm := range samples {
 		if esm.Err != nil {
+			atomic.AddUint64(&s.Metrics.DecodeErrors, 1)
 			glog.Warning(esm.Err)
 			continue
 		}
 
+		// Lost records are totalled for each subscription with an
+		// event sink for the event and reported after the batch.
+		if lr, ok := esm.RawSample.Record.(*perf.LostRecord); ok {
+			atomic.AddUint64(&s.Metrics.LostEvents, lr.Lost)
+			for _, es := range eventMap[esm.EventID] {
+				if lost == nil {
+					lost = make(map[*Subscription]uint64)
+				}
+				lost[es.subscription] += lr.Lost
+			}
+			if esm.RawSample.Time > lostTime {
+				lostTime = esm.RawSample.Time
+			}
+			continue
+		}
+
 		event, ok := esm.DecodedSample.(TelemetryEvent)
 		if !ok || event == nil {
 			continue
 		}
+		counters := s.eventCounts.get(event)
+		atomic.AddUint64(&counters.received, 1)
+
+		// Events from containers that are not monitored are never
+		// seen by subscriptions
+		data := event.CommonTelemetryEventData()
+		if !s.containerPolicy.monitored(data.Container) {
+			counters.addFiltered()
+			continue
+		}
 
 		eventSinks, ok := eventMap[esm.EventID]
 		if !ok {
@@ -678,26 +1101,43 @@ func (s *Sensor) dispatchQueuedSamples(samples []perf.EventMonitorSample) {
 		}
 
 		for _, es := range eventSinks {
+			subscr := es.subscription
 			if es.filter != nil {
 				v, err := es.filter.Evaluate(
 					es.filterTypes,
 					expression.FieldValueMap(esm.DecodedData))
 				if err != nil {
 					glog.V(1).Infof("Expression evaluation error: %s", err)
+					counters.addErrored()
+					subscr.counters.addErrored()
 					continue
 				}
 				if !expression.IsValueTrue(v) {
+					counters.addFiltered()
+					subscr.counters.addFiltered()
 					continue
 				}
 			}
-			subscr := es.subscription
-			containerInfo := event.CommonTelemetryEventData().Container
-			if !subscr.containerFilter.Match(containerInfo) {
+			if !subscr.containerFilter.Match(data.Container) ||
+				(subscr.sampler != nil && !subscr.sampler.sample()) {
+				counters.addFiltered()
+				subscr.counters.addFiltered()
+				continue
+			}
+			if subscr.rateLimiter != nil &&
+				!subscr.rateLimiter.allow(data.MonotimeNanos) {
+				atomic.AddUint64(&s.Metrics.RateLimitedEvents, 1)
+				counters.addDropped()
+				subscr.counters.addDropped()
 				continue
 			}
 			subscr.dispatchFn(event)
 		}
 	}
+
+	for subscr, count := range lost {
+		subscr.dispatchLostEvents(count, lostTime)
+	}
 }
 
 func (s *Sensor) sampleDispatchLoop() {
@@ -157,6 +157,18 @@ format:
 	field:__data_loc char[] argv5;	offset:40;	size:4;	signed:1;
 
 print fmt: "(%lx) filename=\"%s\" argv0=\"%s\" argv1=\"%s\" argv2=\"%s\" argv3=\"%s\" argv4=\"%s\" argv5=\"%s\"", REC->__probe_ip, __get_str(filename), __get_str(argv0), __get_str(argv1), __get_str(argv2), __get_str(argv3), __get_str(argv4), __get_str(argv5)`,
+	"execfile": `name: sensor_^^PID^^_execfile
+ID: 1628
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:__data_loc char[] name;	offset:16;	size:4;	signed:1;
+
+print fmt: "(%lx) name=\"%s\"", REC->__probe_ip, __get_str(name)`,
 	"cgroups1": `name: sensor_^^PID^^_cgroups1
 ID: 1625
 format:
@@ -283,6 +295,8 @@ func newUnstartedUnitTestSensor(t *testing.T) *Sensor {
 
 	kprobeEvents := filepath.Join(tracingDir, "kprobe_events")
 	writeFile(t, kprobeEvents, []byte{})
+	uprobeEvents := filepath.Join(tracingDir, "uprobe_events")
+	writeFile(t, uprobeEvents, []byte{})
 
 	sourceDir := filepath.Join("testdata", "events")
 	targetDir := filepath.Join(tracingDir, "events")
@@ -305,6 +319,7 @@ func newUnstartedUnitTestSensor(t *testing.T) *Sensor {
 		WithProcFileSystem(procFS),
 		WithEventSourceController(perf.NewStubEventSourceController()),
 		WithTracingDir(tracingDir),
+		WithKernelBTFPath(""),
 		WithCleanupFunc(func() { os.RemoveAll(runtimeDir) }))
 	require.NoError(t, err)
 
@@ -333,20 +348,44 @@ func TestNewSensorOptions(t *testing.T) {
 		perfEventDir:          "perfEventDir",
 		tracingDir:            "tracingDir",
 		dockerContainerDir:    "dockerContainerDir",
+		dockerBackend:         DockerBackendEvents,
+		dockerSocketPath:      "dockerSocketPath",
 		ociContainerDir:       "ociContainerDir",
+		ociHookSocketPath:     "ociHookSocketPath",
 		procFS:                procFS,
 		eventSourceController: perf.NewStubEventSourceController(),
 		cgroupNames:           []string{"abc", "def", "ghi"},
+		useBPFCgroupFilter:    true,
+		useContainerCgroups:   true,
+		kernelBTFPath:         "kernelBTFPath",
+		ringBufferNumPages:    64,
+		execStackTraces:       true,
+		containerAllowlist:    []string{"image:nginx:*"},
+		containerDenylist:     []string{"name:*-istio-proxy"},
+		enabledEventFilters:   []string{"process_events"},
+		disabledEventFilters:  []string{"tty_events"},
 	}
 
 	options := []NewSensorOption{
 		WithRuntimeDir(expOptions.runtimeDir),
 		WithDockerContainerDir(expOptions.dockerContainerDir),
+		WithDockerBackend(expOptions.dockerBackend),
+		WithDockerSocketPath(expOptions.dockerSocketPath),
 		WithOciContainerDir(expOptions.ociContainerDir),
+		WithOciHookSocketPath(expOptions.ociHookSocketPath),
 		WithProcFileSystem(expOptions.procFS),
 		WithEventSourceController(expOptions.eventSourceController),
 		WithPerfEventDir(expOptions.perfEventDir),
 		WithTracingDir(expOptions.tracingDir),
+		WithBPFCgroupFilter(expOptions.useBPFCgroupFilter),
+		WithContainerCgroups(expOptions.useContainerCgroups),
+		WithKernelBTFPath(expOptions.kernelBTFPath),
+		WithRingBufferNumPages(expOptions.ringBufferNumPages),
+		WithExecStackTraces(expOptions.execStackTraces),
+		WithContainerAllowlist(expOptions.containerAllowlist),
+		WithContainerDenylist(expOptions.containerDenylist),
+		WithEnabledEventFilters(expOptions.enabledEventFilters),
+		WithDisabledEventFilters(expOptions.disabledEventFilters),
 	}
 	for _, n := range expOptions.cgroupNames {
 		options = append(options, WithCgroupName(n))
@@ -384,14 +423,15 @@ func TestActualKernelSymbol(t *testing.T) {
 	s := Sensor{}
 	s.kallsyms = map[string]string{
 		"create_dev":           "create_dev.constprop.6",
-		"__x64_sys_setuid":     "__x64_sys_setuid",
 		"__cgroup_procs_write": "__cgroup_procs_write",
+
+		syscallHandlerPrefix + "sys_setuid": syscallHandlerPrefix + "sys_setuid",
 	}
 
 	tests := map[string]string{
 		"__cgroup_procs_write": "__cgroup_procs_write",
 		"create_dev":           "create_dev.constprop.6",
-		"sys_setuid":           "__x64_sys_setuid",
+		"sys_setuid":           syscallHandlerPrefix + "sys_setuid",
 	}
 	for sym, exp := range tests {
 		got, err := s.ActualKernelSymbol(sym)
@@ -401,26 +441,40 @@ func TestActualKernelSymbol(t *testing.T) {
 	}
 }
 
-func TestRewriteSyscallFetchargs(t *testing.T) {
-	args := map[string]string{
-		"a=+0(%di):string": "a=+0(+0x70(%di)):string",
-		"b=%si:s32":        "b=+0x68(%di):s32",
-		"c=%dx:u64":        "c=+0x60(%di):u64",
-		"d=%cx:u16":        "d=+0x38(%di):u16",
-		"e=%r8:s8":         "e=+0x48(%di):s8",
-		"f=+0(%r9):string": "f=+0(+0x40(%di)):string",
-		"g=%ax:s32":        "g=+0x50(%di):s32",
-	}
+func TestAvailableKernelSymbols(t *testing.T) {
+	s := Sensor{}
+	assert.Nil(t, s.AvailableKernelSymbols())
 
-	var inputArray, expArray []string
-	for k, v := range args {
-		inputArray = append(inputArray, k)
-		expArray = append(expArray, v)
+	s.kallsyms = map[string]string{
+		"create_dev":           "create_dev.constprop.6",
+		"__x64_sys_setuid":     "__x64_sys_setuid",
+		"__cgroup_procs_write": "__cgroup_procs_write",
 	}
-	input := strings.Join(inputArray, " ")
-	exp := strings.Join(expArray, " ")
-	got := rewriteSyscallFetchargs(input)
-	assert.Equal(t, exp, got)
+	assert.Equal(t, []string{"__cgroup_procs_write", "__x64_sys_setuid",
+		"create_dev"}, s.AvailableKernelSymbols())
+}
+
+func TestAvailableTracepoints(t *testing.T) {
+	tracingDir, err := ioutil.TempDir("", "capsule8_")
+	require.NoError(t, err)
+	defer os.RemoveAll(tracingDir)
+
+	s := Sensor{tracingDir: tracingDir}
+	_, err = s.AvailableTracepoints()
+	assert.Error(t, err)
+
+	events := `sched:sched_process_fork
+capsule8:sensor_1234_1
+syscalls:sys_enter_connect
+raw_syscalls:sys_enter
+
+`
+	writeFile(t, filepath.Join(tracingDir, "available_events"),
+		[]byte(events))
+	got, err := s.AvailableTracepoints()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"raw_syscalls/sys_enter",
+		"sched/sched_process_fork", "syscalls/sys_enter_connect"}, got)
 }
 
 func TestNewSubscription(t *testing.T) {
@@ -0,0 +1,406 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/golang/glog"
+)
+
+// SessionEventTypes defines the field types that can be used with filters on
+// session telemetry events.
+var SessionEventTypes = expression.FieldTypeMap{
+	"user":       expression.ValueTypeString,
+	"tty":        expression.ValueTypeString,
+	"host":       expression.ValueTypeString,
+	"address":    expression.ValueTypeString,
+	"session_id": expression.ValueTypeSignedInt32,
+}
+
+// SessionTelemetryEventData is the data common to all session telemetry
+// events.
+type SessionTelemetryEventData struct {
+	User      string
+	TTY       string
+	Host      string
+	Address   string
+	SessionID int32
+}
+
+func (ted *SessionTelemetryEventData) initWithSample(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) {
+	ted.User = data["user"].(string)
+	ted.TTY = data["tty"].(string)
+	ted.Host = data["host"].(string)
+	ted.Address = data["address"].(string)
+	ted.SessionID = data["session_id"].(int32)
+}
+
+// SessionLoginTelemetryEvent is a telemetry event generated by the session
+// event source when a user logs in to the host. The process information is
+// that of the session's login process.
+type SessionLoginTelemetryEvent struct {
+	TelemetryEventData
+	SessionTelemetryEventData
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a session login telemetry event.
+func (e SessionLoginTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// SessionLogoutTelemetryEvent is a telemetry event generated by the session
+// event source when a user logs out of the host. The process information is
+// that of the session's login process.
+type SessionLogoutTelemetryEvent struct {
+	TelemetryEventData
+	SessionTelemetryEventData
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a session logout telemetry event.
+func (e SessionLogoutTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// struct utmp from glibc's bits/utmp.h, as written to wtmp on 64-bit Linux
+const (
+	utmpRecordSize = 384
+
+	utmpTypeOffset    = 0
+	utmpPIDOffset     = 4
+	utmpLineOffset    = 8
+	utmpLineSize      = 32
+	utmpUserOffset    = 44
+	utmpUserSize      = 32
+	utmpHostOffset    = 76
+	utmpHostSize      = 256
+	utmpSessionOffset = 336
+	utmpAddressOffset = 348
+	utmpAddressSize   = 16
+
+	utmpTypeBootTime    = 2
+	utmpTypeUserProcess = 7
+	utmpTypeDeadProcess = 8
+)
+
+// wtmp is only ever appended to, so it is polled for new records rather than
+// watched.
+const sessionPollInterval = time.Second
+
+// utmpRecord is a single login accounting record.
+type utmpRecord struct {
+	Type      int16
+	PID       int32
+	Line      string
+	User      string
+	Host      string
+	SessionID int32
+	Address   string
+}
+
+func utmpString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
+
+// utmpAddress formats the remote address of a record. IPv4 addresses only
+// use the first of the four words.
+func utmpAddress(b []byte) string {
+	v4 := true
+	for _, c := range b[4:] {
+		if c != 0 {
+			v4 = false
+			break
+		}
+	}
+	if v4 {
+		if binary.LittleEndian.Uint32(b[:4]) == 0 {
+			return ""
+		}
+		return net.IP(b[:4]).String()
+	}
+	return net.IP(b).String()
+}
+
+func parseUtmpRecord(b []byte) utmpRecord {
+	return utmpRecord{
+		Type:      int16(binary.LittleEndian.Uint16(b[utmpTypeOffset:])),
+		PID:       int32(binary.LittleEndian.Uint32(b[utmpPIDOffset:])),
+		Line:      utmpString(b[utmpLineOffset : utmpLineOffset+utmpLineSize]),
+		User:      utmpString(b[utmpUserOffset : utmpUserOffset+utmpUserSize]),
+		Host:      utmpString(b[utmpHostOffset : utmpHostOffset+utmpHostSize]),
+		SessionID: int32(binary.LittleEndian.Uint32(b[utmpSessionOffset:])),
+		Address:   utmpAddress(b[utmpAddressOffset : utmpAddressOffset+utmpAddressSize]),
+	}
+}
+
+// sessionMonitor follows wtmp and enqueues login and logout events as
+// records are appended to it.
+type sessionMonitor struct {
+	sensor        *Sensor
+	path          string
+	loginEventID  uint64
+	logoutEventID uint64
+
+	// The offset of the next record to read and the login records of
+	// the sessions that are open, keyed by terminal line. These are only
+	// used by the polling goroutine once it has started.
+	offset   int64
+	sessions map[string]utmpRecord
+
+	done      chan struct{}
+	waitGroup sync.WaitGroup
+}
+
+// newSessionMonitor creates a session monitor for the specified wtmp file.
+// The records already in the file are read to learn which sessions are open,
+// but no events are generated for them.
+func newSessionMonitor(sensor *Sensor, path string) (*sessionMonitor, error) {
+	m := &sessionMonitor{
+		sensor:   sensor,
+		path:     path,
+		sessions: make(map[string]utmpRecord),
+		done:     make(chan struct{}),
+	}
+	if err := m.poll(false); err != nil {
+		return nil, err
+	}
+
+	monitor := sensor.Monitor()
+	m.loginEventID = monitor.RegisterExternalEvent("SESSION_LOGIN",
+		sensor.decodeSessionLogin)
+	m.logoutEventID = monitor.RegisterExternalEvent("SESSION_LOGOUT",
+		sensor.decodeSessionLogout)
+
+	return m, nil
+}
+
+func (m *sessionMonitor) start() {
+	m.waitGroup.Add(1)
+	go func() {
+		defer m.waitGroup.Done()
+
+		ticker := time.NewTicker(sessionPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.done:
+				return
+			case <-ticker.C:
+				if err := m.poll(true); err != nil {
+					glog.V(1).Infof("Could not read %s: %v",
+						m.path, err)
+				}
+			}
+		}
+	}()
+}
+
+func (m *sessionMonitor) stop() {
+	close(m.done)
+	m.waitGroup.Wait()
+}
+
+// poll reads the records that have been appended to wtmp since the last
+// poll. A file that has shrunk is assumed to have been rotated and is read
+// from the beginning.
+func (m *sessionMonitor) poll(enqueue bool) error {
+	f, err := os.Open(m.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := fi.Size()
+	if size < m.offset {
+		m.offset = 0
+	}
+
+	// A record that is only partially written is read at the next poll.
+	n := (size - m.offset) / utmpRecordSize * utmpRecordSize
+	if n == 0 {
+		return nil
+	}
+	buf := make([]byte, n)
+	if _, err = f.ReadAt(buf, m.offset); err != nil && err != io.EOF {
+		return err
+	}
+	m.offset += n
+
+	sampleID := perf.SampleID{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	for b := buf; len(b) >= utmpRecordSize; b = b[utmpRecordSize:] {
+		m.handleRecord(sampleID, parseUtmpRecord(b), enqueue)
+	}
+	return nil
+}
+
+func (m *sessionMonitor) handleRecord(
+	sampleID perf.SampleID,
+	r utmpRecord,
+	enqueue bool,
+) {
+	var eventID uint64
+	switch r.Type {
+	case utmpTypeBootTime:
+		// Every session ends when the system reboots
+		m.sessions = make(map[string]utmpRecord)
+		return
+	case utmpTypeUserProcess:
+		m.sessions[r.Line] = r
+		eventID = m.loginEventID
+	case utmpTypeDeadProcess:
+		// Logout records normally only identify the terminal line, so
+		// the rest comes from the matching login record.
+		login, ok := m.sessions[r.Line]
+		if !ok {
+			return
+		}
+		delete(m.sessions, r.Line)
+		if r.PID == 0 {
+			r.PID = login.PID
+		}
+		r.User, r.Host, r.Address = login.User, login.Host, login.Address
+		r.SessionID = login.SessionID
+		eventID = m.logoutEventID
+	default:
+		return
+	}
+	if !enqueue {
+		return
+	}
+
+	data := perf.TraceEventSampleData{
+		"common_pid": r.PID,
+		"user":       r.User,
+		"tty":        r.Line,
+		"host":       r.Host,
+		"address":    r.Address,
+		"session_id": r.SessionID,
+	}
+	err := m.sensor.Monitor().EnqueueExternalSample(eventID, sampleID, data)
+	if err != nil {
+		glog.V(1).Infof("Could not enqueue session event: %v", err)
+	}
+}
+
+func (s *Sensor) decodeSessionLogin(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e SessionLoginTelemetryEvent
+	if !e.InitWithSample(s, sample, data) {
+		return nil, nil
+	}
+	e.SessionTelemetryEventData.initWithSample(sample, data)
+	return e, nil
+}
+
+func (s *Sensor) decodeSessionLogout(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e SessionLogoutTelemetryEvent
+	if !e.InitWithSample(s, sample, data) {
+		return nil, nil
+	}
+	e.SessionTelemetryEventData.initWithSample(sample, data)
+	return e, nil
+}
+
+// sessionMonitor returns the sensor's session monitor, creating and starting
+// it the first time it is needed.
+func (s *Sensor) sessionMonitor() (*sessionMonitor, error) {
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
+
+	if s.sessions == nil {
+		if len(s.wtmpPath) == 0 {
+			return nil, errors.New("Session monitoring is disabled")
+		}
+		m, err := newSessionMonitor(s, s.wtmpPath)
+		if err != nil {
+			return nil, err
+		}
+		s.sessions = m
+		s.sessions.start()
+	}
+	return s.sessions, nil
+}
+
+func (s *Subscription) registerSessionEventFilter(
+	login bool,
+	expr *expression.Expression,
+) {
+	if expr != nil {
+		if err := expr.Validate(SessionEventTypes); err != nil {
+			s.logStatus(
+				fmt.Sprintf("Invalid session filter expression: %v", err))
+			return
+		}
+	}
+
+	m, err := s.sensor.sessionMonitor()
+	if err != nil {
+		s.logStatus(
+			fmt.Sprintf("Could not monitor login sessions: %v", err))
+		return
+	}
+	eventID := m.logoutEventID
+	if login {
+		eventID = m.loginEventID
+	}
+	if _, err = s.addEventSink(eventID, expr, SessionEventTypes); err != nil {
+		s.logStatus(
+			fmt.Sprintf("Invalid session filter expression: %v", err))
+	}
+}
+
+// RegisterSessionLoginEventFilter registers a session login event filter with
+// a subscription.
+func (s *Subscription) RegisterSessionLoginEventFilter(expr *expression.Expression) {
+	s.registerSessionEventFilter(true, expr)
+}
+
+// RegisterSessionLogoutEventFilter registers a session logout event filter
+// with a subscription.
+func (s *Subscription) RegisterSessionLogoutEventFilter(expr *expression.Expression) {
+	s.registerSessionEventFilter(false, expr)
+}
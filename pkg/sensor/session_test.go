@@ -0,0 +1,225 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUtmpRecord(r utmpRecord, address net.IP) []byte {
+	b := make([]byte, utmpRecordSize)
+	binary.LittleEndian.PutUint16(b[utmpTypeOffset:], uint16(r.Type))
+	binary.LittleEndian.PutUint32(b[utmpPIDOffset:], uint32(r.PID))
+	copy(b[utmpLineOffset:utmpLineOffset+utmpLineSize], r.Line)
+	copy(b[utmpUserOffset:utmpUserOffset+utmpUserSize], r.User)
+	copy(b[utmpHostOffset:utmpHostOffset+utmpHostSize], r.Host)
+	binary.LittleEndian.PutUint32(b[utmpSessionOffset:], uint32(r.SessionID))
+	if v4 := address.To4(); v4 != nil {
+		copy(b[utmpAddressOffset:], v4)
+	} else {
+		copy(b[utmpAddressOffset:], address)
+	}
+	return b
+}
+
+func newTestWtmpFile(t *testing.T, records ...[]byte) string {
+	f, err := ioutil.TempFile("", "capsule8_wtmp_")
+	require.NoError(t, err)
+	defer f.Close()
+
+	for _, r := range records {
+		_, err = f.Write(r)
+		require.NoError(t, err)
+	}
+	return f.Name()
+}
+
+func appendTestWtmpFile(t *testing.T, path string, records ...[]byte) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	defer f.Close()
+
+	for _, r := range records {
+		_, err = f.Write(r)
+		require.NoError(t, err)
+	}
+}
+
+func TestParseUtmpRecord(t *testing.T) {
+	expected := utmpRecord{
+		Type:      utmpTypeUserProcess,
+		PID:       111343,
+		Line:      "pts/0",
+		User:      "alice",
+		Host:      "10.0.0.5",
+		SessionID: 12,
+		Address:   "10.0.0.5",
+	}
+	r := parseUtmpRecord(newTestUtmpRecord(expected, net.ParseIP("10.0.0.5")))
+	assert.Equal(t, expected, r)
+
+	expected.Address = "2001:db8::1"
+	r = parseUtmpRecord(newTestUtmpRecord(expected, net.ParseIP("2001:db8::1")))
+	assert.Equal(t, expected, r)
+
+	// Local sessions have no address
+	expected.Line = "tty1"
+	expected.Host = ""
+	expected.Address = ""
+	r = parseUtmpRecord(newTestUtmpRecord(expected, nil))
+	assert.Equal(t, expected, r)
+}
+
+func TestSessionMonitorPoll(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	login := utmpRecord{
+		Type: utmpTypeUserProcess,
+		PID:  111343,
+		Line: "pts/0",
+		User: "alice",
+		Host: "bastion",
+	}
+	path := newTestWtmpFile(t, newTestUtmpRecord(login, net.ParseIP("10.0.0.5")))
+	defer os.Remove(path)
+
+	m, err := newSessionMonitor(sensor, path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(utmpRecordSize), m.offset)
+	require.Contains(t, m.sessions, "pts/0")
+	assert.Equal(t, "alice", m.sessions["pts/0"].User)
+
+	// Partially written records are left for the next poll
+	logout := utmpRecord{Type: utmpTypeDeadProcess, Line: "pts/0"}
+	login.Line = "pts/1"
+	appendTestWtmpFile(t, path,
+		newTestUtmpRecord(logout, nil),
+		newTestUtmpRecord(login, nil),
+		make([]byte, 10))
+	err = m.poll(true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3*utmpRecordSize), m.offset)
+	assert.NotContains(t, m.sessions, "pts/0")
+	assert.Contains(t, m.sessions, "pts/1")
+
+	// A rotated file is read from the beginning
+	login.Line = "pts/2"
+	err = ioutil.WriteFile(path, newTestUtmpRecord(login, nil), 0644)
+	require.NoError(t, err)
+	err = m.poll(true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(utmpRecordSize), m.offset)
+	assert.Contains(t, m.sessions, "pts/1")
+	assert.Contains(t, m.sessions, "pts/2")
+
+	// A reboot ends every session
+	appendTestWtmpFile(t, path,
+		newTestUtmpRecord(utmpRecord{Type: utmpTypeBootTime, Line: "~"}, nil))
+	err = m.poll(true)
+	require.NoError(t, err)
+	assert.Len(t, m.sessions, 0)
+
+	_, err = newSessionMonitor(sensor, path+".missing")
+	assert.Error(t, err)
+}
+
+func TestDecodeSessionEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(405),
+		"user":       "alice",
+		"tty":        "pts/0",
+		"host":       "bastion",
+		"address":    "10.0.0.5",
+		"session_id": int32(12),
+	}
+
+	i, err := sensor.decodeSessionLogin(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, SessionLoginTelemetryEvent{}, i)
+
+	e := i.(SessionLoginTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, 405, e.PID)
+	assert.Equal(t, "alice", e.User)
+	assert.Equal(t, "pts/0", e.TTY)
+	assert.Equal(t, "bastion", e.Host)
+	assert.Equal(t, "10.0.0.5", e.Address)
+	assert.Equal(t, int32(12), e.SessionID)
+
+	i, err = sensor.decodeSessionLogout(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, SessionLogoutTelemetryEvent{}, i)
+
+	data["common_pid"] = int32(sensorPID)
+	i, err = sensor.decodeSessionLogout(sample, data)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+}
+
+func TestSessionEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	path := newTestWtmpFile(t)
+	defer os.Remove(path)
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("user"),
+		expression.Value("root"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	// Session monitoring is disabled without a wtmp path
+	sensor.wtmpPath = ""
+	s.RegisterSessionLoginEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+
+	sensor.wtmpPath = path
+	s = newTestSubscription(t, sensor)
+	s.RegisterSessionLoginEventFilter(expr)
+	s.RegisterSessionLogoutEventFilter(nil)
+	assert.Len(t, s.eventSinks, 2)
+	assert.Len(t, s.status, 0)
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterSessionLogoutEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
@@ -0,0 +1,145 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// SignalGenerateEventTypes defines the field types that can be used with
+// filters on signal generate telemetry events.
+var SignalGenerateEventTypes = expression.FieldTypeMap{
+	"sig":    expression.ValueTypeSignedInt32,
+	"errno":  expression.ValueTypeSignedInt32,
+	"code":   expression.ValueTypeSignedInt32,
+	"pid":    expression.ValueTypeSignedInt32,
+	"group":  expression.ValueTypeSignedInt32,
+	"result": expression.ValueTypeSignedInt32,
+}
+
+// SignalDeliverEventTypes defines the field types that can be used with
+// filters on signal deliver telemetry events.
+var SignalDeliverEventTypes = expression.FieldTypeMap{
+	"sig":        expression.ValueTypeSignedInt32,
+	"errno":      expression.ValueTypeSignedInt32,
+	"code":       expression.ValueTypeSignedInt32,
+	"sa_handler": expression.ValueTypeUnsignedInt64,
+	"sa_flags":   expression.ValueTypeUnsignedInt64,
+}
+
+// SignalGenerateTelemetryEvent is a telemetry event generated by the signal
+// event source when a signal is sent. The process information is that of the
+// sender.
+type SignalGenerateTelemetryEvent struct {
+	TelemetryEventData
+
+	Signal int32
+	Errno  int32
+	Code   int32
+
+	TargetPID         int32
+	TargetProcessID   string
+	TargetContainerID string
+	Group             bool
+	Result            int32
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a signal generate telemetry event.
+func (e SignalGenerateTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// SignalDeliverTelemetryEvent is a telemetry event generated by the signal
+// event source when a signal is delivered. The process information is that of
+// the receiver.
+type SignalDeliverTelemetryEvent struct {
+	TelemetryEventData
+
+	Signal int32
+	Errno  int32
+	Code   int32
+
+	SaHandler uint64
+	SaFlags   uint64
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a signal deliver telemetry event.
+func (e SignalDeliverTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// signal_generate fires in the context of the sending task, and its pid field
+// is the receiving task. signal_deliver fires in the context of the receiving
+// task just before its handler (or the default action) is run.
+const (
+	signalGenerateTracepoint = "signal/signal_generate"
+	signalDeliverTracepoint  = "signal/signal_deliver"
+)
+
+func (s *Subscription) decodeSignalGenerate(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e SignalGenerateTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Signal = data["sig"].(int32)
+	e.Errno = data["errno"].(int32)
+	e.Code = data["code"].(int32)
+	e.TargetPID = data["pid"].(int32)
+	e.Group = data["group"].(int32) != 0
+	e.Result = data["result"].(int32)
+
+	cache := s.sensor.ProcessCache
+	target := cache.LookupTask(int(e.TargetPID))
+	e.TargetProcessID = target.ProcessID
+	e.TargetContainerID = cache.taskContainerID(target)
+
+	return e, nil
+}
+
+func (s *Subscription) decodeSignalDeliver(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+) (interface{}, error) {
+	var e SignalDeliverTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Signal = data["sig"].(int32)
+	e.Errno = data["errno"].(int32)
+	e.Code = data["code"].(int32)
+	e.SaHandler = data["sa_handler"].(uint64)
+	e.SaFlags = data["sa_flags"].(uint64)
+	return e, nil
+}
+
+// RegisterSignalGenerateEventFilter registers a signal generate event filter
+// with a subscription.
+func (s *Subscription) RegisterSignalGenerateEventFilter(expr *expression.Expression) {
+	s.registerTracepoint(signalGenerateTracepoint, s.decodeSignalGenerate,
+		expr, SignalGenerateEventTypes)
+}
+
+// RegisterSignalDeliverEventFilter registers a signal deliver event filter
+// with a subscription.
+func (s *Subscription) RegisterSignalDeliverEventFilter(expr *expression.Expression) {
+	s.registerTracepoint(signalDeliverTracepoint, s.decodeSignalDeliver,
+		expr, SignalDeliverEventTypes)
+}
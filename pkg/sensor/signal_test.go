@@ -0,0 +1,136 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestDecodeSignalGenerate(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"sig":        int32(unix.SIGKILL),
+		"errno":      int32(0),
+		"code":       int32(0),
+		"pid":        int32(111343),
+		"group":      int32(1),
+		"result":     int32(0),
+	}
+
+	i, err := s.decodeSignalGenerate(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	delete(data, "common_pid")
+	i, err = s.decodeSignalGenerate(sample, data)
+	require.NotNil(t, i)
+	require.NoError(t, err)
+	e, ok := i.(SignalGenerateTelemetryEvent)
+	require.True(t, ok)
+
+	ok = testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, int32(unix.SIGKILL), e.Signal)
+	assert.Equal(t, int32(111343), e.TargetPID)
+	assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+		e.TargetContainerID)
+	assert.True(t, e.Group)
+	assert.Equal(t, int32(0), e.Result)
+}
+
+func TestDecodeSignalDeliver(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"sig":        int32(unix.SIGSEGV),
+		"errno":      int32(0),
+		"code":       int32(1),
+		"sa_handler": uint64(0),
+		"sa_flags":   uint64(0),
+	}
+
+	i, err := s.decodeSignalDeliver(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	delete(data, "common_pid")
+	i, err = s.decodeSignalDeliver(sample, data)
+	require.NotNil(t, i)
+	require.NoError(t, err)
+	e, ok := i.(SignalDeliverTelemetryEvent)
+	require.True(t, ok)
+
+	ok = testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, int32(unix.SIGSEGV), e.Signal)
+	assert.Equal(t, int32(1), e.Code)
+	assert.Equal(t, uint64(0), e.SaHandler)
+}
+
+func verifySignalEventRegistration(t *testing.T, s *Subscription, count int) {
+	if count > 0 {
+		assert.Len(t, s.eventSinks, count)
+	} else {
+		assert.Len(t, s.status, -count)
+		assert.Len(t, s.eventSinks, 0)
+	}
+}
+
+func TestSignalEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	e := expression.Equal(expression.Identifier("foo"), expression.Value("bar"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	registerFuncs := []func(*Subscription, *expression.Expression){
+		(*Subscription).RegisterSignalGenerateEventFilter,
+		(*Subscription).RegisterSignalDeliverEventFilter,
+	}
+	for _, f := range registerFuncs {
+		s := newTestSubscription(t, sensor)
+		f(s, expr)
+		verifySignalEventRegistration(t, s, -1)
+
+		s = newTestSubscription(t, sensor)
+		f(s, nil)
+		verifySignalEventRegistration(t, s, 1)
+	}
+}
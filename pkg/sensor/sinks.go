@@ -0,0 +1,238 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/capsule8/capsule8/pkg/config"
+	"github.com/capsule8/capsule8/pkg/sink"
+)
+
+type namedSink struct {
+	name string
+	sink sink.Sink
+}
+
+func newKafkaSink() (sink.Sink, error) {
+	encoding, err := sink.ParseEncoding(config.Sensor.KafkaEncoding)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := sink.ParseCompression(config.Sensor.KafkaCompression)
+	if err != nil {
+		return nil, err
+	}
+	topics := make(map[string]string)
+	for _, t := range config.Sensor.KafkaTopics {
+		parts := strings.SplitN(t, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("Kafka topic %q is invalid", t)
+		}
+		topics[parts[0]] = parts[1]
+	}
+	return sink.NewKafkaSink(config.Sensor.KafkaBrokers,
+		config.Sensor.KafkaTopic,
+		sink.WithKafkaTopics(topics),
+		sink.WithKafkaPartitionByContainer(config.Sensor.KafkaPartitionByContainer),
+		sink.WithKafkaEncoding(encoding),
+		sink.WithKafkaCompression(compression))
+}
+
+func newSyslogSink() (sink.Sink, error) {
+	encoding, err := sink.ParseEncoding(config.Sensor.SyslogEncoding)
+	if err != nil {
+		return nil, err
+	}
+	options := []sink.SyslogOption{
+		sink.WithSyslogFacility(config.Sensor.SyslogFacility),
+		sink.WithSyslogEncoding(encoding),
+	}
+	if config.Sensor.SyslogTLSCACertPath != "" {
+		ca, err := ioutil.ReadFile(config.Sensor.SyslogTLSCACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read syslog ca certificate: %s", err)
+		}
+		certPool := x509.NewCertPool()
+		if ok := certPool.AppendCertsFromPEM(ca); !ok {
+			return nil, errors.New("failed to append syslog certs")
+		}
+		options = append(options, sink.WithSyslogTLSConfig(&tls.Config{
+			RootCAs: certPool,
+		}))
+	}
+	return sink.NewSyslogSink(config.Sensor.SyslogTransport,
+		config.Sensor.SyslogAddr, options...)
+}
+
+func newElasticsearchSink() (sink.Sink, error) {
+	compression, err := sink.ParseCompression(config.Sensor.ElasticsearchCompression)
+	if err != nil {
+		return nil, err
+	}
+	return sink.NewElasticsearchSink(config.Sensor.ElasticsearchURL,
+		sink.WithElasticsearchIndexPrefix(config.Sensor.ElasticsearchIndexPrefix),
+		sink.WithElasticsearchBasicAuth(config.Sensor.ElasticsearchUsername,
+			config.Sensor.ElasticsearchPassword),
+		sink.WithElasticsearchCompression(compression))
+}
+
+func newWebhookSink() (sink.Sink, error) {
+	encoding, err := sink.ParseEncoding(config.Sensor.WebhookEncoding)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := sink.ParseCompression(config.Sensor.WebhookCompression)
+	if err != nil {
+		return nil, err
+	}
+	headers := make(map[string]string)
+	for _, h := range config.Sensor.WebhookHeaders {
+		parts := strings.SplitN(h, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("webhook header %q is invalid", h)
+		}
+		headers[parts[0]] = parts[1]
+	}
+	return sink.NewWebhookSink(config.Sensor.WebhookURL,
+		sink.WithWebhookSecret(config.Sensor.WebhookSecret),
+		sink.WithWebhookHeaders(headers),
+		sink.WithWebhookEncoding(encoding),
+		sink.WithWebhookDeadLetterPath(config.Sensor.WebhookDeadLetterPath),
+		sink.WithWebhookCompression(compression))
+}
+
+func closeNamedSinks(sinks []namedSink) {
+	for _, s := range sinks {
+		s.sink.Close()
+	}
+}
+
+// configuredSinks creates the sinks that are enabled by the configuration.
+func configuredSinks() ([]namedSink, error) {
+	var sinks []namedSink
+	if len(config.Sensor.KafkaBrokers) > 0 {
+		s, err := newKafkaSink()
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, namedSink{"Kafka", s})
+	}
+	if config.Sensor.JSONSinkPath != "" {
+		encoding, err := sink.ParseEncoding(config.Sensor.JSONSinkEncoding)
+		if err != nil {
+			closeNamedSinks(sinks)
+			return nil, err
+		}
+		s, err := sink.NewFileSink(config.Sensor.JSONSinkPath,
+			sink.WithFileMaxSize(config.Sensor.JSONSinkMaxSize),
+			sink.WithFileMaxFiles(config.Sensor.JSONSinkMaxFiles),
+			sink.WithFileEncoding(encoding))
+		if err != nil {
+			closeNamedSinks(sinks)
+			return nil, err
+		}
+		sinks = append(sinks, namedSink{"JSON", s})
+	}
+	if config.Sensor.SyslogAddr != "" {
+		s, err := newSyslogSink()
+		if err != nil {
+			closeNamedSinks(sinks)
+			return nil, err
+		}
+		sinks = append(sinks, namedSink{"Syslog", s})
+	}
+	if config.Sensor.FluentdAddr != "" {
+		s, err := sink.NewFluentdSink(config.Sensor.FluentdAddr,
+			config.Sensor.FluentdTag,
+			sink.WithFluentdSharedKey(config.Sensor.FluentdSharedKey),
+			sink.WithFluentdUser(config.Sensor.FluentdUsername,
+				config.Sensor.FluentdPassword))
+		if err != nil {
+			closeNamedSinks(sinks)
+			return nil, err
+		}
+		sinks = append(sinks, namedSink{"Fluentd", s})
+	}
+	if config.Sensor.ElasticsearchURL != "" {
+		s, err := newElasticsearchSink()
+		if err != nil {
+			closeNamedSinks(sinks)
+			return nil, err
+		}
+		sinks = append(sinks, namedSink{"Elasticsearch", s})
+	}
+	if config.Sensor.WebhookURL != "" {
+		s, err := newWebhookSink()
+		if err != nil {
+			closeNamedSinks(sinks)
+			return nil, err
+		}
+		sinks = append(sinks, namedSink{"Webhook", s})
+	}
+	return sinks, nil
+}
+
+// startEventSinks starts publishing the events of the configured sink
+// subscription to each of the configured sinks. Each sink has its own
+// subscription so that one that is slow or unavailable does not hold up
+// the others. The subscriptions are closed once ctx is done, and each sink
+// is closed and removed from wg once it has published the events that its
+// subscription held, or once abortCtx is done.
+func startEventSinks(
+	ctx, abortCtx context.Context,
+	sensor *Sensor,
+	wg *sync.WaitGroup,
+) error {
+	sinks, err := configuredSinks()
+	if err != nil || len(sinks) == 0 {
+		return err
+	}
+	if config.Sensor.SinkSubscriptionPath == "" {
+		closeNamedSinks(sinks)
+		return errors.New("Sink subscription path is not set")
+	}
+	sub, err := readSubscriptionFile(config.Sensor.SinkSubscriptionPath)
+	if err != nil {
+		closeNamedSinks(sinks)
+		return fmt.Errorf("could not read sink subscription: %v", err)
+	}
+
+	for i, s := range sinks {
+		events, err := runInternalSubscription(ctx, sensor,
+			s.name+" sink", sub)
+		if err != nil {
+			closeNamedSinks(sinks[i:])
+			return err
+		}
+		b := sink.NewBatcher(s.sink,
+			sink.WithBatchSize(config.Sensor.SinkBatchSize),
+			sink.WithBatchInterval(config.Sensor.SinkBatchInterval),
+			sink.WithRetries(config.Sensor.SinkRetries))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.Run(abortCtx, events)
+		}()
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfiguredSinks(t *testing.T) {
+	saved := config.Sensor
+	defer func() { config.Sensor = saved }()
+
+	sinks, err := configuredSinks()
+	require.NoError(t, err)
+	assert.Empty(t, sinks)
+
+	config.Sensor.KafkaBrokers = []string{"localhost:9092"}
+	config.Sensor.KafkaTopics = []string{"process"}
+	_, err = configuredSinks()
+	assert.Error(t, err)
+
+	config.Sensor.KafkaTopics = []string{"process=process-events"}
+	config.Sensor.KafkaEncoding = "xml"
+	_, err = configuredSinks()
+	assert.Error(t, err)
+
+	config.Sensor.KafkaEncoding = "json"
+	config.Sensor.KafkaCompression = "zstd"
+	_, err = configuredSinks()
+	assert.Error(t, err)
+
+	config.Sensor.KafkaCompression = "gzip"
+	sinks, err = configuredSinks()
+	require.NoError(t, err)
+	require.Len(t, sinks, 1)
+	assert.Equal(t, "Kafka", sinks[0].name)
+
+	config.Sensor.JSONSinkPath = "-"
+	sinks, err = configuredSinks()
+	require.NoError(t, err)
+	require.Len(t, sinks, 2)
+	assert.Equal(t, "JSON", sinks[1].name)
+	closeNamedSinks(sinks)
+
+	config.Sensor.WebhookURL = "http://localhost/events"
+	config.Sensor.WebhookEncoding = "json"
+	config.Sensor.WebhookHeaders = []string{"Authorization"}
+	_, err = configuredSinks()
+	assert.Error(t, err)
+
+	config.Sensor.WebhookHeaders = []string{"Authorization=Bearer token"}
+	sinks, err = configuredSinks()
+	require.NoError(t, err)
+	require.Len(t, sinks, 3)
+	assert.Equal(t, "Webhook", sinks[2].name)
+
+	// A subscription is required once a sink is configured
+	config.Sensor.SinkSubscriptionPath = ""
+	var wg sync.WaitGroup
+	assert.Error(t, startEventSinks(context.Background(),
+		context.Background(), nil, &wg))
+}
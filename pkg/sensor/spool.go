@@ -0,0 +1,399 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/config"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+)
+
+// The spool is a directory of segment files, each named by the sequence
+// number of its first event in hex with spoolSegmentSuffix. A segment is a
+// series of records, each a big-endian uint32 length followed by a
+// marshaled api.ReceivedTelemetryEvent; the sequence numbers of its events
+// follow from their positions. Records are written without syncing, so
+// they survive the sensor exiting but not the host crashing. A partial
+// record at the end of the newest segment is removed when the spool is
+// opened.
+
+const (
+	spoolSegmentSuffix = ".spool"
+
+	// The spool is split into about this many segments, so that no more
+	// than this fraction of it is removed at a time when it is full
+	spoolSegmentCount = 8
+
+	// The largest record that is read back from a segment
+	maxSpoolRecordSize = 64 << 20
+)
+
+type spoolSegment struct {
+	first uint64
+	path  string
+	size  int64
+}
+
+// eventSpool is a size-bounded journal of translated events on disk.
+type eventSpool struct {
+	dir         string
+	maxSize     int64
+	segmentSize int64
+
+	mutex    sync.Mutex
+	segments []*spoolSegment
+	file     *os.File
+	size     int64
+	sequence uint64
+}
+
+func parseSpoolSegmentName(name string) (uint64, bool) {
+	if !strings.HasSuffix(name, spoolSegmentSuffix) {
+		return 0, false
+	}
+	first, err := strconv.ParseUint(
+		strings.TrimSuffix(name, spoolSegmentSuffix), 16, 64)
+	if err != nil || first == 0 {
+		return 0, false
+	}
+	return first, true
+}
+
+// readSpoolRecord reads the next record of a segment. A partial record at
+// the end of a segment is reported as io.EOF.
+func readSpoolRecord(r *bufio.Reader) (*api.ReceivedTelemetryEvent, int64, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return nil, 0, err
+	}
+	if length > maxSpoolRecordSize {
+		return nil, 0, fmt.Errorf("record length is invalid (%d)", length)
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return nil, 0, err
+	}
+	re := &api.ReceivedTelemetryEvent{}
+	if err := proto.Unmarshal(b, re); err != nil {
+		return nil, 0, err
+	}
+	return re, int64(4 + length), nil
+}
+
+// recoverSpoolSegment counts the complete records of a segment, removing a
+// partial record from the end of it.
+func recoverSpoolSegment(seg *spoolSegment) (uint64, error) {
+	f, err := os.OpenFile(seg.path, os.O_RDWR, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var (
+		n    uint64
+		size int64
+	)
+	r := bufio.NewReader(f)
+	for {
+		_, l, err := readSpoolRecord(r)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, fmt.Errorf("%s: %v", seg.path, err)
+		}
+		n++
+		size += l
+	}
+	if err = f.Truncate(size); err != nil {
+		return 0, err
+	}
+	seg.size = size
+	return n, nil
+}
+
+// openEventSpool opens the spool in a directory, making the directory if
+// it does not exist.
+func openEventSpool(dir string, maxSize int64) (*eventSpool, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("Spool size is invalid (%d)", maxSize)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &eventSpool{
+		dir:         dir,
+		maxSize:     maxSize,
+		segmentSize: maxSize / spoolSegmentCount,
+	}
+	for _, fi := range files {
+		if first, ok := parseSpoolSegmentName(fi.Name()); ok && fi.Mode().IsRegular() {
+			sp.segments = append(sp.segments, &spoolSegment{
+				first: first,
+				path:  filepath.Join(dir, fi.Name()),
+				size:  fi.Size(),
+			})
+		}
+	}
+	sort.Slice(sp.segments, func(i, j int) bool {
+		return sp.segments[i].first < sp.segments[j].first
+	})
+
+	if len(sp.segments) > 0 {
+		last := sp.segments[len(sp.segments)-1]
+		n, err := recoverSpoolSegment(last)
+		if err != nil {
+			return nil, err
+		}
+		sp.sequence = last.first + n - 1
+		if n == 0 {
+			// An empty segment holds no events to number the next
+			// one from, so it is replaced
+			sp.sequence = last.first - 1
+			os.Remove(last.path)
+			sp.segments = sp.segments[:len(sp.segments)-1]
+		}
+	}
+	glog.V(1).Infof("Opened spool %s at sequence number %d", dir,
+		sp.sequence)
+	return sp, nil
+}
+
+// rotate starts a new segment for the next event, removing the oldest
+// segments if the spool is full. It must be called with the spool's mutex
+// held.
+func (sp *eventSpool) rotate() error {
+	if sp.file != nil {
+		sp.file.Close()
+		sp.file = nil
+	}
+
+	var total int64
+	for _, seg := range sp.segments {
+		total += seg.size
+	}
+	for len(sp.segments) > 0 && total+sp.segmentSize > sp.maxSize {
+		seg := sp.segments[0]
+		if err := os.Remove(seg.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= seg.size
+		sp.segments = sp.segments[1:]
+	}
+
+	first := sp.sequence + 1
+	path := filepath.Join(sp.dir,
+		fmt.Sprintf("%016x%s", first, spoolSegmentSuffix))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	sp.file = f
+	sp.size = 0
+	sp.segments = append(sp.segments, &spoolSegment{
+		first: first,
+		path:  path,
+	})
+	return nil
+}
+
+// write appends an event to the spool, returning its sequence number.
+func (sp *eventSpool) write(event *api.TelemetryEvent, now time.Time) (uint64, error) {
+	b, err := proto.Marshal(&api.ReceivedTelemetryEvent{
+		PublishTimeMicros: now.UnixNano() / int64(time.Microsecond),
+		Event:             event,
+	})
+	if err != nil {
+		return 0, err
+	}
+	record := make([]byte, 4+len(b))
+	binary.BigEndian.PutUint32(record, uint32(len(b)))
+	copy(record[4:], b)
+
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	if sp.file == nil || sp.size+int64(len(record)) > sp.segmentSize {
+		// Events are never split across segments, so a segment may
+		// be larger than segmentSize if it holds one large event
+		if sp.file == nil || sp.size > 0 {
+			if err = sp.rotate(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if _, err = sp.file.Write(record); err != nil {
+		return 0, err
+	}
+	sp.size += int64(len(record))
+	sp.segments[len(sp.segments)-1].size = sp.size
+	sp.sequence++
+	return sp.sequence, nil
+}
+
+// replay calls fn with each event in the spool, in order, whose sequence
+// number is greater than since and that was written at or after
+// sinceMicros. Events written after replay is called are not included.
+func (sp *eventSpool) replay(
+	since uint64,
+	sinceMicros int64,
+	fn func(sequence uint64, re *api.ReceivedTelemetryEvent) error,
+) error {
+	sp.mutex.Lock()
+	segments := make([]*spoolSegment, len(sp.segments))
+	copy(segments, sp.segments)
+	last := sp.sequence
+	sp.mutex.Unlock()
+
+	for i, seg := range segments {
+		if i+1 < len(segments) && segments[i+1].first <= since+1 {
+			continue
+		}
+		f, err := os.Open(seg.path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The segment was removed because the spool
+				// was full
+				continue
+			}
+			return err
+		}
+		r := bufio.NewReader(f)
+		for sequence := seg.first; sequence <= last; sequence++ {
+			re, _, err := readSpoolRecord(r)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				f.Close()
+				return fmt.Errorf("%s: %v", seg.path, err)
+			}
+			if sequence <= since || re.PublishTimeMicros < sinceMicros {
+				continue
+			}
+			if err = fn(sequence, re); err != nil {
+				f.Close()
+				return err
+			}
+		}
+		f.Close()
+	}
+	return nil
+}
+
+func (sp *eventSpool) close() {
+	sp.mutex.Lock()
+	if sp.file != nil {
+		sp.file.Close()
+		sp.file = nil
+	}
+	sp.mutex.Unlock()
+}
+
+// run writes the events of a subscription to the spool until ctx is done.
+// The spool is closed and removed from wg once the events held for the
+// subscription have been written.
+func (sp *eventSpool) run(
+	ctx context.Context,
+	sensor *Sensor,
+	sub *api.Subscription,
+	wg *sync.WaitGroup,
+) error {
+	events, err := runInternalSubscription(ctx, sensor, "Spool", sub)
+	if err != nil {
+		return err
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for event := range events {
+			if _, err := sp.write(event, time.Now()); err != nil {
+				glog.Errorf("Could not write event to spool: %v", err)
+			}
+		}
+		sp.close()
+	}()
+	return nil
+}
+
+// startEventSpool opens the spool configured for the sensor and starts
+// writing the events of the configured spool subscription to it.
+func startEventSpool(
+	ctx context.Context,
+	sensor *Sensor,
+	wg *sync.WaitGroup,
+) (*eventSpool, error) {
+	if config.Sensor.SpoolSubscriptionPath == "" {
+		return nil, errors.New("Spool subscription path is not set")
+	}
+	sub, err := readSubscriptionFile(config.Sensor.SpoolSubscriptionPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read spool subscription: %v", err)
+	}
+
+	sp, err := openEventSpool(config.Sensor.SpoolDir, config.Sensor.SpoolMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	if err = sp.run(ctx, sensor, sub, wg); err != nil {
+		sp.close()
+		return nil, err
+	}
+	return sp, nil
+}
+
+func (t *telemetryServiceServer) ReplayEvents(
+	req *api.ReplayEventsRequest,
+	stream api.TelemetryService_ReplayEventsServer,
+) error {
+	glog.V(1).Infof("ReplayEvents(%+v)", req)
+
+	if t.spool == nil {
+		return errors.New("Spool is not enabled")
+	}
+	return t.spool.replay(req.SinceSequenceNumber, req.SinceTimeMicros,
+		func(sequence uint64, re *api.ReceivedTelemetryEvent) error {
+			return stream.Send(&api.ReplayEventsResponse{
+				SequenceNumber: sequence,
+				Event:          re,
+			})
+		})
+}
@@ -0,0 +1,176 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type replayedEvent struct {
+	sequence uint64
+	id       string
+}
+
+func replaySpool(t *testing.T, sp *eventSpool, since uint64, sinceMicros int64) []replayedEvent {
+	var events []replayedEvent
+	err := sp.replay(since, sinceMicros,
+		func(sequence uint64, re *api.ReceivedTelemetryEvent) error {
+			events = append(events, replayedEvent{sequence, re.Event.Id})
+			return nil
+		})
+	require.NoError(t, err)
+	return events
+}
+
+func TestEventSpool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spool_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = openEventSpool(dir, 0)
+	assert.Error(t, err)
+
+	sp, err := openEventSpool(dir, 1<<20)
+	require.NoError(t, err)
+	assert.Empty(t, replaySpool(t, sp, 0, 0))
+
+	start := time.Unix(1000, 0)
+	for i, id := range []string{"a", "b", "c"} {
+		var sequence uint64
+		sequence, err = sp.write(&api.TelemetryEvent{Id: id},
+			start.Add(time.Duration(i)*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), sequence)
+	}
+	assert.Equal(t, []replayedEvent{{1, "a"}, {2, "b"}, {3, "c"}},
+		replaySpool(t, sp, 0, 0))
+	assert.Equal(t, []replayedEvent{{3, "c"}}, replaySpool(t, sp, 2, 0))
+	assert.Equal(t, []replayedEvent{{2, "b"}, {3, "c"}},
+		replaySpool(t, sp, 0, start.Add(time.Second).UnixNano()/1000))
+	sp.close()
+
+	// Sequence numbers continue when the spool is opened again, and a
+	// partial record left at the end of a segment is removed
+	segments, err := filepath.Glob(filepath.Join(dir, "*"+spoolSegmentSuffix))
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	f, err := os.OpenFile(segments[0], os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	f.Write([]byte{0, 0, 1})
+	f.Close()
+
+	sp, err = openEventSpool(dir, 1<<20)
+	require.NoError(t, err)
+	sequence, err := sp.write(&api.TelemetryEvent{Id: "d"}, start)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), sequence)
+	assert.Equal(t, []replayedEvent{{1, "a"}, {2, "b"}, {3, "c"}, {4, "d"}},
+		replaySpool(t, sp, 0, 0))
+	sp.close()
+}
+
+func TestEventSpoolSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spool_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Each segment holds a few events, and the oldest segments are
+	// removed to keep the spool within its size
+	const maxSize = spoolSegmentCount * 64
+	sp, err := openEventSpool(dir, maxSize)
+	require.NoError(t, err)
+	defer sp.close()
+
+	for i := 0; i < 200; i++ {
+		_, err = sp.write(&api.TelemetryEvent{Id: "0123456789"}, time.Now())
+		require.NoError(t, err)
+	}
+
+	var total int64
+	segments, err := filepath.Glob(filepath.Join(dir, "*"+spoolSegmentSuffix))
+	require.NoError(t, err)
+	for _, path := range segments {
+		fi, err := os.Stat(path)
+		require.NoError(t, err)
+		total += fi.Size()
+	}
+	assert.True(t, total <= maxSize, "spool size %d", total)
+	assert.True(t, len(segments) > 1)
+
+	events := replaySpool(t, sp, 0, 0)
+	require.NotEmpty(t, events)
+	assert.True(t, events[0].sequence > 1)
+	assert.Equal(t, uint64(200), events[len(events)-1].sequence)
+	for i := 1; i < len(events); i++ {
+		assert.Equal(t, events[i-1].sequence+1, events[i].sequence)
+	}
+}
+
+func TestEventSpoolRun(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	dir, err := ioutil.TempDir("", "spool_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sp, err := openEventSpool(dir, 1<<20)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	err = sp.run(ctx, sensor, &api.Subscription{}, &wg)
+	assert.Error(t, err)
+	err = sp.run(ctx, sensor, &api.Subscription{
+		EventFilter: &api.EventFilter{},
+	}, &wg)
+	assert.Error(t, err)
+
+	err = sp.run(ctx, sensor, &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{
+					Interval: int64(10 * time.Millisecond),
+				},
+			},
+		},
+	}, &wg)
+	require.NoError(t, err)
+
+	var events []replayedEvent
+	for i := 0; i < 100 && len(events) < 3; i++ {
+		time.Sleep(10 * time.Millisecond)
+		events = replaySpool(t, sp, 0, 0)
+	}
+	assert.True(t, len(events) >= 3)
+
+	// The spool is closed once its subscription's events are written
+	cancel()
+	wg.Wait()
+	assert.Nil(t, sp.file)
+}
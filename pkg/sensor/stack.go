@@ -0,0 +1,65 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"sort"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
+
+	"github.com/golang/glog"
+)
+
+// SetCaptureStackTraces sets whether the subscription's events that support
+// them carry stack traces. It must be called before any events are
+// registered.
+func (s *Subscription) SetCaptureStackTraces(captureStackTraces bool) {
+	s.captureStackTraces = captureStackTraces
+}
+
+// stackTraceOptions adds the event registration options needed for events
+// that carry stack traces when the subscription wants them.
+func (s *Subscription) stackTraceOptions(
+	options ...perf.RegisterEventOption,
+) []perf.RegisterEventOption {
+	if s.captureStackTraces {
+		options = append(options, perf.WithEventCallchain())
+	}
+	return options
+}
+
+// lookupKernelSymbol returns the kernel text symbol containing an address.
+// Since kallsyms does not provide symbol sizes, this is the nearest symbol at
+// or below the address.
+func (s *Sensor) lookupKernelSymbol(address uint64) (proc.KernelSymbol, bool) {
+	s.kernelSymbolsOnce.Do(func() {
+		symbols, err := s.ProcFS.KernelTextSymbols()
+		if err != nil {
+			glog.V(1).Infof("Kernel stack traces will not be symbolized: %v",
+				err)
+		}
+		s.kernelSymbols = symbols
+	})
+
+	symbols := s.kernelSymbols
+	i := sort.Search(len(symbols), func(i int) bool {
+		return symbols[i].Address > address
+	})
+	if i == 0 {
+		return proc.KernelSymbol{}, false
+	}
+	return symbols[i-1], true
+}
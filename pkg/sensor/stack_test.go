@@ -0,0 +1,81 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/capsule8/capsule8/pkg/sys/proc/procfs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stackTestKallsyms = `ffffffff81000000 T _stext
+ffffffff81200000 T do_init_module
+ffffffff81200400 D not_text
+ffffffff812e0000 T do_execveat_common
+ffffffffc0400000 t nf_hook [nf_tables]
+`
+
+func TestLookupKernelSymbol(t *testing.T) {
+	procDir, err := ioutil.TempDir("", "capsule8_")
+	require.NoError(t, err)
+	defer os.RemoveAll(procDir)
+	writeFile(t, filepath.Join(procDir, "kallsyms"),
+		[]byte(stackTestKallsyms))
+	procFS, err := procfs.NewFileSystem(procDir)
+	require.NoError(t, err)
+
+	s := &Sensor{ProcFS: procFS}
+	_, ok := s.lookupKernelSymbol(0xffffffff80000000)
+	assert.False(t, ok)
+
+	sym, ok := s.lookupKernelSymbol(0xffffffff81200000)
+	require.True(t, ok)
+	assert.Equal(t, "do_init_module", sym.Name)
+
+	// Data symbols are not used
+	sym, ok = s.lookupKernelSymbol(0xffffffff81200408)
+	require.True(t, ok)
+	assert.Equal(t, "do_init_module", sym.Name)
+
+	sym, ok = s.lookupKernelSymbol(0xffffffffc0400010)
+	require.True(t, ok)
+	assert.Equal(t, "nf_hook", sym.Name)
+	assert.Equal(t, "nf_tables", sym.Module)
+
+	// Without symbols, nothing is found
+	s = &Sensor{ProcFS: procFS}
+	os.Remove(filepath.Join(procDir, "kallsyms"))
+	_, ok = s.lookupKernelSymbol(0xffffffff81200000)
+	assert.False(t, ok)
+}
+
+func TestStackTraceOptions(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	assert.Len(t, s.stackTraceOptions(perf.WithEventEnabled()), 1)
+
+	s.SetCaptureStackTraces(true)
+	assert.Len(t, s.stackTraceOptions(perf.WithEventEnabled()), 2)
+	assert.Len(t, s.stackTraceOptions(), 1)
+}
@@ -0,0 +1,175 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+
+	"golang.org/x/net/context"
+)
+
+// eventCounters counts what happened to events. The counters are accessed
+// atomically.
+type eventCounters struct {
+	received   uint64
+	translated uint64
+	filtered   uint64
+	dropped    uint64
+	errored    uint64
+}
+
+// The counters of a subscription are those of the stream that it belongs to,
+// if any, so that they survive the subscription being replaced by
+// ModifySubscription. These add to them when there are any.
+
+func (c *eventCounters) addFiltered() {
+	if c != nil {
+		atomic.AddUint64(&c.filtered, 1)
+	}
+}
+
+func (c *eventCounters) addDropped() {
+	if c != nil {
+		atomic.AddUint64(&c.dropped, 1)
+	}
+}
+
+func (c *eventCounters) addErrored() {
+	if c != nil {
+		atomic.AddUint64(&c.errored, 1)
+	}
+}
+
+func (c *eventCounters) addTranslated() {
+	if c != nil {
+		atomic.AddUint64(&c.translated, 1)
+	}
+}
+
+// eventTypeCounters counts the events received by the sensor from its event
+// sources, by the type of telemetry event.
+type eventTypeCounters struct {
+	counters sync.Map // reflect.Type -> *eventCounters
+}
+
+// eventTypeName returns the name used for the type of a telemetry event in
+// statistics and metrics, e.g. "ProcessExec" for ProcessExecTelemetryEvent.
+func eventTypeName(t reflect.Type) string {
+	return strings.TrimSuffix(t.Name(), "TelemetryEvent")
+}
+
+// get returns the counters for the type of an event.
+func (c *eventTypeCounters) get(e TelemetryEvent) *eventCounters {
+	t := reflect.TypeOf(e)
+	v, ok := c.counters.Load(t)
+	if !ok {
+		v, _ = c.counters.LoadOrStore(t, &eventCounters{})
+	}
+	return v.(*eventCounters)
+}
+
+// namedEventCounters is a copy of the counters for one type of event.
+type namedEventCounters struct {
+	name string
+	eventCounters
+}
+
+// snapshot returns copies of the counters for each type of event, sorted by
+// name.
+func (c *eventTypeCounters) snapshot() []namedEventCounters {
+	var counters []namedEventCounters
+	c.counters.Range(func(k, v interface{}) bool {
+		ec := v.(*eventCounters)
+		counters = append(counters, namedEventCounters{
+			name: eventTypeName(k.(reflect.Type)),
+			eventCounters: eventCounters{
+				received:   atomic.LoadUint64(&ec.received),
+				translated: atomic.LoadUint64(&ec.translated),
+				filtered:   atomic.LoadUint64(&ec.filtered),
+				dropped:    atomic.LoadUint64(&ec.dropped),
+				errored:    atomic.LoadUint64(&ec.errored),
+			},
+		})
+		return true
+	})
+	sort.Slice(counters, func(i, j int) bool {
+		return counters[i].name < counters[j].name
+	})
+	return counters
+}
+
+// totalReceived returns the number of events received of all types.
+func (c *eventTypeCounters) totalReceived() uint64 {
+	var total uint64
+	c.counters.Range(func(k, v interface{}) bool {
+		total += atomic.LoadUint64(&v.(*eventCounters).received)
+		return true
+	})
+	return total
+}
+
+func (t *telemetryServiceServer) GetStatistics(
+	ctx context.Context,
+	req *api.GetStatisticsRequest,
+) (*api.GetStatisticsResponse, error) {
+	glog.V(1).Infof("GetStatistics(%+v)", req)
+
+	s := t.sensor
+	r := &api.GetStatisticsResponse{
+		LostRecords:  atomic.LoadUint64(&s.Metrics.LostEvents),
+		DecodeErrors: atomic.LoadUint64(&s.Metrics.DecodeErrors),
+	}
+	for _, c := range s.eventCounts.snapshot() {
+		r.EventSources = append(r.EventSources, &api.EventSourceStatistics{
+			Type:       c.name,
+			Received:   c.received,
+			Translated: c.translated,
+			Filtered:   c.filtered,
+			Dropped:    c.dropped,
+			Errored:    c.errored,
+		})
+	}
+
+	streams := t.listStreams()
+	sort.Slice(streams, func(i, j int) bool {
+		if !streams[i].startTime.Equal(streams[j].startTime) {
+			return streams[i].startTime.Before(streams[j].startTime)
+		}
+		return streams[i].id < streams[j].id
+	})
+	for _, ts := range streams {
+		c := &ts.counters
+		r.Subscriptions = append(r.Subscriptions, &api.SubscriptionStatistics{
+			SubscriptionId: ts.id,
+			Received:       atomic.LoadUint64(&ts.eventsReceived),
+			Translated:     atomic.LoadUint64(&c.translated),
+			Filtered:       atomic.LoadUint64(&c.filtered),
+			Dropped: atomic.LoadUint64(&c.dropped) +
+				atomic.LoadUint64(&ts.eventsDropped),
+			Errored: atomic.LoadUint64(&c.errored),
+			Sent:    atomic.LoadUint64(&ts.eventsSent),
+		})
+	}
+
+	return r, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"golang.org/x/net/context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventTypeCounters(t *testing.T) {
+	var c eventTypeCounters
+	c.get(ProcessExecTelemetryEvent{}).received++
+	c.get(ProcessExecTelemetryEvent{}).received++
+	c.get(ContainerCreatedTelemetryEvent{}).received++
+	c.get(ContainerCreatedTelemetryEvent{}).addFiltered()
+
+	var nilCounters *eventCounters
+	nilCounters.addFiltered()
+
+	assert.Equal(t, []namedEventCounters{
+		{
+			name:          "ContainerCreated",
+			eventCounters: eventCounters{received: 1, filtered: 1},
+		},
+		{
+			name:          "ProcessExec",
+			eventCounters: eventCounters{received: 2},
+		},
+	}, c.snapshot())
+}
+
+func TestDispatchStatistics(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	eventID := sensor.Monitor().RegisterExternalEvent("statistics test", nil)
+
+	s := newTestSubscription(t, sensor)
+	s.counters = &eventCounters{}
+	_, err := s.addEventSink(eventID, nil, nil)
+	require.NoError(t, err)
+	s.SetRateLimit(1, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err = s.Run(ctx, func(e TelemetryEvent) {})
+	require.NoError(t, err)
+
+	e := TickerTelemetryEvent{}
+	e.MonotimeNanos = 1e9
+	sample := perf.EventMonitorSample{
+		EventID:       eventID,
+		DecodedSample: e,
+	}
+	sensor.dispatchQueuedSamples([]perf.EventMonitorSample{
+		sample,
+		sample,
+		{Err: assert.AnError},
+	})
+
+	assert.Equal(t, []namedEventCounters{
+		{
+			name:          "Ticker",
+			eventCounters: eventCounters{received: 2, dropped: 1},
+		},
+	}, sensor.eventCounts.snapshot())
+	assert.Equal(t, uint64(1), s.counters.dropped)
+	assert.Equal(t, uint64(1), sensor.Metrics.DecodeErrors)
+}
+
+func TestGetStatistics(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	c := sensor.eventCounts.get(ProcessExecTelemetryEvent{})
+	c.received = 5
+	c.translated = 3
+	c.filtered = 2
+	sensor.Metrics.LostEvents = 7
+	sensor.Metrics.DecodeErrors = 1
+
+	handler := NewTelemetryService(sensor, "").handler
+	now := time.Now()
+	handler.addStream(&getEventsStream{
+		id:             "b",
+		startTime:      now,
+		eventsReceived: 4,
+		eventsSent:     2,
+		eventsDropped:  1,
+		counters: eventCounters{
+			translated: 3,
+			filtered:   1,
+			dropped:    1,
+		},
+	})
+	handler.addStream(&getEventsStream{
+		id:        "a",
+		startTime: now.Add(time.Second),
+	})
+
+	r, err := handler.GetStatistics(context.Background(),
+		&api.GetStatisticsRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, &api.GetStatisticsResponse{
+		EventSources: []*api.EventSourceStatistics{
+			{
+				Type:       "ProcessExec",
+				Received:   5,
+				Translated: 3,
+				Filtered:   2,
+			},
+		},
+		Subscriptions: []*api.SubscriptionStatistics{
+			{
+				SubscriptionId: "b",
+				Received:       4,
+				Translated:     3,
+				Filtered:       1,
+				Dropped:        2,
+				Sent:           2,
+			},
+			{
+				SubscriptionId: "a",
+			},
+		},
+		LostRecords:  7,
+		DecodeErrors: 1,
+	}, r)
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"runtime"
+	"sync/atomic"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+)
+
+// SensorStatusTelemetryEvent is a telemetry event generated periodically for
+// each stream to report the health of the sensor. It is not subject to the
+// subscription's filters.
+type SensorStatusTelemetryEvent struct {
+	TelemetryEventData
+
+	UptimeNanos         int64
+	EventsReceived      uint64
+	EventsPerSecond     float64
+	LostRecords         uint64
+	RateLimitedEvents   uint64
+	DecodeErrors        uint64
+	StreamEventsDropped uint64
+	HeapAllocBytes      uint64
+	SysBytes            uint64
+	Probes              []string
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a sensor status telemetry event.
+func (e SensorStatusTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// statusReporter creates the sensor status events of a stream. The event
+// rate of each is measured since the one before it.
+type statusReporter struct {
+	sensor  *Sensor
+	dropped *uint64
+
+	lastMonotimeNanos int64
+	lastReceived      uint64
+}
+
+// newStatusReporter creates a statusReporter for a stream whose count of
+// events dropped because its client fell behind is dropped.
+func newStatusReporter(sensor *Sensor, dropped *uint64) *statusReporter {
+	return &statusReporter{
+		sensor:            sensor,
+		dropped:           dropped,
+		lastMonotimeNanos: sys.CurrentMonotonicRaw() - sensor.bootMonotimeNanos,
+		lastReceived:      sensor.eventCounts.totalReceived(),
+	}
+}
+
+func (r *statusReporter) newEvent() SensorStatusTelemetryEvent {
+	s := r.sensor
+
+	var e SensorStatusTelemetryEvent
+	e.Init(s)
+	// Event monotimes are relative to when the sensor started
+	e.UptimeNanos = e.MonotimeNanos
+	e.EventsReceived = s.eventCounts.totalReceived()
+	if d := e.MonotimeNanos - r.lastMonotimeNanos; d > 0 {
+		e.EventsPerSecond = float64(e.EventsReceived-r.lastReceived) /
+			(float64(d) / 1e9)
+	}
+	r.lastMonotimeNanos = e.MonotimeNanos
+	r.lastReceived = e.EventsReceived
+
+	e.LostRecords = atomic.LoadUint64(&s.Metrics.LostEvents)
+	e.RateLimitedEvents = atomic.LoadUint64(&s.Metrics.RateLimitedEvents)
+	e.DecodeErrors = atomic.LoadUint64(&s.Metrics.DecodeErrors)
+	e.StreamEventsDropped = atomic.LoadUint64(r.dropped)
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	e.HeapAllocBytes = m.HeapAlloc
+	e.SysBytes = m.Sys
+
+	e.Probes = s.Monitor().RegisteredProbes()
+	return e
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusReporter(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	var dropped uint64 = 3
+	r := newStatusReporter(sensor, &dropped)
+
+	c := sensor.eventCounts.get(ProcessExecTelemetryEvent{})
+	c.received = 10
+	sensor.Metrics.LostEvents = 7
+	sensor.Metrics.RateLimitedEvents = 2
+	sensor.Metrics.DecodeErrors = 1
+
+	e := r.newEvent()
+	assert.Equal(t, e.MonotimeNanos, e.UptimeNanos)
+	assert.Equal(t, uint64(10), e.EventsReceived)
+	assert.True(t, e.EventsPerSecond > 0)
+	assert.Equal(t, uint64(7), e.LostRecords)
+	assert.Equal(t, uint64(2), e.RateLimitedEvents)
+	assert.Equal(t, uint64(1), e.DecodeErrors)
+	assert.Equal(t, uint64(3), e.StreamEventsDropped)
+	assert.NotZero(t, e.HeapAllocBytes)
+	assert.NotZero(t, e.SysBytes)
+
+	// The rate is measured since the last event
+	e = r.newEvent()
+	assert.Equal(t, uint64(10), e.EventsReceived)
+	assert.Zero(t, e.EventsPerSecond)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/capsule8/capsule8/pkg/expression"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
@@ -52,6 +53,33 @@ type Subscription struct {
 	eventSinks      map[uint64]*eventSink
 	status          []string
 	dispatchFn      EventSinkDispatchFn
+
+	// The size in pages of the ring buffers used for the subscription's
+	// events, or 0 to use the sensor's default
+	ringBufferNumPages int
+
+	// The start of the window for the next lost events report
+	lostEventsWindowStart int64
+
+	// The perf_event cgroups to which the subscription's events are
+	// restricted in the kernel, if any
+	cgroups []string
+
+	// Whether events that support them should carry stack traces
+	captureStackTraces bool
+
+	// Counts the subscription's events, if set
+	counters *eventCounters
+
+	// Whether process exec events should carry the allowed environment
+	// variables of the process
+	captureExecEnvironment bool
+
+	// Limits the rate at which events are dispatched, if set
+	rateLimiter *rateLimiter
+
+	// Selects the events that are dispatched, if set
+	sampler *eventSampler
 }
 
 // Run enables and runs a telemetry event subscription. Canceling the specified
@@ -118,6 +146,42 @@ func (s *Subscription) SetContainerFilter(f *ContainerFilter) {
 	s.containerFilter = f
 }
 
+// SetRateLimit limits the rate at which events matching the subscription are
+// dispatched to eventsPerSecond, allowing bursts of up to burst events. Events
+// in excess of the limit are dropped by the sensor before they are dispatched
+// and counted in the sensor's RateLimitedEvents metric. Lost events reports
+// are not subject to the limit.
+func (s *Subscription) SetRateLimit(eventsPerSecond float64, burst int) {
+	s.rateLimiter = newRateLimiter(eventsPerSecond, burst)
+}
+
+// SetSampleRate randomly selects one in oneIn events matching the subscription
+// for dispatch. Events that are not selected are dropped by the sensor before
+// they are dispatched or counted against a rate limit. Lost events reports
+// are always dispatched. A rate of 1 or less selects every event.
+func (s *Subscription) SetSampleRate(oneIn int64) {
+	if oneIn > 1 {
+		s.sampler = newEventSampler(oneIn, time.Now().UnixNano())
+	} else {
+		s.sampler = nil
+	}
+}
+
+// SetRingBufferNumPages sets the size in pages of the perf ring buffers used
+// for the subscription's events. It must be called before any events are
+// registered. A larger size trades memory for fewer lost events.
+func (s *Subscription) SetRingBufferNumPages(numPages int) {
+	s.ringBufferNumPages = numPages
+}
+
+// SetCgroups restricts the kernel events generated for the subscription to
+// tasks in the specified perf_event cgroups. It must be called before any
+// events are registered. The subscription's container filter, if any, is still
+// applied to the events.
+func (s *Subscription) SetCgroups(cgroups []string) {
+	s.cgroups = cgroups
+}
+
 func (s *Subscription) addEventSink(
 	eventID uint64,
 	filterExpression *expression.Expression,
@@ -162,6 +226,33 @@ func (s *Subscription) addEventSink(
 	return es, nil
 }
 
+// registerWithSensorFilter registers events whose filter expression is only
+// evaluated by the sensor, after they are decoded, rather than by the
+// kernel. This is needed when the expression refers to fields that are
+// derived by the decoder, or when the events need a kernel filter of their
+// own. The register function registers the events without a filter
+// expression. If the expression is invalid, a status naming the kind of
+// filter is logged, nothing is registered, and false is returned.
+func (s *Subscription) registerWithSensorFilter(
+	kind string,
+	expr *expression.Expression,
+	filterTypes expression.FieldTypeMap,
+	register func() (*eventSink, error),
+) bool {
+	if expr != nil {
+		if err := expr.Validate(filterTypes); err != nil {
+			s.logStatus(
+				fmt.Sprintf("Invalid %s filter expression: %v", kind, err))
+			return false
+		}
+	}
+	es, err := register()
+	if err == nil && expr != nil {
+		es.filter = expr
+	}
+	return true
+}
+
 func (s *Subscription) removeEventSink(es *eventSink) {
 	delete(s.eventSinks, es.eventID)
 }
@@ -173,7 +264,14 @@ func (s *Subscription) logStatus(st string) {
 func (s *Subscription) createEventGroup() error {
 	if s.eventGroupID == 0 {
 		monitor := s.sensor.Monitor()
-		if groupID, err := monitor.RegisterEventGroup(""); err == nil {
+		options := []perf.RegisterEventOption{
+			perf.WithEventRingBufferNumPages(s.ringBufferNumPages),
+		}
+		if len(s.cgroups) > 0 {
+			options = append(options, perf.WithEventCgroups(s.cgroups))
+		}
+		groupID, err := monitor.RegisterEventGroup("", options...)
+		if err == nil {
 			s.eventGroupID = groupID
 		} else {
 			s.logStatus(
@@ -245,6 +343,54 @@ func (s *Subscription) registerKprobe(
 	return es, nil
 }
 
+func (s *Subscription) registerUprobe(
+	bin string,
+	address string,
+	onReturn bool,
+	output string,
+	fn perf.TraceEventDecoderFn,
+	filterExpr *expression.Expression,
+	filterTypes expression.FieldTypeMap,
+	options ...perf.RegisterEventOption,
+) (*eventSink, error) {
+	if err := s.createEventGroup(); err != nil {
+		return nil, err
+	}
+	options = append(options, perf.WithEventGroup(s.eventGroupID))
+
+	monitor := s.sensor.Monitor()
+	output = translateFetchargRegisters(output)
+	eventID, err := monitor.RegisterUprobe(bin, address, onReturn, output,
+		fn, options...)
+	if err != nil {
+		s.logStatus(
+			fmt.Sprintf("Could not register uprobe %s:%s: %v",
+				bin, address, err))
+		return nil, err
+	}
+
+	// This is a dynamic uprobe -- determine filterTypes dynamically from
+	// the kernel.
+	if filterExpr != nil && filterTypes == nil {
+		uprobeFields := monitor.RegisteredEventFields(eventID)
+		filterTypes = make(expression.FieldTypeMap, len(uprobeFields))
+		for k, v := range uprobeFields {
+			filterTypes[k] = perfTypeMapping[v]
+		}
+	}
+
+	es, err := s.addEventSink(eventID, filterExpr, filterTypes)
+	if err != nil {
+		s.logStatus(
+			fmt.Sprintf("Could not register uprobe %s:%s: %v",
+				bin, address, err))
+		monitor.UnregisterEvent(eventID)
+		return nil, err
+	}
+
+	return es, nil
+}
+
 func (s *Subscription) registerTracepoint(
 	name string,
 	fn perf.TraceEventDecoderFn,
@@ -281,10 +427,10 @@ func (s *Subscription) registerTracepoint(
 
 //
 // safeSubscriptionMap
-// map[uint64]map[int32]*eventSink
+// map[uint64]map[uint64]*eventSink
 //
 
-type subscriptionMap map[uint64]map[int32]*eventSink
+type subscriptionMap map[uint64]map[uint64]*eventSink
 
 func newSubscriptionMap() subscriptionMap {
 	return make(subscriptionMap)
@@ -292,7 +438,7 @@ func newSubscriptionMap() subscriptionMap {
 
 type safeSubscriptionMap struct {
 	sync.Mutex              // used only by writers
-	active     atomic.Value // map[uint64]map[int32]*eventSink
+	active     atomic.Value // map[uint64]map[uint64]*eventSink
 }
 
 func newSafeSubscriptionMap() *safeSubscriptionMap {
@@ -315,7 +461,7 @@ func (ssm *safeSubscriptionMap) subscribe(subscr *Subscription) {
 
 	if om != nil {
 		for k, v := range om {
-			c := make(map[int32]*eventSink, len(v))
+			c := make(map[uint64]*eventSink, len(v))
 			for k2, v2 := range v {
 				c[k2] = v2
 			}
@@ -326,10 +472,10 @@ func (ssm *safeSubscriptionMap) subscribe(subscr *Subscription) {
 	for eventID, es := range subscr.eventSinks {
 		subscriptionMap, ok := nm[eventID]
 		if !ok {
-			subscriptionMap = make(map[int32]*eventSink)
+			subscriptionMap = make(map[uint64]*eventSink)
 			nm[eventID] = subscriptionMap
 		}
-		subscriptionMap[subscr.eventGroupID] = es
+		subscriptionMap[subscr.subscriptionID] = es
 	}
 
 	ssm.active.Store(nm)
@@ -346,14 +492,14 @@ func (ssm *safeSubscriptionMap) unsubscribe(
 
 	ssm.Lock()
 	if om := ssm.getMap(); om != nil {
-		subscriptionID := subscr.eventGroupID
+		subscriptionID := subscr.subscriptionID
 		nm := make(subscriptionMap, len(om))
 		for eventID, v := range om {
-			var m map[int32]*eventSink
+			var m map[uint64]*eventSink
 			for ID, es := range v {
 				if ID != subscriptionID {
 					if m == nil {
-						m = make(map[int32]*eventSink)
+						m = make(map[uint64]*eventSink)
 					}
 					m[ID] = es
 				} else if es.unregister != nil {
@@ -15,9 +15,14 @@
 package sensor
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,3 +38,78 @@ func dumpSubscriptionStatus(s *Subscription) {
 		fmt.Printf("Status: %s\n", msg)
 	}
 }
+
+func TestSubscriptionCloseExternalEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	// Subscriptions with only external events have no event group, so
+	// closing one must not remove the event sinks of the others.
+	var events [2][]TelemetryEvent
+	var eventIDs [2]uint64
+	var subscriptions [2]*Subscription
+	for i := range subscriptions {
+		i := i
+		eventIDs[i] = sensor.Monitor().RegisterExternalEvent(
+			fmt.Sprintf("external %d", i), nil)
+		s := newTestSubscription(t, sensor)
+		_, err := s.addEventSink(eventIDs[i], nil, nil)
+		require.NoError(t, err)
+		_, err = s.Run(context.Background(), func(e TelemetryEvent) {
+			events[i] = append(events[i], e)
+		})
+		require.NoError(t, err)
+		subscriptions[i] = s
+	}
+
+	subscriptions[0].Close()
+	sensor.dispatchQueuedSamples([]perf.EventMonitorSample{
+		perf.EventMonitorSample{
+			EventID:       eventIDs[1],
+			DecodedSample: TickerTelemetryEvent{},
+		},
+	})
+	assert.Len(t, events[0], 0)
+	assert.Len(t, events[1], 1)
+	subscriptions[1].Close()
+}
+
+func TestRegisterWithSensorFilter(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	eventID := sensor.Monitor().RegisterExternalEvent("sensor filter", nil)
+	register := func() (*eventSink, error) {
+		return s.addEventSink(eventID, nil, nil)
+	}
+
+	expr, err := expression.NewExpression(expression.Equal(
+		expression.Identifier("seconds"), expression.Value(int64(1))))
+	require.NoError(t, err)
+	assert.True(t, s.registerWithSensorFilter("ticker", expr,
+		TickerEventTypes, register))
+	require.Contains(t, s.eventSinks, eventID)
+	assert.Equal(t, expr, s.eventSinks[eventID].filter)
+	assert.Len(t, s.status, 0)
+
+	// Events registered without an expression are not filtered
+	s = newTestSubscription(t, sensor)
+	assert.True(t, s.registerWithSensorFilter("ticker", nil,
+		TickerEventTypes, register))
+	require.Contains(t, s.eventSinks, eventID)
+	assert.Nil(t, s.eventSinks[eventID].filter)
+
+	// Nothing is registered for an invalid expression
+	s = newTestSubscription(t, sensor)
+	expr, err = expression.NewExpression(expression.Equal(
+		expression.Identifier("bogus"), expression.Value("value")))
+	require.NoError(t, err)
+	assert.False(t, s.registerWithSensorFilter("ticker", expr,
+		TickerEventTypes, func() (*eventSink, error) {
+			t.Fatal("registered with an invalid expression")
+			return nil, nil
+		}))
+	assert.Equal(t, 1, len(s.status))
+	assert.Contains(t, s.status[0], "Invalid ticker filter expression")
+}
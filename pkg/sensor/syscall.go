@@ -45,6 +45,35 @@ var SyscallExitEventTypes = expression.FieldTypeMap{
 	"ret": expression.ValueTypeSignedInt64,
 }
 
+//go:generate go run mksyscalls.go -arch amd64 -o syscall_names_amd64.go
+//go:generate go run mksyscalls.go -arch arm64 -o syscall_names_arm64.go
+
+var (
+	syscallNumbersOnce sync.Once
+	syscallNumbers     map[string]int64
+)
+
+// syscallName returns the name of the system call with the specified number,
+// or "" if the number is unknown.
+func syscallName(id int64) string {
+	return syscallNames[id]
+}
+
+// syscallNumber returns the number of the named system call, or -1 if the
+// system call does not exist on this architecture.
+func syscallNumber(name string) int64 {
+	syscallNumbersOnce.Do(func() {
+		syscallNumbers = make(map[string]int64, len(syscallNames))
+		for id, n := range syscallNames {
+			syscallNumbers[n] = id
+		}
+	})
+	if id, ok := syscallNumbers[name]; ok {
+		return id
+	}
+	return -1
+}
+
 func decodeDummySysEnter(
 	sample *perf.SampleRecord,
 	data perf.TraceEventSampleData,
@@ -82,6 +111,18 @@ func (e SyscallExitTelemetryEvent) CommonTelemetryEventData() TelemetryEventData
 	return e.TelemetryEventData
 }
 
+// syscallID returns the system call number fetched by the syscall enter kprobe.
+// It is a 32-bit value in the arm64 struct pt_regs.
+func syscallID(value interface{}) int64 {
+	switch id := value.(type) {
+	case int32:
+		return int64(id)
+	case int64:
+		return id
+	}
+	return 0
+}
+
 func (s *Subscription) decodeSyscallTraceEnter(
 	sample *perf.SampleRecord,
 	data perf.TraceEventSampleData,
@@ -90,13 +131,37 @@ func (s *Subscription) decodeSyscallTraceEnter(
 	if !e.InitWithSample(s.sensor, sample, data) {
 		return nil, nil
 	}
-	e.ID = data["id"].(int64)
+	e.ID = syscallID(data["id"])
 	for i := 0; i < 6; i++ {
 		e.Arguments[i] = data[fmt.Sprintf("arg%d", i)].(uint64)
 	}
 	return e, nil
 }
 
+func (s *Subscription) decodeRawSysEnter(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+	argCount int,
+) (interface{}, error) {
+	var e SyscallEnterTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.ID = data["id"].(int64)
+
+	// Arguments that were not requested are reported as 0. Make the
+	// arguments visible to filter expressions, which are evaluated
+	// against the sample data after decoding.
+	args := data["args"].([]interface{})
+	for i := 0; i < syscallMaxArgs; i++ {
+		if i < argCount && i < len(args) {
+			e.Arguments[i] = args[i].(uint64)
+		}
+		data[fmt.Sprintf("arg%d", i)] = e.Arguments[i]
+	}
+	return e, nil
+}
+
 func (s *Subscription) decodeSysExit(
 	sample *perf.SampleRecord,
 	data perf.TraceEventSampleData,
@@ -111,18 +176,12 @@ func (s *Subscription) decodeSysExit(
 }
 
 const (
+	// syscallMaxArgs is the number of arguments recorded by the sys_enter
+	// tracepoint, which is the most any system call takes.
+	syscallMaxArgs = 6
+
 	syscallNewEnterKprobeAddress string = "syscall_trace_enter_phase1"
 	syscallOldEnterKprobeAddress string = "syscall_trace_enter"
-
-	// These offsets index into the x86_64 version of struct pt_regs
-	// in the kernel. This is a stable structure.
-	syscallEnterKprobeFetchargs string = "id=+120(%di):s64 " + // orig_ax
-		"arg0=+112(%di):u64 " + // di
-		"arg1=+104(%di):u64 " + // si
-		"arg2=+96(%di):u64 " + // dx
-		"arg3=+56(%di):u64 " + // r10
-		"arg4=+72(%di):u64 " + // r8
-		"arg5=+64(%di):u64" // r9
 )
 
 var (
@@ -255,3 +314,45 @@ func (s *Subscription) RegisterSyscallExitEventFilter(
 	s.registerTracepoint(syscallExitName, s.decodeSysExit,
 		filter, SyscallExitEventTypes)
 }
+
+// RegisterRawSyscallEnterEventFilter registers a syscall enter event filter
+// with a subscription that attaches directly to the sys_enter tracepoint for
+// the specified set of system call numbers. The first argCount arguments of
+// each system call are reported; the remaining arguments are reported as 0.
+func (s *Subscription) RegisterRawSyscallEnterEventFilter(
+	ids []int64,
+	argCount int,
+	filter *expression.Expression,
+) {
+	if len(ids) == 0 {
+		s.logStatus("Raw syscall enter filter requires at least one syscall number")
+		return
+	}
+	if argCount < 0 || argCount > syscallMaxArgs {
+		s.logStatus(
+			fmt.Sprintf("Invalid raw syscall argument count %d", argCount))
+		return
+	}
+	syscallOnce.Do(s.initSyscallNames)
+
+	// The arguments are recorded as an array, which kernel filters cannot
+	// reference, so only the syscall numbers are filtered in the kernel.
+	kernelFilter := ""
+	for i, id := range ids {
+		if i > 0 {
+			kernelFilter += " || "
+		}
+		kernelFilter += fmt.Sprintf("id == %d", id)
+	}
+
+	decoder := func(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+		return s.decodeRawSysEnter(sample, data, argCount)
+	}
+	s.registerWithSensorFilter("raw syscall enter", filter,
+		SyscallEnterEventTypes,
+		func() (*eventSink, error) {
+			return s.registerTracepoint(syscallEnterName, decoder,
+				nil, SyscallEnterEventTypes,
+				perf.WithFilter(kernelFilter))
+		})
+}
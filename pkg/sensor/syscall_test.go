@@ -24,8 +24,28 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"golang.org/x/sys/unix"
 )
 
+func TestSyscallNames(t *testing.T) {
+	syscalls := map[string]int64{
+		"read":     unix.SYS_READ,
+		"openat":   unix.SYS_OPENAT,
+		"execve":   unix.SYS_EXECVE,
+		"ptrace":   unix.SYS_PTRACE,
+		"fcntl":    unix.SYS_FCNTL,
+		"renameat": unix.SYS_RENAMEAT,
+	}
+	for name, id := range syscalls {
+		assert.Equal(t, name, syscallName(id))
+		assert.Equal(t, id, syscallNumber(name))
+	}
+
+	assert.Equal(t, "", syscallName(-1))
+	assert.Equal(t, int64(-1), syscallNumber("not_a_syscall"))
+}
+
 func TestDecodeDummySysEnter(t *testing.T) {
 	// This does nothing except increase coverage since the function it's
 	// "testing" does nothing except return nil, nil
@@ -74,6 +94,56 @@ func TestDecodeSyscallTraceEnter(t *testing.T) {
 	assert.Equal(t, data["arg3"], e.Arguments[3])
 	assert.Equal(t, data["arg4"], e.Arguments[4])
 	assert.Equal(t, data["arg5"], e.Arguments[5])
+
+	// The syscall number is 32 bits wide on arm64
+	data["id"] = int32(-1)
+	i, err = s.decodeSyscallTraceEnter(sample, data)
+	require.NoError(t, err)
+	e, ok = i.(SyscallEnterTelemetryEvent)
+	require.True(t, ok)
+	assert.Equal(t, int64(-1), e.ID)
+}
+
+func TestDecodeRawSysEnter(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"id":         int64(321),
+		"args": []interface{}{
+			uint64(0x11), uint64(0x22), uint64(0x33),
+			uint64(0x44), uint64(0x55), uint64(0x66),
+		},
+	}
+
+	i, err := s.decodeRawSysEnter(sample, data, 2)
+	assert.Nil(t, i)
+	assert.NoError(t, err)
+
+	delete(data, "common_pid")
+	i, err = s.decodeRawSysEnter(sample, data, 2)
+	require.NotNil(t, i)
+	require.NoError(t, err)
+	e, ok := i.(SyscallEnterTelemetryEvent)
+	require.True(t, ok)
+
+	ok = testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, int64(321), e.ID)
+	assert.Equal(t, [6]uint64{0x11, 0x22, 0, 0, 0, 0}, e.Arguments)
+	assert.Equal(t, uint64(0x22), data["arg1"])
+	assert.Equal(t, uint64(0), data["arg2"])
+
+	i, err = s.decodeRawSysEnter(sample, data, syscallMaxArgs)
+	require.NoError(t, err)
+	e = i.(SyscallEnterTelemetryEvent)
+	assert.Equal(t, [6]uint64{0x11, 0x22, 0x33, 0x44, 0x55, 0x66}, e.Arguments)
 }
 
 func TestDecodeSysExit(t *testing.T) {
@@ -246,3 +316,41 @@ func TestRegisterSyscallExitEventFilter(t *testing.T) {
 	s.RegisterSyscallExitEventFilter(nil)
 	verifyRegisterSyscallExitEventFilter(t, s, 1)
 }
+
+func TestRegisterRawSyscallEnterEventFilter(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	e := expression.Equal(expression.Identifier("arg1"),
+		expression.Value(uint64(0x22)))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s := newTestSubscription(t, sensor)
+	s.RegisterRawSyscallEnterEventFilter([]int64{321, 322}, 2, expr)
+	require.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	s.RegisterRawSyscallEnterEventFilter(nil, 2, nil)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+
+	s = newTestSubscription(t, sensor)
+	s.RegisterRawSyscallEnterEventFilter([]int64{321}, 7, nil)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+
+	e = expression.Equal(expression.Identifier("foo"), expression.Value("bar"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s = newTestSubscription(t, sensor)
+	s.RegisterRawSyscallEnterEventFilter([]int64{321}, 0, expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
@@ -16,19 +16,21 @@ package sensor
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"math"
 	"net"
-	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	api "github.com/capsule8/capsule8/api/v0"
 	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/logging"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
 
 	"github.com/golang/glog"
@@ -40,6 +42,11 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	// Registers the gzip compressor so that clients may request that
+	// events are compressed with grpc.UseCompressor
+	_ "google.golang.org/grpc/encoding/gzip"
 )
 
 // TelemetryServiceGetEventsRequestFunc is a function called when a new
@@ -113,12 +120,21 @@ func WithGetEventsResponseFunc(f TelemetryServiceGetEventsResponseFunc) Telemetr
 // process telemetry subscription requests and stream the resulting telemetry
 // events.
 type TelemetryService struct {
-	server *grpc.Server
-	sensor *Sensor
+	server  *grpc.Server
+	handler *telemetryServiceServer
+	sensor  *Sensor
 
 	address string
 
 	options telemetryServiceOptions
+
+	// draining is closed when the service is stopped, so that streams,
+	// the spool, and sinks send the events that they hold and finish.
+	draining     chan struct{}
+	drainingOnce sync.Once
+
+	// The spool and sinks that Serve waits for once draining
+	internal sync.WaitGroup
 }
 
 // NewTelemetryService creates a new TelemetryService instance that can be used
@@ -129,13 +145,18 @@ func NewTelemetryService(
 	options ...TelemetryServiceOption,
 ) *TelemetryService {
 	ts := &TelemetryService{
-		sensor:  sensor,
-		address: address,
+		sensor:   sensor,
+		address:  address,
+		draining: make(chan struct{}),
 	}
 
 	for _, o := range options {
 		o(&ts.options)
 	}
+	ts.handler = &telemetryServiceServer{
+		sensor:  sensor,
+		service: ts,
+	}
 
 	return ts
 }
@@ -157,36 +178,10 @@ func (ts *TelemetryService) Serve() error {
 	glog.V(1).Info("Serving gRPC API on ", ts.address)
 
 	parts := strings.Split(ts.address, ":")
-	if len(parts) > 1 && parts[0] == "unix" {
-		socketPath := parts[1]
-
-		// Check whether socket already exists and if someone
-		// is already listening on it.
-		_, err = os.Stat(socketPath)
-		if err == nil {
-			var ua *net.UnixAddr
-
-			ua, err = net.ResolveUnixAddr("unix", socketPath)
-			if err == nil {
-				var c *net.UnixConn
-
-				c, err = net.DialUnix("unix", nil, ua)
-				if err == nil {
-					// There is another running service.
-					// Try to listen below and return the
-					// error.
-					c.Close()
-				} else {
-					// Remove the stale socket so the
-					// listen below will succeed.
-					os.Remove(socketPath)
-				}
-			}
-		}
-
-		oldMask := unix.Umask(0077)
-		lis, err = net.Listen("unix", socketPath)
-		unix.Umask(oldMask)
+	isUnix := len(parts) > 1 && parts[0] == "unix"
+	if isUnix {
+		lis, err = listenUnix(parts[1], config.Sensor.ListenSocketMode,
+			config.Sensor.ListenSocketGroup)
 	} else {
 		lis, err = net.Listen("tcp", ts.address)
 	}
@@ -196,52 +191,106 @@ func (ts *TelemetryService) Serve() error {
 	}
 	defer lis.Close()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The spool and sinks are given until the shutdown timeout to finish
+	// writing and publishing their events once ctx is done
+	abortCtx, abort := context.WithCancel(context.Background())
+	defer abort()
+
 	// Start local gRPC service on listener
+	var opts []grpc.ServerOption
 	if config.Sensor.UseTLS {
 		glog.V(1).Infoln("Starting telemetry server with TLS credentials")
 
-		certificate, err := tls.LoadX509KeyPair(config.Sensor.TLSServerCertPath, config.Sensor.TLSServerKeyPath)
-		if err != nil {
-			return fmt.Errorf("could not load server key pair: %s", err)
-		}
-
-		certPool := x509.NewCertPool()
-		ca, err := ioutil.ReadFile(config.Sensor.TLSCACertPath)
+		reloader, err := newTLSReloader(config.Sensor.TLSServerCertPath,
+			config.Sensor.TLSServerKeyPath, config.Sensor.TLSCACertPath)
 		if err != nil {
-			return fmt.Errorf("could not read ca certificate: %s", err)
+			return err
 		}
-
-		if ok := certPool.AppendCertsFromPEM(ca); !ok {
-			return errors.New("failed to append certs")
+		if config.Sensor.TLSReloadInterval > 0 {
+			go reloader.watch(ctx, config.Sensor.TLSReloadInterval)
 		}
 
-		creds := credentials.NewTLS(&tls.Config{
-			ClientAuth:   tls.RequireAndVerifyClientCert,
-			Certificates: []tls.Certificate{certificate},
-			ClientCAs:    certPool,
-		})
-		ts.server = grpc.NewServer(grpc.Creds(creds))
+		creds := credentials.NewTLS(reloader.config())
+		opts = append(opts, grpc.Creds(creds))
 	} else {
 		glog.V(1).Infoln("Starting telemetry server")
-		ts.server = grpc.NewServer()
+		if isUnix {
+			opts = append(opts, grpc.Creds(unixPeerCredentials{}))
+		}
 	}
+	if config.Sensor.AuthTokensPath != "" {
+		auth, err := newTokenAuthenticator(config.Sensor.AuthTokensPath)
+		if err != nil {
+			return fmt.Errorf("could not load tokens: %v", err)
+		}
+		opts = append(opts, auth.serverOptions()...)
+	}
+	ts.server = grpc.NewServer(opts...)
 
-	t := &telemetryServiceServer{
-		sensor:  ts.sensor,
-		service: ts,
+	t := ts.handler
+	if config.Sensor.SpoolDir != "" {
+		t.spool, err = startEventSpool(ctx, ts.sensor, &ts.internal)
+		if err != nil {
+			return fmt.Errorf("could not start spool: %v", err)
+		}
+	}
+	err = startEventSinks(ctx, abortCtx, ts.sensor, &ts.internal)
+	if err != nil {
+		cancel()
+		ts.internal.Wait()
+		return fmt.Errorf("could not start sinks: %v", err)
 	}
 	api.RegisterTelemetryServiceServer(ts.server, t)
+	registerHealthServer(ctx, ts.server, ts.sensor)
 
 	if ts.options.start != nil {
 		ts.options.start()
 	}
 
-	return ts.server.Serve(lis)
+	err = ts.server.Serve(lis)
+
+	// Close the subscriptions of the spool and sinks, and wait for them
+	// to finish with the events that they hold
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		ts.internal.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(config.Sensor.ShutdownTimeout):
+		glog.Warningf("Spool and sinks did not finish within %s",
+			config.Sensor.ShutdownTimeout)
+		abort()
+	}
+
+	return err
 }
 
-// Stop will stop a running TelemetryService.
+// Stop will stop a running TelemetryService. Each GetEvents stream is sent
+// the events held for it and then the end of the stream, and the spool and
+// sinks write and publish the events that they hold. Streams that have not
+// ended after the configured shutdown timeout are closed.
 func (ts *TelemetryService) Stop() {
-	ts.server.Stop()
+	ts.drainingOnce.Do(func() { close(ts.draining) })
+
+	done := make(chan struct{})
+	go func() {
+		ts.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(config.Sensor.ShutdownTimeout):
+		glog.Warningf("Streams did not end within %s, closing them",
+			config.Sensor.ShutdownTimeout)
+		ts.server.Stop()
+	}
+
 	if ts.options.stop != nil {
 		ts.options.stop()
 	}
@@ -250,6 +299,148 @@ func (ts *TelemetryService) Stop() {
 type telemetryServiceServer struct {
 	sensor  *Sensor
 	service *TelemetryService
+
+	streamsMutex sync.Mutex
+	streams      map[string]*getEventsStream
+
+	deliveriesMutex sync.Mutex
+	deliveries      map[string]*eventDelivery
+
+	spool *eventSpool
+}
+
+// streamUpdate is sent to a GetEvents stream to replace its subscription.
+type streamUpdate struct {
+	subscription *Subscription
+	expression   *expression.Expression
+	cancel       context.CancelFunc
+}
+
+// streamModifyFunc replaces the filters of a GetEvents stream, returning the
+// statuses of the new subscription.
+type streamModifyFunc func(sub *api.Subscription) ([]string, error)
+
+// getEventsStream describes an open GetEvents stream.
+type getEventsStream struct {
+	// These are accessed atomically and must be first for alignment
+	eventsReceived uint64
+	eventsSent     uint64
+	eventsDropped  uint64
+	counters       eventCounters
+
+	id         string
+	peer       string
+	identity   string
+	scope      *tokenScope
+	startTime  time.Time
+	apiVersion api.APIVersion
+	modify     streamModifyFunc
+
+	mutex        sync.Mutex
+	subscription *api.Subscription
+}
+
+func newGetEventsStream(ctx context.Context, sub *api.Subscription) *getEventsStream {
+	ts := &getEventsStream{
+		scope:        tokenScopeFromContext(ctx),
+		startTime:    time.Now(),
+		subscription: sub,
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if p.Addr != nil {
+			ts.peer = p.Addr.String()
+		}
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if certs := tlsInfo.State.PeerCertificates; len(certs) > 0 {
+				ts.identity = certs[0].Subject.CommonName
+			}
+		} else if unixInfo, ok := p.AuthInfo.(unixPeerAuthInfo); ok {
+			ts.identity = fmt.Sprintf("uid=%d gid=%d pid=%d",
+				unixInfo.UID, unixInfo.GID, unixInfo.Pid)
+		}
+	}
+	if ts.identity == "" && ts.scope != nil {
+		ts.identity = ts.scope.name
+	}
+	return ts
+}
+
+// setFilters records the filters of a subscription that replaced those of
+// the stream.
+func (ts *getEventsStream) setFilters(sub *api.Subscription) {
+	ts.mutex.Lock()
+	s := *sub
+	s.Modifier = ts.subscription.Modifier
+	s.CorrelateContainers = ts.subscription.CorrelateContainers
+	s.TtlSeconds = ts.subscription.TtlSeconds
+	ts.subscription = &s
+	ts.mutex.Unlock()
+}
+
+func (ts *getEventsStream) info(now time.Time) *api.SubscriptionInfo {
+	ts.mutex.Lock()
+	sub := ts.subscription
+	ts.mutex.Unlock()
+
+	info := &api.SubscriptionInfo{
+		SubscriptionId:  ts.id,
+		Subscription:    sub,
+		Peer:            ts.peer,
+		PeerIdentity:    ts.identity,
+		StartTimeMicros: ts.startTime.UnixNano() / int64(time.Microsecond),
+		EventsReceived:  atomic.LoadUint64(&ts.eventsReceived),
+		EventsSent:      atomic.LoadUint64(&ts.eventsSent),
+		EventsDropped:   atomic.LoadUint64(&ts.eventsDropped),
+		ApiVersion:      ts.apiVersion,
+	}
+	if d := now.Sub(ts.startTime); d > 0 {
+		info.EventsPerSecond = float64(info.EventsSent) / d.Seconds()
+	}
+	return info
+}
+
+func (t *telemetryServiceServer) addStream(ts *getEventsStream) {
+	t.streamsMutex.Lock()
+	if t.streams == nil {
+		t.streams = make(map[string]*getEventsStream)
+	}
+	t.streams[ts.id] = ts
+	t.streamsMutex.Unlock()
+}
+
+func (t *telemetryServiceServer) removeStream(id string) {
+	t.streamsMutex.Lock()
+	delete(t.streams, id)
+	t.streamsMutex.Unlock()
+}
+
+// listStreams returns the open GetEvents streams in no particular order.
+func (t *telemetryServiceServer) listStreams() []*getEventsStream {
+	t.streamsMutex.Lock()
+	streams := make([]*getEventsStream, 0, len(t.streams))
+	for _, ts := range t.streams {
+		streams = append(streams, ts)
+	}
+	t.streamsMutex.Unlock()
+	return streams
+}
+
+// newSubscriptionStatuses returns the statuses sent to a client for a
+// subscription, which are OK if the subscription has no errors.
+func newSubscriptionStatuses(statuses []string) []*status.Status {
+	if len(statuses) == 0 {
+		return []*status.Status{
+			&status.Status{Code: int32(code.Code_OK)},
+		}
+	}
+	r := make([]*status.Status, 0, len(statuses))
+	for _, st := range statuses {
+		r = append(r, &status.Status{
+			Code:    int32(code.Code_UNKNOWN),
+			Message: st,
+		})
+	}
+	return r
 }
 
 func (t *telemetryServiceServer) getEventsError(err error) error {
@@ -270,18 +461,25 @@ func (t *telemetryServiceServer) GetEvents(
 	sub := req.Subscription
 	glog.V(1).Infof("GetEvents(%+v)", sub)
 
-	if sub.EventFilter == nil {
-		glog.V(1).Infof("Invalid subscription: %+v", sub)
-		return t.getEventsError(errors.New("Invalid subscription (no EventFilter)"))
-	}
-
 	// Validate sub.Modifier
 	var (
 		err              error
 		maxEvents        int64
 		throttleDuration time.Duration
+		rateLimit        *api.RateLimitModifier
+		sampleOneIn      int64
+		keyedThrottle    *keyedThrottle
+		aggregator       *eventAggregator
+		projection       *eventProjection
+		batch            *eventBatch
+		bufferModifier   *api.BufferModifier
 	)
+	apiVersion, err := negotiateAPIVersion(req.ApiVersion)
+	if err != nil {
+		return t.getEventsError(err)
+	}
 	if sub.Modifier != nil {
+		bufferModifier = sub.Modifier.Buffer
 		if sub.Modifier.Limit != nil {
 			maxEvents = sub.Modifier.Limit.Limit
 			if maxEvents < 1 {
@@ -291,67 +489,183 @@ func (t *telemetryServiceServer) GetEvents(
 			}
 		}
 		if sub.Modifier.Throttle != nil {
-			if sub.Modifier.Throttle.Interval <= 0 {
-				err = fmt.Errorf("ThrottleModifier interval is invalid (%d)",
-					sub.Modifier.Throttle.Interval)
+			throttleDuration, err = throttleInterval(
+				sub.Modifier.Throttle.Interval,
+				sub.Modifier.Throttle.IntervalType)
+			if err != nil {
+				err = fmt.Errorf("ThrottleModifier %v", err)
 				return t.getEventsError(err)
 			}
-			throttleDuration =
-				time.Duration(sub.Modifier.Throttle.Interval)
-			switch sub.Modifier.Throttle.IntervalType {
-			case api.ThrottleModifier_MILLISECOND:
-				throttleDuration *= time.Millisecond
-			case api.ThrottleModifier_SECOND:
-				throttleDuration *= time.Second
-			case api.ThrottleModifier_MINUTE:
-				throttleDuration *= time.Minute
-			case api.ThrottleModifier_HOUR:
-				throttleDuration *= time.Hour
-			default:
-				err = fmt.Errorf("ThrottleModifier interval type is invalid (%d)",
-					sub.Modifier.Throttle.IntervalType)
+		}
+		if sub.Modifier.KeyedThrottle != nil {
+			keyedThrottle, err = newKeyedThrottle(sub.Modifier.KeyedThrottle)
+			if err != nil {
+				err = fmt.Errorf("KeyedThrottleModifier %v", err)
+				return t.getEventsError(err)
+			}
+		}
+		if sub.Modifier.Aggregate != nil {
+			aggregator, err = newEventAggregator(sub.Modifier.Aggregate)
+			if err != nil {
+				err = fmt.Errorf("AggregateModifier %v", err)
+				return t.getEventsError(err)
+			}
+		}
+		if sub.Modifier.Projection != nil {
+			projection, err = newEventProjection(sub.Modifier.Projection)
+			if err != nil {
+				err = fmt.Errorf("ProjectionModifier %v", err)
+				return t.getEventsError(err)
+			}
+		}
+		if sub.Modifier.Batch != nil {
+			batch, err = newEventBatch(sub.Modifier.Batch)
+			if err != nil {
+				err = fmt.Errorf("BatchModifier %v", err)
+				return t.getEventsError(err)
+			}
+		}
+		if rateLimit = sub.Modifier.RateLimit; rateLimit != nil {
+			if !(rateLimit.EventsPerSecond > 0) {
+				err = fmt.Errorf("RateLimitModifier events per second is invalid (%v)",
+					rateLimit.EventsPerSecond)
 				return t.getEventsError(err)
 			}
 		}
+		if sub.Modifier.Sample != nil {
+			sampleOneIn = int64(sub.Modifier.Sample.OneIn)
+			if sampleOneIn < 1 {
+				err = fmt.Errorf("SampleModifier rate is invalid (%d)",
+					sampleOneIn)
+				return t.getEventsError(err)
+			}
+		}
+	}
+	buffer, err := newEventBuffer(bufferModifier)
+	if err != nil {
+		err = fmt.Errorf("BufferModifier %v", err)
+		return t.getEventsError(err)
+	}
+
+	var delivery *eventDelivery
+	if sub.DeliveryId != "" {
+		delivery, err = t.attachDelivery(sub.DeliveryId,
+			tokenScopeFromContext(stream.Context()), time.Now())
+		if err != nil {
+			return t.getEventsError(err)
+		}
+		defer func() { t.detachDelivery(delivery, time.Now()) }()
+	}
+
+	correlateContainers := sub.CorrelateContainers
+	scope := tokenScopeFromContext(stream.Context())
+
+	// newSubscription creates the sensor subscription for the stream from
+	// the filters in sub. The stream's modifiers are applied to it, but
+	// those in sub are not, so that ModifySubscription can use it to
+	// replace the filters of the stream.
+	newSubscription := func(sub *api.Subscription) (*Subscription, *expression.Expression, error) {
+		if sub.EventFilter == nil {
+			glog.V(1).Infof("Invalid subscription: %+v", sub)
+			return nil, nil, errors.New("Invalid subscription (no EventFilter)")
+		}
+		if err := checkAPIVersion(sub, apiVersion); err != nil {
+			return nil, nil, err
+		}
+		if err := scope.authorize(sub); err != nil {
+			return nil, nil, err
+		}
+
+		var eventExpr *expression.Expression
+		if sub.Expression != "" {
+			var err error
+			if eventExpr, err = newEventExpression(sub.Expression); err != nil {
+				return nil, nil, fmt.Errorf("Expression is invalid: %v", err)
+			}
+		}
+
+		// Validate container filter patterns here so that a bad pattern
+		// fails the subscription rather than being dropped from the
+		// filter
+		if sub.ContainerFilter != nil {
+			if err := validateContainerFilter(sub.ContainerFilter); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		subscr := t.sensor.NewSubscription()
+		subscr.translateTelemetryServiceSubscription(sub)
+		if rateLimit != nil {
+			burst := int(rateLimit.Burst)
+			if burst == 0 {
+				burst = int(math.Min(math.Ceil(rateLimit.EventsPerSecond),
+					math.MaxInt32))
+			}
+			subscr.SetRateLimit(rateLimit.EventsPerSecond, burst)
+		}
+		subscr.SetSampleRate(sampleOneIn)
+		if len(subscr.eventSinks) == 0 && len(subscr.status) == 0 {
+			glog.V(1).Infof("Invalid subscription: %+v", sub)
+			return nil, nil, errors.New("Invalid subscription (empty EventFilter)")
+		}
+		if correlateContainers {
+			subscr.registerCorrelationEvents()
+		}
+		return subscr, eventExpr, nil
 	}
 
-	subscr := t.sensor.NewSubscription()
-	subscr.translateTelemetryServiceSubscription(sub)
-	if len(subscr.eventSinks) == 0 && len(subscr.status) == 0 {
-		glog.V(1).Infof("Invalid subscription: %+v", sub)
-		return t.getEventsError(errors.New("Invalid subscription (empty EventFilter)"))
+	subscr, eventExpr, err := newSubscription(sub)
+	if err != nil {
+		return t.getEventsError(err)
+	}
+	var correlator *containerCorrelator
+	if correlateContainers {
+		correlator = newContainerCorrelator(subscr)
 	}
 
-	events := make(chan TelemetryEvent, config.Sensor.ChannelBufferLength)
+	ts := newGetEventsStream(stream.Context(), sub)
+	ts.apiVersion = apiVersion
+	subscr.counters = &ts.counters
+	events := buffer.events
 	f := func(e TelemetryEvent) {
-		// Send the event to the data channel, but drop the event if
-		// the channel is full. Do not block the sensor from delivering
+		// Send the event to the stream's buffer, which drops events
+		// when it is full according to its overflow policy so that a
+		// slow client does not block the sensor from delivering
 		// telemetry to other subscribers.
-		select {
-		case events <- e:
-		default:
+		atomic.AddUint64(&ts.eventsReceived, 1)
+		if n := buffer.add(e); n > 0 {
+			atomic.AddUint64(&ts.eventsDropped, uint64(n))
 		}
 	}
 
-	ctx, cancel := context.WithCancel(stream.Context())
+	// The subscription is closed when its context is done, so the TTL is
+	// enforced with a deadline on the context
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		ttl    time.Duration
+	)
+	if sub.TtlSeconds > 0 {
+		ttl = time.Duration(sub.TtlSeconds) * time.Second
+		ctx, cancel = context.WithTimeout(stream.Context(), ttl)
+	} else {
+		ctx, cancel = context.WithCancel(stream.Context())
+	}
 	defer cancel()
 
-	statuses, runErr := subscr.Run(ctx, f)
+	// Each sensor subscription for the stream has its own context so that
+	// it can be closed when ModifySubscription replaces it
+	subscrCtx, subscrCancel := context.WithCancel(ctx)
+	defer func() { subscrCancel() }()
+	statuses, runErr := subscr.Run(subscrCtx, f)
+	ts.id = strconv.FormatUint(subscr.subscriptionID, 10)
 	if runErr == nil || len(statuses) > 0 {
-		r := &api.GetEventsResponse{}
-		if len(statuses) == 0 {
-			r.Statuses = []*status.Status{
-				&status.Status{Code: int32(code.Code_OK)},
-			}
-		} else {
-			r.Statuses = make([]*status.Status, 0, len(statuses))
-			for _, st := range statuses {
-				r.Statuses = append(r.Statuses,
-					&status.Status{
-						Code:    int32(code.Code_UNKNOWN),
-						Message: st,
-					})
-			}
+		r := &api.GetEventsResponse{
+			Statuses: newSubscriptionStatuses(statuses),
+		}
+		if runErr == nil {
+			r.SubscriptionId = ts.id
+			r.ApiVersion = apiVersion
 		}
 		if err = stream.Send(r); err != nil {
 			return t.getEventsError(err)
@@ -366,38 +680,307 @@ func (t *telemetryServiceServer) GetEvents(
 		return runErr
 	}
 
+	// Responses that were not acknowledged on an earlier stream are sent
+	// before any new events
+	if delivery != nil {
+		for _, r := range delivery.unacknowledged() {
+			if err = stream.Send(r); err != nil {
+				return err
+			}
+		}
+	}
+
+	// modify replaces the sensor subscription for the stream with a new
+	// one made from sub. The new subscription is running before the one
+	// it replaces is closed, so no events are lost.
+	updates := make(chan streamUpdate)
+	ts.modify = func(sub *api.Subscription) ([]string, error) {
+		s, expr, err := newSubscription(sub)
+		if err != nil {
+			return nil, err
+		}
+		s.counters = &ts.counters
+		sctx, scancel := context.WithCancel(ctx)
+		statuses, err := s.Run(sctx, f)
+		if err != nil {
+			scancel()
+			return statuses, err
+		}
+		select {
+		case updates <- streamUpdate{
+			subscription: s,
+			expression:   expr,
+			cancel:       scancel,
+		}:
+			ts.setFilters(sub)
+			return statuses, nil
+		case <-ctx.Done():
+			scancel()
+			return nil, ctx.Err()
+		}
+	}
+	t.addStream(ts)
+	defer t.removeStream(ts.id)
+
 	var nEvents int64
 	nextEventTime := time.Now()
+
+	// sendResponse sends a response of events to the client, holding it
+	// until it is acknowledged if the subscription has a delivery.
+	// Otherwise, the events are released to be reused once they have been
+	// serialized by Send.
+	sendResponse := func(events []*api.ReceivedTelemetryEvent) error {
+		r := &api.GetEventsResponse{
+			Events: events,
+		}
+		if delivery != nil {
+			if err := delivery.add(ctx, r); err != nil {
+				return err
+			}
+		}
+		if err := stream.Send(r); err != nil {
+			return err
+		}
+		atomic.AddUint64(&ts.eventsSent, uint64(len(events)))
+		if delivery == nil {
+			releaseReceivedTelemetryEvents(events)
+		}
+		return nil
+	}
+
+	// batchC fires when the first event of the pending batch has waited
+	// the BatchModifier's max latency
+	var batchC <-chan time.Time
+	flushBatch := func() error {
+		batchC = nil
+		if batch.empty() {
+			return nil
+		}
+		return sendResponse(batch.flush())
+	}
+
+	// streamSend sends events to the client, applying the projection,
+	// batch, and limit modifiers. An error ends the stream.
+	streamSend := func(events []*api.ReceivedTelemetryEvent) error {
+		for _, re := range events {
+			if projection != nil {
+				projection.apply(re.Event)
+			}
+			if batch == nil {
+				if err := sendResponse([]*api.ReceivedTelemetryEvent{re}); err != nil {
+					return err
+				}
+			} else if batch.add(re) {
+				if err := flushBatch(); err != nil {
+					return err
+				}
+			} else if batchC == nil {
+				batchC = time.After(batch.maxLatency)
+			}
+			if maxEvents > 0 {
+				nEvents++
+				if nEvents == maxEvents {
+					if batch != nil {
+						if err := flushBatch(); err != nil {
+							return err
+						}
+					}
+					return fmt.Errorf("Event limit reached (%d)",
+						maxEvents)
+				}
+			}
+		}
+		return nil
+	}
+
+	// correlateC fires when the oldest events held by the correlator
+	// must be released
+	var correlateC <-chan time.Time
+	scheduleCorrelation := func() {
+		if end, ok := correlator.nextReleaseTime(); ok {
+			correlateC = time.After(end.Sub(time.Now()))
+		} else {
+			correlateC = nil
+		}
+	}
+
+	// drop counts an event that is not sent as filtered and releases it
+	drop := func(event *api.TelemetryEvent) {
+		ts.counters.addFiltered()
+		releaseTelemetryEvent(event)
+	}
+
+	// send drops events that did not exist at the stream's API version
+	// and applies the throttle modifiers to an event, then passes it to
+	// the correlator, if any, before sending it.
+	send := func(re *api.ReceivedTelemetryEvent) error {
+		if !eventInAPIVersion(re.Event, apiVersion) {
+			drop(re.Event)
+			return nil
+		}
+		if keyedThrottle != nil && !keyedThrottle.allow(re.Event, time.Now()) {
+			drop(re.Event)
+			return nil
+		}
+		if throttleDuration != 0 {
+			now := time.Now()
+			if now.Before(nextEventTime) {
+				drop(re.Event)
+				return nil
+			}
+			nextEventTime = now.Add(throttleDuration)
+		}
+		if correlator == nil {
+			return streamSend([]*api.ReceivedTelemetryEvent{re})
+		}
+		events := correlator.add(re, time.Now())
+		if correlateC == nil {
+			scheduleCorrelation()
+		}
+		return streamSend(events)
+	}
+
+	// statusC fires when the stream's next sensor status event is due.
+	// Clients using API version 1 cannot decode them.
+	var (
+		statusC <-chan time.Time
+		status  *statusReporter
+	)
+	if interval := config.CurrentSensor().StatusInterval; interval > 0 &&
+		apiVersion >= api.APIVersion_API_VERSION_2 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		statusC = ticker.C
+		status = newStatusReporter(t.sensor, &ts.eventsDropped)
+	}
+
+	// aggregateC fires when the oldest pending aggregate's window ends
+	var aggregateC <-chan time.Time
+	scheduleAggregates := func() {
+		if end, ok := aggregator.nextWindowEnd(); ok {
+			aggregateC = time.After(end.Sub(time.Now()))
+		} else {
+			aggregateC = nil
+		}
+	}
+
+	// receive translates an event from the stream's buffer and sends it
+	// or adds it to its aggregate
+	receive := func(e TelemetryEvent) error {
+		event := subscr.translateEvent(e)
+		if eventExpr != nil && !matchEventExpression(eventExpr, event) {
+			drop(event)
+			return nil
+		}
+		if aggregator != nil {
+			aggregator.add(event, time.Now())
+			if aggregateC == nil {
+				scheduleAggregates()
+			}
+			return nil
+		}
+		return send(&api.ReceivedTelemetryEvent{
+			Event: event,
+		})
+	}
+
+	// flush sends the events held by the aggregator, then those held by
+	// the correlator, which may include aggregates, and then the batch.
+	flush := func() error {
+		if aggregator != nil {
+			for _, re := range aggregator.flush() {
+				if err := send(re); err != nil {
+					return err
+				}
+			}
+		}
+		if correlator != nil {
+			if err := streamSend(correlator.flush()); err != nil {
+				return err
+			}
+		}
+		if batch != nil {
+			return flushBatch()
+		}
+		return nil
+	}
+
+	// drain sends all of the events that the stream holds once the
+	// sensor is shutting down, and then the end of the stream.
+	drain := func() error {
+		// Close the subscription so that no more events are added
+		// to the buffer
+		subscrCancel()
+		for drained := false; !drained; {
+			select {
+			case e := <-events:
+				if err := receive(e); err != nil {
+					return err
+				}
+			default:
+				drained = true
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		return stream.Send(&api.GetEventsResponse{
+			EndOfStream: true,
+		})
+	}
+
 	for {
 		select {
+		case <-t.service.draining:
+			glog.V(1).Infof("Sensor is shutting down, closing stream")
+			return drain()
 		case <-ctx.Done():
+			if ttl > 0 && stream.Context().Err() == nil {
+				glog.V(1).Infof("Subscription TTL expired, closing stream")
+				if err = flush(); err != nil {
+					return err
+				}
+				return fmt.Errorf("Subscription TTL expired (%s)", ttl)
+			}
 			glog.V(1).Infof("Client disconnected, closing stream")
 			return ctx.Err()
+		case u := <-updates:
+			subscrCancel()
+			subscr, eventExpr, subscrCancel = u.subscription,
+				u.expression, u.cancel
+			if correlator != nil {
+				correlator.subscription = subscr
+			}
 		case e := <-events:
-			if throttleDuration != 0 {
-				now := time.Now()
-				if now.Before(nextEventTime) {
-					break
+			if err = receive(e); err != nil {
+				return err
+			}
+		case <-aggregateC:
+			for _, re := range aggregator.expire(time.Now()) {
+				if err = send(re); err != nil {
+					return err
 				}
-				nextEventTime = now
-				nextEventTime.Add(throttleDuration)
 			}
-			r := &api.GetEventsResponse{
-				Events: []*api.ReceivedTelemetryEvent{
-					&api.ReceivedTelemetryEvent{
-						Event: subscr.translateEvent(e),
-					},
-				},
+			scheduleAggregates()
+		case <-correlateC:
+			if err = streamSend(correlator.expire(time.Now())); err != nil {
+				return err
 			}
-			if err = stream.Send(r); err != nil {
+			scheduleCorrelation()
+		case <-batchC:
+			if err = flushBatch(); err != nil {
 				return err
 			}
-			if maxEvents > 0 {
-				nEvents++
-				if nEvents == maxEvents {
-					return fmt.Errorf("Event limit reached (%d)",
-						maxEvents)
-				}
+		case <-statusC:
+			// Status events skip the subscription's expression and
+			// throttling so that they are always seen
+			err = streamSend([]*api.ReceivedTelemetryEvent{
+				&api.ReceivedTelemetryEvent{
+					Event: subscr.translateEvent(status.newEvent()),
+				},
+			})
+			if err != nil {
+				return err
 			}
 		}
 	}
@@ -406,78 +989,708 @@ func (t *telemetryServiceServer) GetEvents(
 	return nil
 }
 
-func (s *Subscription) translateTelemetryServiceSubscription(sub *api.Subscription) {
-	if sub.ContainerFilter != nil {
-		cf := NewContainerFilter()
-		for _, id := range sub.ContainerFilter.Ids {
-			cf.AddContainerID(id)
-		}
-		for _, name := range sub.ContainerFilter.Names {
-			cf.AddContainerName(name)
+func stringsWithPrefix(l []string, prefix string) []string {
+	if len(prefix) == 0 {
+		return l
+	}
+	var r []string
+	for _, s := range l {
+		if strings.HasPrefix(s, prefix) {
+			r = append(r, s)
 		}
-		for _, id := range sub.ContainerFilter.ImageIds {
-			cf.AddImageID(id)
+	}
+	return r
+}
+
+func (t *telemetryServiceServer) ModifySubscription(
+	ctx context.Context,
+	req *api.ModifySubscriptionRequest,
+) (*api.ModifySubscriptionResponse, error) {
+	glog.V(1).Infof("ModifySubscription(%+v)", req)
+
+	if req.Subscription == nil {
+		return nil, errors.New("Invalid request (no Subscription)")
+	}
+
+	t.streamsMutex.Lock()
+	ts, ok := t.streams[req.SubscriptionId]
+	t.streamsMutex.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("Subscription %q does not exist",
+			req.SubscriptionId)
+	}
+	if !tokenScopeFromContext(ctx).permits(ts.scope) {
+		return nil, permissionDenied("Subscription %q belongs to another token",
+			req.SubscriptionId)
+	}
+
+	statuses, err := ts.modify(req.Subscription)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ModifySubscriptionResponse{
+		Statuses: newSubscriptionStatuses(statuses),
+	}, nil
+}
+
+func (t *telemetryServiceServer) ListSubscriptions(
+	ctx context.Context,
+	req *api.ListSubscriptionsRequest,
+) (*api.ListSubscriptionsResponse, error) {
+	glog.V(1).Infof("ListSubscriptions(%+v)", req)
+
+	now := time.Now()
+	streams := t.listStreams()
+	infos := make([]*api.SubscriptionInfo, 0, len(streams))
+	for _, ts := range streams {
+		infos = append(infos, ts.info(now))
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].StartTimeMicros != infos[j].StartTimeMicros {
+			return infos[i].StartTimeMicros < infos[j].StartTimeMicros
 		}
-		for _, name := range sub.ContainerFilter.ImageNames {
-			cf.AddImageName(name)
+		return infos[i].SubscriptionId < infos[j].SubscriptionId
+	})
+	return &api.ListSubscriptionsResponse{
+		Subscriptions: infos,
+	}, nil
+}
+
+func (t *telemetryServiceServer) ListTracingEvents(
+	ctx context.Context,
+	req *api.ListTracingEventsRequest,
+) (*api.ListTracingEventsResponse, error) {
+	glog.V(1).Infof("ListTracingEvents(%+v)", req)
+
+	tracepoints, err := t.sensor.AvailableTracepoints()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &api.ListTracingEventsResponse{
+		Tracepoints: stringsWithPrefix(tracepoints, req.Prefix),
+	}
+	if req.IncludeKernelSymbols {
+		r.KernelSymbols = stringsWithPrefix(
+			t.sensor.AvailableKernelSymbols(), req.Prefix)
+	}
+	return r, nil
+}
+
+func (t *telemetryServiceServer) SetLogLevel(
+	ctx context.Context,
+	req *api.SetLogLevelRequest,
+) (*api.SetLogLevelResponse, error) {
+	glog.V(1).Infof("SetLogLevel(%+v)", req)
+
+	r := &api.SetLogLevelResponse{
+		PreviousVerbosity: int32(logging.Verbosity()),
+		PreviousVmodule:   logging.VModule(),
+	}
+	if len(req.Vmodule) > 0 {
+		if err := logging.SetVModule(req.Vmodule); err != nil {
+			return nil, err
+		}
+	}
+	if err := logging.SetVerbosity(int(req.Verbosity)); err != nil {
+		return nil, err
+	}
+	glog.Infof("Log level changed: %s", logging.Fields(
+		"verbosity", req.Verbosity, "vmodule", logging.VModule(),
+		"previous_verbosity", r.PreviousVerbosity,
+		"previous_vmodule", r.PreviousVmodule))
+	return r, nil
+}
+
+func validateContainerFilter(filter *api.ContainerFilter) error {
+	cf := NewContainerFilter()
+	for _, name := range filter.ImageNames {
+		if err := cf.AddImageName(name); err != nil {
+			return fmt.Errorf("ContainerFilter image name %q is invalid: %v",
+				name, err)
+		}
+	}
+	for _, pattern := range filter.NameRegexps {
+		if err := cf.AddContainerNameRegexp(pattern); err != nil {
+			return fmt.Errorf("ContainerFilter name regexp is invalid: %v",
+				err)
+		}
+	}
+	for _, pattern := range filter.ImageNameRegexps {
+		if err := cf.AddImageNameRegexp(pattern); err != nil {
+			return fmt.Errorf("ContainerFilter image name regexp is invalid: %v",
+				err)
+		}
+	}
+	if filter.Exclude != nil {
+		if err := validateContainerFilter(filter.Exclude); err != nil {
+			return fmt.Errorf("ContainerFilter exclude is invalid: %v",
+				err)
+		}
+	}
+	return nil
+}
+
+func (s *Subscription) translateContainerFilter(filter *api.ContainerFilter) *ContainerFilter {
+	cf := NewContainerFilter()
+	for _, id := range filter.Ids {
+		cf.AddContainerID(id)
+	}
+	for _, name := range filter.Names {
+		cf.AddContainerName(name)
+	}
+	for _, id := range filter.ImageIds {
+		cf.AddImageID(id)
+	}
+	for _, name := range filter.ImageNames {
+		if err := cf.AddImageName(name); err != nil {
+			s.logStatus(
+				fmt.Sprintf("Invalid container image name %q: %v",
+					name, err))
+		}
+	}
+	for _, pattern := range filter.NameRegexps {
+		if err := cf.AddContainerNameRegexp(pattern); err != nil {
+			s.logStatus(
+				fmt.Sprintf("Invalid container name regexp %q: %v",
+					pattern, err))
+		}
+	}
+	for _, pattern := range filter.ImageNameRegexps {
+		if err := cf.AddImageNameRegexp(pattern); err != nil {
+			s.logStatus(
+				fmt.Sprintf("Invalid container image name regexp %q: %v",
+					pattern, err))
+		}
+	}
+	if filter.Exclude != nil {
+		if x := s.translateContainerFilter(filter.Exclude); x.Len() > 0 {
+			cf.SetExclusion(x)
+		}
+	}
+	return cf
+}
+
+func (s *Subscription) translateTelemetryServiceSubscription(sub *api.Subscription) {
+	if n := sub.RingBufferPages; n > 0 {
+		// The kernel requires a power of 2
+		if n&(n-1) == 0 {
+			s.SetRingBufferNumPages(int(n))
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Ignoring ring buffer size %d (not a power of 2)",
+					n))
+		}
+	}
+
+	s.SetCaptureStackTraces(sub.CaptureStackTraces)
+	s.SetCaptureExecEnvironment(sub.CaptureExecEnvironment)
+
+	if sub.ContainerFilter != nil {
+		cf := s.translateContainerFilter(sub.ContainerFilter)
+		if cf.Len() > 0 {
+			s.SetContainerFilter(cf)
+		}
+
+		// Filters on names and images may match containers that
+		// have not started yet, so only IDs can be mapped to cgroups.
+		// Exclusions count towards the length, so they are never
+		// mapped.
+		if s.sensor.useContainerCgroups && len(cf.containerIDs) > 0 &&
+			len(cf.containerIDs) == cf.Len() {
+			cgroups, err := s.sensor.ContainerCache.ContainerCgroups(
+				sub.ContainerFilter.Ids)
+			if err == nil {
+				s.SetCgroups(cgroups)
+			} else {
+				glog.V(1).Infof("Filtering subscription %d on containers in the sensor: %v",
+					s.subscriptionID, err)
+			}
+		}
+	}
+
+	filter := sub.EventFilter
+	if disabled := s.sensor.disabledEventFilters; len(disabled) > 0 {
+		var removed []string
+		filter, removed = removeDisabledEventFilters(filter, disabled)
+		for _, name := range removed {
+			s.logStatus(
+				fmt.Sprintf("Ignoring %s (disabled by the Sensor)",
+					name))
+		}
+	}
+
+	s.registerBPFEvents(filter.BpfEvents)
+	s.registerChargenEvents(filter.ChargenEvents)
+	s.registerContainerEvents(filter.ContainerEvents)
+	s.registerFileEvents(filter.FileEvents)
+	s.registerImageEvents(filter.ImageEvents)
+	s.registerIOUringEvents(filter.IoUringEvents)
+	s.registerKernelFunctionCallEvents(filter.KernelEvents)
+	s.registerKernelModuleEvents(filter.KernelModuleEvents)
+	s.registerLSMEvents(filter.LsmEvents)
+	s.registerMemoryEvents(filter.MemoryEvents)
+	s.registerMountEvents(filter.MountEvents)
+	s.registerNetworkEvents(filter.NetworkEvents)
+	s.registerPerformanceEvents(filter.PerformanceEvents)
+	s.registerProcessEvents(filter.ProcessEvents)
+	s.registerSessionEvents(filter.SessionEvents)
+	s.registerSignalEvents(filter.SignalEvents)
+	s.registerSyscallEvents(filter.SyscallEvents)
+	s.registerTickerEvents(filter.TickerEvents)
+	s.registerTTYEvents(filter.TtyEvents)
+	s.registerUserFunctionCallEvents(filter.UserEvents)
+}
+
+func (s *Subscription) registerBPFEvents(events []*api.BpfEventFilter) {
+	type registerFunc func(*expression.Expression)
+
+	var (
+		filters       [3]*api.Expression
+		subscriptions [3]registerFunc
+		wildcards     [3]bool
+	)
+
+	for _, e := range events {
+		t := e.GetType()
+		if t < 1 || t > api.BpfEventType(len(subscriptions)-1) {
+			s.logStatus(
+				fmt.Sprintf("BpfEventType %d is invalid", t))
+			continue
+		}
+
+		if subscriptions[t] == nil {
+			switch t {
+			case api.BpfEventType_BPF_EVENT_TYPE_PROGRAM_LOAD:
+				subscriptions[t] = s.RegisterBPFProgramLoadEventFilter
+			case api.BpfEventType_BPF_EVENT_TYPE_PROGRAM_ATTACH:
+				subscriptions[t] = s.RegisterBPFProgramAttachEventFilter
+			}
+		}
+		if e.FilterExpression == nil {
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
+		}
+	}
+
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid BPF filter expression: %v", err))
+		}
+	}
+}
+
+func (s *Subscription) registerChargenEvents(events []*api.ChargenEventFilter) {
+	for _, e := range events {
+		s.RegisterChargenEventFilter(e.Length, nil)
+	}
+}
+
+func (s *Subscription) registerContainerEvents(events []*api.ContainerEventFilter) {
+	type registerFunc func(*expression.Expression)
+
+	var (
+		filters       [9]*api.Expression
+		subscriptions [9]registerFunc
+		views         [9]bool
+		wildcards     [9]bool
+	)
+
+	for _, e := range events {
+		t := e.GetType()
+		if t < 1 || t > 8 {
+			s.logStatus(
+				fmt.Sprintf("ContainerEventType %d is invalid", t))
+			continue
+		}
+
+		if subscriptions[t] == nil {
+			switch t {
+			case api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED:
+				subscriptions[t] = s.RegisterContainerCreatedEventFilter
+			case api.ContainerEventType_CONTAINER_EVENT_TYPE_RUNNING:
+				subscriptions[t] = s.RegisterContainerRunningEventFilter
+			case api.ContainerEventType_CONTAINER_EVENT_TYPE_EXITED:
+				subscriptions[t] = s.RegisterContainerExitedEventFilter
+			case api.ContainerEventType_CONTAINER_EVENT_TYPE_DESTROYED:
+				subscriptions[t] = s.RegisterContainerDestroyedEventFilter
+			case api.ContainerEventType_CONTAINER_EVENT_TYPE_UPDATED:
+				subscriptions[t] = s.RegisterContainerUpdatedEventFilter
+			case api.ContainerEventType_CONTAINER_EVENT_TYPE_HEALTH:
+				subscriptions[t] = s.RegisterContainerHealthEventFilter
+			case api.ContainerEventType_CONTAINER_EVENT_TYPE_PAUSED:
+				subscriptions[t] = s.RegisterContainerPausedEventFilter
+			case api.ContainerEventType_CONTAINER_EVENT_TYPE_RESUMED:
+				subscriptions[t] = s.RegisterContainerResumedEventFilter
+			}
+		}
+		if e.View == api.ContainerEventView_FULL {
+			views[t] = true
+		}
+		if e.FilterExpression == nil {
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
+		}
+	}
+
+	// FIXME do something with views!
+
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid container filter expression: %v", err))
+		}
+	}
+}
+
+func rewriteFileEventFilter(fef *api.FileEventFilter) {
+	if fef.Filename != nil {
+		newExpr := expression.Equal(
+			expression.Identifier("filename"),
+			expression.Value(fef.Filename.Value))
+		fef.FilterExpression = expression.LogicalAnd(
+			fef.FilterExpression, newExpr)
+		fef.Filename = nil
+		fef.FilenamePattern = nil
+	} else if fef.FilenamePattern != nil {
+		newExpr := expression.Like(
+			expression.Identifier("filename"),
+			expression.Value(fef.FilenamePattern.Value))
+		fef.FilterExpression = expression.LogicalAnd(
+			fef.FilterExpression, newExpr)
+		fef.FilenamePattern = nil
+	}
+
+	if fef.OpenFlagsMask != nil {
+		newExpr := expression.NotEqual(
+			expression.BitwiseAnd(
+				expression.Identifier("flags"),
+				expression.Value(fef.OpenFlagsMask.Value)),
+			expression.Value(int32(0)))
+		fef.FilterExpression = expression.LogicalAnd(
+			fef.FilterExpression, newExpr)
+		fef.OpenFlagsMask = nil
+	}
+
+	if fef.CreateModeMask != nil {
+		newExpr := expression.NotEqual(
+			expression.BitwiseAnd(
+				expression.Identifier("mode"),
+				expression.Value(fef.CreateModeMask.Value)),
+			expression.Value(int32(0)))
+		fef.FilterExpression = expression.LogicalAnd(
+			fef.FilterExpression, newExpr)
+		fef.CreateModeMask = nil
+	}
+
+	if len(fef.PathPrefixes) > 0 || len(fef.PathGlobs) > 0 {
+		var pathExpr *api.Expression
+		for _, prefix := range fef.PathPrefixes {
+			pathExpr = expression.LogicalOr(pathExpr,
+				expression.Like(
+					expression.Identifier("filename"),
+					expression.Value(prefix+"*")))
+		}
+		for _, glob := range fef.PathGlobs {
+			pathExpr = expression.LogicalOr(pathExpr,
+				expression.Like(
+					expression.Identifier("filename"),
+					expression.Value(glob)))
+		}
+		fef.FilterExpression = expression.LogicalAnd(
+			fef.FilterExpression, pathExpr)
+		fef.PathPrefixes = nil
+		fef.PathGlobs = nil
+	}
+}
+
+func (s *Subscription) registerFileEvents(events []*api.FileEventFilter) {
+	type registerFunc func(*expression.Expression)
+
+	var (
+		filters       [4]*api.Expression
+		subscriptions [4]registerFunc
+		wildcards     [4]bool
+	)
+
+	for _, e := range events {
+		t := e.GetType()
+		if t < 1 || t > 3 {
+			s.logStatus(
+				fmt.Sprintf("FileEventType %d is invalid", t))
+			continue
+		}
+
+		if subscriptions[t] == nil {
+			switch t {
+			case api.FileEventType_FILE_EVENT_TYPE_OPEN:
+				subscriptions[t] = s.RegisterFileOpenEventFilter
+			case api.FileEventType_FILE_EVENT_TYPE_WRITE:
+				subscriptions[t] = s.RegisterFileWriteEventFilter
+			case api.FileEventType_FILE_EVENT_TYPE_MEMFD_CREATE:
+				subscriptions[t] = s.RegisterFileMemfdCreateEventFilter
+			}
+		}
+
+		// Translate deprecated fields into an expression
+		rewriteFileEventFilter(e)
+
+		if e.FilterExpression == nil {
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
+		}
+	}
+
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid file filter expression: %v", err))
+		}
+	}
+}
+
+func (s *Subscription) registerImageEvents(events []*api.ImageEventFilter) {
+	type registerFunc func(*expression.Expression)
+
+	var (
+		filters       [5]*api.Expression
+		subscriptions [5]registerFunc
+		wildcards     [5]bool
+	)
+
+	for _, e := range events {
+		t := e.GetType()
+		if t < 1 || t > 4 {
+			s.logStatus(
+				fmt.Sprintf("ImageEventType %d is invalid", t))
+			continue
+		}
+
+		if subscriptions[t] == nil {
+			switch t {
+			case api.ImageEventType_IMAGE_EVENT_TYPE_PULLED:
+				subscriptions[t] = s.RegisterImagePulledEventFilter
+			case api.ImageEventType_IMAGE_EVENT_TYPE_TAGGED:
+				subscriptions[t] = s.RegisterImageTaggedEventFilter
+			case api.ImageEventType_IMAGE_EVENT_TYPE_UNTAGGED:
+				subscriptions[t] = s.RegisterImageUntaggedEventFilter
+			case api.ImageEventType_IMAGE_EVENT_TYPE_DELETED:
+				subscriptions[t] = s.RegisterImageDeletedEventFilter
+			}
+		}
+		if e.FilterExpression == nil {
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
+		}
+	}
+
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid image filter expression: %v", err))
+		}
+	}
+}
+
+func (s *Subscription) registerIOUringEvents(events []*api.IoUringEventFilter) {
+	type registerFunc func(*expression.Expression)
+
+	var (
+		filters       [3]*api.Expression
+		subscriptions [3]registerFunc
+		wildcards     [3]bool
+	)
+
+	for _, e := range events {
+		t := e.GetType()
+		if t < 1 || t > api.IoUringEventType(len(subscriptions)-1) {
+			s.logStatus(
+				fmt.Sprintf("IoUringEventType %d is invalid", t))
+			continue
+		}
+
+		if subscriptions[t] == nil {
+			switch t {
+			case api.IoUringEventType_IO_URING_EVENT_TYPE_SETUP:
+				subscriptions[t] = s.RegisterIOUringSetupEventFilter
+			case api.IoUringEventType_IO_URING_EVENT_TYPE_SUBMIT:
+				subscriptions[t] = s.RegisterIOUringSubmitEventFilter
+			}
+		}
+		if e.FilterExpression == nil {
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
+		}
+	}
+
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid io_uring filter expression: %v", err))
+		}
+	}
+}
+
+func (s *Subscription) registerKernelFunctionCallEvents(events []*api.KernelFunctionCallFilter) {
+	for _, e := range events {
+		var onReturn bool
+		switch e.Type {
+		case api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_ENTER:
+			onReturn = false
+		case api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_EXIT:
+			onReturn = true
+		default:
+			s.logStatus(
+				fmt.Sprintf("KernelFunctionCallEventType %d is invalid", e.Type))
+			continue
+		}
+
+		var filterExpression *expression.Expression
+		if expr := e.GetFilterExpression(); expr != nil {
+			var err error
+			filterExpression, err = expression.NewExpression(expr)
+			if err != nil {
+				s.logStatus(
+					fmt.Sprintf("Invalid filter expression for kernel function call filter: %v", err))
+				continue
+			}
+		}
+
+		s.RegisterKernelFunctionCallEventFilter(e.Symbol, onReturn,
+			e.Arguments, filterExpression)
+	}
+}
+
+func (s *Subscription) registerKernelModuleEvents(events []*api.KernelModuleEventFilter) {
+	type registerFunc func(*expression.Expression)
+
+	var (
+		filters       [3]*api.Expression
+		subscriptions [3]registerFunc
+		wildcards     [3]bool
+	)
+
+	for _, e := range events {
+		t := e.GetType()
+		if t < 1 || t > 2 {
+			s.logStatus(
+				fmt.Sprintf("KernelModuleEventType %d is invalid", t))
+			continue
+		}
+
+		if subscriptions[t] == nil {
+			switch t {
+			case api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD:
+				subscriptions[t] = s.RegisterKernelModuleLoadEventFilter
+			case api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD:
+				subscriptions[t] = s.RegisterKernelModuleUnloadEventFilter
+			}
 		}
-		if cf.Len() > 0 {
-			s.SetContainerFilter(cf)
+		if e.FilterExpression == nil {
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
 		}
 	}
 
-	s.registerChargenEvents(sub.EventFilter.ChargenEvents)
-	s.registerContainerEvents(sub.EventFilter.ContainerEvents)
-	s.registerFileEvents(sub.EventFilter.FileEvents)
-	s.registerKernelFunctionCallEvents(sub.EventFilter.KernelEvents)
-	s.registerNetworkEvents(sub.EventFilter.NetworkEvents)
-	s.registerPerformanceEvents(sub.EventFilter.PerformanceEvents)
-	s.registerProcessEvents(sub.EventFilter.ProcessEvents)
-	s.registerSyscallEvents(sub.EventFilter.SyscallEvents)
-	s.registerTickerEvents(sub.EventFilter.TickerEvents)
-}
-
-func (s *Subscription) registerChargenEvents(events []*api.ChargenEventFilter) {
-	for _, e := range events {
-		s.RegisterChargenEventFilter(e.Length, nil)
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid kernel module filter expression: %v", err))
+		}
 	}
 }
 
-func (s *Subscription) registerContainerEvents(events []*api.ContainerEventFilter) {
+func (s *Subscription) registerLSMEvents(events []*api.LsmEventFilter) {
 	type registerFunc func(*expression.Expression)
 
 	var (
-		filters       [6]*api.Expression
-		subscriptions [6]registerFunc
-		views         [6]bool
-		wildcards     [6]bool
+		filters       [2]*api.Expression
+		subscriptions [2]registerFunc
+		wildcards     [2]bool
 	)
 
 	for _, e := range events {
 		t := e.GetType()
-		if t < 1 || t > 5 {
+		if t < 1 || t > api.LsmEventType(len(subscriptions)-1) {
 			s.logStatus(
-				fmt.Sprintf("ContainerEventType %d is invalid", t))
+				fmt.Sprintf("LsmEventType %d is invalid", t))
 			continue
 		}
 
 		if subscriptions[t] == nil {
 			switch t {
-			case api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED:
-				subscriptions[t] = s.RegisterContainerCreatedEventFilter
-			case api.ContainerEventType_CONTAINER_EVENT_TYPE_RUNNING:
-				subscriptions[t] = s.RegisterContainerRunningEventFilter
-			case api.ContainerEventType_CONTAINER_EVENT_TYPE_EXITED:
-				subscriptions[t] = s.RegisterContainerExitedEventFilter
-			case api.ContainerEventType_CONTAINER_EVENT_TYPE_DESTROYED:
-				subscriptions[t] = s.RegisterContainerDestroyedEventFilter
-			case api.ContainerEventType_CONTAINER_EVENT_TYPE_UPDATED:
-				subscriptions[t] = s.RegisterContainerUpdatedEventFilter
+			case api.LsmEventType_LSM_EVENT_TYPE_DENIAL:
+				subscriptions[t] = s.RegisterLSMDenialEventFilter
 			}
 		}
-		if e.View == api.ContainerEventView_FULL {
-			views[t] = true
-		}
 		if e.FilterExpression == nil {
 			wildcards[t] = true
 			filters[t] = nil
@@ -488,8 +1701,6 @@ func (s *Subscription) registerContainerEvents(events []*api.ContainerEventFilte
 		}
 	}
 
-	// FIXME do something with views!
-
 	for i, f := range subscriptions {
 		if f == nil {
 			continue
@@ -500,115 +1711,108 @@ func (s *Subscription) registerContainerEvents(events []*api.ContainerEventFilte
 			f(expr)
 		} else {
 			s.logStatus(
-				fmt.Sprintf("Invalid container filter expression: %v", err))
+				fmt.Sprintf("Invalid LSM filter expression: %v", err))
 		}
 	}
 }
 
-func rewriteFileEventFilter(fef *api.FileEventFilter) {
-	if fef.Filename != nil {
-		newExpr := expression.Equal(
-			expression.Identifier("filename"),
-			expression.Value(fef.Filename.Value))
-		fef.FilterExpression = expression.LogicalAnd(
-			fef.FilterExpression, newExpr)
-		fef.Filename = nil
-		fef.FilenamePattern = nil
-	} else if fef.FilenamePattern != nil {
-		newExpr := expression.Like(
-			expression.Identifier("filename"),
-			expression.Value(fef.FilenamePattern.Value))
-		fef.FilterExpression = expression.LogicalAnd(
-			fef.FilterExpression, newExpr)
-		fef.FilenamePattern = nil
-	}
-
-	if fef.OpenFlagsMask != nil {
-		newExpr := expression.NotEqual(
-			expression.BitwiseAnd(
-				expression.Identifier("flags"),
-				expression.Value(fef.OpenFlagsMask.Value)),
-			expression.Value(int32(0)))
-		fef.FilterExpression = expression.LogicalAnd(
-			fef.FilterExpression, newExpr)
-		fef.OpenFlagsMask = nil
-	}
-
-	if fef.CreateModeMask != nil {
-		newExpr := expression.NotEqual(
-			expression.BitwiseAnd(
-				expression.Identifier("mode"),
-				expression.Value(fef.CreateModeMask.Value)),
-			expression.Value(int32(0)))
-		fef.FilterExpression = expression.LogicalAnd(
-			fef.FilterExpression, newExpr)
-		fef.CreateModeMask = nil
-	}
-}
+func (s *Subscription) registerMemoryEvents(events []*api.MemoryEventFilter) {
+	type registerFunc func(*expression.Expression)
 
-func (s *Subscription) registerFileEvents(events []*api.FileEventFilter) {
 	var (
-		filter   *api.Expression
-		wildcard bool
+		filters       [3]*api.Expression
+		subscriptions [3]registerFunc
+		wildcards     [3]bool
 	)
 
 	for _, e := range events {
-		if e.Type != api.FileEventType_FILE_EVENT_TYPE_OPEN {
+		t := e.GetType()
+		if t < 1 || t > api.MemoryEventType(len(subscriptions)-1) {
 			s.logStatus(
-				fmt.Sprintf("FileEventType %d is invalid", e.Type))
+				fmt.Sprintf("MemoryEventType %d is invalid", t))
 			continue
 		}
 
-		// Translate deprecated fields into an expression
-		rewriteFileEventFilter(e)
-
+		if subscriptions[t] == nil {
+			switch t {
+			case api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP_EXEC:
+				subscriptions[t] = s.RegisterMemoryMmapExecEventFilter
+			case api.MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT_EXEC:
+				subscriptions[t] = s.RegisterMemoryMprotectExecEventFilter
+			}
+		}
 		if e.FilterExpression == nil {
-			wildcard = true
-			filter = nil
-		} else if !wildcard {
-			filter = expression.LogicalOr(e.FilterExpression, filter)
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
 		}
 	}
 
-	if wildcard {
-		s.RegisterFileOpenEventFilter(nil)
-	} else if filter == nil {
-		return
-	} else if expr, err := expression.NewExpression(filter); err == nil {
-		s.RegisterFileOpenEventFilter(expr)
-	} else {
-		s.logStatus(
-			fmt.Sprintf("Invalid filter expression for file open filter: %v", err))
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid memory filter expression: %v", err))
+		}
 	}
 }
 
-func (s *Subscription) registerKernelFunctionCallEvents(events []*api.KernelFunctionCallFilter) {
+func (s *Subscription) registerMountEvents(events []*api.MountEventFilter) {
+	type registerFunc func(*expression.Expression)
+
+	var (
+		filters       [3]*api.Expression
+		subscriptions [3]registerFunc
+		wildcards     [3]bool
+	)
+
 	for _, e := range events {
-		var onReturn bool
-		switch e.Type {
-		case api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_ENTER:
-			onReturn = false
-		case api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_EXIT:
-			onReturn = true
-		default:
+		t := e.GetType()
+		if t < 1 || t > 2 {
 			s.logStatus(
-				fmt.Sprintf("KernelFunctionCallEventType %d is invalid", e.Type))
+				fmt.Sprintf("MountEventType %d is invalid", t))
 			continue
 		}
 
-		var filterExpression *expression.Expression
-		if expr := e.GetFilterExpression(); expr != nil {
-			var err error
-			filterExpression, err = expression.NewExpression(expr)
-			if err != nil {
-				s.logStatus(
-					fmt.Sprintf("Invalid filter expression for kernel function call filter: %v", err))
-				continue
+		if subscriptions[t] == nil {
+			switch t {
+			case api.MountEventType_MOUNT_EVENT_TYPE_MOUNT:
+				subscriptions[t] = s.RegisterMountEventFilter
+			case api.MountEventType_MOUNT_EVENT_TYPE_UNMOUNT:
+				subscriptions[t] = s.RegisterUnmountEventFilter
 			}
 		}
+		if e.FilterExpression == nil {
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
+		}
+	}
 
-		s.RegisterKernelFunctionCallEventFilter(e.Symbol, onReturn,
-			e.Arguments, filterExpression)
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid mount filter expression: %v", err))
+		}
 	}
 }
 
@@ -660,6 +1864,12 @@ type networkFilterSet struct {
 	recvfromResultFilters  networkFilterItem
 	sendtoAttemptFilters   networkFilterItem
 	sendtoResultFilters    networkFilterItem
+	tcpConnectFilters      networkFilterItem
+	tcpAcceptFilters       networkFilterItem
+	dnsQueryFilters        networkFilterItem
+	unixConnectFilters     networkFilterItem
+	tcpListenFilters       networkFilterItem
+	udpBindFilters         networkFilterItem
 }
 
 func (nfs *networkFilterSet) add(
@@ -691,6 +1901,18 @@ func (nfs *networkFilterSet) add(
 		nfs.sendtoAttemptFilters.add(nef)
 	case api.NetworkEventType_NETWORK_EVENT_TYPE_SENDTO_RESULT:
 		nfs.sendtoResultFilters.add(nef)
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_CONNECT:
+		nfs.tcpConnectFilters.add(nef)
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ACCEPT:
+		nfs.tcpAcceptFilters.add(nef)
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_DNS_QUERY:
+		nfs.dnsQueryFilters.add(nef)
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_UNIX_CONNECT:
+		nfs.unixConnectFilters.add(nef)
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_LISTEN:
+		nfs.tcpListenFilters.add(nef)
+	case api.NetworkEventType_NETWORK_EVENT_TYPE_UDP_BIND:
+		nfs.udpBindFilters.add(nef)
 	default:
 		subscr.logStatus(
 			fmt.Sprintf("Invalid NetworkEventType %d", nef.Type))
@@ -716,6 +1938,12 @@ func (s *Subscription) registerNetworkEvents(events []*api.NetworkEventFilter) {
 	nfs.recvfromResultFilters.register(s, s.RegisterNetworkRecvfromResultEventFilter)
 	nfs.sendtoAttemptFilters.register(s, s.RegisterNetworkSendtoAttemptEventFilter)
 	nfs.sendtoResultFilters.register(s, s.RegisterNetworkSendtoResultEventFilter)
+	nfs.tcpConnectFilters.register(s, s.RegisterNetworkTCPConnectEventFilter)
+	nfs.tcpAcceptFilters.register(s, s.RegisterNetworkTCPAcceptEventFilter)
+	nfs.dnsQueryFilters.register(s, s.RegisterNetworkDNSQueryEventFilter)
+	nfs.unixConnectFilters.register(s, s.RegisterNetworkUnixConnectEventFilter)
+	nfs.tcpListenFilters.register(s, s.RegisterNetworkTCPListenEventFilter)
+	nfs.udpBindFilters.register(s, s.RegisterNetworkUDPBindEventFilter)
 }
 
 func (s *Subscription) registerPerformanceEvents(events []*api.PerformanceEventFilter) {
@@ -768,7 +1996,7 @@ func (s *Subscription) registerPerformanceEvents(events []*api.PerformanceEventF
 			counters = append(counters, m)
 		}
 
-		s.RegisterPerformanceEventFilter(attr, counters)
+		s.RegisterPerformanceEventFilter(attr, counters, e.Cgroups)
 	}
 }
 
@@ -791,6 +2019,22 @@ func rewriteProcessEventFilter(pef *api.ProcessEventFilter) {
 				pef.FilterExpression, newExpr)
 			pef.ExecFilenamePattern = nil
 		}
+		for _, filename := range pef.ExcludeExecFilenames {
+			newExpr := expression.NotEqual(
+				expression.Identifier("filename"),
+				expression.Value(filename))
+			pef.FilterExpression = expression.LogicalAnd(
+				pef.FilterExpression, newExpr)
+		}
+		for _, pattern := range pef.ExcludeExecFilenamePatterns {
+			newExpr := expression.LogicalNot(expression.Like(
+				expression.Identifier("filename"),
+				expression.Value(pattern)))
+			pef.FilterExpression = expression.LogicalAnd(
+				pef.FilterExpression, newExpr)
+		}
+		pef.ExcludeExecFilenames = nil
+		pef.ExcludeExecFilenamePatterns = nil
 	case api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT:
 		if pef.ExitCode != nil {
 			newExpr := expression.Equal(
@@ -800,6 +2044,17 @@ func rewriteProcessEventFilter(pef *api.ProcessEventFilter) {
 				pef.FilterExpression, newExpr)
 			pef.ExitCode = nil
 		}
+	case api.ProcessEventType_PROCESS_EVENT_TYPE_CAPABILITY_CHANGE:
+		if pef.CapabilityMask != 0 {
+			newExpr := expression.NotEqual(
+				expression.BitwiseAnd(
+					expression.Identifier("cap_gained"),
+					expression.Value(pef.CapabilityMask)),
+				expression.Value(uint64(0)))
+			pef.FilterExpression = expression.LogicalAnd(
+				pef.FilterExpression, newExpr)
+			pef.CapabilityMask = 0
+		}
 	}
 }
 
@@ -807,13 +2062,14 @@ func (s *Subscription) registerProcessEvents(events []*api.ProcessEventFilter) {
 	type registerFunc func(*expression.Expression)
 
 	var (
-		filters       [5]*api.Expression
-		subscriptions [5]registerFunc
-		wildcards     [5]bool
+		filters       [11]*api.Expression
+		subscriptions [11]registerFunc
+		wildcards     [11]bool
 	)
 
 	for _, e := range events {
-		// Translate deprecated fields into an expression
+		// Translate deprecated fields and the capability mask into an
+		// expression
 		rewriteProcessEventFilter(e)
 
 		t := e.GetType()
@@ -833,6 +2089,136 @@ func (s *Subscription) registerProcessEvents(events []*api.ProcessEventFilter) {
 				subscriptions[t] = s.RegisterProcessExitEventFilter
 			case api.ProcessEventType_PROCESS_EVENT_TYPE_UPDATE:
 				subscriptions[t] = s.RegisterProcessUpdateEventFilter
+			case api.ProcessEventType_PROCESS_EVENT_TYPE_CRED_CHANGE:
+				subscriptions[t] = s.RegisterProcessCredChangeEventFilter
+			case api.ProcessEventType_PROCESS_EVENT_TYPE_CAPABILITY_CHANGE:
+				subscriptions[t] = s.RegisterProcessCapabilityChangeEventFilter
+			case api.ProcessEventType_PROCESS_EVENT_TYPE_PTRACE_ATTACH:
+				subscriptions[t] = s.RegisterProcessPtraceAttachEventFilter
+			case api.ProcessEventType_PROCESS_EVENT_TYPE_OOM_KILL:
+				subscriptions[t] = s.RegisterProcessOOMKillEventFilter
+			case api.ProcessEventType_PROCESS_EVENT_TYPE_CRASH:
+				subscriptions[t] = s.RegisterProcessCrashEventFilter
+			case api.ProcessEventType_PROCESS_EVENT_TYPE_SECCOMP_VIOLATION:
+				subscriptions[t] = s.RegisterProcessSeccompViolationEventFilter
+			}
+		}
+		if e.FilterExpression == nil {
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
+		}
+	}
+
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid process filter expression: %v", err))
+		}
+	}
+}
+
+func rewriteSignalEventFilter(sef *api.SignalEventFilter) {
+	if len(sef.Signals) > 0 {
+		var sigExpr *api.Expression
+		for _, sig := range sef.Signals {
+			sigExpr = expression.LogicalOr(sigExpr,
+				expression.Equal(
+					expression.Identifier("sig"),
+					expression.Value(sig)))
+		}
+		sef.FilterExpression = expression.LogicalAnd(
+			sef.FilterExpression, sigExpr)
+		sef.Signals = nil
+	}
+}
+
+func (s *Subscription) registerSessionEvents(events []*api.SessionEventFilter) {
+	type registerFunc func(*expression.Expression)
+
+	var (
+		filters       [3]*api.Expression
+		subscriptions [3]registerFunc
+		wildcards     [3]bool
+	)
+
+	for _, e := range events {
+		t := e.GetType()
+		if t < 1 || t > api.SessionEventType(len(subscriptions)-1) {
+			s.logStatus(
+				fmt.Sprintf("SessionEventType %d is invalid", t))
+			continue
+		}
+
+		if subscriptions[t] == nil {
+			switch t {
+			case api.SessionEventType_SESSION_EVENT_TYPE_LOGIN:
+				subscriptions[t] = s.RegisterSessionLoginEventFilter
+			case api.SessionEventType_SESSION_EVENT_TYPE_LOGOUT:
+				subscriptions[t] = s.RegisterSessionLogoutEventFilter
+			}
+		}
+		if e.FilterExpression == nil {
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
+		}
+	}
+
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid session filter expression: %v", err))
+		}
+	}
+}
+
+func (s *Subscription) registerSignalEvents(events []*api.SignalEventFilter) {
+	type registerFunc func(*expression.Expression)
+
+	var (
+		filters       [3]*api.Expression
+		subscriptions [3]registerFunc
+		wildcards     [3]bool
+	)
+
+	for _, e := range events {
+		// Translate the signal numbers into an expression
+		rewriteSignalEventFilter(e)
+
+		t := e.GetType()
+		if t < 1 || t > api.SignalEventType(len(subscriptions)-1) {
+			s.logStatus(
+				fmt.Sprintf("SignalEventType %d is invalid", t))
+			continue
+		}
+
+		if subscriptions[t] == nil {
+			switch t {
+			case api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE:
+				subscriptions[t] = s.RegisterSignalGenerateEventFilter
+			case api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER:
+				subscriptions[t] = s.RegisterSignalDeliverEventFilter
 			}
 		}
 		if e.FilterExpression == nil {
@@ -855,11 +2241,22 @@ func (s *Subscription) registerProcessEvents(events []*api.ProcessEventFilter) {
 			f(expr)
 		} else {
 			s.logStatus(
-				fmt.Sprintf("Invalid process filter expression: %v", err))
+				fmt.Sprintf("Invalid signal filter expression: %v", err))
 		}
 	}
 }
 
+func syscallIDsExpression(ids []int64) *api.Expression {
+	var expr *api.Expression
+	for _, id := range ids {
+		newExpr := expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(id))
+		expr = expression.LogicalOr(expr, newExpr)
+	}
+	return expr
+}
+
 func rewriteSyscallEventFilter(sef *api.SyscallEventFilter) {
 	if sef.Id != nil {
 		newExpr := expression.Equal(
@@ -925,6 +2322,14 @@ func rewriteSyscallEventFilter(sef *api.SyscallEventFilter) {
 			sef.Arg5 = nil
 		}
 	} else if sef.Type == api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT {
+		// Exit events always come from the sys_exit tracepoint, so
+		// the set of syscall numbers is simply another filter.
+		if len(sef.Ids) > 0 {
+			sef.FilterExpression = expression.LogicalAnd(
+				sef.FilterExpression, syscallIDsExpression(sef.Ids))
+			sef.Ids = nil
+		}
+
 		if sef.Ret != nil {
 			newExpr := expression.Equal(
 				expression.Identifier("ret"),
@@ -962,12 +2367,29 @@ func containsIDFilter(expr *api.Expression) bool {
 }
 
 func (s *Subscription) registerSyscallEvents(events []*api.SyscallEventFilter) {
-	var enterFilter, exitFilter *api.Expression
+	var (
+		enterFilter, exitFilter, rawEnterFilter *api.Expression
+		rawEnterIDs                             []int64
+		rawEnterArgCount                        uint32
+	)
 
 	for _, e := range events {
 		// Translate deprecated fields into an expression
 		rewriteSyscallEventFilter(e)
 
+		if e.GetType() == api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER && len(e.Ids) > 0 {
+			// All raw enter filters share a single tracepoint, so
+			// limit each filter expression to its own syscalls.
+			rawEnterIDs = append(rawEnterIDs, e.Ids...)
+			if e.ArgCount > rawEnterArgCount {
+				rawEnterArgCount = e.ArgCount
+			}
+			rawEnterFilter = expression.LogicalOr(rawEnterFilter,
+				expression.LogicalAnd(syscallIDsExpression(e.Ids),
+					e.FilterExpression))
+			continue
+		}
+
 		if !containsIDFilter(e.FilterExpression) {
 			// No wildcard filters for now
 			s.logStatus(
@@ -1005,6 +2427,15 @@ func (s *Subscription) registerSyscallEvents(events []*api.SyscallEventFilter) {
 				fmt.Sprintf("Invalid filter expression for syscall exit filter: %v", err))
 		}
 	}
+	if rawEnterFilter != nil {
+		if expr, err := expression.NewExpression(rawEnterFilter); err == nil {
+			s.RegisterRawSyscallEnterEventFilter(rawEnterIDs,
+				int(rawEnterArgCount), expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid filter expression for raw syscall enter filter: %v", err))
+		}
+	}
 }
 
 func (s *Subscription) registerTickerEvents(events []*api.TickerEventFilter) {
@@ -1013,8 +2444,91 @@ func (s *Subscription) registerTickerEvents(events []*api.TickerEventFilter) {
 	}
 }
 
+func (s *Subscription) registerTTYEvents(events []*api.TtyEventFilter) {
+	type registerFunc func(bool, *expression.Expression)
+
+	var (
+		filters       [2]*api.Expression
+		subscriptions [2]registerFunc
+		wildcards     [2]bool
+		includeData   [2]bool
+	)
+
+	for _, e := range events {
+		t := e.GetType()
+		if t < 1 || t > api.TtyEventType(len(subscriptions)-1) {
+			s.logStatus(
+				fmt.Sprintf("TtyEventType %d is invalid", t))
+			continue
+		}
+
+		if subscriptions[t] == nil {
+			switch t {
+			case api.TtyEventType_TTY_EVENT_TYPE_READ:
+				subscriptions[t] = s.RegisterTTYReadEventFilter
+			}
+		}
+		if e.IncludeData {
+			includeData[t] = true
+		}
+		if e.FilterExpression == nil {
+			wildcards[t] = true
+			filters[t] = nil
+		} else if !wildcards[t] {
+			filters[t] = expression.LogicalOr(
+				e.FilterExpression,
+				filters[t])
+		}
+	}
+
+	for i, f := range subscriptions {
+		if f == nil {
+			continue
+		}
+		if wildcards[i] {
+			f(includeData[i], nil)
+		} else if expr, err := expression.NewExpression(filters[i]); err == nil {
+			f(includeData[i], expr)
+		} else {
+			s.logStatus(
+				fmt.Sprintf("Invalid TTY filter expression: %v", err))
+		}
+	}
+}
+
+func (s *Subscription) registerUserFunctionCallEvents(events []*api.UserFunctionCallFilter) {
+	for _, e := range events {
+		var onReturn bool
+		switch e.Type {
+		case api.UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_ENTER:
+			onReturn = false
+		case api.UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_EXIT:
+			onReturn = true
+		default:
+			s.logStatus(
+				fmt.Sprintf("UserFunctionCallEventType %d is invalid", e.Type))
+			continue
+		}
+
+		var filterExpression *expression.Expression
+		if expr := e.GetFilterExpression(); expr != nil {
+			var err error
+			filterExpression, err = expression.NewExpression(expr)
+			if err != nil {
+				s.logStatus(
+					fmt.Sprintf("Invalid filter expression for user function call filter: %v", err))
+				continue
+			}
+		}
+
+		s.RegisterUserFunctionCallEventFilter(e.Executable, e.Symbol,
+			e.Offset, onReturn, e.Arguments, filterExpression)
+	}
+}
+
 func newTelemetryEvent(e TelemetryEventData) *api.TelemetryEvent {
-	event := &api.TelemetryEvent{
+	event := telemetryEventPool.Get().(*api.TelemetryEvent)
+	*event = api.TelemetryEvent{
 		Id:                   e.EventID,
 		ProcessId:            e.ProcessID,
 		ProcessPid:           int32(e.PID),
@@ -1027,24 +2541,176 @@ func newTelemetryEvent(e TelemetryEventData) *api.TelemetryEvent {
 		ImageName:            e.Container.ImageName,
 		Cpu:                  int32(e.CPU),
 		ProcessTgid:          int32(e.TGID),
+		ProcessNamespacePid:  int32(e.NamespacePID),
+		ProcessNamespaceTgid: int32(e.NamespaceTGID),
 	}
 
 	if e.HasCredentials {
-		event.Credentials = &api.Credentials{
-			Uid:   e.Credentials.UID,
-			Gid:   e.Credentials.GID,
-			Euid:  e.Credentials.EUID,
-			Egid:  e.Credentials.EGID,
-			Suid:  e.Credentials.SUID,
-			Sgid:  e.Credentials.SGID,
-			Fsuid: e.Credentials.FSUID,
-			Fsgid: e.Credentials.FSGID,
+		event.Credentials = credentialsPool.Get().(*api.Credentials)
+		translateCredentialsTo(event.Credentials, e.Credentials)
+	}
+	if e.HasNamespaces {
+		event.Namespaces = namespacesPool.Get().(*api.Namespaces)
+		*event.Namespaces = api.Namespaces{
+			Net:  e.Namespaces.Net,
+			Mnt:  e.Namespaces.Mnt,
+			Pid:  e.Namespaces.PID,
+			User: e.Namespaces.User,
 		}
 	}
 
 	return event
 }
 
+// translateCallchain splits a PERF_SAMPLE_CALLCHAIN callchain into kernel and
+// user stack frames. Frames from other contexts (hypervisor, guest) are
+// dropped.
+func (s *Sensor) translateCallchain(callchain []uint64) *api.StackTrace {
+	st := &api.StackTrace{}
+	var frames *[]*api.StackFrame
+	for _, ip := range callchain {
+		switch {
+		case ip == perf.PERF_CONTEXT_KERNEL:
+			frames = &st.KernelFrames
+		case ip == perf.PERF_CONTEXT_USER:
+			frames = &st.UserFrames
+		case ip >= perf.PERF_CONTEXT_MAX:
+			frames = nil
+		case frames == &st.KernelFrames:
+			frame := &api.StackFrame{Address: ip}
+			if sym, ok := s.lookupKernelSymbol(ip); ok {
+				frame.Symbol = sym.Name
+				frame.Offset = ip - sym.Address
+				frame.Module = sym.Module
+			}
+			st.KernelFrames = append(st.KernelFrames, frame)
+		case frames != nil:
+			*frames = append(*frames, &api.StackFrame{Address: ip})
+		}
+	}
+	return st
+}
+
+func translateCredentials(c Cred) *api.Credentials {
+	creds := new(api.Credentials)
+	translateCredentialsTo(creds, c)
+	return creds
+}
+
+// translateCredentialsTo translates credentials into an existing message,
+// i.e. one from credentialsPool.
+func translateCredentialsTo(creds *api.Credentials, c Cred) {
+	creds.Uid = c.UID
+	creds.Gid = c.GID
+	creds.Euid = c.EUID
+	creds.Egid = c.EGID
+	creds.Suid = c.SUID
+	creds.Sgid = c.SGID
+	creds.Fsuid = c.FSUID
+	creds.Fsgid = c.FSGID
+}
+
+// hostProcessInfo returns the cached information about the process with a
+// host PID, or nil if the process is not known.
+func (s *Sensor) hostProcessInfo(pid int) *api.ProcessInfo {
+	if s.ProcessCache == nil {
+		return nil
+	}
+	t := s.ProcessCache.LookupKnownTask(pid)
+	if t == nil {
+		return nil
+	}
+	leader := t.Leader()
+	info := &api.ProcessInfo{
+		ProcessId:   t.ProcessID,
+		Pid:         int32(t.PID),
+		Tgid:        int32(t.TGID),
+		Executable:  leader.Executable,
+		CommandLine: leader.CommandLine,
+		Cwd:         t.CWD,
+		StartTime:   t.StartTime,
+		ContainerId: s.ProcessCache.taskContainerID(t),
+	}
+	if t.Creds != nil {
+		info.Credentials = translateCredentials(*t.Creds)
+	}
+	nsPID, _ := s.ProcessCache.LookupTaskNamespacePIDs(t)
+	info.NamespacePid = int32(nsPID)
+	return info
+}
+
+// processLineage returns the lineage of the process with a PID and unique
+// process ID, limited to the configured ProcessLineageDepth.
+func (s *Sensor) processLineage(pid int, processID string) []*api.Process {
+	depth := config.CurrentSensor().ProcessLineageDepth
+	if s.ProcessCache == nil || pid <= 0 || depth <= 0 {
+		return nil
+	}
+	tasks := s.ProcessCache.LookupTaskLineage(pid, processID, depth)
+	if len(tasks) == 0 {
+		return nil
+	}
+	lineage := make([]*api.Process, len(tasks))
+	for i, t := range tasks {
+		// The executable is only known by thread group leaders
+		leader := t
+		if t.PID != t.TGID && t.parent != nil {
+			leader = t.parent
+		}
+		lineage[i] = &api.Process{
+			Pid:         int32(t.PID),
+			Command:     t.Command,
+			Executable:  leader.Executable,
+			ProcessId:   t.ProcessID,
+			ContainerId: s.ProcessCache.taskContainerID(leader),
+		}
+	}
+	return lineage
+}
+
+func translateFieldValues(
+	data perf.TraceEventSampleData,
+) map[string]*api.KernelFunctionCallEvent_FieldValue {
+	args := make(map[string]*api.KernelFunctionCallEvent_FieldValue)
+	for k, v := range data {
+		value := &api.KernelFunctionCallEvent_FieldValue{}
+		switch v := v.(type) {
+		case []byte:
+			value.FieldType = api.KernelFunctionCallEvent_BYTES
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_BytesValue{BytesValue: v}
+		case string:
+			value.FieldType = api.KernelFunctionCallEvent_STRING
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_StringValue{StringValue: v}
+		case int8:
+			value.FieldType = api.KernelFunctionCallEvent_SINT8
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_SignedValue{SignedValue: int64(v)}
+		case int16:
+			value.FieldType = api.KernelFunctionCallEvent_SINT16
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_SignedValue{SignedValue: int64(v)}
+		case int32:
+			value.FieldType = api.KernelFunctionCallEvent_SINT32
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_SignedValue{SignedValue: int64(v)}
+		case int64:
+			value.FieldType = api.KernelFunctionCallEvent_SINT64
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_SignedValue{SignedValue: v}
+		case uint8:
+			value.FieldType = api.KernelFunctionCallEvent_UINT8
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_UnsignedValue{UnsignedValue: uint64(v)}
+		case uint16:
+			value.FieldType = api.KernelFunctionCallEvent_UINT16
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_UnsignedValue{UnsignedValue: uint64(v)}
+		case uint32:
+			value.FieldType = api.KernelFunctionCallEvent_UINT32
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_UnsignedValue{UnsignedValue: uint64(v)}
+		case uint64:
+			value.FieldType = api.KernelFunctionCallEvent_UINT64
+			value.Value = &api.KernelFunctionCallEvent_FieldValue_UnsignedValue{UnsignedValue: v}
+		}
+		args[k] = value
+	}
+	return args
+}
+
 func translateNetworkAddress(addr NetworkAddressTelemetryEventData) *api.NetworkAddress {
 	switch addr.Family {
 	case unix.AF_LOCAL:
@@ -1083,8 +2749,23 @@ func translateNetworkAddress(addr NetworkAddressTelemetryEventData) *api.Network
 	return nil
 }
 
+func newImageEvent(t api.ImageEventType, info *ImageInfo) *api.ImageEvent {
+	return &api.ImageEvent{
+		Type:        t,
+		ImageId:     info.ID,
+		ImageName:   info.Name,
+		Registry:    info.Registry,
+		Digest:      info.Digest,
+		RepoTags:    info.RepoTags,
+		RepoDigests: info.RepoDigests,
+	}
+}
+
 func (s *Subscription) translateEvent(ev TelemetryEvent) *api.TelemetryEvent {
 	eventData := ev.CommonTelemetryEventData()
+	s.sensor.translationLatency.observeEvent(s.sensor, eventData)
+	s.sensor.eventCounts.get(ev).addTranslated()
+	s.counters.addTranslated()
 	if len(eventData.Container.ID) > 0 && len(eventData.Container.Name) == 0 {
 		// We have a container ID without a name. Let's see if
 		// we can refresh that.
@@ -1095,6 +2776,13 @@ func (s *Subscription) translateEvent(ev TelemetryEvent) *api.TelemetryEvent {
 		}
 	}
 	event := newTelemetryEvent(eventData)
+	if event.Credentials != nil && s.sensor.userNames != nil {
+		s.sensor.userNames.resolve(event.Credentials,
+			eventData.Container.ID, eventData.TGID)
+	}
+	if s.captureStackTraces && len(eventData.Callchain) > 0 {
+		event.StackTrace = s.sensor.translateCallchain(eventData.Callchain)
+	}
 
 	switch e := ev.(type) {
 	case ChargenTelemetryEvent:
@@ -1106,30 +2794,32 @@ func (s *Subscription) translateEvent(ev TelemetryEvent) *api.TelemetryEvent {
 		}
 
 	case ContainerCreatedTelemetryEvent:
-		event.Event = &api.TelemetryEvent_Container{
-			Container: &api.ContainerEvent{
-				Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED,
-				Name:             e.Container.Name,
-				ImageId:          e.Container.ImageID,
-				ImageName:        e.Container.ImageName,
-				HostPid:          int32(e.Container.Pid),
-				DockerConfigJson: e.Container.JSONConfig,
-				OciConfigJson:    e.Container.OCIConfig,
-			},
-		}
+		event.Event = newContainerEvent(api.ContainerEvent{
+			Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED,
+			Name:             e.Container.Name,
+			ImageId:          e.Container.ImageID,
+			ImageName:        e.Container.ImageName,
+			ImageDigest:      e.Container.ImageDigest,
+			ImageLabels:      e.Container.ImageLabels,
+			HostPid:          int32(e.Container.Pid),
+			HealthStatus:     e.Container.Health,
+			DockerConfigJson: e.Container.JSONConfig,
+			OciConfigJson:    e.Container.OCIConfig,
+		})
 
 	case ContainerDestroyedTelemetryEvent:
-		event.Event = &api.TelemetryEvent_Container{
-			Container: &api.ContainerEvent{
-				Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_DESTROYED,
-				Name:             e.Container.Name,
-				ImageId:          e.Container.ImageID,
-				ImageName:        e.Container.ImageName,
-				HostPid:          int32(e.Container.Pid),
-				DockerConfigJson: e.Container.JSONConfig,
-				OciConfigJson:    e.Container.OCIConfig,
-			},
-		}
+		event.Event = newContainerEvent(api.ContainerEvent{
+			Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_DESTROYED,
+			Name:             e.Container.Name,
+			ImageId:          e.Container.ImageID,
+			ImageName:        e.Container.ImageName,
+			ImageDigest:      e.Container.ImageDigest,
+			ImageLabels:      e.Container.ImageLabels,
+			HostPid:          int32(e.Container.Pid),
+			HealthStatus:     e.Container.Health,
+			DockerConfigJson: e.Container.JSONConfig,
+			OciConfigJson:    e.Container.OCIConfig,
+		})
 	case ContainerExitedTelemetryEvent:
 		var exitSignal, exitStatus uint32
 		ws := unix.WaitStatus(e.Container.ExitCode)
@@ -1139,44 +2829,154 @@ func (s *Subscription) translateEvent(ev TelemetryEvent) *api.TelemetryEvent {
 		if ws.Signaled() {
 			exitSignal = uint32(ws.Signal())
 		}
-		event.Event = &api.TelemetryEvent_Container{
-			Container: &api.ContainerEvent{
-				Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_EXITED,
-				Name:             e.Container.Name,
-				ImageId:          e.Container.ImageID,
-				ImageName:        e.Container.ImageName,
-				HostPid:          int32(e.Container.Pid),
-				ExitCode:         int32(e.Container.ExitCode),
-				ExitStatus:       exitStatus,
-				ExitSignal:       exitSignal,
-				ExitCoreDumped:   ws.CoreDump(),
-				DockerConfigJson: e.Container.JSONConfig,
-				OciConfigJson:    e.Container.OCIConfig,
+		event.Event = newContainerEvent(api.ContainerEvent{
+			Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_EXITED,
+			Name:             e.Container.Name,
+			ImageId:          e.Container.ImageID,
+			ImageName:        e.Container.ImageName,
+			ImageDigest:      e.Container.ImageDigest,
+			ImageLabels:      e.Container.ImageLabels,
+			HostPid:          int32(e.Container.Pid),
+			ExitCode:         int32(e.Container.ExitCode),
+			ExitStatus:       exitStatus,
+			ExitSignal:       exitSignal,
+			ExitCoreDumped:   ws.CoreDump(),
+			HealthStatus:     e.Container.Health,
+			DockerConfigJson: e.Container.JSONConfig,
+			OciConfigJson:    e.Container.OCIConfig,
+		})
+	case ContainerRunningTelemetryEvent:
+		event.Event = newContainerEvent(api.ContainerEvent{
+			Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_RUNNING,
+			Name:             e.Container.Name,
+			ImageId:          e.Container.ImageID,
+			ImageName:        e.Container.ImageName,
+			ImageDigest:      e.Container.ImageDigest,
+			ImageLabels:      e.Container.ImageLabels,
+			HostPid:          int32(e.Container.Pid),
+			HealthStatus:     e.Container.Health,
+			DockerConfigJson: e.Container.JSONConfig,
+			OciConfigJson:    e.Container.OCIConfig,
+		})
+
+	case ContainerHealthTelemetryEvent:
+		event.Event = newContainerEvent(api.ContainerEvent{
+			Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_HEALTH,
+			Name:             e.Container.Name,
+			ImageId:          e.Container.ImageID,
+			ImageName:        e.Container.ImageName,
+			ImageDigest:      e.Container.ImageDigest,
+			ImageLabels:      e.Container.ImageLabels,
+			HostPid:          int32(e.Container.Pid),
+			HealthStatus:     e.Container.Health,
+			DockerConfigJson: e.Container.JSONConfig,
+			OciConfigJson:    e.Container.OCIConfig,
+		})
+
+	case ContainerPausedTelemetryEvent:
+		event.Event = newContainerEvent(api.ContainerEvent{
+			Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_PAUSED,
+			Name:             e.Container.Name,
+			ImageId:          e.Container.ImageID,
+			ImageName:        e.Container.ImageName,
+			ImageDigest:      e.Container.ImageDigest,
+			ImageLabels:      e.Container.ImageLabels,
+			HostPid:          int32(e.Container.Pid),
+			HealthStatus:     e.Container.Health,
+			DockerConfigJson: e.Container.JSONConfig,
+			OciConfigJson:    e.Container.OCIConfig,
+		})
+
+	case ContainerResumedTelemetryEvent:
+		event.Event = newContainerEvent(api.ContainerEvent{
+			Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_RESUMED,
+			Name:             e.Container.Name,
+			ImageId:          e.Container.ImageID,
+			ImageName:        e.Container.ImageName,
+			ImageDigest:      e.Container.ImageDigest,
+			ImageLabels:      e.Container.ImageLabels,
+			HostPid:          int32(e.Container.Pid),
+			HealthStatus:     e.Container.Health,
+			DockerConfigJson: e.Container.JSONConfig,
+			OciConfigJson:    e.Container.OCIConfig,
+		})
+
+	case ContainerUpdatedTelemetryEvent:
+		event.Event = newContainerEvent(api.ContainerEvent{
+			Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_UPDATED,
+			Name:             e.Container.Name,
+			ImageId:          e.Container.ImageID,
+			ImageName:        e.Container.ImageName,
+			ImageDigest:      e.Container.ImageDigest,
+			ImageLabels:      e.Container.ImageLabels,
+			HostPid:          int32(e.Container.Pid),
+			HealthStatus:     e.Container.Health,
+			DockerConfigJson: e.Container.JSONConfig,
+			OciConfigJson:    e.Container.OCIConfig,
+		})
+
+	case ImagePulledTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Image{
+			Image: newImageEvent(api.ImageEventType_IMAGE_EVENT_TYPE_PULLED,
+				&e.Image),
+		}
+
+	case ImageTaggedTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Image{
+			Image: newImageEvent(api.ImageEventType_IMAGE_EVENT_TYPE_TAGGED,
+				&e.Image),
+		}
+
+	case ImageUntaggedTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Image{
+			Image: newImageEvent(api.ImageEventType_IMAGE_EVENT_TYPE_UNTAGGED,
+				&e.Image),
+		}
+
+	case ImageDeletedTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Image{
+			Image: newImageEvent(api.ImageEventType_IMAGE_EVENT_TYPE_DELETED,
+				&e.Image),
+		}
+
+	case BPFProgramLoadTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Bpf{
+			Bpf: &api.BpfEvent{
+				Type:                 api.BpfEventType_BPF_EVENT_TYPE_PROGRAM_LOAD,
+				LoadProgramType:      e.ProgramType,
+				LoadProgramName:      e.ProgramName,
+				LoadInstructionCount: e.InstructionCount,
 			},
 		}
-	case ContainerRunningTelemetryEvent:
-		event.Event = &api.TelemetryEvent_Container{
-			Container: &api.ContainerEvent{
-				Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_RUNNING,
-				Name:             e.Container.Name,
-				ImageId:          e.Container.ImageID,
-				ImageName:        e.Container.ImageName,
-				HostPid:          int32(e.Container.Pid),
-				DockerConfigJson: e.Container.JSONConfig,
-				OciConfigJson:    e.Container.OCIConfig,
+
+	case BPFProgramAttachTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Bpf{
+			Bpf: &api.BpfEvent{
+				Type:            api.BpfEventType_BPF_EVENT_TYPE_PROGRAM_ATTACH,
+				AttachType:      e.AttachType,
+				AttachProgramFd: e.ProgramFD,
+				AttachTargetFd:  e.TargetFD,
 			},
 		}
 
-	case ContainerUpdatedTelemetryEvent:
-		event.Event = &api.TelemetryEvent_Container{
-			Container: &api.ContainerEvent{
-				Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_UPDATED,
-				Name:             e.Container.Name,
-				ImageId:          e.Container.ImageID,
-				ImageName:        e.Container.ImageName,
-				HostPid:          int32(e.Container.Pid),
-				DockerConfigJson: e.Container.JSONConfig,
-				OciConfigJson:    e.Container.OCIConfig,
+	case IOUringSetupTelemetryEvent:
+		event.Event = &api.TelemetryEvent_IoUring{
+			IoUring: &api.IoUringEvent{
+				Type:           api.IoUringEventType_IO_URING_EVENT_TYPE_SETUP,
+				SetupFd:        e.FD,
+				SetupSqEntries: e.SQEntries,
+				SetupCqEntries: e.CQEntries,
+				SetupFlags:     e.Flags,
+			},
+		}
+
+	case IOUringSubmitTelemetryEvent:
+		event.Event = &api.TelemetryEvent_IoUring{
+			IoUring: &api.IoUringEvent{
+				Type:           api.IoUringEventType_IO_URING_EVENT_TYPE_SUBMIT,
+				SubmitOpcode:   uint32(e.Opcode),
+				SubmitUserData: e.UserData,
+				SubmitSqThread: e.SQThread,
 			},
 		}
 
@@ -1190,47 +2990,120 @@ func (s *Subscription) translateEvent(ev TelemetryEvent) *api.TelemetryEvent {
 			},
 		}
 
+	case FileWriteTelemetryEvent:
+		event.Event = &api.TelemetryEvent_File{
+			File: &api.FileEvent{
+				Type:       api.FileEventType_FILE_EVENT_TYPE_WRITE,
+				Filename:   e.Filename,
+				WriteCount: e.Count,
+			},
+		}
+
+	case FileMemfdCreateTelemetryEvent:
+		event.Event = &api.TelemetryEvent_File{
+			File: &api.FileEvent{
+				Type:       api.FileEventType_FILE_EVENT_TYPE_MEMFD_CREATE,
+				Filename:   e.Filename,
+				MemfdFlags: e.Flags,
+			},
+		}
+
+	case KernelModuleLoadTelemetryEvent:
+		event.Event = &api.TelemetryEvent_KernelModule{
+			KernelModule: &api.KernelModuleEvent{
+				Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD,
+				Name: e.Name,
+			},
+		}
+
+	case KernelModuleUnloadTelemetryEvent:
+		event.Event = &api.TelemetryEvent_KernelModule{
+			KernelModule: &api.KernelModuleEvent{
+				Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD,
+				Name: e.Name,
+			},
+		}
+
+	case LSMDenialTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Lsm{
+			Lsm: &api.LsmEvent{
+				Type:        api.LsmEventType_LSM_EVENT_TYPE_DENIAL,
+				Module:      e.Module,
+				Operation:   e.Operation,
+				Subject:     e.Subject,
+				Object:      e.Object,
+				ObjectClass: e.ObjectClass,
+				Name:        e.Name,
+				Message:     e.Message,
+			},
+		}
+
+	case MemoryMmapExecTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Memory{
+			Memory: &api.MemoryEvent{
+				Type:    api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP_EXEC,
+				Address: e.Address,
+				Length:  e.Length,
+				Prot:    e.Prot,
+				Flags:   e.Flags,
+			},
+		}
+
+	case MemoryMprotectExecTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Memory{
+			Memory: &api.MemoryEvent{
+				Type:    api.MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT_EXEC,
+				Address: e.Address,
+				Length:  e.Length,
+				Prot:    e.Prot,
+				OldProt: e.OldProt,
+			},
+		}
+
+	case MountTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Mount{
+			Mount: &api.MountEvent{
+				Type:   api.MountEventType_MOUNT_EVENT_TYPE_MOUNT,
+				Source: e.Source,
+				Target: e.Target,
+				Fstype: e.FSType,
+				Flags:  e.Flags,
+			},
+		}
+
+	case UnmountTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Mount{
+			Mount: &api.MountEvent{
+				Type:   api.MountEventType_MOUNT_EVENT_TYPE_UNMOUNT,
+				Target: e.Target,
+				Flags:  uint64(e.Flags),
+			},
+		}
+
 	case KernelFunctionCallTelemetryEvent:
-		args := make(map[string]*api.KernelFunctionCallEvent_FieldValue)
-		for k, v := range e.Arguments {
-			value := &api.KernelFunctionCallEvent_FieldValue{}
-			switch v := v.(type) {
-			case []byte:
-				value.FieldType = api.KernelFunctionCallEvent_BYTES
-				value.Value = &api.KernelFunctionCallEvent_FieldValue_BytesValue{BytesValue: v}
-			case string:
-				value.FieldType = api.KernelFunctionCallEvent_STRING
-				value.Value = &api.KernelFunctionCallEvent_FieldValue_StringValue{StringValue: v}
-			case int8:
-				value.FieldType = api.KernelFunctionCallEvent_SINT8
-				value.Value = &api.KernelFunctionCallEvent_FieldValue_SignedValue{SignedValue: int64(v)}
-			case int16:
-				value.FieldType = api.KernelFunctionCallEvent_SINT16
-				value.Value = &api.KernelFunctionCallEvent_FieldValue_SignedValue{SignedValue: int64(v)}
-			case int32:
-				value.FieldType = api.KernelFunctionCallEvent_SINT32
-				value.Value = &api.KernelFunctionCallEvent_FieldValue_SignedValue{SignedValue: int64(v)}
-			case int64:
-				value.FieldType = api.KernelFunctionCallEvent_SINT64
-				value.Value = &api.KernelFunctionCallEvent_FieldValue_SignedValue{SignedValue: v}
-			case uint8:
-				value.FieldType = api.KernelFunctionCallEvent_UINT8
-				value.Value = &api.KernelFunctionCallEvent_FieldValue_UnsignedValue{UnsignedValue: uint64(v)}
-			case uint16:
-				value.FieldType = api.KernelFunctionCallEvent_UINT16
-				value.Value = &api.KernelFunctionCallEvent_FieldValue_UnsignedValue{UnsignedValue: uint64(v)}
-			case uint32:
-				value.FieldType = api.KernelFunctionCallEvent_UINT32
-				value.Value = &api.KernelFunctionCallEvent_FieldValue_UnsignedValue{UnsignedValue: uint64(v)}
-			case uint64:
-				value.FieldType = api.KernelFunctionCallEvent_UINT64
-				value.Value = &api.KernelFunctionCallEvent_FieldValue_UnsignedValue{UnsignedValue: v}
-			}
-			args[k] = value
+		t := api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_ENTER
+		if e.OnReturn {
+			t = api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_EXIT
 		}
 		event.Event = &api.TelemetryEvent_KernelCall{
 			KernelCall: &api.KernelFunctionCallEvent{
-				Arguments: args,
+				Type:      t,
+				Symbol:    e.Symbol,
+				Arguments: translateFieldValues(e.Arguments),
+			},
+		}
+
+	case UserFunctionCallTelemetryEvent:
+		t := api.UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_ENTER
+		if e.OnReturn {
+			t = api.UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_EXIT
+		}
+		event.Event = &api.TelemetryEvent_UserCall{
+			UserCall: &api.UserFunctionCallEvent{
+				Type:       t,
+				Executable: e.Executable,
+				Symbol:     e.Symbol,
+				Arguments:  translateFieldValues(e.Arguments),
 			},
 		}
 
@@ -1334,6 +3207,63 @@ func (s *Subscription) translateEvent(ev TelemetryEvent) *api.TelemetryEvent {
 			},
 		}
 
+	case NetworkTCPConnectTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Network{
+			Network: &api.NetworkEvent{
+				Type:         api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_CONNECT,
+				Address:      translateNetworkAddress(e.Remote),
+				LocalAddress: translateNetworkAddress(e.Local),
+			},
+		}
+
+	case NetworkTCPAcceptTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Network{
+			Network: &api.NetworkEvent{
+				Type:         api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ACCEPT,
+				Address:      translateNetworkAddress(e.Remote),
+				LocalAddress: translateNetworkAddress(e.Local),
+			},
+		}
+
+	case NetworkDNSQueryTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Network{
+			Network: &api.NetworkEvent{
+				Type:         api.NetworkEventType_NETWORK_EVENT_TYPE_DNS_QUERY,
+				Address:      translateNetworkAddress(e.Server),
+				DnsQueryId:   uint32(e.QueryID),
+				DnsQueryName: e.QueryName,
+			},
+		}
+
+	case NetworkUnixConnectTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Network{
+			Network: &api.NetworkEvent{
+				Type: api.NetworkEventType_NETWORK_EVENT_TYPE_UNIX_CONNECT,
+				Address: &api.NetworkAddress{
+					Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_LOCAL,
+					Address: &api.NetworkAddress_LocalAddress{
+						LocalAddress: e.Path,
+					},
+				},
+			},
+		}
+
+	case NetworkTCPListenTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Network{
+			Network: &api.NetworkEvent{
+				Type:         api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_LISTEN,
+				LocalAddress: translateNetworkAddress(e.Local),
+			},
+		}
+
+	case NetworkUDPBindTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Network{
+			Network: &api.NetworkEvent{
+				Type:         api.NetworkEventType_NETWORK_EVENT_TYPE_UDP_BIND,
+				LocalAddress: translateNetworkAddress(e.Local),
+			},
+		}
+
 	case PerformanceTelemetryEvent:
 		values := make([]*api.PerformanceEventValue, len(e.Counters))
 		for i, v := range e.Counters {
@@ -1361,39 +3291,152 @@ func (s *Subscription) translateEvent(ev TelemetryEvent) *api.TelemetryEvent {
 		}
 
 	case ProcessExecTelemetryEvent:
-		event.Event = &api.TelemetryEvent_Process{
-			Process: &api.ProcessEvent{
-				Type:            api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
-				ExecFilename:    e.Filename,
-				ExecCommandLine: e.CommandLine,
-			},
+		pe := api.ProcessEvent{
+			Type:            api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+			ExecFilename:    e.Filename,
+			ExecCommandLine: e.CommandLine,
+			ExecFileless:    e.Fileless,
+			ExecSha256:      e.SHA256,
+		}
+		if s.captureExecEnvironment {
+			pe.ExecEnvironment = e.Environment
 		}
+		event.Event = newProcessEvent(pe)
 
 	case ProcessExitTelemetryEvent:
-		event.Event = &api.TelemetryEvent_Process{
-			Process: &api.ProcessEvent{
-				Type:           api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT,
-				ExitCode:       e.ExitCode,
-				ExitStatus:     e.ExitStatus,
-				ExitSignal:     e.ExitSignal,
-				ExitCoreDumped: e.ExitCoreDumped,
+		event.Event = newProcessEvent(api.ProcessEvent{
+			Type:           api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT,
+			ExitCode:       e.ExitCode,
+			ExitStatus:     e.ExitStatus,
+			ExitSignal:     e.ExitSignal,
+			ExitCoreDumped: e.ExitCoreDumped,
+			ExitTgid:       e.TGID,
+			ExitParentPid:  e.ParentPID,
+			ExitParentTgid: e.ParentTGID,
+		})
+
+	case ProcessForkTelemetryEvent:
+		event.Event = newProcessEvent(api.ProcessEvent{
+			Type:           api.ProcessEventType_PROCESS_EVENT_TYPE_FORK,
+			ForkChildId:    e.ChildProcessID,
+			ForkChildPid:   e.ChildPID,
+			ForkChildTgid:  e.ChildTGID,
+			ForkCloneFlags: e.CloneFlags,
+			ForkParentPid:  e.ParentPID,
+			ForkParentTgid: e.ParentTGID,
+		})
+
+	case ProcessCredChangeTelemetryEvent:
+		event.Event = newProcessEvent(api.ProcessEvent{
+			Type:          api.ProcessEventType_PROCESS_EVENT_TYPE_CRED_CHANGE,
+			CredChangeOld: translateCredentials(e.OldCreds),
+			CredChangeNew: translateCredentials(e.NewCreds),
+		})
+
+	case ProcessCapabilityChangeTelemetryEvent:
+		event.Event = newProcessEvent(api.ProcessEvent{
+			Type:                  api.ProcessEventType_PROCESS_EVENT_TYPE_CAPABILITY_CHANGE,
+			CapChangeGained:       e.Gained,
+			CapChangePermitted:    e.Permitted,
+			CapChangeEffective:    e.Effective,
+			CapChangeOldPermitted: e.OldPermitted,
+			CapChangeOldEffective: e.OldEffective,
+		})
+
+	case ProcessCrashTelemetryEvent:
+		event.Event = newProcessEvent(api.ProcessEvent{
+			Type:            api.ProcessEventType_PROCESS_EVENT_TYPE_CRASH,
+			CrashSignal:     e.Signal,
+			CrashCode:       e.Code,
+			CrashExecutable: e.Executable,
+		})
+
+	case ProcessOOMKillTelemetryEvent:
+		event.Event = newProcessEvent(api.ProcessEvent{
+			Type:              api.ProcessEventType_PROCESS_EVENT_TYPE_OOM_KILL,
+			OomKillTriggerPid: e.TriggerPID,
+			OomKillTotalVm:    e.TotalVM,
+			OomKillAnonRss:    e.AnonRSS,
+			OomKillFileRss:    e.FileRSS,
+			OomKillShmemRss:   e.ShmemRSS,
+			OomKillScoreAdj:   e.OOMScoreAdj,
+		})
+
+	case ProcessPtraceAttachTelemetryEvent:
+		event.Event = newProcessEvent(api.ProcessEvent{
+			Type:                    api.ProcessEventType_PROCESS_EVENT_TYPE_PTRACE_ATTACH,
+			PtraceRequest:           e.Request,
+			PtraceTracerPid:         e.TracerPID,
+			PtraceTracerProcessId:   e.TracerProcessID,
+			PtraceTracerContainerId: e.TracerContainerID,
+			PtraceTraceePid:         e.TraceePID,
+			PtraceTraceeProcessId:   e.TraceeProcessID,
+			PtraceTraceeContainerId: e.TraceeContainerID,
+		})
+
+	case ProcessSeccompViolationTelemetryEvent:
+		event.Event = newProcessEvent(api.ProcessEvent{
+			Type:               api.ProcessEventType_PROCESS_EVENT_TYPE_SECCOMP_VIOLATION,
+			SeccompSyscall:     e.Syscall,
+			SeccompAction:      api.SeccompAction(e.Action),
+			SeccompData:        e.Data,
+			SeccompSyscallName: syscallName(int64(e.Syscall)),
+		})
+
+	case ProcessUpdateTelemetryEvent:
+		event.Event = newProcessEvent(api.ProcessEvent{
+			Type:      api.ProcessEventType_PROCESS_EVENT_TYPE_UPDATE,
+			UpdateCwd: e.CWD,
+		})
+
+	case SessionLoginTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Session{
+			Session: &api.SessionEvent{
+				Type:      api.SessionEventType_SESSION_EVENT_TYPE_LOGIN,
+				User:      e.User,
+				Tty:       e.TTY,
+				Host:      e.Host,
+				Address:   e.Address,
+				SessionId: e.SessionID,
 			},
 		}
 
-	case ProcessForkTelemetryEvent:
-		event.Event = &api.TelemetryEvent_Process{
-			Process: &api.ProcessEvent{
-				Type:         api.ProcessEventType_PROCESS_EVENT_TYPE_FORK,
-				ForkChildId:  e.ChildProcessID,
-				ForkChildPid: e.ChildPID,
+	case SessionLogoutTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Session{
+			Session: &api.SessionEvent{
+				Type:      api.SessionEventType_SESSION_EVENT_TYPE_LOGOUT,
+				User:      e.User,
+				Tty:       e.TTY,
+				Host:      e.Host,
+				Address:   e.Address,
+				SessionId: e.SessionID,
 			},
 		}
 
-	case ProcessUpdateTelemetryEvent:
-		event.Event = &api.TelemetryEvent_Process{
-			Process: &api.ProcessEvent{
-				Type:      api.ProcessEventType_PROCESS_EVENT_TYPE_UPDATE,
-				UpdateCwd: e.CWD,
+	case SignalGenerateTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Signal{
+			Signal: &api.SignalEvent{
+				Type:                      api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE,
+				Signal:                    e.Signal,
+				Errno:                     e.Errno,
+				Code:                      e.Code,
+				GenerateTargetPid:         e.TargetPID,
+				GenerateTargetProcessId:   e.TargetProcessID,
+				GenerateTargetContainerId: e.TargetContainerID,
+				GenerateGroup:             e.Group,
+				GenerateResult:            e.Result,
+			},
+		}
+
+	case SignalDeliverTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Signal{
+			Signal: &api.SignalEvent{
+				Type:             api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER,
+				Signal:           e.Signal,
+				Errno:            e.Errno,
+				Code:             e.Code,
+				DeliverSaHandler: e.SaHandler,
+				DeliverSaFlags:   e.SaFlags,
 			},
 		}
 
@@ -1402,6 +3445,7 @@ func (s *Subscription) translateEvent(ev TelemetryEvent) *api.TelemetryEvent {
 			Syscall: &api.SyscallEvent{
 				Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
 				Id:   e.ID,
+				Name: syscallName(e.ID),
 				Arg0: e.Arguments[0],
 				Arg1: e.Arguments[1],
 				Arg2: e.Arguments[2],
@@ -1416,10 +3460,35 @@ func (s *Subscription) translateEvent(ev TelemetryEvent) *api.TelemetryEvent {
 			Syscall: &api.SyscallEvent{
 				Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
 				Id:   e.ID,
+				Name: syscallName(e.ID),
 				Ret:  e.Return,
 			},
 		}
 
+	case LostEventsTelemetryEvent:
+		event.Event = &api.TelemetryEvent_LostEvents{
+			LostEvents: &api.LostEventsEvent{
+				Count:                    e.Count,
+				WindowStartMonotimeNanos: e.WindowStartMonotimeNanos,
+			},
+		}
+
+	case SensorStatusTelemetryEvent:
+		event.Event = &api.TelemetryEvent_SensorStatus{
+			SensorStatus: &api.SensorStatusEvent{
+				UptimeNanos:         e.UptimeNanos,
+				EventsReceived:      e.EventsReceived,
+				EventsPerSecond:     e.EventsPerSecond,
+				LostRecords:         e.LostRecords,
+				RateLimitedEvents:   e.RateLimitedEvents,
+				DecodeErrors:        e.DecodeErrors,
+				StreamEventsDropped: e.StreamEventsDropped,
+				HeapAllocBytes:      e.HeapAllocBytes,
+				SysBytes:            e.SysBytes,
+				Probes:              e.Probes,
+			},
+		}
+
 	case TickerTelemetryEvent:
 		event.Event = &api.TelemetryEvent_Ticker{
 			Ticker: &api.TickerEvent{
@@ -1427,6 +3496,41 @@ func (s *Subscription) translateEvent(ev TelemetryEvent) *api.TelemetryEvent {
 				Nanoseconds: e.Nanoseconds,
 			},
 		}
+
+	case TTYReadTelemetryEvent:
+		event.Event = &api.TelemetryEvent_Tty{
+			Tty: &api.TtyEvent{
+				Type:   api.TtyEventType_TTY_EVENT_TYPE_READ,
+				Data:   e.Data,
+				Length: e.Length,
+			},
+		}
+	}
+
+	// Enrich events that identify a host process with what the process
+	// info cache knows about it.
+	if c, ok := event.Event.(*api.TelemetryEvent_Container); ok &&
+		c.Container.HostPid > 0 {
+		c.Container.HostProcess = s.sensor.hostProcessInfo(
+			int(c.Container.HostPid))
+	}
+
+	if p := event.GetProcess(); p != nil && s.sensor.userNames != nil {
+		for _, c := range []*api.Credentials{p.CredChangeOld, p.CredChangeNew} {
+			if c != nil {
+				s.sensor.userNames.resolve(c,
+					eventData.Container.ID, eventData.TGID)
+			}
+		}
+	}
+
+	// Exec and network events include the lineage of their process so
+	// that what led to them can be seen without rebuilding the process
+	// tree from fork events.
+	if event.GetNetwork() != nil || event.GetProcess().GetType() ==
+		api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC {
+		event.ProcessLineage = s.sensor.processLineage(eventData.PID,
+			eventData.ProcessID)
 	}
 
 	return event
@@ -16,9 +16,12 @@ package sensor
 
 import (
 	"context"
+	"io"
 	"net"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,7 +29,10 @@ import (
 
 	"github.com/capsule8/capsule8/pkg/config"
 	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/logging"
+	"github.com/capsule8/capsule8/pkg/sys"
 	"github.com/capsule8/capsule8/pkg/sys/perf"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 
@@ -71,13 +77,18 @@ func newTelemetryStream(
 }
 
 func TestTelemetryService(t *testing.T) {
-	var start, stop, getEventsRequest, getEventsResponse bool
+	// The functions are called by the service's goroutines
+	var start, stop, getEventsRequest, getEventsResponse int32
 
 	options := []TelemetryServiceOption{
-		WithStartFunc(func() { start = true }),
-		WithStopFunc(func() { stop = true }),
-		WithGetEventsRequestFunc(func(request *api.GetEventsRequest) { getEventsRequest = true }),
-		WithGetEventsResponseFunc(func(response *api.GetEventsResponse, err error) { getEventsResponse = true }),
+		WithStartFunc(func() { atomic.StoreInt32(&start, 1) }),
+		WithStopFunc(func() { atomic.StoreInt32(&stop, 1) }),
+		WithGetEventsRequestFunc(func(request *api.GetEventsRequest) {
+			atomic.StoreInt32(&getEventsRequest, 1)
+		}),
+		WithGetEventsResponseFunc(func(response *api.GetEventsResponse, err error) {
+			atomic.StoreInt32(&getEventsResponse, 1)
+		}),
 	}
 
 	sensor := newUnitTestSensor(t)
@@ -86,6 +97,13 @@ func TestTelemetryService(t *testing.T) {
 	assert.NotZero(t, service.Name())
 
 	config.Sensor.UseTLS = false
+
+	// Options read by the service's goroutines must be set before it
+	// serves. Only streams using version 2 are sent status events.
+	oldStatusInterval := config.Sensor.StatusInterval
+	config.Sensor.StatusInterval = 50 * time.Millisecond
+	defer func() { config.Sensor.StatusInterval = oldStatusInterval }()
+
 	go service.Serve()
 	time.Sleep(200 * time.Millisecond)
 
@@ -137,6 +155,97 @@ func TestTelemetryService(t *testing.T) {
 				},
 			},
 		},
+		// RateLimitModifier events per second is invalid (0)
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			Modifier: &api.Modifier{
+				RateLimit: &api.RateLimitModifier{},
+			},
+		},
+		// SampleModifier rate is invalid (0)
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			Modifier: &api.Modifier{
+				Sample: &api.SampleModifier{},
+			},
+		},
+		// KeyedThrottleModifier key is invalid
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			Modifier: &api.Modifier{
+				KeyedThrottle: &api.KeyedThrottleModifier{
+					Keys:     []string{"event.no_such_field"},
+					Limit:    1,
+					Interval: 1,
+				},
+			},
+		},
+		// AggregateModifier interval is invalid (0)
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			Modifier: &api.Modifier{
+				Aggregate: &api.AggregateModifier{},
+			},
+		},
+		// ProjectionModifier field is invalid
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			Modifier: &api.Modifier{
+				Projection: &api.ProjectionModifier{
+					Exclude: []string{"event.container.no_such_field"},
+				},
+			},
+		},
+		// BufferModifier overflow policy is invalid (8888)
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			Modifier: &api.Modifier{
+				Buffer: &api.BufferModifier{
+					OverflowPolicy: 8888,
+				},
+			},
+		},
+		// BatchModifier max events is invalid (0)
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			Modifier: &api.Modifier{
+				Batch: &api.BatchModifier{},
+			},
+		},
+		// Expression is invalid
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			Expression:  "event.image_name.matches(\"(redis\")",
+		},
+		// ContainerFilter image name glob is invalid
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			ContainerFilter: &api.ContainerFilter{
+				ImageNames: []string{"nginx:[1"},
+			},
+		},
+		// ContainerFilter regexps are invalid
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			ContainerFilter: &api.ContainerFilter{
+				NameRegexps: []string{"[web"},
+			},
+		},
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			ContainerFilter: &api.ContainerFilter{
+				ImageNameRegexps: []string{"nginx:(1"},
+			},
+		},
+		// ContainerFilter exclusion is invalid
+		&api.Subscription{
+			EventFilter: &api.EventFilter{},
+			ContainerFilter: &api.ContainerFilter{
+				Exclude: &api.ContainerFilter{
+					ImageNames: []string{"*-sidecar:[1"},
+				},
+			},
+		},
 	}
 	for _, sub := range badSubscriptions {
 		var (
@@ -211,6 +320,8 @@ func TestTelemetryService(t *testing.T) {
 		if assert.NotZero(t, len(response.Statuses)) {
 			assert.Equal(t, int32(code.Code_OK), response.Statuses[0].Code)
 		}
+		// Clients that do not send a version use version 1
+		assert.Equal(t, api.APIVersion_API_VERSION_1, response.ApiVersion)
 
 		gotError := false
 		var events []*api.ReceivedTelemetryEvent
@@ -228,14 +339,500 @@ func TestTelemetryService(t *testing.T) {
 		streamCancel()
 	}
 
+	// Events are sent in batches with a BatchModifier, and the last
+	// partial batch is sent when the limit is reached
+	sub = &api.Subscription{
+		EventFilter: &api.EventFilter{
+			ChargenEvents: []*api.ChargenEventFilter{
+				&api.ChargenEventFilter{
+					Length: 10,
+				},
+			},
+		},
+		Modifier: &api.Modifier{
+			Limit: &api.LimitModifier{
+				Limit: 10,
+			},
+			Batch: &api.BatchModifier{
+				MaxEvents:      4,
+				MaxLatency:     10,
+				MaxLatencyType: api.ThrottleModifier_SECOND,
+			},
+		},
+	}
+	stream, streamCancel, err = newTelemetryStream(t, client, sub)
+	if assert.NoErrorf(t, err, "%#v", sub) {
+		var response *api.GetEventsResponse
+		response, err = stream.Recv()
+		require.NoError(t, err)
+		assert.Empty(t, response.Events)
+
+		var sizes []int
+		for {
+			if response, err = stream.Recv(); err != nil {
+				break
+			}
+			sizes = append(sizes, len(response.Events))
+		}
+		assert.Equal(t, []int{4, 4, 2}, sizes)
+		streamCancel()
+	}
+
+	// Partial batches are sent once their first event has waited the
+	// max latency
+	sub = &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{
+					Interval: int64(10 * time.Millisecond),
+				},
+			},
+		},
+		Modifier: &api.Modifier{
+			Batch: &api.BatchModifier{
+				MaxEvents:  1000,
+				MaxLatency: 50,
+			},
+		},
+	}
+	stream, streamCancel, err = newTelemetryStream(t, client, sub)
+	if assert.NoErrorf(t, err, "%#v", sub) {
+		_, err = stream.Recv()
+		require.NoError(t, err)
+		var response *api.GetEventsResponse
+		response, err = stream.Recv()
+		require.NoError(t, err)
+		assert.NotEmpty(t, response.Events)
+		assert.True(t, len(response.Events) < 1000)
+		streamCancel()
+	}
+
+	// Streams use the newest version that both the client and the Sensor
+	// support, and event filters newer than a stream's version fail
+	// the subscription
+	sub = &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{
+					Interval: int64(10 * time.Millisecond),
+				},
+			},
+		},
+	}
+	streamContext, streamCancel := context.WithCancel(context.Background())
+	stream, err = client.GetEvents(streamContext, &api.GetEventsRequest{
+		Subscription: sub,
+		ApiVersion:   maxAPIVersion + 1,
+	})
+	require.NoError(t, err)
+	response, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, maxAPIVersion, response.ApiVersion)
+	streamCancel()
+
+	sub.EventFilter.MountEvents = []*api.MountEventFilter{
+		&api.MountEventFilter{},
+	}
+	stream, streamCancel, err = newTelemetryStream(t, client, sub)
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "requires API version")
+	}
+	streamCancel()
+
+	// Streams using version 2 are sent sensor status events
+	sub = &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{
+					Interval: int64(time.Hour),
+				},
+			},
+		},
+	}
+	streamContext, streamCancel = context.WithCancel(context.Background())
+	stream, err = client.GetEvents(streamContext, &api.GetEventsRequest{
+		Subscription: sub,
+		ApiVersion:   api.APIVersion_API_VERSION_2,
+	})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	response, err = stream.Recv()
+	if assert.NoError(t, err) && assert.Len(t, response.Events, 1) {
+		status := response.Events[0].Event.GetSensorStatus()
+		if assert.NotNil(t, status) {
+			assert.NotZero(t, status.UptimeNanos)
+			assert.NotZero(t, status.SysBytes)
+		}
+	}
+	streamCancel()
+
+	// The stream of a subscription with a TTL ends when the TTL expires
+	sub = &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{
+					Interval: int64(100 * time.Millisecond),
+				},
+			},
+		},
+		TtlSeconds: 1,
+	}
+	stream, streamCancel, err = newTelemetryStream(t, client, sub)
+	if assert.NoErrorf(t, err, "%#v", sub) {
+		begin := time.Now()
+		for {
+			if _, err = stream.Recv(); err != nil {
+				break
+			}
+		}
+		assert.Contains(t, err.Error(), "TTL expired")
+		assert.True(t, time.Since(begin) >= 900*time.Millisecond)
+		assert.True(t, time.Since(begin) < 5*time.Second)
+
+		streamCancel()
+	}
+
+	// Aggregates held when the TTL expires are sent before the stream
+	// ends
+	sub.Modifier = &api.Modifier{
+		Aggregate: &api.AggregateModifier{
+			Interval:     1,
+			IntervalType: api.ThrottleModifier_HOUR,
+		},
+	}
+	stream, streamCancel, err = newTelemetryStream(t, client, sub)
+	if assert.NoErrorf(t, err, "%#v", sub) {
+		var aggregated uint64
+		for {
+			response, err = stream.Recv()
+			if err != nil {
+				break
+			}
+			for _, re := range response.Events {
+				if assert.NotNil(t, re.Aggregate) {
+					aggregated += re.Aggregate.Count
+				}
+			}
+		}
+		assert.Contains(t, err.Error(), "TTL expired")
+		assert.NotZero(t, aggregated)
+
+		streamCancel()
+	}
+
+	// The filters of a stream can be replaced without closing it
+	_, err = client.ModifySubscription(connContext,
+		&api.ModifySubscriptionRequest{
+			SubscriptionId: "no such subscription",
+			Subscription:   sub,
+		})
+	assert.Error(t, err)
+
+	sub = &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{
+					Interval: int64(10 * time.Millisecond),
+				},
+			},
+		},
+	}
+	stream, streamCancel, err = newTelemetryStream(t, client, sub)
+	if assert.NoErrorf(t, err, "%#v", sub) {
+		var response *api.GetEventsResponse
+		response, err = stream.Recv()
+		require.NoError(t, err)
+		require.NotZero(t, response.SubscriptionId)
+
+		req := &api.ModifySubscriptionRequest{
+			SubscriptionId: response.SubscriptionId,
+		}
+		_, err = client.ModifySubscription(connContext, req)
+		assert.Error(t, err)
+		req.Subscription = &api.Subscription{
+			EventFilter: &api.EventFilter{},
+		}
+		_, err = client.ModifySubscription(connContext, req)
+		assert.Error(t, err)
+
+		req.Subscription = &api.Subscription{
+			EventFilter: &api.EventFilter{
+				ChargenEvents: []*api.ChargenEventFilter{
+					&api.ChargenEventFilter{
+						Length: 10,
+					},
+				},
+			},
+		}
+		var r *api.ModifySubscriptionResponse
+		r, err = client.ModifySubscription(connContext, req)
+		require.NoError(t, err)
+		if assert.Len(t, r.Statuses, 1) {
+			assert.Equal(t, int32(code.Code_OK), r.Statuses[0].Code)
+		}
+
+		// Ticker events may be received until the old subscription is
+		// closed, followed by only chargen events
+		var chargen int
+		for chargen < 10 {
+			response, err = stream.Recv()
+			require.NoError(t, err)
+			for _, e := range response.Events {
+				if e.Event.GetChargen() != nil {
+					chargen++
+				} else {
+					assert.Zero(t, chargen)
+				}
+			}
+		}
+
+		// The stream is listed with its replaced filters
+		var l *api.ListSubscriptionsResponse
+		l, err = client.ListSubscriptions(connContext,
+			&api.ListSubscriptionsRequest{})
+		require.NoError(t, err)
+		var info *api.SubscriptionInfo
+		for _, i := range l.Subscriptions {
+			if i.SubscriptionId == req.SubscriptionId {
+				info = i
+			}
+		}
+		if assert.NotNil(t, info) {
+			if assert.NotNil(t, info.Subscription) {
+				assert.Len(t, info.Subscription.EventFilter.ChargenEvents, 1)
+				assert.Len(t, info.Subscription.EventFilter.TickerEvents, 0)
+			}
+			assert.NotZero(t, info.StartTimeMicros)
+			assert.True(t, info.EventsSent >= 10)
+			assert.True(t, info.EventsReceived >= info.EventsSent)
+			assert.True(t, info.EventsPerSecond > 0)
+		}
+
+		streamCancel()
+	}
+
+	// Unacknowledged responses are sent again when a stream reconnects
+	sub = &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{
+					Interval: int64(10 * time.Millisecond),
+				},
+			},
+		},
+		DeliveryId: "test delivery",
+	}
+	stream, streamCancel, err = newTelemetryStream(t, client, sub)
+	if assert.NoErrorf(t, err, "%#v", sub) {
+		var response *api.GetEventsResponse
+		response, err = stream.Recv()
+		require.NoError(t, err)
+		assert.Zero(t, response.SequenceNumber)
+		for i := uint64(1); i <= 3; i++ {
+			response, err = stream.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, i, response.SequenceNumber)
+		}
+
+		var ack *api.AcknowledgeEventsResponse
+		ack, err = client.AcknowledgeEvents(connContext,
+			&api.AcknowledgeEventsRequest{
+				DeliveryId:     sub.DeliveryId,
+				SequenceNumber: 1,
+			})
+		require.NoError(t, err)
+		assert.True(t, ack.Pending >= 2)
+
+		// Only one stream may use a delivery at a time
+		other, otherCancel, _ := newTelemetryStream(t, client, sub)
+		_, err = other.Recv()
+		assert.Error(t, err)
+		otherCancel()
+
+		streamCancel()
+	}
+
+	var redelivered uint64
+	for i := 0; i < 50 && redelivered == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		stream, streamCancel, err = newTelemetryStream(t, client, sub)
+		require.NoError(t, err)
+		var response *api.GetEventsResponse
+		if response, err = stream.Recv(); err == nil {
+			response, err = stream.Recv()
+			require.NoError(t, err)
+			redelivered = response.SequenceNumber
+		}
+		streamCancel()
+	}
+	assert.Equal(t, uint64(2), redelivered)
+
+	// The log level can be changed at runtime
+	oldVerbosity := logging.Verbosity()
+	level, err := client.SetLogLevel(connContext,
+		&api.SetLogLevelRequest{Verbosity: int32(oldVerbosity + 2)})
+	require.NoError(t, err)
+	assert.Equal(t, int32(oldVerbosity), level.PreviousVerbosity)
+	assert.Equal(t, oldVerbosity+2, logging.Verbosity())
+	_, err = client.SetLogLevel(connContext,
+		&api.SetLogLevelRequest{Verbosity: -1})
+	assert.Error(t, err)
+	_, err = client.SetLogLevel(connContext,
+		&api.SetLogLevelRequest{Verbosity: int32(oldVerbosity)})
+	require.NoError(t, err)
+	assert.Equal(t, oldVerbosity, logging.Verbosity())
+
+	// The spool is not enabled
+	replay, err := client.ReplayEvents(connContext,
+		&api.ReplayEventsRequest{})
+	require.NoError(t, err)
+	_, err = replay.Recv()
+	assert.Error(t, err)
+
+	// The unit test sensor's tracing directory has no available_events
+	_, err = client.ListTracingEvents(connContext,
+		&api.ListTracingEventsRequest{})
+	assert.Error(t, err)
+
+	events := "sched:sched_process_exec\nsyscalls:sys_enter_connect\n" +
+		"syscalls:sys_exit_connect\n"
+	writeFile(t, filepath.Join(sensor.tracingDir, "available_events"),
+		[]byte(events))
+	r, err := client.ListTracingEvents(connContext,
+		&api.ListTracingEventsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sched/sched_process_exec",
+		"syscalls/sys_enter_connect", "syscalls/sys_exit_connect"},
+		r.Tracepoints)
+	assert.Len(t, r.KernelSymbols, 0)
+
+	r, err = client.ListTracingEvents(connContext,
+		&api.ListTracingEventsRequest{
+			Prefix:               "sys_c",
+			IncludeKernelSymbols: true,
+		})
+	require.NoError(t, err)
+	assert.Len(t, r.Tracepoints, 0)
+	assert.Contains(t, r.KernelSymbols, "sys_clone")
+	for _, symbol := range r.KernelSymbols {
+		assert.True(t, strings.HasPrefix(symbol, "sys_c"))
+	}
+
 	connCancel()
 
 	service.Stop()
 
-	assert.True(t, start)
-	assert.True(t, stop)
-	assert.True(t, getEventsRequest)
-	assert.True(t, getEventsResponse)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&start))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&stop))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&getEventsRequest))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&getEventsResponse))
+}
+
+func TestTelemetryServiceShutdown(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	address := "unix:" + filepath.Join(sensor.runtimeDir, "shutdown.sock")
+	service := NewTelemetryService(sensor, address)
+	config.Sensor.UseTLS = false
+	served := make(chan error)
+	go func() { served <- service.Serve() }()
+	time.Sleep(200 * time.Millisecond)
+
+	conn, err := grpc.Dial(address,
+		grpc.WithDialer(dialer),
+		grpc.WithBlock(),
+		grpc.WithTimeout(1*time.Second),
+		grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := api.NewTelemetryServiceClient(conn)
+
+	// Aggregates are held until their hour long windows end, so they are
+	// only sent because the stream is drained
+	stream, err := client.GetEvents(context.Background(),
+		&api.GetEventsRequest{
+			Subscription: &api.Subscription{
+				EventFilter: &api.EventFilter{
+					TickerEvents: []*api.TickerEventFilter{
+						&api.TickerEventFilter{
+							Interval: int64(10 * time.Millisecond),
+						},
+					},
+				},
+				Modifier: &api.Modifier{
+					Aggregate: &api.AggregateModifier{
+						Interval:     1,
+						IntervalType: api.ThrottleModifier_HOUR,
+					},
+				},
+			},
+		})
+	require.NoError(t, err)
+	r, err := stream.Recv()
+	require.NoError(t, err)
+	require.NotEmpty(t, r.SubscriptionId)
+	time.Sleep(100 * time.Millisecond)
+
+	go service.Stop()
+
+	var aggregated uint64
+	for {
+		r, err = stream.Recv()
+		require.NoError(t, err)
+		for _, re := range r.Events {
+			require.NotNil(t, re.Aggregate)
+			aggregated += re.Aggregate.Count
+		}
+		if r.EndOfStream {
+			break
+		}
+	}
+	assert.NotZero(t, aggregated)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	select {
+	case <-served:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return")
+	}
+}
+
+func TestRegisterBPFEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	events := []*api.BpfEventFilter{
+		&api.BpfEventFilter{
+			Type: api.BpfEventType_BPF_EVENT_TYPE_PROGRAM_LOAD,
+			FilterExpression: expression.Equal(
+				expression.Identifier("prog_type"),
+				expression.Value(uint32(2))),
+		},
+		&api.BpfEventFilter{
+			Type: api.BpfEventType_BPF_EVENT_TYPE_PROGRAM_ATTACH,
+		},
+	}
+	invalidEvents := []*api.BpfEventFilter{
+		&api.BpfEventFilter{
+			Type: api.BpfEventType_BPF_EVENT_TYPE_UNKNOWN,
+		},
+		&api.BpfEventFilter{
+			Type: api.BpfEventType(999),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	prepareForRegisterBPFProgramLoadEventFilter(t, s, 0)
+	prepareForRegisterBPFProgramAttachEventFilter(t, s, 1)
+	s.registerBPFEvents(events)
+	s.registerBPFEvents(invalidEvents)
+	assert.Len(t, s.eventSinks, 2)
+	assert.Len(t, s.status, 2)
 }
 
 func TestRegisterChargenEvents(t *testing.T) {
@@ -281,6 +878,18 @@ func TestRegisterContainerEvents(t *testing.T) {
 		&api.ContainerEventFilter{
 			Type: api.ContainerEventType_CONTAINER_EVENT_TYPE_DESTROYED,
 		},
+		&api.ContainerEventFilter{
+			Type: api.ContainerEventType_CONTAINER_EVENT_TYPE_HEALTH,
+			FilterExpression: expression.Equal(
+				expression.Identifier("health_status"),
+				expression.Value("unhealthy")),
+		},
+		&api.ContainerEventFilter{
+			Type: api.ContainerEventType_CONTAINER_EVENT_TYPE_PAUSED,
+		},
+		&api.ContainerEventFilter{
+			Type: api.ContainerEventType_CONTAINER_EVENT_TYPE_RESUMED,
+		},
 	}
 	invalidEvents := []*api.ContainerEventFilter{
 		&api.ContainerEventFilter{
@@ -323,6 +932,21 @@ func TestRegisterFileEvents(t *testing.T) {
 			Type: api.FileEventType_FILE_EVENT_TYPE_OPEN,
 		},
 	}
+	eventSet4 := []*api.FileEventFilter{
+		&api.FileEventFilter{
+			Type:         api.FileEventType_FILE_EVENT_TYPE_WRITE,
+			PathPrefixes: []string{"/etc/", "/usr/bin/"},
+			PathGlobs:    []string{"*.so"},
+		},
+	}
+	eventSet5 := []*api.FileEventFilter{
+		&api.FileEventFilter{
+			Type: api.FileEventType_FILE_EVENT_TYPE_MEMFD_CREATE,
+			FilterExpression: expression.NotEqual(
+				expression.Identifier("filename"),
+				expression.Value("")),
+		},
+	}
 	invalidEvents := []*api.FileEventFilter{
 		&api.FileEventFilter{
 			Type: api.FileEventType_FILE_EVENT_TYPE_UNKNOWN,
@@ -344,6 +968,274 @@ func TestRegisterFileEvents(t *testing.T) {
 	s.registerFileEvents(eventSet3)
 	s.registerFileEvents(invalidEvents)
 	verifyRegisterFileOpenEventFilter(t, s, len(eventSet1)+len(eventSet2)+len(eventSet3))
+
+	s = newTestSubscription(t, sensor)
+	prepareForRegisterFileWriteEventFilter(t, s, 0)
+	s.registerFileEvents(eventSet4)
+	verifyRegisterFileOpenEventFilter(t, s, len(eventSet4))
+
+	s = newTestSubscription(t, sensor)
+	prepareForRegisterFileMemfdCreateEventFilter(t, s, 0)
+	s.registerFileEvents(eventSet5)
+	verifyRegisterFileOpenEventFilter(t, s, len(eventSet5))
+}
+
+func TestRewriteFileEventFilter(t *testing.T) {
+	fef := &api.FileEventFilter{
+		Type:         api.FileEventType_FILE_EVENT_TYPE_WRITE,
+		PathPrefixes: []string{"/etc/"},
+		PathGlobs:    []string{"*.so"},
+	}
+	rewriteFileEventFilter(fef)
+	assert.Len(t, fef.PathPrefixes, 0)
+	assert.Len(t, fef.PathGlobs, 0)
+
+	expr, err := expression.NewExpression(fef.FilterExpression)
+	require.NoError(t, err)
+
+	type testCase struct {
+		filename string
+		matched  bool
+	}
+	testCases := []testCase{
+		testCase{"/etc/passwd", true},
+		testCase{"/usr/lib/libc.so", true},
+		testCase{"/usr/bin/ls", false},
+	}
+	for _, tc := range testCases {
+		v, err := expr.Evaluate(FileWriteEventTypes,
+			expression.FieldValueMap{"filename": tc.filename})
+		require.NoError(t, err)
+		assert.Equal(t, tc.matched, expression.IsValueTrue(v), tc.filename)
+	}
+}
+
+func TestRegisterImageEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	events := []*api.ImageEventFilter{
+		&api.ImageEventFilter{
+			Type: api.ImageEventType_IMAGE_EVENT_TYPE_PULLED,
+			FilterExpression: expression.Equal(
+				expression.Identifier("registry"),
+				expression.Value("docker.io")),
+		},
+		&api.ImageEventFilter{
+			Type: api.ImageEventType_IMAGE_EVENT_TYPE_PULLED,
+		},
+		&api.ImageEventFilter{
+			Type: api.ImageEventType_IMAGE_EVENT_TYPE_TAGGED,
+		},
+		&api.ImageEventFilter{
+			Type: api.ImageEventType_IMAGE_EVENT_TYPE_UNTAGGED,
+		},
+		&api.ImageEventFilter{
+			Type: api.ImageEventType_IMAGE_EVENT_TYPE_DELETED,
+		},
+	}
+	invalidEvents := []*api.ImageEventFilter{
+		&api.ImageEventFilter{
+			Type: api.ImageEventType_IMAGE_EVENT_TYPE_UNKNOWN,
+		},
+		&api.ImageEventFilter{
+			Type: api.ImageEventType_IMAGE_EVENT_TYPE_TAGGED,
+			FilterExpression: expression.BitwiseAnd(
+				expression.Identifier("asdfa"),
+				expression.Value(make(chan bool))),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	s.registerImageEvents(events)
+	s.registerImageEvents(invalidEvents)
+	verifyImageEventRegistration(t, s, len(events)-1)
+}
+
+func TestRegisterKernelModuleEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	events := []*api.KernelModuleEventFilter{
+		&api.KernelModuleEventFilter{
+			Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD,
+			FilterExpression: expression.Equal(
+				expression.Identifier("name"),
+				expression.Value("nf_conntrack")),
+		},
+		&api.KernelModuleEventFilter{
+			Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD,
+			FilterExpression: expression.Like(
+				expression.Identifier("name"),
+				expression.Value("xt_*")),
+		},
+		&api.KernelModuleEventFilter{
+			Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD,
+		},
+	}
+	invalidEvents := []*api.KernelModuleEventFilter{
+		&api.KernelModuleEventFilter{
+			Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNKNOWN,
+		},
+		&api.KernelModuleEventFilter{
+			Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD,
+			FilterExpression: expression.BitwiseAnd(
+				expression.Identifier("asdfa"),
+				expression.Value(make(chan bool))),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	s.registerKernelModuleEvents(events)
+	s.registerKernelModuleEvents(invalidEvents)
+	verifyKernelModuleEventRegistration(t, s, 2)
+}
+
+func TestRegisterLSMEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	newTestAuditListener(sensor)
+
+	events := []*api.LsmEventFilter{
+		&api.LsmEventFilter{
+			Type: api.LsmEventType_LSM_EVENT_TYPE_DENIAL,
+			FilterExpression: expression.Equal(
+				expression.Identifier("module"),
+				expression.Value(LSMModuleAppArmor)),
+		},
+		&api.LsmEventFilter{
+			Type: api.LsmEventType_LSM_EVENT_TYPE_DENIAL,
+			FilterExpression: expression.Equal(
+				expression.Identifier("object_class"),
+				expression.Value("file")),
+		},
+	}
+	invalidEvents := []*api.LsmEventFilter{
+		&api.LsmEventFilter{
+			Type: api.LsmEventType_LSM_EVENT_TYPE_UNKNOWN,
+		},
+		&api.LsmEventFilter{
+			Type: api.LsmEventType(999),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	s.registerLSMEvents(events)
+	s.registerLSMEvents(invalidEvents)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 2)
+}
+
+func TestRegisterMemoryEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	events := []*api.MemoryEventFilter{
+		&api.MemoryEventFilter{
+			Type: api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP_EXEC,
+			FilterExpression: expression.GreaterThan(
+				expression.Identifier("length"),
+				expression.Value(uint64(4096))),
+		},
+		&api.MemoryEventFilter{
+			Type: api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP_EXEC,
+			FilterExpression: expression.NotEqual(
+				expression.Identifier("addr"),
+				expression.Value(uint64(0))),
+		},
+		&api.MemoryEventFilter{
+			Type: api.MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT_EXEC,
+		},
+	}
+	invalidEvents := []*api.MemoryEventFilter{
+		&api.MemoryEventFilter{
+			Type: api.MemoryEventType_MEMORY_EVENT_TYPE_UNKNOWN,
+		},
+		&api.MemoryEventFilter{
+			Type: api.MemoryEventType(999),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	prepareForRegisterMemoryMmapExecEventFilter(t, s, 0)
+	prepareForRegisterMemoryMprotectExecEventFilter(t, s, 1)
+	s.registerMemoryEvents(events)
+	s.registerMemoryEvents(invalidEvents)
+	assert.Len(t, s.eventSinks, 2)
+	assert.Len(t, s.status, 2)
+}
+
+func TestRegisterMountEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	events := []*api.MountEventFilter{
+		&api.MountEventFilter{
+			Type: api.MountEventType_MOUNT_EVENT_TYPE_MOUNT,
+			FilterExpression: expression.Equal(
+				expression.Identifier("fstype"),
+				expression.Value("proc")),
+		},
+		&api.MountEventFilter{
+			Type: api.MountEventType_MOUNT_EVENT_TYPE_MOUNT,
+			FilterExpression: expression.Equal(
+				expression.Identifier("source"),
+				expression.Value("/var/run/docker.sock")),
+		},
+		&api.MountEventFilter{
+			Type: api.MountEventType_MOUNT_EVENT_TYPE_UNMOUNT,
+		},
+	}
+	invalidEvents := []*api.MountEventFilter{
+		&api.MountEventFilter{
+			Type: api.MountEventType_MOUNT_EVENT_TYPE_UNKNOWN,
+		},
+		&api.MountEventFilter{
+			Type: api.MountEventType_MOUNT_EVENT_TYPE_UNMOUNT,
+			FilterExpression: expression.BitwiseAnd(
+				expression.Identifier("asdfa"),
+				expression.Value(make(chan bool))),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	prepareForRegisterMountEventFilter(t, s, 0)
+	prepareForRegisterUnmountEventFilter(t, s, 1)
+	s.registerMountEvents(events)
+	s.registerMountEvents(invalidEvents)
+	verifyMountEventRegistration(t, s, 2)
+}
+
+func TestRegisterIOUringEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	events := []*api.IoUringEventFilter{
+		&api.IoUringEventFilter{
+			Type: api.IoUringEventType_IO_URING_EVENT_TYPE_SETUP,
+		},
+		&api.IoUringEventFilter{
+			Type: api.IoUringEventType_IO_URING_EVENT_TYPE_SUBMIT,
+			FilterExpression: expression.Equal(
+				expression.Identifier("opcode"),
+				expression.Value(uint8(16))),
+		},
+	}
+	invalidEvents := []*api.IoUringEventFilter{
+		&api.IoUringEventFilter{
+			Type: api.IoUringEventType_IO_URING_EVENT_TYPE_UNKNOWN,
+		},
+		&api.IoUringEventFilter{
+			Type: api.IoUringEventType(999),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	s.registerIOUringEvents(events)
+	s.registerIOUringEvents(invalidEvents)
+	assert.Len(t, s.eventSinks, 2)
+	assert.Len(t, s.status, 2)
 }
 
 func TestRegisterKernelFunctionCallEvents(t *testing.T) {
@@ -382,6 +1274,52 @@ func TestRegisterKernelFunctionCallEvents(t *testing.T) {
 	verifyRegisterKernelFunctionCallEventFilter(t, s, len(events))
 }
 
+func TestRegisterUserFunctionCallEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	events := []*api.UserFunctionCallFilter{
+		&api.UserFunctionCallFilter{
+			Type:       api.UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_ENTER,
+			Executable: "/lib/x86_64-linux-gnu/libc.so.6",
+			Offset:     0x4f440,
+			Arguments: map[string]string{
+				"command": "+0(%di):string",
+			},
+		},
+		&api.UserFunctionCallFilter{
+			Type:       api.UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_EXIT,
+			Executable: "/lib/x86_64-linux-gnu/libc.so.6",
+			Offset:     0x4f440,
+			Arguments: map[string]string{
+				"ret": "$retval:s32",
+			},
+		},
+	}
+	invalidEvents := []*api.UserFunctionCallFilter{
+		&api.UserFunctionCallFilter{
+			Type: api.UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_UNKNOWN,
+		},
+		&api.UserFunctionCallFilter{
+			Type:       api.UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_ENTER,
+			Executable: "/lib/x86_64-linux-gnu/libc.so.6",
+			Offset:     0x4f440,
+			FilterExpression: expression.Equal(
+				expression.Identifier("asdfasdf"),
+				expression.Value(make(chan bool))),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	for x := range events {
+		newUnitTestKprobe(t, sensor, uint64(x), networkKprobeFormat)
+	}
+	s.registerUserFunctionCallEvents(events)
+	s.registerUserFunctionCallEvents(invalidEvents)
+	assert.Len(t, s.eventSinks, len(events))
+	assert.Len(t, s.status, len(invalidEvents))
+}
+
 func TestRegisterNetworkEvents(t *testing.T) {
 	sensor := newUnitTestSensor(t)
 	defer sensor.Stop()
@@ -424,7 +1362,31 @@ func TestRegisterNetworkEvents(t *testing.T) {
 			Type: api.NetworkEventType_NETWORK_EVENT_TYPE_SENDTO_ATTEMPT,
 		},
 		&api.NetworkEventFilter{
-			Type: api.NetworkEventType_NETWORK_EVENT_TYPE_SENDTO_RESULT,
+			Type: api.NetworkEventType_NETWORK_EVENT_TYPE_SENDTO_RESULT,
+		},
+		&api.NetworkEventFilter{
+			Type: api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_CONNECT,
+		},
+		&api.NetworkEventFilter{
+			Type: api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ACCEPT,
+			FilterExpression: expression.Equal(
+				expression.Identifier("local_port"),
+				expression.Value(uint16(443))),
+		},
+		&api.NetworkEventFilter{
+			Type: api.NetworkEventType_NETWORK_EVENT_TYPE_DNS_QUERY,
+		},
+		&api.NetworkEventFilter{
+			Type: api.NetworkEventType_NETWORK_EVENT_TYPE_UNIX_CONNECT,
+		},
+		&api.NetworkEventFilter{
+			Type: api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_LISTEN,
+		},
+		&api.NetworkEventFilter{
+			Type: api.NetworkEventType_NETWORK_EVENT_TYPE_UDP_BIND,
+			FilterExpression: expression.Equal(
+				expression.Identifier("local_port"),
+				expression.Value(uint16(53))),
 		},
 	}
 	invalidEvents := []*api.NetworkEventFilter{
@@ -443,9 +1405,15 @@ func TestRegisterNetworkEvents(t *testing.T) {
 	prepareForRegisterNetworkBindAttemptEventFilter(t, s, 0)
 	prepareForRegisterNetworkConnectAttemptEventFilter(t, s, 1)
 	prepareForRegisterNetworkSendtoAttemptEventFilter(t, s, 2)
+	prepareForRegisterNetworkTCPConnectEventFilter(t, s, 4)
+	prepareForRegisterNetworkTCPAcceptEventFilter(t, s, 6)
+	prepareForRegisterNetworkDNSQueryEventFilter(t, s, 7)
+	prepareForRegisterNetworkUnixConnectEventFilter(t, s, 10)
+	prepareForRegisterNetworkTCPListenEventFilter(t, s, 11)
+	prepareForRegisterNetworkUDPBindEventFilter(t, s, 12)
 	s.registerNetworkEvents(events)
 	s.registerNetworkEvents(invalidEvents)
-	verifyNetworkEventRegistration(t, s, "(telemetry api)", len(events)+6)
+	verifyNetworkEventRegistration(t, s, "(telemetry api)", len(events)+9)
 }
 
 func TestRegisterPerformanceEvents(t *testing.T) {
@@ -525,6 +1493,16 @@ func TestRegisterProcessEvents(t *testing.T) {
 		&api.ProcessEventFilter{
 			Type: api.ProcessEventType_PROCESS_EVENT_TYPE_UPDATE,
 		},
+		&api.ProcessEventFilter{
+			Type:           api.ProcessEventType_PROCESS_EVENT_TYPE_CAPABILITY_CHANGE,
+			CapabilityMask: 1<<CAP_SYS_ADMIN | 1<<CAP_NET_RAW,
+		},
+		&api.ProcessEventFilter{
+			Type: api.ProcessEventType_PROCESS_EVENT_TYPE_CRED_CHANGE,
+			FilterExpression: expression.Equal(
+				expression.Identifier("euid"),
+				expression.Value(uint32(0))),
+		},
 	}
 	eventSet2 := []*api.ProcessEventFilter{
 		/*	FIXME
@@ -558,6 +1536,21 @@ func TestRegisterProcessEvents(t *testing.T) {
 	verifyProcessEventRegistration(t, s, len(eventSet1)+len(eventSet2))
 }
 
+func TestSyscallIDsExpression(t *testing.T) {
+	assert.Nil(t, syscallIDsExpression(nil))
+
+	expr := syscallIDsExpression([]int64{59, 322})
+	expected := expression.LogicalOr(
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(59))),
+		expression.Equal(
+			expression.Identifier("id"),
+			expression.Value(int64(322))))
+	assert.Equal(t, expected, expr)
+	assert.True(t, containsIDFilter(expr))
+}
+
 func TestContainsIDFilter(t *testing.T) {
 	expr := expression.Equal(
 		expression.Identifier("id"),
@@ -600,6 +1593,118 @@ func TestContainsIDFilter(t *testing.T) {
 	assert.True(t, containsIDFilter(expr))
 }
 
+func TestRewriteProcessEventFilter(t *testing.T) {
+	pef := &api.ProcessEventFilter{
+		Type:                        api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+		ExcludeExecFilenames:        []string{"/usr/bin/pause"},
+		ExcludeExecFilenamePatterns: []string{"/opt/probes/*"},
+	}
+	rewriteProcessEventFilter(pef)
+	assert.Nil(t, pef.ExcludeExecFilenames)
+	assert.Nil(t, pef.ExcludeExecFilenamePatterns)
+
+	expr, err := expression.NewExpression(pef.FilterExpression)
+	require.NoError(t, err)
+	require.NoError(t, expr.Validate(ProcessExecEventTypes))
+	assert.Equal(t, `filename != "/usr/bin/pause" AND NOT (filename LIKE "/opt/probes/*")`,
+		expr.String())
+
+	// Negations cannot be evaluated by the kernel
+	assert.Error(t, expr.ValidateKernelFilter())
+
+	tests := map[string]bool{
+		"/bin/sh":              true,
+		"/usr/bin/pause":       false,
+		"/opt/probes/liveness": false,
+	}
+	for filename, expected := range tests {
+		v, err := expr.Evaluate(ProcessExecEventTypes,
+			expression.FieldValueMap{"filename": filename})
+		require.NoError(t, err)
+		assert.Equal(t, expected, v, filename)
+	}
+}
+
+func TestRewriteSignalEventFilter(t *testing.T) {
+	sef := &api.SignalEventFilter{
+		Type:    api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER,
+		Signals: []int32{9, 11},
+	}
+	rewriteSignalEventFilter(sef)
+	assert.Nil(t, sef.Signals)
+
+	expr, err := expression.NewExpression(sef.FilterExpression)
+	require.NoError(t, err)
+	assert.Equal(t, "sig == 9 || sig == 11", expr.KernelFilterString())
+}
+
+func TestRegisterSessionEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	sensor.wtmpPath = newTestWtmpFile(t)
+	defer os.Remove(sensor.wtmpPath)
+
+	events := []*api.SessionEventFilter{
+		&api.SessionEventFilter{
+			Type: api.SessionEventType_SESSION_EVENT_TYPE_LOGIN,
+			FilterExpression: expression.Equal(
+				expression.Identifier("user"),
+				expression.Value("root")),
+		},
+		&api.SessionEventFilter{
+			Type: api.SessionEventType_SESSION_EVENT_TYPE_LOGOUT,
+		},
+	}
+	invalidEvents := []*api.SessionEventFilter{
+		&api.SessionEventFilter{
+			Type: api.SessionEventType_SESSION_EVENT_TYPE_UNKNOWN,
+		},
+		&api.SessionEventFilter{
+			Type: api.SessionEventType(999),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	s.registerSessionEvents(events)
+	s.registerSessionEvents(invalidEvents)
+	assert.Len(t, s.eventSinks, 2)
+	assert.Len(t, s.status, 2)
+}
+
+func TestRegisterSignalEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	events := []*api.SignalEventFilter{
+		&api.SignalEventFilter{
+			Type:    api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE,
+			Signals: []int32{9},
+		},
+		&api.SignalEventFilter{
+			Type:    api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER,
+			Signals: []int32{9, 11},
+			FilterExpression: expression.Equal(
+				expression.Identifier("code"),
+				expression.Value(int32(1))),
+		},
+	}
+	invalidEvents := []*api.SignalEventFilter{
+		&api.SignalEventFilter{
+			Type: api.SignalEventType_SIGNAL_EVENT_TYPE_UNKNOWN,
+		},
+		&api.SignalEventFilter{
+			Type: api.SignalEventType(999),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	s.registerSignalEvents(events)
+	s.registerSignalEvents(invalidEvents)
+	assert.Len(t, s.eventSinks, 2)
+	assert.Len(t, s.status, 2)
+}
+
 func TestRegisterSyscallEvents(t *testing.T) {
 	sensor := newUnitTestSensor(t)
 	defer sensor.Stop()
@@ -623,6 +1728,24 @@ func TestRegisterSyscallEvents(t *testing.T) {
 			Ret:  &wrappers.Int64Value{Value: 0},
 		},
 	}
+	rawEvents := []*api.SyscallEventFilter{
+		&api.SyscallEventFilter{
+			Type:     api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+			Ids:      []int64{101, 310},
+			ArgCount: 2,
+			FilterExpression: expression.Equal(
+				expression.Identifier("arg0"),
+				expression.Value(uint64(16))),
+		},
+		&api.SyscallEventFilter{
+			Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_ENTER,
+			Ids:  []int64{321},
+		},
+		&api.SyscallEventFilter{
+			Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_EXIT,
+			Ids:  []int64{321},
+		},
+	}
 	invalidEvents := []*api.SyscallEventFilter{
 		&api.SyscallEventFilter{
 			Type: api.SyscallEventType_SYSCALL_EVENT_TYPE_UNKNOWN,
@@ -659,6 +1782,11 @@ func TestRegisterSyscallEvents(t *testing.T) {
 	s.registerSyscallEvents(exitEvents)
 	verifyRegisterSyscallExitEventFilter(t, s, len(exitEvents))
 
+	s = newTestSubscription(t, sensor)
+	s.registerSyscallEvents(rawEvents)
+	assert.Len(t, s.eventSinks, 2)
+	assert.Len(t, s.status, 0)
+
 	s = newTestSubscription(t, sensor)
 	s.registerSyscallEvents(invalidEvents)
 	assert.Len(t, s.eventSinks, 0)
@@ -682,6 +1810,43 @@ func TestRegisterTickerEvents(t *testing.T) {
 	verifyRegisterTickerEventFilter(t, s, len(events))
 }
 
+func TestRegisterTTYEvents(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	events := []*api.TtyEventFilter{
+		&api.TtyEventFilter{
+			Type: api.TtyEventType_TTY_EVENT_TYPE_READ,
+			FilterExpression: expression.GreaterThan(
+				expression.Identifier("length"),
+				expression.Value(uint64(1))),
+		},
+		&api.TtyEventFilter{
+			Type:        api.TtyEventType_TTY_EVENT_TYPE_READ,
+			IncludeData: true,
+		},
+	}
+	invalidEvents := []*api.TtyEventFilter{
+		&api.TtyEventFilter{
+			Type: api.TtyEventType_TTY_EVENT_TYPE_UNKNOWN,
+		},
+		&api.TtyEventFilter{
+			Type: api.TtyEventType(999),
+		},
+	}
+
+	s := newTestSubscription(t, sensor)
+	prepareForRegisterTTYReadEventFilter(t, s, 0)
+	s.registerTTYEvents(events)
+	s.registerTTYEvents(invalidEvents)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 2)
+	for _, es := range s.eventSinks {
+		// One filter has no expression, so all reads are included
+		assert.Nil(t, es.filter)
+	}
+}
+
 func TestNewTelemetryEvent(t *testing.T) {
 	data := TelemetryEventData{
 		EventID:        "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz012345678./",
@@ -690,9 +1855,13 @@ func TestNewTelemetryEvent(t *testing.T) {
 		ProcessID:      "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ012345678./",
 		PID:            872364,
 		TGID:           28734,
+		NamespacePID:   12,
+		NamespaceTGID:  1,
 		CPU:            3,
 		HasCredentials: true,
 		Credentials:    Cred{12, 34, 56, 78, 90, 98, 76, 54},
+		HasNamespaces:  true,
+		Namespaces:     proc.Namespaces{Net: 1, Mnt: 2, PID: 3, User: 4},
 		Container: ContainerInfo{
 			ID:         "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
 			Name:       "capsule8-sensor-container",
@@ -720,6 +1889,10 @@ func TestNewTelemetryEvent(t *testing.T) {
 	assert.Equal(t, data.Container.ImageID, e.ImageId)
 	assert.Equal(t, data.Container.ImageName, e.ImageName)
 	assert.Equal(t, data.CPU, uint32(e.Cpu))
+	assert.Equal(t, data.NamespacePID, int(e.ProcessNamespacePid))
+	assert.Equal(t, data.NamespaceTGID, int(e.ProcessNamespaceTgid))
+	assert.Equal(t, &api.Namespaces{Net: 1, Mnt: 2, Pid: 3, User: 4},
+		e.Namespaces)
 	assert.Equal(t, data.Credentials.UID, e.Credentials.Uid)
 	assert.Equal(t, data.Credentials.GID, e.Credentials.Gid)
 	assert.Equal(t, data.Credentials.EUID, e.Credentials.Euid)
@@ -802,6 +1975,249 @@ func TestTranslateNetworkAddress(t *testing.T) {
 	}
 }
 
+func TestTranslateRingBufferPages(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	sub := &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{Interval: 374},
+			},
+		},
+		RingBufferPages: 128,
+	}
+	s := newTestSubscription(t, sensor)
+	s.translateTelemetryServiceSubscription(sub)
+	assert.Equal(t, 128, s.ringBufferNumPages)
+	assert.Len(t, s.status, 0)
+
+	// The kernel only accepts powers of 2
+	sub.RingBufferPages = 100
+	s = newTestSubscription(t, sensor)
+	s.translateTelemetryServiceSubscription(sub)
+	assert.Equal(t, 0, s.ringBufferNumPages)
+	assert.Len(t, s.status, 1)
+}
+
+func TestTranslateContainerCgroups(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	info := sensor.ContainerCache.LookupContainer("abc", true)
+	info.Pid = 76989
+	info.State = ContainerStateRunning
+
+	sub := &api.Subscription{
+		EventFilter: &api.EventFilter{
+			TickerEvents: []*api.TickerEventFilter{
+				&api.TickerEventFilter{Interval: 374},
+			},
+		},
+		ContainerFilter: &api.ContainerFilter{
+			Ids: []string{"abc"},
+		},
+	}
+
+	// Disabled by default
+	s := newTestSubscription(t, sensor)
+	s.translateTelemetryServiceSubscription(sub)
+	assert.Nil(t, s.cgroups)
+
+	sensor.useContainerCgroups = true
+	s = newTestSubscription(t, sensor)
+	s.translateTelemetryServiceSubscription(sub)
+	assert.Equal(t, []string{"/"}, s.cgroups)
+
+	// Containers that aren't running fall back to filtering in the sensor
+	info.State = ContainerStateExited
+	s = newTestSubscription(t, sensor)
+	s.translateTelemetryServiceSubscription(sub)
+	assert.Nil(t, s.cgroups)
+	assert.NotNil(t, s.containerFilter)
+	info.State = ContainerStateRunning
+
+	// Names can't be mapped to cgroups
+	sub.ContainerFilter.Names = []string{"/ecstatic_darwin"}
+	s = newTestSubscription(t, sensor)
+	s.translateTelemetryServiceSubscription(sub)
+	assert.Nil(t, s.cgroups)
+}
+
+func TestTranslateDisabledEventFilters(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	disabled, err := newDisabledEventFilters(nil,
+		[]string{"container_events", "tty_events"})
+	require.NoError(t, err)
+	sensor.disabledEventFilters = disabled
+
+	sub := &api.Subscription{
+		EventFilter: &api.EventFilter{
+			ContainerEvents: []*api.ContainerEventFilter{
+				&api.ContainerEventFilter{
+					Type: api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED,
+				},
+			},
+		},
+	}
+	s := newTestSubscription(t, sensor)
+	s.translateTelemetryServiceSubscription(sub)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Equal(t, []string{
+		"Ignoring container_events (disabled by the Sensor)",
+	}, s.status)
+	assert.Len(t, sub.EventFilter.ContainerEvents, 1)
+
+	sub.EventFilter.TickerEvents = []*api.TickerEventFilter{
+		&api.TickerEventFilter{Interval: int64(time.Hour)},
+	}
+	s = newTestSubscription(t, sensor)
+	s.translateTelemetryServiceSubscription(sub)
+	assert.NotZero(t, len(s.eventSinks))
+	assert.Len(t, s.status, 1)
+	s.Close()
+}
+
+func TestTranslateMultipleEventClasses(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	containerEvents := []*api.ContainerEventFilter{
+		&api.ContainerEventFilter{
+			Type: api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED,
+		},
+	}
+	processEvents := []*api.ProcessEventFilter{
+		&api.ProcessEventFilter{
+			Type: api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+		},
+	}
+	tickerEvents := []*api.TickerEventFilter{
+		&api.TickerEventFilter{Interval: int64(time.Hour)},
+	}
+
+	// A subscription carrying every class registers the union of what
+	// each class registers by itself.
+	expected := 0
+	for _, f := range []*api.EventFilter{
+		&api.EventFilter{ContainerEvents: containerEvents},
+		&api.EventFilter{ProcessEvents: processEvents},
+		&api.EventFilter{TickerEvents: tickerEvents},
+	} {
+		s := newTestSubscription(t, sensor)
+		s.translateTelemetryServiceSubscription(&api.Subscription{
+			EventFilter: f,
+		})
+		require.Len(t, s.status, 0)
+		require.NotZero(t, len(s.eventSinks))
+		expected += len(s.eventSinks)
+		s.Close()
+	}
+
+	s := newTestSubscription(t, sensor)
+	s.translateTelemetryServiceSubscription(&api.Subscription{
+		EventFilter: &api.EventFilter{
+			ContainerEvents: containerEvents,
+			ProcessEvents:   processEvents,
+			TickerEvents:    tickerEvents,
+		},
+	})
+	assert.Len(t, s.status, 0)
+	assert.Len(t, s.eventSinks, expected)
+	s.Close()
+
+	// Events of different classes are delivered to a single dispatch
+	// function in the order that the sensor dispatches them.
+	containerID := sensor.Monitor().RegisterExternalEvent("container test", nil)
+	processID := sensor.Monitor().RegisterExternalEvent("process test", nil)
+
+	s = newTestSubscription(t, sensor)
+	_, err := s.addEventSink(containerID, nil, nil)
+	require.NoError(t, err)
+	_, err = s.addEventSink(processID, nil, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var events []TelemetryEvent
+	_, err = s.Run(ctx, func(e TelemetryEvent) {
+		events = append(events, e)
+	})
+	require.NoError(t, err)
+
+	created := ContainerCreatedTelemetryEvent{}
+	created.MonotimeNanos = 1
+	exec := ProcessExecTelemetryEvent{}
+	exec.MonotimeNanos = 2
+	sensor.dispatchQueuedSamples([]perf.EventMonitorSample{
+		perf.EventMonitorSample{EventID: containerID, DecodedSample: created},
+		perf.EventMonitorSample{EventID: processID, DecodedSample: exec},
+	})
+	assert.Equal(t, []TelemetryEvent{created, exec}, events)
+}
+
+func TestTranslateCallchain(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	sensor.kernelSymbolsOnce.Do(func() {
+		sensor.kernelSymbols = []proc.KernelSymbol{
+			proc.KernelSymbol{Address: 0xffffffff81200000, Name: "do_init_module"},
+			proc.KernelSymbol{Address: 0xffffffffc0400000, Name: "nf_hook", Module: "nf_tables"},
+		}
+	})
+
+	callchain := []uint64{
+		perf.PERF_CONTEXT_KERNEL,
+		0xffffffff81200010,
+		0xffffffffc0400020,
+		0xffffffff80000000,
+		perf.PERF_CONTEXT_USER,
+		0x7f0012345678,
+		0x401000,
+		perf.PERF_CONTEXT_GUEST,
+		0x1234,
+	}
+	expected := &api.StackTrace{
+		KernelFrames: []*api.StackFrame{
+			&api.StackFrame{
+				Address: 0xffffffff81200010,
+				Symbol:  "do_init_module",
+				Offset:  0x10,
+			},
+			&api.StackFrame{
+				Address: 0xffffffffc0400020,
+				Symbol:  "nf_hook",
+				Offset:  0x20,
+				Module:  "nf_tables",
+			},
+			&api.StackFrame{Address: 0xffffffff80000000},
+		},
+		UserFrames: []*api.StackFrame{
+			&api.StackFrame{Address: 0x7f0012345678},
+			&api.StackFrame{Address: 0x401000},
+		},
+	}
+	assert.Equal(t, expected, sensor.translateCallchain(callchain))
+
+	// Stack traces are only included for subscriptions that want them
+	e := KernelModuleLoadTelemetryEvent{
+		TelemetryEventData: TelemetryEventData{Callchain: callchain},
+		Name:               "nf_tables",
+	}
+	sub := newTestSubscription(t, sensor)
+	assert.Nil(t, sub.translateEvent(e).StackTrace)
+
+	sub.translateTelemetryServiceSubscription(&api.Subscription{
+		EventFilter:        &api.EventFilter{},
+		CaptureStackTraces: true,
+	})
+	assert.Equal(t, expected, sub.translateEvent(e).StackTrace)
+}
+
 func TestTranslateEvent(t *testing.T) {
 	sensor := newUnitTestSensor(t)
 	defer sensor.Stop()
@@ -816,22 +2232,136 @@ func TestTranslateEvent(t *testing.T) {
 	testCases := []testCase{
 		// Chargen
 		testCase{
-			event: ChargenTelemetryEvent{
-				Index:      65,
-				Characters: "abcdefghijklmnopqrstuvwxyz",
+			event: ChargenTelemetryEvent{
+				Index:      65,
+				Characters: "abcdefghijklmnopqrstuvwxyz",
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Chargen{
+					Chargen: &api.ChargenEvent{
+						Index:      65,
+						Characters: "abcdefghijklmnopqrstuvwxyz",
+					},
+				},
+			},
+		},
+		// ContainerCreated
+		testCase{
+			event: ContainerCreatedTelemetryEvent{
+				TelemetryEventData{
+					Container: ContainerInfo{
+						ID:          "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
+						Name:        "capsule8-sensor-container",
+						ImageID:     "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
+						ImageName:   "capsule8-sensor-image",
+						ImageDigest: "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+						ImageLabels: map[string]string{"maintainer": "someone"},
+						Pid:         872364,
+						ExitCode:    255,
+						Runtime:     ContainerRuntimeDocker,
+						State:       ContainerStateRunning,
+						JSONConfig:  "This is the JSON config that isn't actually JSON",
+						OCIConfig:   "This is the OCI config that isn't real",
+					},
+				},
+			},
+			expected: &api.TelemetryEvent{
+				ContainerId:   "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
+				ContainerName: "capsule8-sensor-container",
+				ImageId:       "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
+				ImageName:     "capsule8-sensor-image",
+				Event: &api.TelemetryEvent_Container{
+					Container: &api.ContainerEvent{
+						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED,
+						Name:             "capsule8-sensor-container",
+						ImageId:          "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
+						ImageName:        "capsule8-sensor-image",
+						ImageDigest:      "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+						ImageLabels:      map[string]string{"maintainer": "someone"},
+						HostPid:          872364,
+						DockerConfigJson: "This is the JSON config that isn't actually JSON",
+						OciConfigJson:    "This is the OCI config that isn't real",
+					},
+				},
+			},
+		},
+		// ContainerDestroyed
+		testCase{
+			event: ContainerDestroyedTelemetryEvent{
+				TelemetryEventData{
+					Container: ContainerInfo{
+						ID:         "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
+						Name:       "capsule8-sensor-container",
+						ImageID:    "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
+						ImageName:  "capsule8-sensor-image",
+						Pid:        872364,
+						ExitCode:   255,
+						Runtime:    ContainerRuntimeDocker,
+						State:      ContainerStateRunning,
+						JSONConfig: "This is the JSON config that isn't actually JSON",
+						OCIConfig:  "This is the OCI config that isn't real",
+					},
+				},
+			},
+			expected: &api.TelemetryEvent{
+				ContainerId:   "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
+				ContainerName: "capsule8-sensor-container",
+				ImageId:       "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
+				ImageName:     "capsule8-sensor-image",
+				Event: &api.TelemetryEvent_Container{
+					Container: &api.ContainerEvent{
+						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_DESTROYED,
+						Name:             "capsule8-sensor-container",
+						ImageId:          "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
+						ImageName:        "capsule8-sensor-image",
+						HostPid:          872364,
+						DockerConfigJson: "This is the JSON config that isn't actually JSON",
+						OciConfigJson:    "This is the OCI config that isn't real",
+					},
+				},
+			},
+		},
+		// ContainerExited (WaitStatus.Exited)
+		testCase{
+			event: ContainerExitedTelemetryEvent{
+				TelemetryEventData{
+					Container: ContainerInfo{
+						ID:         "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
+						Name:       "capsule8-sensor-container",
+						ImageID:    "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
+						ImageName:  "capsule8-sensor-image",
+						Pid:        872364,
+						ExitCode:   88 << 8,
+						Runtime:    ContainerRuntimeDocker,
+						State:      ContainerStateRunning,
+						JSONConfig: "This is the JSON config that isn't actually JSON",
+						OCIConfig:  "This is the OCI config that isn't real",
+					},
+				},
 			},
 			expected: &api.TelemetryEvent{
-				Event: &api.TelemetryEvent_Chargen{
-					Chargen: &api.ChargenEvent{
-						Index:      65,
-						Characters: "abcdefghijklmnopqrstuvwxyz",
+				ContainerId:   "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
+				ContainerName: "capsule8-sensor-container",
+				ImageId:       "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
+				ImageName:     "capsule8-sensor-image",
+				Event: &api.TelemetryEvent_Container{
+					Container: &api.ContainerEvent{
+						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_EXITED,
+						Name:             "capsule8-sensor-container",
+						ImageId:          "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
+						ImageName:        "capsule8-sensor-image",
+						HostPid:          872364,
+						ExitCode:         88 << 8,
+						ExitStatus:       88,
+						DockerConfigJson: "This is the JSON config that isn't actually JSON",
+						OciConfigJson:    "This is the OCI config that isn't real",
 					},
 				},
 			},
 		},
-		// ContainerCreated
+		// ContainerExited (WaitStatus.Signaled SIGSEGV w/ CoreDump)
 		testCase{
-			event: ContainerCreatedTelemetryEvent{
+			event: ContainerExitedTelemetryEvent{
 				TelemetryEventData{
 					Container: ContainerInfo{
 						ID:         "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
@@ -839,7 +2369,7 @@ func TestTranslateEvent(t *testing.T) {
 						ImageID:    "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:  "capsule8-sensor-image",
 						Pid:        872364,
-						ExitCode:   255,
+						ExitCode:   int(unix.SIGSEGV) | 0x80,
 						Runtime:    ContainerRuntimeDocker,
 						State:      ContainerStateRunning,
 						JSONConfig: "This is the JSON config that isn't actually JSON",
@@ -854,20 +2384,23 @@ func TestTranslateEvent(t *testing.T) {
 				ImageName:     "capsule8-sensor-image",
 				Event: &api.TelemetryEvent_Container{
 					Container: &api.ContainerEvent{
-						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_CREATED,
+						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_EXITED,
 						Name:             "capsule8-sensor-container",
 						ImageId:          "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:        "capsule8-sensor-image",
 						HostPid:          872364,
+						ExitCode:         int32(unix.SIGSEGV) | 0x80,
+						ExitSignal:       uint32(unix.SIGSEGV),
+						ExitCoreDumped:   true,
 						DockerConfigJson: "This is the JSON config that isn't actually JSON",
 						OciConfigJson:    "This is the OCI config that isn't real",
 					},
 				},
 			},
 		},
-		// ContainerDestroyed
+		// ContainerRunning
 		testCase{
-			event: ContainerDestroyedTelemetryEvent{
+			event: ContainerRunningTelemetryEvent{
 				TelemetryEventData{
 					Container: ContainerInfo{
 						ID:         "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
@@ -890,7 +2423,7 @@ func TestTranslateEvent(t *testing.T) {
 				ImageName:     "capsule8-sensor-image",
 				Event: &api.TelemetryEvent_Container{
 					Container: &api.ContainerEvent{
-						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_DESTROYED,
+						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_RUNNING,
 						Name:             "capsule8-sensor-container",
 						ImageId:          "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:        "capsule8-sensor-image",
@@ -901,9 +2434,9 @@ func TestTranslateEvent(t *testing.T) {
 				},
 			},
 		},
-		// ContainerExited (WaitStatus.Exited)
+		// ContainerUpdated
 		testCase{
-			event: ContainerExitedTelemetryEvent{
+			event: ContainerUpdatedTelemetryEvent{
 				TelemetryEventData{
 					Container: ContainerInfo{
 						ID:         "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
@@ -911,7 +2444,7 @@ func TestTranslateEvent(t *testing.T) {
 						ImageID:    "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:  "capsule8-sensor-image",
 						Pid:        872364,
-						ExitCode:   88 << 8,
+						ExitCode:   255,
 						Runtime:    ContainerRuntimeDocker,
 						State:      ContainerStateRunning,
 						JSONConfig: "This is the JSON config that isn't actually JSON",
@@ -926,22 +2459,20 @@ func TestTranslateEvent(t *testing.T) {
 				ImageName:     "capsule8-sensor-image",
 				Event: &api.TelemetryEvent_Container{
 					Container: &api.ContainerEvent{
-						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_EXITED,
+						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_UPDATED,
 						Name:             "capsule8-sensor-container",
 						ImageId:          "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:        "capsule8-sensor-image",
 						HostPid:          872364,
-						ExitCode:         88 << 8,
-						ExitStatus:       88,
 						DockerConfigJson: "This is the JSON config that isn't actually JSON",
 						OciConfigJson:    "This is the OCI config that isn't real",
 					},
 				},
 			},
 		},
-		// ContainerExited (WaitStatus.Signaled SIGSEGV w/ CoreDump)
+		// ContainerPaused
 		testCase{
-			event: ContainerExitedTelemetryEvent{
+			event: ContainerPausedTelemetryEvent{
 				TelemetryEventData{
 					Container: ContainerInfo{
 						ID:         "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
@@ -949,9 +2480,8 @@ func TestTranslateEvent(t *testing.T) {
 						ImageID:    "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:  "capsule8-sensor-image",
 						Pid:        872364,
-						ExitCode:   int(unix.SIGSEGV) | 0x80,
 						Runtime:    ContainerRuntimeDocker,
-						State:      ContainerStateRunning,
+						State:      ContainerStatePaused,
 						JSONConfig: "This is the JSON config that isn't actually JSON",
 						OCIConfig:  "This is the OCI config that isn't real",
 					},
@@ -964,23 +2494,20 @@ func TestTranslateEvent(t *testing.T) {
 				ImageName:     "capsule8-sensor-image",
 				Event: &api.TelemetryEvent_Container{
 					Container: &api.ContainerEvent{
-						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_EXITED,
+						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_PAUSED,
 						Name:             "capsule8-sensor-container",
 						ImageId:          "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:        "capsule8-sensor-image",
 						HostPid:          872364,
-						ExitCode:         int32(unix.SIGSEGV) | 0x80,
-						ExitSignal:       uint32(unix.SIGSEGV),
-						ExitCoreDumped:   true,
 						DockerConfigJson: "This is the JSON config that isn't actually JSON",
 						OciConfigJson:    "This is the OCI config that isn't real",
 					},
 				},
 			},
 		},
-		// ContainerRunning
+		// ContainerResumed
 		testCase{
-			event: ContainerRunningTelemetryEvent{
+			event: ContainerResumedTelemetryEvent{
 				TelemetryEventData{
 					Container: ContainerInfo{
 						ID:         "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
@@ -988,7 +2515,6 @@ func TestTranslateEvent(t *testing.T) {
 						ImageID:    "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:  "capsule8-sensor-image",
 						Pid:        872364,
-						ExitCode:   255,
 						Runtime:    ContainerRuntimeDocker,
 						State:      ContainerStateRunning,
 						JSONConfig: "This is the JSON config that isn't actually JSON",
@@ -1003,7 +2529,7 @@ func TestTranslateEvent(t *testing.T) {
 				ImageName:     "capsule8-sensor-image",
 				Event: &api.TelemetryEvent_Container{
 					Container: &api.ContainerEvent{
-						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_RUNNING,
+						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_RESUMED,
 						Name:             "capsule8-sensor-container",
 						ImageId:          "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:        "capsule8-sensor-image",
@@ -1014,9 +2540,9 @@ func TestTranslateEvent(t *testing.T) {
 				},
 			},
 		},
-		// ContainerUpdated
+		// ContainerHealth
 		testCase{
-			event: ContainerUpdatedTelemetryEvent{
+			event: ContainerHealthTelemetryEvent{
 				TelemetryEventData{
 					Container: ContainerInfo{
 						ID:         "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ./",
@@ -1024,9 +2550,9 @@ func TestTranslateEvent(t *testing.T) {
 						ImageID:    "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:  "capsule8-sensor-image",
 						Pid:        872364,
-						ExitCode:   255,
 						Runtime:    ContainerRuntimeDocker,
 						State:      ContainerStateRunning,
+						Health:     "unhealthy",
 						JSONConfig: "This is the JSON config that isn't actually JSON",
 						OCIConfig:  "This is the OCI config that isn't real",
 					},
@@ -1039,17 +2565,200 @@ func TestTranslateEvent(t *testing.T) {
 				ImageName:     "capsule8-sensor-image",
 				Event: &api.TelemetryEvent_Container{
 					Container: &api.ContainerEvent{
-						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_UPDATED,
+						Type:             api.ContainerEventType_CONTAINER_EVENT_TYPE_HEALTH,
 						Name:             "capsule8-sensor-container",
 						ImageId:          "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./",
 						ImageName:        "capsule8-sensor-image",
 						HostPid:          872364,
+						HealthStatus:     "unhealthy",
 						DockerConfigJson: "This is the JSON config that isn't actually JSON",
 						OciConfigJson:    "This is the OCI config that isn't real",
 					},
 				},
 			},
 		},
+		// ImagePulled
+		testCase{
+			event: ImagePulledTelemetryEvent{
+				TelemetryEventData: TelemetryEventData{},
+				Image: ImageInfo{
+					ID:          "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b",
+					Name:        "bash:latest",
+					Registry:    "docker.io",
+					Digest:      "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+					RepoTags:    []string{"bash:latest"},
+					RepoDigests: []string{"bash@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424"},
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Image{
+					Image: &api.ImageEvent{
+						Type:        api.ImageEventType_IMAGE_EVENT_TYPE_PULLED,
+						ImageId:     "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b",
+						ImageName:   "bash:latest",
+						Registry:    "docker.io",
+						Digest:      "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+						RepoTags:    []string{"bash:latest"},
+						RepoDigests: []string{"bash@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424"},
+					},
+				},
+			},
+		},
+		// ImageTagged
+		testCase{
+			event: ImageTaggedTelemetryEvent{
+				TelemetryEventData: TelemetryEventData{},
+				Image: ImageInfo{
+					ID:          "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b",
+					Name:        "bash:latest",
+					Registry:    "docker.io",
+					Digest:      "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+					RepoTags:    []string{"bash:latest"},
+					RepoDigests: []string{"bash@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424"},
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Image{
+					Image: &api.ImageEvent{
+						Type:        api.ImageEventType_IMAGE_EVENT_TYPE_TAGGED,
+						ImageId:     "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b",
+						ImageName:   "bash:latest",
+						Registry:    "docker.io",
+						Digest:      "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+						RepoTags:    []string{"bash:latest"},
+						RepoDigests: []string{"bash@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424"},
+					},
+				},
+			},
+		},
+		// ImageUntagged
+		testCase{
+			event: ImageUntaggedTelemetryEvent{
+				TelemetryEventData: TelemetryEventData{},
+				Image: ImageInfo{
+					ID:          "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b",
+					Name:        "bash:latest",
+					Registry:    "docker.io",
+					Digest:      "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+					RepoTags:    []string{"bash:latest"},
+					RepoDigests: []string{"bash@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424"},
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Image{
+					Image: &api.ImageEvent{
+						Type:        api.ImageEventType_IMAGE_EVENT_TYPE_UNTAGGED,
+						ImageId:     "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b",
+						ImageName:   "bash:latest",
+						Registry:    "docker.io",
+						Digest:      "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+						RepoTags:    []string{"bash:latest"},
+						RepoDigests: []string{"bash@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424"},
+					},
+				},
+			},
+		},
+		// ImageDeleted
+		testCase{
+			event: ImageDeletedTelemetryEvent{
+				TelemetryEventData: TelemetryEventData{},
+				Image: ImageInfo{
+					ID:          "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b",
+					Name:        "bash:latest",
+					Registry:    "docker.io",
+					Digest:      "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+					RepoTags:    []string{"bash:latest"},
+					RepoDigests: []string{"bash@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424"},
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Image{
+					Image: &api.ImageEvent{
+						Type:        api.ImageEventType_IMAGE_EVENT_TYPE_DELETED,
+						ImageId:     "59507b30b48ad1faa1fa804b635b1fe0d17c60315722d622d1ed89ca1481192b",
+						ImageName:   "bash:latest",
+						Registry:    "docker.io",
+						Digest:      "sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424",
+						RepoTags:    []string{"bash:latest"},
+						RepoDigests: []string{"bash@sha256:d85914d547a6c92faa39ce7058bd7529baacab7e0cd4255442b04577c4d1f424"},
+					},
+				},
+			},
+		},
+		// BPFProgramLoad
+		testCase{
+			event: BPFProgramLoadTelemetryEvent{
+				ProgramType:      2,
+				ProgramName:      "kprobe_execve",
+				InstructionCount: 64,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Bpf{
+					Bpf: &api.BpfEvent{
+						Type:                 api.BpfEventType_BPF_EVENT_TYPE_PROGRAM_LOAD,
+						LoadProgramType:      2,
+						LoadProgramName:      "kprobe_execve",
+						LoadInstructionCount: 64,
+					},
+				},
+			},
+		},
+		// BPFProgramAttach
+		testCase{
+			event: BPFProgramAttachTelemetryEvent{
+				AttachType: 1,
+				ProgramFD:  4,
+				TargetFD:   3,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Bpf{
+					Bpf: &api.BpfEvent{
+						Type:            api.BpfEventType_BPF_EVENT_TYPE_PROGRAM_ATTACH,
+						AttachType:      1,
+						AttachProgramFd: 4,
+						AttachTargetFd:  3,
+					},
+				},
+			},
+		},
+		// IOUringSetup
+		testCase{
+			event: IOUringSetupTelemetryEvent{
+				FD:        5,
+				SQEntries: 256,
+				CQEntries: 512,
+				Flags:     2,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_IoUring{
+					IoUring: &api.IoUringEvent{
+						Type:           api.IoUringEventType_IO_URING_EVENT_TYPE_SETUP,
+						SetupFd:        5,
+						SetupSqEntries: 256,
+						SetupCqEntries: 512,
+						SetupFlags:     2,
+					},
+				},
+			},
+		},
+		// IOUringSubmit
+		testCase{
+			event: IOUringSubmitTelemetryEvent{
+				Opcode:   16,
+				UserData: 0xdeadbeef,
+				SQThread: true,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_IoUring{
+					IoUring: &api.IoUringEvent{
+						Type:           api.IoUringEventType_IO_URING_EVENT_TYPE_SUBMIT,
+						SubmitOpcode:   16,
+						SubmitUserData: 0xdeadbeef,
+						SubmitSqThread: true,
+					},
+				},
+			},
+		},
 		// FileOpen
 		testCase{
 			event: FileOpenTelemetryEvent{
@@ -1068,9 +2777,172 @@ func TestTranslateEvent(t *testing.T) {
 				},
 			},
 		},
+		// FileWrite
+		testCase{
+			event: FileWriteTelemetryEvent{
+				Filename: "/path/to/foo.bar",
+				Count:    4096,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_File{
+					File: &api.FileEvent{
+						Type:       api.FileEventType_FILE_EVENT_TYPE_WRITE,
+						Filename:   "/path/to/foo.bar",
+						WriteCount: 4096,
+					},
+				},
+			},
+		},
+		// FileMemfdCreate
+		testCase{
+			event: FileMemfdCreateTelemetryEvent{
+				Filename: "payload",
+				Flags:    1,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_File{
+					File: &api.FileEvent{
+						Type:       api.FileEventType_FILE_EVENT_TYPE_MEMFD_CREATE,
+						Filename:   "payload",
+						MemfdFlags: 1,
+					},
+				},
+			},
+		},
+		// KernelModuleLoad
+		testCase{
+			event: KernelModuleLoadTelemetryEvent{
+				Name: "nf_conntrack",
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_KernelModule{
+					KernelModule: &api.KernelModuleEvent{
+						Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_LOAD,
+						Name: "nf_conntrack",
+					},
+				},
+			},
+		},
+		// KernelModuleUnload
+		testCase{
+			event: KernelModuleUnloadTelemetryEvent{
+				Name: "nf_conntrack",
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_KernelModule{
+					KernelModule: &api.KernelModuleEvent{
+						Type: api.KernelModuleEventType_KERNEL_MODULE_EVENT_TYPE_UNLOAD,
+						Name: "nf_conntrack",
+					},
+				},
+			},
+		},
+		// LSMDenial
+		testCase{
+			event: LSMDenialTelemetryEvent{
+				Module:      LSMModuleSELinux,
+				Operation:   "read",
+				Subject:     "system_u:system_r:container_t:s0:c1,c2",
+				Object:      "system_u:object_r:shadow_t:s0",
+				ObjectClass: "file",
+				Name:        "shadow",
+				Message:     "avc:  denied  { read }",
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Lsm{
+					Lsm: &api.LsmEvent{
+						Type:        api.LsmEventType_LSM_EVENT_TYPE_DENIAL,
+						Module:      LSMModuleSELinux,
+						Operation:   "read",
+						Subject:     "system_u:system_r:container_t:s0:c1,c2",
+						Object:      "system_u:object_r:shadow_t:s0",
+						ObjectClass: "file",
+						Name:        "shadow",
+						Message:     "avc:  denied  { read }",
+					},
+				},
+			},
+		},
+		// MemoryMmapExec
+		testCase{
+			event: MemoryMmapExecTelemetryEvent{
+				Address: 0x7f0000000000,
+				Length:  4096,
+				Prot:    7,
+				Flags:   0x22,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Memory{
+					Memory: &api.MemoryEvent{
+						Type:    api.MemoryEventType_MEMORY_EVENT_TYPE_MMAP_EXEC,
+						Address: 0x7f0000000000,
+						Length:  4096,
+						Prot:    7,
+						Flags:   0x22,
+					},
+				},
+			},
+		},
+		// MemoryMprotectExec
+		testCase{
+			event: MemoryMprotectExecTelemetryEvent{
+				Address: 0x7f0000000000,
+				Length:  8192,
+				Prot:    5,
+				OldProt: 3,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Memory{
+					Memory: &api.MemoryEvent{
+						Type:    api.MemoryEventType_MEMORY_EVENT_TYPE_MPROTECT_EXEC,
+						Address: 0x7f0000000000,
+						Length:  8192,
+						Prot:    5,
+						OldProt: 3,
+					},
+				},
+			},
+		},
+		// Mount
+		testCase{
+			event: MountTelemetryEvent{
+				Source: "proc",
+				Target: "/mnt/proc",
+				FSType: "proc",
+				Flags:  6,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Mount{
+					Mount: &api.MountEvent{
+						Type:   api.MountEventType_MOUNT_EVENT_TYPE_MOUNT,
+						Source: "proc",
+						Target: "/mnt/proc",
+						Fstype: "proc",
+						Flags:  6,
+					},
+				},
+			},
+		},
+		// Unmount
+		testCase{
+			event: UnmountTelemetryEvent{
+				Target: "/mnt/proc",
+				Flags:  2,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Mount{
+					Mount: &api.MountEvent{
+						Type:   api.MountEventType_MOUNT_EVENT_TYPE_UNMOUNT,
+						Target: "/mnt/proc",
+						Flags:  2,
+					},
+				},
+			},
+		},
 		// KernelFunctionCall
 		testCase{
 			event: KernelFunctionCallTelemetryEvent{
+				Symbol: "do_sys_open",
 				Arguments: perf.TraceEventSampleData{
 					"bytes":  []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66},
 					"string": "string_value",
@@ -1087,6 +2959,8 @@ func TestTranslateEvent(t *testing.T) {
 			expected: &api.TelemetryEvent{
 				Event: &api.TelemetryEvent_KernelCall{
 					KernelCall: &api.KernelFunctionCallEvent{
+						Type:   api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_ENTER,
+						Symbol: "do_sys_open",
 						Arguments: map[string]*api.KernelFunctionCallEvent_FieldValue{
 							"bytes": &api.KernelFunctionCallEvent_FieldValue{
 								FieldType: api.KernelFunctionCallEvent_BYTES,
@@ -1153,6 +3027,85 @@ func TestTranslateEvent(t *testing.T) {
 				},
 			},
 		},
+		testCase{
+			event: KernelFunctionCallTelemetryEvent{
+				Symbol:   "__sys_connect",
+				OnReturn: true,
+				Arguments: perf.TraceEventSampleData{
+					"ret": int64(-111),
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_KernelCall{
+					KernelCall: &api.KernelFunctionCallEvent{
+						Type:   api.KernelFunctionCallEventType_KERNEL_FUNCTION_CALL_EVENT_TYPE_EXIT,
+						Symbol: "__sys_connect",
+						Arguments: map[string]*api.KernelFunctionCallEvent_FieldValue{
+							"ret": &api.KernelFunctionCallEvent_FieldValue{
+								FieldType: api.KernelFunctionCallEvent_SINT64,
+								Value: &api.KernelFunctionCallEvent_FieldValue_SignedValue{
+									SignedValue: int64(-111),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		// UserFunctionCallTelemetryEvent
+		testCase{
+			event: UserFunctionCallTelemetryEvent{
+				Executable: "/usr/lib/x86_64-linux-gnu/libssl.so.3",
+				Symbol:     "SSL_write",
+				Arguments: perf.TraceEventSampleData{
+					"num": int32(512),
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_UserCall{
+					UserCall: &api.UserFunctionCallEvent{
+						Type:       api.UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_ENTER,
+						Executable: "/usr/lib/x86_64-linux-gnu/libssl.so.3",
+						Symbol:     "SSL_write",
+						Arguments: map[string]*api.KernelFunctionCallEvent_FieldValue{
+							"num": &api.KernelFunctionCallEvent_FieldValue{
+								FieldType: api.KernelFunctionCallEvent_SINT32,
+								Value: &api.KernelFunctionCallEvent_FieldValue_SignedValue{
+									SignedValue: int64(512),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		testCase{
+			event: UserFunctionCallTelemetryEvent{
+				Executable: "/lib/x86_64-linux-gnu/libpam.so.0",
+				Symbol:     "pam_authenticate",
+				OnReturn:   true,
+				Arguments: perf.TraceEventSampleData{
+					"ret": int32(7),
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_UserCall{
+					UserCall: &api.UserFunctionCallEvent{
+						Type:       api.UserFunctionCallEventType_USER_FUNCTION_CALL_EVENT_TYPE_EXIT,
+						Executable: "/lib/x86_64-linux-gnu/libpam.so.0",
+						Symbol:     "pam_authenticate",
+						Arguments: map[string]*api.KernelFunctionCallEvent_FieldValue{
+							"ret": &api.KernelFunctionCallEvent_FieldValue{
+								FieldType: api.KernelFunctionCallEvent_SINT32,
+								Value: &api.KernelFunctionCallEvent_FieldValue_SignedValue{
+									SignedValue: int64(7),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		// NetworkAcceptAttemptTelemetryEvent
 		testCase{
 			event: NetworkAcceptAttemptTelemetryEvent{
@@ -1377,6 +3330,215 @@ func TestTranslateEvent(t *testing.T) {
 				},
 			},
 		},
+		// NetworkTCPConnectTelemetryEvent
+		testCase{
+			event: NetworkTCPConnectTelemetryEvent{
+				NetworkTCPTelemetryEventData: NetworkTCPTelemetryEventData{
+					Local: NetworkAddressTelemetryEventData{
+						Family:      unix.AF_INET,
+						IPv4Address: 0x0100007f,
+						IPv4Port:    0x3930,
+					},
+					Remote: NetworkAddressTelemetryEventData{
+						Family:      unix.AF_INET,
+						IPv4Address: 0x0200007f,
+						IPv4Port:    0x5000,
+					},
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Network{
+					Network: &api.NetworkEvent{
+						Type: api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_CONNECT,
+						Address: &api.NetworkAddress{
+							Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET,
+							Address: &api.NetworkAddress_Ipv4Address{
+								Ipv4Address: &api.IPv4AddressAndPort{
+									Address: &api.IPv4Address{
+										Address: 0x0200007f,
+									},
+									Port: 0x5000,
+								},
+							},
+						},
+						LocalAddress: &api.NetworkAddress{
+							Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET,
+							Address: &api.NetworkAddress_Ipv4Address{
+								Ipv4Address: &api.IPv4AddressAndPort{
+									Address: &api.IPv4Address{
+										Address: 0x0100007f,
+									},
+									Port: 0x3930,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		// NetworkTCPAcceptTelemetryEvent
+		testCase{
+			event: NetworkTCPAcceptTelemetryEvent{
+				NetworkTCPTelemetryEventData: NetworkTCPTelemetryEventData{
+					Local: NetworkAddressTelemetryEventData{
+						Family:          unix.AF_INET6,
+						IPv6AddressHigh: 0x1122334455667788,
+						IPv6AddressLow:  0x9900aabbccddeeff,
+						IPv6Port:        0xbb01,
+					},
+					Remote: NetworkAddressTelemetryEventData{
+						Family:          unix.AF_INET6,
+						IPv6AddressHigh: 0x8877665544332211,
+						IPv6AddressLow:  0xffeeddccbbaa0099,
+						IPv6Port:        0x39e2,
+					},
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Network{
+					Network: &api.NetworkEvent{
+						Type: api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_ACCEPT,
+						Address: &api.NetworkAddress{
+							Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET6,
+							Address: &api.NetworkAddress_Ipv6Address{
+								Ipv6Address: &api.IPv6AddressAndPort{
+									Address: &api.IPv6Address{
+										High: 0x8877665544332211,
+										Low:  0xffeeddccbbaa0099,
+									},
+									Port: 0x39e2,
+								},
+							},
+						},
+						LocalAddress: &api.NetworkAddress{
+							Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET6,
+							Address: &api.NetworkAddress_Ipv6Address{
+								Ipv6Address: &api.IPv6AddressAndPort{
+									Address: &api.IPv6Address{
+										High: 0x1122334455667788,
+										Low:  0x9900aabbccddeeff,
+									},
+									Port: 0xbb01,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		// NetworkDNSQueryTelemetryEvent
+		testCase{
+			event: NetworkDNSQueryTelemetryEvent{
+				Server: NetworkAddressTelemetryEventData{
+					Family:      unix.AF_INET,
+					IPv4Address: 0x08080808,
+					IPv4Port:    0x3500,
+				},
+				QueryID:   0x1234,
+				QueryName: "www.example.com",
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Network{
+					Network: &api.NetworkEvent{
+						Type: api.NetworkEventType_NETWORK_EVENT_TYPE_DNS_QUERY,
+						Address: &api.NetworkAddress{
+							Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET,
+							Address: &api.NetworkAddress_Ipv4Address{
+								Ipv4Address: &api.IPv4AddressAndPort{
+									Address: &api.IPv4Address{
+										Address: 0x08080808,
+									},
+									Port: 0x3500,
+								},
+							},
+						},
+						DnsQueryId:   0x1234,
+						DnsQueryName: "www.example.com",
+					},
+				},
+			},
+		},
+		// NetworkUnixConnectTelemetryEvent
+		testCase{
+			event: NetworkUnixConnectTelemetryEvent{
+				Path: "/var/run/docker.sock",
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Network{
+					Network: &api.NetworkEvent{
+						Type: api.NetworkEventType_NETWORK_EVENT_TYPE_UNIX_CONNECT,
+						Address: &api.NetworkAddress{
+							Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_LOCAL,
+							Address: &api.NetworkAddress_LocalAddress{
+								LocalAddress: "/var/run/docker.sock",
+							},
+						},
+					},
+				},
+			},
+		},
+		// NetworkTCPListenTelemetryEvent
+		testCase{
+			event: NetworkTCPListenTelemetryEvent{
+				NetworkListenerTelemetryEventData: NetworkListenerTelemetryEventData{
+					Local: NetworkAddressTelemetryEventData{
+						Family:      unix.AF_INET,
+						IPv4Address: 0x0100007f,
+						IPv4Port:    0x901f,
+					},
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Network{
+					Network: &api.NetworkEvent{
+						Type: api.NetworkEventType_NETWORK_EVENT_TYPE_TCP_LISTEN,
+						LocalAddress: &api.NetworkAddress{
+							Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET,
+							Address: &api.NetworkAddress_Ipv4Address{
+								Ipv4Address: &api.IPv4AddressAndPort{
+									Address: &api.IPv4Address{
+										Address: 0x0100007f,
+									},
+									Port: 0x901f,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		// NetworkUDPBindTelemetryEvent
+		testCase{
+			event: NetworkUDPBindTelemetryEvent{
+				NetworkListenerTelemetryEventData: NetworkListenerTelemetryEventData{
+					Local: NetworkAddressTelemetryEventData{
+						Family:          unix.AF_INET6,
+						IPv6AddressHigh: 0x1122334455667788,
+						IPv6AddressLow:  0x9900aabbccddeeff,
+						IPv6Port:        0x3500,
+					},
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Network{
+					Network: &api.NetworkEvent{
+						Type: api.NetworkEventType_NETWORK_EVENT_TYPE_UDP_BIND,
+						LocalAddress: &api.NetworkAddress{
+							Family: api.NetworkAddressFamily_NETWORK_ADDRESS_FAMILY_INET6,
+							Address: &api.NetworkAddress_Ipv6Address{
+								Ipv6Address: &api.IPv6AddressAndPort{
+									Address: &api.IPv6Address{
+										High: 0x1122334455667788,
+										Low:  0x9900aabbccddeeff,
+									},
+									Port: 0x3500,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		// PerformanceTelemetryEvent
 		testCase{
 			event: PerformanceTelemetryEvent{
@@ -1442,18 +3604,44 @@ func TestTranslateEvent(t *testing.T) {
 				},
 			},
 		},
+		// ProcessExec (fileless)
+		testCase{
+			event: ProcessExecTelemetryEvent{
+				Filename:    "/dev/fd/3",
+				CommandLine: []string{"payload"},
+				Fileless:    true,
+				SHA256:      "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Process{
+					Process: &api.ProcessEvent{
+						Type:            api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+						ExecFilename:    "/dev/fd/3",
+						ExecCommandLine: []string{"payload"},
+						ExecFileless:    true,
+						ExecSha256:      "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+					},
+				},
+			},
+		},
 		// ProcessExit (WaitStatus.Exited)
 		testCase{
 			event: ProcessExitTelemetryEvent{
 				ExitCode:   88 << 8,
 				ExitStatus: 88,
+				TGID:       4321,
+				ParentPID:  1234,
+				ParentTGID: 1234,
 			},
 			expected: &api.TelemetryEvent{
 				Event: &api.TelemetryEvent_Process{
 					Process: &api.ProcessEvent{
-						Type:       api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT,
-						ExitCode:   88 << 8,
-						ExitStatus: 88,
+						Type:           api.ProcessEventType_PROCESS_EVENT_TYPE_EXIT,
+						ExitCode:       88 << 8,
+						ExitStatus:     88,
+						ExitTgid:       4321,
+						ExitParentPid:  1234,
+						ExitParentTgid: 1234,
 					},
 				},
 			},
@@ -1481,13 +3669,149 @@ func TestTranslateEvent(t *testing.T) {
 			event: ProcessForkTelemetryEvent{
 				ChildPID:       872364,
 				ChildProcessID: "some random string for a child process id",
+				ChildTGID:      872364,
+				CloneFlags:     CLONE_NEWNS | CLONE_NEWPID,
+				ParentPID:      872360,
+				ParentTGID:     872359,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Process{
+					Process: &api.ProcessEvent{
+						Type:           api.ProcessEventType_PROCESS_EVENT_TYPE_FORK,
+						ForkChildId:    "some random string for a child process id",
+						ForkChildPid:   872364,
+						ForkChildTgid:  872364,
+						ForkCloneFlags: CLONE_NEWNS | CLONE_NEWPID,
+						ForkParentPid:  872360,
+						ForkParentTgid: 872359,
+					},
+				},
+			},
+		},
+		// ProcessCredChange
+		testCase{
+			event: ProcessCredChangeTelemetryEvent{
+				OldCreds: Cred{1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000},
+				NewCreds: Cred{1000, 1000, 0, 1000, 0, 1000, 0, 1000},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Process{
+					Process: &api.ProcessEvent{
+						Type: api.ProcessEventType_PROCESS_EVENT_TYPE_CRED_CHANGE,
+						CredChangeOld: &api.Credentials{
+							Uid: 1000, Gid: 1000, Euid: 1000, Egid: 1000,
+							Suid: 1000, Sgid: 1000, Fsuid: 1000, Fsgid: 1000,
+						},
+						CredChangeNew: &api.Credentials{
+							Uid: 1000, Gid: 1000, Euid: 0, Egid: 1000,
+							Suid: 0, Sgid: 1000, Fsuid: 0, Fsgid: 1000,
+						},
+					},
+				},
+			},
+		},
+		// ProcessCapabilityChange
+		testCase{
+			event: ProcessCapabilityChangeTelemetryEvent{
+				Gained:       1 << CAP_SYS_ADMIN,
+				Permitted:    1<<CAP_SYS_ADMIN | 1<<CAP_NET_RAW,
+				Effective:    1 << CAP_SYS_ADMIN,
+				OldPermitted: 1 << CAP_NET_RAW,
+				OldEffective: 0,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Process{
+					Process: &api.ProcessEvent{
+						Type:                  api.ProcessEventType_PROCESS_EVENT_TYPE_CAPABILITY_CHANGE,
+						CapChangeGained:       1 << CAP_SYS_ADMIN,
+						CapChangePermitted:    1<<CAP_SYS_ADMIN | 1<<CAP_NET_RAW,
+						CapChangeEffective:    1 << CAP_SYS_ADMIN,
+						CapChangeOldPermitted: 1 << CAP_NET_RAW,
+					},
+				},
+			},
+		},
+		// ProcessCrash
+		testCase{
+			event: ProcessCrashTelemetryEvent{
+				Signal:     int32(unix.SIGSEGV),
+				Code:       1,
+				Executable: "/bin/bash",
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Process{
+					Process: &api.ProcessEvent{
+						Type:            api.ProcessEventType_PROCESS_EVENT_TYPE_CRASH,
+						CrashSignal:     int32(unix.SIGSEGV),
+						CrashCode:       1,
+						CrashExecutable: "/bin/bash",
+					},
+				},
+			},
+		},
+		// ProcessOOMKill
+		testCase{
+			event: ProcessOOMKillTelemetryEvent{
+				TriggerPID:  405,
+				TotalVM:     4404,
+				AnonRSS:     188,
+				FileRSS:     1724,
+				ShmemRSS:    0,
+				OOMScoreAdj: -500,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Process{
+					Process: &api.ProcessEvent{
+						Type:              api.ProcessEventType_PROCESS_EVENT_TYPE_OOM_KILL,
+						OomKillTriggerPid: 405,
+						OomKillTotalVm:    4404,
+						OomKillAnonRss:    188,
+						OomKillFileRss:    1724,
+						OomKillScoreAdj:   -500,
+					},
+				},
+			},
+		},
+		// ProcessPtraceAttach
+		testCase{
+			event: ProcessPtraceAttachTelemetryEvent{
+				Request:           16,
+				TracerPID:         111343,
+				TracerProcessID:   "tracer",
+				TracerContainerID: "container",
+				TraceePID:         111344,
+				TraceeProcessID:   "tracee",
+				TraceeContainerID: "container",
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Process{
+					Process: &api.ProcessEvent{
+						Type:                    api.ProcessEventType_PROCESS_EVENT_TYPE_PTRACE_ATTACH,
+						PtraceRequest:           16,
+						PtraceTracerPid:         111343,
+						PtraceTracerProcessId:   "tracer",
+						PtraceTracerContainerId: "container",
+						PtraceTraceePid:         111344,
+						PtraceTraceeProcessId:   "tracee",
+						PtraceTraceeContainerId: "container",
+					},
+				},
+			},
+		},
+		// ProcessSeccompViolation
+		testCase{
+			event: ProcessSeccompViolationTelemetryEvent{
+				Syscall: int32(unix.SYS_PTRACE),
+				Action:  SeccompActionKill,
+				Data:    0,
 			},
 			expected: &api.TelemetryEvent{
 				Event: &api.TelemetryEvent_Process{
 					Process: &api.ProcessEvent{
-						Type:         api.ProcessEventType_PROCESS_EVENT_TYPE_FORK,
-						ForkChildId:  "some random string for a child process id",
-						ForkChildPid: 872364,
+						Type:               api.ProcessEventType_PROCESS_EVENT_TYPE_SECCOMP_VIOLATION,
+						SeccompSyscall:     int32(unix.SYS_PTRACE),
+						SeccompAction:      api.SeccompAction_SECCOMP_ACTION_KILL,
+						SeccompSyscallName: "ptrace",
 					},
 				},
 			},
@@ -1506,6 +3830,92 @@ func TestTranslateEvent(t *testing.T) {
 				},
 			},
 		},
+		// SessionLogin
+		testCase{
+			event: SessionLoginTelemetryEvent{
+				SessionTelemetryEventData: SessionTelemetryEventData{
+					User:      "alice",
+					TTY:       "pts/0",
+					Host:      "bastion",
+					Address:   "10.0.0.5",
+					SessionID: 12,
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Session{
+					Session: &api.SessionEvent{
+						Type:      api.SessionEventType_SESSION_EVENT_TYPE_LOGIN,
+						User:      "alice",
+						Tty:       "pts/0",
+						Host:      "bastion",
+						Address:   "10.0.0.5",
+						SessionId: 12,
+					},
+				},
+			},
+		},
+		// SessionLogout
+		testCase{
+			event: SessionLogoutTelemetryEvent{
+				SessionTelemetryEventData: SessionTelemetryEventData{
+					User: "alice",
+					TTY:  "pts/0",
+				},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Session{
+					Session: &api.SessionEvent{
+						Type: api.SessionEventType_SESSION_EVENT_TYPE_LOGOUT,
+						User: "alice",
+						Tty:  "pts/0",
+					},
+				},
+			},
+		},
+		// SignalGenerate
+		testCase{
+			event: SignalGenerateTelemetryEvent{
+				Signal:            9,
+				TargetPID:         111343,
+				TargetProcessID:   "target",
+				TargetContainerID: "container",
+				Group:             true,
+				Result:            1,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Signal{
+					Signal: &api.SignalEvent{
+						Type:                      api.SignalEventType_SIGNAL_EVENT_TYPE_GENERATE,
+						Signal:                    9,
+						GenerateTargetPid:         111343,
+						GenerateTargetProcessId:   "target",
+						GenerateTargetContainerId: "container",
+						GenerateGroup:             true,
+						GenerateResult:            1,
+					},
+				},
+			},
+		},
+		// SignalDeliver
+		testCase{
+			event: SignalDeliverTelemetryEvent{
+				Signal:    11,
+				Code:      1,
+				SaHandler: 0x401000,
+				SaFlags:   0x4000000,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Signal{
+					Signal: &api.SignalEvent{
+						Type:             api.SignalEventType_SIGNAL_EVENT_TYPE_DELIVER,
+						Signal:           11,
+						Code:             1,
+						DeliverSaHandler: 0x401000,
+						DeliverSaFlags:   0x4000000,
+					},
+				},
+			},
+		},
 		// SyscallEnter
 		testCase{
 			event: SyscallEnterTelemetryEvent{
@@ -1543,6 +3953,54 @@ func TestTranslateEvent(t *testing.T) {
 				},
 			},
 		},
+		// Lost events
+		testCase{
+			event: LostEventsTelemetryEvent{
+				Count:                    8237,
+				WindowStartMonotimeNanos: 982374,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_LostEvents{
+					LostEvents: &api.LostEventsEvent{
+						Count:                    8237,
+						WindowStartMonotimeNanos: 982374,
+					},
+				},
+			},
+		},
+
+		// Sensor status
+		testCase{
+			event: SensorStatusTelemetryEvent{
+				UptimeNanos:         87236487,
+				EventsReceived:      2873,
+				EventsPerSecond:     12.5,
+				LostRecords:         8,
+				RateLimitedEvents:   3,
+				DecodeErrors:        1,
+				StreamEventsDropped: 2,
+				HeapAllocBytes:      8372638,
+				SysBytes:            72836482,
+				Probes:              []string{"sched/sched_process_exec"},
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_SensorStatus{
+					SensorStatus: &api.SensorStatusEvent{
+						UptimeNanos:         87236487,
+						EventsReceived:      2873,
+						EventsPerSecond:     12.5,
+						LostRecords:         8,
+						RateLimitedEvents:   3,
+						DecodeErrors:        1,
+						StreamEventsDropped: 2,
+						HeapAllocBytes:      8372638,
+						SysBytes:            72836482,
+						Probes:              []string{"sched/sched_process_exec"},
+					},
+				},
+			},
+		},
+
 		// Ticker
 		testCase{
 			event: TickerTelemetryEvent{
@@ -1558,6 +4016,22 @@ func TestTranslateEvent(t *testing.T) {
 				},
 			},
 		},
+		// TTYRead
+		testCase{
+			event: TTYReadTelemetryEvent{
+				Data:   []byte("ls -l\r"),
+				Length: 6,
+			},
+			expected: &api.TelemetryEvent{
+				Event: &api.TelemetryEvent_Tty{
+					Tty: &api.TtyEvent{
+						Type:   api.TtyEventType_TTY_EVENT_TYPE_READ,
+						Data:   []byte("ls -l\r"),
+						Length: 6,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1565,3 +4039,117 @@ func TestTranslateEvent(t *testing.T) {
 		assert.Equal(t, tc.expected, got)
 	}
 }
+
+func TestTranslateContainerHostProcess(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	task := sensor.ProcessCache.LookupTask(2837)
+	changes := map[string]interface{}{
+		"TGID":        task.PID,
+		"Executable":  "/usr/bin/dockerd",
+		"CommandLine": []string{"dockerd", "-H", "fd://"},
+		"CWD":         "/",
+		"Creds":       &rootCredentials,
+		"StartTime":   int64(8723648),
+	}
+	task.Update(changes, uint64(sys.CurrentMonotonicRaw()), sensor.ProcFS)
+	task.parent = &rootTask
+
+	got := s.translateEvent(ContainerRunningTelemetryEvent{
+		TelemetryEventData{
+			Container: ContainerInfo{Pid: 2837},
+		},
+	})
+	require.IsType(t, &api.TelemetryEvent_Container{}, got.Event)
+	assert.Equal(t, &api.ProcessInfo{
+		ProcessId:   task.ProcessID,
+		Pid:         2837,
+		Tgid:        2837,
+		Executable:  "/usr/bin/dockerd",
+		CommandLine: []string{"dockerd", "-H", "fd://"},
+		Cwd:         "/",
+		Credentials: &api.Credentials{},
+		StartTime:   8723648,
+	}, got.Event.(*api.TelemetryEvent_Container).Container.HostProcess)
+
+	// Processes that are not cached are not made up
+	got = s.translateEvent(ContainerRunningTelemetryEvent{
+		TelemetryEventData{
+			Container: ContainerInfo{Pid: 2838},
+		},
+	})
+	assert.Nil(t, got.Event.(*api.TelemetryEvent_Container).Container.HostProcess)
+	assert.Nil(t, sensor.ProcessCache.LookupKnownTask(2838))
+}
+
+func TestTranslateProcessLineage(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	parent := sensor.ProcessCache.LookupTask(2839)
+	parent.parent = &rootTask
+	changes := map[string]interface{}{
+		"TGID":       parent.PID,
+		"Command":    "bash",
+		"Executable": "/bin/bash",
+	}
+	parent.Update(changes, uint64(sys.CurrentMonotonicRaw()), sensor.ProcFS)
+	task := sensor.ProcessCache.LookupTask(2840)
+	task.parent = parent
+	changes = map[string]interface{}{
+		"TGID":       task.PID,
+		"Command":    "curl",
+		"Executable": "/usr/bin/curl",
+	}
+	task.Update(changes, uint64(sys.CurrentMonotonicRaw()), sensor.ProcFS)
+
+	eventData := TelemetryEventData{
+		ProcessID: task.ProcessID,
+		PID:       task.PID,
+		TGID:      task.TGID,
+	}
+	expected := []*api.Process{
+		&api.Process{
+			Pid:        2840,
+			Command:    "curl",
+			Executable: "/usr/bin/curl",
+			ProcessId:  task.ProcessID,
+		},
+		&api.Process{
+			Pid:        2839,
+			Command:    "bash",
+			Executable: "/bin/bash",
+			ProcessId:  parent.ProcessID,
+		},
+	}
+
+	got := s.translateEvent(ProcessExecTelemetryEvent{
+		TelemetryEventData: eventData,
+		Filename:           "/usr/bin/curl",
+	})
+	assert.Equal(t, expected, got.ProcessLineage)
+
+	got = s.translateEvent(NetworkConnectAttemptTelemetryEvent{
+		TelemetryEventData: eventData,
+	})
+	assert.Equal(t, expected, got.ProcessLineage)
+
+	// Other events do not include lineage
+	got = s.translateEvent(ProcessForkTelemetryEvent{
+		TelemetryEventData: eventData,
+	})
+	assert.Nil(t, got.ProcessLineage)
+
+	depth := config.Sensor.ProcessLineageDepth
+	config.Sensor.ProcessLineageDepth = 1
+	defer func() { config.Sensor.ProcessLineageDepth = depth }()
+	got = s.translateEvent(ProcessExecTelemetryEvent{
+		TelemetryEventData: eventData,
+	})
+	assert.Equal(t, expected[:1], got.ProcessLineage)
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// throttleInterval converts a throttle interval from the API into a duration.
+func throttleInterval(
+	interval int64,
+	intervalType api.ThrottleModifier_IntervalType,
+) (time.Duration, error) {
+	if interval <= 0 {
+		return 0, fmt.Errorf("interval is invalid (%d)", interval)
+	}
+	d := time.Duration(interval)
+	switch intervalType {
+	case api.ThrottleModifier_MILLISECOND:
+		d *= time.Millisecond
+	case api.ThrottleModifier_SECOND:
+		d *= time.Second
+	case api.ThrottleModifier_MINUTE:
+		d *= time.Minute
+	case api.ThrottleModifier_HOUR:
+		d *= time.Hour
+	default:
+		return 0, fmt.Errorf("interval type is invalid (%d)", intervalType)
+	}
+	return d, nil
+}
+
+// keyedThrottle limits the number of translated events sent for each distinct
+// key in fixed time windows. Counts for all keys are discarded at the start
+// of each window, so the memory used is bounded by the number of keys seen in
+// one window.
+type keyedThrottle struct {
+	keys     []string
+	limit    uint64
+	interval time.Duration
+
+	windowEnd time.Time
+	counts    map[string]uint64
+}
+
+func newKeyedThrottle(m *api.KeyedThrottleModifier) (*keyedThrottle, error) {
+	if len(m.Keys) == 0 {
+		return nil, fmt.Errorf("no keys specified")
+	}
+	types := getEventExpressionTypes()
+	for _, key := range m.Keys {
+		if _, ok := types[key]; !ok {
+			return nil, fmt.Errorf("key %q is not an event field", key)
+		}
+	}
+	if m.Limit == 0 {
+		return nil, fmt.Errorf("limit is invalid (%d)", m.Limit)
+	}
+	interval, err := throttleInterval(m.Interval, m.IntervalType)
+	if err != nil {
+		return nil, err
+	}
+	return &keyedThrottle{
+		keys:     m.Keys,
+		limit:    m.Limit,
+		interval: interval,
+	}, nil
+}
+
+func (k *keyedThrottle) eventKey(event *api.TelemetryEvent) string {
+	values := eventExpressionValues(event)
+	parts := make([]string, len(k.keys))
+	for i, key := range k.keys {
+		if v, ok := values[key]; ok {
+			parts[i] = fmt.Sprint(v)
+		}
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// allow reports whether an event may be sent at the specified time, counting
+// it against its key if so.
+func (k *keyedThrottle) allow(event *api.TelemetryEvent, now time.Time) bool {
+	if !now.Before(k.windowEnd) {
+		k.windowEnd = now.Add(k.interval)
+		k.counts = make(map[string]uint64)
+	}
+	key := k.eventKey(event)
+	if k.counts[key] >= k.limit {
+		return false
+	}
+	k.counts[key]++
+	return true
+}
@@ -0,0 +1,114 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottleInterval(t *testing.T) {
+	tests := []struct {
+		interval     int64
+		intervalType api.ThrottleModifier_IntervalType
+		expected     time.Duration
+	}{
+		{5, api.ThrottleModifier_MILLISECOND, 5 * time.Millisecond},
+		{5, api.ThrottleModifier_SECOND, 5 * time.Second},
+		{5, api.ThrottleModifier_MINUTE, 5 * time.Minute},
+		{5, api.ThrottleModifier_HOUR, 5 * time.Hour},
+	}
+	for _, tc := range tests {
+		d, err := throttleInterval(tc.interval, tc.intervalType)
+		if assert.NoError(t, err) {
+			assert.Equal(t, tc.expected, d)
+		}
+	}
+
+	_, err := throttleInterval(0, api.ThrottleModifier_SECOND)
+	assert.Error(t, err)
+	_, err = throttleInterval(1, 8888)
+	assert.Error(t, err)
+}
+
+func TestNewKeyedThrottle(t *testing.T) {
+	bad := []*api.KeyedThrottleModifier{
+		&api.KeyedThrottleModifier{
+			Limit:    1,
+			Interval: 1,
+		},
+		&api.KeyedThrottleModifier{
+			Keys:     []string{"event.no_such_field"},
+			Limit:    1,
+			Interval: 1,
+		},
+		&api.KeyedThrottleModifier{
+			Keys:     []string{"event.container_id"},
+			Interval: 1,
+		},
+		&api.KeyedThrottleModifier{
+			Keys:  []string{"event.container_id"},
+			Limit: 1,
+		},
+	}
+	for _, m := range bad {
+		_, err := newKeyedThrottle(m)
+		assert.Error(t, err, "%+v", m)
+	}
+}
+
+func TestKeyedThrottle(t *testing.T) {
+	k, err := newKeyedThrottle(&api.KeyedThrottleModifier{
+		Keys:         []string{"event.container_id", "event.process.exec_filename"},
+		Limit:        2,
+		Interval:     1,
+		IntervalType: api.ThrottleModifier_SECOND,
+	})
+	require.NoError(t, err)
+
+	newEvent := func(containerID, filename string) *api.TelemetryEvent {
+		return &api.TelemetryEvent{
+			ContainerId: containerID,
+			Event: &api.TelemetryEvent_Process{
+				Process: &api.ProcessEvent{
+					ExecFilename: filename,
+				},
+			},
+		}
+	}
+	a := newEvent("a", "/bin/sh")
+	b := newEvent("b", "/bin/sh")
+	c := newEvent("a", "/bin/ls")
+
+	now := time.Now()
+	assert.True(t, k.allow(a, now))
+	assert.True(t, k.allow(a, now))
+	assert.False(t, k.allow(a, now))
+
+	// Other keys have their own limits
+	assert.True(t, k.allow(b, now))
+	assert.True(t, k.allow(c, now))
+	assert.True(t, k.allow(&api.TelemetryEvent{}, now))
+
+	// A new window resets the counts
+	now = now.Add(time.Second)
+	assert.True(t, k.allow(a, now))
+	assert.Len(t, k.counts, 1)
+}
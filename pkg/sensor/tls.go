@@ -0,0 +1,155 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// tlsFileState identifies a version of a file by its modification time and
+// size, which are checked rather than watching for events so that files
+// replaced by renaming them or by changing a symbolic link, as is done for
+// mounted Kubernetes secrets, are noticed.
+type tlsFileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// tlsReloader holds the server certificate and client CAs of the telemetry
+// service, reloading them when their files change so that certificates can
+// be rotated without restarting the sensor. Connections that are already
+// established keep the certificates that they were made with.
+type tlsReloader struct {
+	certPath string
+	keyPath  string
+	caPath   string
+
+	mutex       sync.RWMutex
+	certificate tls.Certificate
+	clientCAs   *x509.CertPool
+	states      [3]tlsFileState
+}
+
+func newTLSReloader(certPath, keyPath, caPath string) (*tlsReloader, error) {
+	r := &tlsReloader{
+		certPath: certPath,
+		keyPath:  keyPath,
+		caPath:   caPath,
+	}
+	if err := r.load(r.fileStates()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *tlsReloader) fileStates() [3]tlsFileState {
+	var states [3]tlsFileState
+	for i, path := range []string{r.certPath, r.keyPath, r.caPath} {
+		if fi, err := os.Stat(path); err == nil {
+			states[i] = tlsFileState{
+				modTime: fi.ModTime(),
+				size:    fi.Size(),
+			}
+		}
+	}
+	return states
+}
+
+func (r *tlsReloader) load(states [3]tlsFileState) error {
+	certificate, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		return fmt.Errorf("could not load server key pair: %s", err)
+	}
+
+	certPool := x509.NewCertPool()
+	ca, err := ioutil.ReadFile(r.caPath)
+	if err != nil {
+		return fmt.Errorf("could not read ca certificate: %s", err)
+	}
+	if ok := certPool.AppendCertsFromPEM(ca); !ok {
+		return errors.New("failed to append certs")
+	}
+
+	r.mutex.Lock()
+	r.certificate = certificate
+	r.clientCAs = certPool
+	r.states = states
+	r.mutex.Unlock()
+	return nil
+}
+
+// reload loads the certificates again if any of their files have changed.
+// If they cannot be loaded, the certificates already loaded are kept.
+func (r *tlsReloader) reload() (bool, error) {
+	states := r.fileStates()
+	r.mutex.RLock()
+	changed := states != r.states
+	r.mutex.RUnlock()
+	if !changed {
+		return false, nil
+	}
+	if err := r.load(states); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// watch checks for changes to the certificates' files at an interval until
+// ctx is done.
+func (r *tlsReloader) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if changed, err := r.reload(); err != nil {
+				glog.Warningf("Could not reload TLS certificates: %v", err)
+			} else if changed {
+				glog.Infof("Reloaded TLS certificates")
+			}
+		}
+	}
+}
+
+// config returns the TLS configuration of the telemetry service, which
+// requires clients to present a certificate signed by one of the client CAs
+// and uses the certificates loaded when each connection is made.
+func (r *tlsReloader) config() *tls.Config {
+	return &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mutex.RLock()
+			defer r.mutex.RUnlock()
+			return &tls.Config{
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				Certificates: []tls.Certificate{r.certificate},
+				ClientCAs:    r.clientCAs,
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+}
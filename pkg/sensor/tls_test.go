@@ -0,0 +1,196 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCertificate(t *testing.T, name string, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer,
+		&key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCertificate{
+		cert: cert,
+		key:  key,
+		der:  der,
+	}
+}
+
+func (c *testCertificate) tlsCertificate() tls.Certificate {
+	return tls.Certificate{
+		Certificate: [][]byte{c.der},
+		PrivateKey:  c.key,
+	}
+}
+
+func writeTestCertificate(t *testing.T, c *testCertificate, certPath, keyPath string, modTime time.Time) {
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der})
+	require.NoError(t, ioutil.WriteFile(certPath, certPEM, 0600))
+	require.NoError(t, os.Chtimes(certPath, modTime, modTime))
+	if keyPath != "" {
+		b, err := x509.MarshalECPrivateKey(c.key)
+		require.NoError(t, err)
+		keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b})
+		require.NoError(t, ioutil.WriteFile(keyPath, keyPEM, 0600))
+		require.NoError(t, os.Chtimes(keyPath, modTime, modTime))
+	}
+}
+
+func serverCommonName(t *testing.T, r *tlsReloader) string {
+	c, err := r.config().GetConfigForClient(nil)
+	require.NoError(t, err)
+	require.Len(t, c.Certificates, 1)
+	cert, err := x509.ParseCertificate(c.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	return cert.Subject.CommonName
+}
+
+func TestTLSReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certPath := filepath.Join(dir, "server.crt")
+	keyPath := filepath.Join(dir, "server.key")
+	caPath := filepath.Join(dir, "ca.crt")
+
+	_, err = newTLSReloader(certPath, keyPath, caPath)
+	assert.Error(t, err)
+
+	start := time.Now().Add(-time.Minute)
+	ca := newTestCertificate(t, "ca", nil)
+	writeTestCertificate(t, ca, caPath, "", start)
+	writeTestCertificate(t, newTestCertificate(t, "server1", ca),
+		certPath, keyPath, start)
+
+	r, err := newTLSReloader(certPath, keyPath, caPath)
+	require.NoError(t, err)
+	assert.Equal(t, "server1", serverCommonName(t, r))
+
+	changed, err := r.reload()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// New certificates are used once their files change
+	writeTestCertificate(t, newTestCertificate(t, "server2", ca),
+		certPath, keyPath, start.Add(time.Second))
+	changed, err = r.reload()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "server2", serverCommonName(t, r))
+
+	// Certificates that cannot be loaded are not used
+	require.NoError(t, ioutil.WriteFile(certPath, []byte("garbage"), 0600))
+	_, err = r.reload()
+	assert.Error(t, err)
+	assert.Equal(t, "server2", serverCommonName(t, r))
+}
+
+func TestTLSReloaderHandshake(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certPath := filepath.Join(dir, "server.crt")
+	keyPath := filepath.Join(dir, "server.key")
+	caPath := filepath.Join(dir, "ca.crt")
+
+	ca := newTestCertificate(t, "ca", nil)
+	writeTestCertificate(t, ca, caPath, "", time.Now())
+	writeTestCertificate(t, newTestCertificate(t, "server", ca),
+		certPath, keyPath, time.Now())
+	r, err := newTLSReloader(certPath, keyPath, caPath)
+	require.NoError(t, err)
+
+	handshake := func(client *testCertificate) error {
+		c, s := net.Pipe()
+		defer c.Close()
+		defer s.Close()
+
+		server := tls.Server(s, r.config())
+		go server.Handshake()
+
+		// Only the server's verification of the client is tested
+		config := &tls.Config{
+			InsecureSkipVerify: true,
+		}
+		if client != nil {
+			config.Certificates = []tls.Certificate{client.tlsCertificate()}
+		}
+		conn := tls.Client(c, config)
+		if err := conn.Handshake(); err != nil {
+			return err
+		}
+		// The server's verification of the client certificate is
+		// reported when the client reads
+		conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		_, err := conn.Read(make([]byte, 1))
+		if ne, ok := err.(net.Error); ok && ne.Timeout() {
+			return nil
+		}
+		return err
+	}
+
+	assert.NoError(t, handshake(newTestCertificate(t, "client", ca)))
+	assert.Error(t, handshake(nil))
+	assert.Error(t, handshake(newTestCertificate(t, "client",
+		newTestCertificate(t, "other ca", nil))))
+}
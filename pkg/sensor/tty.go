@@ -0,0 +1,102 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// TTYReadEventTypes defines the field types that can be used with filters on
+// TTY read telemetry events.
+var TTYReadEventTypes = expression.FieldTypeMap{
+	"data":   expression.ValueTypeString,
+	"length": expression.ValueTypeUnsignedInt64,
+}
+
+// TTYReadTelemetryEvent is a telemetry event generated by the TTY event
+// source when a process reads input from a terminal. The process information
+// is that of the reader, which is normally a shell.
+type TTYReadTelemetryEvent struct {
+	TelemetryEventData
+
+	Data   []byte
+	Length uint64
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a TTY read telemetry event.
+func (e TTYReadTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// tty_audit_add_data is called by the n_tty line discipline in the context
+// of the reading task with each chunk of input that is copied out to it,
+// whether or not TTY auditing is enabled. The data is not NUL terminated, so
+// the string that is fetched must be truncated to the length. Input that
+// contains NUL bytes is cut short at the first one.
+const (
+	ttyReadKprobeSymbol    = "tty_audit_add_data"
+	ttyReadKprobeFetchargs = "data=+0(%si):string length=%dx:u64"
+)
+
+func (s *Subscription) decodeTTYRead(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+	includeData bool,
+) (interface{}, error) {
+	var e TTYReadTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+
+	input := data["data"].(string)
+	e.Length = data["length"].(uint64)
+	if uint64(len(input)) > e.Length {
+		input = input[:e.Length]
+	}
+	if includeData {
+		e.Data = []byte(input)
+	}
+
+	// Make the truncated input visible to filter expressions, which are
+	// evaluated against the sample data after decoding.
+	data["data"] = input
+
+	return e, nil
+}
+
+// RegisterTTYReadEventFilter registers a TTY read event filter with a
+// subscription. The input that was read is only included in events if
+// includeData is true; otherwise only its length is reported.
+func (s *Subscription) RegisterTTYReadEventFilter(
+	includeData bool,
+	expr *expression.Expression,
+) {
+	decoder := func(
+		sample *perf.SampleRecord,
+		data perf.TraceEventSampleData,
+	) (interface{}, error) {
+		return s.decodeTTYRead(sample, data, includeData)
+	}
+
+	s.registerWithSensorFilter("TTY", expr, TTYReadEventTypes,
+		func() (*eventSink, error) {
+			// The input is only known after decoding
+			return s.registerKprobe(ttyReadKprobeSymbol, false,
+				ttyReadKprobeFetchargs, decoder, nil,
+				TTYReadEventTypes)
+		})
+}
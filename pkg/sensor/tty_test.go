@@ -0,0 +1,125 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTTYRead(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"data":       "id\rgarbage",
+		"length":     uint64(3),
+	}
+
+	i, err := s.decodeTTYRead(sample, data, true)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	data["common_pid"] = int32(111343)
+	i, err = s.decodeTTYRead(sample, data, true)
+	require.NoError(t, err)
+	require.IsType(t, TTYReadTelemetryEvent{}, i)
+
+	e := i.(TTYReadTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+		e.Container.ID)
+	assert.Equal(t, []byte("id\r"), e.Data)
+	assert.Equal(t, uint64(3), e.Length)
+	assert.Equal(t, "id\r", data["data"])
+
+	// Only the length is reported unless the data is requested
+	data["data"] = "x"
+	data["length"] = uint64(1)
+	i, err = s.decodeTTYRead(sample, data, false)
+	require.NoError(t, err)
+	require.IsType(t, TTYReadTelemetryEvent{}, i)
+
+	e = i.(TTYReadTelemetryEvent)
+	assert.Nil(t, e.Data)
+	assert.Equal(t, uint64(1), e.Length)
+	assert.Equal(t, "x", data["data"])
+}
+
+func prepareForRegisterTTYReadEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:__data_loc char[] data;	offset:16;	size:4;	signed:1;
+	field:u64 length;	offset:24;	size:8;	signed:0;
+
+print fmt: "(%lx) data=\"%s\" length=%Lu", REC->__probe_ip, __get_str(data), REC->length`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func TestTTYReadEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("data"),
+		expression.Value("sudo su\r"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	prepareForRegisterTTYReadEventFilter(t, s, 0)
+	s.RegisterTTYReadEventFilter(true, expr)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	prepareForRegisterTTYReadEventFilter(t, s, 0)
+	s.RegisterTTYReadEventFilter(false, nil)
+	assert.Len(t, s.eventSinks, 1)
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterTTYReadEventFilter(true, expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
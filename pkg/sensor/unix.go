@@ -0,0 +1,214 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// Access to the telemetry service over a unix socket is controlled by the
+// socket's file permissions, which are set by ListenSocketMode and
+// ListenSocketGroup, so that node-local agents can be allowed to connect by
+// running them as the sensor's user or in the socket's group.
+
+// parseSocketMode parses an octal file mode for the socket.
+func parseSocketMode(s string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || mode&^0777 != 0 {
+		return 0, fmt.Errorf("socket mode %q is invalid", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+// lookupSocketGroup returns the gid of a group given by name or number.
+func lookupSocketGroup(s string) (int, error) {
+	if gid, err := strconv.Atoi(s); err == nil && gid >= 0 {
+		return gid, nil
+	}
+	g, err := user.LookupGroup(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(g.Gid)
+}
+
+// unixSocketListener is a listener on a unix socket that was created
+// elsewhere and linked into place, which removes the socket when it is
+// closed.
+type unixSocketListener struct {
+	net.Listener
+	path string
+}
+
+func (l *unixSocketListener) Close() error {
+	err := l.Listener.Close()
+	os.Remove(l.path)
+	return err
+}
+
+// listenUnix listens on a unix socket, replacing a stale socket if no one
+// is listening on it, and sets the socket's permissions. The group is not
+// changed if it is empty.
+func listenUnix(socketPath, modeString, group string) (net.Listener, error) {
+	mode, err := parseSocketMode(modeString)
+	if err != nil {
+		return nil, err
+	}
+	gid := -1
+	if group != "" {
+		if gid, err = lookupSocketGroup(group); err != nil {
+			return nil, fmt.Errorf("socket group %q is invalid: %v",
+				group, err)
+		}
+	}
+
+	// Check whether socket already exists and if someone
+	// is already listening on it.
+	if _, err = os.Stat(socketPath); err == nil {
+		var ua *net.UnixAddr
+
+		ua, err = net.ResolveUnixAddr("unix", socketPath)
+		if err == nil {
+			var c *net.UnixConn
+
+			c, err = net.DialUnix("unix", nil, ua)
+			if err == nil {
+				// There is another running service.
+				// Try to listen below and return the
+				// error.
+				c.Close()
+			} else {
+				// Remove the stale socket so the
+				// listen below will succeed.
+				os.Remove(socketPath)
+			}
+		}
+	}
+
+	// The socket is created in a directory that only the sensor can
+	// enter, so that no one can connect before its permissions are set,
+	// and then linked into place. Linking fails if another service has
+	// created the socket in the meantime.
+	dir, err := ioutil.TempDir(filepath.Dir(socketPath), ".listen")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	privatePath := filepath.Join(dir, "socket")
+
+	lis, err := net.Listen("unix", privatePath)
+	if err != nil {
+		return nil, err
+	}
+	if gid != -1 {
+		if err = os.Chown(privatePath, -1, gid); err != nil {
+			lis.Close()
+			return nil, err
+		}
+	}
+	if err = os.Chmod(privatePath, mode); err != nil {
+		lis.Close()
+		return nil, err
+	}
+	if err = os.Link(privatePath, socketPath); err != nil {
+		lis.Close()
+		return nil, err
+	}
+	return &unixSocketListener{
+		Listener: lis,
+		path:     socketPath,
+	}, nil
+}
+
+// unixPeerAuthInfo holds the credentials of the process at the other end
+// of a unix socket connection.
+type unixPeerAuthInfo struct {
+	Pid int32
+	UID uint32
+	GID uint32
+}
+
+func (unixPeerAuthInfo) AuthType() string {
+	return "unix"
+}
+
+// unixPeerCredentials are the transport credentials of the telemetry
+// service on a unix socket without TLS, which identify clients by their
+// peer credentials.
+type unixPeerCredentials struct{}
+
+func (unixPeerCredentials) ClientHandshake(
+	ctx context.Context,
+	authority string,
+	conn net.Conn,
+) (net.Conn, credentials.AuthInfo, error) {
+	return conn, nil, nil
+}
+
+func (unixPeerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return conn, nil, nil
+	}
+	rc, err := uc.SyscallConn()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		ucred  *unix.Ucred
+		sysErr error
+	)
+	err = rc.Control(func(fd uintptr) {
+		ucred, sysErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET,
+			unix.SO_PEERCRED)
+	})
+	if err == nil {
+		err = sysErr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, unixPeerAuthInfo{
+		Pid: ucred.Pid,
+		UID: ucred.Uid,
+		GID: ucred.Gid,
+	}, nil
+}
+
+func (unixPeerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "unix",
+	}
+}
+
+func (c unixPeerCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (unixPeerCredentials) OverrideServerName(string) error {
+	return nil
+}
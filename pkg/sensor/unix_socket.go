@@ -0,0 +1,86 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// NetworkUnixConnectEventTypes defines the field types that can be used with
+// filters on network unix connect telemetry events.
+var NetworkUnixConnectEventTypes = expression.FieldTypeMap{
+	"sun_path": expression.ValueTypeString,
+}
+
+// NetworkUnixConnectTelemetryEvent is a telemetry event generated by the
+// network unix connect event source when a process connects to a unix domain
+// stream socket.
+type NetworkUnixConnectTelemetryEvent struct {
+	TelemetryEventData
+
+	// Path is the path of the socket as seen from the connecting
+	// process's mount namespace. Abstract socket names are prefixed with
+	// "@" in place of the leading NUL byte.
+	Path string
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a network unix connect telemetry event.
+func (e NetworkUnixConnectTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+// unix_stream_connect(sock, uaddr, addr_len, flags) is called for connect(2)
+// on unix domain stream sockets. uaddr has already been copied into the
+// kernel. Abstract socket names begin with a NUL byte, so the name is fetched
+// a second time from the byte after it; sun_path is empty for these.
+const (
+	networkKprobeUnixConnectSymbol    = "unix_stream_connect"
+	networkKprobeUnixConnectFetchargs = "sun_path=+2(%si):string " +
+		"sun_abstract=+3(%si):string"
+)
+
+func (s *Subscription) decodeUnixStreamConnect(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+	var e NetworkUnixConnectTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+
+	e.Path = data["sun_path"].(string)
+	if len(e.Path) == 0 {
+		if abstract := data["sun_abstract"].(string); len(abstract) > 0 {
+			e.Path = "@" + abstract
+		}
+	}
+
+	// Make the abstract name visible to filter expressions, which are
+	// evaluated against the sample data after decoding.
+	data["sun_path"] = e.Path
+
+	return e, nil
+}
+
+// RegisterNetworkUnixConnectEventFilter registers a network unix connect event
+// filter with a subscription.
+func (s *Subscription) RegisterNetworkUnixConnectEventFilter(expr *expression.Expression) {
+	s.registerWithSensorFilter("unix connect", expr, NetworkUnixConnectEventTypes,
+		func() (*eventSink, error) {
+			// Abstract names are only known after decoding
+			return s.registerKprobe(networkKprobeUnixConnectSymbol, false,
+				networkKprobeUnixConnectFetchargs, s.decodeUnixStreamConnect, nil,
+				NetworkUnixConnectEventTypes)
+		})
+}
@@ -0,0 +1,115 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeUnixStreamConnect(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid":   int32(sensorPID),
+		"sun_path":     "/var/run/docker.sock",
+		"sun_abstract": "var/run/docker.sock",
+	}
+
+	i, err := s.decodeUnixStreamConnect(sample, data)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	data["common_pid"] = int32(111343)
+	i, err = s.decodeUnixStreamConnect(sample, data)
+	require.NoError(t, err)
+	require.IsType(t, NetworkUnixConnectTelemetryEvent{}, i)
+
+	e := i.(NetworkUnixConnectTelemetryEvent)
+	ok := testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+		e.Container.ID)
+	assert.Equal(t, "/var/run/docker.sock", e.Path)
+
+	// Abstract names begin with a NUL byte
+	data["sun_path"] = ""
+	data["sun_abstract"] = "/tmp/.X11-unix/X0"
+	i, err = s.decodeUnixStreamConnect(sample, data)
+	require.NoError(t, err)
+	e = i.(NetworkUnixConnectTelemetryEvent)
+	assert.Equal(t, "@/tmp/.X11-unix/X0", e.Path)
+	assert.Equal(t, "@/tmp/.X11-unix/X0", data["sun_path"])
+}
+
+func prepareForRegisterNetworkUnixConnectEventFilter(t *testing.T, s *Subscription, delta uint64) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:__data_loc char[] sun_path;	offset:16;	size:4;	signed:1;
+	field:__data_loc char[] sun_abstract;	offset:20;	size:4;	signed:1;
+
+print fmt: "(%lx) sun_path=\"%s\" sun_abstract=\"%s\"", REC->__probe_ip, __get_str(sun_path), __get_str(sun_abstract)`
+
+	newUnitTestKprobe(t, s.sensor, delta, format)
+}
+
+func TestNetworkUnixConnectEventRegistration(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	e := expression.Equal(expression.Identifier("sun_path"),
+		expression.Value("/var/run/docker.sock"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	prepareForRegisterNetworkUnixConnectEventFilter(t, s, 0)
+	s.RegisterNetworkUnixConnectEventFilter(expr)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+	for _, es := range s.eventSinks {
+		// Filters must always be evaluated in the sensor
+		assert.Equal(t, expr, es.filter)
+	}
+
+	s = newTestSubscription(t, sensor)
+	e = expression.Equal(expression.Identifier("bogus"),
+		expression.Value("value"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s.RegisterNetworkUnixConnectEventFilter(expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+}
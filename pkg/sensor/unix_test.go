@@ -0,0 +1,93 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSocketMode(t *testing.T) {
+	mode, err := parseSocketMode("0660")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), mode)
+
+	for _, s := range []string{"", "rw", "0999", "01777"} {
+		_, err = parseSocketMode(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestListenUnix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "unix_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "socket")
+
+	_, err = listenUnix(socketPath, "0660", "no such group")
+	assert.Error(t, err)
+
+	lis, err := listenUnix(socketPath, "0660", strconv.Itoa(os.Getgid()))
+	require.NoError(t, err)
+	fi, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), fi.Mode().Perm())
+
+	// The socket is in use
+	_, err = listenUnix(socketPath, "0600", "")
+	assert.Error(t, err)
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		c, _ := lis.Accept()
+		accepted <- c
+	}()
+	c, err := net.Dial("unix", socketPath)
+	require.NoError(t, err)
+	defer c.Close()
+	s := <-accepted
+	require.NotNil(t, s)
+	defer s.Close()
+
+	_, authInfo, err := unixPeerCredentials{}.ServerHandshake(s)
+	require.NoError(t, err)
+	info, ok := authInfo.(unixPeerAuthInfo)
+	require.True(t, ok)
+	assert.Equal(t, uint32(os.Getuid()), info.UID)
+	assert.Equal(t, int32(os.Getpid()), info.Pid)
+
+	// Only the socket is left in its directory, and it is removed when
+	// the listener is closed
+	names, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, names, 1)
+	assert.Equal(t, "socket", names[0].Name())
+	lis.Close()
+	_, err = os.Stat(socketPath)
+	assert.True(t, os.IsNotExist(err))
+
+	// A stale socket is replaced
+	require.NoError(t, ioutil.WriteFile(socketPath, nil, 0600))
+	lis, err = listenUnix(socketPath, "0600", "")
+	require.NoError(t, err)
+	lis.Close()
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+)
+
+// UserFunctionCallTelemetryEvent is a telemetry event generated by the user
+// function call event source.
+type UserFunctionCallTelemetryEvent struct {
+	TelemetryEventData
+
+	Executable string
+	Symbol     string
+	OnReturn   bool
+	Arguments  perf.TraceEventSampleData
+}
+
+// CommonTelemetryEventData returns the telemtry event data common to all
+// telemetry events for a user function call telemetry event.
+func (e UserFunctionCallTelemetryEvent) CommonTelemetryEventData() TelemetryEventData {
+	return e.TelemetryEventData
+}
+
+func (s *Subscription) decodeUprobe(
+	sample *perf.SampleRecord,
+	data perf.TraceEventSampleData,
+	executable, symbol string,
+	onReturn bool,
+) (interface{}, error) {
+	var e UserFunctionCallTelemetryEvent
+	if !e.InitWithSample(s.sensor, sample, data) {
+		return nil, nil
+	}
+	e.Executable = executable
+	e.Symbol = symbol
+	e.OnReturn = onReturn
+	e.Arguments = data
+	return e, nil
+}
+
+// RegisterUserFunctionCallEventFilter registers a user function call event
+// filter with a subscription. The executable is the path of the executable or
+// shared library to probe as seen by the sensor; files inside of a container
+// may be probed via /proc/PID/root for any process in the container. If
+// symbol is empty, offset is used as the file offset of the instruction to
+// probe. If onReturn is true, the event is generated when the function returns
+// rather than when it is entered, and the return value may be fetched using
+// $retval in arguments. The offset must then be that of the function's first
+// instruction.
+func (s *Subscription) RegisterUserFunctionCallEventFilter(
+	executable string,
+	symbol string,
+	offset uint64,
+	onReturn bool,
+	arguments map[string]string,
+	filter *expression.Expression,
+) {
+	if !filepath.IsAbs(executable) {
+		s.logStatus(
+			fmt.Sprintf("User function call executable %q is not an absolute path",
+				executable))
+		return
+	}
+
+	// The symbol must begin with [A-Za-z_] and contain only [A-Za-z0-9_].
+	// The perf layer resolves it to an offset, because the kernel does not
+	// do symbol resolution for uprobes.
+	var address string
+	if symbol != "" {
+		if !validSymbolRegex.MatchString(symbol) {
+			s.logStatus(
+				fmt.Sprintf("User function call symbol %q is invalid", symbol))
+			return
+		}
+		address = symbol
+	} else if offset != 0 {
+		address = fmt.Sprintf("%#x", offset)
+		symbol = address
+	} else {
+		s.logStatus("User function call filter requires a symbol or offset")
+		return
+	}
+
+	l := make([]string, 0, len(arguments))
+	for k, v := range arguments {
+		l = append(l, fmt.Sprintf("%s=%s", k, v))
+	}
+	fetchargs := strings.Join(l, " ")
+
+	decoder := func(sample *perf.SampleRecord, data perf.TraceEventSampleData) (interface{}, error) {
+		return s.decodeUprobe(sample, data, executable, symbol, onReturn)
+	}
+
+	// Pass nil for filterTypes here to force the filter types to be
+	// determined dynamically after the uprobe is registered with the
+	// kernel.
+	s.registerUprobe(executable, address, onReturn, fetchargs, decoder,
+		filter, nil)
+}
@@ -0,0 +1,175 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/capsule8/capsule8/pkg/expression"
+	"github.com/capsule8/capsule8/pkg/sys"
+	"github.com/capsule8/capsule8/pkg/sys/perf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeUprobe(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+
+	sample := &perf.SampleRecord{
+		Time: uint64(sys.CurrentMonotonicRaw()),
+	}
+	data := perf.TraceEventSampleData{
+		"common_pid": int32(sensorPID),
+		"command":    "/bin/sh -c id",
+	}
+
+	i, err := s.decodeUprobe(sample, data, "/lib/libc.so.6", "system", false)
+	require.Nil(t, i)
+	require.NoError(t, err)
+
+	data["common_pid"] = int32(111343)
+	i, err = s.decodeUprobe(sample, data, "/lib/libc.so.6", "system", false)
+	require.NotNil(t, i)
+	require.NoError(t, err)
+	e, ok := i.(UserFunctionCallTelemetryEvent)
+	require.True(t, ok)
+
+	ok = testCommonTelemetryEventData(t, sensor, e)
+	require.True(t, ok)
+	assert.Equal(t, "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae",
+		e.Container.ID)
+	assert.Equal(t, "/lib/libc.so.6", e.Executable)
+	assert.Equal(t, "system", e.Symbol)
+	assert.False(t, e.OnReturn)
+	assert.Equal(t, data, e.Arguments)
+
+	data = perf.TraceEventSampleData{
+		"common_pid": int32(111343),
+		"ret":        int32(7),
+	}
+	i, err = s.decodeUprobe(sample, data, "/lib/libpam.so.0", "pam_authenticate", true)
+	require.NoError(t, err)
+	e = i.(UserFunctionCallTelemetryEvent)
+	assert.Equal(t, "pam_authenticate", e.Symbol)
+	assert.True(t, e.OnReturn)
+	assert.Equal(t, int32(7), e.Arguments["ret"])
+}
+
+func prepareForRegisterUserFunctionCallEventFilter(t *testing.T, s *Subscription) {
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_ip;	offset:8;	size:8;	signed:0;
+	field:__data_loc char[] command;	offset:16;	size:4;	signed:1;
+
+print fmt: "(%lx) command=\"%s\"", REC->__probe_ip, __get_str(command)`
+
+	newUnitTestKprobe(t, s.sensor, 0, format)
+}
+
+func TestRegisterUserFunctionCallEventFilter(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	arguments := map[string]string{
+		"command": "+0(%di):string",
+	}
+
+	e := expression.Equal(expression.Identifier("command"),
+		expression.Value("/bin/sh"))
+	expr, err := expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s := newTestSubscription(t, sensor)
+	prepareForRegisterUserFunctionCallEventFilter(t, s)
+	s.RegisterUserFunctionCallEventFilter("/lib/libc.so.6", "", 0x4f440,
+		false, arguments, expr)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+
+	definitions, err := ioutil.ReadFile(filepath.Join(sensor.tracingDir,
+		"uprobe_events"))
+	require.NoError(t, err)
+	assert.Contains(t, string(definitions), " /lib/libc.so.6:0x4f440 command=+0(%di):string")
+
+	format := `name: ^^NAME^^
+id: ^^ID^^
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned long __probe_func;	offset:8;	size:8;	signed:0;
+	field:unsigned long __probe_ret_ip;	offset:16;	size:8;	signed:0;
+	field:s32 ret;	offset:24;	size:4;	signed:1;
+
+print fmt: "(%lx <- %lx) ret=%d", REC->__probe_func, REC->__probe_ret_ip, REC->ret`
+
+	s = newTestSubscription(t, sensor)
+	newUnitTestKprobe(t, s.sensor, 0, format)
+	s.RegisterUserFunctionCallEventFilter("/lib/libpam.so.0", "", 0x7b30,
+		true, map[string]string{"ret": "$retval:s32"}, nil)
+	assert.Len(t, s.eventSinks, 1)
+	assert.Len(t, s.status, 0)
+
+	definitions, err = ioutil.ReadFile(filepath.Join(sensor.tracingDir,
+		"uprobe_events"))
+	require.NoError(t, err)
+	assert.Contains(t, string(definitions), "r:")
+	assert.Contains(t, string(definitions), " /lib/libpam.so.0:0x7b30 ret=$retval:s32")
+
+	e = expression.Equal(expression.Identifier("foo"), expression.Value("bar"))
+	expr, err = expression.NewExpression(e)
+	require.NoError(t, err)
+
+	s = newTestSubscription(t, sensor)
+	prepareForRegisterUserFunctionCallEventFilter(t, s)
+	s.RegisterUserFunctionCallEventFilter("/lib/libc.so.6", "", 0x4f440,
+		false, arguments, expr)
+	assert.Len(t, s.eventSinks, 0)
+	assert.Len(t, s.status, 1)
+
+	type testCase struct {
+		executable string
+		symbol     string
+		offset     uint64
+	}
+	invalidCases := []testCase{
+		testCase{"lib/libc.so.6", "system", 0},
+		testCase{"/lib/libc.so.6", "system+4", 0},
+		testCase{"/lib/libc.so.6", "", 0},
+		testCase{os.Args[0], "no_such_symbol_in_test_binary", 0},
+	}
+	for _, tc := range invalidCases {
+		s = newTestSubscription(t, sensor)
+		s.RegisterUserFunctionCallEventFilter(tc.executable, tc.symbol,
+			tc.offset, false, nil, nil)
+		assert.Len(t, s.eventSinks, 0, "%#v", tc)
+		assert.Len(t, s.status, 1, "%#v", tc)
+	}
+}
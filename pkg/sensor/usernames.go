@@ -0,0 +1,122 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/sys/proc"
+)
+
+// User and group names are resolved using the /etc/passwd and /etc/group
+// files seen by the process, which are read through /proc/<pid>/root so that
+// containerized processes use their container's files rather than the
+// host's. The files are cached for each container (and the host), and are
+// read again once they are older than the cache's TTL. Files that cannot be
+// read are cached as empty, so that IDs are not resolved for a container
+// until then.
+
+// userNames holds the user and group names of one container or the host.
+type userNames struct {
+	loaded time.Time
+	users  map[uint32]string
+	groups map[uint32]string
+}
+
+// userNameCache caches the user and group names of containers by container
+// ID. The host's are cached with an empty ID.
+type userNameCache struct {
+	sync.Mutex
+	procFS  proc.FileSystem
+	ttl     time.Duration
+	entries map[string]*userNames
+}
+
+func newUserNameCache(procFS proc.FileSystem, ttl time.Duration) *userNameCache {
+	return &userNameCache{
+		procFS:  procFS,
+		ttl:     ttl,
+		entries: make(map[string]*userNames),
+	}
+}
+
+// lookup returns the user and group names of a container, reading them from
+// the root directory of the process tgid in it if they are not cached.
+func (c *userNameCache) lookup(containerID string, tgid int) *userNames {
+	c.Lock()
+	defer c.Unlock()
+
+	n, ok := c.entries[containerID]
+	if ok && (tgid <= 0 || time.Since(n.loaded) < c.ttl) {
+		return n
+	}
+	n = &userNames{loaded: time.Now()}
+	if tgid > 0 {
+		if b, err := c.procFS.ReadProcessRootFile(tgid, "/etc/passwd"); err == nil {
+			n.users = parseIDNames(b)
+		}
+		if b, err := c.procFS.ReadProcessRootFile(tgid, "/etc/group"); err == nil {
+			n.groups = parseIDNames(b)
+		}
+	}
+	c.entries[containerID] = n
+	return n
+}
+
+// resolve sets the user and group names of credentials belonging to the
+// process tgid in a container.
+func (c *userNameCache) resolve(
+	cred *api.Credentials,
+	containerID string,
+	tgid int,
+) {
+	n := c.lookup(containerID, tgid)
+	cred.UidName = n.users[cred.Uid]
+	cred.GidName = n.groups[cred.Gid]
+	cred.EuidName = n.users[cred.Euid]
+	cred.EgidName = n.groups[cred.Egid]
+}
+
+// parseIDNames parses the names and IDs of an /etc/passwd or /etc/group
+// file, both of which have the name as the first field and the ID as the
+// third. The first name for an ID is used if there are several.
+func parseIDNames(b []byte) map[uint32]string {
+	names := make(map[uint32]string)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		fields := strings.SplitN(line, ":", 4)
+		if len(fields) < 3 || len(fields[0]) == 0 {
+			continue
+		}
+		id, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			continue
+		}
+		if _, ok := names[uint32(id)]; !ok {
+			names[uint32(id)] = fields[0]
+		}
+	}
+	return names
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sensor
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIDNames(t *testing.T) {
+	names := parseIDNames([]byte(`root:x:0:0:root:/root:/bin/bash
+# comment
+
+toor:x:0:0::/root:/bin/sh
+nobody:x:65534:65534::/:/usr/sbin/nologin
+bad:x:notanumber:0::/:
+:x:12:12::/:
+`))
+	assert.Equal(t, map[uint32]string{
+		0:     "root",
+		65534: "nobody",
+	}, names)
+}
+
+func TestUserNameCache(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	c := newUserNameCache(sensor.ProcFS, time.Hour)
+	containerID := "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae"
+
+	cred := &api.Credentials{Uid: 999, Gid: 33, Euid: 0, Egid: 999}
+	c.resolve(cred, containerID, 111343)
+	assert.Equal(t, "redis", cred.UidName)
+	assert.Equal(t, "www-data", cred.GidName)
+	assert.Equal(t, "root", cred.EuidName)
+	assert.Equal(t, "redis", cred.EgidName)
+
+	// Cached names are used until they expire
+	c.entries[containerID].users[999] = "cached"
+	cred = &api.Credentials{Uid: 999}
+	c.resolve(cred, containerID, 111343)
+	assert.Equal(t, "cached", cred.UidName)
+	c.entries[containerID].loaded = time.Now().Add(-2 * time.Hour)
+	c.resolve(cred, containerID, 111343)
+	assert.Equal(t, "redis", cred.UidName)
+
+	// Unknown IDs and containers have no names
+	cred = &api.Credentials{Uid: 1234}
+	c.resolve(cred, containerID, 111343)
+	assert.Empty(t, cred.UidName)
+	cred = &api.Credentials{Uid: 999}
+	c.resolve(cred, "unknown", 3322)
+	assert.Empty(t, cred.UidName)
+}
+
+func TestTranslateUserNames(t *testing.T) {
+	sensor := newUnitTestSensor(t)
+	defer sensor.Stop()
+
+	s := newTestSubscription(t, sensor)
+	got := s.translateEvent(ProcessCredChangeTelemetryEvent{
+		TelemetryEventData: TelemetryEventData{
+			PID:            111343,
+			TGID:           111343,
+			HasCredentials: true,
+			Credentials:    Cred{UID: 999, EUID: 999},
+		},
+		OldCreds: Cred{UID: 33},
+		NewCreds: Cred{UID: 999},
+	})
+	assert.Equal(t, "redis", got.Credentials.UidName)
+	assert.Equal(t, "root", got.Credentials.GidName)
+	assert.Equal(t, "www-data", got.GetProcess().CredChangeOld.UidName)
+	assert.Equal(t, "redis", got.GetProcess().CredChangeNew.UidName)
+}
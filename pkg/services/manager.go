@@ -80,7 +80,7 @@ func (sm *ServiceManager) RegisterService(service Service) {
 // Run starts and runs all services registered with a ServiceManager. This
 // function does not return until the ServiceManager is stopped via Stop.
 func (sm *ServiceManager) Run() {
-	sigChan := make(chan os.Signal)
+	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt)
 	signal.Notify(sigChan, syscall.SIGTERM)
 
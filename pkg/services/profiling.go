@@ -16,29 +16,63 @@ package services
 
 import (
 	"net/http"
-
-	// Include pprof
-	_ "net/http/pprof"
+	"net/http/pprof"
+	"runtime"
 
 	"golang.org/x/net/context"
 
 	"github.com/golang/glog"
 )
 
+// ProfilingServiceOption represents options passed into the profiling
+// service constructor
+type ProfilingServiceOption func(*profilingServiceOptions)
+
+type profilingServiceOptions struct {
+	blockProfileRate     int
+	mutexProfileFraction int
+}
+
+// WithBlockProfileRate enables the block profile, sampling one blocking event
+// for about every rate nanoseconds spent blocked. See
+// runtime.SetBlockProfileRate.
+func WithBlockProfileRate(rate int) ProfilingServiceOption {
+	return func(o *profilingServiceOptions) {
+		o.blockProfileRate = rate
+	}
+}
+
+// WithMutexProfileFraction enables the mutex profile, sampling one in
+// fraction contended mutex events. See runtime.SetMutexProfileFraction.
+func WithMutexProfileFraction(fraction int) ProfilingServiceOption {
+	return func(o *profilingServiceOptions) {
+		o.mutexProfileFraction = fraction
+	}
+}
+
 // ProfilingService is a service that returns profiling information via a
 // HTTP server.
 type ProfilingService struct {
 	server *http.Server
 
 	address string
+
+	options profilingServiceOptions
 }
 
 // NewProfilingService creates a new ProfilingService instance bound to
 // a specified address.
-func NewProfilingService(address string) *ProfilingService {
-	return &ProfilingService{
+func NewProfilingService(
+	address string,
+	options ...ProfilingServiceOption,
+) *ProfilingService {
+	ps := &ProfilingService{
 		address: address,
 	}
+	for _, o := range options {
+		o(&ps.options)
+	}
+	return ps
 }
 
 // Name returns a human-readable name for a ProfilingService.
@@ -52,8 +86,27 @@ func (ps *ProfilingService) Serve() error {
 	glog.V(1).Infof("Serving profiling HTTP endpoints on %s",
 		ps.address)
 
+	// Block and mutex profiles are empty unless their sampling is
+	// enabled, since it costs something for every event sampled.
+	if ps.options.blockProfileRate > 0 {
+		runtime.SetBlockProfileRate(ps.options.blockProfileRate)
+	}
+	if ps.options.mutexProfileFraction > 0 {
+		runtime.SetMutexProfileFraction(ps.options.mutexProfileFraction)
+	}
+
+	// The pprof handlers are registered with a mux of our own rather
+	// than the default mux, so that they are only served here.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
 	ps.server = &http.Server{
-		Addr: ps.address,
+		Addr:    ps.address,
+		Handler: mux,
 	}
 
 	err := ps.server.ListenAndServe()
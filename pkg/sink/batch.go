@@ -0,0 +1,175 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+)
+
+// BatcherOption is used to implement optional arguments for NewBatcher.
+// It must be exported, but it is not typically used directly.
+type BatcherOption func(*batcherOptions)
+
+type batcherOptions struct {
+	size     int
+	interval time.Duration
+	retries  int
+	backoff  time.Duration
+}
+
+// WithBatchSize sets the largest number of events published together. The
+// default is 100.
+func WithBatchSize(size int) BatcherOption {
+	return func(o *batcherOptions) {
+		o.size = size
+	}
+}
+
+// WithBatchInterval sets the longest time that an event waits to be
+// published with others. The default is 1 second.
+func WithBatchInterval(interval time.Duration) BatcherOption {
+	return func(o *batcherOptions) {
+		o.interval = interval
+	}
+}
+
+// WithRetries sets the number of times that publishing a batch is retried
+// before its events are dropped. The default is 3.
+func WithRetries(retries int) BatcherOption {
+	return func(o *batcherOptions) {
+		o.retries = retries
+	}
+}
+
+// WithRetryBackoff sets the time waited before the first retry, which is
+// doubled for each retry after it. The default is 100 milliseconds.
+func WithRetryBackoff(backoff time.Duration) BatcherOption {
+	return func(o *batcherOptions) {
+		o.backoff = backoff
+	}
+}
+
+// Batcher publishes events to a sink in batches, retrying batches that
+// could not be published.
+type Batcher struct {
+	sink    Sink
+	options batcherOptions
+}
+
+// NewBatcher creates a new Batcher that publishes events to a sink.
+func NewBatcher(s Sink, options ...BatcherOption) *Batcher {
+	b := &Batcher{
+		sink: s,
+		options: batcherOptions{
+			size:     100,
+			interval: time.Second,
+			retries:  3,
+			backoff:  100 * time.Millisecond,
+		},
+	}
+	for _, option := range options {
+		option(&b.options)
+	}
+	if b.options.size < 1 {
+		b.options.size = 1
+	}
+	return b
+}
+
+// Run publishes the events received from a channel until ctx is done or
+// the channel is closed. The events pending then, including those still
+// queued in the channel, are published before the sink is closed.
+func (b *Batcher) Run(ctx context.Context, events <-chan *api.TelemetryEvent) {
+	defer b.sink.Close()
+
+	batch := make([]*api.TelemetryEvent, 0, b.options.size)
+	flush := func() {
+		if len(batch) > 0 {
+			b.publish(ctx, batch)
+			batch = make([]*api.TelemetryEvent, 0, b.options.size)
+		}
+	}
+	add := func(e *api.TelemetryEvent) {
+		batch = append(batch, e)
+		if len(batch) >= b.options.size {
+			flush()
+		}
+	}
+
+	var tick <-chan time.Time
+	if b.options.interval > 0 {
+		ticker := time.NewTicker(b.options.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			for drained := false; !drained; {
+				select {
+				case e, ok := <-events:
+					if ok {
+						add(e)
+					} else {
+						drained = true
+					}
+				default:
+					drained = true
+				}
+			}
+			flush()
+			return
+		case e, ok := <-events:
+			if !ok {
+				flush()
+				return
+			}
+			add(e)
+		case <-tick:
+			flush()
+		}
+	}
+}
+
+// publish publishes a batch of events, retrying until it succeeds or the
+// retries are used up. Once ctx is done, the batch is tried only once
+// more.
+func (b *Batcher) publish(ctx context.Context, batch []*api.TelemetryEvent) {
+	backoff := b.options.backoff
+	for attempt := 0; ; attempt++ {
+		err := b.sink.Publish(batch)
+		if err == nil {
+			return
+		}
+		if attempt >= b.options.retries || ctx.Err() != nil {
+			glog.Errorf("Dropping %d events that could not be published: %v",
+				len(batch), err)
+			return
+		}
+		glog.V(1).Infof("Could not publish %d events, retrying: %v",
+			len(batch), err)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/version"
+)
+
+// Events are rendered in CEF (ArcSight Common Event Format) and LEEF
+// (QRadar Log Event Extended Format) with the event type as the signature
+// or event ID. Container, process, and file fields are mapped to the
+// standard CEF extensions where there is one, and to labeled custom
+// strings where there is not. LEEF allows custom attributes, so they are
+// given their own names there.
+
+const (
+	eventVendor  = "Capsule8"
+	eventProduct = "Sensor"
+
+	// CEF severity of events, which is "Low" since the sensor reports
+	// what happened rather than judging it
+	cefSeverity = 3
+)
+
+// eventAttribute is a field of an event with its CEF extension key and its
+// LEEF attribute name. The CEF label is set for custom string extensions.
+type eventAttribute struct {
+	cef      string
+	cefLabel string
+	leef     string
+	value    string
+}
+
+// eventSubtype returns the name of the type of an event within its event
+// type, i.e. "PROCESS_EVENT_TYPE_EXEC", or the event type if it has none.
+func eventSubtype(e *api.TelemetryEvent) string {
+	if e.Event != nil {
+		v := reflect.ValueOf(e.Event).Elem()
+		if v.NumField() > 0 && v.Field(0).Kind() == reflect.Ptr &&
+			!v.Field(0).IsNil() {
+			t := v.Field(0).Elem().FieldByName("Type")
+			if t.IsValid() {
+				if s, ok := t.Interface().(fmt.Stringer); ok {
+					return s.String()
+				}
+			}
+		}
+	}
+	return EventType(e)
+}
+
+func eventAttributes(e *api.TelemetryEvent, now time.Time) []eventAttribute {
+	attrs := []eventAttribute{
+		{cef: "rt", leef: "devTime", value: strconv.FormatInt(
+			now.UnixNano()/int64(time.Millisecond), 10)},
+		{cef: "externalId", leef: "eventId", value: e.Id},
+		{cef: "deviceExternalId", leef: "sensorId", value: e.SensorId},
+		{cef: "cs1", cefLabel: "containerId", leef: "containerId",
+			value: e.ContainerId},
+		{cef: "cs2", cefLabel: "containerName", leef: "containerName",
+			value: e.ContainerName},
+		{cef: "cs3", cefLabel: "imageName", leef: "imageName",
+			value: e.ImageName},
+		{cef: "cs4", cefLabel: "imageId", leef: "imageId",
+			value: e.ImageId},
+		{cef: "cs5", cefLabel: "processId", leef: "processId",
+			value: e.ProcessId},
+	}
+	if e.ProcessTgid != 0 {
+		attrs = append(attrs, eventAttribute{cef: "spid", leef: "pid",
+			value: strconv.Itoa(int(e.ProcessTgid))})
+	}
+	if c := e.Credentials; c != nil {
+		attrs = append(attrs,
+			eventAttribute{cef: "suid", leef: "uid",
+				value: strconv.FormatUint(uint64(c.Uid), 10)},
+			eventAttribute{cef: "cn1", cefLabel: "gid", leef: "gid",
+				value: strconv.FormatUint(uint64(c.Gid), 10)})
+	}
+
+	switch event := e.Event.(type) {
+	case *api.TelemetryEvent_Process:
+		if p := event.Process; p != nil && p.ExecFilename != "" {
+			attrs = append(attrs,
+				eventAttribute{cef: "filePath", leef: "filePath",
+					value: p.ExecFilename},
+				eventAttribute{cef: "cs6", cefLabel: "commandLine",
+					leef:  "commandLine",
+					value: strings.Join(p.ExecCommandLine, " ")})
+		}
+	case *api.TelemetryEvent_File:
+		if f := event.File; f != nil {
+			attrs = append(attrs, eventAttribute{cef: "filePath",
+				leef: "filePath", value: f.Filename})
+		}
+	}
+	return attrs
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`,
+		"\n", `\n`, "\r", `\r`)
+	leefValueEscaper = strings.NewReplacer("\t", `\t`, "\n", `\n`,
+		"\r", `\r`)
+)
+
+// marshalCEF renders an event as a CEF record, i.e.
+//
+//	CEF:0|Capsule8|Sensor|0.1.0|process|PROCESS_EVENT_TYPE_EXEC|3|rt=...
+func marshalCEF(e *api.TelemetryEvent, now time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|", eventVendor, eventProduct,
+		cefHeaderEscaper.Replace(version.Version),
+		cefHeaderEscaper.Replace(EventType(e)),
+		cefHeaderEscaper.Replace(eventSubtype(e)),
+		cefSeverity)
+
+	first := true
+	for _, a := range eventAttributes(e, now) {
+		if a.value == "" {
+			continue
+		}
+		if a.cefLabel != "" {
+			if !first {
+				b.WriteByte(' ')
+			}
+			first = false
+			fmt.Fprintf(&b, "%sLabel=%s", a.cef,
+				cefExtensionEscaper.Replace(a.cefLabel))
+		}
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		fmt.Fprintf(&b, "%s=%s", a.cef, cefExtensionEscaper.Replace(a.value))
+	}
+	return b.Bytes()
+}
+
+// marshalLEEF renders an event as a tab-delimited LEEF 1.0 record, i.e.
+//
+//	LEEF:1.0|Capsule8|Sensor|0.1.0|PROCESS_EVENT_TYPE_EXEC|cat=process...
+func marshalLEEF(e *api.TelemetryEvent, now time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "LEEF:1.0|%s|%s|%s|%s|cat=%s\tsev=%d", eventVendor,
+		eventProduct, cefHeaderEscaper.Replace(version.Version),
+		cefHeaderEscaper.Replace(eventSubtype(e)),
+		leefValueEscaper.Replace(EventType(e)), cefSeverity)
+	for _, a := range eventAttributes(e, now) {
+		if a.value != "" {
+			fmt.Fprintf(&b, "\t%s=%s", a.leef,
+				leefValueEscaper.Replace(a.value))
+		}
+	}
+	return b.Bytes()
+}
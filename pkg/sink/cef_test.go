@@ -0,0 +1,82 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+	"github.com/capsule8/capsule8/pkg/version"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var cefTestEvent = &api.TelemetryEvent{
+	Id:          "id",
+	SensorId:    "sensor",
+	ContainerId: "container",
+	ImageName:   "busybox",
+	ProcessId:   "process",
+	ProcessTgid: 42,
+	Credentials: &api.Credentials{
+		Uid: 1000,
+		Gid: 100,
+	},
+	Event: &api.TelemetryEvent_Process{
+		Process: &api.ProcessEvent{
+			Type:            api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+			ExecFilename:    "/bin/sh",
+			ExecCommandLine: []string{"sh", "-c", "a=b|c"},
+		},
+	},
+}
+
+func TestCEF(t *testing.T) {
+	saved := version.Version
+	version.Version = "1.0|rc"
+	defer func() { version.Version = saved }()
+
+	now := time.Unix(1500000000, 0)
+	assert.Equal(t, `CEF:0|Capsule8|Sensor|1.0\|rc|process|`+
+		`PROCESS_EVENT_TYPE_EXEC|3|rt=1500000000000 externalId=id `+
+		`deviceExternalId=sensor cs1Label=containerId cs1=container `+
+		`cs3Label=imageName cs3=busybox cs5Label=processId cs5=process `+
+		`spid=42 suid=1000 cn1Label=gid cn1=100 filePath=/bin/sh `+
+		`cs6Label=commandLine cs6=sh -c a\=b|c`,
+		string(marshalCEF(cefTestEvent, now)))
+
+	assert.Equal(t, `CEF:0|Capsule8|Sensor|1.0\|rc|ticker|ticker|3|`+
+		`rt=1500000000000`,
+		string(marshalCEF(&api.TelemetryEvent{
+			Event: &api.TelemetryEvent_Ticker{
+				Ticker: &api.TickerEvent{},
+			},
+		}, now)))
+}
+
+func TestLEEF(t *testing.T) {
+	saved := version.Version
+	version.Version = "1.0"
+	defer func() { version.Version = saved }()
+
+	now := time.Unix(1500000000, 0)
+	assert.Equal(t, "LEEF:1.0|Capsule8|Sensor|1.0|PROCESS_EVENT_TYPE_EXEC|"+
+		"cat=process\tsev=3\tdevTime=1500000000000\teventId=id"+
+		"\tsensorId=sensor\tcontainerId=container\timageName=busybox"+
+		"\tprocessId=process\tpid=42\tuid=1000\tgid=100"+
+		"\tfilePath=/bin/sh\tcommandLine=sh -c a=b|c",
+		string(marshalLEEF(cefTestEvent, now)))
+}
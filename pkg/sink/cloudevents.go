@@ -0,0 +1,62 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+)
+
+// cloudEventTypePrefix is prepended to the event type to form the type of a
+// CloudEvent, i.e. "com.capsule8.sensor.process".
+const cloudEventTypePrefix = "com.capsule8.sensor."
+
+// cloudEvent is a CloudEvents 1.0 envelope in the JSON event format.
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// marshalCloudEvent wraps the JSON form of an event in a CloudEvent whose
+// source is the sensor ID and whose subject is the container ID, if any.
+func marshalCloudEvent(e *api.TelemetryEvent, now time.Time) ([]byte, error) {
+	var data bytes.Buffer
+	if err := jsonMarshaler.Marshal(&data, e); err != nil {
+		return nil, err
+	}
+	eventType := EventType(e)
+	if eventType == "" {
+		eventType = "unknown"
+	}
+	return json.Marshal(&cloudEvent{
+		SpecVersion:     "1.0",
+		ID:              e.Id,
+		Source:          e.SensorId,
+		Type:            cloudEventTypePrefix + eventType,
+		Subject:         e.ContainerId,
+		Time:            now.UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		Data:            data.Bytes(),
+	})
+}
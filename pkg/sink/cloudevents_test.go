@@ -0,0 +1,60 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudEvent(t *testing.T) {
+	now := time.Date(2018, 3, 1, 12, 0, 0, 500, time.UTC)
+	b, err := marshalCloudEvent(&api.TelemetryEvent{
+		Id:          "id",
+		SensorId:    "sensor",
+		ContainerId: "container",
+		Event: &api.TelemetryEvent_Process{
+			Process: &api.ProcessEvent{
+				ExecFilename: "/bin/sh",
+			},
+		},
+	}, now)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"specversion": "1.0",
+		"id": "id",
+		"source": "sensor",
+		"type": "com.capsule8.sensor.process",
+		"subject": "container",
+		"time": "2018-03-01T12:00:00.0000005Z",
+		"datacontenttype": "application/json",
+		"data": {
+			"id": "id",
+			"sensorId": "sensor",
+			"containerId": "container",
+			"process": {"execFilename": "/bin/sh"}
+		}
+	}`, string(b))
+
+	b, err = marshalCloudEvent(&api.TelemetryEvent{Id: "id"}, now)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"type":"com.capsule8.sensor.unknown"`)
+	assert.NotContains(t, string(b), `"subject"`)
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Compression is the compression of serialized batches of events sent by
+// a sink.
+type Compression int
+
+const (
+	// CompressionNone sends batches uncompressed.
+	CompressionNone Compression = iota
+
+	// CompressionGzip compresses batches with gzip.
+	CompressionGzip
+)
+
+// ParseCompression returns the Compression named by s: "none" (or "") or
+// "gzip". zstd is not supported yet, since there is no zstd implementation
+// among the sensor's dependencies.
+func ParseCompression(s string) (Compression, error) {
+	switch strings.ToLower(s) {
+	case "", "none":
+		return CompressionNone, nil
+	case "gzip":
+		return CompressionGzip, nil
+	case "zstd":
+		return 0, errors.New("zstd compression is not supported yet")
+	}
+	return 0, fmt.Errorf("compression %q is invalid", s)
+}
+
+// contentEncoding returns the HTTP Content-Encoding of the compression.
+func (c Compression) contentEncoding() string {
+	if c == CompressionGzip {
+		return "gzip"
+	}
+	return ""
+}
+
+// compress returns b compressed.
+func (c Compression) compress(b []byte) ([]byte, error) {
+	if c != CompressionGzip {
+		return b, nil
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
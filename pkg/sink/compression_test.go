@@ -0,0 +1,60 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompression(t *testing.T) {
+	for s, expected := range map[string]Compression{
+		"":     CompressionNone,
+		"none": CompressionNone,
+		"GZIP": CompressionGzip,
+	} {
+		c, err := ParseCompression(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, c, s)
+	}
+	for _, s := range []string{"zstd", "lz4"} {
+		_, err := ParseCompression(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestCompress(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"dockerConfigJson":"..."}`), 100)
+
+	b, err := CompressionNone.compress(data)
+	require.NoError(t, err)
+	assert.Equal(t, data, b)
+	assert.Empty(t, CompressionNone.contentEncoding())
+
+	b, err = CompressionGzip.compress(data)
+	require.NoError(t, err)
+	assert.True(t, len(b) < len(data)/10)
+	assert.Equal(t, "gzip", CompressionGzip.contentEncoding())
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	require.NoError(t, err)
+	decompressed, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, data, decompressed)
+}
@@ -0,0 +1,360 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+)
+
+// Events are indexed with the bulk API into daily indices per event type,
+// named "<prefix>-<type>-YYYY.MM.DD", with the event ID as the document ID
+// so that retried events are not duplicated. Each document is the JSON form
+// of an event with an "@timestamp" of when it was indexed. Before the first
+// batch, an index template is installed for the indices so that the IDs in
+// events are indexed as keywords rather than as text.
+
+// ElasticsearchOption is used to implement optional arguments for
+// NewElasticsearchSink. It must be exported, but it is not typically used
+// directly.
+type ElasticsearchOption func(*elasticsearchOptions)
+
+type elasticsearchOptions struct {
+	indexPrefix string
+	username    string
+	password    string
+	template    bool
+	retries     int
+	backoff     time.Duration
+	compression Compression
+	client      *http.Client
+}
+
+// WithElasticsearchIndexPrefix sets the prefix of the names of indices. The
+// default is "capsule8".
+func WithElasticsearchIndexPrefix(prefix string) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.indexPrefix = prefix
+	}
+}
+
+// WithElasticsearchBasicAuth sets the username and password used to
+// authenticate with the cluster.
+func WithElasticsearchBasicAuth(username, password string) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithElasticsearchTemplate sets whether the index template is installed.
+// The default is true.
+func WithElasticsearchTemplate(template bool) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.template = template
+	}
+}
+
+// WithElasticsearchRetries sets the number of times that events rejected
+// because the cluster is overloaded (429 Too Many Requests) are retried
+// within a batch. The default is 3.
+func WithElasticsearchRetries(retries int) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.retries = retries
+	}
+}
+
+// WithElasticsearchBackoff sets the time waited before first retrying
+// rejected events, which is doubled for each retry after it. The default
+// is 500 milliseconds.
+func WithElasticsearchBackoff(backoff time.Duration) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.backoff = backoff
+	}
+}
+
+// WithElasticsearchCompression sets the compression of request bodies,
+// which the cluster must allow with http.compression. The default is
+// CompressionNone.
+func WithElasticsearchCompression(compression Compression) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.compression = compression
+	}
+}
+
+// WithElasticsearchHTTPClient sets the HTTP client used to make requests.
+func WithElasticsearchHTTPClient(client *http.Client) ElasticsearchOption {
+	return func(o *elasticsearchOptions) {
+		o.client = client
+	}
+}
+
+// ElasticsearchSink indexes events in an Elasticsearch cluster.
+type ElasticsearchSink struct {
+	url     string
+	options elasticsearchOptions
+
+	mutex             sync.Mutex
+	templateInstalled bool
+}
+
+// NewElasticsearchSink creates a new sink that indexes events in the
+// cluster at url, i.e. "http://localhost:9200".
+func NewElasticsearchSink(url string, options ...ElasticsearchOption) (*ElasticsearchSink, error) {
+	if url == "" {
+		return nil, errors.New("Elasticsearch URL is empty")
+	}
+	s := &ElasticsearchSink{
+		url: strings.TrimRight(url, "/"),
+		options: elasticsearchOptions{
+			indexPrefix: "capsule8",
+			template:    true,
+			retries:     3,
+			backoff:     500 * time.Millisecond,
+			client:      &http.Client{Timeout: 30 * time.Second},
+		},
+	}
+	for _, option := range options {
+		option(&s.options)
+	}
+	if s.options.indexPrefix == "" {
+		return nil, errors.New("Elasticsearch index prefix is empty")
+	}
+	return s, nil
+}
+
+func (s *ElasticsearchSink) do(method, path string, body []byte, contentType string) ([]byte, int, error) {
+	body, err := s.options.compression.compress(body)
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequest(method, s.url+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if ce := s.options.compression.contentEncoding(); ce != "" {
+		req.Header.Set("Content-Encoding", ce)
+	}
+	if s.options.username != "" {
+		req.SetBasicAuth(s.options.username, s.options.password)
+	}
+	resp, err := s.options.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	return b, resp.StatusCode, err
+}
+
+// installTemplate installs the index template for the sink's indices.
+func (s *ElasticsearchSink) installTemplate() error {
+	keyword := map[string]string{"type": "keyword"}
+	template := map[string]interface{}{
+		"index_patterns": []string{s.options.indexPrefix + "-*"},
+		"template": map[string]interface{}{
+			"mappings": map[string]interface{}{
+				"properties": map[string]interface{}{
+					"@timestamp":  map[string]string{"type": "date"},
+					"id":          keyword,
+					"sensorId":    keyword,
+					"processId":   keyword,
+					"containerId": keyword,
+					"imageId":     keyword,
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+	b, status, err := s.do("PUT", "/_index_template/"+s.options.indexPrefix,
+		body, "application/json")
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("Could not install Elasticsearch index template (%d): %s",
+			status, b)
+	}
+	return nil
+}
+
+// elasticsearchDocument returns the JSON form of an event with the time
+// that it is indexed.
+func elasticsearchDocument(e *api.TelemetryEvent, now time.Time) ([]byte, error) {
+	b, err := EncodingJSON.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	timestamp := fmt.Sprintf(`{"@timestamp":"%s"`,
+		now.UTC().Format(time.RFC3339Nano))
+	if len(b) <= 2 {
+		return []byte(timestamp + "}"), nil
+	}
+	return append([]byte(timestamp+","), b[1:]...), nil
+}
+
+type elasticsearchBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// bulk indexes documents, returning those that were rejected because the
+// cluster is overloaded.
+func (s *ElasticsearchSink) bulk(actions, documents [][]byte) ([]int, error) {
+	var body bytes.Buffer
+	for i := range actions {
+		body.Write(actions[i])
+		body.WriteByte('\n')
+		body.Write(documents[i])
+		body.WriteByte('\n')
+	}
+	b, status, err := s.do("POST", "/_bulk", body.Bytes(),
+		"application/x-ndjson")
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusTooManyRequests {
+		indices := make([]int, len(actions))
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices, nil
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Elasticsearch bulk request failed (%d): %s",
+			status, b)
+	}
+
+	var resp elasticsearchBulkResponse
+	if err = json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.Errors {
+		return nil, nil
+	}
+	var rejected []int
+	for i, item := range resp.Items {
+		for _, result := range item {
+			switch {
+			case result.Status == http.StatusTooManyRequests:
+				rejected = append(rejected, i)
+			case result.Status >= 300:
+				// Documents that cannot be indexed will not be
+				// indexed by retrying either
+				glog.Warningf("Could not index event: %s: %s",
+					result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+	return rejected, nil
+}
+
+// Publish indexes a batch of events. Events that the cluster rejects
+// because it is overloaded are retried with backoff.
+func (s *ElasticsearchSink) Publish(events []*api.TelemetryEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.options.template && !s.templateInstalled {
+		if err := s.installTemplate(); err != nil {
+			return err
+		}
+		s.templateInstalled = true
+	}
+
+	now := time.Now()
+	date := now.UTC().Format("2006.01.02")
+	var actions, documents [][]byte
+	for _, e := range events {
+		doc, err := elasticsearchDocument(e, now)
+		if err != nil {
+			glog.Warningf("Could not serialize event %s: %v", e.Id, err)
+			continue
+		}
+		eventType := EventType(e)
+		if eventType == "" {
+			eventType = "unknown"
+		}
+		action := map[string]map[string]string{
+			"index": {
+				"_index": fmt.Sprintf("%s-%s-%s",
+					s.options.indexPrefix, eventType, date),
+			},
+		}
+		if e.Id != "" {
+			action["index"]["_id"] = e.Id
+		}
+		b, err := json.Marshal(action)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, b)
+		documents = append(documents, doc)
+	}
+
+	backoff := s.options.backoff
+	for attempt := 0; len(actions) > 0; attempt++ {
+		rejected, err := s.bulk(actions, documents)
+		if err != nil {
+			return err
+		}
+		if len(rejected) == 0 {
+			return nil
+		}
+		if attempt >= s.options.retries {
+			return fmt.Errorf("Elasticsearch rejected %d events (429)",
+				len(rejected))
+		}
+		glog.V(1).Infof("Elasticsearch rejected %d events, retrying in %s",
+			len(rejected), backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+
+		var retryActions, retryDocuments [][]byte
+		for _, i := range rejected {
+			retryActions = append(retryActions, actions[i])
+			retryDocuments = append(retryDocuments, documents[i])
+		}
+		actions, documents = retryActions, retryDocuments
+	}
+	return nil
+}
+
+// Close does nothing, since requests are not left in progress.
+func (s *ElasticsearchSink) Close() error {
+	return nil
+}
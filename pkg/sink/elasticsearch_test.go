@@ -0,0 +1,189 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeElasticsearch struct {
+	mutex     sync.Mutex
+	templates map[string]interface{}
+	requests  int
+	documents map[string]map[string]interface{}
+
+	// Statuses returned for the items of successive bulk requests, or
+	// 429 for the whole request if an entry is nil
+	statuses [][]int
+}
+
+func (es *fakeElasticsearch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	if user, password, _ := r.BasicAuth(); user != "elastic" ||
+		password != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/_index_template/") {
+		var template interface{}
+		json.NewDecoder(r.Body).Decode(&template)
+		es.templates[strings.TrimPrefix(r.URL.Path, "/_index_template/")] = template
+		fmt.Fprint(w, `{"acknowledged":true}`)
+		return
+	}
+	if r.Method != "POST" || r.URL.Path != "/_bulk" ||
+		r.Header.Get("Content-Type") != "application/x-ndjson" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var statuses []int
+	if es.requests < len(es.statuses) {
+		statuses = es.statuses[es.requests]
+		if statuses == nil {
+			es.requests++
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+	}
+	es.requests++
+
+	var items []string
+	scanner := bufio.NewScanner(r.Body)
+	for i := 0; scanner.Scan(); i++ {
+		var action map[string]map[string]string
+		json.Unmarshal(scanner.Bytes(), &action)
+		scanner.Scan()
+		var doc map[string]interface{}
+		json.Unmarshal(scanner.Bytes(), &doc)
+
+		status := http.StatusCreated
+		if i < len(statuses) {
+			status = statuses[i]
+		}
+		if status == http.StatusCreated {
+			es.documents[action["index"]["_index"]+"/"+
+				action["index"]["_id"]] = doc
+		}
+		items = append(items, fmt.Sprintf(`{"index":{"status":%d}}`, status))
+	}
+	fmt.Fprintf(w, `{"errors":%v,"items":[%s]}`, len(statuses) > 0,
+		strings.Join(items, ","))
+}
+
+func newTestElasticsearch(t *testing.T) (*fakeElasticsearch, *ElasticsearchSink, func()) {
+	es := &fakeElasticsearch{
+		templates: make(map[string]interface{}),
+		documents: make(map[string]map[string]interface{}),
+	}
+	server := httptest.NewServer(es)
+	s, err := NewElasticsearchSink(server.URL+"/",
+		WithElasticsearchBasicAuth("elastic", "secret"),
+		WithElasticsearchIndexPrefix("test"),
+		WithElasticsearchRetries(2),
+		WithElasticsearchBackoff(time.Millisecond))
+	require.NoError(t, err)
+	return es, s, server.Close
+}
+
+func TestElasticsearchSink(t *testing.T) {
+	es, s, done := newTestElasticsearch(t)
+	defer done()
+
+	events := []*api.TelemetryEvent{
+		{
+			Id:          "1",
+			ContainerId: "c1",
+			Event: &api.TelemetryEvent_Process{
+				Process: &api.ProcessEvent{
+					Type: api.ProcessEventType_PROCESS_EVENT_TYPE_EXEC,
+				},
+			},
+		},
+		{
+			Id: "2",
+			Event: &api.TelemetryEvent_File{
+				File: &api.FileEvent{Filename: "/etc/passwd"},
+			},
+		},
+	}
+	require.NoError(t, s.Publish(events))
+	require.NoError(t, s.Publish(events[:1]))
+	require.NoError(t, s.Close())
+
+	require.Len(t, es.templates, 1)
+	template := es.templates["test"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"test-*"}, template["index_patterns"])
+
+	date := time.Now().UTC().Format("2006.01.02")
+	require.Len(t, es.documents, 2)
+	process := es.documents["test-process-"+date+"/1"]
+	require.NotNil(t, process)
+	assert.Equal(t, "c1", process["containerId"])
+	_, err := time.Parse(time.RFC3339Nano, process["@timestamp"].(string))
+	assert.NoError(t, err)
+	file := es.documents["test-file-"+date+"/2"]
+	require.NotNil(t, file)
+	assert.Equal(t, "/etc/passwd",
+		file["file"].(map[string]interface{})["filename"])
+}
+
+func TestElasticsearchSinkBackoff(t *testing.T) {
+	es, s, done := newTestElasticsearch(t)
+	defer done()
+
+	events := []*api.TelemetryEvent{{Id: "1"}, {Id: "2"}, {Id: "3"}}
+
+	// Only the rejected events are retried, and events that cannot be
+	// indexed are not retried.
+	es.statuses = [][]int{nil, {201, 429, 400}}
+	require.NoError(t, s.Publish(events))
+	assert.Equal(t, 3, es.requests)
+	assert.Len(t, es.documents, 2)
+
+	// Retries are limited
+	es.requests = 0
+	es.statuses = [][]int{{429}, {429}, {429}}
+	assert.Error(t, s.Publish(events[:1]))
+	assert.Equal(t, 3, es.requests)
+}
+
+func TestElasticsearchSinkErrors(t *testing.T) {
+	_, err := NewElasticsearchSink("")
+	assert.Error(t, err)
+	_, err = NewElasticsearchSink("http://localhost:9200",
+		WithElasticsearchIndexPrefix(""))
+	assert.Error(t, err)
+
+	_, s, done := newTestElasticsearch(t)
+	defer done()
+	s.options.username = "nobody"
+	assert.Error(t, s.Publish([]*api.TelemetryEvent{{Id: "1"}}))
+}
@@ -0,0 +1,189 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+)
+
+// FileOption is used to implement optional arguments for NewFileSink.
+// It must be exported, but it is not typically used directly.
+type FileOption func(*fileOptions)
+
+type fileOptions struct {
+	maxSize  int64
+	maxFiles int
+	encoding Encoding
+}
+
+// WithFileEncoding sets the serialization of each line. The default is
+// EncodingJSON.
+func WithFileEncoding(encoding Encoding) FileOption {
+	return func(o *fileOptions) {
+		o.encoding = encoding
+	}
+}
+
+// WithFileMaxSize sets the size in bytes at which the file is rotated. The
+// file is not rotated if this is 0, which is the default.
+func WithFileMaxSize(maxSize int64) FileOption {
+	return func(o *fileOptions) {
+		o.maxSize = maxSize
+	}
+}
+
+// WithFileMaxFiles sets the number of rotated files that are kept, named
+// with the suffixes ".1" for the newest through ".n" for the oldest. The
+// default is 5.
+func WithFileMaxFiles(maxFiles int) FileOption {
+	return func(o *fileOptions) {
+		o.maxFiles = maxFiles
+	}
+}
+
+// FileSink writes events, one per line, to a file or to standard output.
+// By default each line is a JSON object, so that the file is
+// newline-delimited JSON.
+type FileSink struct {
+	path    string
+	options fileOptions
+
+	mutex sync.Mutex
+	w     io.Writer
+	file  *os.File
+	size  int64
+}
+
+// NewFileSink creates a new sink that appends events to the file at path,
+// creating it if necessary, or writes them to standard output if path is
+// "-".
+func NewFileSink(path string, options ...FileOption) (*FileSink, error) {
+	s := &FileSink{
+		path: path,
+		options: fileOptions{
+			maxFiles: 5,
+			encoding: EncodingJSON,
+		},
+	}
+	for _, option := range options {
+		option(&s.options)
+	}
+	if s.options.encoding == EncodingProtobuf {
+		return nil, fmt.Errorf("File sink lines cannot be protobuf")
+	}
+
+	if path == "-" {
+		s.w = os.Stdout
+		return s, nil
+	}
+	if path == "" {
+		return nil, fmt.Errorf("File sink path is empty")
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.file = f
+	s.w = f
+	s.size = fi.Size()
+	return nil
+}
+
+// rotate renames the file to path.1, shifting older files up to
+// path.maxFiles, and opens a new file.
+func (s *FileSink) rotate() error {
+	s.file.Close()
+	s.file = nil
+
+	if s.options.maxFiles < 1 {
+		os.Remove(s.path)
+	} else {
+		os.Remove(fmt.Sprintf("%s.%d", s.path, s.options.maxFiles))
+		for i := s.options.maxFiles - 1; i >= 1; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", s.path, i),
+				fmt.Sprintf("%s.%d", s.path, i+1))
+		}
+		if err := os.Rename(s.path, s.path+".1"); err != nil {
+			glog.Warningf("Could not rotate %s: %v", s.path, err)
+		}
+	}
+	return s.open()
+}
+
+// Publish writes a batch of events, one per line.
+func (s *FileSink) Publish(events []*api.TelemetryEvent) error {
+	var b bytes.Buffer
+	for _, e := range events {
+		line, err := s.options.encoding.Marshal(e)
+		if err != nil {
+			glog.Warningf("Could not serialize event %s: %v", e.Id, err)
+			continue
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil && s.path != "-" {
+		// A previous rotation failed to open a new file
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	if s.file != nil && s.options.maxSize > 0 && s.size > 0 &&
+		s.size+int64(b.Len()) > s.options.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.w.Write(b.Bytes())
+	s.size += int64(n)
+	return err
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	s.w = nil
+	return err
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readJSONLines(t *testing.T, path string) []string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e struct{ ID string }
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		ids = append(ids, e.ID)
+	}
+	require.NoError(t, scanner.Err())
+	return ids
+}
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.json")
+
+	_, err = NewFileSink("")
+	assert.Error(t, err)
+	_, err = NewFileSink(path, WithFileEncoding(EncodingProtobuf))
+	assert.Error(t, err)
+
+	// Each event is about 12 bytes, so two batches of two fit in a file
+	s, err := NewFileSink(path, WithFileMaxSize(50), WithFileMaxFiles(2))
+	require.NoError(t, err)
+	for i := 0; i < 8; i += 2 {
+		require.NoError(t, s.Publish([]*api.TelemetryEvent{
+			&api.TelemetryEvent{Id: fmt.Sprintf("%d", i)},
+			&api.TelemetryEvent{Id: fmt.Sprintf("%d", i+1)},
+		}))
+	}
+	require.NoError(t, s.Close())
+
+	assert.Equal(t, []string{"4", "5", "6", "7"}, readJSONLines(t, path))
+	assert.Equal(t, []string{"0", "1", "2", "3"}, readJSONLines(t, path+".1"))
+	_, err = os.Stat(path + ".2")
+	assert.True(t, os.IsNotExist(err))
+
+	// Events are appended to an existing file
+	s, err = NewFileSink(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Publish([]*api.TelemetryEvent{
+		&api.TelemetryEvent{Id: "8"},
+	}))
+	require.NoError(t, s.Close())
+	assert.Equal(t, []string{"4", "5", "6", "7", "8"}, readJSONLines(t, path))
+}
@@ -0,0 +1,339 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+)
+
+// Events are sent with the fluentd forward protocol (v1) in Forward mode,
+// one message per tag per batch, each with a chunk ID that the server must
+// acknowledge. Each record is the JSON form of an event. The tag of an
+// event is the sink's tag followed by the event type, i.e.
+// "capsule8.sensor.process", so that fluentd can route events by type.
+// When a shared key is set, the client authenticates with the server's
+// HELO, PING, and PONG handshake.
+
+// FluentdOption is used to implement optional arguments for
+// NewFluentdSink. It must be exported, but it is not typically used
+// directly.
+type FluentdOption func(*fluentdOptions)
+
+type fluentdOptions struct {
+	sharedKey string
+	username  string
+	password  string
+	hostname  string
+	timeout   time.Duration
+}
+
+// WithFluentdSharedKey sets the shared key used to authenticate with the
+// server. The server's handshake is not expected if this is empty, which
+// is the default.
+func WithFluentdSharedKey(sharedKey string) FluentdOption {
+	return func(o *fluentdOptions) {
+		o.sharedKey = sharedKey
+	}
+}
+
+// WithFluentdUser sets the username and password used to authenticate with
+// servers that require user authentication in addition to a shared key.
+func WithFluentdUser(username, password string) FluentdOption {
+	return func(o *fluentdOptions) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithFluentdHostname sets the hostname sent to the server in the
+// handshake. The default is the host's name.
+func WithFluentdHostname(hostname string) FluentdOption {
+	return func(o *fluentdOptions) {
+		o.hostname = hostname
+	}
+}
+
+// WithFluentdTimeout sets how long to wait to connect to the server and for
+// it to respond. The default is 10 seconds.
+func WithFluentdTimeout(timeout time.Duration) FluentdOption {
+	return func(o *fluentdOptions) {
+		o.timeout = timeout
+	}
+}
+
+// FluentdSink sends events to a fluentd or fluent-bit forward input.
+type FluentdSink struct {
+	addr    string
+	tag     string
+	options fluentdOptions
+
+	mutex sync.Mutex
+	conn  net.Conn
+	r     *bufio.Reader
+}
+
+// NewFluentdSink creates a new sink that sends events to the forward input
+// at addr (host:port) with tags beginning with tag.
+func NewFluentdSink(addr, tag string, options ...FluentdOption) (*FluentdSink, error) {
+	if addr == "" {
+		return nil, errors.New("fluentd address is empty")
+	}
+	if tag == "" {
+		return nil, errors.New("fluentd tag is empty")
+	}
+	s := &FluentdSink{
+		addr: addr,
+		tag:  tag,
+		options: fluentdOptions{
+			timeout: 10 * time.Second,
+		},
+	}
+	for _, option := range options {
+		option(&s.options)
+	}
+	if s.options.hostname == "" {
+		s.options.hostname, _ = os.Hostname()
+	}
+	return s, nil
+}
+
+func fluentdDigest(parts ...string) string {
+	h := sha512.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// randomHex returns n random bytes in hex.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *FluentdSink) read() (interface{}, error) {
+	s.conn.SetReadDeadline(time.Now().Add(s.options.timeout))
+	return readMsgpack(s.r)
+}
+
+func (s *FluentdSink) write(b []byte) error {
+	s.conn.SetWriteDeadline(time.Now().Add(s.options.timeout))
+	_, err := s.conn.Write(b)
+	return err
+}
+
+// handshake authenticates with the server.
+func (s *FluentdSink) handshake() error {
+	v, err := s.read()
+	if err != nil {
+		return err
+	}
+	helo, ok := v.([]interface{})
+	if !ok || len(helo) < 2 || helo[0] != "HELO" {
+		return errors.New("fluentd server did not send HELO")
+	}
+	heloOptions, _ := helo[1].(map[string]interface{})
+	nonce, _ := heloOptions["nonce"].(string)
+	authSalt, _ := heloOptions["auth"].(string)
+
+	sharedKeySalt, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+	var ping msgpackEncoder
+	ping.putArrayLen(6)
+	ping.putString("PING")
+	ping.putString(s.options.hostname)
+	ping.putString(sharedKeySalt)
+	ping.putString(fluentdDigest(sharedKeySalt, s.options.hostname, nonce,
+		s.options.sharedKey))
+	if authSalt != "" {
+		ping.putString(s.options.username)
+		ping.putString(fluentdDigest(authSalt, s.options.username,
+			s.options.password))
+	} else {
+		ping.putString("")
+		ping.putString("")
+	}
+	if err = s.write(ping.b); err != nil {
+		return err
+	}
+
+	v, err = s.read()
+	if err != nil {
+		return err
+	}
+	pong, ok := v.([]interface{})
+	if !ok || len(pong) < 5 || pong[0] != "PONG" {
+		return errors.New("fluentd server did not send PONG")
+	}
+	if authenticated, _ := pong[1].(bool); !authenticated {
+		return fmt.Errorf("fluentd authentication failed: %v", pong[2])
+	}
+	serverHostname, _ := pong[3].(string)
+	if pong[4] != fluentdDigest(sharedKeySalt, serverHostname, nonce,
+		s.options.sharedKey) {
+		return errors.New("fluentd server's shared key digest is invalid")
+	}
+	return nil
+}
+
+func (s *FluentdSink) connect() error {
+	conn, err := net.DialTimeout("tcp", s.addr, s.options.timeout)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	s.r = bufio.NewReader(conn)
+	if s.options.sharedKey != "" {
+		if err = s.handshake(); err != nil {
+			s.disconnect()
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FluentdSink) disconnect() {
+	s.conn.Close()
+	s.conn = nil
+	s.r = nil
+}
+
+// eventRecord converts an event to the map of its JSON form.
+func eventRecord(e *api.TelemetryEvent) (interface{}, error) {
+	b, err := EncodingJSON.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var record interface{}
+	if err = d.Decode(&record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Publish sends a batch of events and waits for the server to acknowledge
+// them.
+func (s *FluentdSink) Publish(events []*api.TelemetryEvent) error {
+	now := time.Now()
+	sec, nsec := uint32(now.Unix()), uint32(now.Nanosecond())
+
+	entries := make(map[string]*msgpackEncoder)
+	counts := make(map[string]int)
+	for _, e := range events {
+		record, err := eventRecord(e)
+		if err != nil {
+			glog.Warningf("Could not serialize event %s: %v", e.Id, err)
+			continue
+		}
+		tag := s.tag
+		if t := EventType(e); t != "" {
+			tag += "." + t
+		}
+		enc, ok := entries[tag]
+		if !ok {
+			enc = &msgpackEncoder{}
+			entries[tag] = enc
+		}
+		enc.putArrayLen(2)
+		enc.putEventTime(sec, nsec)
+		if err = enc.putValue(record); err != nil {
+			return err
+		}
+		counts[tag]++
+	}
+	tags := make([]string, 0, len(entries))
+	for tag := range entries {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+	for _, tag := range tags {
+		if err := s.forward(tag, counts[tag], entries[tag].b); err != nil {
+			s.disconnect()
+			return err
+		}
+	}
+	return nil
+}
+
+// forward sends a Forward mode message and waits for its acknowledgement.
+func (s *FluentdSink) forward(tag string, count int, entries []byte) error {
+	chunk, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+
+	var msg msgpackEncoder
+	msg.putArrayLen(3)
+	msg.putString(tag)
+	msg.putArrayLen(count)
+	msg.b = append(msg.b, entries...)
+	msg.putMapLen(1)
+	msg.putString("chunk")
+	msg.putString(chunk)
+	if err = s.write(msg.b); err != nil {
+		return err
+	}
+
+	v, err := s.read()
+	if err != nil {
+		return err
+	}
+	if ack, ok := v.(map[string]interface{}); !ok || ack["ack"] != chunk {
+		return errors.New("fluentd server did not acknowledge events")
+	}
+	return nil
+}
+
+// Close closes the connection to the server.
+func (s *FluentdSink) Close() error {
+	s.mutex.Lock()
+	if s.conn != nil {
+		s.disconnect()
+	}
+	s.mutex.Unlock()
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgpack(t *testing.T) {
+	var value interface{}
+	d := json.NewDecoder(strings.NewReader(`{
+		"b": [true, false, null, 1, -1, -100, 300, 1.5, "` +
+		strings.Repeat("x", 40) + `"],
+		"a": {"c": "d"}
+	}`))
+	d.UseNumber()
+	require.NoError(t, d.Decode(&value))
+
+	var e msgpackEncoder
+	require.NoError(t, e.putValue(value))
+	e.putEventTime(1, 2)
+	e.putArrayLen(20)
+	for i := 0; i < 20; i++ {
+		e.putInt(int64(i))
+	}
+
+	r := bufio.NewReader(bytes.NewReader(e.b))
+	decoded, err := readMsgpack(r)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a": map[string]interface{}{"c": "d"},
+		"b": []interface{}{true, false, nil, int64(1), int64(-1),
+			int64(-100), uint64(300), 1.5, strings.Repeat("x", 40)},
+	}, decoded)
+
+	// Extension types are skipped
+	decoded, err = readMsgpack(r)
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	decoded, err = readMsgpack(r)
+	require.NoError(t, err)
+	assert.Len(t, decoded, 20)
+
+	_, err = readMsgpack(r)
+	assert.Error(t, err)
+}
+
+const (
+	testFluentdSharedKey = "secret"
+	testFluentdNonce     = "nonce"
+)
+
+// serveTestFluentd accepts a connection, performs the handshake, and then
+// acknowledges each message after sending its tag and records on messages.
+func serveTestFluentd(t *testing.T, l net.Listener, sharedKey string, messages chan<- []interface{}) {
+	c, err := l.Accept()
+	if err != nil {
+		return
+	}
+	defer c.Close()
+	r := bufio.NewReader(c)
+
+	var helo msgpackEncoder
+	helo.putArrayLen(2)
+	helo.putString("HELO")
+	helo.putMapLen(1)
+	helo.putString("nonce")
+	helo.putString(testFluentdNonce)
+	c.Write(helo.b)
+
+	v, err := readMsgpack(r)
+	require.NoError(t, err)
+	ping := v.([]interface{})
+	require.Equal(t, "PING", ping[0])
+	salt := ping[2].(string)
+	ok := ping[3] == fluentdDigest(salt, ping[1].(string),
+		testFluentdNonce, sharedKey)
+
+	var pong msgpackEncoder
+	pong.putArrayLen(5)
+	pong.putString("PONG")
+	pong.putBool(ok)
+	pong.putString("")
+	pong.putString("server")
+	pong.putString(fluentdDigest(salt, "server", testFluentdNonce,
+		sharedKey))
+	c.Write(pong.b)
+	if !ok {
+		return
+	}
+
+	for {
+		v, err := readMsgpack(r)
+		if err != nil {
+			return
+		}
+		msg := v.([]interface{})
+		messages <- msg[:2]
+
+		option := msg[2].(map[string]interface{})
+		var ack msgpackEncoder
+		ack.putMapLen(1)
+		ack.putString("ack")
+		ack.putString(option["chunk"].(string))
+		c.Write(ack.b)
+	}
+}
+
+func TestFluentdSink(t *testing.T) {
+	_, err := NewFluentdSink("", "tag")
+	assert.Error(t, err)
+	_, err = NewFluentdSink("localhost:24224", "")
+	assert.Error(t, err)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	messages := make(chan []interface{}, 10)
+	go serveTestFluentd(t, l, testFluentdSharedKey, messages)
+
+	s, err := NewFluentdSink(l.Addr().String(), "capsule8",
+		WithFluentdSharedKey(testFluentdSharedKey))
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Publish([]*api.TelemetryEvent{
+		&api.TelemetryEvent{
+			Id: "1",
+			Event: &api.TelemetryEvent_Process{
+				Process: &api.ProcessEvent{ExecFilename: "/bin/sh"},
+			},
+		},
+		&api.TelemetryEvent{Id: "2"},
+		&api.TelemetryEvent{
+			Id: "3",
+			Event: &api.TelemetryEvent_Process{
+				Process: &api.ProcessEvent{ForkChildPid: 10},
+			},
+		},
+	}))
+
+	msg := <-messages
+	assert.Equal(t, "capsule8", msg[0])
+	entries := msg[1].([]interface{})
+	require.Len(t, entries, 1)
+	assert.Equal(t, map[string]interface{}{"id": "2"},
+		entries[0].([]interface{})[1])
+
+	msg = <-messages
+	assert.Equal(t, "capsule8.process", msg[0])
+	entries = msg[1].([]interface{})
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]interface{}{
+		"id":      "3",
+		"process": map[string]interface{}{"forkChildPid": int64(10)},
+	}, entries[1].([]interface{})[1])
+}
+
+func TestFluentdSinkSharedKey(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go serveTestFluentd(t, l, "other", nil)
+
+	s, err := NewFluentdSink(l.Addr().String(), "capsule8",
+		WithFluentdSharedKey(testFluentdSharedKey))
+	require.NoError(t, err)
+	defer s.Close()
+	assert.Error(t, s.Publish([]*api.TelemetryEvent{&api.TelemetryEvent{}}))
+}
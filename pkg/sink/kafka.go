@@ -0,0 +1,703 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+)
+
+// The Kafka sink speaks the Kafka protocol directly. Only the requests
+// needed to produce are implemented: Metadata v1 to find the leaders of
+// the topics' partitions, and Produce v3 with v2 record batches, which are
+// supported by Kafka 0.11 and later. Record batches may be compressed with
+// gzip.
+
+const (
+	kafkaProduceKey  = 0
+	kafkaMetadataKey = 3
+
+	kafkaProduceVersion  = 3
+	kafkaMetadataVersion = 1
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// KafkaOption is used to implement optional arguments for NewKafkaSink.
+// It must be exported, but it is not typically used directly.
+type KafkaOption func(*kafkaOptions)
+
+type kafkaOptions struct {
+	topics               map[string]string
+	partitionByContainer bool
+	encoding             Encoding
+	clientID             string
+	timeout              time.Duration
+	acks                 int16
+	compression          Compression
+}
+
+// WithKafkaTopics sets the topics that events of each type are published
+// to instead of the default topic. The map is keyed by event type, as
+// returned by EventType.
+func WithKafkaTopics(topics map[string]string) KafkaOption {
+	return func(o *kafkaOptions) {
+		o.topics = topics
+	}
+}
+
+// WithKafkaPartitionByContainer publishes all of the events of a container
+// to the same partition so that they are consumed in order, except while that
+// partition has no leader. Events are otherwise spread across partitions
+// round robin.
+func WithKafkaPartitionByContainer(partitionByContainer bool) KafkaOption {
+	return func(o *kafkaOptions) {
+		o.partitionByContainer = partitionByContainer
+	}
+}
+
+// WithKafkaEncoding sets the serialization of events. The default is
+// EncodingProtobuf.
+func WithKafkaEncoding(encoding Encoding) KafkaOption {
+	return func(o *kafkaOptions) {
+		o.encoding = encoding
+	}
+}
+
+// WithKafkaClientID sets the client ID sent to the brokers. The default is
+// "capsule8-sensor".
+func WithKafkaClientID(clientID string) KafkaOption {
+	return func(o *kafkaOptions) {
+		o.clientID = clientID
+	}
+}
+
+// WithKafkaTimeout sets how long to wait for a broker to respond. The
+// default is 10 seconds.
+func WithKafkaTimeout(timeout time.Duration) KafkaOption {
+	return func(o *kafkaOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithKafkaAcks sets the number of acknowledgements that the leader of a
+// partition waits for before responding: 0 for none, 1 for the leader
+// only, or -1 for all in-sync replicas. The default is -1.
+func WithKafkaAcks(acks int16) KafkaOption {
+	return func(o *kafkaOptions) {
+		o.acks = acks
+	}
+}
+
+// WithKafkaCompression sets the compression of record batches. The default
+// is CompressionNone.
+func WithKafkaCompression(compression Compression) KafkaOption {
+	return func(o *kafkaOptions) {
+		o.compression = compression
+	}
+}
+
+type kafkaPartition struct {
+	id     int32
+	leader int32
+}
+
+// KafkaSink publishes events to Kafka topics.
+type KafkaSink struct {
+	brokers []string
+	topic   string
+	options kafkaOptions
+
+	mutex       sync.Mutex
+	conns       map[int32]*kafkaConn
+	brokerAddrs map[int32]string
+	next        map[string]int
+
+	// Every partition of each topic in ID order, with a leader of -1 if
+	// it has none, and the partitions that have leaders
+	partitions map[string][]kafkaPartition
+	leaders    map[string][]kafkaPartition
+}
+
+// NewKafkaSink creates a new sink that publishes events to a topic of the
+// Kafka cluster that the brokers belong to.
+func NewKafkaSink(brokers []string, topic string, options ...KafkaOption) (*KafkaSink, error) {
+	if len(brokers) == 0 {
+		return nil, errors.New("No Kafka brokers")
+	}
+	if topic == "" {
+		return nil, errors.New("Kafka topic is empty")
+	}
+	k := &KafkaSink{
+		brokers: brokers,
+		topic:   topic,
+		options: kafkaOptions{
+			clientID: "capsule8-sensor",
+			timeout:  10 * time.Second,
+			acks:     -1,
+		},
+		conns: make(map[int32]*kafkaConn),
+		next:  make(map[string]int),
+	}
+	for _, option := range options {
+		option(&k.options)
+	}
+	return k, nil
+}
+
+func (k *KafkaSink) eventTopic(e *api.TelemetryEvent) string {
+	if t, ok := k.options.topics[EventType(e)]; ok && t != "" {
+		return t
+	}
+	return k.topic
+}
+
+type kafkaRecord struct {
+	key   []byte
+	value []byte
+}
+
+// Publish publishes a batch of events, which are sent to the leaders of
+// their partitions. If any are not acknowledged, the cluster's metadata is
+// fetched again before the next batch.
+func (k *KafkaSink) Publish(events []*api.TelemetryEvent) error {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	topics := make(map[string]bool)
+	for _, e := range events {
+		topics[k.eventTopic(e)] = true
+	}
+	if err := k.refreshMetadata(topics); err != nil {
+		return err
+	}
+
+	// leader -> topic -> partition -> records
+	requests := make(map[int32]map[string]map[int32][]kafkaRecord)
+	for _, e := range events {
+		value, err := k.options.encoding.Marshal(e)
+		if err != nil {
+			glog.Warningf("Could not serialize event %s: %v", e.Id, err)
+			continue
+		}
+		topic := k.eventTopic(e)
+		leaders := k.leaders[topic]
+
+		var (
+			p   kafkaPartition
+			key []byte
+		)
+		if k.options.partitionByContainer && e.ContainerId != "" {
+			// A container is hashed across all of the partitions
+			// so that it keeps its partition when leaders change.
+			// It only moves while its partition has no leader.
+			h := fnv.New32a()
+			h.Write([]byte(e.ContainerId))
+			sum := h.Sum32()
+			partitions := k.partitions[topic]
+			p = partitions[sum%uint32(len(partitions))]
+			if p.leader < 0 {
+				p = leaders[sum%uint32(len(leaders))]
+			}
+			key = []byte(e.ContainerId)
+		} else {
+			p = leaders[k.next[topic]%len(leaders)]
+			k.next[topic]++
+		}
+
+		leaderTopics, ok := requests[p.leader]
+		if !ok {
+			leaderTopics = make(map[string]map[int32][]kafkaRecord)
+			requests[p.leader] = leaderTopics
+		}
+		topicPartitions, ok := leaderTopics[topic]
+		if !ok {
+			topicPartitions = make(map[int32][]kafkaRecord)
+			leaderTopics[topic] = topicPartitions
+		}
+		topicPartitions[p.id] = append(topicPartitions[p.id],
+			kafkaRecord{key: key, value: value})
+	}
+
+	var firstErr error
+	for leader, data := range requests {
+		if err := k.produce(leader, data); err != nil {
+			k.invalidate(leader)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// invalidate closes the connection to a broker and forgets the metadata so
+// that it is fetched again.
+func (k *KafkaSink) invalidate(broker int32) {
+	if c, ok := k.conns[broker]; ok {
+		c.close()
+		delete(k.conns, broker)
+	}
+	k.partitions = nil
+	k.leaders = nil
+}
+
+// Close closes the connections to the brokers.
+func (k *KafkaSink) Close() error {
+	k.mutex.Lock()
+	for id, c := range k.conns {
+		c.close()
+		delete(k.conns, id)
+	}
+	k.mutex.Unlock()
+	return nil
+}
+
+func (k *KafkaSink) refreshMetadata(topics map[string]bool) error {
+	missing := false
+	for topic := range topics {
+		if len(k.leaders[topic]) == 0 {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return nil
+	}
+
+	var lastErr error
+	for _, addr := range k.brokers {
+		c, err := dialKafka(addr, k.options.clientID, k.options.timeout)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		err = k.fetchMetadata(c, topics)
+		c.close()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("Could not get Kafka metadata: %v", lastErr)
+}
+
+func (k *KafkaSink) fetchMetadata(c *kafkaConn, topics map[string]bool) error {
+	var req kafkaEncoder
+	req.putInt32(int32(len(topics)))
+	for topic := range topics {
+		req.putString(topic)
+	}
+	resp, err := c.roundTrip(kafkaMetadataKey, kafkaMetadataVersion, req.b)
+	if err != nil {
+		return err
+	}
+
+	d := &kafkaDecoder{b: resp}
+	brokerAddrs := make(map[int32]string)
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		id := d.int32()
+		host := d.string()
+		port := d.int32()
+		d.string() // rack
+		brokerAddrs[id] = net.JoinHostPort(host,
+			strconv.Itoa(int(port)))
+	}
+	d.int32() // controller_id
+
+	partitions := make(map[string][]kafkaPartition)
+	leaders := make(map[string][]kafkaPartition)
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		errorCode := d.int16()
+		topic := d.string()
+		d.int8() // is_internal
+		var ps []kafkaPartition
+		for j, m := 0, d.arrayLen(); j < m; j++ {
+			d.int16() // error_code
+			id := d.int32()
+			leader := d.int32()
+			d.skipInt32Array() // replicas
+			d.skipInt32Array() // isr
+			if leader < 0 {
+				leader = -1
+			}
+			ps = append(ps, kafkaPartition{
+				id:     id,
+				leader: leader,
+			})
+		}
+		if d.err != nil {
+			break
+		}
+		if errorCode != 0 {
+			return fmt.Errorf("topic %s: Kafka error %d", topic, errorCode)
+		}
+		sort.Slice(ps, func(i, j int) bool {
+			return ps[i].id < ps[j].id
+		})
+		var led []kafkaPartition
+		for _, p := range ps {
+			if p.leader >= 0 {
+				led = append(led, p)
+			}
+		}
+		if len(led) == 0 {
+			return fmt.Errorf("topic %s has no partitions with leaders",
+				topic)
+		}
+		partitions[topic] = ps
+		leaders[topic] = led
+	}
+	if d.err != nil {
+		return d.err
+	}
+	for topic := range topics {
+		if _, ok := partitions[topic]; !ok {
+			return fmt.Errorf("topic %s is missing from metadata", topic)
+		}
+	}
+
+	k.brokerAddrs = brokerAddrs
+	if k.partitions == nil {
+		k.partitions = make(map[string][]kafkaPartition)
+		k.leaders = make(map[string][]kafkaPartition)
+	}
+	for topic, ps := range partitions {
+		k.partitions[topic] = ps
+		k.leaders[topic] = leaders[topic]
+	}
+	return nil
+}
+
+func (k *KafkaSink) conn(broker int32) (*kafkaConn, error) {
+	if c, ok := k.conns[broker]; ok {
+		return c, nil
+	}
+	addr, ok := k.brokerAddrs[broker]
+	if !ok {
+		return nil, fmt.Errorf("Kafka broker %d is unknown", broker)
+	}
+	c, err := dialKafka(addr, k.options.clientID, k.options.timeout)
+	if err != nil {
+		return nil, err
+	}
+	k.conns[broker] = c
+	return c, nil
+}
+
+func (k *KafkaSink) produce(broker int32, data map[string]map[int32][]kafkaRecord) error {
+	c, err := k.conn(broker)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	var req kafkaEncoder
+	req.putInt16(-1) // transactional_id
+	req.putInt16(k.options.acks)
+	req.putInt32(int32(k.options.timeout / time.Millisecond))
+	req.putInt32(int32(len(data)))
+	for topic, partitions := range data {
+		req.putString(topic)
+		req.putInt32(int32(len(partitions)))
+		for id, records := range partitions {
+			batch, err := encodeKafkaRecordBatch(records, now,
+				k.options.compression)
+			if err != nil {
+				return err
+			}
+			req.putInt32(id)
+			req.putBytes(batch)
+		}
+	}
+
+	if k.options.acks == 0 {
+		return c.send(kafkaProduceKey, kafkaProduceVersion, req.b)
+	}
+	resp, err := c.roundTrip(kafkaProduceKey, kafkaProduceVersion, req.b)
+	if err != nil {
+		return err
+	}
+
+	d := &kafkaDecoder{b: resp}
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		topic := d.string()
+		for j, m := 0, d.arrayLen(); j < m; j++ {
+			id := d.int32()
+			errorCode := d.int16()
+			d.int64() // base_offset
+			d.int64() // log_append_time
+			if errorCode != 0 && d.err == nil {
+				return fmt.Errorf("topic %s partition %d: Kafka error %d",
+					topic, id, errorCode)
+			}
+		}
+	}
+	return d.err
+}
+
+// encodeKafkaRecordBatch encodes records as a v2 record batch (the "magic"
+// 2 message format). When the batch is compressed, the records following
+// the record count are compressed together.
+func encodeKafkaRecordBatch(
+	records []kafkaRecord,
+	timestamp int64,
+	compression Compression,
+) ([]byte, error) {
+	var body kafkaEncoder
+	switch compression {
+	case CompressionGzip:
+		body.putInt16(1) // attributes: gzip codec
+	default:
+		body.putInt16(0) // attributes
+	}
+	body.putInt32(int32(len(records) - 1))
+	body.putInt64(timestamp) // first_timestamp
+	body.putInt64(timestamp) // max_timestamp
+	body.putInt64(-1)        // producer_id
+	body.putInt16(-1)        // producer_epoch
+	body.putInt32(-1)        // base_sequence
+	body.putInt32(int32(len(records)))
+	var recs kafkaEncoder
+	for i, r := range records {
+		var rec kafkaEncoder
+		rec.putInt8(0)   // attributes
+		rec.putVarint(0) // timestamp_delta
+		rec.putVarint(int64(i))
+		if r.key == nil {
+			rec.putVarint(-1)
+		} else {
+			rec.putVarint(int64(len(r.key)))
+			rec.b = append(rec.b, r.key...)
+		}
+		rec.putVarint(int64(len(r.value)))
+		rec.b = append(rec.b, r.value...)
+		rec.putVarint(0) // headers
+
+		recs.putVarint(int64(len(rec.b)))
+		recs.b = append(recs.b, rec.b...)
+	}
+	compressed, err := compression.compress(recs.b)
+	if err != nil {
+		return nil, err
+	}
+	body.b = append(body.b, compressed...)
+
+	var batch kafkaEncoder
+	batch.putInt64(0) // base_offset
+	// batch_length counts everything after it: the partition leader
+	// epoch, magic, and crc before the body
+	batch.putInt32(int32(4 + 1 + 4 + len(body.b)))
+	batch.putInt32(-1) // partition_leader_epoch
+	batch.putInt8(2)   // magic
+	batch.putInt32(int32(crc32.Checksum(body.b, castagnoli)))
+	batch.b = append(batch.b, body.b...)
+	return batch.b, nil
+}
+
+// kafkaConn is a connection to a Kafka broker.
+type kafkaConn struct {
+	conn          net.Conn
+	r             *bufio.Reader
+	clientID      string
+	timeout       time.Duration
+	correlationID int32
+}
+
+func dialKafka(addr, clientID string, timeout time.Duration) (*kafkaConn, error) {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaConn{
+		conn:     conn,
+		r:        bufio.NewReader(conn),
+		clientID: clientID,
+		timeout:  timeout,
+	}, nil
+}
+
+func (c *kafkaConn) close() {
+	c.conn.Close()
+}
+
+func (c *kafkaConn) send(key, version int16, body []byte) error {
+	c.correlationID++
+
+	var header kafkaEncoder
+	header.putInt32(0) // size, set below
+	header.putInt16(key)
+	header.putInt16(version)
+	header.putInt32(c.correlationID)
+	header.putString(c.clientID)
+	binary.BigEndian.PutUint32(header.b,
+		uint32(len(header.b)-4+len(body)))
+
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	if _, err := c.conn.Write(append(header.b, body...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// roundTrip sends a request and returns the body of its response.
+func (c *kafkaConn) roundTrip(key, version int16, body []byte) ([]byte, error) {
+	if err := c.send(key, version, body); err != nil {
+		return nil, err
+	}
+
+	var header [8]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[0:4])
+	if size < 4 || size > 64<<20 {
+		return nil, fmt.Errorf("Kafka response size %d is invalid", size)
+	}
+	if id := int32(binary.BigEndian.Uint32(header[4:8])); id != c.correlationID {
+		return nil, fmt.Errorf("Kafka response is for request %d, not %d",
+			id, c.correlationID)
+	}
+	resp := make([]byte, size-4)
+	if _, err := io.ReadFull(c.r, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type kafkaEncoder struct {
+	b []byte
+}
+
+func (e *kafkaEncoder) putInt8(v int8) {
+	e.b = append(e.b, byte(v))
+}
+
+func (e *kafkaEncoder) putInt16(v int16) {
+	e.b = append(e.b, byte(v>>8), byte(v))
+}
+
+func (e *kafkaEncoder) putInt32(v int32) {
+	e.b = append(e.b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (e *kafkaEncoder) putInt64(v int64) {
+	e.putInt32(int32(v >> 32))
+	e.putInt32(int32(v))
+}
+
+func (e *kafkaEncoder) putVarint(v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v)
+	e.b = append(e.b, buf[:n]...)
+}
+
+func (e *kafkaEncoder) putString(s string) {
+	e.putInt16(int16(len(s)))
+	e.b = append(e.b, s...)
+}
+
+func (e *kafkaEncoder) putBytes(b []byte) {
+	e.putInt32(int32(len(b)))
+	e.b = append(e.b, b...)
+}
+
+// kafkaDecoder decodes a response. Once a field cannot be decoded, err is
+// set and all further fields are zero.
+type kafkaDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *kafkaDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.b) < n {
+		d.err = errors.New("Kafka response is truncated")
+		d.b = nil
+		return nil
+	}
+	b := d.b[:n]
+	d.b = d.b[n:]
+	return b
+}
+
+func (d *kafkaDecoder) int8() int8 {
+	if b := d.next(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int16() int16 {
+	if b := d.next(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int32() int32 {
+	if b := d.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int64() int64 {
+	if b := d.next(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+// string decodes a string, which is empty if it is null.
+func (d *kafkaDecoder) string() string {
+	n := d.int16()
+	if n <= 0 {
+		return ""
+	}
+	return string(d.next(int(n)))
+}
+
+func (d *kafkaDecoder) arrayLen() int {
+	n := d.int32()
+	if n < 0 {
+		return 0
+	}
+	if int(n) > len(d.b) {
+		d.err = errors.New("Kafka response is truncated")
+		return 0
+	}
+	return int(n)
+}
+
+func (d *kafkaDecoder) skipInt32Array() {
+	d.next(4 * d.arrayLen())
+}
@@ -0,0 +1,404 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type producedRecord struct {
+	topic     string
+	partition int32
+	key       []byte
+	value     []byte
+}
+
+// testKafkaBroker is a single Kafka broker that leads every partition of
+// every topic, except those that are leaderless.
+type testKafkaBroker struct {
+	t          *testing.T
+	listener   net.Listener
+	partitions int32
+
+	mutex           sync.Mutex
+	records         []producedRecord
+	metadataCount   int
+	produceFailures int
+	leaderless      map[int32]bool
+}
+
+func newTestKafkaBroker(t *testing.T, partitions int32) *testKafkaBroker {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	b := &testKafkaBroker{
+		t:          t,
+		listener:   l,
+		partitions: partitions,
+	}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(c)
+		}
+	}()
+	return b
+}
+
+func (b *testKafkaBroker) close() {
+	b.listener.Close()
+}
+
+func (b *testKafkaBroker) serve(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err := io.ReadFull(r, req); err != nil {
+			return
+		}
+		d := &kafkaDecoder{b: req}
+		key := d.int16()
+		version := d.int16()
+		correlationID := d.int32()
+		d.string() // client_id
+
+		var resp kafkaEncoder
+		resp.putInt32(0)
+		resp.putInt32(correlationID)
+		switch {
+		case key == kafkaMetadataKey && version == kafkaMetadataVersion:
+			b.metadata(d, &resp)
+		case key == kafkaProduceKey && version == kafkaProduceVersion:
+			b.produce(d, &resp)
+		default:
+			b.t.Errorf("Unexpected request %d v%d", key, version)
+			return
+		}
+		if d.err != nil {
+			b.t.Errorf("Could not decode request: %v", d.err)
+			return
+		}
+		binary.BigEndian.PutUint32(resp.b, uint32(len(resp.b)-4))
+		if _, err := c.Write(resp.b); err != nil {
+			return
+		}
+	}
+}
+
+func (b *testKafkaBroker) metadata(d *kafkaDecoder, resp *kafkaEncoder) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.metadataCount++
+
+	host, port, _ := net.SplitHostPort(b.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	resp.putInt32(1)
+	resp.putInt32(1) // node_id
+	resp.putString(host)
+	resp.putInt32(int32(portNumber))
+	resp.putInt16(-1) // rack
+	resp.putInt32(1)  // controller_id
+
+	n := d.arrayLen()
+	resp.putInt32(int32(n))
+	for i := 0; i < n; i++ {
+		resp.putInt16(0)
+		resp.putString(d.string())
+		resp.putInt8(0)
+		resp.putInt32(b.partitions)
+		for p := int32(0); p < b.partitions; p++ {
+			resp.putInt16(0)
+			resp.putInt32(p)
+			if b.leaderless[p] {
+				resp.putInt32(-1) // leader
+			} else {
+				resp.putInt32(1) // leader
+			}
+			resp.putInt32(1) // replicas
+			resp.putInt32(1)
+			resp.putInt32(1) // isr
+			resp.putInt32(1)
+		}
+	}
+}
+
+func (b *testKafkaBroker) produce(d *kafkaDecoder, resp *kafkaEncoder) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	errorCode := int16(0)
+	if b.produceFailures > 0 {
+		b.produceFailures--
+		errorCode = 6
+	}
+
+	assert.Equal(b.t, int16(-1), d.int16()) // transactional_id
+	assert.Equal(b.t, int16(-1), d.int16()) // acks
+	d.int32()                               // timeout
+
+	n := d.arrayLen()
+	resp.putInt32(int32(n))
+	for i := 0; i < n; i++ {
+		topic := d.string()
+		resp.putString(topic)
+		m := d.arrayLen()
+		resp.putInt32(int32(m))
+		for j := 0; j < m; j++ {
+			partition := d.int32()
+			batch := d.next(int(d.int32()))
+			records := decodeKafkaRecordBatch(b.t, batch)
+			if errorCode == 0 {
+				for _, r := range records {
+					r.topic = topic
+					r.partition = partition
+					b.records = append(b.records, r)
+				}
+			}
+			resp.putInt32(partition)
+			resp.putInt16(errorCode)
+			resp.putInt64(0)
+			resp.putInt64(-1)
+		}
+	}
+	resp.putInt32(0) // throttle_time_ms
+}
+
+func decodeKafkaRecordBatch(t *testing.T, batch []byte) []producedRecord {
+	d := &kafkaDecoder{b: batch}
+	d.int64() // base_offset
+	assert.Equal(t, int(d.int32()), len(batch)-12)
+	d.int32() // partition_leader_epoch
+	assert.Equal(t, int8(2), d.int8())
+	crc := uint32(d.int32())
+	assert.Equal(t, crc32.Checksum(d.b, castagnoli), crc)
+	attributes := d.int16()
+	lastOffsetDelta := d.int32()
+	d.next(8 + 8 + 8 + 2 + 4)
+	n := d.int32()
+	assert.Equal(t, lastOffsetDelta, n-1)
+	switch attributes & 7 {
+	case 0:
+	case 1:
+		r, err := gzip.NewReader(bytes.NewReader(d.b))
+		require.NoError(t, err)
+		d.b, err = ioutil.ReadAll(r)
+		require.NoError(t, err)
+	default:
+		t.Fatalf("compression codec %d is unexpected", attributes&7)
+	}
+
+	varint := func() int64 {
+		v, size := binary.Varint(d.b)
+		require.True(t, size > 0)
+		d.b = d.b[size:]
+		return v
+	}
+	var records []producedRecord
+	for i := int32(0); i < n; i++ {
+		varint() // length
+		d.int8()
+		varint() // timestamp_delta
+		assert.Equal(t, int64(i), varint())
+		var r producedRecord
+		if keyLength := varint(); keyLength >= 0 {
+			r.key = d.next(int(keyLength))
+		}
+		r.value = d.next(int(varint()))
+		assert.Zero(t, varint())
+		records = append(records, r)
+	}
+	require.NoError(t, d.err)
+	assert.Empty(t, d.b)
+	return records
+}
+
+func (b *testKafkaBroker) produced() []producedRecord {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([]producedRecord(nil), b.records...)
+}
+
+func TestNewKafkaSink(t *testing.T) {
+	_, err := NewKafkaSink(nil, "topic")
+	assert.Error(t, err)
+	_, err = NewKafkaSink([]string{"localhost:9092"}, "")
+	assert.Error(t, err)
+}
+
+func TestKafkaSink(t *testing.T) {
+	broker := newTestKafkaBroker(t, 4)
+	defer broker.close()
+
+	k, err := NewKafkaSink([]string{broker.listener.Addr().String()}, "events",
+		WithKafkaTopics(map[string]string{"process": "process-events"}),
+		WithKafkaPartitionByContainer(true))
+	require.NoError(t, err)
+	defer k.Close()
+
+	var events []*api.TelemetryEvent
+	for i := 0; i < 8; i++ {
+		e := &api.TelemetryEvent{
+			Id: fmt.Sprintf("%d", i),
+		}
+		if i%2 == 0 {
+			e.ContainerId = "container"
+			e.Event = &api.TelemetryEvent_Process{
+				Process: &api.ProcessEvent{},
+			}
+		}
+		events = append(events, e)
+	}
+	require.NoError(t, k.Publish(events))
+
+	records := broker.produced()
+	require.Len(t, records, len(events))
+	partitions := make(map[int32]bool)
+	containerPartition := int32(-1)
+	for _, r := range records {
+		var e api.TelemetryEvent
+		require.NoError(t, proto.Unmarshal(r.value, &e))
+		if e.ContainerId != "" {
+			assert.Equal(t, "process-events", r.topic)
+			assert.Equal(t, "container", string(r.key))
+			if containerPartition == -1 {
+				containerPartition = r.partition
+			}
+			assert.Equal(t, containerPartition, r.partition)
+		} else {
+			assert.Equal(t, "events", r.topic)
+			assert.Nil(t, r.key)
+			partitions[r.partition] = true
+		}
+	}
+	// Events without a container are spread round robin
+	assert.Len(t, partitions, 4)
+
+	// Metadata is fetched again after an error
+	broker.mutex.Lock()
+	broker.produceFailures = 1
+	broker.mutex.Unlock()
+	assert.Error(t, k.Publish(events[:1]))
+	require.NoError(t, k.Publish(events[:1]))
+	assert.Len(t, broker.produced(), len(events)+1)
+	broker.mutex.Lock()
+	assert.Equal(t, 2, broker.metadataCount)
+	broker.mutex.Unlock()
+}
+
+func TestKafkaSinkLeaderChange(t *testing.T) {
+	broker := newTestKafkaBroker(t, 4)
+	defer broker.close()
+
+	k, err := NewKafkaSink([]string{broker.listener.Addr().String()}, "events",
+		WithKafkaPartitionByContainer(true))
+	require.NoError(t, err)
+	defer k.Close()
+
+	events := []*api.TelemetryEvent{
+		&api.TelemetryEvent{Id: "1", ContainerId: "container"},
+	}
+	// publish publishes the event and returns the partition that it was
+	// produced to, once the metadata has been fetched again if it fails
+	publish := func() int32 {
+		broker.mutex.Lock()
+		broker.records = nil
+		broker.mutex.Unlock()
+		if k.Publish(events) != nil {
+			require.NoError(t, k.Publish(events))
+		}
+		records := broker.produced()
+		require.Len(t, records, 1)
+		return records[0].partition
+	}
+	containerPartition := publish()
+
+	// Changing the leaders of other partitions does not move the
+	// container
+	broker.mutex.Lock()
+	broker.leaderless = map[int32]bool{(containerPartition + 1) % 4: true}
+	broker.produceFailures = 1
+	broker.mutex.Unlock()
+	assert.Equal(t, containerPartition, publish())
+
+	// The container only moves while its partition has no leader
+	broker.mutex.Lock()
+	broker.leaderless = map[int32]bool{containerPartition: true}
+	broker.produceFailures = 1
+	broker.mutex.Unlock()
+	assert.NotEqual(t, containerPartition, publish())
+
+	broker.mutex.Lock()
+	broker.leaderless = nil
+	broker.produceFailures = 1
+	broker.mutex.Unlock()
+	assert.Equal(t, containerPartition, publish())
+}
+
+func TestKafkaSinkCompression(t *testing.T) {
+	broker := newTestKafkaBroker(t, 1)
+	defer broker.close()
+
+	k, err := NewKafkaSink([]string{broker.listener.Addr().String()}, "events",
+		WithKafkaCompression(CompressionGzip))
+	require.NoError(t, err)
+	defer k.Close()
+
+	require.NoError(t, k.Publish([]*api.TelemetryEvent{
+		&api.TelemetryEvent{Id: "1"},
+		&api.TelemetryEvent{Id: "2"},
+	}))
+	records := broker.produced()
+	require.Len(t, records, 2)
+	for i, r := range records {
+		var e api.TelemetryEvent
+		require.NoError(t, proto.Unmarshal(r.value, &e))
+		assert.Equal(t, strconv.Itoa(i+1), e.Id)
+	}
+}
+
+func TestKafkaSinkUnavailable(t *testing.T) {
+	broker := newTestKafkaBroker(t, 1)
+	addr := broker.listener.Addr().String()
+	broker.close()
+
+	k, err := NewKafkaSink([]string{addr}, "events")
+	require.NoError(t, err)
+	assert.Error(t, k.Publish([]*api.TelemetryEvent{&api.TelemetryEvent{}}))
+}
@@ -0,0 +1,319 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// Only as much of MessagePack as the fluentd forward protocol needs is
+// implemented: encoding the values that encoding/json decodes to, and
+// decoding the handshake and acknowledgement messages sent by servers.
+
+// The longest string, array, or map that is decoded
+const maxMsgpackLength = 1 << 20
+
+type msgpackEncoder struct {
+	b []byte
+}
+
+func (e *msgpackEncoder) putNil() {
+	e.b = append(e.b, 0xc0)
+}
+
+func (e *msgpackEncoder) putBool(v bool) {
+	if v {
+		e.b = append(e.b, 0xc3)
+	} else {
+		e.b = append(e.b, 0xc2)
+	}
+}
+
+func (e *msgpackEncoder) putUint(prefix byte, n int, v uint64) {
+	e.b = append(e.b, prefix)
+	for i := n - 1; i >= 0; i-- {
+		e.b = append(e.b, byte(v>>(uint(i)*8)))
+	}
+}
+
+func (e *msgpackEncoder) putInt(v int64) {
+	switch {
+	case v >= 0 && v <= 0x7f:
+		e.b = append(e.b, byte(v))
+	case v < 0 && v >= -32:
+		e.b = append(e.b, byte(v))
+	case v >= 0:
+		e.putUint(0xcf, 8, uint64(v))
+	default:
+		e.putUint(0xd3, 8, uint64(v))
+	}
+}
+
+func (e *msgpackEncoder) putFloat(v float64) {
+	e.putUint(0xcb, 8, math.Float64bits(v))
+}
+
+func (e *msgpackEncoder) putLength(n int, fix byte, fixMax int, l16, l32 byte) {
+	switch {
+	case n <= fixMax:
+		e.b = append(e.b, fix|byte(n))
+	case n <= math.MaxUint16:
+		e.putUint(l16, 2, uint64(n))
+	default:
+		e.putUint(l32, 4, uint64(n))
+	}
+}
+
+func (e *msgpackEncoder) putString(s string) {
+	if len(s) <= 31 {
+		e.b = append(e.b, 0xa0|byte(len(s)))
+	} else if len(s) <= math.MaxUint8 {
+		e.b = append(e.b, 0xd9, byte(len(s)))
+	} else if len(s) <= math.MaxUint16 {
+		e.putUint(0xda, 2, uint64(len(s)))
+	} else {
+		e.putUint(0xdb, 4, uint64(len(s)))
+	}
+	e.b = append(e.b, s...)
+}
+
+func (e *msgpackEncoder) putArrayLen(n int) {
+	e.putLength(n, 0x90, 15, 0xdc, 0xdd)
+}
+
+func (e *msgpackEncoder) putMapLen(n int) {
+	e.putLength(n, 0x80, 15, 0xde, 0xdf)
+}
+
+// putEventTime encodes a time as the fluentd EventTime extension type.
+func (e *msgpackEncoder) putEventTime(sec, nsec uint32) {
+	e.b = append(e.b, 0xd7, 0x00)
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[0:4], sec)
+	binary.BigEndian.PutUint32(b[4:8], nsec)
+	e.b = append(e.b, b[:]...)
+}
+
+// putValue encodes a value decoded by encoding/json with UseNumber. Map
+// keys are sorted so that the encoding is deterministic.
+func (e *msgpackEncoder) putValue(v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		e.putNil()
+	case bool:
+		e.putBool(v)
+	case string:
+		e.putString(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			e.putInt(i)
+		} else if f, err := v.Float64(); err == nil {
+			e.putFloat(f)
+		} else {
+			return err
+		}
+	case []interface{}:
+		e.putArrayLen(len(v))
+		for _, x := range v {
+			if err := e.putValue(x); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		e.putMapLen(len(v))
+		for _, k := range keys {
+			e.putString(k)
+			if err := e.putValue(v[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cannot encode %T as msgpack", v)
+	}
+	return nil
+}
+
+// readMsgpack decodes a value. Strings and binary are decoded as strings,
+// arrays as []interface{}, maps as map[string]interface{} (with other keys
+// formatted as strings), integers as int64 or uint64, and extension types
+// as nil.
+func readMsgpack(r *bufio.Reader) (interface{}, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	readN := func(n int) ([]byte, error) {
+		if n > maxMsgpackLength {
+			return nil, fmt.Errorf("msgpack length %d is too long", n)
+		}
+		b := make([]byte, n)
+		_, err := io.ReadFull(r, b)
+		return b, err
+	}
+	readUint := func(n int) (uint64, error) {
+		b, err := readN(n)
+		if err != nil {
+			return 0, err
+		}
+		var v uint64
+		for _, x := range b {
+			v = v<<8 | uint64(x)
+		}
+		return v, nil
+	}
+	readString := func(n uint64, err error) (interface{}, error) {
+		if err != nil {
+			return nil, err
+		}
+		b, err := readN(int(n))
+		return string(b), err
+	}
+	readArray := func(n uint64, err error) (interface{}, error) {
+		if err != nil {
+			return nil, err
+		}
+		if n > maxMsgpackLength {
+			return nil, fmt.Errorf("msgpack length %d is too long", n)
+		}
+		var a []interface{}
+		for i := uint64(0); i < n; i++ {
+			v, err := readMsgpack(r)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		return a, nil
+	}
+	readMap := func(n uint64, err error) (interface{}, error) {
+		if err != nil {
+			return nil, err
+		}
+		if n > maxMsgpackLength {
+			return nil, fmt.Errorf("msgpack length %d is too long", n)
+		}
+		m := make(map[string]interface{})
+		for i := uint64(0); i < n; i++ {
+			k, err := readMsgpack(r)
+			if err != nil {
+				return nil, err
+			}
+			v, err := readMsgpack(r)
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(k)] = v
+		}
+		return m, nil
+	}
+	skipExt := func(n uint64, err error) (interface{}, error) {
+		if err != nil {
+			return nil, err
+		}
+		_, err = readN(int(n) + 1)
+		return nil, err
+	}
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xf0 == 0x80:
+		return readMap(uint64(c&0x0f), nil)
+	case c&0xf0 == 0x90:
+		return readArray(uint64(c&0x0f), nil)
+	case c&0xe0 == 0xa0:
+		return readString(uint64(c&0x1f), nil)
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xd9:
+		return readString(readUint(1))
+	case 0xc5, 0xda:
+		return readString(readUint(2))
+	case 0xc6, 0xdb:
+		return readString(readUint(4))
+	case 0xc7:
+		return skipExt(readUint(1))
+	case 0xc8:
+		return skipExt(readUint(2))
+	case 0xc9:
+		return skipExt(readUint(4))
+	case 0xca:
+		v, err := readUint(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case 0xcb:
+		v, err := readUint(8)
+		return math.Float64frombits(v), err
+	case 0xcc:
+		return readUint(1)
+	case 0xcd:
+		return readUint(2)
+	case 0xce:
+		return readUint(4)
+	case 0xcf:
+		return readUint(8)
+	case 0xd0:
+		v, err := readUint(1)
+		return int64(int8(v)), err
+	case 0xd1:
+		v, err := readUint(2)
+		return int64(int16(v)), err
+	case 0xd2:
+		v, err := readUint(4)
+		return int64(int32(v)), err
+	case 0xd3:
+		v, err := readUint(8)
+		return int64(v), err
+	case 0xd4:
+		return skipExt(1, nil)
+	case 0xd5:
+		return skipExt(2, nil)
+	case 0xd6:
+		return skipExt(4, nil)
+	case 0xd7:
+		return skipExt(8, nil)
+	case 0xd8:
+		return skipExt(16, nil)
+	case 0xdc:
+		return readArray(readUint(2))
+	case 0xdd:
+		return readArray(readUint(4))
+	case 0xde:
+		return readMap(readUint(2))
+	case 0xdf:
+		return readMap(readUint(4))
+	}
+	return nil, fmt.Errorf("msgpack type 0x%02x is invalid", c)
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sink publishes telemetry events from the sensor to external
+// systems without a gRPC client in between.
+package sink
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// Sink is an external system that telemetry events are published to.
+type Sink interface {
+	// Publish publishes a batch of events. If an error is returned, none
+	// of the events may be assumed to have been published.
+	Publish(events []*api.TelemetryEvent) error
+
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// Encoding is the serialization of events published by a sink.
+type Encoding int
+
+const (
+	// EncodingProtobuf serializes events as binary protocol buffers.
+	EncodingProtobuf Encoding = iota
+
+	// EncodingJSON serializes events as JSON objects using the protocol
+	// buffer JSON mapping.
+	EncodingJSON
+
+	// EncodingCEF renders events in the ArcSight Common Event Format.
+	EncodingCEF
+
+	// EncodingLEEF renders events in the QRadar Log Event Extended
+	// Format.
+	EncodingLEEF
+
+	// EncodingCloudEvents wraps the JSON form of events in CloudEvents
+	// 1.0 envelopes in the JSON event format.
+	EncodingCloudEvents
+)
+
+// ParseEncoding returns the Encoding named by s: "protobuf", "json", "cef",
+// "leef", or "cloudevents".
+func ParseEncoding(s string) (Encoding, error) {
+	switch strings.ToLower(s) {
+	case "protobuf", "proto":
+		return EncodingProtobuf, nil
+	case "json":
+		return EncodingJSON, nil
+	case "cef":
+		return EncodingCEF, nil
+	case "leef":
+		return EncodingLEEF, nil
+	case "cloudevents":
+		return EncodingCloudEvents, nil
+	}
+	return 0, fmt.Errorf("encoding %q is invalid", s)
+}
+
+var jsonMarshaler = jsonpb.Marshaler{}
+
+// Marshal serializes an event.
+func (enc Encoding) Marshal(e *api.TelemetryEvent) ([]byte, error) {
+	switch enc {
+	case EncodingJSON:
+		var b bytes.Buffer
+		if err := jsonMarshaler.Marshal(&b, e); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	case EncodingCEF:
+		return marshalCEF(e, time.Now()), nil
+	case EncodingLEEF:
+		return marshalLEEF(e, time.Now()), nil
+	case EncodingCloudEvents:
+		return marshalCloudEvent(e, time.Now())
+	}
+	return proto.Marshal(e)
+}
+
+// EventType returns the name of the type of an event, which is the name of
+// its field in the TelemetryEvent's event oneof, i.e. "process" or
+// "kernel_call".
+func EventType(e *api.TelemetryEvent) string {
+	if e.Event == nil {
+		return ""
+	}
+	t := reflect.TypeOf(e.Event).Elem()
+	if t.NumField() == 0 {
+		return ""
+	}
+	tag := t.Field(0).Tag.Get("protobuf")
+	for _, part := range strings.Split(tag, ",") {
+		if strings.HasPrefix(part, "name=") {
+			return strings.TrimPrefix(part, "name=")
+		}
+	}
+	return ""
+}
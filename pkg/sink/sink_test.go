@@ -0,0 +1,180 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventType(t *testing.T) {
+	assert.Equal(t, "", EventType(&api.TelemetryEvent{}))
+	assert.Equal(t, "process", EventType(&api.TelemetryEvent{
+		Event: &api.TelemetryEvent_Process{},
+	}))
+	assert.Equal(t, "kernel_call", EventType(&api.TelemetryEvent{
+		Event: &api.TelemetryEvent_KernelCall{},
+	}))
+}
+
+func TestEncoding(t *testing.T) {
+	_, err := ParseEncoding("xml")
+	assert.Error(t, err)
+
+	e := &api.TelemetryEvent{
+		Id:          "id",
+		ContainerId: "container",
+	}
+
+	enc, err := ParseEncoding("json")
+	require.NoError(t, err)
+	b, err := enc.Marshal(e)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "id", "containerId": "container"}`, string(b))
+
+	enc, err = ParseEncoding("protobuf")
+	require.NoError(t, err)
+	b, err = enc.Marshal(e)
+	require.NoError(t, err)
+	var decoded api.TelemetryEvent
+	require.NoError(t, proto.Unmarshal(b, &decoded))
+	assert.True(t, proto.Equal(e, &decoded))
+}
+
+type testSink struct {
+	mutex   sync.Mutex
+	batches [][]*api.TelemetryEvent
+	fail    int
+	closed  bool
+}
+
+func (s *testSink) Publish(events []*api.TelemetryEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.fail > 0 {
+		s.fail--
+		return errors.New("failed")
+	}
+	s.batches = append(s.batches, events)
+	return nil
+}
+
+func (s *testSink) Close() error {
+	s.mutex.Lock()
+	s.closed = true
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *testSink) batchSizes() []int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var sizes []int
+	for _, b := range s.batches {
+		sizes = append(sizes, len(b))
+	}
+	return sizes
+}
+
+func TestBatcher(t *testing.T) {
+	s := &testSink{fail: 1}
+	b := NewBatcher(s,
+		WithBatchSize(3),
+		WithBatchInterval(time.Hour),
+		WithRetryBackoff(time.Millisecond))
+
+	events := make(chan *api.TelemetryEvent)
+	done := make(chan struct{})
+	go func() {
+		b.Run(context.Background(), events)
+		close(done)
+	}()
+	for i := 0; i < 7; i++ {
+		events <- &api.TelemetryEvent{}
+	}
+	close(events)
+	<-done
+
+	// The first batch is retried, and the last partial batch is
+	// published when the events end
+	assert.Equal(t, []int{3, 3, 1}, s.batchSizes())
+	assert.True(t, s.closed)
+
+	// Batches that cannot be published are dropped after the retries
+	s = &testSink{fail: 10}
+	b = NewBatcher(s,
+		WithBatchSize(1),
+		WithRetries(2),
+		WithRetryBackoff(time.Millisecond))
+	events = make(chan *api.TelemetryEvent, 2)
+	events <- &api.TelemetryEvent{}
+	events <- &api.TelemetryEvent{}
+	close(events)
+	b.Run(context.Background(), events)
+	assert.Empty(t, s.batchSizes())
+	assert.Equal(t, 4, s.fail)
+}
+
+func TestBatcherInterval(t *testing.T) {
+	s := &testSink{}
+	b := NewBatcher(s,
+		WithBatchSize(100),
+		WithBatchInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan *api.TelemetryEvent)
+	done := make(chan struct{})
+	go func() {
+		b.Run(ctx, events)
+		close(done)
+	}()
+	events <- &api.TelemetryEvent{}
+	events <- &api.TelemetryEvent{}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []int{2}, s.batchSizes())
+
+	cancel()
+	<-done
+	assert.True(t, s.closed)
+}
+
+func TestBatcherDrain(t *testing.T) {
+	s := &testSink{}
+	b := NewBatcher(s,
+		WithBatchSize(2),
+		WithBatchInterval(time.Hour))
+
+	// Events still queued when ctx is done are published
+	events := make(chan *api.TelemetryEvent, 5)
+	for i := 0; i < 5; i++ {
+		events <- &api.TelemetryEvent{}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b.Run(ctx, events)
+	assert.Equal(t, []int{2, 2, 1}, s.batchSizes())
+	assert.Len(t, events, 0)
+	assert.True(t, s.closed)
+}
@@ -0,0 +1,261 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+)
+
+// Syslog messages are formatted as described by RFC 5424, with the event
+// type as the MSGID and the serialized event as the MSG. Over UDP each
+// message is sent in its own datagram (RFC 5426), and over TCP and TLS
+// messages are framed by octet counting (RFC 5425 and RFC 6587).
+
+const (
+	syslogSeverityInfo = 6
+
+	// The largest UDP datagram that is sent; longer messages are
+	// truncated
+	maxSyslogUDPMessage = 65000
+)
+
+// SyslogOption is used to implement optional arguments for NewSyslogSink.
+// It must be exported, but it is not typically used directly.
+type SyslogOption func(*syslogOptions)
+
+type syslogOptions struct {
+	facility  int
+	appName   string
+	hostname  string
+	encoding  Encoding
+	tlsConfig *tls.Config
+	timeout   time.Duration
+}
+
+// WithSyslogFacility sets the facility of messages, i.e. 16 for local0,
+// which is the default.
+func WithSyslogFacility(facility int) SyslogOption {
+	return func(o *syslogOptions) {
+		o.facility = facility
+	}
+}
+
+// WithSyslogAppName sets the APP-NAME of messages. The default is
+// "capsule8-sensor".
+func WithSyslogAppName(appName string) SyslogOption {
+	return func(o *syslogOptions) {
+		o.appName = appName
+	}
+}
+
+// WithSyslogHostname sets the HOSTNAME of messages. The default is the
+// host's name.
+func WithSyslogHostname(hostname string) SyslogOption {
+	return func(o *syslogOptions) {
+		o.hostname = hostname
+	}
+}
+
+// WithSyslogEncoding sets the serialization of events in messages. The
+// default is EncodingJSON.
+func WithSyslogEncoding(encoding Encoding) SyslogOption {
+	return func(o *syslogOptions) {
+		o.encoding = encoding
+	}
+}
+
+// WithSyslogTLSConfig sets the TLS configuration of the "tls" transport.
+func WithSyslogTLSConfig(tlsConfig *tls.Config) SyslogOption {
+	return func(o *syslogOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithSyslogTimeout sets how long to wait to connect to and write to the
+// server. The default is 10 seconds.
+func WithSyslogTimeout(timeout time.Duration) SyslogOption {
+	return func(o *syslogOptions) {
+		o.timeout = timeout
+	}
+}
+
+// SyslogSink sends events to a syslog server.
+type SyslogSink struct {
+	transport string
+	addr      string
+	options   syslogOptions
+	procID    string
+
+	mutex sync.Mutex
+	conn  net.Conn
+}
+
+// NewSyslogSink creates a new sink that sends events to the syslog server
+// at addr (host:port) using the "udp", "tcp", or "tls" transport.
+func NewSyslogSink(transport, addr string, options ...SyslogOption) (*SyslogSink, error) {
+	switch transport {
+	case "udp", "tcp", "tls":
+	default:
+		return nil, fmt.Errorf("syslog transport %q is invalid", transport)
+	}
+	if addr == "" {
+		return nil, fmt.Errorf("syslog address is empty")
+	}
+
+	s := &SyslogSink{
+		transport: transport,
+		addr:      addr,
+		options: syslogOptions{
+			facility: 16,
+			appName:  "capsule8-sensor",
+			encoding: EncodingJSON,
+			timeout:  10 * time.Second,
+		},
+		procID: strconv.Itoa(os.Getpid()),
+	}
+	for _, option := range options {
+		option(&s.options)
+	}
+	if s.options.facility < 0 || s.options.facility > 23 {
+		return nil, fmt.Errorf("syslog facility %d is invalid",
+			s.options.facility)
+	}
+	if s.options.encoding == EncodingProtobuf {
+		return nil, fmt.Errorf("syslog messages cannot be protobuf")
+	}
+	if s.options.hostname == "" {
+		s.options.hostname, _ = os.Hostname()
+	}
+	return s, nil
+}
+
+// syslogHeaderField returns the value of a header field, which is "-" if
+// it is empty, with characters that are not permitted replaced.
+func syslogHeaderField(s string, maxLen int) string {
+	if s == "" {
+		return "-"
+	}
+	b := []byte(s)
+	for i, c := range b {
+		if c < 33 || c > 126 {
+			b[i] = '_'
+		}
+	}
+	if len(b) > maxLen {
+		b = b[:maxLen]
+	}
+	return string(b)
+}
+
+// formatMessage formats an event as a syslog message.
+func (s *SyslogSink) formatMessage(e *api.TelemetryEvent, now time.Time) ([]byte, error) {
+	msg, err := s.options.encoding.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %s %s - ",
+		s.options.facility*8+syslogSeverityInfo,
+		now.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderField(s.options.hostname, 255),
+		syslogHeaderField(s.options.appName, 48),
+		syslogHeaderField(s.procID, 128),
+		syslogHeaderField(EventType(e), 32))
+	b.Write(msg)
+	return b.Bytes(), nil
+}
+
+func (s *SyslogSink) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.options.timeout}
+	if s.transport == "tls" {
+		return tls.DialWithDialer(dialer, "tcp", s.addr, s.options.tlsConfig)
+	}
+	return dialer.Dial(s.transport, s.addr)
+}
+
+// Publish sends a batch of events, each in its own message.
+func (s *SyslogSink) Publish(events []*api.TelemetryEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.conn == nil {
+		conn, err := s.dial()
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	now := time.Now()
+	var stream bytes.Buffer
+	for _, e := range events {
+		msg, err := s.formatMessage(e, now)
+		if err != nil {
+			glog.Warningf("Could not serialize event %s: %v", e.Id, err)
+			continue
+		}
+		if s.transport == "udp" {
+			if len(msg) > maxSyslogUDPMessage {
+				msg = msg[:maxSyslogUDPMessage]
+			}
+			if err = s.write(msg); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(&stream, "%d ", len(msg))
+		stream.Write(msg)
+	}
+	if stream.Len() > 0 {
+		return s.write(stream.Bytes())
+	}
+	return nil
+}
+
+// write writes to the connection, which is closed if it fails so that it
+// is dialed again.
+func (s *SyslogSink) write(b []byte) error {
+	s.conn.SetWriteDeadline(time.Now().Add(s.options.timeout))
+	if _, err := s.conn.Write(b); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection to the server.
+func (s *SyslogSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
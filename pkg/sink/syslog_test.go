@@ -0,0 +1,114 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var syslogMessagePattern = regexp.MustCompile(
+	`^<134>1 \d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{6}Z test_host capsule8-sensor \d+ (\S+) - (.*)$`)
+
+func TestNewSyslogSink(t *testing.T) {
+	_, err := NewSyslogSink("http", "localhost:514")
+	assert.Error(t, err)
+	_, err = NewSyslogSink("udp", "")
+	assert.Error(t, err)
+	_, err = NewSyslogSink("udp", "localhost:514", WithSyslogFacility(24))
+	assert.Error(t, err)
+	_, err = NewSyslogSink("udp", "localhost:514",
+		WithSyslogEncoding(EncodingProtobuf))
+	assert.Error(t, err)
+}
+
+var syslogTestEvents = []*api.TelemetryEvent{
+	&api.TelemetryEvent{
+		Id: "1",
+		Event: &api.TelemetryEvent_Process{
+			Process: &api.ProcessEvent{},
+		},
+	},
+	&api.TelemetryEvent{
+		Id: "2",
+	},
+}
+
+func TestSyslogSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	s, err := NewSyslogSink("udp", conn.LocalAddr().String(),
+		WithSyslogHostname("test host"))
+	require.NoError(t, err)
+	defer s.Close()
+	require.NoError(t, s.Publish(syslogTestEvents))
+
+	var msgs [][]string
+	buf := make([]byte, 65536)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	for i := 0; i < 2; i++ {
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		m := syslogMessagePattern.FindStringSubmatch(string(buf[:n]))
+		require.NotNil(t, m, string(buf[:n]))
+		msgs = append(msgs, m[1:])
+	}
+	assert.Equal(t, [][]string{
+		{"process", `{"id":"1","process":{}}`},
+		{"-", `{"id":"2"}`},
+	}, msgs)
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	s, err := NewSyslogSink("tcp", l.Addr().String(),
+		WithSyslogHostname("test_host"))
+	require.NoError(t, err)
+	defer s.Close()
+	require.NoError(t, s.Publish(syslogTestEvents))
+
+	c, err := l.Accept()
+	require.NoError(t, err)
+	defer c.Close()
+	c.SetReadDeadline(time.Now().Add(time.Second))
+	r := bufio.NewReader(c)
+	for i := 0; i < 2; i++ {
+		length, err := r.ReadString(' ')
+		require.NoError(t, err)
+		n, err := strconv.Atoi(length[:len(length)-1])
+		require.NoError(t, err)
+		msg := make([]byte, n)
+		_, err = io.ReadFull(r, msg)
+		require.NoError(t, err)
+		m := syslogMessagePattern.FindStringSubmatch(string(msg))
+		require.NotNil(t, m, string(msg))
+		assert.Contains(t, m[2], `"id":"`+syslogTestEvents[i].Id+`"`)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/golang/glog"
+)
+
+// Each batch of events is POSTed as a JSON array of events, or as a
+// CloudEvents JSON batch, which may be compressed with a Content-Encoding.
+// When a secret is set, requests are signed with an HMAC-SHA256 of the Unix
+// time in the WebhookTimestampHeader, a ".", and the body as sent (after
+// any compression), which is sent hex encoded in the
+// WebhookSignatureHeader as "sha256=<hex>". Receivers should check that
+// the timestamp is recent so that requests cannot be replayed.
+//
+// Requests that fail because of the network, a 5xx status, or a 429
+// status are retried with exponential backoff. Batches that cannot be
+// delivered are appended to the dead-letter file, if there is one, as
+// newline-delimited JSON events.
+
+const (
+	// WebhookTimestampHeader is the header containing the Unix time at
+	// which a webhook request was signed.
+	WebhookTimestampHeader = "X-Capsule8-Timestamp"
+
+	// WebhookSignatureHeader is the header containing the signature of a
+	// webhook request.
+	WebhookSignatureHeader = "X-Capsule8-Signature"
+)
+
+// WebhookOption is used to implement optional arguments for
+// NewWebhookSink. It must be exported, but it is not typically used
+// directly.
+type WebhookOption func(*webhookOptions)
+
+type webhookOptions struct {
+	secret         []byte
+	headers        map[string]string
+	encoding       Encoding
+	retries        int
+	backoff        time.Duration
+	deadLetterPath string
+	compression    Compression
+	client         *http.Client
+}
+
+// WithWebhookSecret sets the secret used to sign requests. Requests are not
+// signed if it is empty, which is the default.
+func WithWebhookSecret(secret string) WebhookOption {
+	return func(o *webhookOptions) {
+		o.secret = []byte(secret)
+	}
+}
+
+// WithWebhookHeaders sets additional headers sent with each request, i.e.
+// an Authorization header.
+func WithWebhookHeaders(headers map[string]string) WebhookOption {
+	return func(o *webhookOptions) {
+		o.headers = headers
+	}
+}
+
+// WithWebhookEncoding sets the encoding of events, which must be
+// EncodingJSON (the default) or EncodingCloudEvents.
+func WithWebhookEncoding(encoding Encoding) WebhookOption {
+	return func(o *webhookOptions) {
+		o.encoding = encoding
+	}
+}
+
+// WithWebhookRetries sets the number of times that a failed request is
+// retried before the batch is given up on. The default is 3.
+func WithWebhookRetries(retries int) WebhookOption {
+	return func(o *webhookOptions) {
+		o.retries = retries
+	}
+}
+
+// WithWebhookBackoff sets the time waited before first retrying a failed
+// request, which is doubled for each retry after it. The default is 500
+// milliseconds.
+func WithWebhookBackoff(backoff time.Duration) WebhookOption {
+	return func(o *webhookOptions) {
+		o.backoff = backoff
+	}
+}
+
+// WithWebhookDeadLetterPath sets the path of the file that batches that
+// cannot be delivered are appended to. If it is empty, which is the
+// default, Publish returns an error for them instead.
+func WithWebhookDeadLetterPath(path string) WebhookOption {
+	return func(o *webhookOptions) {
+		o.deadLetterPath = path
+	}
+}
+
+// WithWebhookCompression sets the compression of request bodies. The
+// default is CompressionNone.
+func WithWebhookCompression(compression Compression) WebhookOption {
+	return func(o *webhookOptions) {
+		o.compression = compression
+	}
+}
+
+// WithWebhookHTTPClient sets the HTTP client used to make requests.
+func WithWebhookHTTPClient(client *http.Client) WebhookOption {
+	return func(o *webhookOptions) {
+		o.client = client
+	}
+}
+
+// WebhookSink POSTs batches of events to a URL.
+type WebhookSink struct {
+	url     string
+	options webhookOptions
+
+	mutex      sync.Mutex
+	deadLetter *os.File
+}
+
+// webhookError is a failed request, which is temporary if the request may
+// succeed when it is retried.
+type webhookError struct {
+	err       error
+	temporary bool
+}
+
+func (e webhookError) Error() string {
+	return e.err.Error()
+}
+
+// NewWebhookSink creates a new sink that POSTs batches of events to url.
+func NewWebhookSink(url string, options ...WebhookOption) (*WebhookSink, error) {
+	if url == "" {
+		return nil, errors.New("webhook URL is empty")
+	}
+	s := &WebhookSink{
+		url: url,
+		options: webhookOptions{
+			encoding: EncodingJSON,
+			retries:  3,
+			backoff:  500 * time.Millisecond,
+			client:   &http.Client{Timeout: 30 * time.Second},
+		},
+	}
+	for _, option := range options {
+		option(&s.options)
+	}
+	if s.options.encoding != EncodingJSON &&
+		s.options.encoding != EncodingCloudEvents {
+		return nil, errors.New("webhook encoding must be JSON or CloudEvents")
+	}
+	if s.options.deadLetterPath != "" {
+		f, err := os.OpenFile(s.options.deadLetterPath,
+			os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, err
+		}
+		s.deadLetter = f
+	}
+	return s, nil
+}
+
+// sign returns the signature of a request body at a time.
+func (s *WebhookSink) sign(timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, s.options.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookSink) post(body []byte) error {
+	req, err := http.NewRequest("POST", s.url, bytes.NewReader(body))
+	if err != nil {
+		return webhookError{err: err}
+	}
+	if s.options.encoding == EncodingCloudEvents {
+		req.Header.Set("Content-Type", "application/cloudevents-batch+json")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if ce := s.options.compression.contentEncoding(); ce != "" {
+		req.Header.Set("Content-Encoding", ce)
+	}
+	for k, v := range s.options.headers {
+		req.Header.Set(k, v)
+	}
+	if len(s.options.secret) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, s.sign(timestamp, body))
+	}
+
+	resp, err := s.options.client.Do(req)
+	if err != nil {
+		return webhookError{err: err, temporary: true}
+	}
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<20))
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return webhookError{
+		err: fmt.Errorf("webhook returned %s", resp.Status),
+		temporary: resp.StatusCode >= 500 ||
+			resp.StatusCode == http.StatusTooManyRequests,
+	}
+}
+
+// writeDeadLetters appends events that could not be delivered to the
+// dead-letter file.
+func (s *WebhookSink) writeDeadLetters(events []*api.TelemetryEvent) error {
+	var b bytes.Buffer
+	for _, e := range events {
+		if err := jsonMarshaler.Marshal(&b, e); err != nil {
+			glog.Warningf("Could not serialize event %s: %v", e.Id, err)
+			continue
+		}
+		b.WriteByte('\n')
+	}
+	_, err := s.deadLetter.Write(b.Bytes())
+	return err
+}
+
+// Publish POSTs a batch of events, retrying failed requests with backoff.
+// Batches that cannot be delivered are written to the dead-letter file, if
+// there is one.
+func (s *WebhookSink) Publish(events []*api.TelemetryEvent) error {
+	var body bytes.Buffer
+	body.WriteByte('[')
+	for _, e := range events {
+		b, err := s.options.encoding.Marshal(e)
+		if err != nil {
+			glog.Warningf("Could not serialize event %s: %v", e.Id, err)
+			continue
+		}
+		if body.Len() > 1 {
+			body.WriteByte(',')
+		}
+		body.Write(b)
+	}
+	body.WriteByte(']')
+	b, err := s.options.compression.compress(body.Bytes())
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	backoff := s.options.backoff
+	for attempt := 0; ; attempt++ {
+		if err = s.post(b); err == nil {
+			return nil
+		}
+		if !err.(webhookError).temporary || attempt >= s.options.retries {
+			break
+		}
+		glog.V(1).Infof("Webhook request failed (%v), retrying in %s",
+			err, backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	if s.deadLetter == nil {
+		return err
+	}
+	glog.Warningf("Could not deliver %d events to webhook (%v), writing them to %s",
+		len(events), err, s.options.deadLetterPath)
+	if dlErr := s.writeDeadLetters(events); dlErr != nil {
+		return fmt.Errorf("%v; could not write dead letters: %v", err, dlErr)
+	}
+	return nil
+}
+
+// Close closes the dead-letter file.
+func (s *WebhookSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.deadLetter == nil {
+		return nil
+	}
+	err := s.deadLetter.Close()
+	s.deadLetter = nil
+	return err
+}
@@ -0,0 +1,179 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/capsule8/capsule8/api/v0"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWebhook struct {
+	mutex    sync.Mutex
+	requests int
+	batches  [][]map[string]interface{}
+
+	// Statuses returned for successive requests, after which 200 is
+	// returned
+	statuses []int
+}
+
+func (h *fakeWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(r.Header.Get(WebhookTimestampHeader) + "."))
+	mac.Write(body)
+	if r.Header.Get(WebhookSignatureHeader) !=
+		"sha256="+hex.EncodeToString(mac.Sum(nil)) ||
+		r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ = ioutil.ReadAll(gz)
+	}
+
+	status := http.StatusOK
+	if h.requests < len(h.statuses) {
+		status = h.statuses[h.requests]
+	}
+	h.requests++
+	if status == http.StatusOK {
+		var batch []map[string]interface{}
+		json.Unmarshal(body, &batch)
+		h.batches = append(h.batches, batch)
+	}
+	w.WriteHeader(status)
+}
+
+func newTestWebhook(t *testing.T, options ...WebhookOption) (*fakeWebhook, *WebhookSink, func()) {
+	h := &fakeWebhook{}
+	server := httptest.NewServer(h)
+	options = append([]WebhookOption{
+		WithWebhookSecret("secret"),
+		WithWebhookHeaders(map[string]string{
+			"Authorization": "Bearer token",
+		}),
+		WithWebhookRetries(2),
+		WithWebhookBackoff(time.Millisecond),
+	}, options...)
+	s, err := NewWebhookSink(server.URL, options...)
+	require.NoError(t, err)
+	return h, s, server.Close
+}
+
+func TestWebhookSink(t *testing.T) {
+	_, err := NewWebhookSink("")
+	assert.Error(t, err)
+	_, err = NewWebhookSink("http://localhost",
+		WithWebhookEncoding(EncodingCEF))
+	assert.Error(t, err)
+
+	h, s, done := newTestWebhook(t)
+	defer done()
+
+	// Temporary failures are retried
+	h.statuses = []int{http.StatusServiceUnavailable,
+		http.StatusTooManyRequests}
+	require.NoError(t, s.Publish([]*api.TelemetryEvent{
+		{Id: "1"}, {Id: "2"},
+	}))
+	assert.Equal(t, 3, h.requests)
+	require.Len(t, h.batches, 1)
+	require.Len(t, h.batches[0], 2)
+	assert.Equal(t, "1", h.batches[0][0]["id"])
+	assert.Equal(t, "2", h.batches[0][1]["id"])
+
+	// Other failures are not
+	h.requests = 0
+	h.statuses = []int{http.StatusBadRequest}
+	assert.Error(t, s.Publish([]*api.TelemetryEvent{{Id: "3"}}))
+	assert.Equal(t, 1, h.requests)
+
+	// Retries are limited
+	h.requests = 0
+	h.statuses = []int{500, 500, 500, 500}
+	assert.Error(t, s.Publish([]*api.TelemetryEvent{{Id: "4"}}))
+	assert.Equal(t, 3, h.requests)
+	require.NoError(t, s.Close())
+}
+
+func TestWebhookSinkCloudEvents(t *testing.T) {
+	h, s, done := newTestWebhook(t, WithWebhookEncoding(EncodingCloudEvents))
+	defer done()
+
+	require.NoError(t, s.Publish([]*api.TelemetryEvent{{Id: "1"}}))
+	require.Len(t, h.batches, 1)
+	require.Len(t, h.batches[0], 1)
+	assert.Equal(t, "1.0", h.batches[0][0]["specversion"])
+	assert.Equal(t, "1", h.batches[0][0]["id"])
+}
+
+func TestWebhookSinkCompression(t *testing.T) {
+	h, s, done := newTestWebhook(t, WithWebhookCompression(CompressionGzip))
+	defer done()
+
+	require.NoError(t, s.Publish([]*api.TelemetryEvent{{Id: "1"}}))
+	require.Len(t, h.batches, 1)
+	require.Len(t, h.batches[0], 1)
+	assert.Equal(t, "1", h.batches[0][0]["id"])
+}
+
+func TestWebhookSinkDeadLetters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhook_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "dead-letters.json")
+
+	h, s, done := newTestWebhook(t, WithWebhookDeadLetterPath(path))
+	defer done()
+
+	h.statuses = []int{http.StatusBadRequest, 500, 500, 500}
+	require.NoError(t, s.Publish([]*api.TelemetryEvent{{Id: "1"}}))
+	require.NoError(t, s.Publish([]*api.TelemetryEvent{
+		{Id: "2"}, {Id: "3"},
+	}))
+	require.NoError(t, s.Publish([]*api.TelemetryEvent{{Id: "4"}}))
+	require.NoError(t, s.Close())
+
+	assert.Equal(t, []string{"1", "2", "3"}, readJSONLines(t, path))
+	require.Len(t, h.batches, 1)
+	assert.Equal(t, "4", h.batches[0][0]["id"])
+}
@@ -0,0 +1,195 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bpf implements just enough of the Linux bpf(2) interface to load
+// small hand-assembled eBPF programs and the maps that they use.
+//
+// The only program provided is CgroupFilterProgram, which decides whether a
+// tracing event is recorded. There is no eBPF collection backend: events are
+// still recorded through the ftrace tracepoint and kprobe formats into perf
+// ring buffers, and kprobe arguments are still extracted by fetch args.
+package bpf
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// ProgramType is the type of an eBPF program, which determines where it may be
+// attached and which helpers it may call.
+type ProgramType uint32
+
+// Program types from include/uapi/linux/bpf.h
+const (
+	// ProgramTypeKprobe programs may be attached to kprobes and uprobes.
+	ProgramTypeKprobe ProgramType = 2
+
+	// ProgramTypeTracepoint programs may be attached to tracepoints.
+	ProgramTypeTracepoint ProgramType = 5
+)
+
+// Register is an eBPF register.
+type Register uint8
+
+// eBPF registers. R0 holds return values, R1-R5 hold function arguments, and
+// R10 is the read-only frame pointer.
+const (
+	R0 Register = iota
+	R1
+	R2
+	R3
+	R4
+	R5
+	R6
+	R7
+	R8
+	R9
+	R10
+)
+
+// Instruction opcode fields from include/uapi/linux/bpf_common.h and
+// include/uapi/linux/bpf.h
+const (
+	classLD    = 0x00
+	classSTX   = 0x03
+	classJMP   = 0x05
+	classALU64 = 0x07
+
+	sizeDW  = 0x18
+	modeMEM = 0x60
+
+	aluADD = 0x00
+	aluMOV = 0xb0
+
+	srcK = 0x00
+	srcX = 0x08
+
+	jmpJEQ  = 0x10
+	jmpCALL = 0x80
+	jmpEXIT = 0x90
+
+	pseudoMapFD = 1
+)
+
+// Helper function IDs from include/uapi/linux/bpf.h
+const (
+	funcMapLookupElem              = 1
+	funcGetCurrentAncestorCgroupID = 123
+)
+
+// Instruction is a single eBPF instruction.
+type Instruction struct {
+	Code   uint8
+	Dst    Register
+	Src    Register
+	Offset int16
+	Imm    int32
+}
+
+func mov64Imm(dst Register, imm int32) Instruction {
+	return Instruction{Code: classALU64 | aluMOV | srcK, Dst: dst, Imm: imm}
+}
+
+func mov64Reg(dst, src Register) Instruction {
+	return Instruction{Code: classALU64 | aluMOV | srcX, Dst: dst, Src: src}
+}
+
+func add64Imm(dst Register, imm int32) Instruction {
+	return Instruction{Code: classALU64 | aluADD | srcK, Dst: dst, Imm: imm}
+}
+
+func storeMem64(dst Register, offset int16, src Register) Instruction {
+	return Instruction{
+		Code:   classSTX | sizeDW | modeMEM,
+		Dst:    dst,
+		Src:    src,
+		Offset: offset,
+	}
+}
+
+// loadMapFD loads a map's file descriptor into a register. The kernel replaces
+// it with a pointer to the map when the program is loaded. It is the only wide
+// instruction, so it occupies two instruction slots.
+func loadMapFD(dst Register, fd int) []Instruction {
+	return []Instruction{
+		Instruction{
+			Code: classLD | sizeDW,
+			Dst:  dst,
+			Src:  pseudoMapFD,
+			Imm:  int32(fd),
+		},
+		Instruction{},
+	}
+}
+
+func jumpEqImm(dst Register, imm int32, offset int16) Instruction {
+	return Instruction{
+		Code:   classJMP | jmpJEQ | srcK,
+		Dst:    dst,
+		Offset: offset,
+		Imm:    imm,
+	}
+}
+
+func call(function int32) Instruction {
+	return Instruction{Code: classJMP | jmpCALL, Imm: function}
+}
+
+func exit() Instruction {
+	return Instruction{Code: classJMP | jmpEXIT}
+}
+
+// CgroupFilterProgram returns a program that returns 1 if the current task is
+// in one of the cgroups in the specified hash map or in a descendant of one,
+// and 0 otherwise. Keys must be 64-bit cgroup IDs, which are the inode numbers
+// of cgroup2 directories. Levels are the depths in the cgroup2 hierarchy of the
+// cgroups in the map, where the root cgroup is at level 0. The program
+// requires Linux 5.7 or later.
+func CgroupFilterProgram(mapFD int, levels []int) []Instruction {
+	var insns []Instruction
+	for _, level := range levels {
+		// *(u64 *)(r10 - 8) = bpf_get_current_ancestor_cgroup_id(level)
+		insns = append(insns,
+			mov64Imm(R1, int32(level)),
+			call(funcGetCurrentAncestorCgroupID),
+			storeMem64(R10, -8, R0),
+		)
+
+		// if (bpf_map_lookup_elem(map, r10 - 8) != NULL) return 1
+		insns = append(insns, loadMapFD(R1, mapFD)...)
+		insns = append(insns,
+			mov64Reg(R2, R10),
+			add64Imm(R2, -8),
+			call(funcMapLookupElem),
+			jumpEqImm(R0, 0, 2),
+			mov64Imm(R0, 1),
+			exit(),
+		)
+	}
+
+	return append(insns, mov64Imm(R0, 0), exit())
+}
+
+// EncodeInstructions encodes instructions in the form expected by the kernel.
+func EncodeInstructions(insns []Instruction) []byte {
+	buf := new(bytes.Buffer)
+	for _, insn := range insns {
+		binary.Write(buf, binary.LittleEndian, insn.Code)
+		binary.Write(buf, binary.LittleEndian,
+			uint8(insn.Src&0xf)<<4|uint8(insn.Dst&0xf))
+		binary.Write(buf, binary.LittleEndian, insn.Offset)
+		binary.Write(buf, binary.LittleEndian, insn.Imm)
+	}
+	return buf.Bytes()
+}
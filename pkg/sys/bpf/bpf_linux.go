@@ -0,0 +1,145 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpf
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"github.com/capsule8/capsule8/pkg/sys"
+
+	"golang.org/x/sys/unix"
+)
+
+// bpf(2) commands and map types from include/uapi/linux/bpf.h
+const (
+	cmdMapCreate     = 0
+	cmdMapUpdateElem = 2
+	cmdProgLoad      = 5
+
+	mapTypeHash = 1
+
+	// The size of the buffer used for verifier messages when a program
+	// fails to load
+	verifierLogSize = 64 * 1024
+)
+
+type mapCreateAttr struct {
+	mapType    uint32
+	keySize    uint32
+	valueSize  uint32
+	maxEntries uint32
+	mapFlags   uint32
+}
+
+type mapElemAttr struct {
+	mapFD uint32
+	_     uint32
+	key   uint64
+	value uint64
+	flags uint64
+}
+
+type progLoadAttr struct {
+	progType    uint32
+	insnCnt     uint32
+	insns       uint64
+	license     uint64
+	logLevel    uint32
+	logSize     uint32
+	logBuf      uint64
+	kernVersion uint32
+	_           uint32
+}
+
+func bpf(cmd int, attr unsafe.Pointer, size uintptr) (int, error) {
+	r1, _, errno := unix.Syscall(unix.SYS_BPF, uintptr(cmd),
+		uintptr(attr), size)
+	if errno != 0 {
+		return -1, errno
+	}
+	return int(r1), nil
+}
+
+// CreateHashMap creates a new hash map and returns its file descriptor.
+func CreateHashMap(keySize, valueSize, maxEntries uint32) (int, error) {
+	attr := mapCreateAttr{
+		mapType:    mapTypeHash,
+		keySize:    keySize,
+		valueSize:  valueSize,
+		maxEntries: maxEntries,
+	}
+	return bpf(cmdMapCreate, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+}
+
+// UpdateElement creates or updates an element in a map.
+func UpdateElement(fd int, key, value []byte) error {
+	attr := mapElemAttr{
+		mapFD: uint32(fd),
+		key:   uint64(uintptr(unsafe.Pointer(&key[0]))),
+		value: uint64(uintptr(unsafe.Pointer(&value[0]))),
+	}
+	_, err := bpf(cmdMapUpdateElem, unsafe.Pointer(&attr),
+		unsafe.Sizeof(attr))
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(value)
+	return err
+}
+
+// LoadProgram loads a program into the kernel and returns its file descriptor.
+// If the verifier rejects the program, its messages are included in the error.
+func LoadProgram(
+	progType ProgramType,
+	insns []Instruction,
+	license string,
+) (int, error) {
+	code := EncodeInstructions(insns)
+	lic, err := unix.BytePtrFromString(license)
+	if err != nil {
+		return -1, err
+	}
+	log := make([]byte, verifierLogSize)
+
+	// Kernels before 5.0 refuse to load kprobe programs with a
+	// kern_version that does not match the running kernel.
+	major, minor, patchlevel := sys.KernelVersion()
+	if patchlevel > 255 {
+		patchlevel = 255
+	}
+
+	attr := progLoadAttr{
+		progType:    uint32(progType),
+		insnCnt:     uint32(len(insns)),
+		insns:       uint64(uintptr(unsafe.Pointer(&code[0]))),
+		license:     uint64(uintptr(unsafe.Pointer(lic))),
+		logLevel:    1,
+		logSize:     uint32(len(log)),
+		logBuf:      uint64(uintptr(unsafe.Pointer(&log[0]))),
+		kernVersion: uint32(major<<16 | minor<<8 | patchlevel),
+	}
+	fd, err := bpf(cmdProgLoad, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	runtime.KeepAlive(code)
+	runtime.KeepAlive(lic)
+	runtime.KeepAlive(log)
+	if err != nil {
+		if n := bytes.IndexByte(log, 0); n > 0 {
+			return -1, fmt.Errorf("%v: %s", err, log[:n])
+		}
+		return -1, err
+	}
+	return fd, nil
+}
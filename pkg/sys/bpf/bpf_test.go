@@ -0,0 +1,65 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bpf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeInstructions(t *testing.T) {
+	insns := []Instruction{
+		storeMem64(R10, -8, R0),
+		add64Imm(R2, -8),
+		exit(),
+	}
+	insns = append(insns, loadMapFD(R1, 7)...)
+
+	expected := []byte{
+		0x7b, 0x0a, 0xf8, 0xff, 0x00, 0x00, 0x00, 0x00,
+		0x07, 0x02, 0x00, 0x00, 0xf8, 0xff, 0xff, 0xff,
+		0x95, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x18, 0x11, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	assert.Equal(t, expected, EncodeInstructions(insns))
+}
+
+func TestCgroupFilterProgram(t *testing.T) {
+	insns := CgroupFilterProgram(9, nil)
+	assert.Equal(t, []Instruction{mov64Imm(R0, 0), exit()}, insns)
+
+	insns = CgroupFilterProgram(9, []int{1, 3})
+	assert.Len(t, insns, 24)
+
+	for i, level := range []int{1, 3} {
+		block := insns[i*11 : (i+1)*11]
+
+		// The map lookup key is the ancestor cgroup ID at each level
+		assert.Equal(t, mov64Imm(R1, int32(level)), block[0])
+		assert.Equal(t, call(funcGetCurrentAncestorCgroupID), block[1])
+		assert.Equal(t, Instruction{Code: 0x18, Dst: R1,
+			Src: pseudoMapFD, Imm: 9}, block[3])
+		assert.Equal(t, call(funcMapLookupElem), block[7])
+
+		// A miss must skip to the next block
+		assert.Equal(t, int16(2), block[8].Offset)
+		assert.Equal(t, exit(), block[10])
+	}
+	assert.Equal(t, mov64Imm(R1, 3), insns[11])
+	assert.Equal(t, mov64Imm(R0, 0), insns[22])
+	assert.Equal(t, exit(), insns[23])
+}
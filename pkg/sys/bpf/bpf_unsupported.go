@@ -0,0 +1,38 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !linux
+
+package bpf
+
+import "golang.org/x/sys/unix"
+
+// CreateHashMap creates a new hash map and returns its file descriptor.
+func CreateHashMap(keySize, valueSize, maxEntries uint32) (int, error) {
+	return -1, unix.ENOSYS
+}
+
+// UpdateElement creates or updates an element in a map.
+func UpdateElement(fd int, key, value []byte) error {
+	return unix.ENOSYS
+}
+
+// LoadProgram loads a program into the kernel and returns its file descriptor.
+func LoadProgram(
+	progType ProgramType,
+	insns []Instruction,
+	license string,
+) (int, error) {
+	return -1, unix.ENOSYS
+}
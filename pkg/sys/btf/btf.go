@@ -0,0 +1,296 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package btf reads the BPF Type Format (BTF) type information that newer
+// kernels export in /sys/kernel/btf/vmlinux. It is used to find the offsets of
+// kernel structure members on the running kernel rather than relying on
+// offsets that were correct for the kernels that the sensor was built for.
+package btf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// VmlinuxPath is the location of the running kernel's BTF on Linux 5.4 and
+// later kernels built with CONFIG_DEBUG_INFO_BTF.
+const VmlinuxPath = "/sys/kernel/btf/vmlinux"
+
+const (
+	btfMagic = 0xeb9f
+
+	// The size of the fixed portion of struct btf_header
+	headerSize = 24
+
+	// The size of struct btf_type, which precedes any kind-specific data
+	typeSize = 12
+)
+
+// Type kinds from include/uapi/linux/btf.h
+const (
+	kindInt       = 1
+	kindPtr       = 2
+	kindArray     = 3
+	kindStruct    = 4
+	kindUnion     = 5
+	kindEnum      = 6
+	kindFwd       = 7
+	kindTypedef   = 8
+	kindVolatile  = 9
+	kindConst     = 10
+	kindRestrict  = 11
+	kindFunc      = 12
+	kindFuncProto = 13
+	kindVar       = 14
+	kindDatasec   = 15
+	kindFloat     = 16
+	kindDeclTag   = 17
+	kindTypeTag   = 18
+	kindEnum64    = 19
+)
+
+type member struct {
+	name      string
+	typeID    uint32
+	bitOffset uint32
+}
+
+type btfType struct {
+	name    string
+	kind    uint8
+	typeID  uint32 // referenced type for modifiers and typedefs
+	members []member
+}
+
+// Spec is the set of types described by a BTF blob.
+type Spec struct {
+	// Indexed by type ID. Type ID 0 is void.
+	types []btfType
+
+	// Struct and union type IDs by name
+	structs map[string][]uint32
+}
+
+// Load reads BTF from the specified file.
+func Load(filename string) (*Spec, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses raw BTF data, as found in /sys/kernel/btf/vmlinux or in the
+// .BTF section of an ELF object.
+func Parse(data []byte) (*Spec, error) {
+	if len(data) < headerSize {
+		return nil, errors.New("BTF data is too short")
+	}
+
+	var order binary.ByteOrder
+	switch {
+	case binary.LittleEndian.Uint16(data) == btfMagic:
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint16(data) == btfMagic:
+		order = binary.BigEndian
+	default:
+		return nil, errors.New("Invalid BTF magic")
+	}
+
+	hdrLen := order.Uint32(data[4:])
+	typeOff := order.Uint32(data[8:])
+	typeLen := order.Uint32(data[12:])
+	strOff := order.Uint32(data[16:])
+	strLen := order.Uint32(data[20:])
+
+	typeStart := uint64(hdrLen) + uint64(typeOff)
+	typeEnd := typeStart + uint64(typeLen)
+	strStart := uint64(hdrLen) + uint64(strOff)
+	strEnd := strStart + uint64(strLen)
+	if typeEnd > uint64(len(data)) || strEnd > uint64(len(data)) {
+		return nil, errors.New("BTF section exceeds data")
+	}
+
+	strs := data[strStart:strEnd]
+	s := &Spec{
+		types:   []btfType{btfType{}},
+		structs: make(map[string][]uint32),
+	}
+
+	buf := data[typeStart:typeEnd]
+	for len(buf) > 0 {
+		if len(buf) < typeSize {
+			return nil, errors.New("Truncated BTF type")
+		}
+		name, err := stringAt(strs, order.Uint32(buf))
+		if err != nil {
+			return nil, err
+		}
+		info := order.Uint32(buf[4:])
+		t := btfType{
+			name:   name,
+			kind:   uint8((info >> 24) & 0x1f),
+			typeID: order.Uint32(buf[8:]),
+		}
+		vlen := int(info & 0xffff)
+		kindFlag := info>>31 != 0
+		buf = buf[typeSize:]
+
+		var extra int
+		switch t.kind {
+		case kindInt, kindVar, kindDeclTag:
+			extra = 4
+		case kindArray:
+			extra = 12
+		case kindStruct, kindUnion, kindDatasec, kindEnum64:
+			extra = vlen * 12
+		case kindEnum, kindFuncProto:
+			extra = vlen * 8
+		case kindPtr, kindFwd, kindTypedef, kindVolatile, kindConst,
+			kindRestrict, kindFunc, kindFloat, kindTypeTag:
+		default:
+			return nil, fmt.Errorf("Unknown BTF kind %d", t.kind)
+		}
+		if len(buf) < extra {
+			return nil, errors.New("Truncated BTF type")
+		}
+
+		if t.kind == kindStruct || t.kind == kindUnion {
+			t.members = make([]member, vlen)
+			for i := range t.members {
+				m := buf[i*12:]
+				if t.members[i].name, err = stringAt(strs, order.Uint32(m)); err != nil {
+					return nil, err
+				}
+				t.members[i].typeID = order.Uint32(m[4:])
+				t.members[i].bitOffset = order.Uint32(m[8:])
+				if kindFlag {
+					// The high 8 bits are the bitfield size
+					t.members[i].bitOffset &= 0xffffff
+				}
+			}
+			if len(t.name) > 0 {
+				id := uint32(len(s.types))
+				s.structs[t.name] = append(s.structs[t.name], id)
+			}
+		}
+		buf = buf[extra:]
+
+		s.types = append(s.types, t)
+	}
+
+	return s, nil
+}
+
+func stringAt(strs []byte, offset uint32) (string, error) {
+	if uint64(offset) >= uint64(len(strs)) {
+		return "", fmt.Errorf("BTF string offset %d out of range", offset)
+	}
+	n := bytes.IndexByte(strs[offset:], 0)
+	if n < 0 {
+		return "", errors.New("Unterminated BTF string")
+	}
+	return string(strs[offset : offset+uint32(n)]), nil
+}
+
+// resolve skips typedefs and type modifiers.
+func (s *Spec) resolve(id uint32) (*btfType, error) {
+	for i := 0; i < len(s.types); i++ {
+		if int(id) >= len(s.types) {
+			return nil, fmt.Errorf("BTF type ID %d out of range", id)
+		}
+		t := &s.types[id]
+		switch t.kind {
+		case kindTypedef, kindVolatile, kindConst, kindRestrict,
+			kindTypeTag:
+			id = t.typeID
+		default:
+			return t, nil
+		}
+	}
+	return nil, errors.New("BTF type loop")
+}
+
+// findMember returns the offset in bits of the named member of a struct or
+// union. Members of anonymous structs and unions are searched too, since C
+// allows them to be named as if they were members of the enclosing type.
+func (s *Spec) findMember(t *btfType, name string) (*member, uint32, bool) {
+	for i := range t.members {
+		m := &t.members[i]
+		if m.name == name {
+			return m, m.bitOffset, true
+		}
+	}
+	for i := range t.members {
+		m := &t.members[i]
+		if len(m.name) > 0 {
+			continue
+		}
+		inner, err := s.resolve(m.typeID)
+		if err != nil || (inner.kind != kindStruct && inner.kind != kindUnion) {
+			continue
+		}
+		if found, offset, ok := s.findMember(inner, name); ok {
+			return found, m.bitOffset + offset, true
+		}
+	}
+	return nil, 0, false
+}
+
+// MemberOffset returns the offset in bytes of a member of the named struct or
+// union. Nested members may be named with a dotted path such as
+// "__sk_common.skc_family".
+func (s *Spec) MemberOffset(structName, memberPath string) (uint32, error) {
+	ids, ok := s.structs[structName]
+	if !ok {
+		return 0, fmt.Errorf("BTF: struct %s not found", structName)
+	}
+
+	var lastErr error
+	for _, id := range ids {
+		offset, err := s.memberOffset(&s.types[id], memberPath)
+		if err == nil {
+			return offset, nil
+		}
+		lastErr = err
+	}
+	return 0, fmt.Errorf("BTF: struct %s: %v", structName, lastErr)
+}
+
+func (s *Spec) memberOffset(t *btfType, memberPath string) (uint32, error) {
+	var bitOffset uint32
+	for _, name := range strings.Split(memberPath, ".") {
+		if t.kind != kindStruct && t.kind != kindUnion {
+			return 0, fmt.Errorf("%s is not in a struct or union", name)
+		}
+		m, offset, ok := s.findMember(t, name)
+		if !ok {
+			return 0, fmt.Errorf("member %s not found", name)
+		}
+		bitOffset += offset
+
+		var err error
+		if t, err = s.resolve(m.typeID); err != nil {
+			return 0, err
+		}
+	}
+	if bitOffset%8 != 0 {
+		return 0, fmt.Errorf("%s is a bitfield", memberPath)
+	}
+	return bitOffset / 8, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package btf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBuilder struct {
+	types   bytes.Buffer
+	strings bytes.Buffer
+}
+
+func (b *testBuilder) str(s string) uint32 {
+	if b.strings.Len() == 0 {
+		b.strings.WriteByte(0)
+	}
+	if len(s) == 0 {
+		return 0
+	}
+	offset := uint32(b.strings.Len())
+	b.strings.WriteString(s)
+	b.strings.WriteByte(0)
+	return offset
+}
+
+func (b *testBuilder) add(name string, kind, vlen uint32, kindFlag bool, sizeOrType uint32, extra ...uint32) {
+	info := kind<<24 | vlen
+	if kindFlag {
+		info |= 1 << 31
+	}
+	binary.Write(&b.types, binary.LittleEndian,
+		[]uint32{b.str(name), info, sizeOrType})
+	binary.Write(&b.types, binary.LittleEndian, extra)
+}
+
+func (b *testBuilder) bytes() []byte {
+	hdr := []uint32{
+		headerSize,
+		0, uint32(b.types.Len()),
+		uint32(b.types.Len()), uint32(b.strings.Len()),
+	}
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.LittleEndian, []uint16{btfMagic})
+	buf.Write([]byte{1, 0})
+	binary.Write(buf, binary.LittleEndian, hdr)
+	buf.Write(b.types.Bytes())
+	buf.Write(b.strings.Bytes())
+	return buf.Bytes()
+}
+
+func newTestSpec() []byte {
+	b := &testBuilder{}
+	b.str("")
+
+	// [1] int
+	b.add("int", kindInt, 0, false, 4, 32)
+	// [2] struct { int skc_dport:16; int skc_num:16; }
+	b.add("", kindStruct, 2, true, 4,
+		b.str("skc_dport"), 1, 16<<24|0,
+		b.str("skc_num"), 1, 16<<24|16)
+	// [3] struct sock_common
+	b.add("sock_common", kindStruct, 3, false, 24,
+		b.str("skc_daddr"), 1, 0,
+		0, 2, 96,
+		b.str("skc_family"), 1, 128)
+	// [4] const struct sock_common
+	b.add("", kindConst, 0, false, 3)
+	// [5] enum with one value, which must be skipped correctly
+	b.add("e", kindEnum, 1, false, 4, b.str("E"), 0)
+	// [6] struct sock
+	b.add("sock", kindStruct, 2, true, 32,
+		b.str("__sk_common"), 4, 0,
+		b.str("sk_bits"), 1, 3<<24|195)
+	// [7] a later struct with a name that was already used
+	b.add("sock_common", kindStruct, 1, false, 8,
+		b.str("other"), 1, 32)
+
+	return b.bytes()
+}
+
+func TestMemberOffset(t *testing.T) {
+	s, err := Parse(newTestSpec())
+	require.NoError(t, err)
+
+	offset, err := s.MemberOffset("sock_common", "skc_family")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(16), offset)
+
+	// Members of anonymous structs are found in the outer struct
+	offset, err = s.MemberOffset("sock_common", "skc_num")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(14), offset)
+
+	// Paths are followed through modifiers
+	offset, err = s.MemberOffset("sock", "__sk_common.skc_dport")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(12), offset)
+
+	// Types with the same name are all searched
+	offset, err = s.MemberOffset("sock_common", "other")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), offset)
+
+	_, err = s.MemberOffset("sock", "sk_bits")
+	assert.Error(t, err)
+	_, err = s.MemberOffset("sock", "missing")
+	assert.Error(t, err)
+	_, err = s.MemberOffset("sock", "__sk_common.skc_family.x")
+	assert.Error(t, err)
+	_, err = s.MemberOffset("missing", "x")
+	assert.Error(t, err)
+}
+
+func TestParseErrors(t *testing.T) {
+	data := newTestSpec()
+
+	_, err := Parse(data[:10])
+	assert.Error(t, err)
+
+	bad := append([]byte{}, data...)
+	bad[0] = 0
+	_, err = Parse(bad)
+	assert.Error(t, err)
+
+	// Truncating the string section makes the header inconsistent
+	_, err = Parse(data[:len(data)-1])
+	assert.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	f, err := ioutil.TempFile("", "capsule8_")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.Write(newTestSpec())
+	require.NoError(t, err)
+	f.Close()
+
+	s, err := Load(f.Name())
+	require.NoError(t, err)
+	_, err = s.MemberOffset("sock_common", "skc_daddr")
+	assert.NoError(t, err)
+
+	_, err = Load(f.Name() + ".missing")
+	assert.Error(t, err)
+}
@@ -173,6 +173,18 @@ const (
 	PERF_SAMPLE_MAX
 )
 
+// Context markers in PERF_SAMPLE_CALLCHAIN callchains. Each marker precedes
+// the instruction pointers captured in that context.
+const (
+	PERF_CONTEXT_HV           uint64 = 0xffffffffffffffe0 // (u64)-32
+	PERF_CONTEXT_KERNEL       uint64 = 0xffffffffffffff80 // (u64)-128
+	PERF_CONTEXT_USER         uint64 = 0xfffffffffffffe00 // (u64)-512
+	PERF_CONTEXT_GUEST        uint64 = 0xfffffffffffff800 // (u64)-2048
+	PERF_CONTEXT_GUEST_KERNEL uint64 = 0xfffffffffffff780 // (u64)-2176
+	PERF_CONTEXT_GUEST_USER   uint64 = 0xfffffffffffff600 // (u64)-2560
+	PERF_CONTEXT_MAX          uint64 = 0xfffffffffffff001 // (u64)-4095
+)
+
 // Bitmasks for bitfield in EventAttr
 const (
 	eaDisabled = 1 << iota
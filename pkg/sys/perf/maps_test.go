@@ -182,6 +182,10 @@ func (s *dummyPerfGroupLeaderEventSourceLeader) SetFilter(f string) error {
 	return nil
 }
 
+func (s *dummyPerfGroupLeaderEventSourceLeader) SetBPFProgram(fd int) error {
+	return nil
+}
+
 func (s *dummyPerfGroupLeaderEventSourceLeader) SourceID() uint64 {
 	return s.id
 }
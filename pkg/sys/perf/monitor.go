@@ -50,6 +50,7 @@ type eventMonitorOptions struct {
 	ringBufferNumPages    int
 	cgroups               []string
 	pids                  []int
+	bpfFilterPrograms     map[EventType]int
 }
 
 // EventMonitorOption is used to implement optional arguments for
@@ -161,6 +162,21 @@ func WithPids(pids []int) EventMonitorOption {
 	}
 }
 
+// WithBPFFilterProgram is used to attach a loaded BPF program to every event
+// of the specified type registered with the EventMonitor. Only tracepoints,
+// kprobes, and uprobes are supported, and the kernel requires the program type
+// to match the event type. Samples are only recorded for these events when the
+// program returns non-zero. The EventMonitor does not take ownership of the
+// program's file descriptor.
+func WithBPFFilterProgram(eventType EventType, fd int) EventMonitorOption {
+	return func(o *eventMonitorOptions) {
+		if o.bpfFilterPrograms == nil {
+			o.bpfFilterPrograms = make(map[EventType]int)
+		}
+		o.bpfFilterPrograms[eventType] = fd
+	}
+}
+
 type registerEventOptions struct {
 	disabled  bool
 	eventAttr *EventAttr
@@ -168,6 +184,9 @@ type registerEventOptions struct {
 	groupID   int32
 	decoderFn TraceEventDecoderFn
 	name      string
+	cgroups   []string
+	numPages  int
+	callchain bool
 }
 
 // RegisterEventOption is used to implement optional arguments for event
@@ -233,6 +252,34 @@ func WithTracingEventName(name string) RegisterEventOption {
 	}
 }
 
+// WithEventCgroups is used to restrict a new event group to the specified
+// perf_event cgroups instead of the sources that the EventMonitor monitors.
+// Cgroup paths are relative to the perf_event cgroup mountpoint. It applies
+// only to RegisterEventGroup and RegisterCounterEventGroup.
+func WithEventCgroups(cgroups []string) RegisterEventOption {
+	return func(o *registerEventOptions) {
+		o.cgroups = append(o.cgroups, cgroups...)
+	}
+}
+
+// WithEventRingBufferNumPages is used to set the size of the ring buffers used
+// by a new event group instead of the EventMonitor's default. It applies only
+// to RegisterEventGroup and RegisterCounterEventGroup.
+func WithEventRingBufferNumPages(numPages int) RegisterEventOption {
+	return func(o *registerEventOptions) {
+		o.numPages = numPages
+	}
+}
+
+// WithEventCallchain is used to have the kernel record a callchain with each
+// sample for the event. The instruction pointers are decoded into the IPs
+// field of SampleRecord.
+func WithEventCallchain() RegisterEventOption {
+	return func(o *registerEventOptions) {
+		o.callchain = true
+	}
+}
+
 // EventType represents the type of an event (tracepoint, external, etc.)
 type EventType int
 
@@ -440,6 +487,9 @@ type registeredEvent struct {
 	group     *eventMonitorGroup
 	leader    bool
 	formatID  uint16
+
+	// What a kprobe or uprobe is attached to, e.g. "kprobe:do_sys_open"
+	probe string
 }
 
 const (
@@ -451,6 +501,9 @@ const (
 type perfGroupLeader struct {
 	source EventSourceLeader
 
+	// The CPU that the leader's ring buffer services. Immutable.
+	cpu int
+
 	// Mutable only by the monitor goroutine while running. No
 	// synchronization is required.
 	pendingSamples []EventMonitorSample
@@ -572,6 +625,12 @@ type EventMonitor struct {
 	// required.
 	hasPendingSamples bool
 
+	// One reader per CPU, created by Run. The monitor goroutine hands
+	// each reader its group leaders and collects the samples read. See
+	// ringBufferReader for how access to them is ordered.
+	readers   []*ringBufferReader
+	readersWG sync.WaitGroup
+
 	// Mutable by the thread on which Stop is called.
 	stopRequested atomic.Value // bool
 
@@ -610,13 +669,15 @@ type EventMonitor struct {
 	hasExternalSamples     atomic.Value // bool
 
 	// Immutable, used only when adding new tracepoints/probes
-	defaultAttr EventAttr
-	tracingDir  string
-	procFS      proc.FileSystem
+	defaultAttr       EventAttr
+	tracingDir        string
+	procFS            proc.FileSystem
+	bpfFilterPrograms map[EventType]int
 
 	// Immutable, used only when adding new groups
 	ringBufferNumPages int
 	perfEventOpenFlags uintptr
+	perfEventDir       string
 	cgroups            []int
 	pids               []int
 
@@ -813,6 +874,9 @@ func (monitor *EventMonitor) newRegisteredPerfEvent(
 	attr.Type = perfTypeFromEventType(eventType)
 	attr.Config = config
 	attr.Disabled = opts.disabled
+	if opts.callchain {
+		attr.SampleType |= PERF_SAMPLE_CALLCHAIN
+	}
 
 	switch eventType {
 	case EventTypeTracepoint, EventTypeKprobe, EventTypeUprobe:
@@ -829,6 +893,18 @@ func (monitor *EventMonitor) newRegisteredPerfEvent(
 		return 0, err
 	}
 
+	if fd, ok := monitor.bpfFilterPrograms[eventType]; ok {
+		for _, source := range newsources {
+			err = source.SetBPFProgram(fd)
+			if err != nil {
+				for _, source = range newsources {
+					source.Close()
+				}
+				return 0, err
+			}
+		}
+	}
+
 	eventid := monitor.newRegisteredEvent(name, newsources, fields,
 		eventType, decoder, attr, group, false, formatID)
 	return eventid, nil
@@ -902,6 +978,8 @@ type CounterEventGroupMember struct {
 }
 
 // RegisterCounterEventGroup registers a performance counter event group.
+// WithEventCgroups may be used to count events only for tasks in specific
+// cgroups.
 func (monitor *EventMonitor) RegisterCounterEventGroup(
 	name string,
 	counters []CounterEventGroupMember,
@@ -953,7 +1031,14 @@ func (monitor *EventMonitor) RegisterCounterEventGroup(
 	leaderAttr.Type = perfTypeFromEventType(counters[0].EventType)
 	leaderAttr.Config = counters[0].Config
 	leaderAttr.Pinned = true
-	group, err := monitor.newEventGroup(leaderAttr)
+	var group *eventMonitorGroup
+	var err error
+	if len(opts.cgroups) > 0 {
+		group, err = monitor.newCgroupEventGroup(leaderAttr, opts.cgroups,
+			opts.numPages)
+	} else {
+		group, err = monitor.newEventGroup(leaderAttr, opts.numPages)
+	}
 	if err != nil {
 		return 0, 0, err
 	}
@@ -1045,6 +1130,13 @@ func (monitor *EventMonitor) RegisterKprobe(
 		monitor.removeKprobe(name)
 		return 0, err
 	}
+	if event, ok := monitor.events.lookup(eventid); ok {
+		if onReturn {
+			event.probe = "kretprobe:" + address
+		} else {
+			event.probe = "kprobe:" + address
+		}
+	}
 
 	return eventid, nil
 }
@@ -1096,6 +1188,13 @@ func (monitor *EventMonitor) RegisterUprobe(
 		monitor.removeUprobe(name)
 		return 0, err
 	}
+	if event, ok := monitor.events.lookup(eventid); ok {
+		if onReturn {
+			event.probe = fmt.Sprintf("uretprobe:%s:%s", bin, address)
+		} else {
+			event.probe = fmt.Sprintf("uprobe:%s:%s", bin, address)
+		}
+	}
 
 	return eventid, nil
 }
@@ -1229,6 +1328,32 @@ func (monitor *EventMonitor) RegisteredEventType(
 	return EventTypeInvalid, false
 }
 
+// RegisteredProbes returns the names of the registered tracepoints and what
+// the registered kprobes and uprobes are attached to, sorted and without
+// duplicates.
+func (monitor *EventMonitor) RegisteredProbes() []string {
+	monitor.lock.Lock()
+	defer monitor.lock.Unlock()
+
+	seen := make(map[string]bool)
+	var probes []string
+	for _, event := range monitor.events.getMap() {
+		var probe string
+		switch event.eventType {
+		case EventTypeTracepoint:
+			probe = event.name
+		case EventTypeKprobe, EventTypeUprobe:
+			probe = event.probe
+		}
+		if len(probe) > 0 && !seen[probe] {
+			seen[probe] = true
+			probes = append(probes, probe)
+		}
+	}
+	sort.Strings(probes)
+	return probes
+}
+
 // RegisteredEventFields returns the fields that are defined for the specified
 // event identifier.
 func (monitor *EventMonitor) RegisteredEventFields(
@@ -1420,7 +1545,10 @@ type EventMonitorSample struct {
 	DecodedData TraceEventSampleData
 
 	// DecodedSample is the value returned from calling the registered
-	// decoder for RawSample and DecodedData together.
+	// decoder for RawSample and DecodedData together. It is nil if
+	// RawSample.Record is a *LostRecord, which reports the number of
+	// samples for EventID that the kernel dropped because the ring buffer
+	// was full.
 	DecodedSample interface{}
 
 	// Err will be non-nil if any occurred during processing of RawSample.
@@ -1653,11 +1781,17 @@ func (monitor *EventMonitor) dispatchSamples(samples [][]EventMonitorSample) {
 			continue
 		}
 
-		if esm.Err == nil {
-			event.decoder.decodeSample(&esm, monitor)
-		}
-		if esm.Err != nil || esm.DecodedSample != nil {
+		if _, ok = esm.RawSample.Record.(*LostRecord); ok {
+			// Lost records are not decoded. They are dispatched
+			// as-is so that they can be accounted for.
 			batch = append(batch, esm)
+		} else {
+			if esm.Err == nil {
+				event.decoder.decodeSample(&esm, monitor)
+			}
+			if esm.Err != nil || esm.DecodedSample != nil {
+				batch = append(batch, esm)
+			}
 		}
 		if esm.RawSample.Time > monitor.lastSampleTimeDispatched {
 			monitor.lastSampleTimeDispatched = esm.RawSample.Time
@@ -1738,60 +1872,161 @@ func (monitor *EventMonitor) enqueueSamples(samples [][]EventMonitorSample) {
 	monitor.lock.Unlock()
 }
 
-func (monitor *EventMonitor) readEventSources() {
-	// Clear the monitor's hasPendingSamples flag immediately. All samples
-	// pending at this time will be included for processing. New pending
-	// samples may be added and so this flag will be updated later.
-	monitor.hasPendingSamples = false
+// ringBufferReader drains the ring buffers of the group leaders for one CPU.
+// The first reader runs on the monitor goroutine; each of the others runs in
+// its own goroutine so that the ring buffers for all CPUs are drained in
+// parallel. A reader's fields are only written by the monitor goroutine
+// before the reader is woken, and its results are only read by the monitor
+// goroutine after the reader has signalled readersWG, so no lock is needed.
+type ringBufferReader struct {
+	wake    chan struct{}
+	attrMap map[uint64]EventAttr
+	leaders []*perfGroupLeader
+	results []ringBufferReadResult
+	closed  []uint64
+}
 
-	var lastTimestamp uint64
-	ids := make(map[uint64]struct{})
-	groupLeaders := monitor.groupLeaders.getMap()
-	samples := make([][]EventMonitorSample, 0, len(groupLeaders))
-	for _, pgl := range groupLeaders {
-		var groupSamples, newPendingSamples []EventMonitorSample
+type ringBufferReadResult struct {
+	pgl     *perfGroupLeader
+	samples []EventMonitorSample
+}
 
-		// If the pgl's state is not active, skip it. Either we need to
-		// to clean it up later or it has already been cleaned up.
-		// Either way, we're not interested in its ringbuffer (and in
-		// the latter case, we'd segfault)
+func (r *ringBufferReader) run(wg *sync.WaitGroup) {
+	for range r.wake {
+		r.read()
+		wg.Done()
+	}
+}
+
+func (r *ringBufferReader) read() {
+	for _, pgl := range r.leaders {
+		// If the pgl's state is not active, skip it. Either we need
+		// to clean it up or it has already been cleaned up. Either
+		// way, we're not interested in its ringbuffer (and in the
+		// latter case, we'd segfault)
 		switch atomic.LoadInt32(&pgl.state) {
 		case perfGroupLeaderStateActive:
 			break
 		case perfGroupLeaderStateClosing:
-			ids[pgl.source.SourceID()] = struct{}{}
+			r.closed = append(r.closed, pgl.source.SourceID())
 			pgl.cleanup()
 			continue
 		default:
 			continue
 		}
 
-		attrMap := monitor.eventAttrMap.getMap()
-		pgl.source.Read(attrMap, func(sample Sample, err error) {
+		var groupSamples []EventMonitorSample
+		pgl.source.Read(r.attrMap, func(sample Sample, err error) {
 			ems := EventMonitorSample{
 				Err:       err,
 				RawSample: sample,
 			}
 			groupSamples = append(groupSamples, ems)
 		})
+		r.results = append(r.results, ringBufferReadResult{
+			pgl:     pgl,
+			samples: groupSamples,
+		})
+	}
+}
+
+func (monitor *EventMonitor) startReaders() {
+	ncpu := 1
+	if monitor.procFS != nil {
+		if n := monitor.procFS.NumCPU(); n > 1 {
+			ncpu = n
+		}
+	}
+	monitor.readers = make([]*ringBufferReader, ncpu)
+	for i := range monitor.readers {
+		r := &ringBufferReader{}
+		if i > 0 {
+			r.wake = make(chan struct{}, 1)
+			go r.run(&monitor.readersWG)
+		}
+		monitor.readers[i] = r
+	}
+}
+
+func (monitor *EventMonitor) stopReaders() {
+	for _, r := range monitor.readers {
+		if r.wake != nil {
+			close(r.wake)
+		}
+	}
+	monitor.readers = nil
+}
+
+func (monitor *EventMonitor) readEventSources() {
+	// Clear the monitor's hasPendingSamples flag immediately. All samples
+	// pending at this time will be included for processing. New pending
+	// samples may be added and so this flag will be updated later.
+	monitor.hasPendingSamples = false
+
+	readers := monitor.readers
+	attrMap := monitor.eventAttrMap.getMap()
+	for _, r := range readers {
+		r.attrMap = attrMap
+		r.leaders = r.leaders[:0]
+	}
+	groupLeaders := monitor.groupLeaders.getMap()
+	for _, pgl := range groupLeaders {
+		r := readers[pgl.cpu%len(readers)]
+		r.leaders = append(r.leaders, pgl)
+	}
+	for _, r := range readers[1:] {
+		if len(r.leaders) > 0 {
+			monitor.readersWG.Add(1)
+			r.wake <- struct{}{}
+		}
+	}
+	readers[0].read()
+	monitor.readersWG.Wait()
 
-		if len(groupSamples) == 0 {
-			if len(pgl.pendingSamples) > 0 {
-				samples = append(samples, pgl.pendingSamples)
+	// Samples from each ringbuffer are in timestamp order, but a ringbuffer
+	// may yet receive samples older than the newest ones read from the
+	// others. Hold back any samples newer than the oldest last sample read
+	// from any ringbuffer until the next read.
+	var lastTimestamp uint64
+	for _, r := range readers {
+		for _, result := range r.results {
+			if l := len(result.samples); l > 0 {
+				t := result.samples[l-1].RawSample.Time
+				if lastTimestamp == 0 || t < lastTimestamp {
+					lastTimestamp = t
+				}
 			}
-			pgl.pendingSamples = nil
-			continue
 		}
+	}
 
-		if lastTimestamp == 0 {
-			lastTimestamp = groupSamples[len(groupSamples)-1].RawSample.Time
-		} else {
+	var ids map[uint64]struct{}
+	samples := make([][]EventMonitorSample, 0, len(groupLeaders))
+	for _, r := range readers {
+		for _, id := range r.closed {
+			if ids == nil {
+				ids = make(map[uint64]struct{})
+			}
+			ids[id] = struct{}{}
+		}
+		r.closed = r.closed[:0]
+
+		for i, result := range r.results {
+			r.results[i] = ringBufferReadResult{}
+			pgl, groupSamples := result.pgl, result.samples
+			if len(groupSamples) == 0 {
+				if len(pgl.pendingSamples) > 0 {
+					samples = append(samples, pgl.pendingSamples)
+				}
+				pgl.pendingSamples = nil
+				continue
+			}
+
+			var newPendingSamples []EventMonitorSample
 			l := len(groupSamples)
-			for i := l - 1; i >= 0; i-- {
-				if groupSamples[i].RawSample.Time <= lastTimestamp {
+			for ; l > 0; l-- {
+				if groupSamples[l-1].RawSample.Time <= lastTimestamp {
 					break
 				}
-				l--
 			}
 			if l != len(groupSamples) {
 				monitor.hasPendingSamples = true
@@ -1802,11 +2037,12 @@ func (monitor *EventMonitor) readEventSources() {
 					continue
 				}
 			}
-		}
 
-		groupSamples = append(pgl.pendingSamples, groupSamples...)
-		pgl.pendingSamples = newPendingSamples
-		samples = append(samples, groupSamples)
+			groupSamples = append(pgl.pendingSamples, groupSamples...)
+			pgl.pendingSamples = newPendingSamples
+			samples = append(samples, groupSamples)
+		}
+		r.results = r.results[:0]
 	}
 
 	if len(ids) > 0 {
@@ -1852,6 +2088,8 @@ func (monitor *EventMonitor) Run(fn SampleDispatchFn) error {
 	monitor.wg.Add(1)
 	go monitor.dispatchSampleLoop()
 
+	monitor.startReaders()
+	defer monitor.stopReaders()
 	defer monitor.stopWithSignal()
 
 	for {
@@ -1944,8 +2182,12 @@ func (monitor *EventMonitor) initializeGroupLeaders(
 	pid int,
 	flags uintptr,
 	attr EventAttr,
+	numPages int,
 ) ([]*perfGroupLeader, error) {
 	var err error
+	if numPages <= 0 {
+		numPages = monitor.ringBufferNumPages
+	}
 	ncpu := monitor.procFS.NumCPU()
 	pgls := make([]*perfGroupLeader, ncpu)
 	for cpu := 0; cpu < ncpu; cpu++ {
@@ -1953,13 +2195,14 @@ func (monitor *EventMonitor) initializeGroupLeaders(
 
 		source, err =
 			monitor.eventSourceController.NewEventSourceLeader(
-				attr, pid, cpu, flags)
+				attr, pid, cpu, flags, numPages)
 		if err != nil {
 			break
 		}
 
 		pgls[cpu] = &perfGroupLeader{
 			source: source,
+			cpu:    cpu,
 			state:  perfGroupLeaderStateActive,
 		}
 	}
@@ -1979,6 +2222,7 @@ func (monitor *EventMonitor) initializeGroupLeaders(
 
 func (monitor *EventMonitor) newEventGroup(
 	attr EventAttr,
+	numPages int,
 ) (*eventMonitorGroup, error) {
 	ncpu := monitor.procFS.NumCPU()
 	nleaders := (len(monitor.cgroups) + len(monitor.pids)) * ncpu
@@ -1988,7 +2232,7 @@ func (monitor *EventMonitor) newEventGroup(
 		flags := monitor.perfEventOpenFlags | PERF_FLAG_PID_CGROUP
 		for _, fd := range monitor.cgroups {
 			pgls, err := monitor.initializeGroupLeaders(fd, flags,
-				attr)
+				attr, numPages)
 			if err != nil {
 				for _, pgl := range leaders {
 					pgl.cleanup()
@@ -2003,7 +2247,7 @@ func (monitor *EventMonitor) newEventGroup(
 		flags := monitor.perfEventOpenFlags
 		for _, pid := range monitor.pids {
 			pgls, err := monitor.initializeGroupLeaders(pid, flags,
-				attr)
+				attr, numPages)
 			if err != nil {
 				for _, pgl := range leaders {
 					pgl.cleanup()
@@ -2021,6 +2265,56 @@ func (monitor *EventMonitor) newEventGroup(
 	}, nil
 }
 
+func (monitor *EventMonitor) newCgroupEventGroup(
+	attr EventAttr,
+	cgroups []string,
+	numPages int,
+) (*eventMonitorGroup, error) {
+	perfEventDir := monitor.perfEventDir
+	if len(perfEventDir) == 0 {
+		perfEventDir = monitor.procFS.PerfEventDir()
+		if len(perfEventDir) == 0 {
+			return nil, errors.New("Can't monitor specific cgroups without perf_event cgroupfs")
+		}
+	}
+
+	ncpu := monitor.procFS.NumCPU()
+	leaders := make([]*perfGroupLeader, 0, len(cgroups)*ncpu)
+	seen := make(map[string]bool, len(cgroups))
+	flags := monitor.perfEventOpenFlags | PERF_FLAG_PID_CGROUP
+	for _, cgroup := range cgroups {
+		if seen[cgroup] {
+			glog.V(1).Infof("Ignoring duplicate cgroup %s", cgroup)
+			continue
+		}
+		seen[cgroup] = true
+
+		// The kernel takes its own reference to the cgroup when the
+		// event is opened, so the descriptor is only needed here.
+		path := filepath.Join(perfEventDir, cgroup)
+		fd, err := unix.Open(path, unix.O_RDONLY, 0)
+		if err == nil {
+			var pgls []*perfGroupLeader
+			pgls, err = monitor.initializeGroupLeaders(fd, flags,
+				attr, numPages)
+			unix.Close(fd)
+			leaders = append(leaders, pgls...)
+		}
+		if err != nil {
+			for _, pgl := range leaders {
+				pgl.cleanup()
+			}
+			return nil, err
+		}
+	}
+
+	return &eventMonitorGroup{
+		leaders: leaders,
+		events:  make(map[uint64]*registeredEvent),
+		monitor: monitor,
+	}, nil
+}
+
 func (monitor *EventMonitor) registerNewEventGroup(group *eventMonitorGroup) {
 	// This should be called with monitor.lock LOCKED!
 
@@ -2036,9 +2330,24 @@ func (monitor *EventMonitor) registerNewEventGroup(group *eventMonitorGroup) {
 }
 
 // RegisterEventGroup creates a new event group that can be used for grouping
-// events.
-func (monitor *EventMonitor) RegisterEventGroup(name string) (int32, error) {
-	group, err := monitor.newEventGroup(groupEventAttr)
+// events. WithEventRingBufferNumPages may be used to size the group's ring
+// buffers, and WithEventCgroups may be used to have the kernel only generate
+// events in the group for tasks in specific cgroups.
+func (monitor *EventMonitor) RegisterEventGroup(
+	name string,
+	options ...RegisterEventOption,
+) (int32, error) {
+	opts := newRegisterEventOptions()
+	opts.processOptions(options...)
+
+	var group *eventMonitorGroup
+	var err error
+	if len(opts.cgroups) > 0 {
+		group, err = monitor.newCgroupEventGroup(groupEventAttr,
+			opts.cgroups, opts.numPages)
+	} else {
+		group, err = monitor.newEventGroup(groupEventAttr, opts.numPages)
+	}
 	if err != nil {
 		return -1, err
 	}
@@ -2243,6 +2552,8 @@ func NewEventMonitor(options ...EventMonitorOption) (monitor *EventMonitor, err
 		procFS:                opts.procfs,
 		ringBufferNumPages:    opts.ringBufferNumPages,
 		perfEventOpenFlags:    opts.flags,
+		perfEventDir:          opts.perfEventDir,
+		bpfFilterPrograms:     opts.bpfFilterPrograms,
 	}
 	monitor.cond = sync.Cond{L: &monitor.lock}
 	monitor.isRunning.Store(false)
@@ -48,6 +48,10 @@ func (fs *testProcFileSystem) KernelTextSymbolNames() (map[string]string, error)
 	return nil, unix.ENOSYS
 }
 
+func (fs *testProcFileSystem) KernelTextSymbols() ([]proc.KernelSymbol, error) {
+	return nil, unix.ENOSYS
+}
+
 func (fs *testProcFileSystem) ProcessContainerID(pid int) (string, error) {
 	return "", unix.ESRCH
 }
@@ -56,6 +60,10 @@ func (fs *testProcFileSystem) ProcessCommandLine(pid int) ([]string, error) {
 	return nil, unix.ESRCH
 }
 
+func (fs *testProcFileSystem) ProcessEnvironment(pid int) ([]string, error) {
+	return nil, unix.ESRCH
+}
+
 func (fs *testProcFileSystem) TaskControlGroups(tigd, pid int) ([]proc.ControlGroup, error) {
 	return nil, unix.ESRCH
 }
@@ -64,6 +72,22 @@ func (fs *testProcFileSystem) TaskCWD(tgid, pid int) (string, error) {
 	return "", unix.ESRCH
 }
 
+func (fs *testProcFileSystem) TaskExecutable(tgid, pid int) (string, error) {
+	return "", unix.ESRCH
+}
+
+func (fs *testProcFileSystem) OpenTaskExecutable(tgid, pid int) (*os.File, error) {
+	return nil, unix.ESRCH
+}
+
+func (fs *testProcFileSystem) ReadProcessRootFile(pid int, path string) ([]byte, error) {
+	return nil, unix.ESRCH
+}
+
+func (fs *testProcFileSystem) TaskNamespaces(tgid, pid int) (proc.Namespaces, error) {
+	return proc.Namespaces{}, unix.ESRCH
+}
+
 func (fs *testProcFileSystem) TaskStartTime(tgid, pid int) (int64, error) {
 	return 0, unix.ESRCH
 }
@@ -90,6 +114,10 @@ func TestEventMonitorOptions(t *testing.T) {
 	expOptions.ringBufferNumPages = 88
 	expOptions.cgroups = []string{"docker", "kubernetes", "capsule8"}
 	expOptions.pids = []int{123, 456, 789}
+	expOptions.bpfFilterPrograms = map[EventType]int{
+		EventTypeKprobe:     8,
+		EventTypeTracepoint: 9,
+	}
 
 	var err error
 	expOptions.procfs, err = procfs.NewFileSystem("../proc/procfs/testdata/proc")
@@ -107,6 +135,8 @@ func TestEventMonitorOptions(t *testing.T) {
 		WithCgroup("extra"),
 		WithPids(expOptions.pids),
 		WithPid(-1),
+		WithBPFFilterProgram(EventTypeKprobe, 8),
+		WithBPFFilterProgram(EventTypeTracepoint, 9),
 	}
 	expOptions.cgroups = append(expOptions.cgroups, "extra")
 	expOptions.pids = append(expOptions.pids, -1)
@@ -123,6 +153,8 @@ func TestRegisterEventOptions(t *testing.T) {
 	expOptions.filter = "*** filter string ***"
 	expOptions.groupID = 88888
 	expOptions.name = "nnAAmmEE"
+	expOptions.numPages = 64
+	expOptions.callchain = true
 
 	options := []RegisterEventOption{
 		WithEventDisabled(),
@@ -130,6 +162,8 @@ func TestRegisterEventOptions(t *testing.T) {
 		WithFilter(expOptions.filter),
 		WithEventGroup(expOptions.groupID),
 		WithTracingEventName(expOptions.name),
+		WithEventRingBufferNumPages(expOptions.numPages),
+		WithEventCallchain(),
 	}
 
 	gotOptions := registerEventOptions{}
@@ -565,6 +599,47 @@ func TestEventGroupRegistration(t *testing.T) {
 	}
 }
 
+func TestEventGroupRingBufferNumPages(t *testing.T) {
+	monitor, err := NewEventMonitor(
+		WithEventSourceController(NewStubEventSourceController()),
+		WithProcFileSystem(newTestProcFileSystem()),
+		WithTracingDir("testdata"),
+		WithRingBufferNumPages(16),
+		WithPid(234))
+	ok(t, err)
+	defer monitor.Close()
+
+	checkNumPages := func(groupID int32, numPages int) {
+		group := monitor.groups[groupID]
+		equals(t, 2, len(group.leaders))
+		for _, pgl := range group.leaders {
+			leader := pgl.source.(*StubEventSourceLeader)
+			equals(t, numPages, leader.RingBufferNumPages)
+		}
+	}
+
+	// The monitor's default applies unless the group overrides it
+	id, err := monitor.RegisterEventGroup("")
+	ok(t, err)
+	checkNumPages(id, 16)
+
+	id, err = monitor.RegisterEventGroup("",
+		WithEventRingBufferNumPages(128))
+	ok(t, err)
+	checkNumPages(id, 128)
+
+	counters := []CounterEventGroupMember{
+		CounterEventGroupMember{
+			EventType: EventTypeHardware,
+			Config:    PERF_COUNT_HW_INSTRUCTIONS,
+		},
+	}
+	id, _, err = monitor.RegisterCounterEventGroup("counters", counters,
+		nil, WithEventRingBufferNumPages(32))
+	ok(t, err)
+	checkNumPages(id, 32)
+}
+
 func TestEventManipulation(t *testing.T) {
 	tracingDir, err := createTempTracingDir()
 	if tracingDir != "" {
@@ -745,6 +820,26 @@ print fmt: "pid=%d comm=%s clone_flags=%lx oom_score_adj=%hd", REC->pid, REC->co
 	equals(t, true, found)
 	equals(t, eventid, e.id)
 	equals(t, EventTypeTracepoint, e.eventType)
+	equals(t, []string{"task/task_newtask"}, monitor.RegisteredProbes())
+	for _, source := range e.sources {
+		attr := monitor.eventAttrMap.getMap()[source.SourceID()]
+		equals(t, uint64(0), attr.SampleType&PERF_SAMPLE_CALLCHAIN)
+	}
+
+	err = monitor.UnregisterEvent(eventid)
+	ok(t, err)
+	equals(t, 0, len(monitor.events.getMap()))
+
+	eventid, err = monitor.RegisterTracepoint("task/task_newtask", nil,
+		WithEventCallchain())
+	ok(t, err)
+	e, found = monitor.events.lookup(eventid)
+	equals(t, true, found)
+	for _, source := range e.sources {
+		attr := monitor.eventAttrMap.getMap()[source.SourceID()]
+		equals(t, PERF_SAMPLE_CALLCHAIN,
+			attr.SampleType&PERF_SAMPLE_CALLCHAIN)
+	}
 
 	err = monitor.UnregisterEvent(eventid)
 	ok(t, err)
@@ -757,6 +852,7 @@ print fmt: "pid=%d comm=%s clone_flags=%lx oom_score_adj=%hd", REC->pid, REC->co
 	equals(t, true, found)
 	equals(t, eventid, e.id)
 	equals(t, EventTypeKprobe, e.eventType)
+	equals(t, []string{"kprobe:address"}, monitor.RegisteredProbes())
 
 	err = monitor.UnregisterEvent(eventid)
 	ok(t, err)
@@ -803,6 +899,77 @@ print fmt: "pid=%d comm=%s clone_flags=%lx oom_score_adj=%hd", REC->pid, REC->co
 	equals(t, 0, len(monitor.events.getMap()))
 }
 
+func TestBPFFilterPrograms(t *testing.T) {
+	tracingDir, err := createTempTracingDir()
+	if tracingDir != "" {
+		defer os.RemoveAll(tracingDir)
+	}
+	ok(t, err)
+
+	formatContent := `name: task_newtask
+ID: 109
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:pid_t pid;	offset:8;	size:4;	signed:1;
+
+print fmt: "pid=%d", REC->pid`
+	probeName := fmt.Sprintf("capsule8/sensor_%d_1", unix.Getpid())
+	for _, name := range []string{"task/task_newtask", probeName} {
+		eventDir := filepath.Join(tracingDir, "events", name)
+		err = os.MkdirAll(eventDir, 0777)
+		ok(t, err)
+
+		formatFile := filepath.Join(eventDir, "format")
+		err = ioutil.WriteFile(formatFile, []byte(formatContent), 0666)
+		ok(t, err)
+	}
+
+	monitor, err := NewEventMonitor(
+		WithEventSourceController(NewStubEventSourceController()),
+		WithProcFileSystem(newTestProcFileSystem()),
+		WithTracingDir(tracingDir),
+		WithBPFFilterProgram(EventTypeTracepoint, 28),
+		WithBPFFilterProgram(EventTypeKprobe, 29))
+	ok(t, err)
+	defer monitor.Close()
+
+	sourcePrograms := func(eventid uint64) []int {
+		e, found := monitor.events.lookup(eventid)
+		equals(t, true, found)
+		var fds []int
+		for _, source := range e.sources {
+			switch s := source.(type) {
+			case *StubEventSource:
+				fds = append(fds, s.BPFProgram)
+			case *StubEventSourceLeader:
+				fds = append(fds, s.BPFProgram)
+			}
+		}
+		return fds
+	}
+
+	// NumCPU is 2, so there are two sources for each event
+	eventid, err := monitor.RegisterTracepoint("task/task_newtask", nil)
+	ok(t, err)
+	equals(t, []int{28, 28}, sourcePrograms(eventid))
+
+	eventid, err = monitor.RegisterKprobe("address", false, "output", nil)
+	ok(t, err)
+	equals(t, []int{29, 29}, sourcePrograms(eventid))
+
+	// Counters never have BPF programs attached
+	counters := []CounterEventGroupMember{
+		CounterEventGroupMember{EventType: EventTypeSoftware},
+	}
+	_, eventid, err = monitor.RegisterCounterEventGroup("name", counters, nil)
+	ok(t, err)
+	equals(t, []int{0, 0}, sourcePrograms(eventid))
+}
+
 func TestRegisterExternalEvent(t *testing.T) {
 	tracingDir, err := createTempTracingDir()
 	if tracingDir != "" {
@@ -868,6 +1035,95 @@ func TestRegisterCounterEventGroup(t *testing.T) {
 	equals(t, 2, len(monitor.groups))
 }
 
+func TestRegisterCounterEventGroupCgroups(t *testing.T) {
+	perfEventDir, err := ioutil.TempDir("", "capsule8_")
+	ok(t, err)
+	defer os.RemoveAll(perfEventDir)
+	for _, cgroup := range []string{"docker/a", "docker/b"} {
+		err = os.MkdirAll(filepath.Join(perfEventDir, cgroup), 0777)
+		ok(t, err)
+	}
+
+	counters := []CounterEventGroupMember{
+		CounterEventGroupMember{EventType: EventTypeHardware},
+		CounterEventGroupMember{EventType: EventTypeHardwareCache},
+	}
+
+	monitor, err := NewEventMonitor(
+		WithEventSourceController(NewStubEventSourceController()),
+		WithProcFileSystem(newTestProcFileSystem()),
+		WithTracingDir("testdata"))
+	ok(t, err)
+	defer monitor.Close()
+
+	// The test procfs has no perf_event cgroupfs
+	_, _, err = monitor.RegisterCounterEventGroup("name", counters, nil,
+		WithEventCgroups([]string{"docker/a"}))
+	assert(t, err != nil, "cgroups require perf_event cgroupfs")
+
+	monitor, err = NewEventMonitor(
+		WithEventSourceController(NewStubEventSourceController()),
+		WithProcFileSystem(newTestProcFileSystem()),
+		WithTracingDir("testdata"),
+		WithPerfEventDir(perfEventDir))
+	ok(t, err)
+	defer monitor.Close()
+
+	_, _, err = monitor.RegisterCounterEventGroup("name", counters, nil,
+		WithEventCgroups([]string{"docker/a", "docker/missing"}))
+	assert(t, err != nil, "cgroup does not exist")
+	equals(t, 1, len(monitor.groups))
+
+	groupid, _, err := monitor.RegisterCounterEventGroup("name", counters,
+		nil, WithEventCgroups([]string{"docker/a", "docker/b", "docker/a"}))
+	ok(t, err)
+	equals(t, 2, len(monitor.events.getMap()))
+
+	// NumCPU is 2, so there is a leader per cgroup per CPU; the monitor's
+	// own pid -1 sources are not used.
+	group := monitor.groups[groupid]
+	equals(t, 4, len(group.leaders))
+	equals(t, 2, len(monitor.groups))
+}
+
+func TestRegisterEventGroupCgroups(t *testing.T) {
+	perfEventDir, err := ioutil.TempDir("", "capsule8_")
+	ok(t, err)
+	defer os.RemoveAll(perfEventDir)
+	err = os.MkdirAll(filepath.Join(perfEventDir, "docker", "a"), 0777)
+	ok(t, err)
+
+	monitor, err := NewEventMonitor(
+		WithEventSourceController(NewStubEventSourceController()),
+		WithProcFileSystem(newTestProcFileSystem()),
+		WithTracingDir("testdata"),
+		WithPerfEventDir(perfEventDir))
+	ok(t, err)
+	defer monitor.Close()
+
+	_, err = monitor.RegisterEventGroup("",
+		WithEventCgroups([]string{"docker/missing"}))
+	assert(t, err != nil, "cgroup does not exist")
+
+	groupid, err := monitor.RegisterEventGroup("",
+		WithEventCgroups([]string{"docker/a"}))
+	ok(t, err)
+
+	// Events registered to the group are only opened on its cgroup
+	group := monitor.groups[groupid]
+	equals(t, 2, len(group.leaders))
+	for _, pgl := range group.leaders {
+		leader := pgl.source.(*StubEventSourceLeader)
+		assert(t, leader.pid > 0, "leader pid should be a cgroup fd")
+	}
+
+	eventid, err := monitor.RegisterTracepoint("valid/valid2", nil,
+		WithEventGroup(groupid))
+	ok(t, err)
+	event, _ := monitor.events.lookup(eventid)
+	equals(t, 2, len(event.sources))
+}
+
 func TestMonitorRunStop(t *testing.T) {
 	monitor, err := NewEventMonitor(
 		WithEventSourceController(NewStubEventSourceController()),
@@ -1102,3 +1358,106 @@ func TestSampleDispatch(t *testing.T) {
 	expectedTimes := []uint64{100, 200, 300}
 	equals(t, expectedTimes, gotTimes)
 }
+
+func TestReadEventSourcesPerCPU(t *testing.T) {
+	monitor, err := NewEventMonitor(
+		WithEventSourceController(NewStubEventSourceController()),
+		WithProcFileSystem(newTestProcFileSystem()),
+		WithTracingDir("testdata"),
+		WithPids([]int{1, 2}))
+	ok(t, err)
+	defer monitor.Close()
+
+	monitor.startReaders()
+	defer monitor.stopReaders()
+	equals(t, 2, len(monitor.readers))
+
+	var leaders []*perfGroupLeader
+	for _, group := range monitor.groups {
+		leaders = append(leaders, group.leaders...)
+	}
+	equals(t, 4, len(leaders))
+
+	enqueue := func(pgl *perfGroupLeader, times ...uint64) {
+		for _, t := range times {
+			sample := Sample{SampleID: SampleID{Time: t}}
+			pgl.source.(*StubEventSourceLeader).EnqueueSample(sample, nil)
+		}
+	}
+	dequeue := func() []uint64 {
+		monitor.lock.Lock()
+		samples := monitor.dequeueSamples()
+		monitor.lock.Unlock()
+
+		var times []uint64
+		m := newSampleMerger(samples)
+		for {
+			esm, done := m.next()
+			if done {
+				break
+			}
+			times = append(times, esm.RawSample.Time)
+		}
+		return times
+	}
+
+	// Samples newer than the oldest last sample read are held back
+	enqueue(leaders[0], 100, 400)
+	enqueue(leaders[1], 200)
+	enqueue(leaders[2], 150, 300)
+	enqueue(leaders[3], 250, 500)
+	monitor.readEventSources()
+	equals(t, true, monitor.hasPendingSamples)
+	equals(t, []uint64{100, 150, 200}, dequeue())
+
+	enqueue(leaders[1], 600)
+	monitor.readEventSources()
+	equals(t, []uint64{250, 300, 400, 500, 600}, dequeue())
+
+	// Closing leaders are cleaned up by their reader
+	leaders[3].state = perfGroupLeaderStateClosing
+	monitor.readEventSources()
+	equals(t, perfGroupLeaderStateClosed, leaders[3].state)
+	equals(t, []uint64(nil), dequeue())
+}
+
+func TestLostRecordDispatch(t *testing.T) {
+	monitor, err := NewEventMonitor(
+		WithEventSourceController(NewStubEventSourceController()),
+		WithProcFileSystem(newTestProcFileSystem()),
+		WithTracingDir("testdata"))
+	ok(t, err)
+	defer monitor.Close()
+
+	var gotSamples []EventMonitorSample
+	go monitor.Run(func(samples []EventMonitorSample) {
+		gotSamples = append(gotSamples, samples...)
+	})
+
+	eventid, err := monitor.RegisterTracepoint("valid/valid2",
+		func(sample *SampleRecord, data TraceEventSampleData) (interface{}, error) {
+			t.Error("Lost record passed to decoder")
+			return sample, nil
+		})
+	ok(t, err)
+	event, ok := monitor.events.lookup(eventid)
+	equals(t, true, ok)
+
+	lost := &LostRecord{ID: event.sources[0].SourceID(), Lost: 37}
+	sample := Sample{
+		SampleID: SampleID{Time: 200, StreamID: event.sources[0].SourceID()},
+		Record:   lost,
+	}
+	sample.Type = PERF_RECORD_LOST
+	event.group.leaders[0].source.(*StubEventSourceLeader).EnqueueSample(sample, nil)
+
+	monitor.eventSourceController.(*StubEventSourceController).Wakeup()
+	time.Sleep(200 * time.Millisecond)
+
+	monitor.Stop(true)
+
+	equals(t, 1, len(gotSamples))
+	equals(t, eventid, gotSamples[0].EventID)
+	equals(t, lost, gotSamples[0].RawSample.Record)
+	equals(t, nil, gotSamples[0].DecodedSample)
+}
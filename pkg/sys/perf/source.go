@@ -32,6 +32,11 @@ type EventSource interface {
 	// for the filter clears the filter.
 	SetFilter(filter string) error
 
+	// SetBPFProgram attaches a loaded BPF program to an event source. Only
+	// tracepoint, kprobe, and uprobe event sources support BPF programs.
+	// Samples are only recorded when the program returns non-zero.
+	SetBPFProgram(fd int) error
+
 	// SourceID returns a unique identifier for the EventSource.
 	SourceID() uint64
 }
@@ -59,8 +64,10 @@ type EventSourceController interface {
 	Close()
 
 	// NewEventSourceLeader creates a new event source as a group leader.
-	// Group leaders may or may not have event sources as children.
-	NewEventSourceLeader(attr EventAttr, pid, cpu int, flags uintptr) (EventSourceLeader, error)
+	// Group leaders may or may not have event sources as children. The
+	// size of the leader's ring buffer is given in pages; if it is 0, the
+	// controller's default is used.
+	NewEventSourceLeader(attr EventAttr, pid, cpu int, flags uintptr, ringBufferNumPages int) (EventSourceLeader, error)
 
 	// Wait pauses execution until events become available for processing
 	// or the specified time arrives. The time is specified using the
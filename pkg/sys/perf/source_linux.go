@@ -102,6 +102,13 @@ func (s *defaultEventSource) SetFilter(filter string) error {
 	return nil
 }
 
+func (s *defaultEventSource) SetBPFProgram(fd int) error {
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(s.fd), PERF_EVENT_IOC_SET_BPF, uintptr(fd)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
 func (s *defaultEventSource) SourceID() uint64 {
 	return uint64(s.streamID)
 }
@@ -369,6 +376,7 @@ func (c *defaultEventSourceController) NewEventSourceLeader(
 	attr EventAttr,
 	pid, cpu int,
 	flags uintptr,
+	ringBufferNumPages int,
 ) (EventSourceLeader, error) {
 	var err error
 	s := &defaultEventSourceLeader{
@@ -394,7 +402,10 @@ func (c *defaultEventSourceController) NewEventSourceLeader(
 		return nil, err
 	}
 
-	if s.rb, err = newRingBuffer(s.fd, c.ringBufferNumPages); err != nil {
+	if ringBufferNumPages <= 0 {
+		ringBufferNumPages = c.ringBufferNumPages
+	}
+	if s.rb, err = newRingBuffer(s.fd, ringBufferNumPages); err != nil {
 		s.Close()
 		return nil, err
 	}
@@ -36,6 +36,7 @@ type StubEventSource struct {
 	EnableCount    int
 	SetFilterCount int
 	Filter         string
+	BPFProgram     int
 	Enabled        bool
 	Closed         bool
 }
@@ -77,6 +78,12 @@ func (s *StubEventSource) SetFilter(filter string) error {
 	return nil
 }
 
+// SetBPFProgram attaches a loaded BPF program to an event source.
+func (s *StubEventSource) SetBPFProgram(fd int) error {
+	s.BPFProgram = fd
+	return nil
+}
+
 // SourceID returns a unique identifier for the EventSource.
 func (s *StubEventSource) SourceID() uint64 {
 	return s.sourceID
@@ -99,6 +106,9 @@ type StubEventSourceLeader struct {
 	pid, cpu   int
 	controller *StubEventSourceController
 	queue      []stubSample
+
+	// The ring buffer size requested when the leader was created
+	RingBufferNumPages int
 }
 
 func newStubEventSourceLeader(attr EventAttr, pid, cpu int) *StubEventSourceLeader {
@@ -191,9 +201,11 @@ func (c *StubEventSourceController) NewEventSourceLeader(
 	attr EventAttr,
 	pid, cpu int,
 	flags uintptr,
+	ringBufferNumPages int,
 ) (EventSourceLeader, error) {
 	l := newStubEventSourceLeader(attr, pid, cpu)
 	l.controller = c
+	l.RingBufferNumPages = ringBufferNumPages
 	c.lock.Lock()
 	c.activeLeaders[l.sourceID] = l
 	c.lock.Unlock()
@@ -44,6 +44,10 @@ func (s *defaultEventSource) SetFilter(filter string) error {
 	return unix.ENOSYS
 }
 
+func (s *defaultEventSource) SetBPFProgram(fd int) error {
+	return unix.ENOSYS
+}
+
 func (s *defaultEventSource) SourceID() uint64 {
 	return s.sourceID
 }
@@ -82,6 +86,7 @@ func (c *defaultEventSourceController) NewEventSourceLeader(
 	attr EventAttr,
 	pid, cpu int,
 	flags uintptr,
+	ringBufferNumPages int,
 ) (EventSourceLeader, error) {
 	return &defaultEventSourceLeader{
 		defaultEventSource{
@@ -0,0 +1,93 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proc
+
+import "strings"
+
+// Container runtimes name the cgroup of a container after its ID, which is
+// a hex encoded SHA-256 hash. With the cgroupfs cgroup driver the name is
+// the bare ID, e.g.:
+// - /docker/[CONTAINER_ID]
+// - /kubepods/[...]/pod[POD_UID]/[CONTAINER_ID]
+// With the systemd cgroup driver it is a scope unit named for the runtime:
+// - /system.slice/docker-[CONTAINER_ID].scope
+// - /kubepods.slice/[...]/cri-containerd-[CONTAINER_ID].scope
+// - /kubepods.slice/[...]/crio-[CONTAINER_ID].scope
+// - /machine.slice/libpod-[CONTAINER_ID].scope
+// CRI-O with the cgroupfs driver also uses crio-[CONTAINER_ID].
+
+// The length of a container ID
+const containerIDLength = 64
+
+// Prefixes of the cgroup names that the systemd cgroup driver gives the
+// scope units of containers, by runtime
+var containerScopePrefixes = []string{
+	"docker-",
+	"cri-containerd-",
+	"crio-",
+	"libpod-",
+}
+
+// ContainerIDFromCgroupPath returns the ID of the container whose cgroup
+// contains the cgroup with the given path, or the empty string if it does
+// not belong to a container. The path may also be just the name of a
+// cgroup. Cgroups created within a container are attributed to the
+// innermost container.
+func ContainerIDFromCgroupPath(path string) string {
+	for len(path) > 0 {
+		name := path
+		if i := strings.LastIndexByte(path, '/'); i >= 0 {
+			name = path[i+1:]
+			path = path[:i]
+		} else {
+			path = ""
+		}
+		if id := containerIDFromCgroupName(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// containerIDFromCgroupName returns the container ID named by a single cgroup
+// path element, or the empty string if it does not name a container.
+func containerIDFromCgroupName(name string) string {
+	if isContainerID(name) {
+		return name
+	}
+	name = strings.TrimSuffix(name, ".scope")
+	for _, prefix := range containerScopePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			if id := name[len(prefix):]; isContainerID(id) {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+func isContainerID(s string) bool {
+	if len(s) != containerIDLength {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') &&
+			!(c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
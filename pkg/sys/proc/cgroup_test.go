@@ -0,0 +1,64 @@
+// Copyright 2018 Capsule8, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerIDFromCgroupPath(t *testing.T) {
+	const (
+		id    = "29923fe3b8d282573feac35570414a21546ecc64427b976b178dfa57e04500ae"
+		inner = "0bd01cb0b4e2b5b0d6b24f10a8e6a3e7a10377d7f9bd2b4c7e0a4b8d1d8dd5b0"
+	)
+
+	testCases := map[string]string{
+		// Docker with the cgroupfs driver
+		"/docker/" + id: id,
+		// Kubernetes with the cgroupfs driver
+		"/kubepods/burstable/pod5f3c2a4e-4c5b-11e8-9a3c-42010a8e0002/" + id: id,
+		// Docker with the systemd driver
+		"/system.slice/docker-" + id + ".scope": id,
+		// containerd with the systemd driver
+		"/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod5f3c2a4e_4c5b_11e8_9a3c_42010a8e0002.slice/cri-containerd-" + id + ".scope": id,
+		// CRI-O with the systemd and cgroupfs drivers
+		"/kubepods.slice/kubepods-pod5f3c2a4e_4c5b_11e8_9a3c_42010a8e0002.slice/crio-" + id + ".scope": id,
+		"/kubepods/pod5f3c2a4e-4c5b-11e8-9a3c-42010a8e0002/crio-" + id:                                 id,
+		// Podman
+		"/machine.slice/libpod-" + id + ".scope": id,
+		// Cgroups created within containers
+		"/system.slice/docker-" + id + ".scope/init.scope":         id,
+		"/docker/" + id + "/docker/" + inner:                       inner,
+		"/docker/" + id + "/system.slice/systemd-journald.service": id,
+		// Cgroup names
+		id:                        id,
+		"docker-" + id + ".scope": id,
+
+		// Not containers
+		"":  "",
+		"/": "",
+		"/user.slice/user-1000.slice/session-2.scope":                             "",
+		"/system.slice/docker.service":                                            "",
+		"/kubepods.slice/kubepods-pod5f3c2a4e.slice/crio-conmon-" + id + ".scope": "",
+		"/docker/" + id[:63]:                                                      "",
+		"/docker/" + id[:63] + "g":                                                "",
+		"/system.slice/runc-" + id + ".scope":                                     "",
+	}
+	for path, expected := range testCases {
+		assert.Equal(t, expected, ContainerIDFromCgroupPath(path), path)
+	}
+}
@@ -14,6 +14,8 @@
 
 package proc
 
+import "os"
+
 // FileSystem is an interface for obtaining system information from the Linux
 // proc filesystem.
 type FileSystem interface {
@@ -55,6 +57,11 @@ type FileSystem interface {
 	// be used for things like kprobes.
 	KernelTextSymbolNames() (map[string]string, error)
 
+	// KernelTextSymbols returns the kernel symbols in the text segment
+	// sorted by address. Symbols whose addresses are hidden from the
+	// calling process (see kptr_restrict) are not included.
+	KernelTextSymbols() ([]KernelSymbol, error)
+
 	// ProcessContainerID returns the container ID running the specified
 	// process. If the process is not running inside of a container, the
 	// return will be the empty string.
@@ -64,6 +71,10 @@ type FileSystem interface {
 	// specified process.
 	ProcessCommandLine(pid int) ([]string, error)
 
+	// ProcessEnvironment returns the environment variables of the
+	// specified process as "NAME=value" strings.
+	ProcessEnvironment(pid int) ([]string, error)
+
 	// TaskControlGroups returns the cgroup membership of the specified task.
 	TaskControlGroups(tgid, pid int) ([]ControlGroup, error)
 
@@ -71,6 +82,23 @@ type FileSystem interface {
 	// task.
 	TaskCWD(tgid, pid int) (string, error)
 
+	// TaskExecutable returns the path of the executable being run by the
+	// specified task.
+	TaskExecutable(tgid, pid int) (string, error)
+
+	// OpenTaskExecutable opens the executable being run by the specified
+	// task. The file is opened even if it is not reachable by its path
+	// from the calling process's mount namespace.
+	OpenTaskExecutable(tgid, pid int) (*os.File, error)
+
+	// ReadProcessRootFile reads a file relative to the root directory of
+	// the specified process, which is in the process's mount namespace.
+	// For processes in containers, this is a file in the container.
+	ReadProcessRootFile(pid int, path string) ([]byte, error)
+
+	// TaskNamespaces returns the namespaces of the specified task.
+	TaskNamespaces(tgid, pid int) (Namespaces, error)
+
 	// TaskStartTime returns the time at which the specified task started.
 	TaskStartTime(tgid, pid int) (int64, error)
 
@@ -106,6 +134,25 @@ type Mount struct {
 	SuperOptions   map[string]string
 }
 
+// KernelSymbol is a kernel symbol and its address.
+type KernelSymbol struct {
+	Address uint64
+	Name    string
+
+	// Module is the name of the kernel module that contains the symbol.
+	// It is empty for symbols in the kernel image.
+	Module string
+}
+
+// Namespaces holds the inode numbers that identify the namespaces of a task.
+// Tasks in the same namespace have the same inode number for it.
+type Namespaces struct {
+	Net  uint64
+	Mnt  uint64
+	PID  uint64
+	User uint64
+}
+
 // ControlGroup describes the cgroup membership of a process
 type ControlGroup struct {
 	// Unique hierarchy ID
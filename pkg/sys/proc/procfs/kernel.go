@@ -18,7 +18,11 @@ import (
 	"bufio"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/capsule8/capsule8/pkg/sys/proc"
 )
 
 // KernelTextSymbolNames returns a mapping of kernel symbols in the text
@@ -59,3 +63,44 @@ func (fs *FileSystem) KernelTextSymbolNames() (map[string]string, error) {
 
 	return symbols, nil
 }
+
+// KernelTextSymbols returns the kernel symbols in the text segment sorted by
+// address. Symbols whose addresses are hidden from the calling process (see
+// kptr_restrict) are not included.
+func (fs *FileSystem) KernelTextSymbols() ([]proc.KernelSymbol, error) {
+	filename := filepath.Join(fs.MountPoint, "kallsyms")
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var symbols []proc.KernelSymbol
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		if fields[1] != "t" && fields[1] != "T" {
+			continue
+		}
+		address, err := strconv.ParseUint(fields[0], 16, 64)
+		if err != nil || address == 0 {
+			continue
+		}
+		symbol := proc.KernelSymbol{
+			Address: address,
+			Name:    fields[2],
+		}
+		if len(fields) > 3 {
+			symbol.Module = strings.Trim(fields[3], "[]")
+		}
+		symbols = append(symbols, symbol)
+	}
+
+	sort.Slice(symbols, func(i, j int) bool {
+		return symbols[i].Address < symbols[j].Address
+	})
+	return symbols, nil
+}
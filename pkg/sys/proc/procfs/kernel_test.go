@@ -16,6 +16,8 @@ package procfs
 
 import (
 	"testing"
+
+	"github.com/capsule8/capsule8/pkg/sys/proc"
 )
 
 func TestKernelTextSymbolNames(t *testing.T) {
@@ -27,9 +29,35 @@ func TestKernelTextSymbolNames(t *testing.T) {
 		"create_dev":                         "create_dev.constprop.6",
 		"cgroup_attach_task_all":             "cgroup_attach_task_all",
 		"__cgroup_procs_write":               "__cgroup_procs_write",
+		"nf_conntrack_init":                  "nf_conntrack_init",
 	}
 
 	actualSymbols, err := fs.KernelTextSymbolNames()
 	ok(t, err)
 	equals(t, expectedSymbols, actualSymbols)
 }
+
+func TestKernelTextSymbols(t *testing.T) {
+	fs, err := NewFileSystem("testdata/proc")
+	ok(t, err)
+
+	expectedSymbols := []proc.KernelSymbol{
+		proc.KernelSymbol{
+			Address: 0xffffffff810f5e60,
+			Name:    "cgroup_attach_task_all",
+		},
+		proc.KernelSymbol{
+			Address: 0xffffffff81d6a2b0,
+			Name:    "create_dev.constprop.6",
+		},
+		proc.KernelSymbol{
+			Address: 0xffffffffc0340000,
+			Name:    "nf_conntrack_init",
+			Module:  "nf_conntrack",
+		},
+	}
+
+	actualSymbols, err := fs.KernelTextSymbols()
+	ok(t, err)
+	equals(t, expectedSymbols, actualSymbols)
+}
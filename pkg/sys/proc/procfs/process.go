@@ -25,7 +25,6 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -34,17 +33,6 @@ import (
 	"github.com/golang/glog"
 )
 
-//
-// Docker cgroup paths may look like either of:
-// - /docker/[CONTAINER_ID]
-// - /kubepods/[...]/[CONTAINER_ID]
-// - /system.slice/docker-[CONTAINER_ID].scope
-//
-const cgroupContainerPattern = "^(/docker/|/kubepods/.*/|/system.slice/docker-)([[:xdigit:]]{64})(.scope|$)"
-
-// A regular expression to match docker container cgroup names
-var cgroupContainerRE = regexp.MustCompile(cgroupContainerPattern)
-
 // ProcessContainerID returns the container ID running the specified process.
 // If the process is not running inside of a container, the return will be the
 // empty string.
@@ -55,9 +43,8 @@ func (fs *FileSystem) ProcessContainerID(pid int) (string, error) {
 	}
 
 	for _, cg := range cgroups {
-		matches := cgroupContainerRE.FindStringSubmatch(cg.Path)
-		if len(matches) > 2 {
-			return matches[2], nil
+		if id := proc.ContainerIDFromCgroupPath(cg.Path); id != "" {
+			return id, nil
 		}
 	}
 
@@ -89,6 +76,27 @@ func (fs *FileSystem) ProcessCommandLine(pid int) ([]string, error) {
 	return commandLine, nil
 }
 
+// ProcessEnvironment returns the environment variables of the process
+// indicated by the given PID as "NAME=value" strings. This is the environment
+// that the process was exec'd with, since changes that it makes to its own
+// environment are not visible.
+func (fs *FileSystem) ProcessEnvironment(pid int) ([]string, error) {
+	filename := fmt.Sprintf("%d/environ", pid)
+	data, err := fs.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var environment []string
+	for _, s := range bytes.Split(data, []byte{0}) {
+		if len(s) > 0 {
+			environment = append(environment, string(s))
+		}
+	}
+
+	return environment, nil
+}
+
 // TaskControlGroups returns the cgroup membership of the specified task.
 func (fs *FileSystem) TaskControlGroups(tgid, pid int) ([]proc.ControlGroup, error) {
 	filename := fmt.Sprintf("%d/task/%d/cgroup", tgid, pid)
@@ -127,6 +135,52 @@ func (fs *FileSystem) TaskCWD(tgid, pid int) (string, error) {
 		fs.MountPoint, tgid, pid))
 }
 
+// TaskExecutable returns the path of the executable being run by the
+// specified task.
+func (fs *FileSystem) TaskExecutable(tgid, pid int) (string, error) {
+	return os.Readlink(fmt.Sprintf("%s/%d/task/%d/exe",
+		fs.MountPoint, tgid, pid))
+}
+
+// OpenTaskExecutable opens the executable being run by the specified task.
+func (fs *FileSystem) OpenTaskExecutable(tgid, pid int) (*os.File, error) {
+	return os.Open(fmt.Sprintf("%s/%d/task/%d/exe",
+		fs.MountPoint, tgid, pid))
+}
+
+// ReadProcessRootFile reads a file relative to the root directory of the
+// specified process.
+func (fs *FileSystem) ReadProcessRootFile(pid int, path string) ([]byte, error) {
+	return fs.ReadFile(fmt.Sprintf("%d/root/%s", pid,
+		strings.TrimPrefix(path, "/")))
+}
+
+// TaskNamespaces returns the namespaces of the specified task.
+func (fs *FileSystem) TaskNamespaces(tgid, pid int) (proc.Namespaces, error) {
+	var ns proc.Namespaces
+	for _, n := range []struct {
+		name  string
+		inode *uint64
+	}{
+		{"net", &ns.Net},
+		{"mnt", &ns.Mnt},
+		{"pid", &ns.PID},
+		{"user", &ns.User},
+	} {
+		link, err := os.Readlink(fmt.Sprintf("%s/%d/task/%d/ns/%s",
+			fs.MountPoint, tgid, pid, n.name))
+		if err != nil {
+			return proc.Namespaces{}, err
+		}
+		// The link is "<name>:[<inode>]"
+		if _, err = fmt.Sscanf(link, n.name+":[%d]", n.inode); err != nil {
+			return proc.Namespaces{}, fmt.Errorf("%s namespace link %q is invalid: %v",
+				n.name, link, err)
+		}
+	}
+	return ns, nil
+}
+
 // TaskStartTime returns the time at which the specified task started.
 func (fs *FileSystem) TaskStartTime(tgid, pid int) (int64, error) {
 	filename := fmt.Sprintf("%d/task/%d/stat", tgid, pid)
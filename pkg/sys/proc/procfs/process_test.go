@@ -58,6 +58,26 @@ func TestProcessCommandLine(t *testing.T) {
 	assert(t, err != nil, "Expected non-nil error return")
 }
 
+func TestProcessEnvironment(t *testing.T) {
+	fs, err := NewFileSystem("testdata/proc")
+	ok(t, err)
+
+	expectedEnvironment := []string{
+		"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+		"HOSTNAME=e1d0fbd3a7b2",
+		"LD_PRELOAD=/usr/lib/libjemalloc.so.2",
+		"AWS_REGION=us-east-1",
+		"AWS_SECRET_ACCESS_KEY=wJalrXUtnFEMI",
+		"REDIS_PASSWORD=hunter2",
+	}
+	actualEnvironment, err := fs.ProcessEnvironment(111343)
+	ok(t, err)
+	equals(t, expectedEnvironment, actualEnvironment)
+
+	_, err = fs.ProcessEnvironment(322)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
 func TestTaskControlGroups(t *testing.T) {
 	fs, err := NewFileSystem("testdata/proc")
 	ok(t, err)
@@ -144,6 +164,56 @@ func TestTaskCWD(t *testing.T) {
 	assert(t, err != nil, "Expected non-nil error return")
 }
 
+func TestTaskExecutable(t *testing.T) {
+	fs, err := NewFileSystem("testdata/proc")
+	ok(t, err)
+
+	expectedExecutable := "/sbin/init"
+	actualExecutable, err := fs.TaskExecutable(1, 1)
+	equals(t, expectedExecutable, actualExecutable)
+
+	expectedExecutable = "/bin/bash"
+	actualExecutable, err = fs.TaskExecutable(111343, 111343)
+	equals(t, expectedExecutable, actualExecutable)
+
+	_, err = fs.TaskExecutable(322, 223)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
+func TestOpenTaskExecutable(t *testing.T) {
+	fs, err := NewFileSystem("testdata/proc")
+	ok(t, err)
+
+	_, err = fs.OpenTaskExecutable(322, 223)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
+func TestReadProcessRootFile(t *testing.T) {
+	fs, err := NewFileSystem("testdata/proc")
+	ok(t, err)
+
+	_, err = fs.ReadProcessRootFile(322, "/etc/passwd")
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
+func TestTaskNamespaces(t *testing.T) {
+	fs, err := NewFileSystem("testdata/proc")
+	ok(t, err)
+
+	expectedNamespaces := proc.Namespaces{
+		Net:  4026532299,
+		Mnt:  4026532296,
+		PID:  4026532298,
+		User: 4026531837,
+	}
+	actualNamespaces, err := fs.TaskNamespaces(111343, 111343)
+	ok(t, err)
+	equals(t, expectedNamespaces, actualNamespaces)
+
+	_, err = fs.TaskNamespaces(322, 223)
+	assert(t, err != nil, "Expected non-nil error return")
+}
+
 func TestStartTime(t *testing.T) {
 	fs, err := NewFileSystem("testdata/proc")
 	ok(t, err)